@@ -1,8 +1,10 @@
 package accessor
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -15,10 +17,12 @@ type Access interface {
 	HasToken() bool
 	IsAuthenticated() bool
 	IsAuthorized(string) bool
+	IsPipelineAuthorized(string, string) bool
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
 	TeamRoles() map[string][]string
+	PipelineRoles() map[string]map[string][]string
 	Claims() Claims
 	UserInfo() atc.UserInfo
 }
@@ -30,6 +34,15 @@ type Claims struct {
 	PreferredUsername string
 	Email             string
 	Connector         string
+	Groups            []string
+
+	// Expiry is when the token the request was made with expires, if known.
+	Expiry time.Time
+
+	// APIToken is the name of the API token the request was made with, if
+	// any, and Scopes are the scopes it is limited to.
+	APIToken string
+	Scopes   []string
 }
 
 type Verification struct {
@@ -45,6 +58,7 @@ type access struct {
 	systemClaimValues      []string
 	teams                  []db.Team
 	teamRoles              map[string][]string
+	pipelineRoles          map[string]map[string][]string
 	isAdmin                bool
 	displayUserIdGenerator atc.DisplayUserIdGenerator
 }
@@ -71,14 +85,35 @@ func NewAccessor(
 
 func (a *access) computeTeamRoles() {
 	a.teamRoles = map[string][]string{}
+	a.pipelineRoles = map[string]map[string][]string{}
 
 	for _, team := range a.teams {
 		roles := a.rolesForTeam(team.Auth())
+		if team.Admin() && contains(roles, "owner") {
+			a.isAdmin = true
+		}
+
+		for _, role := range a.grantedRoles(team.Name()) {
+			if !contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+
 		if len(roles) > 0 {
 			a.teamRoles[team.Name()] = roles
 		}
-		if team.Admin() && contains(roles, "owner") {
-			a.isAdmin = true
+
+		for pipelineName, auth := range team.PipelineAuth() {
+			roles := a.rolesFor(auth, false)
+			if len(roles) == 0 {
+				continue
+			}
+
+			if a.pipelineRoles[team.Name()] == nil {
+				a.pipelineRoles[team.Name()] = map[string][]string{}
+			}
+
+			a.pipelineRoles[team.Name()][pipelineName] = roles
 		}
 	}
 }
@@ -93,6 +128,10 @@ func contains(arr []string, val string) bool {
 }
 
 func (a *access) rolesForTeam(auth atc.TeamAuth) []string {
+	return a.rolesFor(auth, true)
+}
+
+func (a *access) rolesFor(auth atc.TeamAuth, allowAllUsers bool) []string {
 	roleSet := map[string]bool{}
 
 	groups := a.groups()
@@ -105,7 +144,7 @@ func (a *access) rolesForTeam(auth atc.TeamAuth) []string {
 		groupAuth := auth["groups"]
 
 		// backwards compatibility for allow-all-users
-		if len(userAuth) == 0 && len(groupAuth) == 0 {
+		if allowAllUsers && len(userAuth) == 0 && len(groupAuth) == 0 {
 			roleSet[role] = true
 		}
 
@@ -152,6 +191,12 @@ func (a *access) IsAuthorized(teamName string) bool {
 	return a.isAdmin || a.hasPermission(a.teamRoles[teamName])
 }
 
+// IsPipelineAuthorized is like IsAuthorized, but also honours the roles bound
+// to the user for the pipelines with the given name.
+func (a *access) IsPipelineAuthorized(teamName string, pipelineName string) bool {
+	return a.IsAuthorized(teamName) || a.hasPermission(a.pipelineRoles[teamName][pipelineName])
+}
+
 func (a *access) TeamNames() []string {
 	teamNames := []string{}
 	for _, team := range a.teams {
@@ -240,17 +285,47 @@ func (a *access) connectorID() string {
 }
 
 func (a *access) groups() []string {
-	groups := []string{}
-	if raw, ok := a.claims()["groups"]; ok {
-		if rawGroups, ok := raw.([]interface{}); ok {
-			for _, rawGroup := range rawGroups {
-				if group, ok := rawGroup.(string); ok {
-					groups = append(groups, group)
-				}
+	return a.claimStrings(a.claims()["groups"])
+}
+
+func (a *access) expiry() time.Time {
+	switch exp := a.claims()["exp"].(type) {
+	case float64:
+		return time.Unix(int64(exp), 0)
+	case json.Number:
+		if secs, err := exp.Int64(); err == nil {
+			return time.Unix(secs, 0)
+		}
+	}
+	return time.Time{}
+}
+
+func (a *access) scopes() []string {
+	return a.claimStrings(a.claims()["scopes"])
+}
+
+// grantedRoles returns the roles granted on the team by the token itself,
+// rather than by the team's auth config. Only team API tokens are granted
+// roles this way.
+func (a *access) grantedRoles(teamName string) []string {
+	if raw, ok := a.claims()["team_roles"]; ok {
+		if teamRoles, ok := raw.(map[string]interface{}); ok {
+			return a.claimStrings(teamRoles[teamName])
+		}
+	}
+	return nil
+}
+
+func (a *access) claimStrings(raw interface{}) []string {
+	var strs []string
+	if rawStrs, ok := raw.([]interface{}); ok {
+		for _, rawStr := range rawStrs {
+			if str, ok := rawStr.(string); ok {
+				strs = append(strs, str)
 			}
 		}
 	}
-	return groups
+	return strs
 }
 
 func (a *access) IsAdmin() bool {
@@ -272,6 +347,10 @@ func (a *access) TeamRoles() map[string][]string {
 	return a.teamRoles
 }
 
+func (a *access) PipelineRoles() map[string]map[string][]string {
+	return a.pipelineRoles
+}
+
 func (a *access) Claims() Claims {
 	return Claims{
 		Sub:               a.claim("sub"),
@@ -280,21 +359,26 @@ func (a *access) Claims() Claims {
 		UserName:          a.claim("name"),
 		PreferredUsername: a.claim("preferred_username"),
 		Connector:         a.connectorID(),
+		Groups:            a.groups(),
+		Expiry:            a.expiry(),
+		APIToken:          a.claim("api_token"),
+		Scopes:            a.scopes(),
 	}
 }
 
 func (a *access) UserInfo() atc.UserInfo {
 	claims := a.Claims()
 	return atc.UserInfo{
-		Sub:       claims.Sub,
-		Name:      claims.UserName,
-		UserId:    claims.UserID,
-		UserName:  claims.PreferredUsername,
-		Email:     claims.Email,
-		Connector: claims.Connector,
-		IsAdmin:   a.IsAdmin(),
-		IsSystem:  a.IsSystem(),
-		Teams:     a.TeamRoles(),
+		Sub:           claims.Sub,
+		Name:          claims.UserName,
+		UserId:        claims.UserID,
+		UserName:      claims.PreferredUsername,
+		Email:         claims.Email,
+		Connector:     claims.Connector,
+		IsAdmin:       a.IsAdmin(),
+		IsSystem:      a.IsSystem(),
+		Teams:         a.TeamRoles(),
+		PipelineRoles: a.PipelineRoles(),
 		DisplayUserId: a.displayUserIdGenerator.DisplayUserId(
 			claims.Connector,
 			claims.UserID,
@@ -304,3 +388,16 @@ func (a *access) UserInfo() atc.UserInfo {
 		),
 	}
 }
+
+// BoundPipelines returns the names of the pipelines, by team, which the
+// access has been bound to a role on.
+func BoundPipelines(acc Access) db.BoundPipelines {
+	bound := db.BoundPipelines{}
+	for teamName, pipelineRoles := range acc.PipelineRoles() {
+		for pipelineName := range pipelineRoles {
+			bound[teamName] = append(bound[teamName], pipelineName)
+		}
+	}
+
+	return bound
+}
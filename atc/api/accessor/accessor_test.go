@@ -1,6 +1,8 @@
 package accessor_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/atcfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -326,6 +328,183 @@ var _ = Describe("Accessor", func() {
 		Entry("user is viewer and group is member attempting viewer action", "viewer", "viewer", "viewer", true),
 	)
 
+	Describe("IsPipelineAuthorized", func() {
+		var result bool
+
+		BeforeEach(func() {
+			requiredRole = "member"
+
+			verification.HasToken = true
+			verification.IsTokenValid = true
+			verification.RawClaims = map[string]interface{}{
+				"groups": []interface{}{"some-group"},
+				"federated_claims": map[string]interface{}{
+					"connector_id": "some-connector",
+					"user_id":      "some-user-id",
+				},
+			}
+
+			fakeTeam1.NameReturns("some-team")
+		})
+
+		JustBeforeEach(func() {
+			result = access.IsPipelineAuthorized("some-team", "some-pipeline")
+		})
+
+		Context("when the user has a role on the whole team", func() {
+			BeforeEach(func() {
+				fakeTeam1.AuthReturns(atc.TeamAuth{
+					"member": map[string][]string{
+						"users": {"some-connector:some-user-id"},
+					},
+				})
+			})
+
+			It("returns true", func() {
+				Expect(result).To(BeTrue())
+			})
+		})
+
+		Context("when the user is bound to a sufficient role on the pipeline", func() {
+			BeforeEach(func() {
+				fakeTeam1.AuthReturns(atc.TeamAuth{
+					"owner": map[string][]string{
+						"users": {"some-connector:someone-else"},
+					},
+				})
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					"some-pipeline": atc.TeamAuth{
+						"member": map[string][]string{
+							"groups": {"some-connector:some-group"},
+						},
+					},
+				})
+			})
+
+			It("returns true", func() {
+				Expect(result).To(BeTrue())
+			})
+
+			It("is not authorized for the rest of the team", func() {
+				Expect(access.IsAuthorized("some-team")).To(BeFalse())
+				Expect(access.IsPipelineAuthorized("some-team", "other-pipeline")).To(BeFalse())
+				Expect(access.TeamNames()).To(BeEmpty())
+			})
+
+			It("lists the role in the pipeline roles", func() {
+				Expect(access.PipelineRoles()).To(Equal(map[string]map[string][]string{
+					"some-team": {"some-pipeline": {"member"}},
+				}))
+			})
+		})
+
+		Context("when the user is bound to an insufficient role on the pipeline", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					"some-pipeline": atc.TeamAuth{
+						"viewer": map[string][]string{
+							"users": {"some-connector:some-user-id"},
+						},
+					},
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline binding has no users or groups", func() {
+			BeforeEach(func() {
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					"some-pipeline": atc.TeamAuth{
+						"member": map[string][]string{},
+					},
+				})
+			})
+
+			It("does not allow all users", func() {
+				Expect(result).To(BeFalse())
+			})
+		})
+
+		Context("when the user is bound as owner of a pipeline in an admin team", func() {
+			BeforeEach(func() {
+				fakeTeam1.AdminReturns(true)
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					"some-pipeline": atc.TeamAuth{
+						"owner": map[string][]string{
+							"users": {"some-connector:some-user-id"},
+						},
+					},
+				})
+			})
+
+			It("does not make the user an admin", func() {
+				Expect(result).To(BeTrue())
+				Expect(access.IsAdmin()).To(BeFalse())
+			})
+		})
+
+		Context("when the user has no token", func() {
+			BeforeEach(func() {
+				verification.HasToken = false
+				verification.IsTokenValid = false
+
+				fakeTeam1.PipelineAuthReturns(atc.PipelineAuth{
+					"some-pipeline": atc.TeamAuth{
+						"member": map[string][]string{
+							"users": {"some-connector:some-user-id"},
+						},
+					},
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("roles granted by the token", func() {
+		BeforeEach(func() {
+			requiredRole = "member"
+
+			verification.HasToken = true
+			verification.IsTokenValid = true
+			verification.RawClaims = map[string]interface{}{
+				"sub": "api-token:1",
+				"federated_claims": map[string]interface{}{
+					"connector_id": "api-token",
+					"user_id":      "some-team/some-token",
+				},
+				"team_roles": map[string]interface{}{
+					"some-team": []interface{}{"member"},
+				},
+			}
+
+			fakeTeam1.NameReturns("some-team")
+			fakeTeam1.AdminReturns(true)
+			fakeTeam1.AuthReturns(atc.TeamAuth{
+				"owner": map[string][]string{
+					"users": {"some-connector:some-user"},
+				},
+			})
+		})
+
+		It("is authorized for the team with the granted role", func() {
+			Expect(access.IsAuthorized("some-team")).To(BeTrue())
+			Expect(access.TeamRoles()).To(Equal(map[string][]string{
+				"some-team": {"member"},
+			}))
+		})
+
+		It("is not authorized for other teams", func() {
+			Expect(access.IsAuthorized("some-team-2")).To(BeFalse())
+			Expect(access.IsAdmin()).To(BeFalse())
+		})
+	})
+
 	Describe("TeamNames", func() {
 		var result []string
 
@@ -670,6 +849,45 @@ var _ = Describe("Accessor", func() {
 				}))
 			})
 		})
+
+		Context("when the token is a scoped API token", func() {
+			BeforeEach(func() {
+				verification.HasToken = true
+				verification.IsTokenValid = true
+				verification.RawClaims = map[string]interface{}{
+					"sub":       "some-sub",
+					"name":      "some-name",
+					"groups":    []interface{}{"some-group"},
+					"api_token": "some-token",
+					"scopes":    []interface{}{"read-only", "trigger-job"},
+				}
+			})
+
+			It("returns the token and its scopes", func() {
+				Expect(result).To(Equal(accessor.Claims{
+					Sub:      "some-sub",
+					UserName: "some-name",
+					Groups:   []string{"some-group"},
+					APIToken: "some-token",
+					Scopes:   []string{"read-only", "trigger-job"},
+				}))
+			})
+		})
+
+		Context("when the token has an expiry", func() {
+			BeforeEach(func() {
+				verification.HasToken = true
+				verification.IsTokenValid = true
+				verification.RawClaims = map[string]interface{}{
+					"sub": "some-sub",
+					"exp": float64(1600000000),
+				}
+			})
+
+			It("returns when it expires", func() {
+				Expect(result.Expiry).To(Equal(time.Unix(1600000000, 0)))
+			})
+		})
 	})
 
 	Describe("UserInfo", func() {
@@ -709,6 +927,7 @@ var _ = Describe("Accessor", func() {
 					IsAdmin:       false,
 					IsSystem:      false,
 					Teams:         map[string][]string{},
+					PipelineRoles: map[string]map[string][]string{},
 					Connector:     "some-connector",
 					DisplayUserId: "some-user-id",
 				}))
@@ -60,6 +60,18 @@ type FakeAccess struct {
 	isAuthorizedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsPipelineAuthorizedStub        func(string, string) bool
+	isPipelineAuthorizedMutex       sync.RWMutex
+	isPipelineAuthorizedArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	isPipelineAuthorizedReturns struct {
+		result1 bool
+	}
+	isPipelineAuthorizedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsSystemStub        func() bool
 	isSystemMutex       sync.RWMutex
 	isSystemArgsForCall []struct {
@@ -70,6 +82,16 @@ type FakeAccess struct {
 	isSystemReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PipelineRolesStub        func() map[string]map[string][]string
+	pipelineRolesMutex       sync.RWMutex
+	pipelineRolesArgsForCall []struct {
+	}
+	pipelineRolesReturns struct {
+		result1 map[string]map[string][]string
+	}
+	pipelineRolesReturnsOnCall map[int]struct {
+		result1 map[string]map[string][]string
+	}
 	TeamNamesStub        func() []string
 	teamNamesMutex       sync.RWMutex
 	teamNamesArgsForCall []struct {
@@ -377,6 +399,68 @@ func (fake *FakeAccess) IsAuthorizedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeAccess) IsPipelineAuthorized(arg1 string, arg2 string) bool {
+	fake.isPipelineAuthorizedMutex.Lock()
+	ret, specificReturn := fake.isPipelineAuthorizedReturnsOnCall[len(fake.isPipelineAuthorizedArgsForCall)]
+	fake.isPipelineAuthorizedArgsForCall = append(fake.isPipelineAuthorizedArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.IsPipelineAuthorizedStub
+	fakeReturns := fake.isPipelineAuthorizedReturns
+	fake.recordInvocation("IsPipelineAuthorized", []interface{}{arg1, arg2})
+	fake.isPipelineAuthorizedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccess) IsPipelineAuthorizedCallCount() int {
+	fake.isPipelineAuthorizedMutex.RLock()
+	defer fake.isPipelineAuthorizedMutex.RUnlock()
+	return len(fake.isPipelineAuthorizedArgsForCall)
+}
+
+func (fake *FakeAccess) IsPipelineAuthorizedCalls(stub func(string, string) bool) {
+	fake.isPipelineAuthorizedMutex.Lock()
+	defer fake.isPipelineAuthorizedMutex.Unlock()
+	fake.IsPipelineAuthorizedStub = stub
+}
+
+func (fake *FakeAccess) IsPipelineAuthorizedArgsForCall(i int) (string, string) {
+	fake.isPipelineAuthorizedMutex.RLock()
+	defer fake.isPipelineAuthorizedMutex.RUnlock()
+	argsForCall := fake.isPipelineAuthorizedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAccess) IsPipelineAuthorizedReturns(result1 bool) {
+	fake.isPipelineAuthorizedMutex.Lock()
+	defer fake.isPipelineAuthorizedMutex.Unlock()
+	fake.IsPipelineAuthorizedStub = nil
+	fake.isPipelineAuthorizedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeAccess) IsPipelineAuthorizedReturnsOnCall(i int, result1 bool) {
+	fake.isPipelineAuthorizedMutex.Lock()
+	defer fake.isPipelineAuthorizedMutex.Unlock()
+	fake.IsPipelineAuthorizedStub = nil
+	if fake.isPipelineAuthorizedReturnsOnCall == nil {
+		fake.isPipelineAuthorizedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isPipelineAuthorizedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeAccess) IsSystem() bool {
 	fake.isSystemMutex.Lock()
 	ret, specificReturn := fake.isSystemReturnsOnCall[len(fake.isSystemArgsForCall)]
@@ -430,6 +514,59 @@ func (fake *FakeAccess) IsSystemReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeAccess) PipelineRoles() map[string]map[string][]string {
+	fake.pipelineRolesMutex.Lock()
+	ret, specificReturn := fake.pipelineRolesReturnsOnCall[len(fake.pipelineRolesArgsForCall)]
+	fake.pipelineRolesArgsForCall = append(fake.pipelineRolesArgsForCall, struct {
+	}{})
+	stub := fake.PipelineRolesStub
+	fakeReturns := fake.pipelineRolesReturns
+	fake.recordInvocation("PipelineRoles", []interface{}{})
+	fake.pipelineRolesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccess) PipelineRolesCallCount() int {
+	fake.pipelineRolesMutex.RLock()
+	defer fake.pipelineRolesMutex.RUnlock()
+	return len(fake.pipelineRolesArgsForCall)
+}
+
+func (fake *FakeAccess) PipelineRolesCalls(stub func() map[string]map[string][]string) {
+	fake.pipelineRolesMutex.Lock()
+	defer fake.pipelineRolesMutex.Unlock()
+	fake.PipelineRolesStub = stub
+}
+
+func (fake *FakeAccess) PipelineRolesReturns(result1 map[string]map[string][]string) {
+	fake.pipelineRolesMutex.Lock()
+	defer fake.pipelineRolesMutex.Unlock()
+	fake.PipelineRolesStub = nil
+	fake.pipelineRolesReturns = struct {
+		result1 map[string]map[string][]string
+	}{result1}
+}
+
+func (fake *FakeAccess) PipelineRolesReturnsOnCall(i int, result1 map[string]map[string][]string) {
+	fake.pipelineRolesMutex.Lock()
+	defer fake.pipelineRolesMutex.Unlock()
+	fake.PipelineRolesStub = nil
+	if fake.pipelineRolesReturnsOnCall == nil {
+		fake.pipelineRolesReturnsOnCall = make(map[int]struct {
+			result1 map[string]map[string][]string
+		})
+	}
+	fake.pipelineRolesReturnsOnCall[i] = struct {
+		result1 map[string]map[string][]string
+	}{result1}
+}
+
 func (fake *FakeAccess) TeamNames() []string {
 	fake.teamNamesMutex.Lock()
 	ret, specificReturn := fake.teamNamesReturnsOnCall[len(fake.teamNamesArgsForCall)]
@@ -602,8 +739,12 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.isAuthenticatedMutex.RUnlock()
 	fake.isAuthorizedMutex.RLock()
 	defer fake.isAuthorizedMutex.RUnlock()
+	fake.isPipelineAuthorizedMutex.RLock()
+	defer fake.isPipelineAuthorizedMutex.RUnlock()
 	fake.isSystemMutex.RLock()
 	defer fake.isSystemMutex.RUnlock()
+	fake.pipelineRolesMutex.RLock()
+	defer fake.pipelineRolesMutex.RUnlock()
 	fake.teamNamesMutex.RLock()
 	defer fake.teamNamesMutex.RUnlock()
 	fake.teamRolesMutex.RLock()
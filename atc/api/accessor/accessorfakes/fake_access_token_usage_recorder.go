@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package accessorfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+type FakeAccessTokenUsageRecorder struct {
+	RecordAccessTokenUseStub        func(string) error
+	recordAccessTokenUseMutex       sync.RWMutex
+	recordAccessTokenUseArgsForCall []struct {
+		arg1 string
+	}
+	recordAccessTokenUseReturns struct {
+		result1 error
+	}
+	recordAccessTokenUseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAccessTokenUsageRecorder) RecordAccessTokenUse(arg1 string) error {
+	fake.recordAccessTokenUseMutex.Lock()
+	ret, specificReturn := fake.recordAccessTokenUseReturnsOnCall[len(fake.recordAccessTokenUseArgsForCall)]
+	fake.recordAccessTokenUseArgsForCall = append(fake.recordAccessTokenUseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RecordAccessTokenUseStub
+	fakeReturns := fake.recordAccessTokenUseReturns
+	fake.recordInvocation("RecordAccessTokenUse", []interface{}{arg1})
+	fake.recordAccessTokenUseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccessTokenUsageRecorder) RecordAccessTokenUseCallCount() int {
+	fake.recordAccessTokenUseMutex.RLock()
+	defer fake.recordAccessTokenUseMutex.RUnlock()
+	return len(fake.recordAccessTokenUseArgsForCall)
+}
+
+func (fake *FakeAccessTokenUsageRecorder) RecordAccessTokenUseCalls(stub func(string) error) {
+	fake.recordAccessTokenUseMutex.Lock()
+	defer fake.recordAccessTokenUseMutex.Unlock()
+	fake.RecordAccessTokenUseStub = stub
+}
+
+func (fake *FakeAccessTokenUsageRecorder) RecordAccessTokenUseArgsForCall(i int) string {
+	fake.recordAccessTokenUseMutex.RLock()
+	defer fake.recordAccessTokenUseMutex.RUnlock()
+	argsForCall := fake.recordAccessTokenUseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAccessTokenUsageRecorder) RecordAccessTokenUseReturns(result1 error) {
+	fake.recordAccessTokenUseMutex.Lock()
+	defer fake.recordAccessTokenUseMutex.Unlock()
+	fake.RecordAccessTokenUseStub = nil
+	fake.recordAccessTokenUseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAccessTokenUsageRecorder) RecordAccessTokenUseReturnsOnCall(i int, result1 error) {
+	fake.recordAccessTokenUseMutex.Lock()
+	defer fake.recordAccessTokenUseMutex.Unlock()
+	fake.RecordAccessTokenUseStub = nil
+	if fake.recordAccessTokenUseReturnsOnCall == nil {
+		fake.recordAccessTokenUseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordAccessTokenUseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAccessTokenUsageRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordAccessTokenUseMutex.RLock()
+	defer fake.recordAccessTokenUseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAccessTokenUsageRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ accessor.AccessTokenUsageRecorder = new(FakeAccessTokenUsageRecorder)
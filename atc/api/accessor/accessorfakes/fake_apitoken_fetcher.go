@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package accessorfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAPITokenFetcher struct {
+	GetAPITokenStub        func(string) (db.APIToken, bool, error)
+	getAPITokenMutex       sync.RWMutex
+	getAPITokenArgsForCall []struct {
+		arg1 string
+	}
+	getAPITokenReturns struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	getAPITokenReturnsOnCall map[int]struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenFetcher) GetAPIToken(arg1 string) (db.APIToken, bool, error) {
+	fake.getAPITokenMutex.Lock()
+	ret, specificReturn := fake.getAPITokenReturnsOnCall[len(fake.getAPITokenArgsForCall)]
+	fake.getAPITokenArgsForCall = append(fake.getAPITokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetAPITokenStub
+	fakeReturns := fake.getAPITokenReturns
+	fake.recordInvocation("GetAPIToken", []interface{}{arg1})
+	fake.getAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAPITokenFetcher) GetAPITokenCallCount() int {
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	return len(fake.getAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFetcher) GetAPITokenCalls(stub func(string) (db.APIToken, bool, error)) {
+	fake.getAPITokenMutex.Lock()
+	defer fake.getAPITokenMutex.Unlock()
+	fake.GetAPITokenStub = stub
+}
+
+func (fake *FakeAPITokenFetcher) GetAPITokenArgsForCall(i int) string {
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	argsForCall := fake.getAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPITokenFetcher) GetAPITokenReturns(result1 db.APIToken, result2 bool, result3 error) {
+	fake.getAPITokenMutex.Lock()
+	defer fake.getAPITokenMutex.Unlock()
+	fake.GetAPITokenStub = nil
+	fake.getAPITokenReturns = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFetcher) GetAPITokenReturnsOnCall(i int, result1 db.APIToken, result2 bool, result3 error) {
+	fake.getAPITokenMutex.Lock()
+	defer fake.getAPITokenMutex.Unlock()
+	fake.GetAPITokenStub = nil
+	if fake.getAPITokenReturnsOnCall == nil {
+		fake.getAPITokenReturnsOnCall = make(map[int]struct {
+			result1 db.APIToken
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getAPITokenReturnsOnCall[i] = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAPITokenFetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ accessor.APITokenFetcher = new(FakeAPITokenFetcher)
@@ -3,64 +3,138 @@ package accessor
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/golang/groupcache/lru"
 )
 
+// usageRecordInterval is how often the use of a cached access token is
+// recorded, so that listing sessions shows roughly when each was last used
+// without writing to the DB on every request.
+const usageRecordInterval = time.Minute
+
+// listenRetryInterval is how long to wait before retrying to listen for
+// revocations, doubling after each failure up to maxListenRetryInterval.
+const (
+	listenRetryInterval    = time.Second
+	maxListenRetryInterval = time.Minute
+)
+
+//counterfeiter:generate . AccessTokenUsageRecorder
+type AccessTokenUsageRecorder interface {
+	RecordAccessTokenUse(rawToken string) error
+}
+
 type claimsCacheEntry struct {
-	claims db.Claims
-	size   int
+	claims       db.Claims
+	size         int
+	lastRecorded time.Time
 }
 
 type claimsCacher struct {
+	logger             lager.Logger
 	accessTokenFetcher AccessTokenFetcher
+	usageRecorder      AccessTokenUsageRecorder
+	notifications      Notifications
 	maxCacheSizeBytes  int
 
 	cache          *lru.Cache
 	cacheSizeBytes int
+	listening      bool
 	mu             sync.Mutex // lru.Cache is not safe for concurrent access
 }
 
+// NewClaimsCacher returns an AccessTokenFetcher which caches claims until it
+// is notified that a token was revoked. Claims are not cached while it is
+// unable to listen for revocations, as they could not be dropped in time.
+
 func NewClaimsCacher(
+	logger lager.Logger,
 	accessTokenFetcher AccessTokenFetcher,
+	usageRecorder AccessTokenUsageRecorder,
+	notifications Notifications,
 	maxCacheSizeBytes int,
 ) *claimsCacher {
 	c := &claimsCacher{
+		logger:             logger,
 		accessTokenFetcher: accessTokenFetcher,
+		usageRecorder:      usageRecorder,
+		notifications:      notifications,
 		maxCacheSizeBytes:  maxCacheSizeBytes,
 		cache:              lru.New(0),
 	}
 	c.cache.OnEvicted = func(_ lru.Key, value interface{}) {
-		entry, _ := value.(claimsCacheEntry)
+		entry, _ := value.(*claimsCacheEntry)
 		c.cacheSizeBytes -= entry.size
 	}
 
+	notifier, err := notifications.Listen(atc.ClaimsCacheChannel)
+	if err != nil {
+		logger.Error("failed-to-listen-for-claims-cache", err)
+		notifier = nil
+	}
+
+	c.listening = notifier != nil
+
+	go c.waitForNotifications(notifier)
+
 	return c
 }
 
 func (c *claimsCacher) GetAccessToken(rawToken string) (db.AccessToken, bool, error) {
+	token, found, record, err := c.getAccessToken(rawToken)
+	if err != nil || !found {
+		return token, found, err
+	}
+
+	if record {
+		err = c.usageRecorder.RecordAccessTokenUse(rawToken)
+		if err != nil {
+			c.logger.Error("failed-to-record-access-token-use", err)
+		}
+	}
+
+	return token, true, nil
+}
+
+func (c *claimsCacher) getAccessToken(rawToken string) (db.AccessToken, bool, bool, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	claims, found := c.cache.Get(rawToken)
+	now := time.Now()
+
+	if !c.listening {
+		token, found, err := c.accessTokenFetcher.GetAccessToken(rawToken)
+		return token, found, found, err
+	}
+
+	cached, found := c.cache.Get(rawToken)
 	if found {
-		entry, _ := claims.(claimsCacheEntry)
-		return db.AccessToken{Token: rawToken, Claims: entry.claims}, true, nil
+		entry, _ := cached.(*claimsCacheEntry)
+
+		record := now.Sub(entry.lastRecorded) >= usageRecordInterval
+		if record {
+			entry.lastRecorded = now
+		}
+
+		return db.AccessToken{Token: rawToken, Claims: entry.claims}, true, record, nil
 	}
 
 	token, found, err := c.accessTokenFetcher.GetAccessToken(rawToken)
 	if err != nil {
-		return db.AccessToken{}, false, err
+		return db.AccessToken{}, false, false, err
 	}
 	if !found {
-		return db.AccessToken{}, false, nil
+		return db.AccessToken{}, false, false, nil
 	}
 	payload, err := json.Marshal(token.Claims)
 	if err != nil {
-		return db.AccessToken{}, false, err
+		return db.AccessToken{}, false, false, err
 	}
-	entry := claimsCacheEntry{claims: token.Claims, size: len(payload)}
+	entry := &claimsCacheEntry{claims: token.Claims, size: len(payload), lastRecorded: now}
 	c.cache.Add(rawToken, entry)
 	c.cacheSizeBytes += entry.size
 
@@ -68,5 +142,44 @@ func (c *claimsCacher) GetAccessToken(rawToken string) (db.AccessToken, bool, er
 		c.cache.RemoveOldest()
 	}
 
-	return token, true, nil
+	return token, true, true, nil
+}
+
+// waitForNotifications drops every cached token whenever any access token is
+// revoked, on this ATC or another, so that revoked tokens stop working
+// straight away. If the ATC could not listen for revocations it retries until
+// it can, and only then starts caching.
+func (c *claimsCacher) waitForNotifications(notifier chan bool) {
+	retryInterval := listenRetryInterval
+	for notifier == nil {
+		time.Sleep(retryInterval)
+
+		var err error
+		notifier, err = c.notifications.Listen(atc.ClaimsCacheChannel)
+		if err != nil {
+			c.logger.Error("failed-to-listen-for-claims-cache", err)
+			notifier = nil
+
+			retryInterval *= 2
+			if retryInterval > maxListenRetryInterval {
+				retryInterval = maxListenRetryInterval
+			}
+
+			continue
+		}
+
+		c.mu.Lock()
+		c.listening = true
+		c.mu.Unlock()
+	}
+
+	defer c.notifications.Unlisten(atc.ClaimsCacheChannel, notifier)
+
+	for {
+		<-notifier
+
+		c.mu.Lock()
+		c.cache.Clear()
+		c.mu.Unlock()
+	}
 }
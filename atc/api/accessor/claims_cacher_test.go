@@ -2,7 +2,10 @@ package accessor_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/db"
@@ -14,6 +17,9 @@ import (
 var _ = Describe("ClaimsCacher", func() {
 	var (
 		fakeAccessTokenFetcher *accessorfakes.FakeAccessTokenFetcher
+		fakeUsageRecorder      *accessorfakes.FakeAccessTokenUsageRecorder
+		fakeNotifications      *accessorfakes.FakeNotifications
+		notifier               chan bool
 		maxCacheSizeBytes      int
 
 		claimsCacher accessor.AccessTokenFetcher
@@ -21,11 +27,23 @@ var _ = Describe("ClaimsCacher", func() {
 
 	BeforeEach(func() {
 		fakeAccessTokenFetcher = new(accessorfakes.FakeAccessTokenFetcher)
+		fakeUsageRecorder = new(accessorfakes.FakeAccessTokenUsageRecorder)
+
+		notifier = make(chan bool, 1)
+		fakeNotifications = new(accessorfakes.FakeNotifications)
+		fakeNotifications.ListenReturns(notifier, nil)
+
 		maxCacheSizeBytes = 1000
 	})
 
 	JustBeforeEach(func() {
-		claimsCacher = accessor.NewClaimsCacher(fakeAccessTokenFetcher, maxCacheSizeBytes)
+		claimsCacher = accessor.NewClaimsCacher(
+			lagertest.NewTestLogger("test"),
+			fakeAccessTokenFetcher,
+			fakeUsageRecorder,
+			fakeNotifications,
+			maxCacheSizeBytes,
+		)
 	})
 
 	It("fetches claims from the DB", func() {
@@ -86,6 +104,74 @@ var _ = Describe("ClaimsCacher", func() {
 		Expect(fakeAccessTokenFetcher.GetAccessTokenCallCount()).To(Equal(4), "evicted the latest token")
 	})
 
+	It("drops cached claims when notified that a token was revoked", func() {
+		fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, true, nil)
+		claimsCacher.GetAccessToken("token")
+
+		Eventually(fakeNotifications.ListenCallCount).Should(Equal(1))
+		channel := fakeNotifications.ListenArgsForCall(0)
+		Expect(channel).To(Equal(atc.ClaimsCacheChannel))
+
+		notifier <- true
+
+		Eventually(func() int {
+			claimsCacher.GetAccessToken("token")
+			return fakeAccessTokenFetcher.GetAccessTokenCallCount()
+		}).Should(Equal(2))
+	})
+
+	Context("when listening for revocations fails", func() {
+		BeforeEach(func() {
+			fakeNotifications.ListenReturnsOnCall(0, nil, errors.New("disaster"))
+		})
+
+		It("does not cache claims until it is listening", func() {
+			fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, true, nil)
+			claimsCacher.GetAccessToken("token")
+			claimsCacher.GetAccessToken("token")
+			Expect(fakeAccessTokenFetcher.GetAccessTokenCallCount()).To(Equal(2), "cached claims without listening")
+
+			Eventually(fakeNotifications.ListenCallCount, 3*time.Second).Should(Equal(2))
+
+			Eventually(func() int {
+				before := fakeAccessTokenFetcher.GetAccessTokenCallCount()
+				claimsCacher.GetAccessToken("other-token")
+				claimsCacher.GetAccessToken("other-token")
+				return fakeAccessTokenFetcher.GetAccessTokenCallCount() - before
+			}).Should(Equal(0), "did not cache claims once listening")
+		})
+
+		It("still records that the token was used", func() {
+			fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, true, nil)
+			claimsCacher.GetAccessToken("token")
+			Expect(fakeUsageRecorder.RecordAccessTokenUseCallCount()).To(Equal(1))
+		})
+	})
+
+	It("records that the token was used, at most once per interval", func() {
+		fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, true, nil)
+		claimsCacher.GetAccessToken("token")
+		claimsCacher.GetAccessToken("token")
+
+		Expect(fakeUsageRecorder.RecordAccessTokenUseCallCount()).To(Equal(1))
+		Expect(fakeUsageRecorder.RecordAccessTokenUseArgsForCall(0)).To(Equal("token"))
+	})
+
+	It("still returns the token when recording its use fails", func() {
+		fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, true, nil)
+		fakeUsageRecorder.RecordAccessTokenUseReturns(errors.New("error"))
+
+		_, found, err := claimsCacher.GetAccessToken("token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+	})
+
+	It("does not record the use of tokens which aren't found", func() {
+		fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, false, nil)
+		claimsCacher.GetAccessToken("token")
+		Expect(fakeUsageRecorder.RecordAccessTokenUseCallCount()).To(Equal(0))
+	})
+
 	It("errors when the DB fails", func() {
 		fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{}, false, errors.New("error"))
 		_, _, err := claimsCacher.GetAccessToken("token")
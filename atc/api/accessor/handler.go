@@ -1,7 +1,9 @@
 package accessor
 
 import (
+	"bufio"
 	"context"
+	"net"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -60,10 +62,68 @@ func (h *accessorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	claims := acc.Claims()
 
+	teamName := r.URL.Query().Get(":team_name")
+	pipelineName := r.URL.Query().Get(":pipeline_name")
+	if !ScopesAllow(claims.Scopes, h.action, requiredRole, teamName, pipelineName) {
+		acc = outOfScopeAccess{acc}
+	}
+
 	ctx := context.WithValue(r.Context(), accessorContextKey, acc)
 
-	h.auditor.Audit(h.action, claims.UserName, r)
-	h.handler.ServeHTTP(w, r.WithContext(ctx))
+	aw := &auditingResponseWriter{
+		ResponseWriter: w,
+		audit: func(status int) {
+			h.auditor.Audit(h.action, auditor.Actor{
+				UserName:  claims.UserName,
+				Connector: claims.Connector,
+			}, r, status)
+		},
+	}
+
+	h.handler.ServeHTTP(aw, r.WithContext(ctx))
+
+	// handlers which write nothing respond with 200
+	aw.record(http.StatusOK)
+}
+
+// auditingResponseWriter audits the request once the status of the response
+// is known, rather than when the handler returns, so that long-lived
+// responses such as event streams and hijacked connections are audited
+// straight away.
+type auditingResponseWriter struct {
+	http.ResponseWriter
+
+	audit   func(int)
+	audited bool
+}
+
+func (w *auditingResponseWriter) record(status int) {
+	if w.audited {
+		return
+	}
+
+	w.audited = true
+	w.audit(status)
+}
+
+func (w *auditingResponseWriter) WriteHeader(status int) {
+	w.record(status)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditingResponseWriter) Write(b []byte) (int, error) {
+	w.record(http.StatusOK)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditingResponseWriter) Flush() {
+	w.record(http.StatusOK)
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (w *auditingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.record(http.StatusSwitchingProtocols)
+	return w.ResponseWriter.(http.Hijacker).Hijack()
 }
 
 func GetAccessor(r *http.Request) Access {
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -127,10 +128,33 @@ var _ = Describe("Handler", func() {
 
 			It("audits the event", func() {
 				Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
-				action, userName, req := fakeAuditor.AuditArgsForCall(0)
+				action, actor, req, status := fakeAuditor.AuditArgsForCall(0)
 				Expect(action).To(Equal("some-action"))
-				Expect(userName).To(Equal("some-user"))
+				Expect(actor).To(Equal(auditor.Actor{
+					UserName:  "some-user",
+					Connector: "some-connector",
+				}))
 				Expect(req).To(Equal(r))
+				Expect(status).To(Equal(http.StatusOK))
+			})
+
+			Context("when the handler responds with a status", func() {
+				BeforeEach(func() {
+					fakeHandler.ServeHTTPStub = func(w http.ResponseWriter, r *http.Request) {
+						defer GinkgoRecover()
+						Expect(fakeAuditor.AuditCallCount()).To(BeZero())
+						w.WriteHeader(http.StatusForbidden)
+						Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+						w.Write([]byte("nope"))
+					}
+				})
+
+				It("audits the event once, with the status", func() {
+					Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+					_, _, _, status := fakeAuditor.AuditArgsForCall(0)
+					Expect(status).To(Equal(http.StatusForbidden))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusForbidden))
+				})
 			})
 
 			It("invokes the handler", func() {
@@ -140,6 +164,51 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the request is made with a scoped API token", func() {
+			BeforeEach(func() {
+				action = atc.CreateJobBuild
+				customRoles = map[string]string{}
+
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{
+					UserName: "some-user",
+					APIToken: "some-token",
+					Scopes:   []string{"trigger-job:pipeline/some-team/some-pipeline"},
+				})
+			})
+
+			Context("when the scopes cover the request", func() {
+				BeforeEach(func() {
+					r.URL.RawQuery = ":team_name=some-team&:pipeline_name=some-pipeline"
+				})
+
+				It("invokes the handler with the access as is", func() {
+					Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(1))
+					_, r := fakeHandler.ServeHTTPArgsForCall(0)
+					Expect(accessor.GetAccessor(r)).To(Equal(fakeAccess))
+				})
+			})
+
+			Context("when the scopes do not cover the request", func() {
+				BeforeEach(func() {
+					r.URL.RawQuery = ":team_name=some-team&:pipeline_name=other-pipeline"
+				})
+
+				It("invokes the handler with access that has no roles", func() {
+					Expect(fakeHandler.ServeHTTPCallCount()).To(Equal(1))
+					_, r := fakeHandler.ServeHTTPArgsForCall(0)
+
+					acc := accessor.GetAccessor(r)
+					Expect(acc.IsAuthenticated()).To(BeTrue())
+					Expect(acc.IsAuthorized("some-team")).To(BeFalse())
+					Expect(acc.IsPipelineAuthorized("some-team", "other-pipeline")).To(BeFalse())
+					Expect(acc.TeamNames()).To(BeEmpty())
+					Expect(acc.Claims().UserName).To(Equal("some-user"))
+				})
+			})
+		})
+
 		Context("when the request is not authenticated", func() {
 			BeforeEach(func() {
 				fakeAccess.IsAuthenticatedReturns(false)
@@ -148,9 +217,9 @@ var _ = Describe("Handler", func() {
 
 			It("audits the anonymous request", func() {
 				Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
-				action, userName, req := fakeAuditor.AuditArgsForCall(0)
+				action, actor, req, _ := fakeAuditor.AuditArgsForCall(0)
 				Expect(action).To(Equal("some-action"))
-				Expect(userName).To(Equal(""))
+				Expect(actor).To(Equal(auditor.Actor{}))
 				Expect(req).To(Equal(r))
 			})
 
@@ -23,10 +23,12 @@ var DefaultRoles = map[string]string{
 	atc.BuildResources:                 ViewerRole,
 	atc.AbortBuild:                     OperatorRole,
 	atc.GetBuildPreparation:            ViewerRole,
+	atc.ListBuildTestSuites:            ViewerRole,
 	atc.GetJob:                         ViewerRole,
 	atc.CreateJobBuild:                 OperatorRole,
 	atc.RerunJobBuild:                  OperatorRole,
 	atc.SetBuildComment:                OperatorRole,
+	atc.ApproveBuild:                   MemberRole,
 	atc.ListAllJobs:                    ViewerRole,
 	atc.ListJobs:                       ViewerRole,
 	atc.ListJobBuilds:                  ViewerRole,
@@ -100,6 +102,14 @@ var DefaultRoles = map[string]string{
 	atc.RenameTeam:                     OwnerRole,
 	atc.DestroyTeam:                    OwnerRole,
 	atc.ListTeamBuilds:                 ViewerRole,
+	atc.ListNotificationDeliveries:     ViewerRole,
+	atc.SearchBuildLogs:                ViewerRole,
+	atc.GetTeamSealingKey:              ViewerRole,
+	atc.ListSecretAccesses:             OwnerRole,
+	atc.SetPipelineAuth:                OwnerRole,
+	atc.ListTeamAPITokens:              OwnerRole,
+	atc.CreateTeamAPIToken:             OwnerRole,
+	atc.RevokeTeamAPIToken:             OwnerRole,
 	atc.CreateArtifact:                 MemberRole,
 	atc.GetArtifact:                    MemberRole,
 	atc.ListBuildArtifacts:             ViewerRole,
@@ -0,0 +1,138 @@
+package accessor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ReadOnlyScope allows any action which only requires the viewer role.
+const ReadOnlyScope = "read-only"
+
+const scopePipelinePrefix = "pipeline/"
+
+// ScopeActions are the named scopes an API token can be limited to, besides
+// ReadOnlyScope and the names of individual actions.
+var ScopeActions = map[string][]string{
+	"trigger-job":    {atc.CreateJobBuild, atc.RerunJobBuild},
+	"check-resource": {atc.CheckResource, atc.CheckResourceType, atc.CheckPrototype},
+	"set-pipeline":   {atc.SaveConfig},
+	"pause-pipeline": {atc.PausePipeline, atc.UnpausePipeline},
+}
+
+// ValidateScope checks that a scope names ReadOnlyScope, one of the
+// ScopeActions or an action, optionally limited to a pipeline of a team, e.g.
+// `trigger-job:pipeline/main/deploy`.
+func ValidateScope(scope string) error {
+	name, teamName, pipelineName, limited := splitScope(scope)
+	if limited && (teamName == "" || pipelineName == "") {
+		return fmt.Errorf("invalid scope '%s': expected '<scope>:pipeline/<team>/<name>'", scope)
+	}
+
+	if name == ReadOnlyScope || ScopeActions[name] != nil {
+		return nil
+	}
+
+	for _, route := range atc.Routes {
+		if route.Name == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid scope '%s': unknown scope or action '%s'", scope, name)
+}
+
+// ScopesAllow reports whether a token limited to the given scopes may perform
+// an action requiring the given role on the given team's pipeline, if any. A
+// token with no scopes may perform any action.
+func ScopesAllow(scopes []string, action string, requiredRole string, teamName string, pipelineName string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+
+	for _, scope := range scopes {
+		name, scopeTeamName, scopePipelineName, limited := splitScope(scope)
+		if limited && (scopeTeamName != teamName || scopePipelineName != pipelineName) {
+			continue
+		}
+
+		if name == action {
+			return true
+		}
+
+		if name == ReadOnlyScope && requiredRole == ViewerRole {
+			return true
+		}
+
+		for _, scopeAction := range ScopeActions[name] {
+			if scopeAction == action {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func splitScope(scope string) (string, string, string, bool) {
+	segs := strings.SplitN(scope, ":", 2)
+	if len(segs) == 1 {
+		return scope, "", "", false
+	}
+
+	if !strings.HasPrefix(segs[1], scopePipelinePrefix) {
+		return segs[0], "", "", true
+	}
+
+	target := strings.SplitN(strings.TrimPrefix(segs[1], scopePipelinePrefix), "/", 2)
+	if len(target) == 1 {
+		return segs[0], "", "", true
+	}
+
+	return segs[0], target[0], target[1], true
+}
+
+// outOfScopeAccess is used for requests made with an API token whose scopes
+// do not cover the requested action. The token is still authenticated, so
+// public resources remain visible, but it has no roles.
+type outOfScopeAccess struct {
+	Access
+}
+
+func (a outOfScopeAccess) IsAuthorized(string) bool {
+	return false
+}
+
+func (a outOfScopeAccess) IsPipelineAuthorized(string, string) bool {
+	return false
+}
+
+func (a outOfScopeAccess) IsAdmin() bool {
+	return false
+}
+
+func (a outOfScopeAccess) IsSystem() bool {
+	return false
+}
+
+func (a outOfScopeAccess) TeamNames() []string {
+	return []string{}
+}
+
+func (a outOfScopeAccess) TeamRoles() map[string][]string {
+	return map[string][]string{}
+}
+
+func (a outOfScopeAccess) PipelineRoles() map[string]map[string][]string {
+	return map[string]map[string][]string{}
+}
+
+func (a outOfScopeAccess) UserInfo() atc.UserInfo {
+	info := a.Access.UserInfo()
+	info.IsAdmin = false
+	info.IsSystem = false
+	info.Teams = a.TeamRoles()
+	info.PipelineRoles = a.PipelineRoles()
+	return info
+}
@@ -0,0 +1,50 @@
+package accessor_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scopes", func() {
+	DescribeTable("ValidateScope",
+		func(scope string, valid bool) {
+			err := accessor.ValidateScope(scope)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("read-only", "read-only", true),
+		Entry("named scope", "trigger-job", true),
+		Entry("named scope limited to a pipeline", "trigger-job:pipeline/some-team/some-pipeline", true),
+		Entry("action", atc.PauseJob, true),
+		Entry("action limited to a pipeline", atc.PauseJob+":pipeline/some-team/some-pipeline", true),
+		Entry("unknown scope", "do-anything", false),
+		Entry("unknown qualifier", "trigger-job:team/some-team", false),
+		Entry("empty pipeline", "trigger-job:pipeline/", false),
+		Entry("pipeline without a team", "trigger-job:pipeline/some-pipeline", false),
+		Entry("team without a pipeline", "trigger-job:pipeline/some-team/", false),
+	)
+
+	DescribeTable("ScopesAllow",
+		func(scopes []string, action string, role string, teamName string, pipelineName string, allowed bool) {
+			Expect(accessor.ScopesAllow(scopes, action, role, teamName, pipelineName)).To(Equal(allowed))
+		},
+		Entry("no scopes allow anything", nil, atc.DestroyTeam, "", "", "", true),
+		Entry("read-only allows viewer actions", []string{"read-only"}, atc.GetJob, accessor.ViewerRole, "some-team", "some-pipeline", true),
+		Entry("read-only does not allow other actions", []string{"read-only"}, atc.CreateJobBuild, accessor.OperatorRole, "some-team", "some-pipeline", false),
+		Entry("read-only does not allow admin actions", []string{"read-only"}, atc.ListAuditEvents, "", "", "", false),
+		Entry("named scope allows its actions", []string{"trigger-job"}, atc.RerunJobBuild, accessor.OperatorRole, "some-team", "some-pipeline", true),
+		Entry("named scope does not allow other actions", []string{"trigger-job"}, atc.PauseJob, accessor.OperatorRole, "some-team", "some-pipeline", false),
+		Entry("action scope allows the action", []string{atc.PauseJob}, atc.PauseJob, accessor.OperatorRole, "some-team", "some-pipeline", true),
+		Entry("limited scope allows the pipeline", []string{"trigger-job:pipeline/some-team/some-pipeline"}, atc.CreateJobBuild, accessor.OperatorRole, "some-team", "some-pipeline", true),
+		Entry("limited scope does not allow the pipeline of the same name in other teams", []string{"trigger-job:pipeline/some-team/some-pipeline"}, atc.CreateJobBuild, accessor.OperatorRole, "other-team", "some-pipeline", false),
+		Entry("limited scope does not allow other pipelines", []string{"trigger-job:pipeline/some-team/some-pipeline"}, atc.CreateJobBuild, accessor.OperatorRole, "some-team", "other-pipeline", false),
+		Entry("limited scope does not allow requests without a pipeline", []string{"read-only:pipeline/some-team/some-pipeline"}, atc.ListTeams, accessor.ViewerRole, "", "", false),
+		Entry("any matching scope allows", []string{"read-only", "trigger-job:pipeline/some-team/some-pipeline"}, atc.CreateJobBuild, accessor.OperatorRole, "some-team", "some-pipeline", true),
+	)
+})
@@ -2,11 +2,13 @@ package accessor
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
@@ -23,9 +25,19 @@ type AccessTokenFetcher interface {
 	GetAccessToken(rawToken string) (db.AccessToken, bool, error)
 }
 
-func NewVerifier(accessTokenFetcher AccessTokenFetcher, audience []string) *verifier {
+//counterfeiter:generate . APITokenFetcher
+type APITokenFetcher interface {
+	GetAPIToken(rawToken string) (db.APIToken, bool, error)
+}
+
+// APITokenConnector is the connector reported for requests made with team
+// API tokens, which do not belong to a user.
+const APITokenConnector = "api-token"
+
+func NewVerifier(accessTokenFetcher AccessTokenFetcher, apiTokenFetcher APITokenFetcher, audience []string) *verifier {
 	return &verifier{
 		accessTokenFetcher: accessTokenFetcher,
+		apiTokenFetcher:    apiTokenFetcher,
 		audience:           audience,
 	}
 }
@@ -33,6 +45,7 @@ func NewVerifier(accessTokenFetcher AccessTokenFetcher, audience []string) *veri
 type verifier struct {
 	sync.Mutex
 	accessTokenFetcher AccessTokenFetcher
+	apiTokenFetcher    APITokenFetcher
 	audience           []string
 }
 
@@ -52,6 +65,10 @@ func (v *verifier) Verify(r *http.Request) (map[string]interface{}, error) {
 }
 
 func (v *verifier) verify(rawToken string) (map[string]interface{}, error) {
+	if strings.HasPrefix(rawToken, atc.APITokenPrefix) {
+		return v.verifyAPIToken(rawToken)
+	}
+
 	token, found, err := v.accessTokenFetcher.GetAccessToken(rawToken)
 	if err != nil {
 		return nil, err
@@ -74,3 +91,56 @@ func (v *verifier) verify(rawToken string) (map[string]interface{}, error) {
 
 	return nil, ErrVerificationInvalidAudience
 }
+
+// API tokens are looked up on every request rather than cached, so that
+// revoking one takes effect straight away.
+func (v *verifier) verifyAPIToken(rawToken string) (map[string]interface{}, error) {
+	token, found, err := v.apiTokenFetcher.GetAPIToken(rawToken)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrVerificationInvalidToken
+	}
+
+	if token.IsExpired() {
+		return nil, ErrVerificationTokenExpired
+	}
+
+	return apiTokenClaims(token), nil
+}
+
+func apiTokenClaims(token db.APIToken) map[string]interface{} {
+	claims := map[string]interface{}{}
+
+	if token.IsTeamToken() {
+		name := token.TeamName + "/" + token.Name
+
+		claims["sub"] = fmt.Sprintf("%s:%d", APITokenConnector, token.ID)
+		claims["name"] = name
+		claims["federated_claims"] = map[string]interface{}{
+			"connector_id": APITokenConnector,
+			"user_id":      name,
+		}
+		claims["team_roles"] = map[string]interface{}{
+			token.TeamName: []interface{}{token.Role},
+		}
+	} else {
+		for key, value := range token.Claims {
+			claims[key] = value
+		}
+	}
+
+	claims["api_token"] = token.Name
+
+	if len(token.Scopes) > 0 {
+		var scopes []interface{}
+		for _, scope := range token.Scopes {
+			scopes = append(scopes, scope)
+		}
+
+		claims["scopes"] = scopes
+	}
+
+	return claims
+}
@@ -18,12 +18,14 @@ var _ = Describe("Verifier", func() {
 	var (
 		accessTokenFetcher *accessorfakes.FakeAccessTokenFetcher
 		accessToken        db.AccessToken
+		apiTokenFetcher    *accessorfakes.FakeAPITokenFetcher
 
 		req *http.Request
 
 		verifier accessor.TokenVerifier
 
-		err error
+		claims map[string]interface{}
+		err    error
 	)
 
 	BeforeEach(func() {
@@ -32,16 +34,18 @@ var _ = Describe("Verifier", func() {
 			return accessToken, true, nil
 		})
 
+		apiTokenFetcher = new(accessorfakes.FakeAPITokenFetcher)
+
 		req, _ = http.NewRequest("GET", "localhost:8080", nil)
 		req.Header.Set("Authorization", "bearer 1234567890")
 
-		verifier = accessor.NewVerifier(accessTokenFetcher, []string{"some-aud"})
+		verifier = accessor.NewVerifier(accessTokenFetcher, apiTokenFetcher, []string{"some-aud"})
 	})
 
 	Describe("Verify", func() {
 
 		JustBeforeEach(func() {
-			_, err = verifier.Verify(req)
+			claims, err = verifier.Verify(req)
 		})
 
 		Context("when request has no token", func() {
@@ -140,5 +144,102 @@ var _ = Describe("Verifier", func() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
+
+		Context("when request has an API token", func() {
+			BeforeEach(func() {
+				req.Header.Set("Authorization", "bearer cct_1234567890")
+			})
+
+			It("looks it up as an API token", func() {
+				Expect(accessTokenFetcher.GetAccessTokenCallCount()).To(BeZero())
+				Expect(apiTokenFetcher.GetAPITokenCallCount()).To(Equal(1))
+				Expect(apiTokenFetcher.GetAPITokenArgsForCall(0)).To(Equal("cct_1234567890"))
+			})
+
+			Context("when the token is not found", func() {
+				BeforeEach(func() {
+					apiTokenFetcher.GetAPITokenReturns(db.APIToken{}, false, nil)
+				})
+
+				It("fails verification", func() {
+					Expect(err).To(Equal(accessor.ErrVerificationInvalidToken))
+				})
+			})
+
+			Context("when looking up the token errors", func() {
+				BeforeEach(func() {
+					apiTokenFetcher.GetAPITokenReturns(db.APIToken{}, false, errors.New("db error"))
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError("db error"))
+				})
+			})
+
+			Context("when the token has expired", func() {
+				BeforeEach(func() {
+					apiTokenFetcher.GetAPITokenReturns(db.APIToken{
+						Name:      "some-token",
+						ExpiresAt: time.Now().Add(-time.Hour),
+					}, true, nil)
+				})
+
+				It("fails verification", func() {
+					Expect(err).To(Equal(accessor.ErrVerificationTokenExpired))
+				})
+			})
+
+			Context("when it is a personal token", func() {
+				BeforeEach(func() {
+					apiTokenFetcher.GetAPITokenReturns(db.APIToken{
+						Name:   "some-token",
+						Scopes: []string{"read-only"},
+						Claims: map[string]interface{}{
+							"sub":  "some-sub",
+							"name": "some-user",
+						},
+						ExpiresAt: time.Now().Add(time.Hour),
+					}, true, nil)
+				})
+
+				It("returns the claims of the user who created it", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(claims).To(Equal(map[string]interface{}{
+						"sub":       "some-sub",
+						"name":      "some-user",
+						"api_token": "some-token",
+						"scopes":    []interface{}{"read-only"},
+					}))
+				})
+			})
+
+			Context("when it is a team token", func() {
+				BeforeEach(func() {
+					apiTokenFetcher.GetAPITokenReturns(db.APIToken{
+						ID:       42,
+						Name:     "some-token",
+						TeamID:   1,
+						TeamName: "some-team",
+						Role:     "member",
+					}, true, nil)
+				})
+
+				It("returns claims granting the token its role on the team", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(claims).To(Equal(map[string]interface{}{
+						"sub":  "api-token:42",
+						"name": "some-team/some-token",
+						"federated_claims": map[string]interface{}{
+							"connector_id": "api-token",
+							"user_id":      "some-team/some-token",
+						},
+						"team_roles": map[string]interface{}{
+							"some-team": []interface{}{"member"},
+						},
+						"api_token": "some-token",
+					}))
+				})
+			})
+		})
 	})
 })
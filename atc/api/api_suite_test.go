@@ -68,6 +68,9 @@ var (
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
 	dbUserFactory           *dbfakes.FakeUserFactory
+	dbAuditEventFactory     *dbfakes.FakeAuditEventFactory
+	dbAPITokenFactory       *dbfakes.FakeAPITokenFactory
+	dbAccessTokenFactory    *dbfakes.FakeAccessTokenFactory
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
@@ -117,6 +120,9 @@ var _ = BeforeEach(func() {
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 	dbUserFactory = new(dbfakes.FakeUserFactory)
+	dbAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+	dbAPITokenFactory = new(dbfakes.FakeAPITokenFactory)
+	dbAccessTokenFactory = new(dbfakes.FakeAccessTokenFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
 
@@ -132,6 +138,9 @@ var _ = BeforeEach(func() {
 	dbWorkerTeamFactory.GetByIDReturns(dbTeam)
 
 	fakeAccess = new(accessorfakes.FakeAccess)
+	fakeAccess.IsPipelineAuthorizedStub = func(teamName string, _ string) bool {
+		return fakeAccess.IsAuthorized(teamName)
+	}
 	fakeAccessor = new(accessorfakes.FakeAccessFactory)
 	fakeAccessor.CreateReturns(fakeAccess, nil)
 
@@ -209,6 +218,9 @@ var _ = BeforeEach(func() {
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		dbUserFactory,
+		dbAuditEventFactory,
+		dbAPITokenFactory,
+		dbAccessTokenFactory,
 
 		constructedEventHandler.Construct,
 
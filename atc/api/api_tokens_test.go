@@ -0,0 +1,490 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	var response *http.Response
+
+	Describe("GET /api/v1/tokens", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub"})
+
+				dbAPITokenFactory.PersonalAPITokensReturns([]db.APIToken{
+					{
+						ID:        1,
+						Name:      "some-token",
+						Scopes:    []string{"read-only"},
+						CreatedBy: "some-user",
+						CreatedAt: time.Unix(100, 0),
+						ExpiresAt: time.Unix(200, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the user's tokens, without the tokens themselves", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbAPITokenFactory.PersonalAPITokensArgsForCall(0)).To(Equal("some-sub"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[{
+					"id": 1,
+					"name": "some-token",
+					"scopes": ["read-only"],
+					"created_by": "some-user",
+					"created_at": 100,
+					"expires_at": 200
+				}]`))
+			})
+
+			Context("when getting the tokens fails", func() {
+				BeforeEach(func() {
+					dbAPITokenFactory.PersonalAPITokensReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the request is made with an API token", func() {
+				BeforeEach(func() {
+					fakeAccess.ClaimsReturns(accessor.Claims{
+						Sub:      "some-sub",
+						APIToken: "some-token",
+					})
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAPITokenFactory.PersonalAPITokensCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/tokens", func() {
+		var (
+			requestBody   string
+			sessionExpiry time.Time
+			tokenExpiry   time.Time
+		)
+
+		BeforeEach(func() {
+			sessionExpiry = time.Now().Add(24 * time.Hour).Truncate(time.Second)
+			tokenExpiry = time.Now().Add(time.Hour).Truncate(time.Second)
+			requestBody = fmt.Sprintf(`{"name":"some-token","scopes":["trigger-job:pipeline/main/some-pipeline"],"expires_at":%d}`, tokenExpiry.Unix())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/tokens", "application/json", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{
+					Sub:       "some-sub",
+					UserID:    "some-user-id",
+					UserName:  "some-user",
+					Connector: "github",
+					Groups:    []string{"some-org:some-team"},
+					Expiry:    sessionExpiry,
+				})
+
+				dbAPITokenFactory.CreateAPITokenStub = func(_ string, token db.APIToken) (db.APIToken, error) {
+					token.ID = 42
+					token.CreatedAt = time.Unix(100, 0)
+					return token, nil
+				}
+			})
+
+			It("creates a token acting as the user and returns it once", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(Equal(1))
+				rawToken, token := dbAPITokenFactory.CreateAPITokenArgsForCall(0)
+				Expect(rawToken).To(HavePrefix(atc.APITokenPrefix))
+				Expect(token.Name).To(Equal("some-token"))
+				Expect(token.OwnerSub).To(Equal("some-sub"))
+				Expect(token.TeamID).To(BeZero())
+				Expect(token.Scopes).To(Equal([]string{"trigger-job:pipeline/main/some-pipeline"}))
+				Expect(token.ExpiresAt).To(Equal(tokenExpiry))
+				Expect(token.CreatedBy).To(Equal("some-user"))
+				Expect(token.Claims).To(HaveKeyWithValue("sub", "some-sub"))
+				Expect(token.Claims).To(HaveKeyWithValue("groups", []string{"some-org:some-team"}))
+				Expect(token.Claims).To(HaveKeyWithValue("federated_claims", map[string]interface{}{
+					"connector_id": "github",
+					"user_id":      "some-user-id",
+				}))
+
+				var created atc.APIToken
+				err := json.NewDecoder(response.Body).Decode(&created)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created.ID).To(Equal(42))
+				Expect(created.Token).To(Equal(rawToken))
+			})
+
+			Context("when the token has an expiry in the past", func() {
+				BeforeEach(func() {
+					requestBody = `{"name":"some-token","expires_at":100}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the token has no expiry", func() {
+				BeforeEach(func() {
+					requestBody = `{"name":"some-token"}`
+				})
+
+				It("returns 400, as the token would keep the user's groups forever", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the token would outlive the session", func() {
+				BeforeEach(func() {
+					requestBody = fmt.Sprintf(`{"name":"some-token","expires_at":%d}`, sessionExpiry.Add(time.Second).Unix())
+				})
+
+				It("returns 400 with the error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("cannot outlive the session"))
+				})
+			})
+
+			Context("when a scope is invalid", func() {
+				BeforeEach(func() {
+					requestBody = fmt.Sprintf(`{"name":"some-token","scopes":["do-anything"],"expires_at":%d}`, tokenExpiry.Unix())
+				})
+
+				It("returns 400 with the error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("do-anything"))
+				})
+			})
+
+			Context("when a role is given", func() {
+				BeforeEach(func() {
+					requestBody = `{"name":"some-token","role":"owner"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when no name is given", func() {
+				BeforeEach(func() {
+					requestBody = `{}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the name is taken", func() {
+				BeforeEach(func() {
+					dbAPITokenFactory.CreateAPITokenStub = nil
+					dbAPITokenFactory.CreateAPITokenReturns(db.APIToken{}, db.ErrAPITokenNameTaken)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the request is made with an API token", func() {
+				BeforeEach(func() {
+					fakeAccess.ClaimsReturns(accessor.Claims{
+						Sub:      "some-sub",
+						APIToken: "other-token",
+					})
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/tokens/:token_id", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/tokens/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub"})
+				dbAPITokenFactory.RevokePersonalAPITokenReturns(true, nil)
+			})
+
+			It("revokes the user's token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				sub, id := dbAPITokenFactory.RevokePersonalAPITokenArgsForCall(0)
+				Expect(sub).To(Equal("some-sub"))
+				Expect(id).To(Equal(42))
+			})
+
+			Context("when the user has no such token", func() {
+				BeforeEach(func() {
+					dbAPITokenFactory.RevokePersonalAPITokenReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the request is made with an API token", func() {
+				BeforeEach(func() {
+					fakeAccess.ClaimsReturns(accessor.Claims{
+						Sub:      "some-sub",
+						APIToken: "some-token",
+					})
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAPITokenFactory.RevokePersonalAPITokenCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("team tokens", func() {
+		var fakeTeam *dbfakes.FakeTeam
+
+		BeforeEach(func() {
+			fakeTeam = new(dbfakes.FakeTeam)
+			fakeTeam.IDReturns(7)
+			fakeTeam.NameReturns("some-team")
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+		})
+
+		Describe("GET /api/v1/teams/:team_name/tokens", func() {
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Get(server.URL + "/api/v1/teams/some-team/tokens")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+
+					dbAPITokenFactory.TeamAPITokensReturns([]db.APIToken{
+						{
+							ID:        1,
+							Name:      "ci-bot",
+							TeamID:    7,
+							TeamName:  "some-team",
+							Role:      "member",
+							CreatedBy: "some-user",
+							CreatedAt: time.Unix(100, 0),
+						},
+					}, nil)
+				})
+
+				It("returns the team's tokens", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbAPITokenFactory.TeamAPITokensArgsForCall(0)).To(Equal(7))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[{
+						"id": 1,
+						"name": "ci-bot",
+						"team_name": "some-team",
+						"role": "member",
+						"created_by": "some-user",
+						"created_at": 100
+					}]`))
+				})
+			})
+		})
+
+		Describe("POST /api/v1/teams/:team_name/tokens", func() {
+			var requestBody string
+
+			BeforeEach(func() {
+				requestBody = `{"name":"ci-bot","role":"pipeline-operator","scopes":["read-only"]}`
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				response, err = client.Post(server.URL+"/api/v1/teams/some-team/tokens", "application/json", strings.NewReader(requestBody))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+					fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub", UserName: "some-user"})
+
+					dbAPITokenFactory.CreateAPITokenStub = func(_ string, token db.APIToken) (db.APIToken, error) {
+						token.ID = 42
+						token.TeamName = "some-team"
+						return token, nil
+					}
+				})
+
+				It("creates a token with a role on the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					_, token := dbAPITokenFactory.CreateAPITokenArgsForCall(0)
+					Expect(token.Name).To(Equal("ci-bot"))
+					Expect(token.TeamID).To(Equal(7))
+					Expect(token.Role).To(Equal("pipeline-operator"))
+					Expect(token.Scopes).To(Equal([]string{"read-only"}))
+					Expect(token.Claims).To(BeNil())
+				})
+
+				Context("when the role is owner", func() {
+					BeforeEach(func() {
+						requestBody = `{"name":"ci-bot","role":"owner"}`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbAPITokenFactory.CreateAPITokenCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Describe("DELETE /api/v1/teams/:team_name/tokens/:token_id", func() {
+			JustBeforeEach(func() {
+				req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/tokens/42", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err = client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+					dbAPITokenFactory.RevokeTeamAPITokenReturns(true, nil)
+				})
+
+				It("revokes the team's token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					teamID, id := dbAPITokenFactory.RevokeTeamAPITokenArgsForCall(0)
+					Expect(teamID).To(Equal(7))
+					Expect(id).To(Equal(42))
+				})
+
+				Context("when the request is made with an API token", func() {
+					BeforeEach(func() {
+						fakeAccess.ClaimsReturns(accessor.Claims{
+							Sub:      "some-sub",
+							APIToken: "some-token",
+						})
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(dbAPITokenFactory.RevokeTeamAPITokenCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+	})
+})
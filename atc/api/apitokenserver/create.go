@@ -0,0 +1,200 @@
+package apitokenserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// teamTokenRoles are the roles a team token may be granted. Tokens cannot
+// be owners, so that they can't manage the team or make other tokens.
+var teamTokenRoles = []string{
+	accessor.MemberRole,
+	accessor.OperatorRole,
+	accessor.ViewerRole,
+}
+
+// CreateAPIToken creates a personal token which acts as the requesting user.
+// The token carries the user's groups as they were when it was created, so
+// it must expire no later than the session it was created with; otherwise a
+// user removed from a group would keep the access it granted.
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-api-token")
+
+	acc := accessor.GetAccessor(r)
+	claims := acc.Claims()
+
+	s.create(logger, w, r, db.APIToken{
+		OwnerSub:  claims.Sub,
+		Claims:    userClaims(claims),
+		CreatedBy: claims.UserName,
+	}, claims.Expiry)
+}
+
+// CreateTeamAPIToken creates a token which is granted a role on the team,
+// rather than acting as any user.
+func (s *Server) CreateTeamAPIToken(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("create-team-api-token", lager.Data{"team": team.Name()})
+
+		acc := accessor.GetAccessor(r)
+		claims := acc.Claims()
+
+		s.create(logger, w, r, db.APIToken{
+			OwnerSub:  claims.Sub,
+			TeamID:    team.ID(),
+			CreatedBy: claims.UserName,
+		}, time.Time{})
+	})
+}
+
+// create creates the token. If maxExpiry is set, the token must expire no
+// later than it.
+func (s *Server) create(logger lager.Logger, w http.ResponseWriter, r *http.Request, token db.APIToken, maxExpiry time.Time) {
+	if rejectAPIToken(logger, w, r, "create") {
+		return
+	}
+
+	var request atc.APIToken
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = validate(request, token.TeamID != 0, maxExpiry)
+	if err != nil {
+		logger.Info("invalid-api-token", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	token.Name = request.Name
+	token.Role = request.Role
+	token.Scopes = request.Scopes
+	if request.ExpiresAt != 0 {
+		token.ExpiresAt = time.Unix(request.ExpiresAt, 0)
+	}
+
+	rawToken, err := generateToken()
+	if err != nil {
+		logger.Error("failed-to-generate-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	created, err := s.apiTokenFactory.CreateAPIToken(rawToken, token)
+	if err != nil {
+		if err == db.ErrAPITokenNameTaken {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		logger.Error("failed-to-create-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := present.APIToken(created)
+	presented.Token = rawToken
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-api-token", err)
+	}
+}
+
+func validate(request atc.APIToken, teamToken bool, maxExpiry time.Time) error {
+	if request.Name == "" {
+		return errors.New("token name must be given")
+	}
+
+	if teamToken {
+		if !contains(teamTokenRoles, request.Role) {
+			return fmt.Errorf("team tokens must have one of the roles: %v", teamTokenRoles)
+		}
+	} else if request.Role != "" {
+		return errors.New("personal tokens act as their user, so cannot be given a role")
+	}
+
+	for _, scope := range request.Scopes {
+		err := accessor.ValidateScope(scope)
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.ExpiresAt != 0 && time.Unix(request.ExpiresAt, 0).Before(time.Now()) {
+		return errors.New("token expiry must be in the future")
+	}
+
+	if !teamToken {
+		if request.ExpiresAt == 0 {
+			return errors.New("personal tokens must be given an expiry")
+		}
+
+		if !maxExpiry.IsZero() && time.Unix(request.ExpiresAt, 0).After(maxExpiry) {
+			return fmt.Errorf("personal tokens cannot outlive the session they are created with, which expires at %s", maxExpiry.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// userClaims are the claims a personal token is created with; enough to
+// match the user against team auth config.
+func userClaims(claims accessor.Claims) map[string]interface{} {
+	userClaims := map[string]interface{}{
+		"sub":                claims.Sub,
+		"name":               claims.UserName,
+		"preferred_username": claims.PreferredUsername,
+		"email":              claims.Email,
+		"federated_claims": map[string]interface{}{
+			"connector_id": claims.Connector,
+			"user_id":      claims.UserID,
+		},
+	}
+
+	if len(claims.Groups) > 0 {
+		userClaims["groups"] = claims.Groups
+	}
+
+	return userClaims
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return atc.APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
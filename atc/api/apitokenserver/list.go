@@ -0,0 +1,67 @@
+package apitokenserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListAPITokens lists the requesting user's personal tokens.
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-api-tokens")
+
+	if rejectAPIToken(logger, w, r, "list") {
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	tokens, err := s.apiTokenFactory.PersonalAPITokens(acc.Claims().Sub)
+	if err != nil {
+		logger.Error("failed-to-get-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.respond(logger, w, tokens)
+}
+
+func (s *Server) ListTeamAPITokens(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-team-api-tokens", lager.Data{"team": team.Name()})
+
+		if rejectAPIToken(logger, w, r, "list") {
+			return
+		}
+
+		tokens, err := s.apiTokenFactory.TeamAPITokens(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-api-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.respond(logger, w, tokens)
+	})
+}
+
+func (s *Server) respond(logger lager.Logger, w http.ResponseWriter, tokens []db.APIToken) {
+	presented := []atc.APIToken{}
+	for _, token := range tokens {
+		presented = append(presented, present.APIToken(token))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
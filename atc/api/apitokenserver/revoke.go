@@ -0,0 +1,64 @@
+package apitokenserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// RevokeAPIToken revokes one of the requesting user's personal tokens.
+func (s *Server) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-api-token")
+
+	if rejectAPIToken(logger, w, r, "revoke") {
+		return
+	}
+
+	id, err := strconv.Atoi(r.FormValue(":token_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	revoked, err := s.apiTokenFactory.RevokePersonalAPIToken(acc.Claims().Sub, id)
+	s.respondRevoked(logger, w, revoked, err)
+}
+
+func (s *Server) RevokeTeamAPIToken(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("revoke-team-api-token", lager.Data{"team": team.Name()})
+
+		if rejectAPIToken(logger, w, r, "revoke") {
+			return
+		}
+
+		id, err := strconv.Atoi(r.FormValue(":token_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		revoked, err := s.apiTokenFactory.RevokeTeamAPIToken(team.ID(), id)
+		s.respondRevoked(logger, w, revoked, err)
+	})
+}
+
+func (s *Server) respondRevoked(logger lager.Logger, w http.ResponseWriter, revoked bool, err error) {
+	if err != nil {
+		logger.Error("failed-to-revoke-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !revoked {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
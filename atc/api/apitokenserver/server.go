@@ -0,0 +1,39 @@
+package apitokenserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger          lager.Logger
+	apiTokenFactory db.APITokenFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	apiTokenFactory db.APITokenFactory,
+) *Server {
+	return &Server{
+		logger:          logger,
+		apiTokenFactory: apiTokenFactory,
+	}
+}
+
+// rejectAPIToken responds with 403 if the request was made with an API token:
+// tokens are only managed by their owners, so that a leaked token, however
+// narrowly scoped, can neither mint new tokens nor find or revoke others.
+func rejectAPIToken(logger lager.Logger, w http.ResponseWriter, r *http.Request, action string) bool {
+	if accessor.GetAccessor(r).Claims().APIToken == "" {
+		return false
+	}
+
+	logger.Info("api-token-used-to-manage-api-tokens")
+	w.WriteHeader(http.StatusForbidden)
+	fmt.Fprintf(w, "API tokens cannot be used to %s API tokens", action)
+	return true
+}
@@ -0,0 +1,167 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	var (
+		response *http.Response
+		query    string
+	)
+
+	BeforeEach(func() {
+		query = ""
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("GET", server.URL+"/api/v1/audit"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("GET /api/v1/audit", func() {
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				dbAuditEventFactory.AuditEventsReturns([]db.AuditEvent{
+					{
+						ID:         42,
+						Action:     atc.SaveConfig,
+						UserName:   "some-user",
+						Connector:  "github",
+						TeamName:   "some-team",
+						Target:     map[string]string{"pipeline_name": "some-pipeline"},
+						Method:     "PUT",
+						Path:       "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+						RemoteAddr: "1.2.3.4:5678",
+						UserAgent:  "fly/7.4.0",
+						Parameters: map[string][]string{"check_creds": {"true"}},
+						Status:     200,
+						CreatedAt:  time.Unix(100, 0),
+					},
+				}, db.Pagination{}, nil)
+			})
+
+			It("returns the events", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[{
+					"id": 42,
+					"action": "SaveConfig",
+					"user_name": "some-user",
+					"connector": "github",
+					"team_name": "some-team",
+					"target": {"pipeline_name": "some-pipeline"},
+					"method": "PUT",
+					"path": "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+					"remote_addr": "1.2.3.4:5678",
+					"user_agent": "fly/7.4.0",
+					"parameters": {"check_creds": ["true"]},
+					"status": 200,
+					"created_at": 100
+				}]`))
+			})
+
+			It("uses the default page size", func() {
+				Expect(dbAuditEventFactory.AuditEventsCallCount()).To(Equal(1))
+				filter, page := dbAuditEventFactory.AuditEventsArgsForCall(0)
+				Expect(filter).To(Equal(db.AuditEventFilter{}))
+				Expect(page).To(Equal(db.Page{Limit: atc.PaginationAPIDefaultLimit}))
+			})
+
+			Context("when filters and a page are given", func() {
+				BeforeEach(func() {
+					query = "?user=some-user&action=SaveConfig&team=some-team&since=100&until=200&to=42&limit=2"
+
+					dbAuditEventFactory.AuditEventsReturns([]db.AuditEvent{}, db.Pagination{
+						Older: &db.Page{To: db.NewIntPtr(40), Limit: 2},
+						Newer: &db.Page{From: db.NewIntPtr(43), Limit: 2},
+					}, nil)
+				})
+
+				It("filters and pages the events", func() {
+					Expect(dbAuditEventFactory.AuditEventsCallCount()).To(Equal(1))
+					filter, page := dbAuditEventFactory.AuditEventsArgsForCall(0)
+					Expect(filter).To(Equal(db.AuditEventFilter{
+						UserName: "some-user",
+						Action:   "SaveConfig",
+						TeamName: "some-team",
+						Since:    time.Unix(100, 0),
+						Until:    time.Unix(200, 0),
+					}))
+					Expect(page).To(Equal(db.Page{To: db.NewIntPtr(42), Limit: 2}))
+				})
+
+				It("returns Link headers which keep the filters", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						`<https://example.com/api/v1/audit?action=SaveConfig&limit=2&since=100&team=some-team&to=40&until=200&user=some-user>; rel="next"`,
+						`<https://example.com/api/v1/audit?action=SaveConfig&from=43&limit=2&since=100&team=some-team&until=200&user=some-user>; rel="previous"`,
+					}))
+				})
+			})
+
+			Context("when a time is not a unix timestamp", func() {
+				BeforeEach(func() {
+					query = "?since=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("invalid since 'yesterday': must be a unix timestamp"))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					dbAuditEventFactory.AuditEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	filter, err := auditEventFilterFromRequest(r)
+	if err != nil {
+		logger.Info("invalid-filter", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	page := db.Page{}
+
+	page.Limit, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if page.Limit <= 0 {
+		page.Limit = atc.PaginationAPIDefaultLimit
+	}
+
+	if from := r.FormValue(atc.PaginationQueryFrom); from != "" {
+		id, _ := strconv.Atoi(from)
+		page.From = db.NewIntPtr(id)
+	}
+
+	if to := r.FormValue(atc.PaginationQueryTo); to != "" {
+		id, _ := strconv.Atoi(to)
+		page.To = db.NewIntPtr(id)
+	}
+
+	events, pagination, err := s.auditEventFactory.AuditEvents(filter, page)
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Older != nil {
+		s.addLink(w, r, atc.PaginationQueryTo, *pagination.Older.To, page.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Newer != nil {
+		s.addLink(w, r, atc.PaginationQueryFrom, *pagination.Newer.From, page.Limit, atc.LinkRelPrevious)
+	}
+
+	presented := []atc.AuditEvent{}
+	for _, event := range events {
+		presented = append(presented, present.AuditEvent(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// addLink adds a pagination link which keeps the request's filters.
+func (s *Server) addLink(w http.ResponseWriter, r *http.Request, boundary string, id int, limit int, rel string) {
+	query := url.Values{}
+	for _, param := range []string{"user", "action", "team", "since", "until"} {
+		if value := r.FormValue(param); value != "" {
+			query.Set(param, value)
+		}
+	}
+
+	query.Set(boundary, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit?%s>; rel="%s"`,
+		s.externalURL,
+		query.Encode(),
+		rel,
+	))
+}
+
+func auditEventFilterFromRequest(r *http.Request) (db.AuditEventFilter, error) {
+	filter := db.AuditEventFilter{
+		UserName: r.FormValue("user"),
+		Action:   r.FormValue("action"),
+		TeamName: r.FormValue("team"),
+	}
+
+	var err error
+	filter.Since, err = unixTimeParam(r, "since")
+	if err != nil {
+		return db.AuditEventFilter{}, err
+	}
+
+	filter.Until, err = unixTimeParam(r, "until")
+	if err != nil {
+		return db.AuditEventFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func unixTimeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.FormValue(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s '%s': must be a unix timestamp", name, value)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
@@ -30,8 +30,9 @@ func (h checkAuthorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 	}
 
 	teamName := r.URL.Query().Get(":team_name")
+	pipelineName := r.URL.Query().Get(":pipeline_name")
 
-	if !acc.IsAuthorized(teamName) {
+	if !acc.IsPipelineAuthorized(teamName, pipelineName) {
 		h.rejector.Forbidden(w, r)
 		return
 	}
@@ -97,7 +97,7 @@ var _ = Describe("CheckAuthorizationHandler", func() {
 
 			Context("when the bearer token's team matches the request's team", func() {
 				BeforeEach(func() {
-					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.IsPipelineAuthorizedReturns(true)
 				})
 
 				It("returns 200", func() {
@@ -109,11 +109,37 @@ var _ = Describe("CheckAuthorizationHandler", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(string(responseBody)).To(Equal("simple hello"))
 				})
+
+				It("checks the team without a pipeline", func() {
+					teamName, pipelineName := fakeaccess.IsPipelineAuthorizedArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(pipelineName).To(BeEmpty())
+				})
+			})
+
+			Context("when the request is for a pipeline", func() {
+				BeforeEach(func() {
+					urlValues := url.Values{
+						":team_name":     []string{"some-team"},
+						":pipeline_name": []string{"some-pipeline"},
+					}
+					request.URL.RawQuery = urlValues.Encode()
+
+					fakeaccess.IsPipelineAuthorizedReturns(true)
+				})
+
+				It("checks the roles bound for the pipeline", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					teamName, pipelineName := fakeaccess.IsPipelineAuthorizedArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(pipelineName).To(Equal("some-pipeline"))
+				})
 			})
 
 			Context("when the bearer token's team is set to something other than the request's team", func() {
 				BeforeEach(func() {
-					fakeaccess.IsAuthorizedReturns(false)
+					fakeaccess.IsPipelineAuthorizedReturns(false)
 				})
 
 				It("returns 403", func() {
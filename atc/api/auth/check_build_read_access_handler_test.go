@@ -121,7 +121,7 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 		Context("when authenticated and accessing same team's build", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.IsPipelineAuthorizedReturns(true)
 			})
 
 			WithExistingBuild(ItReturnsTheBuild)
@@ -130,7 +130,7 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 		Context("when authenticated but accessing different team's build", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.IsPipelineAuthorizedReturns(false)
 			})
 
 			WithExistingBuild(func() {
@@ -328,7 +328,7 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 		Context("when authenticated and accessing same team's build", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.IsPipelineAuthorizedReturns(true)
 			})
 
 			WithExistingBuild(ItReturnsTheBuild)
@@ -337,7 +337,7 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 		Context("when authenticated but accessing different team's build", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.IsPipelineAuthorizedReturns(false)
 			})
 
 			WithExistingBuild(func() {
@@ -41,6 +41,7 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 		pipeline = new(dbfakes.FakePipeline)
 		build.PipelineReturns(pipeline, true, nil)
 		build.TeamNameReturns("some-team")
+		build.PipelineNameReturns("some-pipeline")
 		build.JobNameReturns("some-job")
 
 		innerHandler := handlerFactory.HandlerFor(delegate, auth.UnauthorizedRejector{})
@@ -73,7 +74,7 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 	Context("when authenticated and accessing same team's build", func() {
 		BeforeEach(func() {
 			fakeaccess.IsAuthenticatedReturns(true)
-			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.IsPipelineAuthorizedReturns(true)
 		})
 
 		Context("when build exists", func() {
@@ -89,6 +90,12 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 				Expect(delegate.IsCalled).To(BeTrue())
 				Expect(delegate.ContextBuild).To(BeIdenticalTo(build))
 			})
+
+			It("checks access to the build's pipeline", func() {
+				teamName, pipelineName := fakeaccess.IsPipelineAuthorizedArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(pipelineName).To(Equal("some-pipeline"))
+			})
 		})
 
 		Context("when build is not found", func() {
@@ -115,7 +122,7 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 	Context("when authenticated but accessing different team's build", func() {
 		BeforeEach(func() {
 			fakeaccess.IsAuthenticatedReturns(true)
-			fakeaccess.IsAuthorizedReturns(false)
+			fakeaccess.IsPipelineAuthorizedReturns(false)
 			buildFactory.BuildReturns(build, true, nil)
 		})
 
@@ -77,7 +77,7 @@ func (h checkPipelineAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 	acc := accessor.GetAccessor(r)
 
-	if acc.IsAuthorized(teamName) || pipeline.Public() {
+	if acc.IsPipelineAuthorized(teamName, pipelineName) || pipeline.Public() {
 		ctx := context.WithValue(r.Context(), PipelineContextKey, pipeline)
 		h.delegateHandler.ServeHTTP(w, r.WithContext(ctx))
 		return
@@ -118,7 +118,13 @@ var _ = Describe("CheckPipelineAccessHandler", func() {
 			Context("and authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
-					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.IsPipelineAuthorizedReturns(true)
+				})
+
+				It("checks the roles bound for the pipeline", func() {
+					teamName, pipelineName := fakeaccess.IsPipelineAuthorizedArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(pipelineName).To(Equal("some-pipeline"))
 				})
 
 				It("calls pipelineScopedHandler with pipelineDB in context", func() {
@@ -133,7 +139,7 @@ var _ = Describe("CheckPipelineAccessHandler", func() {
 
 			Context("and unauthorized", func() {
 				BeforeEach(func() {
-					fakeaccess.IsAuthorizedReturns(false)
+					fakeaccess.IsPipelineAuthorizedReturns(false)
 				})
 
 				Context("and is authenticated", func() {
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+type checkTeamAuthorizationHandler struct {
+	handler  http.Handler
+	rejector Rejector
+}
+
+// CheckTeamAuthorizationHandler is like CheckAuthorizationHandler, but does
+// not honour the roles bound to the user for single pipelines. It guards
+// actions whose effects reach beyond the pipeline, such as setting its
+// config: vars in the config are resolved through the team's credential
+// manager, so a user bound only to the pipeline could otherwise read any of
+// the team's secrets.
+func CheckTeamAuthorizationHandler(
+	handler http.Handler,
+	rejector Rejector,
+) http.Handler {
+	return checkTeamAuthorizationHandler{
+		handler:  handler,
+		rejector: rejector,
+	}
+}
+
+func (h checkTeamAuthorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	acc := accessor.GetAccessor(r)
+
+	if !acc.IsAuthenticated() {
+		h.rejector.Unauthorized(w, r)
+		return
+	}
+
+	if !acc.IsAuthorized(r.URL.Query().Get(":team_name")) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,119 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/api/auth"
+	"github.com/concourse/concourse/atc/api/auth/authfakes"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckTeamAuthorizationHandler", func() {
+	var (
+		fakeAccessor *accessorfakes.FakeAccessFactory
+		fakeaccess   *accessorfakes.FakeAccess
+		fakeRejector *authfakes.FakeRejector
+
+		server   *httptest.Server
+		client   *http.Client
+		response *http.Response
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeAccessor = new(accessorfakes.FakeAccessFactory)
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.UnauthorizedStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusUnauthorized)
+		}
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusForbidden)
+		}
+
+		server = httptest.NewServer(accessor.NewHandler(
+			logger,
+			"some-action",
+			auth.CheckTeamAuthorizationHandler(simpleHandler, fakeRejector),
+			fakeAccessor,
+			new(auditorfakes.FakeAuditor),
+			map[string]string{},
+		))
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess, nil)
+
+		request, err := http.NewRequest("PUT", server.URL+"/teams/some-team/pipelines/some-pipeline/config", bytes.NewBufferString("hello"))
+		Expect(err).NotTo(HaveOccurred())
+
+		request.URL.RawQuery = url.Values{
+			":team_name":     []string{"some-team"},
+			":pipeline_name": []string{"some-pipeline"},
+		}.Encode()
+
+		response, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the user is authorized for the team", func() {
+		BeforeEach(func() {
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(true)
+		})
+
+		It("proxies to the handler", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			responseBody, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseBody)).To(Equal("simple hello"))
+
+			Expect(fakeaccess.IsAuthorizedArgsForCall(0)).To(Equal("some-team"))
+		})
+	})
+
+	Context("when the user is only bound to a role on the pipeline", func() {
+		BeforeEach(func() {
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsAuthorizedReturns(false)
+			fakeaccess.IsPipelineAuthorizedReturns(true)
+		})
+
+		It("returns 403", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("when the request is not authenticated", func() {
+		BeforeEach(func() {
+			fakeaccess.IsAuthenticatedReturns(false)
+		})
+
+		It("returns 401", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
@@ -207,7 +207,7 @@ var _ = Describe("Builds API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					teamName, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					teamName, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Limit: 100,
 					}))
@@ -223,7 +223,7 @@ var _ = Describe("Builds API", func() {
 				It("passes them through", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						From:  db.NewIntPtr(2),
 						To:    db.NewIntPtr(3),
@@ -237,7 +237,7 @@ var _ = Describe("Builds API", func() {
 					})
 
 					It("calls AllBuilds", func() {
-						_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+						_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 						Expect(page.UseDate).To(Equal(true))
 					})
 				})
@@ -354,7 +354,7 @@ var _ = Describe("Builds API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						Limit: 100,
 					}))
@@ -369,7 +369,7 @@ var _ = Describe("Builds API", func() {
 				It("passes them through", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
 
-					_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					_, _, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(page).To(Equal(db.Page{
 						From:  db.NewIntPtr(2),
 						To:    db.NewIntPtr(3),
@@ -438,9 +438,22 @@ var _ = Describe("Builds API", func() {
 
 				It("returns builds for teams from the token", func() {
 					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(1))
-					teamName, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
+					teamName, _, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
 					Expect(teamName).To(ConsistOf("some-team"))
 				})
+
+				Context("when the user is bound to pipelines of other teams", func() {
+					BeforeEach(func() {
+						fakeAccess.PipelineRolesReturns(map[string]map[string][]string{
+							"other-team": {"some-pipeline": {"viewer"}},
+						})
+					})
+
+					It("also returns builds of those pipelines", func() {
+						_, boundPipelines, _ := dbBuildFactory.VisibleBuildsArgsForCall(0)
+						Expect(boundPipelines).To(Equal(db.BoundPipelines{"other-team": {"some-pipeline"}}))
+					})
+				})
 			})
 
 			Context("when next/previous pages are available", func() {
@@ -1113,6 +1126,168 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/approvals/:step_name", func() {
+		var (
+			response *http.Response
+			body     string
+		)
+
+		BeforeEach(func() {
+			body = `{"approved":true,"comment":"ship it"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/deploy", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+						fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+						build.PrivatePlanReturns(atc.Plan{
+							ID: "1",
+							Do: &atc.DoPlan{
+								{ID: "2", Approval: &atc.ApprovalPlan{Name: "deploy"}},
+							},
+						})
+						build.IsRunningReturns(true)
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							body = `{`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the build has no approval step with the name", func() {
+						BeforeEach(func() {
+							build.PrivatePlanReturns(atc.Plan{
+								ID:       "1",
+								Approval: &atc.ApprovalPlan{Name: "some-other-step"},
+							})
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when the build is not running", func() {
+						BeforeEach(func() {
+							build.IsRunningReturns(false)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when saving the approval fails", func() {
+						BeforeEach(func() {
+							build.SaveApprovalReturns(false, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the step has already been decided", func() {
+						BeforeEach(func() {
+							build.SaveApprovalReturns(false, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when saving the approval succeeds", func() {
+						BeforeEach(func() {
+							build.SaveApprovalReturns(true, nil)
+						})
+
+						It("saves the approval on behalf of the user", func() {
+							Expect(build.SaveApprovalCallCount()).To(Equal(1))
+							Expect(build.SaveApprovalArgsForCall(0)).To(Equal(db.BuildApproval{
+								Name:     "deploy",
+								Approved: true,
+								Approver: "some-user",
+								Comment:  "ship it",
+							}))
+						})
+
+						It("returns 200 with the approval", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"name": "deploy",
+								"approved": true,
+								"approver": "some-user",
+								"comment": "ship it"
+							}`))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1326,6 +1501,120 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/tests", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/tests")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				build.TestSuitesReturns([]atc.TestSuite{
+					{
+						ID:       1,
+						BuildID:  42,
+						StepName: "unit",
+						Name:     "some-suite",
+						Duration: 1.5,
+						Cases: []atc.TestCase{
+							{Name: "passes", ClassName: "some.Class", Duration: 0.5, Status: atc.TestPassed},
+							{Name: "fails", Duration: 1, Status: atc.TestFailed, FailureMessage: "nope"},
+						},
+					},
+				}, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					expectedHeaderEntries := map[string]string{
+						"Content-Type": "application/json",
+					}
+					Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				})
+
+				It("returns the test suites", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 1,
+							"build_id": 42,
+							"step_name": "unit",
+							"name": "some-suite",
+							"duration": 1.5,
+							"cases": [
+								{"name": "passes", "class_name": "some.Class", "duration": 0.5, "status": "passed"},
+								{"name": "fails", "duration": 1, "status": "failed", "failure_message": "nope"}
+							]
+						}
+					]`))
+				})
+
+				Context("when getting the test suites fails", func() {
+					BeforeEach(func() {
+						build.TestSuitesReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan", func() {
 		var plan *json.RawMessage
 
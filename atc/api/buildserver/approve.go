@@ -0,0 +1,79 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ApproveBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("approve-build", build.LagerData())
+
+		stepName := r.FormValue(":step_name")
+
+		var reqBody atc.ApproveBuildBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !hasApprovalStep(build.PrivatePlan(), stepName) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		approval := db.BuildApproval{
+			Name:     stepName,
+			Approved: reqBody.Approved,
+			Approver: acc.UserInfo().DisplayUserId,
+			Comment:  reqBody.Comment,
+		}
+
+		saved, err := build.SaveApproval(approval)
+		if err != nil {
+			logger.Error("failed-to-save-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !saved {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.BuildApproval(approval))
+		if err != nil {
+			logger.Error("failed-to-encode-approval", err)
+		}
+	})
+}
+
+func hasApprovalStep(plan atc.Plan, name string) bool {
+	found := false
+
+	plan.Each(func(p *atc.Plan) {
+		if p.Approval != nil && p.Approval.Name == name {
+			found = true
+		}
+	})
+
+	return found
+}
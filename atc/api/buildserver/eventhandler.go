@@ -1,6 +1,7 @@
 package buildserver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,10 +12,38 @@ import (
 	"github.com/vito/go-sse/sse"
 )
 
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
+//counterfeiter:generate . BuildLogArchive
+type BuildLogArchive interface {
+	Events(ctx context.Context, location string, from uint) (db.EventSource, error)
+}
+
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+	return newEventHandler(logger, build, func(_ context.Context, from uint) (db.EventSource, error) {
+		return build.Events(from)
+	})
+}
+
+// NewArchivedEventHandlerFactory returns an EventHandlerFactory which streams
+// the events of builds whose logs have been archived and reaped from the
+// archive, and those of every other build from the database.
+func NewArchivedEventHandlerFactory(archive BuildLogArchive) EventHandlerFactory {
+	return func(logger lager.Logger, build db.Build) http.Handler {
+		if build.LogArchiveLocation() == "" || build.ReapTime().IsZero() {
+			return NewEventHandler(logger, build)
+		}
+
+		return newEventHandler(logger, build, func(ctx context.Context, from uint) (db.EventSource, error) {
+			return archive.Events(ctx, build.LogArchiveLocation(), from)
+		})
+	}
+}
+
+func newEventHandler(logger lager.Logger, build db.Build, buildEvents func(context.Context, uint) (db.EventSource, error)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var eventID uint = 0
 		if r.Header.Get("Last-Event-ID") != "" {
@@ -39,7 +68,7 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			responseFlusher: w.(http.Flusher),
 		}
 
-		events, err := build.Events(eventID)
+		events, err := buildEvents(r.Context(), eventID)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -11,6 +11,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
@@ -288,4 +289,93 @@ var _ = Describe("Handler", func() {
 			})
 		})
 	})
+
+	Describe("NewArchivedEventHandlerFactory", func() {
+		var (
+			fakeArchive     *buildserverfakes.FakeBuildLogArchive
+			fakeEventSource *dbfakes.FakeEventSource
+			archivedServer  *httptest.Server
+		)
+
+		BeforeEach(func() {
+			fakeArchive = new(buildserverfakes.FakeBuildLogArchive)
+
+			fakeEventSource = new(dbfakes.FakeEventSource)
+			fakeEventSource.NextReturnsOnCall(0, fakeEvent(`{"event":1}`, "0"), nil)
+			fakeEventSource.NextReturnsOnCall(1, event.Envelope{}, db.ErrEndOfBuildEventStream)
+		})
+
+		JustBeforeEach(func() {
+			archivedServer = httptest.NewServer(NewArchivedEventHandlerFactory(fakeArchive)(lagertest.NewTestLogger("test"), build))
+		})
+
+		AfterEach(func() {
+			archivedServer.Close()
+		})
+
+		streamEvents := func() {
+			response, err := http.Get(archivedServer.URL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			reader := sse.NewReadCloser(response.Body)
+			ev, err := reader.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Data).To(MatchJSON(`{"data":{"event":1},"event":"fake","version":"42.0","event_id":"0"}`))
+
+			ev, err = reader.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Name).To(Equal("end"))
+
+			Expect(reader.Close()).To(Succeed())
+		}
+
+		Context("when the build's logs have been archived and reaped", func() {
+			BeforeEach(func() {
+				build.LogArchiveLocationReturns("file:///archive/builds/1/events.gzip")
+				build.ReapTimeReturns(time.Now())
+
+				fakeArchive.EventsReturns(fakeEventSource, nil)
+			})
+
+			It("streams the events from the archive", func() {
+				streamEvents()
+
+				Expect(fakeArchive.EventsCallCount()).To(Equal(1))
+				_, location, from := fakeArchive.EventsArgsForCall(0)
+				Expect(location).To(Equal("file:///archive/builds/1/events.gzip"))
+				Expect(from).To(Equal(uint(0)))
+
+				Expect(build.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the build's logs have been archived but not reaped", func() {
+			BeforeEach(func() {
+				build.LogArchiveLocationReturns("file:///archive/builds/1/events.gzip")
+
+				build.EventsReturns(fakeEventSource, nil)
+			})
+
+			It("streams the events from the database", func() {
+				streamEvents()
+
+				Expect(build.EventsCallCount()).To(Equal(1))
+				Expect(fakeArchive.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the build's logs have not been archived", func() {
+			BeforeEach(func() {
+				build.EventsReturns(fakeEventSource, nil)
+			})
+
+			It("streams the events from the database", func() {
+				streamEvents()
+
+				Expect(build.EventsCallCount()).To(Equal(1))
+				Expect(fakeArchive.EventsCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -53,7 +53,7 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 	if acc.IsAdmin() {
 		builds, pagination, err = s.buildFactory.AllBuilds(page)
 	} else {
-		builds, pagination, err = s.buildFactory.VisibleBuilds(acc.TeamNames(), page)
+		builds, pagination, err = s.buildFactory.VisibleBuilds(acc.TeamNames(), accessor.BoundPipelines(acc), page)
 	}
 
 	if err != nil {
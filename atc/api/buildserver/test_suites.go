@@ -0,0 +1,29 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildTestSuites(build db.Build) http.Handler {
+	logger := s.logger.Session("list-build-test-suites", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suites, err := build.TestSuites()
+		if err != nil {
+			logger.Error("failed-to-get-test-suites", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(suites)
+		if err != nil {
+			logger.Error("failed-to-encode-test-suites", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -8,10 +8,12 @@ import (
 	"net/http"
 	"time"
 
+	"filippo.io/age"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/sealed"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
@@ -772,6 +774,49 @@ jobs:
 								ExpectCredsValidationFail()
 							})
 
+							Context("when there is a sealed param", func() {
+								BeforeEach(func() {
+									identity, err := age.GenerateX25519Identity()
+									Expect(err).NotTo(HaveOccurred())
+
+									sealedValue, err := sealed.Seal(identity.Recipient().String(), "some-secret")
+									Expect(err).NotTo(HaveOccurred())
+
+									dbTeam.SealingKeyReturns(identity.String(), true, nil)
+
+									payload = `---
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    FOO: ((sealed:` + sealedValue + `))
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource`
+
+									request.Header.Set("Content-Type", "application/x-yaml")
+									request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+								})
+
+								It("unseals it rather than looking it up in the creds manager", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+									Expect(fakeSecretManager.GetCallCount()).To(BeZero())
+								})
+
+								Context("when the team has no sealing key", func() {
+									BeforeEach(func() {
+										dbTeam.SealingKeyReturns("", false, nil)
+									})
+
+									It("fails validation", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+										Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									})
+								})
+							})
+
 							Context("when there is param in resource source config", func() {
 								BeforeEach(func() {
 									payload = `---
@@ -1305,6 +1350,24 @@ jobs:
 			})
 		})
 
+		Context("when only authorized for the pipeline", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				fakeAccess.IsPipelineAuthorizedStub = func(teamName string, pipelineName string) bool {
+					return teamName == "a-team" && pipelineName == "a-pipeline"
+				}
+			})
+
+			It("returns 403, as vars in the config would be resolved with the team's credentials", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not save the config", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when not authenticated", func() {
 			BeforeEach(func() {
 				fakeAccess.IsAuthenticatedReturns(false)
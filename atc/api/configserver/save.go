@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/sealed"
 	"github.com/concourse/concourse/vars"
 	"github.com/hashicorp/go-multierror"
 	"github.com/tedsuo/rata"
@@ -103,18 +104,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if checkCredentials {
-		variables := creds.NewVariables(s.secretManager, teamName, pipelineName, false)
-
-		errs := validateCredParams(variables, config, session)
-		if errs != nil {
-			s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
-			return
-		}
-	}
-
-	session.Info("saving")
-
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		session.Error("failed-to-find-team", err)
@@ -128,6 +117,33 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if checkCredentials {
+		// dynamic secrets are only read to check that they exist
+		secrets := creds.NewTransientSecrets(s.secretManager)
+
+		var variables vars.Variables = creds.NewVariables(secrets, teamName, pipelineName, false)
+
+		// values sealed for the team are unsealed as they are for builds,
+		// rather than looked up in the credential manager
+		if _, found := config.VarSources.Lookup(sealed.VarSource); !found {
+			variables = sealed.NewVariables(variables, team)
+		}
+
+		errs := validateCredParams(variables, config, session)
+
+		err = secrets.RevokeLeases()
+		if err != nil {
+			session.Error("failed-to-revoke-secret-leases", err)
+		}
+
+		if errs != nil {
+			s.handleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
+			return
+		}
+	}
+
+	session.Info("saving")
+
 	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
@@ -8,7 +8,9 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/apitokenserver"
 	"github.com/concourse/concourse/atc/api/artifactserver"
+	"github.com/concourse/concourse/atc/api/auditserver"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/ccserver"
 	"github.com/concourse/concourse/atc/api/cliserver"
@@ -20,6 +22,7 @@ import (
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
+	"github.com/concourse/concourse/atc/api/sessionserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/api/usersserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
@@ -55,6 +58,9 @@ func NewHandler(
 	dbCheckFactory db.CheckFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbAuditEventFactory db.AuditEventFactory,
+	dbAPITokenFactory db.APITokenFactory,
+	dbAccessTokenFactory db.AccessTokenFactory,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
@@ -103,6 +109,9 @@ func NewHandler(
 	artifactServer := artifactserver.NewServer(logger, workerPool)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
+	auditServer := auditserver.NewServer(logger, externalURL, dbAuditEventFactory)
+	apiTokenServer := apitokenserver.NewServer(logger, dbAPITokenFactory)
+	sessionServer := sessionserver.NewServer(logger, dbAccessTokenFactory)
 
 	handlers := map[string]http.Handler{
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
@@ -120,6 +129,8 @@ func NewHandler(
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
 		atc.SetBuildComment:     buildHandlerFactory.HandlerFor(buildServer.SetBuildComment),
+		atc.ApproveBuild:        buildHandlerFactory.HandlerFor(buildServer.ApproveBuild),
+		atc.ListBuildTestSuites: buildHandlerFactory.HandlerFor(buildServer.ListBuildTestSuites),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -214,12 +225,34 @@ func NewHandler(
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
 
+		atc.ListNotificationDeliveries: teamHandlerFactory.HandlerFor(teamServer.ListNotificationDeliveries),
+		atc.SearchBuildLogs:            teamHandlerFactory.HandlerFor(teamServer.SearchBuildLogs),
+		atc.GetTeamSealingKey:          teamHandlerFactory.HandlerFor(teamServer.GetSealingKey),
+		atc.ListSecretAccesses:         teamHandlerFactory.HandlerFor(teamServer.ListSecretAccesses),
+		atc.SetPipelineAuth:            teamHandlerFactory.HandlerFor(teamServer.SetPipelineAuth),
+		atc.ListTeamAPITokens:          teamHandlerFactory.HandlerFor(apiTokenServer.ListTeamAPITokens),
+		atc.CreateTeamAPIToken:         teamHandlerFactory.HandlerFor(apiTokenServer.CreateTeamAPIToken),
+		atc.RevokeTeamAPIToken:         teamHandlerFactory.HandlerFor(apiTokenServer.RevokeTeamAPIToken),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 
 		atc.GetWall:   http.HandlerFunc(wallServer.GetWall),
 		atc.SetWall:   http.HandlerFunc(wallServer.SetWall),
 		atc.ClearWall: http.HandlerFunc(wallServer.ClearWall),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
+
+		atc.ListAPITokens:  http.HandlerFunc(apiTokenServer.ListAPITokens),
+		atc.CreateAPIToken: http.HandlerFunc(apiTokenServer.CreateAPIToken),
+		atc.RevokeAPIToken: http.HandlerFunc(apiTokenServer.RevokeAPIToken),
+
+		atc.ListUserSessions:   http.HandlerFunc(sessionServer.ListUserSessions),
+		atc.RevokeUserSession:  http.HandlerFunc(sessionServer.RevokeUserSession),
+		atc.RevokeUserSessions: http.HandlerFunc(sessionServer.RevokeUserSessions),
+		atc.ListSessions:       http.HandlerFunc(sessionServer.ListSessions),
+		atc.RevokeSession:      http.HandlerFunc(sessionServer.RevokeSession),
+		atc.RevokeSessions:     http.HandlerFunc(sessionServer.RevokeSessions),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
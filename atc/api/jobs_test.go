@@ -214,7 +214,8 @@ var _ = Describe("Jobs API", func() {
 		Context("when not authenticated", func() {
 			It("populates job factory with no team names", func() {
 				Expect(dbJobFactory.VisibleJobsCallCount()).To(Equal(1))
-				Expect(dbJobFactory.VisibleJobsArgsForCall(0)).To(BeEmpty())
+				teamNames, _ := dbJobFactory.VisibleJobsArgsForCall(0)
+				Expect(teamNames).To(BeEmpty())
 			})
 		})
 
@@ -225,7 +226,21 @@ var _ = Describe("Jobs API", func() {
 
 			It("constructs job factory with provided team names", func() {
 				Expect(dbJobFactory.VisibleJobsCallCount()).To(Equal(1))
-				Expect(dbJobFactory.VisibleJobsArgsForCall(0)).To(ContainElement("some-team"))
+				teamNames, _ := dbJobFactory.VisibleJobsArgsForCall(0)
+				Expect(teamNames).To(ContainElement("some-team"))
+			})
+
+			Context("when the user is bound to pipelines of other teams", func() {
+				BeforeEach(func() {
+					fakeAccess.PipelineRolesReturns(map[string]map[string][]string{
+						"other-team": {"some-pipeline": {"viewer"}},
+					})
+				})
+
+				It("includes the jobs of those pipelines", func() {
+					_, boundPipelines := dbJobFactory.VisibleJobsArgsForCall(0)
+					Expect(boundPipelines).To(Equal(db.BoundPipelines{"other-team": {"some-pipeline"}}))
+				})
 			})
 
 			Context("user has the admin privilege", func() {
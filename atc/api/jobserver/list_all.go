@@ -18,7 +18,7 @@ func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 	if acc.IsAdmin() {
 		jobs, err = s.jobFactory.AllActiveJobs()
 	} else {
-		jobs, err = s.jobFactory.VisibleJobs(acc.TeamNames())
+		jobs, err = s.jobFactory.VisibleJobs(acc.TeamNames(), accessor.BoundPipelines(acc))
 	}
 
 	if err != nil {
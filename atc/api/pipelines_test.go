@@ -203,6 +203,37 @@ var _ = Describe("Pipelines API", func() {
 				))
 			})
 
+			Context("when bound to a role on another team's private pipeline", func() {
+				BeforeEach(func() {
+					fakeAccess.TeamNamesReturns([]string{})
+					dbPipelineFactory.VisiblePipelinesReturns([]db.Pipeline{publicPipeline, anotherPublicPipeline}, nil)
+
+					fakeAccess.PipelineRolesReturns(map[string]map[string][]string{
+						"main": {"private-pipeline": {"member"}},
+					})
+					fakeAccess.IsPipelineAuthorizedStub = func(teamName string, pipelineName string) bool {
+						return teamName == "main" && pipelineName == "private-pipeline"
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("includes the bound pipeline", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("main"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					var pipelines []map[string]interface{}
+					err = json.Unmarshal(body, &pipelines)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pipelines).To(ConsistOf(
+						HaveKeyWithValue("id", BeNumerically("==", publicPipeline.ID())),
+						HaveKeyWithValue("id", BeNumerically("==", anotherPublicPipeline.ID())),
+						HaveKeyWithValue("id", BeNumerically("==", privatePipeline.ID())),
+					))
+				})
+			})
+
 			Context("user has the Admin privilege", func() {
 				BeforeEach(func() {
 					fakeAccess.IsAdminReturns(true)
@@ -337,6 +368,33 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 
+		Context("when bound to a role on one of the team's private pipelines", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				fakeAccess.PipelineRolesReturns(map[string]map[string][]string{
+					"main": {"private-pipeline": {"viewer"}},
+				})
+				fakeAccess.IsPipelineAuthorizedStub = func(teamName string, pipelineName string) bool {
+					return teamName == "main" && pipelineName == "private-pipeline"
+				}
+				fakeTeam.NameReturns("main")
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns the team's public pipelines and the bound pipeline", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				var pipelines []map[string]interface{}
+				json.Unmarshal(body, &pipelines)
+
+				Expect(pipelines).To(ConsistOf(
+					HaveKeyWithValue("id", BeNumerically("==", publicPipeline.ID())),
+					HaveKeyWithValue("id", BeNumerically("==", privatePipeline.ID())),
+				))
+			})
+		})
+
 		Context("when authenticated as another team", func() {
 			BeforeEach(func() {
 				fakeAccess.IsAuthenticatedReturns(false)
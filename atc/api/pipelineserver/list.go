@@ -31,7 +31,7 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 	if acc.IsAuthorized(requestTeamName) {
 		pipelines, err = team.Pipelines()
 	} else {
-		pipelines, err = team.PublicPipelines()
+		pipelines, err = boundOrPublicPipelines(team, acc)
 	}
 
 	if err != nil {
@@ -48,3 +48,25 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// boundOrPublicPipelines returns the team's public pipelines along with the
+// private ones the user has been bound to a role on.
+func boundOrPublicPipelines(team db.Team, acc accessor.Access) ([]db.Pipeline, error) {
+	if len(acc.PipelineRoles()[team.Name()]) == 0 {
+		return team.PublicPipelines()
+	}
+
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	visible := []db.Pipeline{}
+	for _, pipeline := range pipelines {
+		if pipeline.Public() || acc.IsPipelineAuthorized(team.Name(), pipeline.Name()) {
+			visible = append(visible, pipeline)
+		}
+	}
+
+	return visible, nil
+}
@@ -3,6 +3,7 @@ package pipelineserver
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
 
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
@@ -30,6 +31,17 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !acc.IsAdmin() {
+		bound, err := s.boundPrivatePipelines(acc)
+		if err != nil {
+			logger.Error("failed-to-get-bound-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		pipelines = append(pipelines, bound...)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(present.Pipelines(pipelines))
 	if err != nil {
@@ -37,3 +49,42 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// boundPrivatePipelines returns the private pipelines of teams the user is
+// not authorized for, but has been bound to a role on. Public pipelines are
+// already visible to everyone.
+func (s *Server) boundPrivatePipelines(acc accessor.Access) ([]db.Pipeline, error) {
+	var teamNames []string
+	for teamName := range acc.PipelineRoles() {
+		if !acc.IsAuthorized(teamName) {
+			teamNames = append(teamNames, teamName)
+		}
+	}
+
+	sort.Strings(teamNames)
+
+	var bound []db.Pipeline
+	for _, teamName := range teamNames {
+		team, found, err := s.teamFactory.FindTeam(teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pipeline := range pipelines {
+			if !pipeline.Public() && acc.IsPipelineAuthorized(teamName, pipeline.Name()) {
+				bound = append(bound, pipeline)
+			}
+		}
+	}
+
+	return bound, nil
+}
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func APIToken(token db.APIToken) atc.APIToken {
+	presented := atc.APIToken{
+		ID:        token.ID,
+		Name:      token.Name,
+		TeamName:  token.TeamName,
+		Role:      token.Role,
+		Scopes:    token.Scopes,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt.Unix(),
+	}
+
+	if !token.ExpiresAt.IsZero() {
+		presented.ExpiresAt = token.ExpiresAt.Unix()
+	}
+
+	return presented
+}
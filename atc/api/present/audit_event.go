@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:         event.ID,
+		Action:     event.Action,
+		UserName:   event.UserName,
+		Connector:  event.Connector,
+		TeamName:   event.TeamName,
+		Target:     event.Target,
+		Method:     event.Method,
+		Path:       event.Path,
+		RemoteAddr: event.RemoteAddr,
+		UserAgent:  event.UserAgent,
+		Parameters: event.Parameters,
+		Status:     event.Status,
+		CreatedAt:  event.CreatedAt.Unix(),
+	}
+}
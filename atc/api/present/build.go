@@ -38,7 +38,7 @@ func Build(build db.Build, job db.Job, access accessor.Access) atc.Build {
 		showComments = showComments || job.Public()
 	}
 	if access != nil {
-		showComments = showComments || access.IsAuthorized(build.TeamName())
+		showComments = showComments || access.IsPipelineAuthorized(build.TeamName(), build.PipelineName())
 	}
 
 	if showComments {
@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildApproval(approval db.BuildApproval) atc.BuildApproval {
+	atcApproval := atc.BuildApproval{
+		Name:     approval.Name,
+		Approved: approval.Approved,
+		Approver: approval.Approver,
+		Comment:  approval.Comment,
+	}
+
+	if !approval.DecidedAt.IsZero() {
+		atcApproval.DecidedAt = approval.DecidedAt.Unix()
+	}
+
+	return atcApproval
+}
@@ -0,0 +1,22 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildLogMatch(match db.BuildLogMatch) atc.BuildLogMatch {
+	return atc.BuildLogMatch{
+		BuildID:              match.BuildID,
+		BuildName:            match.BuildName,
+		BuildStatus:          atc.BuildStatus(match.BuildStatus),
+		JobName:              match.JobName,
+		PipelineName:         match.PipelineName,
+		PipelineInstanceVars: match.PipelineInstanceVars,
+		TeamName:             match.TeamName,
+		EventID:              match.EventID,
+		OriginID:             match.OriginID,
+		Time:                 match.Time.Unix(),
+		Line:                 match.Line,
+	}
+}
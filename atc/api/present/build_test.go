@@ -46,7 +46,7 @@ var _ = Describe("Build", func() {
 
 			It(fmt.Sprintf("should be set if accessor allows it (%v)", v), func() {
 				var accessor accessorfakes.FakeAccess
-				accessor.IsAuthorizedReturns(v)
+				accessor.IsPipelineAuthorizedReturns(v)
 
 				checkComment(v, nil, &accessor)
 			})
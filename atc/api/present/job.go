@@ -36,10 +36,11 @@ func Job(
 	sanitizedInputs := []atc.JobInput{}
 	for _, input := range inputs {
 		sanitizedInputs = append(sanitizedInputs, atc.JobInput{
-			Name:     input.Name,
-			Resource: input.Resource,
-			Passed:   input.Passed,
-			Trigger:  input.Trigger,
+			Name:      input.Name,
+			Resource:  input.Resource,
+			Passed:    input.Passed,
+			PassedAny: input.PassedAny,
+			Trigger:   input.Trigger,
 		})
 	}
 
@@ -0,0 +1,25 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func NotificationDelivery(delivery db.NotificationDelivery) atc.NotificationDelivery {
+	return atc.NotificationDelivery{
+		ID:                   delivery.ID,
+		BuildID:              delivery.BuildID,
+		BuildName:            delivery.BuildName,
+		JobName:              delivery.JobName,
+		PipelineName:         delivery.PipelineName,
+		PipelineInstanceVars: delivery.PipelineInstanceVars,
+		TeamName:             delivery.TeamName,
+		URL:                  delivery.URL,
+		Event:                delivery.Event,
+		Attempt:              delivery.Attempt,
+		StatusCode:           delivery.StatusCode,
+		Error:                delivery.Error,
+		Succeeded:            delivery.Succeeded,
+		AttemptedAt:          delivery.AttemptedAt.Unix(),
+	}
+}
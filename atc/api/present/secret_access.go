@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func SecretAccess(access db.SecretAccess) atc.SecretAccess {
+	return atc.SecretAccess{
+		ID:                   access.ID,
+		TeamName:             access.TeamName,
+		PipelineName:         access.PipelineName,
+		PipelineInstanceVars: access.PipelineInstanceVars,
+		JobName:              access.JobName,
+		BuildID:              access.BuildID,
+		BuildName:            access.BuildName,
+		StepName:             access.StepName,
+		Var:                  access.Var,
+		VarSource:            access.VarSource,
+		Backend:              access.Backend,
+		SecretPath:           access.SecretPath,
+		AccessedAt:           access.AccessedAt.Unix(),
+	}
+}
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func Session(session db.Session) atc.Session {
+	presented := atc.Session{
+		ID:         session.ID,
+		UserName:   session.UserName,
+		Connector:  session.Connector,
+		ClientID:   session.ClientID,
+		RemoteAddr: session.RemoteAddr,
+		CreatedAt:  session.CreatedAt.Unix(),
+		ExpiresAt:  session.ExpiresAt.Unix(),
+	}
+
+	if !session.LastUsedAt.IsZero() {
+		presented.LastUsedAt = session.LastUsedAt.Unix()
+	}
+
+	return presented
+}
@@ -10,5 +10,9 @@ func Team(team db.Team) atc.Team {
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
+
+		Notifications: team.Notifications(),
+
+		PipelineAuth: team.PipelineAuth(),
 	}
 }
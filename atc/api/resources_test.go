@@ -171,7 +171,8 @@ var _ = Describe("Resources API", func() {
 			Context("when not authenticated", func() {
 				It("populates resource factory with no team names", func() {
 					Expect(dbResourceFactory.VisibleResourcesCallCount()).To(Equal(1))
-					Expect(dbResourceFactory.VisibleResourcesArgsForCall(0)).To(BeEmpty())
+					teamNames, _ := dbResourceFactory.VisibleResourcesArgsForCall(0)
+					Expect(teamNames).To(BeEmpty())
 				})
 			})
 
@@ -182,7 +183,21 @@ var _ = Describe("Resources API", func() {
 
 				It("constructs job factory with provided team names", func() {
 					Expect(dbResourceFactory.VisibleResourcesCallCount()).To(Equal(1))
-					Expect(dbResourceFactory.VisibleResourcesArgsForCall(0)).To(ContainElement("some-team"))
+					teamNames, _ := dbResourceFactory.VisibleResourcesArgsForCall(0)
+					Expect(teamNames).To(ContainElement("some-team"))
+				})
+
+				Context("when the user is bound to pipelines of other teams", func() {
+					BeforeEach(func() {
+						fakeAccess.PipelineRolesReturns(map[string]map[string][]string{
+							"other-team": {"some-pipeline": {"viewer"}},
+						})
+					})
+
+					It("includes the resources of those pipelines", func() {
+						_, boundPipelines := dbResourceFactory.VisibleResourcesArgsForCall(0)
+						Expect(boundPipelines).To(Equal(db.BoundPipelines{"other-team": {"some-pipeline"}}))
+					})
 				})
 
 				Context("when user has admin privilege", func() {
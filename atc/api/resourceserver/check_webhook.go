@@ -42,7 +42,15 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		variables, err := dbPipeline.Variables(logger, s.secretManager, s.varSourcePool)
+		secrets := creds.NewTransientSecrets(s.secretManager)
+		defer func() {
+			err := secrets.RevokeLeases()
+			if err != nil {
+				logger.Error("failed-to-revoke-secret-leases", err)
+			}
+		}()
+
+		variables, err := dbPipeline.Variables(logger, secrets, s.varSourcePool)
 		if err != nil {
 			logger.Error("failed-to-create-var-sources", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -22,7 +22,7 @@ func (s *Server) ListAllResources(w http.ResponseWriter, r *http.Request) {
 	if acc.IsAdmin() {
 		dbResources, err = s.resourceFactory.AllResources()
 	} else {
-		dbResources, err = s.resourceFactory.VisibleResources(acc.TeamNames())
+		dbResources, err = s.resourceFactory.VisibleResources(acc.TeamNames(), accessor.BoundPipelines(acc))
 	}
 	if err != nil {
 		logger.Error("failed-to-get-all-visible-resources", err)
@@ -0,0 +1,377 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sessions API", func() {
+	var response *http.Response
+
+	doRequest := func(method string, path string) {
+		request, err := http.NewRequest(method, server.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("GET /api/v1/user/sessions", func() {
+		JustBeforeEach(func() {
+			doRequest("GET", "/api/v1/user/sessions")
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub"})
+
+				dbAccessTokenFactory.SessionsReturns([]db.Session{
+					{
+						ID:         1,
+						Sub:        "some-sub",
+						UserName:   "some-user",
+						Connector:  "github",
+						ClientID:   "fly",
+						RemoteAddr: "10.0.0.1:12345",
+						CreatedAt:  time.Unix(100, 0),
+						LastUsedAt: time.Unix(150, 0),
+						ExpiresAt:  time.Unix(200, 0),
+					},
+					{
+						ID:        2,
+						Sub:       "some-sub",
+						UserName:  "some-user",
+						Connector: "github",
+						CreatedAt: time.Unix(100, 0),
+						ExpiresAt: time.Unix(200, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the user's sessions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbAccessTokenFactory.SessionsArgsForCall(0)).To(Equal(db.SessionFilter{Sub: "some-sub"}))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 1,
+						"user_name": "some-user",
+						"connector": "github",
+						"client_id": "fly",
+						"remote_addr": "10.0.0.1:12345",
+						"created_at": 100,
+						"last_used_at": 150,
+						"expires_at": 200
+					},
+					{
+						"id": 2,
+						"user_name": "some-user",
+						"connector": "github",
+						"created_at": 100,
+						"expires_at": 200
+					}
+				]`))
+			})
+
+			Context("when getting the sessions fails", func() {
+				BeforeEach(func() {
+					dbAccessTokenFactory.SessionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the request is made with an API token", func() {
+				BeforeEach(func() {
+					fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub", APIToken: "some-token"})
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAccessTokenFactory.SessionsCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/user/sessions/:session_id", func() {
+		var sessionID string
+
+		BeforeEach(func() {
+			sessionID = "1"
+		})
+
+		JustBeforeEach(func() {
+			doRequest("DELETE", "/api/v1/user/sessions/"+sessionID)
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub"})
+				dbAccessTokenFactory.RevokeSessionReturns(true, nil)
+			})
+
+			It("revokes the session, as long as it is the user's", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				id, sub := dbAccessTokenFactory.RevokeSessionArgsForCall(0)
+				Expect(id).To(Equal(1))
+				Expect(sub).To(Equal("some-sub"))
+			})
+
+			Context("when the session does not exist", func() {
+				BeforeEach(func() {
+					dbAccessTokenFactory.RevokeSessionReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when revoking fails", func() {
+				BeforeEach(func() {
+					dbAccessTokenFactory.RevokeSessionReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the id is invalid", func() {
+				BeforeEach(func() {
+					sessionID = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/user/sessions", func() {
+		JustBeforeEach(func() {
+			doRequest("DELETE", "/api/v1/user/sessions")
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub"})
+				dbAccessTokenFactory.RevokeSessionsReturns(2, nil)
+			})
+
+			It("revokes all of the user's sessions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(dbAccessTokenFactory.RevokeSessionsArgsForCall(0)).To(Equal(db.SessionFilter{Sub: "some-sub"}))
+			})
+
+			Context("when the request is made with an API token", func() {
+				BeforeEach(func() {
+					fakeAccess.ClaimsReturns(accessor.Claims{Sub: "some-sub", APIToken: "some-token"})
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAccessTokenFactory.RevokeSessionsCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/sessions", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			doRequest("GET", "/api/v1/sessions"+query)
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				dbAccessTokenFactory.SessionsReturns([]db.Session{}, nil)
+			})
+
+			It("lists every user's sessions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbAccessTokenFactory.SessionsArgsForCall(0)).To(Equal(db.SessionFilter{}))
+			})
+
+			Context("when a user is given", func() {
+				BeforeEach(func() {
+					query = "?user=some-user"
+				})
+
+				It("lists their sessions", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbAccessTokenFactory.SessionsArgsForCall(0)).To(Equal(db.SessionFilter{UserName: "some-user"}))
+				})
+
+				Context("when a connector is also given", func() {
+					BeforeEach(func() {
+						query = "?user=some-user&connector=github"
+					})
+
+					It("lists their sessions for that connector", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(dbAccessTokenFactory.SessionsArgsForCall(0)).To(Equal(db.SessionFilter{UserName: "some-user", Connector: "github"}))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/sessions/:session_id", func() {
+		JustBeforeEach(func() {
+			doRequest("DELETE", "/api/v1/sessions/1")
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				fakeAccess.ClaimsReturns(accessor.Claims{Sub: "admin-sub"})
+				dbAccessTokenFactory.RevokeSessionReturns(true, nil)
+			})
+
+			It("revokes the session whoever it belongs to", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				id, sub := dbAccessTokenFactory.RevokeSessionArgsForCall(0)
+				Expect(id).To(Equal(1))
+				Expect(sub).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/sessions", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = "?user=some-user&connector=github"
+		})
+
+		JustBeforeEach(func() {
+			doRequest("DELETE", "/api/v1/sessions"+query)
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				dbAccessTokenFactory.RevokeSessionsReturns(3, nil)
+			})
+
+			It("revokes all of the user's sessions for the connector", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(dbAccessTokenFactory.RevokeSessionsArgsForCall(0)).To(Equal(db.SessionFilter{UserName: "some-user", Connector: "github"}))
+			})
+
+			Context("when the user has no sessions", func() {
+				BeforeEach(func() {
+					dbAccessTokenFactory.RevokeSessionsReturns(0, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when no user is given", func() {
+				BeforeEach(func() {
+					query = ""
+				})
+
+				It("returns 400 rather than revoking everyone's sessions", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbAccessTokenFactory.RevokeSessionsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when no connector is given", func() {
+				BeforeEach(func() {
+					query = "?user=some-user"
+				})
+
+				It("returns 400 rather than revoking the sessions of users of the same name", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbAccessTokenFactory.RevokeSessionsCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,62 @@
+package sessionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListUserSessions lists the sessions of the requesting user.
+func (s *Server) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-user-sessions")
+
+	if rejectAPIToken(logger, w, r) {
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	s.list(logger, w, db.SessionFilter{Sub: acc.Claims().Sub})
+}
+
+// ListSessions lists the sessions of every user, or of the user given by
+// name and optionally connector.
+func (s *Server) ListSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-sessions")
+
+	if rejectAPIToken(logger, w, r) {
+		return
+	}
+
+	s.list(logger, w, db.SessionFilter{
+		UserName:  r.URL.Query().Get("user"),
+		Connector: r.URL.Query().Get("connector"),
+	})
+}
+
+func (s *Server) list(logger lager.Logger, w http.ResponseWriter, filter db.SessionFilter) {
+	sessions, err := s.accessTokenFactory.Sessions(filter)
+	if err != nil {
+		logger.Error("failed-to-get-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.Session, len(sessions))
+	for i, session := range sessions {
+		presented[i] = present.Session(session)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
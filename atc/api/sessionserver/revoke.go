@@ -0,0 +1,108 @@
+package sessionserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// RevokeUserSession revokes one of the requesting user's sessions.
+func (s *Server) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-user-session")
+
+	if rejectAPIToken(logger, w, r) {
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	s.revoke(logger, w, r, acc.Claims().Sub)
+}
+
+// RevokeUserSessions revokes all of the requesting user's sessions, including
+// the one the request was made with.
+func (s *Server) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-user-sessions")
+
+	if rejectAPIToken(logger, w, r) {
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	s.revokeAll(logger, w, db.SessionFilter{Sub: acc.Claims().Sub})
+}
+
+// RevokeSession revokes any user's session.
+func (s *Server) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-session")
+
+	if rejectAPIToken(logger, w, r) {
+		return
+	}
+
+	s.revoke(logger, w, r, "")
+}
+
+// RevokeSessions revokes all of the sessions of the user given by name and
+// connector; names alone are only unique per connector. The user's API tokens
+// are left alone.
+func (s *Server) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-sessions")
+
+	if rejectAPIToken(logger, w, r) {
+		return
+	}
+
+	userName := r.URL.Query().Get("user")
+	connector := r.URL.Query().Get("connector")
+	if userName == "" || connector == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "both user and connector must be given")
+		return
+	}
+
+	s.revokeAll(logger, w, db.SessionFilter{UserName: userName, Connector: connector})
+}
+
+func (s *Server) revoke(logger lager.Logger, w http.ResponseWriter, r *http.Request, sub string) {
+	id, err := strconv.Atoi(r.FormValue(":session_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := s.accessTokenFactory.RevokeSession(id, sub)
+	if err != nil {
+		logger.Error("failed-to-revoke-session", err, lager.Data{"session": id})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !revoked {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) revokeAll(logger lager.Logger, w http.ResponseWriter, filter db.SessionFilter) {
+	revoked, err := s.accessTokenFactory.RevokeSessions(filter)
+	if err != nil {
+		logger.Error("failed-to-revoke-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if revoked == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
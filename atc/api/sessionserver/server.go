@@ -0,0 +1,38 @@
+package sessionserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger             lager.Logger
+	accessTokenFactory db.AccessTokenFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	accessTokenFactory db.AccessTokenFactory,
+) *Server {
+	return &Server{
+		logger:             logger,
+		accessTokenFactory: accessTokenFactory,
+	}
+}
+
+// rejectAPIToken refuses requests made with an API token, which is meant for
+// automation and so should not be able to see or end anyone's sessions.
+func rejectAPIToken(logger lager.Logger, w http.ResponseWriter, r *http.Request) bool {
+	if accessor.GetAccessor(r).Claims().APIToken == "" {
+		return false
+	}
+
+	logger.Info("api-token-used-to-manage-sessions")
+	w.WriteHeader(http.StatusForbidden)
+	fmt.Fprint(w, "API tokens cannot be used to manage sessions")
+	return true
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
@@ -139,6 +140,13 @@ var _ = Describe("Teams API", func() {
 					"groups": {}, "users": {"local:username"},
 				},
 			})
+			fakeTeam.PipelineAuthReturns(atc.PipelineAuth{
+				"some-pipeline": atc.TeamAuth{
+					"member": map[string][]string{
+						"users": {"local:contractor"},
+					},
+				},
+			})
 		})
 
 		JustBeforeEach(func() {
@@ -185,6 +193,15 @@ var _ = Describe("Teams API", func() {
 								"local:username"
 							]
 						}
+					},
+					"pipeline_auth": {
+						"some-pipeline": {
+							"member": {
+								"users": [
+									"local:contractor"
+								]
+							}
+						}
 					}
 				}`))
 			})
@@ -263,6 +280,50 @@ var _ = Describe("Teams API", func() {
 					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 				})
 
+				It("updates the notification rules", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateNotificationsCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateNotificationsArgsForCall(0)).To(BeEmpty())
+				})
+
+				Context("when notification rules are configured", func() {
+					BeforeEach(func() {
+						atcTeam.Notifications = atc.NotificationRules{
+							{URL: "https://example.com/hook", Events: []atc.BuildStatus{atc.StatusFailed}},
+						}
+					})
+
+					It("updates the notification rules", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateNotificationsCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateNotificationsArgsForCall(0)).To(Equal(atcTeam.Notifications))
+					})
+				})
+
+				Context("when a notification rule is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.Notifications = atc.NotificationRules{
+							{URL: "not-a-url"},
+						}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateNotificationsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when updating the notification rules fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateNotificationsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
 				Context("when updating provider auth fails", func() {
 					BeforeEach(func() {
 						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
@@ -789,4 +850,663 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/notifications/deliveries", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notifications/deliveries" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.NotificationDeliveriesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.NotificationDeliveriesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.NotificationDeliveriesReturns([]db.NotificationDelivery{
+					{
+						ID:           2,
+						BuildID:      42,
+						BuildName:    "7",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						TeamName:     "some-team",
+						URL:          "https://example.com/hook",
+						Event:        atc.StatusFailed,
+						Attempt:      1,
+						StatusCode:   502,
+						Error:        "unexpected response: 502 Bad Gateway",
+						AttemptedAt:  time.Unix(100, 0),
+					},
+				}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the delivery attempts", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"build_id": 42,
+						"build_name": "7",
+						"job_name": "some-job",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team",
+						"url": "https://example.com/hook",
+						"event": "failed",
+						"attempt": 1,
+						"status_code": 502,
+						"error": "unexpected response: 502 Bad Gateway",
+						"succeeded": false,
+						"attempted_at": 100
+					}
+				]`))
+			})
+
+			It("uses the default limit", func() {
+				Expect(fakeTeam.NotificationDeliveriesCallCount()).To(Equal(1))
+				limit, failedOnly := fakeTeam.NotificationDeliveriesArgsForCall(0)
+				Expect(limit).To(Equal(100))
+				Expect(failedOnly).To(BeFalse())
+			})
+
+			Context("when the limit and failed params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?limit=5&failed=true"
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.NotificationDeliveriesCallCount()).To(Equal(1))
+					limit, failedOnly := fakeTeam.NotificationDeliveriesArgsForCall(0)
+					Expect(limit).To(Equal(5))
+					Expect(failedOnly).To(BeTrue())
+				})
+			})
+
+			Context("when getting the delivery attempts fails", func() {
+				BeforeEach(func() {
+					fakeTeam.NotificationDeliveriesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/builds/search", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = "?q=connection+refused"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/builds/search" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.SearchBuildLogsReturns([]db.BuildLogMatch{
+					{
+						BuildID:              42,
+						BuildName:            "7",
+						BuildStatus:          db.BuildStatusFailed,
+						JobName:              "some-job",
+						PipelineName:         "some-pipeline",
+						PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
+						TeamName:             "some-team",
+						EventID:              12,
+						OriginID:             "some-plan-id",
+						Time:                 time.Unix(100, 0),
+						Line:                 "error: connection refused",
+					},
+				}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the matching log events", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"build_id": 42,
+						"build_name": "7",
+						"build_status": "failed",
+						"job_name": "some-job",
+						"pipeline_name": "some-pipeline",
+						"pipeline_instance_vars": {"branch": "master"},
+						"team_name": "some-team",
+						"event_id": 12,
+						"origin_id": "some-plan-id",
+						"time": 100,
+						"line": "error: connection refused"
+					}
+				]`))
+			})
+
+			It("searches with the default limit", func() {
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+				Expect(fakeTeam.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+					Query: "connection refused",
+					Limit: 100,
+				}))
+			})
+
+			Context("when filters are passed", func() {
+				var fakePipeline *dbfakes.FakePipeline
+
+				BeforeEach(func() {
+					fakePipeline = new(dbfakes.FakePipeline)
+					fakePipeline.IDReturns(3)
+					fakeTeam.PipelineReturns(fakePipeline, true, nil)
+
+					queryParams += "&pipeline_name=some-pipeline&vars.branch=%22master%22&job_name=some-job" +
+						"&status=failed&status=errored&since=100&until=200&limit=5&oldest_first=true"
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "master"},
+					}))
+
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+					Expect(fakeTeam.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+						Query:       "connection refused",
+						PipelineID:  3,
+						JobName:     "some-job",
+						Statuses:    []db.BuildStatus{db.BuildStatusFailed, db.BuildStatusErrored},
+						Since:       time.Unix(100, 0),
+						Until:       time.Unix(200, 0),
+						Limit:       5,
+						OldestFirst: true,
+					}))
+				})
+
+				Context("when the pipeline is not found", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the query is missing", func() {
+				BeforeEach(func() {
+					queryParams = ""
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the status is unknown", func() {
+				BeforeEach(func() {
+					queryParams += "&status=bogus"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("unknown status 'bogus'"))
+				})
+			})
+
+			Context("when the time range is malformed", func() {
+				BeforeEach(func() {
+					queryParams += "&since=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when searching fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SearchBuildLogsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/sealing_key", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/sealing_key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.SealingKeyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SealingKeyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when the team has a sealing key", func() {
+				BeforeEach(func() {
+					fakeTeam.SealingKeyReturns("AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX", true, nil)
+				})
+
+				It("returns the public key", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"public_key": "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj"
+					}`))
+				})
+
+				It("does not generate a new key", func() {
+					Expect(fakeTeam.SaveSealingKeyCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the team has no sealing key yet", func() {
+				BeforeEach(func() {
+					fakeTeam.SealingKeyReturns("", false, nil)
+					fakeTeam.SaveSealingKeyStub = func(identity string) (string, error) {
+						return identity, nil
+					}
+				})
+
+				It("generates and saves one", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.SaveSealingKeyCallCount()).To(Equal(1))
+					Expect(fakeTeam.SaveSealingKeyArgsForCall(0)).To(HavePrefix("AGE-SECRET-KEY-1"))
+
+					var key atc.SealingKey
+					err := json.NewDecoder(response.Body).Decode(&key)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(key.PublicKey).To(HavePrefix("age1"))
+				})
+			})
+
+			Context("when getting the key fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SealingKeyReturns("", false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/secrets/accesses", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/secrets/accesses" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.SecretAccessesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SecretAccessesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.SecretAccessesReturns([]db.SecretAccess{
+					{
+						ID:           1,
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						BuildID:      42,
+						BuildName:    "3",
+						StepName:     "deploy",
+						SecretAccess: creds.SecretAccess{
+							Var:        "prod-db-password",
+							Backend:    "vault",
+							SecretPath: "/concourse/some-team/prod-db-password",
+						},
+						AccessedAt: time.Unix(1000, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the accesses", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[{
+					"id": 1,
+					"team_name": "some-team",
+					"pipeline_name": "some-pipeline",
+					"job_name": "some-job",
+					"build_id": 42,
+					"build_name": "3",
+					"step_name": "deploy",
+					"var": "prod-db-password",
+					"backend": "vault",
+					"secret_path": "/concourse/some-team/prod-db-password",
+					"accessed_at": 1000
+				}]`))
+			})
+
+			It("defaults the limit", func() {
+				Expect(fakeTeam.SecretAccessesCallCount()).To(Equal(1))
+				Expect(fakeTeam.SecretAccessesArgsForCall(0)).To(Equal(db.SecretAccessFilter{
+					Limit: atc.PaginationAPIDefaultLimit,
+				}))
+			})
+
+			Context("when filters are given", func() {
+				BeforeEach(func() {
+					query = "?var=prod-db-password&pipeline_name=some-pipeline&job_name=some-job&since=100&until=200&limit=5"
+				})
+
+				It("passes them on", func() {
+					Expect(fakeTeam.SecretAccessesCallCount()).To(Equal(1))
+					Expect(fakeTeam.SecretAccessesArgsForCall(0)).To(Equal(db.SecretAccessFilter{
+						Var:          "prod-db-password",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Since:        time.Unix(100, 0),
+						Until:        time.Unix(200, 0),
+						Limit:        5,
+					}))
+				})
+			})
+
+			Context("when since is not a timestamp", func() {
+				BeforeEach(func() {
+					query = "?since=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SecretAccessesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when getting the accesses fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SecretAccessesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/auth", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"member":{"users":["local:contractor"]}}`
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/auth", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.UpdatePipelineAuthCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized for the pipeline", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.UpdatePipelineAuthCallCount()).To(Equal(0))
+			})
+
+			It("checks the roles bound for the pipeline", func() {
+				teamName, pipelineName := fakeAccess.IsPipelineAuthorizedArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(pipelineName).To(Equal("some-pipeline"))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("saves the bindings and refreshes the team cache", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(fakeTeam.UpdatePipelineAuthCallCount()).To(Equal(1))
+				pipelineName, auth := fakeTeam.UpdatePipelineAuthArgsForCall(0)
+				Expect(pipelineName).To(Equal("some-pipeline"))
+				Expect(auth).To(Equal(atc.TeamAuth{
+					"member": map[string][]string{"users": {"local:contractor"}},
+				}))
+
+				Expect(dbTeamFactory.NotifyCacherCallCount()).To(Equal(1))
+			})
+
+			Context("when the body is empty", func() {
+				BeforeEach(func() {
+					requestBody = `{}`
+				})
+
+				It("removes the bindings", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(fakeTeam.UpdatePipelineAuthCallCount()).To(Equal(1))
+					_, auth := fakeTeam.UpdatePipelineAuthArgsForCall(0)
+					Expect(auth).To(BeEmpty())
+				})
+			})
+
+			Context("when a role has no users or groups", func() {
+				BeforeEach(func() {
+					requestBody = `{"member":{}}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.UpdatePipelineAuthCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `nope`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					fakeTeam.UpdatePipelineAuthReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,30 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/sealed"
+)
+
+func (s *Server) GetSealingKey(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-sealing-key")
+
+		publicKey, err := sealed.Recipient(team)
+		if err != nil {
+			logger.Error("failed-to-get-sealing-key", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(atc.SealingKey{PublicKey: publicKey})
+		if err != nil {
+			logger.Error("failed-to-encode-sealing-key", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListSecretAccesses(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-secret-accesses")
+
+		filter, err := secretAccessFilterFromRequest(r)
+		if err != nil {
+			logger.Info("invalid-filter", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		accesses, err := team.SecretAccesses(filter)
+		if err != nil {
+			logger.Error("failed-to-get-secret-accesses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.SecretAccess{}
+		for _, access := range accesses {
+			presented = append(presented, present.SecretAccess(access))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-secret-accesses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func secretAccessFilterFromRequest(r *http.Request) (db.SecretAccessFilter, error) {
+	filter := db.SecretAccessFilter{
+		Var:          r.FormValue("var"),
+		PipelineName: r.FormValue("pipeline_name"),
+		JobName:      r.FormValue("job_name"),
+	}
+
+	var err error
+	filter.Since, err = unixTimeParam(r, "since")
+	if err != nil {
+		return db.SecretAccessFilter{}, err
+	}
+
+	filter.Until, err = unixTimeParam(r, "until")
+	if err != nil {
+		return db.SecretAccessFilter{}, err
+	}
+
+	filter.Limit, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if filter.Limit <= 0 {
+		filter.Limit = atc.PaginationAPIDefaultLimit
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,126 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) SearchBuildLogs(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-build-logs")
+
+		search, err := buildLogSearchFromRequest(r)
+		if err != nil {
+			logger.Info("invalid-search", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		pipelineName := r.FormValue("pipeline_name")
+		if pipelineName != "" {
+			pipelineRef := atc.PipelineRef{Name: pipelineName}
+			pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+			if err != nil {
+				logger.Info("malformed-instance-vars", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			pipeline, found, err := team.Pipeline(pipelineRef)
+			if err != nil {
+				logger.Error("failed-to-get-pipeline", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			search.PipelineID = pipeline.ID()
+		}
+
+		matches, err := team.SearchBuildLogs(search)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.BuildLogMatch{}
+		for _, match := range matches {
+			presented = append(presented, present.BuildLogMatch(match))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-build-log-matches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func buildLogSearchFromRequest(r *http.Request) (db.BuildLogSearch, error) {
+	search := db.BuildLogSearch{
+		Query:       r.FormValue("q"),
+		JobName:     r.FormValue("job_name"),
+		OldestFirst: r.FormValue("oldest_first") == "true",
+	}
+
+	if search.Query == "" {
+		return db.BuildLogSearch{}, fmt.Errorf("query must be specified")
+	}
+
+	for _, status := range r.Form["status"] {
+		switch db.BuildStatus(status) {
+		case db.BuildStatusPending, db.BuildStatusStarted, db.BuildStatusSucceeded,
+			db.BuildStatusFailed, db.BuildStatusErrored, db.BuildStatusAborted:
+			search.Statuses = append(search.Statuses, db.BuildStatus(status))
+		default:
+			return db.BuildLogSearch{}, fmt.Errorf("unknown status '%s'", status)
+		}
+	}
+
+	var err error
+	search.Since, err = unixTimeParam(r, "since")
+	if err != nil {
+		return db.BuildLogSearch{}, err
+	}
+
+	search.Until, err = unixTimeParam(r, "until")
+	if err != nil {
+		return db.BuildLogSearch{}, err
+	}
+
+	search.Limit, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if search.Limit <= 0 {
+		search.Limit = atc.PaginationAPIDefaultLimit
+	}
+
+	return search, nil
+}
+
+func unixTimeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.FormValue(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s '%s': must be a unix timestamp", name, value)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
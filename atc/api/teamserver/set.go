@@ -35,7 +35,7 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := atcTeam.Validate(); err != nil {
-		hLog.Error("malformed-auth-config", err)
+		hLog.Error("malformed-team-config", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -59,6 +59,13 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		err = team.UpdateNotifications(atcTeam.Notifications)
+		if err != nil {
+			hLog.Error("failed-to-update-team-notifications", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
@@ -0,0 +1,54 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// SetPipelineAuth replaces the role bindings for the team's pipelines with
+// the requested name. An empty body removes them.
+func (s *Server) SetPipelineAuth(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-pipeline-auth")
+
+		pipelineName := r.FormValue(":pipeline_name")
+
+		var auth atc.TeamAuth
+		err := json.NewDecoder(r.Body).Decode(&auth)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(auth) > 0 {
+			err = auth.Validate()
+			if err != nil {
+				logger.Error("malformed-pipeline-auth", err)
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		err = team.UpdatePipelineAuth(pipelineName, auth)
+		if err != nil {
+			logger.Error("failed-to-update-pipeline-auth", err, lager.Data{"pipeline": pipelineName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = s.teamFactory.NotifyCacher()
+		if err != nil {
+			logger.Error("failed-to-notify-cacher", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
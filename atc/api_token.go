@@ -0,0 +1,22 @@
+package atc
+
+// APITokenPrefix starts every API token, which distinguishes them from the
+// session tokens issued on login.
+const APITokenPrefix = "cct_"
+
+// APIToken is a long-lived token for automation. Personal tokens act as the
+// user who created them; team tokens are granted Role on TeamName instead.
+// Either may be narrowed with Scopes.
+type APIToken struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	TeamName  string   `json:"team_name,omitempty"`
+	Role      string   `json:"role,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	CreatedBy string   `json:"created_by,omitempty"`
+	CreatedAt int64    `json:"created_at"`
+	ExpiresAt int64    `json:"expires_at,omitempty"`
+
+	// Token is only returned when the token is created.
+	Token string `json:"token,omitempty"`
+}
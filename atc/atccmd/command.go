@@ -2,6 +2,7 @@ package atccmd
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
@@ -40,8 +41,12 @@ import (
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/idtoken"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logsink"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
@@ -80,12 +85,15 @@ import (
 	_ "github.com/concourse/concourse/atc/metric/emitter"
 
 	// dynamically registered policy checkers
+	_ "github.com/concourse/concourse/atc/policy/embedded"
 	_ "github.com/concourse/concourse/atc/policy/opa"
 
 	// dynamically registered credential managers
+	_ "github.com/concourse/concourse/atc/creds/azurekeyvault"
 	_ "github.com/concourse/concourse/atc/creds/conjur"
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
+	_ "github.com/concourse/concourse/atc/creds/encryptedfile"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
@@ -110,7 +118,8 @@ type ATCCommand struct {
 type RunCommand struct {
 	Logger flag.Lager
 
-	varSourcePool creds.VarSourcePool
+	varSourcePool  creds.VarSourcePool
+	secretsBackend string
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -176,6 +185,14 @@ type RunCommand struct {
 		Filter policy.Filter
 	} `group:"Policy Checking"`
 
+	Notifications notifications.Config `group:"Build Notifications"`
+
+	BuildLogArchive logarchive.Config `group:"Build Log Archiving"`
+
+	LogSinks logsink.Config `group:"Build Log Sinks"`
+
+	WorkloadIdentity idtoken.Config `group:"Workload Identity"`
+
 	Server struct {
 		XFrameOptions         string `long:"x-frame-options" default:"deny" description:"The value to set for the X-Frame-Options header."`
 		ContentSecurityPolicy string `long:"content-security-policy" default:"frame-ancestors 'none'" description:"The value to set for the Content-Security-Policy header."`
@@ -190,12 +207,15 @@ type RunCommand struct {
 	GC struct {
 		Interval time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 
-		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
-		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
-		HijackGracePeriod      time.Duration `long:"hijack-grace-period" default:"5m" description:"Period after which hijacked containers will be garbage collected"`
-		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
-		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
-		VarSourceRecyclePeriod time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+		OneOffBuildGracePeriod  time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
+		MissingGracePeriod      time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+		HijackGracePeriod       time.Duration `long:"hijack-grace-period" default:"5m" description:"Period after which hijacked containers will be garbage collected"`
+		FailedGracePeriod       time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
+		CheckRecyclePeriod      time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
+		VarSourceRecyclePeriod  time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+		TaskResultRecyclePeriod time.Duration `long:"task-result-recycle-period" default:"168h" description:"Period after which to reap results of tasks with cache_result enabled that have not been reused."`
+		SecretAccessRetention   time.Duration `long:"secret-access-retention" default:"2160h" description:"Period for which to keep the record of which builds read which secrets."`
+		AuditEventRetention     time.Duration `long:"audit-event-retention" default:"2160h" description:"Period for which to keep saved audit events."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -223,13 +243,15 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+
+		EnableAuditPersistence bool `long:"enable-audit-persistence" description:"Save audited api requests to the database so that they can be queried through the API and 'fly audit-log'."`
 	}
 
 	Syslog struct {
 		Hostname      string        `long:"syslog-hostname" description:"Client hostname with which the build logs will be sent to the syslog server." default:"atc-syslog-drainer"`
 		Address       string        `long:"syslog-address" description:"Remote syslog server address with port (Example: 0.0.0.0:514)."`
 		Transport     string        `long:"syslog-transport" description:"Transport protocol for syslog messages (Currently supporting tcp, udp & tls)."`
-		DrainInterval time.Duration `long:"syslog-drain-interval" description:"Interval over which checking is done for new build logs to send to syslog server (duration measurement units are s/m/h; eg. 30s/30m/1h)" default:"30s"`
+		DrainInterval time.Duration `long:"syslog-drain-interval" description:"Interval over which checking is done for new build logs to send to syslog server and any other configured log sinks (duration measurement units are s/m/h; eg. 30s/30m/1h)" default:"30s"`
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
@@ -691,7 +713,7 @@ func (cmd *RunCommand) constructMembers(
 		return nil, err
 	}
 
-	gcComponents, err := cmd.gcComponents(logger, gcConn, lockFactory)
+	gcComponents, err := cmd.gcComponents(logger, gcConn, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
 	}
@@ -770,6 +792,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	}
 
 	userFactory := db.NewUserFactory(dbConn)
+	dbAuditEventFactory := db.NewAuditEventFactory(dbConn)
 
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
@@ -819,10 +842,11 @@ func (cmd *RunCommand) constructAPIMembers(
 		Timeout:             cmd.GlobalResourceCheckTimeout,
 	})
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
+	dbAPITokenFactory := db.NewAPITokenFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
 
-	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
+	tokenVerifier := cmd.constructTokenVerifier(logger, dbConn.Bus(), dbAccessTokenFactory, dbAPITokenFactory)
 
 	teamsCacher := accessor.NewTeamsCacher(
 		logger,
@@ -863,6 +887,9 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		userFactory,
+		dbAuditEventFactory,
+		dbAPITokenFactory,
+		dbAccessTokenFactory,
 		pool,
 		secretManager,
 		credsManagers,
@@ -894,6 +921,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		logger,
 		httpClient,
 		middleware,
+		storage,
 	)
 	if err != nil {
 		return nil, err
@@ -906,6 +934,16 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	idTokenIssuer, err := cmd.idTokenIssuer()
+	if err != nil {
+		return nil, err
+	}
+
+	var idTokenHandler http.Handler
+	if idTokenIssuer != nil {
+		idTokenHandler = idtoken.NewHandler(logger.Session("id-token"), idTokenIssuer)
+	}
+
 	var httpHandler, httpsHandler http.Handler
 	if cmd.isTLSEnabled() {
 		httpHandler = cmd.constructHTTPHandler(
@@ -941,6 +979,7 @@ func (cmd *RunCommand) constructAPIMembers(
 				externalHost:  cmd.ExternalURL.URL.Host,
 				baseHandler:   legacyHandler,
 			},
+			idTokenHandler,
 			middleware,
 		)
 
@@ -951,6 +990,7 @@ func (cmd *RunCommand) constructAPIMembers(
 			authHandler,
 			loginHandler,
 			legacyHandler,
+			idTokenHandler,
 			middleware,
 		)
 	} else {
@@ -961,6 +1001,7 @@ func (cmd *RunCommand) constructAPIMembers(
 			authHandler,
 			loginHandler,
 			legacyHandler,
+			idTokenHandler,
 			middleware,
 		)
 	}
@@ -1003,9 +1044,26 @@ func (cmd *RunCommand) backendComponents(
 		return nil, fmt.Errorf("syslog Drainer is misconfigured, cannot configure a drainer without a transport")
 	}
 
-	syslogDrainConfigured := true
-	if cmd.Syslog.Address == "" {
-		syslogDrainConfigured = false
+	logSinks := cmd.LogSinks.Sinks()
+	if cmd.Syslog.Address != "" {
+		logSinks = append(logSinks, syslog.NewSink(
+			cmd.Syslog.Transport,
+			cmd.Syslog.Address,
+			cmd.Syslog.Hostname,
+			cmd.Syslog.CACerts,
+		))
+	}
+
+	drainConfigured := len(logSinks) > 0
+
+	buildLogs, err := cmd.buildLogArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	var buildLogArchiver gc.BuildLogArchiver
+	if buildLogs != nil {
+		buildLogArchiver = buildLogs
 	}
 
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
@@ -1015,6 +1073,7 @@ func (cmd *RunCommand) backendComponents(
 	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
 	resourceFetcher := worker.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
+	dbTaskResultFactory := db.NewTaskResultFactory(dbConn)
 
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
 	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.varSourcePool, db.CheckDurations{
@@ -1085,6 +1144,18 @@ func (cmd *RunCommand) backendComponents(
 		clock.NewClock(),
 	)
 
+	var idTokenIssuer idtoken.Issuer
+	jwtIssuer, err := cmd.idTokenIssuer()
+	if err != nil {
+		return nil, err
+	}
+
+	if jwtIssuer != nil {
+		idTokenIssuer = jwtIssuer
+	}
+
+	notifier := notifications.NewWebhookNotifier(teamFactory, cmd.ExternalURL.String(), cmd.Notifications)
+
 	engine := cmd.constructEngine(
 		pool,
 		artifactStreamer,
@@ -1095,12 +1166,15 @@ func (cmd *RunCommand) backendComponents(
 		dbBuildFactory,
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
+		dbTaskResultFactory,
 		secretManager,
 		defaultLimits,
 		buildContainerStrategy,
 		lockFactory,
 		rateLimiter,
 		policyChecker,
+		idTokenIssuer,
+		notifier,
 	)
 
 	// In case that a user configures resource-checking-interval, but forgets to
@@ -1139,7 +1213,8 @@ func (cmd *RunCommand) backendComponents(
 						builds.NewPlanner(
 							atc.NewPlanFactory(time.Now().Unix()),
 						),
-						alg),
+						alg,
+						notifier),
 				},
 				cmd.JobSchedulingMaxInFlight,
 			),
@@ -1166,24 +1241,19 @@ func (cmd *RunCommand) backendComponents(
 					cmd.DefaultDaysToRetainBuildLogs,
 					cmd.MaxDaysToRetainBuildLogs,
 				),
-				syslogDrainConfigured,
+				drainConfigured,
+				buildLogArchiver,
 			),
 		},
 	}
 
-	if syslogDrainConfigured {
+	if drainConfigured {
 		components = append(components, RunnableComponent{
 			Component: atc.Component{
 				Name:     atc.ComponentSyslogDrainer,
 				Interval: cmd.Syslog.DrainInterval,
 			},
-			Runnable: syslog.NewDrainer(
-				cmd.Syslog.Transport,
-				cmd.Syslog.Address,
-				cmd.Syslog.Hostname,
-				cmd.Syslog.CACerts,
-				dbBuildFactory,
-			),
+			Runnable: logsink.NewDrainer(dbBuildFactory, logSinks),
 		})
 	}
 
@@ -1194,6 +1264,7 @@ func (cmd *RunCommand) gcComponents(
 	logger lager.Logger,
 	gcConn db.Conn,
 	lockFactory lock.LockFactory,
+	secretManager creds.Secrets,
 ) ([]RunnableComponent, error) {
 	dbWorkerLifecycle := db.NewWorkerLifecycle(gcConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(gcConn)
@@ -1205,6 +1276,10 @@ func (cmd *RunCommand) gcComponents(
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(gcConn, lockFactory)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
+	dbTaskResultLifecycle := db.NewTaskResultLifecycle(gcConn)
+	dbSecretLeaseLifecycle := db.NewSecretLeaseLifecycle(gcConn)
+	dbSecretAccessLifecycle := db.NewSecretAccessLifecycle(gcConn)
+	dbAuditEventLifecycle := db.NewAuditEventLifecycle(gcConn)
 
 	dbVolumeRepository := db.NewVolumeRepository(gcConn)
 
@@ -1230,6 +1305,13 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
 		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
+		atc.ComponentCollectorTaskResults:       gc.NewTaskResultCollector(dbTaskResultLifecycle, cmd.GC.TaskResultRecyclePeriod),
+		atc.ComponentCollectorSecretAccesses:    gc.NewSecretAccessCollector(dbSecretAccessLifecycle, cmd.GC.SecretAccessRetention),
+		atc.ComponentCollectorAuditEvents:       gc.NewAuditEventCollector(dbAuditEventLifecycle, cmd.GC.AuditEventRetention),
+	}
+
+	if leasingSecrets, ok := secretManager.(creds.LeasingSecrets); ok {
+		collectors[atc.ComponentCollectorSecretLeases] = gc.NewSecretLeaseCollector(dbSecretLeaseLifecycle, leasingSecrets)
 	}
 
 	var components []RunnableComponent
@@ -1341,6 +1423,8 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 			return nil, err
 		}
 
+		cmd.secretsBackend = name
+
 		break
 	}
 
@@ -1571,6 +1655,32 @@ func (cmd *RunCommand) validate() error {
 	return errs.ErrorOrNil()
 }
 
+// idTokenIssuer returns the issuer of the id tokens handed to steps, or nil
+// if workload identity is disabled.
+func (cmd *RunCommand) idTokenIssuer() (*idtoken.JWTIssuer, error) {
+	var sessionSigningKey *rsa.PrivateKey
+	if cmd.Auth.AuthFlags.SigningKey != nil {
+		sessionSigningKey = cmd.Auth.AuthFlags.SigningKey.PrivateKey
+	}
+
+	return cmd.WorkloadIdentity.Issuer(cmd.ExternalURL.String(), sessionSigningKey)
+}
+
+// buildLogArchive returns the archive that build logs are moved to once
+// their retention is exceeded, or nil if they are to be deleted.
+func (cmd *RunCommand) buildLogArchive() (*logarchive.BuildLogs, error) {
+	archiver, err := cmd.BuildLogArchive.Archiver()
+	if err != nil {
+		return nil, err
+	}
+
+	if archiver == nil {
+		return nil, nil
+	}
+
+	return logarchive.NewBuildLogs(archiver, compression.NewGzipCompression()), nil
+}
+
 func (cmd *RunCommand) nonTLSBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 }
@@ -1675,12 +1785,15 @@ func (cmd *RunCommand) constructEngine(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	taskResultFactory db.TaskResultFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
 	policyChecker policy.Checker,
+	idTokenIssuer idtoken.Issuer,
+	notifier notifications.Notifier,
 ) engine.Engine {
 	return engine.NewEngine(
 		engine.NewStepperFactory(
@@ -1693,9 +1806,11 @@ func (cmd *RunCommand) constructEngine(
 				buildFactory,
 				resourceCacheFactory,
 				resourceConfigFactory,
+				taskResultFactory,
 				defaultLimits,
 				strategy,
 				cmd.GlobalResourceCheckTimeout,
+				idTokenIssuer,
 			),
 			cmd.ExternalURL.String(),
 			rateLimiter,
@@ -1705,7 +1820,9 @@ func (cmd *RunCommand) constructEngine(
 			lockFactory,
 		),
 		secretManager,
+		cmd.secretsBackend,
 		cmd.varSourcePool,
+		notifier,
 	)
 }
 
@@ -1716,6 +1833,7 @@ func (cmd *RunCommand) constructHTTPHandler(
 	authHandler http.Handler,
 	loginHandler http.Handler,
 	legacyHandler http.Handler,
+	idTokenHandler http.Handler,
 	middleware token.Middleware,
 ) http.Handler {
 
@@ -1731,6 +1849,11 @@ func (cmd *RunCommand) constructHTTPHandler(
 	webMux.Handle("/auth/", legacyHandler)
 	webMux.Handle("/login", legacyHandler)
 	webMux.Handle("/logout", legacyHandler)
+
+	if idTokenHandler != nil {
+		webMux.Handle("/.well-known/", idTokenHandler)
+	}
+
 	webMux.Handle("/", webHandler)
 
 	httpHandler := wrappa.LoggerHandler{
@@ -1807,6 +1930,7 @@ func (cmd *RunCommand) constructLoginHandler(
 	logger lager.Logger,
 	httpClient *http.Client,
 	middleware token.Middleware,
+	storage storage.Storage,
 ) (http.Handler, error) {
 
 	authPath, _ := url.Parse("/sky/issuer/auth")
@@ -1837,6 +1961,7 @@ func (cmd *RunCommand) constructLoginHandler(
 		TokenParser:     token.Factory{},
 		OAuthConfig:     oauth2Config,
 		HTTPClient:      httpClient,
+		Storage:         storage,
 	})
 	if err != nil {
 		return nil, err
@@ -1845,7 +1970,12 @@ func (cmd *RunCommand) constructLoginHandler(
 	return skyserver.NewSkyHandler(skyServer), nil
 }
 
-func (cmd *RunCommand) constructTokenVerifier(accessTokenFactory db.AccessTokenFactory) accessor.TokenVerifier {
+func (cmd *RunCommand) constructTokenVerifier(
+	logger lager.Logger,
+	notifications accessor.Notifications,
+	accessTokenFactory db.AccessTokenFactory,
+	apiTokenFactory db.APITokenFactory,
+) accessor.TokenVerifier {
 
 	validClients := []string{flyClientID}
 	for clientId := range cmd.Auth.AuthFlags.Clients {
@@ -1853,9 +1983,15 @@ func (cmd *RunCommand) constructTokenVerifier(accessTokenFactory db.AccessTokenF
 	}
 
 	MiB := 1024 * 1024
-	claimsCacher := accessor.NewClaimsCacher(accessTokenFactory, 1*MiB)
+	claimsCacher := accessor.NewClaimsCacher(
+		logger.Session("claims-cacher"),
+		accessTokenFactory,
+		accessTokenFactory,
+		notifications,
+		1*MiB,
+	)
 
-	return accessor.NewVerifier(claimsCacher, validClients)
+	return accessor.NewVerifier(claimsCacher, apiTokenFactory, validClients)
 }
 
 func (cmd *RunCommand) constructAPIHandler(
@@ -1874,6 +2010,9 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbCheckFactory db.CheckFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbAuditEventFactory db.AuditEventFactory,
+	dbAPITokenFactory db.APITokenFactory,
+	dbAccessTokenFactory db.AccessTokenFactory,
 	workerPool worker.Pool,
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
@@ -1889,6 +2028,11 @@ func (cmd *RunCommand) constructAPIHandler(
 
 	rejectArchivedHandlerFactory := pipelineserver.NewRejectArchivedHandlerFactory(teamFactory)
 
+	var auditEventFactory db.AuditEventFactory
+	if cmd.Auditor.EnableAuditPersistence {
+		auditEventFactory = dbAuditEventFactory
+	}
+
 	aud := auditor.NewAuditor(
 		cmd.Auditor.EnableBuildAuditLog,
 		cmd.Auditor.EnableContainerAuditLog,
@@ -1899,6 +2043,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		cmd.Auditor.EnableTeamAuditLog,
 		cmd.Auditor.EnableWorkerAuditLog,
 		cmd.Auditor.EnableVolumeAuditLog,
+		auditEventFactory,
 		logger,
 	)
 
@@ -1907,6 +2052,16 @@ func (cmd *RunCommand) constructAPIHandler(
 		return nil, err
 	}
 
+	buildLogs, err := cmd.buildLogArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	eventHandlerFactory := buildserver.NewEventHandler
+	if buildLogs != nil {
+		eventHandlerFactory = buildserver.NewArchivedEventHandlerFactory(buildLogs)
+	}
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewConcurrentRequestLimitsWrappa(
 			logger,
@@ -1950,8 +2105,11 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbCheckFactory,
 		resourceConfigFactory,
 		dbUserFactory,
+		dbAuditEventFactory,
+		dbAPITokenFactory,
+		dbAccessTokenFactory,
 
-		buildserver.NewEventHandler,
+		eventHandlerFactory,
 
 		workerPool,
 
@@ -0,0 +1,19 @@
+package atc
+
+// AuditEvent records an audited API request and the status it was answered
+// with.
+type AuditEvent struct {
+	ID         int                 `json:"id"`
+	Action     string              `json:"action"`
+	UserName   string              `json:"user_name,omitempty"`
+	Connector  string              `json:"connector,omitempty"`
+	TeamName   string              `json:"team_name,omitempty"`
+	Target     map[string]string   `json:"target,omitempty"`
+	Method     string              `json:"method"`
+	Path       string              `json:"path"`
+	RemoteAddr string              `json:"remote_addr,omitempty"`
+	UserAgent  string              `json:"user_agent,omitempty"`
+	Parameters map[string][]string `json:"parameters,omitempty"`
+	Status     int                 `json:"status"`
+	CreatedAt  int64               `json:"created_at"`
+}
@@ -3,9 +3,12 @@ package auditor
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -21,6 +24,7 @@ func NewAuditor(
 	EnableTeamAuditLog bool,
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
+	auditEventFactory db.AuditEventFactory,
 	logger lager.Logger,
 ) *auditor {
 	return &auditor{
@@ -33,12 +37,19 @@ func NewAuditor(
 		EnableTeamAuditLog:      EnableTeamAuditLog,
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
+		auditEventFactory:       auditEventFactory,
 		logger:                  logger,
 	}
 }
 
+// Actor identifies who made an audited request.
+type Actor struct {
+	UserName  string
+	Connector string
+}
+
 type Auditor interface {
-	Audit(action string, userName string, r *http.Request)
+	Audit(action string, actor Actor, r *http.Request, status int)
 }
 
 type auditor struct {
@@ -51,6 +62,7 @@ type auditor struct {
 	EnableTeamAuditLog      bool
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
+	auditEventFactory       db.AuditEventFactory
 	logger                  lager.Logger
 }
 
@@ -61,11 +73,13 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.CreateBuild,
 		atc.RerunJobBuild,
 		atc.SetBuildComment,
+		atc.ApproveBuild,
 		atc.ListBuilds,
 		atc.BuildEvents,
 		atc.BuildResources,
 		atc.AbortBuild,
 		atc.GetBuildPreparation,
+		atc.ListBuildTestSuites,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
@@ -138,6 +152,16 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetInfo,
 		atc.GetInfoCreds,
 		atc.ListActiveUsersSince,
+		atc.ListAuditEvents,
+		atc.ListAPITokens,
+		atc.CreateAPIToken,
+		atc.RevokeAPIToken,
+		atc.ListUserSessions,
+		atc.RevokeUserSession,
+		atc.RevokeUserSessions,
+		atc.ListSessions,
+		atc.RevokeSession,
+		atc.RevokeSessions,
 		atc.GetUser,
 		atc.GetWall,
 		atc.SetWall,
@@ -148,6 +172,14 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListNotificationDeliveries,
+		atc.SearchBuildLogs,
+		atc.GetTeamSealingKey,
+		atc.ListSecretAccesses,
+		atc.SetPipelineAuth,
+		atc.ListTeamAPITokens,
+		atc.CreateTeamAPIToken,
+		atc.RevokeTeamAPIToken,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
@@ -167,9 +199,80 @@ func (a *auditor) ValidateAction(action string) bool {
 	}
 }
 
-func (a *auditor) Audit(action string, userName string, r *http.Request) {
+func (a *auditor) Audit(action string, actor Actor, r *http.Request, status int) {
 	err := r.ParseForm()
-	if err == nil && a.ValidateAction(action) {
-		a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form})
+	if err != nil || !a.ValidateAction(action) {
+		return
+	}
+
+	form := redactSecretParameters(r.Form)
+
+	a.logger.Info("audit", lager.Data{"action": action, "user": actor.UserName, "parameters": form, "status": status})
+
+	if a.auditEventFactory == nil {
+		return
+	}
+
+	event := db.AuditEvent{
+		Action:     action,
+		UserName:   actor.UserName,
+		Connector:  actor.Connector,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		RemoteAddr: r.RemoteAddr,
+		UserAgent:  r.UserAgent(),
+		Status:     status,
+	}
+
+	// route params are added to the query by the router, prefixed with ':'
+	for key, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+
+		if !strings.HasPrefix(key, ":") {
+			if event.Parameters == nil {
+				event.Parameters = map[string][]string{}
+			}
+
+			event.Parameters[key] = values
+			continue
+		}
+
+		param := strings.TrimPrefix(key, ":")
+		if param == "team_name" {
+			event.TeamName = values[0]
+			continue
+		}
+
+		if event.Target == nil {
+			event.Target = map[string]string{}
+		}
+
+		event.Target[param] = values[0]
+	}
+
+	err = a.auditEventFactory.CreateAuditEvent(event)
+	if err != nil {
+		a.logger.Error("failed-to-save-audit-event", err, lager.Data{"action": action})
+	}
+}
+
+// secretParameters are request parameters which carry credentials, so must
+// never be logged or stored with audit events.
+var secretParameters = map[string]bool{
+	"webhook_token": true,
+}
+
+func redactSecretParameters(form url.Values) url.Values {
+	redacted := url.Values{}
+	for key, values := range form {
+		if secretParameters[key] {
+			values = []string{"((redacted))"}
+		}
+
+		redacted[key] = values
 	}
+
+	return redacted
 }
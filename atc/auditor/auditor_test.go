@@ -1,15 +1,19 @@
 package auditor_test
 
 import (
+	"errors"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Audit", func() {
@@ -28,10 +32,12 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog      bool
 		EnableWorkerAuditLog    bool
 		EnableVolumeAuditLog    bool
+		auditEventFactory       db.AuditEventFactory
 	)
 
 	BeforeEach(func() {
 		userName = "test"
+		auditEventFactory = nil
 
 		var err error
 		req, err = http.NewRequest("GET", "localhost:8080", nil)
@@ -51,6 +57,7 @@ var _ = Describe("Audit", func() {
 			EnableTeamAuditLog,
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
+			auditEventFactory,
 			logger,
 		)
 	})
@@ -80,13 +87,91 @@ var _ = Describe("Audit", func() {
 		})
 		It("all routes are handled and does not panic", func() {
 			for _, route := range atc.Routes {
-				aud.Audit(route.Name, userName, req)
+				aud.Audit(route.Name, auditor.Actor{UserName: userName}, req, http.StatusOK)
 			}
 			logs := logger.Logs()
 			Expect(len(logs)).ToNot(Equal(0))
 		})
 	})
 
+	Describe("persistence", func() {
+		var fakeAuditEventFactory *dbfakes.FakeAuditEventFactory
+
+		BeforeEach(func() {
+			fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+			auditEventFactory = fakeAuditEventFactory
+
+			var err error
+			req, err = http.NewRequest("PUT", "http://localhost:8080/api/v1/teams/some-team/pipelines/some-pipeline/config?:team_name=some-team&:pipeline_name=some-pipeline&check_creds=true", http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.RemoteAddr = "1.2.3.4:5678"
+			req.Header.Set("User-Agent", "fly/7.4.0")
+		})
+
+		Context("when the action is audited", func() {
+			BeforeEach(func() {
+				EnableSystemAuditLog = true
+			})
+
+			It("saves an event describing the request and its result", func() {
+				aud.Audit(atc.SaveConfig, auditor.Actor{UserName: userName, Connector: "github"}, req, http.StatusForbidden)
+
+				Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(Equal(1))
+				Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+					Action:     atc.SaveConfig,
+					UserName:   "test",
+					Connector:  "github",
+					TeamName:   "some-team",
+					Target:     map[string]string{"pipeline_name": "some-pipeline"},
+					Method:     "PUT",
+					Path:       "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+					RemoteAddr: "1.2.3.4:5678",
+					UserAgent:  "fly/7.4.0",
+					Parameters: map[string][]string{"check_creds": {"true"}},
+					Status:     http.StatusForbidden,
+				}))
+			})
+
+			Context("when the request carries a webhook token", func() {
+				BeforeEach(func() {
+					EnableResourceAuditLog = true
+
+					var err error
+					req, err = http.NewRequest("POST", "http://localhost:8080/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook?:team_name=some-team&:pipeline_name=some-pipeline&:resource_name=some-resource&webhook_token=some-secret-token", http.NoBody)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("neither saves nor logs the token", func() {
+					aud.Audit(atc.CheckResourceWebHook, auditor.Actor{UserName: userName}, req, http.StatusCreated)
+
+					Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(Equal(1))
+					event := fakeAuditEventFactory.CreateAuditEventArgsForCall(0)
+					Expect(event.Parameters).To(Equal(map[string][]string{"webhook_token": {"((redacted))"}}))
+
+					Expect(logger.Buffer()).ToNot(gbytes.Say("some-secret-token"))
+				})
+			})
+
+			Context("when saving the event fails", func() {
+				BeforeEach(func() {
+					fakeAuditEventFactory.CreateAuditEventReturns(errors.New("nope"))
+				})
+
+				It("logs the failure", func() {
+					aud.Audit(atc.SaveConfig, auditor.Actor{UserName: userName}, req, http.StatusOK)
+					Expect(logger.LogMessages()).To(ContainElement("access_handler.failed-to-save-audit-event"))
+				})
+			})
+		})
+
+		Context("when the action is not audited", func() {
+			It("does not save an event", func() {
+				aud.Audit(atc.SaveConfig, auditor.Actor{UserName: userName}, req, http.StatusOK)
+				Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("EnableBuildAuditLog", func() {
 
 		Context("When EnableBuildAudit is false with a Build action", func() {
@@ -96,7 +181,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -110,7 +195,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -123,7 +208,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -136,7 +221,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -152,7 +237,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -166,7 +251,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -179,7 +264,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -192,7 +277,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -208,7 +293,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -222,7 +307,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -235,7 +320,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -248,7 +333,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -264,7 +349,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -278,7 +363,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -291,7 +376,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -304,7 +389,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -320,7 +405,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -334,7 +419,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -347,7 +432,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -360,7 +445,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -376,7 +461,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -390,7 +475,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -403,7 +488,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -416,7 +501,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -432,7 +517,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -446,7 +531,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -459,7 +544,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -472,7 +557,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -488,7 +573,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -502,7 +587,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -515,7 +600,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -528,7 +613,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -544,7 +629,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -558,7 +643,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -571,7 +656,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -584,7 +669,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, auditor.Actor{UserName: userName}, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -0,0 +1,14 @@
+package atc
+
+type ApproveBuildBody struct {
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type BuildApproval struct {
+	Name      string `json:"name"`
+	Approved  bool   `json:"approved"`
+	Approver  string `json:"approver"`
+	Comment   string `json:"comment,omitempty"`
+	DecidedAt int64  `json:"decided_at,omitempty"`
+}
@@ -0,0 +1,16 @@
+package atc
+
+// BuildLogMatch is a log event containing the text searched for.
+type BuildLogMatch struct {
+	BuildID              int          `json:"build_id"`
+	BuildName            string       `json:"build_name"`
+	BuildStatus          BuildStatus  `json:"build_status"`
+	JobName              string       `json:"job_name,omitempty"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	TeamName             string       `json:"team_name"`
+	EventID              int          `json:"event_id"`
+	OriginID             string       `json:"origin_id"`
+	Time                 int64        `json:"time"`
+	Line                 string       `json:"line"`
+}
@@ -66,6 +66,7 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		CacheResult:       step.CacheResult,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
@@ -81,6 +82,15 @@ func (visitor *planVisitor) VisitRun(step *atc.RunStep) error {
 
 	object := prototype.Defaults.Merge(atc.Source(step.Params))
 
+	image := atc.ImageResource{
+		Name:   prototype.Name,
+		Type:   prototype.Type,
+		Source: prototype.Source,
+		Params: prototype.Params,
+		Tags:   prototype.Tags,
+	}
+	image.ApplySourceDefaults(visitor.resourceTypes)
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.RunPlan{
 		Message:    step.Message,
 		Type:       step.Type,
@@ -89,6 +99,15 @@ func (visitor *planVisitor) VisitRun(step *atc.RunStep) error {
 		Tags:       step.Tags,
 		Limits:     step.Limits,
 		Timeout:    step.Timeout,
+
+		Inputs:            step.Inputs,
+		Outputs:           step.Outputs,
+		InputMapping:      step.InputMapping,
+		OutputMapping:     step.OutputMapping,
+		ImageArtifactName: step.ImageArtifactName,
+
+		Image:                  image,
+		VersionedResourceTypes: visitor.resourceTypes,
 	})
 
 	return nil
@@ -285,6 +304,15 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitApproval(step *atc.ApprovalStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovalPlan{
+		Name:         step.Name,
+		Instructions: step.Instructions,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	err := step.Step.Config.Visit(visitor)
 	if err != nil {
@@ -312,6 +340,20 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitIf(step *atc.IfStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.IfPlan{
+		Condition: step.Condition,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	retryStep := make(atc.RetryPlan, step.Attempts)
 
@@ -257,6 +257,7 @@ var factoryTests = []PlannerTest{
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			CacheResult:       true,
 		},
 
 		PlanJSON: `{
@@ -276,6 +277,7 @@ var factoryTests = []PlannerTest{
 				"output_mapping": {"specific": "generic"},
 				"image": "some-image",
 				"timeout": "1h",
+				"cache_result": true,
 				"resource_types": [
 					{
 						"name": "some-resource-type",
@@ -358,7 +360,12 @@ var factoryTests = []PlannerTest{
 				CPU:    newCPULimit(456),
 				Memory: newMemoryLimit(2048),
 			},
-			Timeout: "1h",
+			Timeout:           "1h",
+			Inputs:            []string{"some-input"},
+			Outputs:           []string{"some-output"},
+			InputMapping:      map[string]string{"some-input": "some-artifact"},
+			OutputMapping:     map[string]string{"some-output": "other-artifact"},
+			ImageArtifactName: "some-image",
 		},
 
 		PlanJSON: `{
@@ -374,7 +381,26 @@ var factoryTests = []PlannerTest{
 				"privileged": true,
 				"tags": ["tag-1", "tag-2"],
 				"container_limits": {"cpu": 456, "memory": 2048},
-				"timeout": "1h"
+				"timeout": "1h",
+				"inputs": ["some-input"],
+				"outputs": ["some-output"],
+				"input_mapping": {"some-input": "some-artifact"},
+				"output_mapping": {"some-output": "other-artifact"},
+				"image": "some-image",
+				"image_resource": {
+					"name": "some-prototype",
+					"type": "some-base-resource-type",
+					"source": {"some": "prototype-source", "default-key": "default-value"}
+				},
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
 			}
 		}`,
 	},
@@ -400,6 +426,22 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "approval step",
+
+		Config: &atc.ApprovalStep{
+			Name:         "deploy-to-prod",
+			Instructions: "check the staging dashboard first",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approval": {
+				"name": "deploy-to-prod",
+				"instructions": "check the staging dashboard first"
+			}
+		}`,
+	},
 	{
 		Title: "load_var step",
 
@@ -599,6 +641,31 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "if modifier",
+
+		Config: &atc.IfStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "vars.enabled",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"if": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"condition": "vars.enabled"
+			}
+		}`,
+	},
 	{
 		Title: "attempts modifier",
 
@@ -17,6 +17,10 @@ const (
 	ComponentCollectorResourceCacheUses = "collector_resource_cache_uses"
 	ComponentCollectorResourceCaches    = "collector_resource_caches"
 	ComponentCollectorResourceConfigs   = "collector_resource_configs"
+	ComponentCollectorSecretLeases      = "collector_secret_leases"
+	ComponentCollectorSecretAccesses    = "collector_secret_accesses"
+	ComponentCollectorAuditEvents       = "collector_audit_events"
+	ComponentCollectorTaskResults       = "collector_task_results"
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
@@ -11,5 +11,6 @@ import (
 //counterfeiter:generate . Compression
 type Compression interface {
 	NewReader(io.ReadCloser) (io.ReadCloser, error)
+	NewWriter(io.Writer) (io.WriteCloser, error)
 	Encoding() baggageclaim.Encoding
 }
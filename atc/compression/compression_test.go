@@ -1,6 +1,9 @@
 package compression_test
 
 import (
+	"bytes"
+	"io/ioutil"
+
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/compression"
 
@@ -13,6 +16,27 @@ var _ = Describe("Compression", func() {
 		comp compression.Compression
 	)
 
+	itRoundTrips := func() {
+		It("reads back what it wrote", func() {
+			buf := new(bytes.Buffer)
+
+			writer, err := comp.NewWriter(buf)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = writer.Write([]byte("some-content"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+
+			reader, err := comp.NewReader(ioutil.NopCloser(buf))
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := ioutil.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("some-content"))
+			Expect(reader.Close()).To(Succeed())
+		})
+	}
+
 	Describe("Gzip", func() {
 		BeforeEach(func() {
 			comp = compression.NewGzipCompression()
@@ -21,6 +45,8 @@ var _ = Describe("Compression", func() {
 		It("returns gzip", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.GzipEncoding))
 		})
+
+		itRoundTrips()
 	})
 
 	Describe("Zstd", func() {
@@ -31,5 +57,7 @@ var _ = Describe("Compression", func() {
 		It("returns zstd", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.ZstdEncoding))
 		})
+
+		itRoundTrips()
 	})
 })
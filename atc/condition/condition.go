@@ -0,0 +1,417 @@
+// Package condition implements the small expression language used by the
+// `if:` step modifier.
+//
+// An expression compares literals and references using ==, !=, <, <=, > and
+// >=, and combines them using &&, || and !. Strings are quoted with either
+// double or single quotes, and true, false and null are keywords.
+//
+// References start with one of the following roots:
+//
+//	vars.<name>[.<field>...]   a local var, e.g. one set by a load_var step
+//	build.<field>              one of job, pipeline, team or created_by
+//	steps.<name>               the status of a named step that has already
+//	                           run, e.g. "succeeded" or "failed", or null
+package condition
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Type is the static type of an expression, as far as it can be determined
+// before the build runs.
+type Type string
+
+const (
+	TypeAny    Type = "any"
+	TypeBool   Type = "boolean"
+	TypeString Type = "string"
+	TypeNumber Type = "number"
+	TypeNull   Type = "null"
+
+	// Only var values can be objects or arrays.
+	TypeObject Type = "object"
+	TypeArray  Type = "array"
+)
+
+const (
+	RootVars  = "vars"
+	RootBuild = "build"
+	RootSteps = "steps"
+)
+
+// BuildFields are the fields that can be referenced under the build root.
+var BuildFields = []string{"job", "pipeline", "team", "created_by"}
+
+// Reference is a value that is looked up when the condition is evaluated.
+type Reference struct {
+	Root string
+	Path []string
+}
+
+func (ref Reference) String() string {
+	return strings.Join(append([]string{ref.Root}, ref.Path...), ".")
+}
+
+// Resolver looks up the values of references during evaluation. It must
+// return false if the value is not set.
+type Resolver interface {
+	Resolve(Reference) (interface{}, bool, error)
+}
+
+// Condition is a parsed expression.
+type Condition struct {
+	source string
+	root   node
+}
+
+// Parse parses the expression, returning an error if it is syntactically
+// invalid. Use Check to determine whether it is also well-typed.
+func Parse(source string) (Condition, error) {
+	p := &parser{lexer: newLexer(source)}
+
+	root, err := p.parse()
+	if err != nil {
+		return Condition{}, fmt.Errorf("invalid condition '%s': %w", source, err)
+	}
+
+	return Condition{
+		source: source,
+		root:   root,
+	}, nil
+}
+
+func (c Condition) String() string {
+	return c.source
+}
+
+// Check type-checks the condition, returning an error if it refers to
+// unknown values, performs an operation on values of the wrong type, or does
+// not evaluate to a boolean.
+func (c Condition) Check() error {
+	typ, err := c.root.check()
+	if err != nil {
+		return fmt.Errorf("invalid condition '%s': %w", c.source, err)
+	}
+
+	if typ != TypeBool && typ != TypeAny {
+		return fmt.Errorf("invalid condition '%s': must be a boolean expression, not %s", c.source, typ.describe())
+	}
+
+	return nil
+}
+
+// Evaluate evaluates the condition, resolving any references using the given
+// Resolver.
+func (c Condition) Evaluate(resolver Resolver) (bool, error) {
+	val, err := c.root.eval(resolver)
+	if err != nil {
+		return false, fmt.Errorf("evaluate condition '%s': %w", c.source, err)
+	}
+
+	result, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("evaluate condition '%s': must evaluate to a boolean, not %s", c.source, typeOf(val).describe())
+	}
+
+	return result, nil
+}
+
+type node interface {
+	check() (Type, error)
+	eval(Resolver) (interface{}, error)
+}
+
+type literal struct {
+	val interface{}
+}
+
+func (n literal) check() (Type, error) {
+	return typeOf(n.val), nil
+}
+
+func (n literal) eval(Resolver) (interface{}, error) {
+	return n.val, nil
+}
+
+type reference struct {
+	ref Reference
+}
+
+func (n reference) check() (Type, error) {
+	switch n.ref.Root {
+	case RootVars:
+		if len(n.ref.Path) == 0 {
+			return "", fmt.Errorf("'%s' must be followed by a var name", RootVars)
+		}
+
+		return TypeAny, nil
+
+	case RootBuild:
+		if len(n.ref.Path) != 1 {
+			return "", fmt.Errorf("'%s' must be followed by one of %s", RootBuild, strings.Join(BuildFields, ", "))
+		}
+
+		for _, field := range BuildFields {
+			if n.ref.Path[0] == field {
+				return TypeString, nil
+			}
+		}
+
+		return "", fmt.Errorf("unknown build field '%s'; expected one of %s", n.ref.Path[0], strings.Join(BuildFields, ", "))
+
+	case RootSteps:
+		if len(n.ref.Path) != 1 {
+			return "", fmt.Errorf("'%s' must be followed by a step name", RootSteps)
+		}
+
+		return TypeString, nil
+
+	default:
+		return "", fmt.Errorf("unknown reference '%s'; must start with %s, %s or %s", n.ref, RootVars, RootBuild, RootSteps)
+	}
+}
+
+func (n reference) eval(resolver Resolver) (interface{}, error) {
+	if _, err := n.check(); err != nil {
+		return nil, err
+	}
+
+	val, found, err := resolver.Resolve(n.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		if n.ref.Root == RootVars {
+			return nil, fmt.Errorf("undefined var '%s'", strings.Join(n.ref.Path, "."))
+		}
+
+		return nil, nil
+	}
+
+	return normalize(val), nil
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) check() (Type, error) {
+	typ, err := n.operand.check()
+	if err != nil {
+		return "", err
+	}
+
+	if typ != TypeBool && typ != TypeAny {
+		return "", fmt.Errorf("cannot negate %s", typ.describe())
+	}
+
+	return TypeBool, nil
+}
+
+func (n not) eval(resolver Resolver) (interface{}, error) {
+	val, err := n.operand.eval(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := val.(bool)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate %s", typeOf(val).describe())
+	}
+
+	return !b, nil
+}
+
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (n logical) check() (Type, error) {
+	for _, operand := range []node{n.left, n.right} {
+		typ, err := operand.check()
+		if err != nil {
+			return "", err
+		}
+
+		if typ != TypeBool && typ != TypeAny {
+			return "", fmt.Errorf("operands of %s must be booleans, not %s", n.op, typ.describe())
+		}
+	}
+
+	return TypeBool, nil
+}
+
+func (n logical) eval(resolver Resolver) (interface{}, error) {
+	left, err := n.evalOperand(n.left, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	// short-circuit, so that e.g. a var can be checked before it is used
+	if (n.op == "&&" && !left) || (n.op == "||" && left) {
+		return left, nil
+	}
+
+	return n.evalOperand(n.right, resolver)
+}
+
+func (n logical) evalOperand(operand node, resolver Resolver) (bool, error) {
+	val, err := operand.eval(resolver)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("operands of %s must be booleans, not %s", n.op, typeOf(val).describe())
+	}
+
+	return b, nil
+}
+
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (n comparison) check() (Type, error) {
+	left, err := n.left.check()
+	if err != nil {
+		return "", err
+	}
+
+	right, err := n.right.check()
+	if err != nil {
+		return "", err
+	}
+
+	if n.op == "==" || n.op == "!=" {
+		if left != TypeAny && right != TypeAny && left != TypeNull && right != TypeNull && left != right {
+			return "", fmt.Errorf("cannot compare %s with %s", left.describe(), right.describe())
+		}
+
+		return TypeBool, nil
+	}
+
+	for _, typ := range []Type{left, right} {
+		if typ != TypeNumber && typ != TypeAny {
+			return "", fmt.Errorf("operands of %s must be numbers, not %s", n.op, typ.describe())
+		}
+	}
+
+	return TypeBool, nil
+}
+
+func (n comparison) eval(resolver Resolver) (interface{}, error) {
+	left, err := n.left.eval(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("operands of %s must be numbers, not %s and %s", n.op, typeOf(left).describe(), typeOf(right).describe())
+	}
+
+	switch n.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	default:
+		return l >= r, nil
+	}
+}
+
+// normalize converts the numeric types that vars may be decoded as to
+// float64, so that they can be compared with number literals.
+func normalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+		return f
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			normalized[k] = normalize(e)
+		}
+		return normalized
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			normalized[fmt.Sprint(k)] = normalize(e)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, e := range v {
+			normalized[i] = normalize(e)
+		}
+		return normalized
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	default:
+		return val
+	}
+}
+
+func (t Type) describe() string {
+	switch t {
+	case TypeNull:
+		return "null"
+	case TypeAny, TypeArray, TypeObject:
+		return "an " + string(t)
+	default:
+		return "a " + string(t)
+	}
+}
+
+func typeOf(val interface{}) Type {
+	switch val.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBool
+	case string:
+		return TypeString
+	case float64:
+		return TypeNumber
+	case map[string]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	default:
+		return TypeAny
+	}
+}
@@ -0,0 +1,180 @@
+package condition_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/condition"
+)
+
+type staticResolver map[string]interface{}
+
+func (r staticResolver) Resolve(ref condition.Reference) (interface{}, bool, error) {
+	val, found := r[ref.String()]
+	return val, found, nil
+}
+
+var _ = Describe("Condition", func() {
+	resolver := staticResolver{
+		"vars.version":         map[string]interface{}{"major": 2, "tag": "rc"},
+		"vars.enabled":         true,
+		"vars.count":           3.0,
+		"build.job":            "deploy",
+		"build.pipeline":       "main",
+		"build.team":           "main",
+		"build.created_by":     "some-user",
+		"steps.unit":           "succeeded",
+		"steps.integration":    "failed",
+		"vars.version.major":   2,
+		"vars.version.tag":     "rc",
+		"vars.with-dashes":     "ok",
+		"vars.nested.list":     []interface{}{1, "two"},
+		"vars.nested":          map[string]interface{}{"list": []interface{}{1, "two"}},
+		"vars.some-empty-var":  "",
+		"vars.some-null-value": nil,
+	}
+
+	DescribeTable("Evaluate",
+		func(source string, expected bool) {
+			cond, err := condition.Parse(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cond.Check()).To(Succeed())
+
+			result, err := cond.Evaluate(resolver)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("literal true", "true", true),
+		Entry("literal false", "false", false),
+		Entry("bool var", "vars.enabled", true),
+		Entry("negation", "!vars.enabled", false),
+		Entry("string equality", `build.job == "deploy"`, true),
+		Entry("single-quoted string", `build.job == 'deploy'`, true),
+		Entry("string inequality", `build.team != "main"`, false),
+		Entry("escaped quote", `"a\"b" == 'a"b'`, true),
+		Entry("number from int var", "vars.version.major == 2", true),
+		Entry("number comparison", "vars.count > 2.5", true),
+		Entry("negative number", "vars.count >= -1", true),
+		Entry("less than", "vars.count < 3", false),
+		Entry("less than or equal", "vars.count <= 3", true),
+		Entry("step status", `steps.unit == "succeeded"`, true),
+		Entry("step that has not run", "steps.e2e == null", true),
+		Entry("dashes in names", `vars.with-dashes == "ok"`, true),
+		Entry("null var", "vars.some-null-value == null", true),
+		Entry("and", `build.job == "deploy" && steps.integration == "failed"`, true),
+		Entry("or", `build.job == "test" || vars.enabled`, true),
+		Entry("precedence of && over ||", "true || false && false", true),
+		Entry("parentheses", "(true || false) && false", false),
+		Entry("short-circuits &&", "false && vars.undefined", false),
+		Entry("short-circuits ||", "true || vars.undefined", true),
+		Entry("deep equality", "vars.nested.list == vars.nested.list", true),
+	)
+
+	DescribeTable("numeric vars",
+		func(value interface{}) {
+			cond, err := condition.Parse("vars.n > 2 && vars.n <= 3 && vars.n == 3")
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := cond.Evaluate(staticResolver{"vars.n": value})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeTrue())
+		},
+		Entry("int", int(3)),
+		Entry("int8", int8(3)),
+		Entry("int16", int16(3)),
+		Entry("int32", int32(3)),
+		Entry("int64", int64(3)),
+		Entry("uint", uint(3)),
+		Entry("uint8", uint8(3)),
+		Entry("uint16", uint16(3)),
+		Entry("uint32", uint32(3)),
+		Entry("uint64", uint64(3)),
+		Entry("float32", float32(3)),
+		Entry("float64", float64(3)),
+		Entry("json.Number", json.Number("3")),
+	)
+
+	It("normalizes numbers nested within vars", func() {
+		cond, err := condition.Parse("vars.a == vars.b")
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := cond.Evaluate(staticResolver{
+			"vars.a": map[string]interface{}{"n": []interface{}{int32(3)}},
+			"vars.b": map[interface{}]interface{}{"n": []interface{}{json.Number("3")}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeTrue())
+	})
+
+	DescribeTable("Parse errors",
+		func(source string, message string) {
+			_, err := condition.Parse(source)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("empty", "", "unexpected end of condition"),
+		Entry("trailing operator", "true &&", "unexpected end of condition"),
+		Entry("unbalanced parentheses", "(true", "expected ')' but found end of condition"),
+		Entry("unterminated string", `build.job == "deploy`, "unterminated string at position 14"),
+		Entry("unknown character", "build.job = 'x'", "unexpected character '=' at position 11"),
+		Entry("dangling dot", "vars.", "expected a field name after '.' but found end of condition"),
+		Entry("chained comparison", "1 < 2 < 3", "unexpected '<' at position 7"),
+		Entry("invalid number", "1.2.3 == 1", "invalid number '1.2.3' at position 1"),
+	)
+
+	DescribeTable("Check errors",
+		func(source string, message string) {
+			cond, err := condition.Parse(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cond.Check()).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("non-boolean", `"yes"`, "must be a boolean expression, not a string"),
+		Entry("unknown root", "foo.bar", "unknown reference 'foo.bar'; must start with vars, build or steps"),
+		Entry("unknown build field", "build.status == 'x'", "unknown build field 'status'; expected one of job, pipeline, team, created_by"),
+		Entry("nested build field", "build.job.name == 'x'", "'build' must be followed by one of job, pipeline, team, created_by"),
+		Entry("bare vars", "vars", "'vars' must be followed by a var name"),
+		Entry("nested step field", "steps.unit.status == 'x'", "'steps' must be followed by a step name"),
+		Entry("mismatched comparison", "build.job == 1", "cannot compare a string with a number"),
+		Entry("ordering strings", "build.job > 'a'", "operands of > must be numbers, not a string"),
+		Entry("string operand of &&", "build.job && true", "operands of && must be booleans, not a string"),
+		Entry("negating a number", "!1", "cannot negate a number"),
+	)
+
+	DescribeTable("Evaluate errors",
+		func(source string, message string) {
+			cond, err := condition.Parse(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = cond.Evaluate(resolver)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("undefined var", "vars.undefined", "undefined var 'undefined'"),
+		Entry("non-boolean var", "vars.count", "must evaluate to a boolean, not a number"),
+		Entry("ordering a string var", "vars.version.tag > 1", "operands of > must be numbers, not a string and a number"),
+		Entry("negating an object var", "!vars.version", "cannot negate an object"),
+		Entry("string operand of ||", "vars.some-empty-var || true", "operands of || must be booleans, not a string"),
+	)
+
+	Context("when resolving fails", func() {
+		It("returns the error", func() {
+			cond, err := condition.Parse("vars.enabled")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = cond.Evaluate(failingResolver{errors.New("nope")})
+			Expect(err).To(MatchError("evaluate condition 'vars.enabled': nope"))
+		})
+	})
+})
+
+type failingResolver struct {
+	err error
+}
+
+func (r failingResolver) Resolve(condition.Reference) (interface{}, bool, error) {
+	return nil, false, r.err
+}
@@ -0,0 +1,359 @@
+package condition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenDot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of condition"
+	}
+
+	return fmt.Sprintf("'%s' at position %d", t.text, t.pos+1)
+}
+
+type lexer struct {
+	source string
+	pos    int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: source}
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!"}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.source) && unicode.IsSpace(rune(l.source[l.pos])) {
+		l.pos++
+	}
+
+	start := l.pos
+	if start >= len(l.source) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	rest := l.source[start:]
+	c := rest[0]
+
+	switch {
+	case c == '.':
+		l.pos++
+		return token{kind: tokenDot, text: ".", pos: start}, nil
+
+	case c == '(':
+		l.pos++
+		return token{kind: tokenLeftParen, text: "(", pos: start}, nil
+
+	case c == ')':
+		l.pos++
+		return token{kind: tokenRightParen, text: ")", pos: start}, nil
+
+	case c == '"' || c == '\'':
+		return l.string(c)
+
+	case isDigit(c) || (c == '-' && len(rest) > 1 && isDigit(rest[1])):
+		l.pos++
+		for l.pos < len(l.source) && (isDigit(l.source[l.pos]) || l.source[l.pos] == '.') {
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: l.source[start:l.pos], pos: start}, nil
+
+	case isIdentStart(c):
+		for l.pos < len(l.source) && isIdentPart(l.source[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.source[start:l.pos], pos: start}, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			l.pos += len(op)
+			return token{kind: tokenOperator, text: op, pos: start}, nil
+		}
+	}
+
+	return token{}, fmt.Errorf("unexpected character '%c' at position %d", c, start+1)
+}
+
+func (l *lexer) string(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+
+	var value strings.Builder
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		switch c {
+		case quote:
+			l.pos++
+			return token{kind: tokenString, text: value.String(), pos: start}, nil
+		case '\\':
+			if l.pos+1 >= len(l.source) {
+				break
+			}
+			l.pos++
+			value.WriteByte(l.source[l.pos])
+		default:
+			value.WriteByte(c)
+		}
+		l.pos++
+	}
+
+	return token{}, fmt.Errorf("unterminated string at position %d", start+1)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '-'
+}
+
+// parser is a recursive descent parser for the following grammar, listed in
+// order of increasing precedence:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand ]
+//	operand    = literal | reference | "(" or ")"
+//	reference  = ident { "." ident }
+type parser struct {
+	lexer *lexer
+
+	current token
+	peeked  bool
+}
+
+func (p *parser) peek() (token, error) {
+	if !p.peeked {
+		tok, err := p.lexer.next()
+		if err != nil {
+			return token{}, err
+		}
+
+		p.current = tok
+		p.peeked = true
+	}
+
+	return p.current, nil
+}
+
+func (p *parser) advance() (token, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return token{}, err
+	}
+
+	p.peeked = false
+	return tok, nil
+}
+
+func (p *parser) parse() (node, error) {
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s", tok)
+	}
+
+	return root, nil
+}
+
+func (p *parser) or() (node, error) {
+	return p.logical("||", p.and)
+}
+
+func (p *parser) and() (node, error) {
+	return p.logical("&&", p.unary)
+}
+
+func (p *parser) logical(op string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok.kind != tokenOperator || tok.text != op {
+			return left, nil
+		}
+
+		p.advance()
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logical{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.kind == tokenOperator && tok.text == "!" {
+		p.advance()
+
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return not{operand: operand}, nil
+	}
+
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		if tok.kind != tokenOperator {
+			return left, nil
+		}
+	default:
+		return left, nil
+	}
+
+	p.advance()
+
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	return comparison{op: tok.text, left: left, right: right}, nil
+}
+
+func (p *parser) operand() (node, error) {
+	tok, err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok.kind {
+	case tokenString:
+		return literal{val: tok.text}, nil
+
+	case tokenNumber:
+		num, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", tok)
+		}
+
+		return literal{val: num}, nil
+
+	case tokenLeftParen:
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		closing, err := p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected ')' but found %s", closing)
+		}
+
+		return inner, nil
+
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return literal{val: true}, nil
+		case "false":
+			return literal{val: false}, nil
+		case "null":
+			return literal{val: nil}, nil
+		}
+
+		return p.reference(tok)
+	}
+
+	return nil, fmt.Errorf("unexpected %s", tok)
+}
+
+func (p *parser) reference(root token) (node, error) {
+	ref := Reference{Root: root.text}
+
+	for {
+		tok, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok.kind != tokenDot {
+			return reference{ref: ref}, nil
+		}
+
+		p.advance()
+
+		field, err := p.advance()
+		if err != nil {
+			return nil, err
+		}
+
+		if field.kind != tokenIdent {
+			return nil, fmt.Errorf("expected a field name after '.' but found %s", field)
+		}
+
+		ref.Path = append(ref.Path, field.text)
+	}
+}
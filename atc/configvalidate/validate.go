@@ -389,6 +389,12 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 		}
 
+		if job.Notifications != nil {
+			if err := job.Notifications.Validate(); err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.%s", identifier, err))
+			}
+		}
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -1756,6 +1756,65 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an unparseable condition in a step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.IfStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: `build.job == "deploy`,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.plan.do[0].if: invalid condition 'build.job == "deploy': unterminated string at position 14`))
+				})
+			})
+
+			Context("when a plan has an ill-typed condition in a step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.IfStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: "build.job > 1",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].if: invalid condition 'build.job > 1': operands of > must be numbers, not a string"))
+				})
+			})
+
+			Context("when a plan has a valid condition in a step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.IfStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: `vars.version.major >= 2 && steps.unit == "succeeded"`,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
 			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1887,6 +1946,61 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed_any constraints reference a bogus job", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:      "some-resource",
+							PassedAny: []string{"some-job", "bogus-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).passed_any: unknown job 'bogus-job'"))
+				})
+			})
+
+			Context("when a job's input's passed_any constraints references a valid job that does not have the resource as an input or output", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:      "some-resource",
+							PassedAny: []string{"some-empty-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).passed_any: job 'some-empty-job' does not interact with resource 'some-resource'"))
+				})
+			})
+
+			Context("when a job's input lists the same job in passed and passed_any", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:      "some-resource",
+							Passed:    []string{"some-job"},
+							PassedAny: []string{"some-job"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).passed_any: job 'some-job' is also listed in passed"))
+				})
+			})
+
 			Context("when a load_var has no name or file defined", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1926,6 +2040,74 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when two approval steps have the same name", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name: "deploy",
+						},
+					}, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name: "deploy",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].approval(deploy): repeated name"))
+				})
+			})
+
+			Context("when an approval step is within an across step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.ApprovalStep{
+								Name: "deploy",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "env",
+									Values: []interface{}{"staging", "production"},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across.approval(deploy): cannot be used within `across` or `attempts`"))
+				})
+			})
+
+			Context("when an approval step is retried", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.RetryStep{
+							Step: &atc.DoStep{
+								Steps: []atc.Step{
+									{Config: &atc.ApprovalStep{Name: "deploy"}},
+								},
+							},
+							Attempts: 3,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("approval(deploy): cannot be used within `across` or `attempts`"))
+				})
+			})
+
 			Context("when a step has unknown fields", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -2176,6 +2358,21 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has an invalid notification rule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Notifications = &atc.NotificationRules{
+					{URL: "https://example.com/hook", Events: []atc.BuildStatus{"failed"}},
+					{URL: "example.com/hook"},
+					{URL: "https://example.com/hook", Events: []atc.BuildStatus{"pending"}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.notifications[1]: invalid url 'example.com/hook': must be an absolute http or https url"))
+			})
+		})
+
 		Context("when a job has negative build_log_retention values", func() {
 			BeforeEach(func() {
 				config.Jobs[0].BuildLogRetention = &atc.BuildLogRetention{
@@ -0,0 +1,169 @@
+package azurekeyvault_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAzureKeyVault(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Azure Key Vault Suite")
+}
+
+type stubSecret struct {
+	Value       string
+	ContentType string
+	Expires     *int64
+}
+
+// keyVaultStub stands in for both Azure AD, issuing tokens, and the Key
+// Vault REST API, serving the secrets it is given to holders of those
+// tokens.
+type keyVaultStub struct {
+	*httptest.Server
+
+	lock          sync.Mutex
+	secrets       map[string]stubSecret
+	tokenRequests []*http.Request
+	tokenForms    []map[string][]string
+	failSecrets   bool
+}
+
+func newKeyVaultStub() *keyVaultStub {
+	stub := &keyVaultStub{
+		secrets: map[string]stubSecret{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/some-tenant/oauth2/v2.0/token", stub.issueToken)
+	mux.HandleFunc("/metadata/identity/oauth2/token", stub.issueManagedIdentityToken)
+	mux.HandleFunc("/secrets/", stub.getSecret)
+
+	stub.Server = httptest.NewServer(mux)
+
+	return stub
+}
+
+func (stub *keyVaultStub) setSecret(name string, secret stubSecret) {
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+
+	stub.secrets[name] = secret
+}
+
+func (stub *keyVaultStub) tokensIssued() int {
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+
+	return len(stub.tokenRequests)
+}
+
+func (stub *keyVaultStub) issueToken(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+
+	Expect(r.Method).To(Equal("POST"))
+	Expect(r.ParseForm()).To(Succeed())
+
+	stub.lock.Lock()
+	stub.tokenRequests = append(stub.tokenRequests, r)
+	stub.tokenForms = append(stub.tokenForms, r.PostForm)
+	stub.lock.Unlock()
+
+	if r.PostForm.Get("client_secret") == "wrong-secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error":             "invalid_client",
+			"error_description": "Invalid client secret provided.",
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"token_type":   "Bearer",
+		"access_token": "some-access-token",
+		"expires_in":   3599,
+	})
+}
+
+func (stub *keyVaultStub) issueManagedIdentityToken(w http.ResponseWriter, r *http.Request) {
+	stub.lock.Lock()
+	stub.tokenRequests = append(stub.tokenRequests, r)
+	stub.lock.Unlock()
+
+	if r.Header.Get("Metadata") != "true" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// the metadata service returns numbers as strings
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"token_type":   "Bearer",
+		"access_token": "some-access-token",
+		"expires_in":   "3599",
+		"resource":     r.URL.Query().Get("resource"),
+	})
+}
+
+func (stub *keyVaultStub) getSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer some-access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{
+				"code":    "Unauthorized",
+				"message": "AKV10000: Request is missing a Bearer or PoP token.",
+			},
+		})
+		return
+	}
+
+	if stub.failSecrets {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{
+				"code":    "Forbidden",
+				"message": "The user does not have secrets get permission.",
+			},
+		})
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/secrets/")
+
+	stub.lock.Lock()
+	secret, found := stub.secrets[name]
+	stub.lock.Unlock()
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{
+				"code":    "SecretNotFound",
+				"message": "A secret with (name/id) " + name + " was not found in this key vault.",
+			},
+		})
+		return
+	}
+
+	attributes := map[string]interface{}{
+		"enabled": true,
+	}
+
+	if secret.Expires != nil {
+		attributes["exp"] = *secret.Expires
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"value":       secret.Value,
+		"contentType": secret.ContentType,
+		"id":          stub.URL + "/secrets/" + name + "/some-version",
+		"attributes":  attributes,
+	})
+}
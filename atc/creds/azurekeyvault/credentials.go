@@ -0,0 +1,302 @@
+package azurekeyvault
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// tokens are refreshed this long before they expire, so that a request is
+// never sent with a token which expires on the way
+const tokenExpiryMargin = 5 * time.Minute
+
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// A Credential obtains access tokens for the Key Vault API from Azure AD.
+type Credential interface {
+	Token() (string, error)
+}
+
+type token struct {
+	AccessToken string      `json:"access_token"`
+	ExpiresIn   lenientUint `json:"expires_in"`
+}
+
+// lenientUint accepts both numbers and strings, as the managed identity
+// endpoint returns the token lifetime as a string while Azure AD returns it
+// as a number.
+type lenientUint uint64
+
+func (u *lenientUint) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid token lifetime %s: %w", data, err)
+	}
+
+	*u = lenientUint(value)
+
+	return nil
+}
+
+// cachedCredential reuses a token until shortly before it expires.
+type cachedCredential struct {
+	fetch func() (token, error)
+
+	lock      sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (c *cachedCredential) Token() (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token != "" && time.Now().Before(c.expiresAt) {
+		return c.token, nil
+	}
+
+	fetched, err := c.fetch()
+	if err != nil {
+		return "", err
+	}
+
+	c.token = fetched.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(fetched.ExpiresIn)*time.Second - tokenExpiryMargin)
+
+	return c.token, nil
+}
+
+// NewManagedIdentityCredential obtains tokens for the identity assigned to
+// the VM the ATC runs on through the instance metadata service. The clientID
+// selects a user-assigned identity, and may be empty when the VM only has a
+// system-assigned identity.
+func NewManagedIdentityCredential(client *http.Client, endpoint string, resource string, clientID string) Credential {
+	return &cachedCredential{
+		fetch: func() (token, error) {
+			query := url.Values{
+				"api-version": {"2018-02-01"},
+				"resource":    {resource},
+			}
+
+			if clientID != "" {
+				query.Set("client_id", clientID)
+			}
+
+			req, err := http.NewRequest("GET", endpoint+"?"+query.Encode(), nil)
+			if err != nil {
+				return token{}, err
+			}
+
+			req.Header.Set("Metadata", "true")
+
+			return requestToken(client, req)
+		},
+	}
+}
+
+// NewClientSecretCredential obtains tokens for a service principal
+// authenticating with a client secret.
+func NewClientSecretCredential(client *http.Client, authorityHost string, tenantID string, clientID string, clientSecret string, resource string) Credential {
+	tokenURL := tokenEndpoint(authorityHost, tenantID)
+
+	return &cachedCredential{
+		fetch: func() (token, error) {
+			return requestClientCredentials(client, tokenURL, url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {clientID},
+				"client_secret": {clientSecret},
+				"scope":         {scope(resource)},
+			})
+		},
+	}
+}
+
+// NewClientCertificateCredential obtains tokens for a service principal
+// authenticating with a certificate, given as PEM containing both the
+// certificate and its RSA private key.
+func NewClientCertificateCredential(client *http.Client, authorityHost string, tenantID string, clientID string, certificatePEM []byte, resource string) (Credential, error) {
+	cert, key, err := parseCertificate(certificatePEM)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbprint := sha1.Sum(cert.Raw)
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).
+			WithType("JWT").
+			WithHeader("x5t", base64.RawURLEncoding.EncodeToString(thumbprint[:])),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURL := tokenEndpoint(authorityHost, tenantID)
+
+	return &cachedCredential{
+		fetch: func() (token, error) {
+			assertion, err := clientAssertion(signer, tokenURL, clientID)
+			if err != nil {
+				return token{}, err
+			}
+
+			return requestClientCredentials(client, tokenURL, url.Values{
+				"grant_type":            {"client_credentials"},
+				"client_id":             {clientID},
+				"client_assertion_type": {clientAssertionType},
+				"client_assertion":      {assertion},
+				"scope":                 {scope(resource)},
+			})
+		},
+	}, nil
+}
+
+func clientAssertion(signer jose.Signer, tokenURL string, clientID string) (string, error) {
+	jti := make([]byte, 16)
+	_, err := rand.Read(jti)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	return jwt.Signed(signer).Claims(jwt.Claims{
+		ID:        hex.EncodeToString(jti),
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  jwt.Audience{tokenURL},
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(now.Add(10 * time.Minute)),
+	}).CompactSerialize()
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, crypto.Signer, error) {
+	var cert *x509.Certificate
+	var key crypto.Signer
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			if cert != nil {
+				continue
+			}
+
+			parsed, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parse client certificate: %w", err)
+			}
+
+			cert = parsed
+
+		case "RSA PRIVATE KEY":
+			parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parse client certificate key: %w", err)
+			}
+
+			key = parsed
+
+		case "PRIVATE KEY":
+			parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parse client certificate key: %w", err)
+			}
+
+			rsaKey, ok := parsed.(*rsa.PrivateKey)
+			if !ok {
+				return nil, nil, errors.New("client certificate key must be an RSA key")
+			}
+
+			key = rsaKey
+		}
+	}
+
+	if cert == nil {
+		return nil, nil, errors.New("no certificate found in client certificate file")
+	}
+
+	if key == nil {
+		return nil, nil, errors.New("no private key found in client certificate file")
+	}
+
+	return cert, key, nil
+}
+
+func tokenEndpoint(authorityHost string, tenantID string) string {
+	return strings.TrimSuffix(authorityHost, "/") + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+}
+
+func scope(resource string) string {
+	return strings.TrimSuffix(resource, "/") + "/.default"
+}
+
+func requestClientCredentials(client *http.Client, tokenURL string, form url.Values) (token, error) {
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return token{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return requestToken(client, req)
+}
+
+func requestToken(client *http.Client, req *http.Request) (token, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return token{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+
+		if errResp.Error != "" {
+			return token{}, fmt.Errorf("get azure access token: %s: %s", errResp.Error, errResp.ErrorDescription)
+		}
+
+		return token{}, fmt.Errorf("get azure access token: unexpected response: %s", resp.Status)
+	}
+
+	var tok token
+	err = json.NewDecoder(resp.Body).Decode(&tok)
+	if err != nil {
+		return token{}, fmt.Errorf("decode azure access token: %w", err)
+	}
+
+	if tok.AccessToken == "" {
+		return token{}, errors.New("get azure access token: no access token in response")
+	}
+
+	return tok, nil
+}
@@ -0,0 +1,134 @@
+package azurekeyvault_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	. "github.com/concourse/concourse/atc/creds/azurekeyvault"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credentials", func() {
+	var stub *keyVaultStub
+
+	BeforeEach(func() {
+		stub = newKeyVaultStub()
+	})
+
+	AfterEach(func() {
+		stub.Close()
+	})
+
+	Describe("NewManagedIdentityCredential", func() {
+		It("gets tokens from the instance metadata service", func() {
+			credential := NewManagedIdentityCredential(http.DefaultClient, stub.URL+"/metadata/identity/oauth2/token", "https://vault.azure.net", "some-identity")
+
+			token, err := credential.Token()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("some-access-token"))
+
+			Expect(stub.tokensIssued()).To(Equal(1))
+
+			query := stub.tokenRequests[0].URL.Query()
+			Expect(query.Get("resource")).To(Equal("https://vault.azure.net"))
+			Expect(query.Get("client_id")).To(Equal("some-identity"))
+			Expect(query.Get("api-version")).To(Equal("2018-02-01"))
+		})
+
+		It("leaves out the client id for system-assigned identities", func() {
+			credential := NewManagedIdentityCredential(http.DefaultClient, stub.URL+"/metadata/identity/oauth2/token", "https://vault.azure.net", "")
+
+			_, err := credential.Token()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stub.tokenRequests[0].URL.Query()).NotTo(HaveKey("client_id"))
+		})
+	})
+
+	Describe("NewClientCertificateCredential", func() {
+		var (
+			key  *rsa.PrivateKey
+			cert []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "concourse"},
+				NotBefore:    time.Now().Add(-time.Hour),
+				NotAfter:     time.Now().Add(time.Hour),
+			}
+
+			cert, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("authenticates with an assertion signed by the certificate's key", func() {
+			certificatePEM := append(
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+				pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})...,
+			)
+
+			credential, err := NewClientCertificateCredential(http.DefaultClient, stub.URL, "some-tenant", "some-client", certificatePEM, "https://vault.azure.net")
+			Expect(err).NotTo(HaveOccurred())
+
+			token, err := credential.Token()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("some-access-token"))
+
+			form := stub.tokenForms[0]
+			Expect(form["client_id"]).To(Equal([]string{"some-client"}))
+			Expect(form["client_assertion_type"]).To(Equal([]string{"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"}))
+			Expect(form["scope"]).To(Equal([]string{"https://vault.azure.net/.default"}))
+			Expect(form).NotTo(HaveKey("client_secret"))
+
+			assertion, err := jwt.ParseSigned(form["client_assertion"][0])
+			Expect(err).NotTo(HaveOccurred())
+
+			thumbprint := sha1.Sum(cert)
+			Expect(assertion.Headers[0].ExtraHeaders).To(HaveKeyWithValue(BeEquivalentTo("x5t"), base64.RawURLEncoding.EncodeToString(thumbprint[:])))
+
+			var claims jwt.Claims
+			Expect(assertion.Claims(&key.PublicKey, &claims)).To(Succeed())
+			Expect(claims.Issuer).To(Equal("some-client"))
+			Expect(claims.Subject).To(Equal("some-client"))
+			Expect(claims.Audience).To(ConsistOf(stub.URL + "/some-tenant/oauth2/v2.0/token"))
+			Expect(claims.ID).NotTo(BeEmpty())
+		})
+
+		It("accepts PKCS#8 keys", func() {
+			pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			certificatePEM := append(
+				pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})...,
+			)
+
+			_, err = NewClientCertificateCredential(http.DefaultClient, stub.URL, "some-tenant", "some-client", certificatePEM, "https://vault.azure.net")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("errors when the key is missing", func() {
+			certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+
+			_, err := NewClientCertificateCredential(http.DefaultClient, stub.URL, "some-tenant", "some-client", certificatePEM, "https://vault.azure.net")
+			Expect(err).To(MatchError("no private key found in client certificate file"))
+		})
+	})
+})
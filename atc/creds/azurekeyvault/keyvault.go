@@ -0,0 +1,188 @@
+package azurekeyvault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+const APIVersion = "7.2"
+
+// Key Vault secret names may only contain alphanumerics and dashes
+var invalidSecretNameChars = regexp.MustCompile(`[^0-9A-Za-z-]`)
+
+type KeyVault struct {
+	log             lager.Logger
+	client          *http.Client
+	vaultURL        string
+	credential      Credential
+	secretTemplates []*creds.SecretTemplate
+}
+
+func NewKeyVault(log lager.Logger, client *http.Client, vaultURL string, credential Credential, secretTemplates []*creds.SecretTemplate) *KeyVault {
+	return &KeyVault{
+		log:             log,
+		client:          client,
+		vaultURL:        strings.TrimSuffix(vaultURL, "/"),
+		credential:      credential,
+		secretTemplates: secretTemplates,
+	}
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (kv *KeyVault) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	for _, tmpl := range kv.secretTemplates {
+		lPath := creds.NewSecretLookupWithTemplate(tmpl, EscapeName(strings.ToLower(teamName)), EscapeName(pipelineName))
+		if lPath != nil {
+			lookupPaths = append(lookupPaths, escapedSecretLookupPath{lPath})
+		}
+	}
+	return lookupPaths
+}
+
+// escapedSecretLookupPath escapes the var name before it is put into the
+// template. Var names are lowercased first: names differing only in case
+// would refer to the same Key Vault secret anyway, and always belong to the
+// same team and pipeline.
+type escapedSecretLookupPath struct {
+	creds.SecretLookupPath
+}
+
+func (path escapedSecretLookupPath) VariableToSecretPath(varName string) (string, error) {
+	return path.SecretLookupPath.VariableToSecretPath(EscapeName(strings.ToLower(varName)))
+}
+
+// EscapeName encodes a team, pipeline or var name so that it only contains
+// lowercase letters, digits and pairs of dashes: every other byte is written
+// as "--" followed by its hex code, e.g. "my-team" becomes "my--2dteam".
+//
+// A single dash can then only come from the secret template, so different
+// teams, pipelines and vars never map to the same Key Vault secret name, even
+// when their names contain dashes themselves.
+func EscapeName(name string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			escaped.WriteByte(c)
+		} else {
+			fmt.Fprintf(&escaped, "--%02x", c)
+		}
+	}
+
+	return escaped.String()
+}
+
+// Get retrieves the value and expiration of an individual secret
+func (kv *KeyVault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, found, err := kv.getSecret(SecretName(secretPath))
+	if err != nil {
+		kv.log.Error("failed-to-fetch-azure-secret", err, lager.Data{
+			"secret-path": secretPath,
+		})
+		return nil, nil, false, err
+	}
+	if found {
+		return value, expiration, true, nil
+	}
+	return nil, nil, false, nil
+}
+
+// SecretName maps a secret path to a valid Key Vault secret name, replacing
+// all characters Key Vault doesn't allow in the secret template, e.g. slashes,
+// with dashes. Team, pipeline and var names are escaped by EscapeName before
+// they get here.
+func SecretName(secretPath string) string {
+	return invalidSecretNameChars.ReplaceAllString(strings.Trim(secretPath, "/"), "-")
+}
+
+type secretBundle struct {
+	Value       string `json:"value"`
+	ContentType string `json:"contentType"`
+	Attributes  struct {
+		Expires *int64 `json:"exp"`
+	} `json:"attributes"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// getSecret looks up the latest version of the secret. Secrets with a JSON
+// content type are returned as a map[string]interface{}, all others as a
+// string. A secret with an expiry date is only cached until it expires.
+func (kv *KeyVault) getSecret(name string) (interface{}, *time.Time, bool, error) {
+	accessToken, err := kv.credential.Token()
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	req, err := http.NewRequest("GET", kv.vaultURL+"/secrets/"+url.PathEscape(name)+"?api-version="+APIVersion, nil)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := kv.client.Do(req)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil, false, nil
+	default:
+		var errResp errorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+
+		if errResp.Error.Code != "" {
+			return nil, nil, false, fmt.Errorf("get secret %s: %s: %s", name, errResp.Error.Code, errResp.Error.Message)
+		}
+
+		return nil, nil, false, fmt.Errorf("get secret %s: unexpected response: %s", name, resp.Status)
+	}
+
+	var bundle secretBundle
+	err = json.NewDecoder(resp.Body).Decode(&bundle)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("decode secret %s: %w", name, err)
+	}
+
+	var expiration *time.Time
+	if bundle.Attributes.Expires != nil {
+		exp := time.Unix(*bundle.Attributes.Expires, 0)
+		expiration = &exp
+	}
+
+	if isJSON(bundle.ContentType) {
+		var values map[string]interface{}
+		err := json.Unmarshal([]byte(bundle.Value), &values)
+		if err != nil {
+			return nil, nil, true, err
+		}
+
+		return values, expiration, true, nil
+	}
+
+	return bundle.Value, expiration, true, nil
+}
+
+func isJSON(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return strings.EqualFold(mediaType, "application/json")
+}
@@ -0,0 +1,30 @@
+package azurekeyvault
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type keyVaultFactory struct {
+	log             lager.Logger
+	client          *http.Client
+	vaultURL        string
+	credential      Credential
+	secretTemplates []*creds.SecretTemplate
+}
+
+func NewKeyVaultFactory(log lager.Logger, client *http.Client, vaultURL string, credential Credential, secretTemplates []*creds.SecretTemplate) *keyVaultFactory {
+	return &keyVaultFactory{
+		log:             log,
+		client:          client,
+		vaultURL:        vaultURL,
+		credential:      credential,
+		secretTemplates: secretTemplates,
+	}
+}
+
+func (factory *keyVaultFactory) NewSecrets() creds.Secrets {
+	return NewKeyVault(factory.log, factory.client, factory.vaultURL, factory.credential, factory.secretTemplates)
+}
@@ -0,0 +1,194 @@
+package azurekeyvault_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/concourse/concourse/atc/creds/azurekeyvault"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyVault", func() {
+	var (
+		stub        *keyVaultStub
+		keyVault    *KeyVault
+		variables   vars.Variables
+		varRef      vars.Reference
+		credential  Credential
+		pipelineTpl *creds.SecretTemplate
+		teamTpl     *creds.SecretTemplate
+	)
+
+	BeforeEach(func() {
+		stub = newKeyVaultStub()
+
+		var err error
+		pipelineTpl, err = creds.BuildSecretTemplate("pipeline", DefaultPipelineSecretTemplate)
+		Expect(err).NotTo(HaveOccurred())
+
+		teamTpl, err = creds.BuildSecretTemplate("team", DefaultTeamSecretTemplate)
+		Expect(err).NotTo(HaveOccurred())
+
+		credential = NewClientSecretCredential(http.DefaultClient, stub.URL, "some-tenant", "some-client", "some-secret", "https://vault.azure.net")
+
+		varRef = vars.Reference{Path: "cheery"}
+	})
+
+	JustBeforeEach(func() {
+		keyVault = NewKeyVault(lagertest.NewTestLogger("azurekeyvault_test"), http.DefaultClient, stub.URL, credential, []*creds.SecretTemplate{pipelineTpl, teamTpl})
+		variables = creds.NewVariables(keyVault, "alpha", "bogus", false)
+	})
+
+	AfterEach(func() {
+		stub.Close()
+	})
+
+	It("gets pipeline secrets", func() {
+		stub.setSecret("concourse-alpha-bogus-cheery", stubSecret{Value: "pipeline value"})
+
+		value, found, err := variables.Get(varRef)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("pipeline value"))
+	})
+
+	It("falls back to team secrets", func() {
+		stub.setSecret("concourse-alpha-cheery", stubSecret{Value: "team value"})
+
+		value, found, err := variables.Get(varRef)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team value"))
+	})
+
+	It("returns not found when no secret exists", func() {
+		_, found, err := variables.Get(varRef)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("decodes secrets with a JSON content type", func() {
+		stub.setSecret("concourse-alpha-bogus-cheery", stubSecret{
+			Value:       `{"username":"admin","password":"hunter2"}`,
+			ContentType: "application/json; charset=utf-8",
+		})
+
+		value, found, err := variables.Get(vars.Reference{Path: "cheery", Fields: []string{"password"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("hunter2"))
+	})
+
+	It("returns the secret's expiry as its expiration", func() {
+		expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		stub.setSecret("concourse-alpha-bogus-cheery", stubSecret{Value: "value", Expires: &expires})
+
+		_, expiration, found, err := keyVault.Get("concourse-alpha-bogus-cheery")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(expiration).NotTo(BeNil())
+		Expect(expiration.Unix()).To(Equal(expires))
+	})
+
+	It("escapes characters in team, pipeline and var names", func() {
+		stub.setSecret("concourse-some--5fteam-some--2dpipeline--2ev2-some--2fvar", stubSecret{Value: "value"})
+
+		variables = creds.NewVariables(keyVault, "Some_Team", "some-pipeline.v2", false)
+
+		value, found, err := variables.Get(vars.Reference{Path: "Some/Var"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("value"))
+	})
+
+	Describe("secrets of teams whose names contain dashes", func() {
+		BeforeEach(func() {
+			stub.setSecret("concourse-a--2db-x", stubSecret{Value: "team a-b's secret"})
+		})
+
+		It("are not found by another team's pipeline", func() {
+			_, found, err := creds.NewVariables(keyVault, "a", "b", false).Get(vars.Reference{Path: "x"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("are not found by another team's vars", func() {
+			_, found, err := creds.NewVariables(keyVault, "a", "", false).Get(vars.Reference{Path: "b-x"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("are found by the team itself", func() {
+			value, found, err := creds.NewVariables(keyVault, "a-b", "", false).Get(vars.Reference{Path: "x"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team a-b's secret"))
+		})
+	})
+
+	It("never maps two teams to the same secret name", func() {
+		lookups := map[string]string{}
+
+		for _, team := range []string{"a", "a-b", "a-", "a_b", "a--2db"} {
+			for _, pipeline := range []string{"", "b", "-b", "b-x", "2db"} {
+				for _, secret := range []string{"x", "b-x", "-x", "2dx"} {
+					for _, lookupPath := range keyVault.NewSecretLookupPaths(team, pipeline, false) {
+						path, err := lookupPath.VariableToSecretPath(secret)
+						Expect(err).NotTo(HaveOccurred())
+
+						name := SecretName(path)
+						if other, found := lookups[name]; found {
+							Expect(other).To(HavePrefix(team+"/"), "team %s and %s both map to %s", team, other, name)
+						}
+
+						lookups[name] = team + "/" + pipeline + "/" + secret
+					}
+				}
+			}
+		}
+	})
+
+	It("reuses access tokens until they are about to expire", func() {
+		stub.setSecret("concourse-alpha-bogus-cheery", stubSecret{Value: "pipeline value"})
+
+		for i := 0; i < 3; i++ {
+			_, _, err := variables.Get(varRef)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(stub.tokensIssued()).To(Equal(1))
+		Expect(stub.tokenForms[0]).To(Equal(map[string][]string{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {"some-client"},
+			"client_secret": {"some-secret"},
+			"scope":         {"https://vault.azure.net/.default"},
+		}))
+	})
+
+	Context("when Key Vault denies access", func() {
+		BeforeEach(func() {
+			stub.failSecrets = true
+		})
+
+		It("returns the error", func() {
+			_, _, err := variables.Get(varRef)
+			Expect(err).To(MatchError(ContainSubstring("Forbidden: The user does not have secrets get permission.")))
+		})
+	})
+
+	Context("when authentication fails", func() {
+		BeforeEach(func() {
+			credential = NewClientSecretCredential(http.DefaultClient, stub.URL, "some-tenant", "some-client", "wrong-secret", "https://vault.azure.net")
+		})
+
+		It("returns the error", func() {
+			_, _, err := variables.Get(varRef)
+			Expect(err).To(MatchError("get azure access token: invalid_client: Invalid client secret provided."))
+		})
+	})
+})
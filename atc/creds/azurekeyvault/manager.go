@@ -0,0 +1,180 @@
+package azurekeyvault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/flag"
+)
+
+const DefaultPipelineSecretTemplate = "concourse-{{.Team}}-{{.Pipeline}}-{{.Secret}}"
+const DefaultTeamSecretTemplate = "concourse-{{.Team}}-{{.Secret}}"
+
+type Manager struct {
+	VaultURL string `long:"url" description:"URL of the Key Vault, e.g. https://my-vault.vault.azure.net"`
+	Resource string `long:"resource" default:"https://vault.azure.net" description:"Resource to request access tokens for. Only needs changing in national clouds."`
+
+	TenantID          string    `long:"tenant-id" description:"Azure AD tenant of the service principal to authenticate as"`
+	ClientID          string    `long:"client-id" description:"Client ID of the service principal to authenticate as, or of the user-assigned managed identity to use"`
+	ClientSecret      string    `long:"client-secret" description:"Client secret to authenticate the service principal with"`
+	ClientCertificate flag.File `long:"client-certificate" description:"File containing a PEM encoded certificate and RSA private key to authenticate the service principal with"`
+	AuthorityHost     string    `long:"authority-host" default:"https://login.microsoftonline.com" description:"Azure AD endpoint service principals authenticate against"`
+
+	UseManagedIdentity      bool   `long:"use-managed-identity" description:"Authenticate as the managed identity assigned to the VM the web node runs on"`
+	ManagedIdentityEndpoint string `long:"managed-identity-endpoint" default:"http://169.254.169.254/metadata/identity/oauth2/token" description:"Endpoint of the instance metadata service issuing managed identity tokens"`
+
+	PipelineSecretTemplate string `long:"pipeline-secret-template" default:"concourse-{{.Team}}-{{.Pipeline}}-{{.Secret}}" description:"Key Vault secret name template used for pipeline specific secrets. Team and var names are lowercased, and any character in a team, pipeline or var name other than a lowercase letter or digit is written as two dashes followed by its hex code, e.g. my-team becomes my--2dteam."`
+	TeamSecretTemplate     string `long:"team-secret-template" default:"concourse-{{.Team}}-{{.Secret}}" description:"Key Vault secret name template used for team specific secrets. Team and var names are escaped as for the pipeline secret template."`
+
+	KeyVault *KeyVault
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	credential, err := manager.credential()
+	if err != nil {
+		log.Error("create-azure-credential", err)
+		return err
+	}
+
+	manager.KeyVault = NewKeyVault(log, newClient(), manager.VaultURL, credential, nil)
+
+	return nil
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "GetSecret",
+	}
+
+	_, _, _, err := manager.KeyVault.getSecret("concourse-health-check")
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"url":                      manager.VaultURL,
+		"auth_method":              manager.authMethod(),
+		"pipeline_secret_template": manager.PipelineSecretTemplate,
+		"team_secret_template":     manager.TeamSecretTemplate,
+		"health":                   health,
+	})
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.VaultURL != ""
+}
+
+func (manager *Manager) Validate() error {
+	if _, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate); err != nil {
+		return err
+	}
+	if _, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate); err != nil {
+		return err
+	}
+
+	if manager.UseManagedIdentity {
+		if manager.ClientSecret != "" || manager.ClientCertificate != "" {
+			return errors.New("must provide either a managed identity or service principal credentials, not both")
+		}
+
+		return nil
+	}
+
+	if manager.TenantID == "" {
+		return errors.New("must provide a tenant id or use a managed identity")
+	}
+
+	if manager.ClientID == "" {
+		return errors.New("must provide a client id or use a managed identity")
+	}
+
+	if manager.ClientSecret == "" && manager.ClientCertificate == "" {
+		return errors.New("must provide a client secret or client certificate")
+	}
+
+	if manager.ClientSecret != "" && manager.ClientCertificate != "" {
+		return errors.New("must provide either a client secret or client certificate, not both")
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	credential, err := manager.credential()
+	if err != nil {
+		log.Error("create-azure-credential", err)
+		return nil, err
+	}
+
+	pipelineSecretTemplate, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyVaultFactory(log, newClient(), manager.VaultURL, credential, []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}), nil
+}
+
+func (manager Manager) Close(logger lager.Logger) {
+}
+
+func (manager *Manager) authMethod() string {
+	switch {
+	case manager.UseManagedIdentity:
+		return "managed_identity"
+	case manager.ClientCertificate != "":
+		return "client_certificate"
+	default:
+		return "client_secret"
+	}
+}
+
+func (manager *Manager) credential() (Credential, error) {
+	client := newClient()
+
+	switch manager.authMethod() {
+	case "managed_identity":
+		return NewManagedIdentityCredential(client, manager.ManagedIdentityEndpoint, manager.Resource, manager.ClientID), nil
+
+	case "client_certificate":
+		certificate, err := ioutil.ReadFile(manager.ClientCertificate.Path())
+		if err != nil {
+			return nil, fmt.Errorf("read client certificate: %w", err)
+		}
+
+		return NewClientCertificateCredential(client, manager.AuthorityHost, manager.TenantID, manager.ClientID, certificate, manager.Resource)
+
+	default:
+		return NewClientSecretCredential(client, manager.AuthorityHost, manager.TenantID, manager.ClientID, manager.ClientSecret, manager.Resource), nil
+	}
+}
+
+func newClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+	}
+}
@@ -0,0 +1,30 @@
+package azurekeyvault
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type managerFactory struct{}
+
+func init() {
+	creds.Register("azurekeyvault", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+	subGroup, err := group.AddGroup("Azure Key Vault Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+	subGroup.Namespace = "azure-keyvault"
+	return manager
+}
+
+func (factory *managerFactory) NewInstance(interface{}) (creds.Manager, error) {
+	return &Manager{}, nil
+}
@@ -0,0 +1,132 @@
+package azurekeyvault_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds/azurekeyvault"
+	"github.com/concourse/flag"
+	"github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var manager azurekeyvault.Manager
+
+	BeforeEach(func() {
+		manager = azurekeyvault.Manager{}
+		_, err := flags.ParseArgs(&manager, []string{})
+		Expect(err).To(BeNil())
+	})
+
+	Describe("IsConfigured()", func() {
+		It("fails on empty Manager", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes if the vault URL is set", func() {
+			manager.VaultURL = "https://some-vault.vault.azure.net"
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		BeforeEach(func() {
+			manager.VaultURL = "https://some-vault.vault.azure.net"
+			Expect(manager.PipelineSecretTemplate).To(Equal(azurekeyvault.DefaultPipelineSecretTemplate))
+			Expect(manager.TeamSecretTemplate).To(Equal(azurekeyvault.DefaultTeamSecretTemplate))
+		})
+
+		DescribeTable("passes with a single way of authenticating",
+			func(managedIdentity bool, tenantID, clientID, clientSecret, clientCertificate string) {
+				manager.UseManagedIdentity = managedIdentity
+				manager.TenantID = tenantID
+				manager.ClientID = clientID
+				manager.ClientSecret = clientSecret
+				manager.ClientCertificate = flag.File(clientCertificate)
+				Expect(manager.Validate()).To(BeNil())
+			},
+			Entry("system-assigned managed identity", true, "", "", "", ""),
+			Entry("user-assigned managed identity", true, "", "some-client", "", ""),
+			Entry("client secret", false, "some-tenant", "some-client", "some-secret", ""),
+			Entry("client certificate", false, "some-tenant", "some-client", "", "/some/cert.pem"),
+		)
+
+		DescribeTable("fails on incomplete or conflicting credentials",
+			func(managedIdentity bool, tenantID, clientID, clientSecret, clientCertificate string) {
+				manager.UseManagedIdentity = managedIdentity
+				manager.TenantID = tenantID
+				manager.ClientID = clientID
+				manager.ClientSecret = clientSecret
+				manager.ClientCertificate = flag.File(clientCertificate)
+				Expect(manager.Validate()).ToNot(BeNil())
+			},
+			Entry("no credentials", false, "", "", "", ""),
+			Entry("no tenant", false, "", "some-client", "some-secret", ""),
+			Entry("no client", false, "some-tenant", "", "some-secret", ""),
+			Entry("no secret or certificate", false, "some-tenant", "some-client", "", ""),
+			Entry("secret and certificate", false, "some-tenant", "some-client", "some-secret", "/some/cert.pem"),
+			Entry("managed identity and secret", true, "some-tenant", "some-client", "some-secret", ""),
+		)
+
+		It("fails on an invalid secret template", func() {
+			manager.UseManagedIdentity = true
+			manager.TeamSecretTemplate = "{{.Team}}-{{.Bogus}}"
+			Expect(manager.Validate()).ToNot(BeNil())
+		})
+	})
+
+	Describe("Health()", func() {
+		var stub *keyVaultStub
+
+		BeforeEach(func() {
+			stub = newKeyVaultStub()
+
+			manager.VaultURL = stub.URL
+			manager.AuthorityHost = stub.URL
+			manager.TenantID = "some-tenant"
+			manager.ClientID = "some-client"
+			manager.ClientSecret = "some-secret"
+
+			Expect(manager.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			stub.Close()
+		})
+
+		It("reports UP when the vault can be read", func() {
+			health, err := manager.Health()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(health.Method).To(Equal("GetSecret"))
+			Expect(health.Error).To(BeEmpty())
+			Expect(health.Response).To(Equal(map[string]string{"status": "UP"}))
+		})
+
+		It("reports the error when the vault can't be read", func() {
+			stub.failSecrets = true
+
+			health, err := manager.Health()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(health.Error).To(ContainSubstring("Forbidden"))
+		})
+
+		It("includes the health in the manager's info", func() {
+			info, err := json.Marshal(&manager)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info).To(MatchJSON(`{
+				"url": "` + stub.URL + `",
+				"auth_method": "client_secret",
+				"pipeline_secret_template": "concourse-{{.Team}}-{{.Pipeline}}-{{.Secret}}",
+				"team_secret_template": "concourse-{{.Team}}-{{.Secret}}",
+				"health": {
+					"method": "GetSecret",
+					"response": {"status": "UP"}
+				}
+			}`))
+		})
+	})
+})
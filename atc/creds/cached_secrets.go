@@ -37,19 +37,30 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, _, found, err := cs.GetLeased(secretPath)
+	return value, expiration, found, err
+}
+
+func (cs *CachedSecrets) GetLeased(secretPath string) (interface{}, *time.Time, *Lease, bool, error) {
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
 		result := entry.(CacheEntry)
-		return result.value, result.expiration, result.found, nil
+		return result.value, result.expiration, nil, result.found, nil
 	}
 
 	// otherwise, let's make a request to the underlying secret manager
-	value, expiration, found, err := cs.secrets.Get(secretPath)
+	value, expiration, lease, found, err := getLeased(cs.secrets, secretPath)
 
 	// we don't want to cache errors, let the errors be retried the next time around
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, nil, false, err
+	}
+
+	// dynamic secrets are generated anew for every read, and must not be
+	// shared beyond the holder of their lease
+	if lease != nil {
+		return value, expiration, lease, found, nil
 	}
 
 	// here we want to cache secret value, expiration, and found flag too
@@ -71,7 +82,15 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
-	return value, expiration, found, nil
+	return value, expiration, nil, found, nil
+}
+
+func (cs *CachedSecrets) RenewLease(lease Lease) (Lease, error) {
+	return renewLease(cs.secrets, lease)
+}
+
+func (cs *CachedSecrets) RevokeLease(leaseID string) error {
+	return revokeLease(cs.secrets, leaseID)
 }
 
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
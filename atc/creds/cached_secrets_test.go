@@ -163,3 +163,42 @@ var _ = Describe("Caching of secrets", func() {
 	})
 
 })
+
+var _ = Describe("Caching of dynamic secrets", func() {
+	var secretManager *credsfakes.FakeLeasingSecrets
+	var cachedSecretManager *creds.CachedSecrets
+
+	BeforeEach(func() {
+		secretManager = new(credsfakes.FakeLeasingSecrets)
+		secretManager.GetLeasedReturns("value", nil, &creds.Lease{ID: "some-lease"}, true, nil)
+
+		cachedSecretManager = creds.NewCachedSecrets(secretManager, creds.SecretCacheConfig{
+			Duration:         time.Minute,
+			DurationNotFound: time.Minute,
+			PurgeInterval:    time.Minute,
+		})
+	})
+
+	It("should not cache secrets which are leased", func() {
+		_, _, lease, found, err := cachedSecretManager.GetLeased("foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(lease).To(Equal(&creds.Lease{ID: "some-lease"}))
+
+		_, _, _, _, err = cachedSecretManager.GetLeased("foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secretManager.GetLeasedCallCount()).To(Equal(2))
+	})
+
+	It("should pass renewals and revocations through", func() {
+		secretManager.RenewLeaseReturns(creds.Lease{ID: "some-lease", Duration: time.Hour}, nil)
+
+		renewed, err := cachedSecretManager.RenewLease(creds.Lease{ID: "some-lease"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renewed.Duration).To(Equal(time.Hour))
+
+		err = cachedSecretManager.RevokeLease("some-lease")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secretManager.RevokeLeaseArgsForCall(0)).To(Equal("some-lease"))
+	})
+})
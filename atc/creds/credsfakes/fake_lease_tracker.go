@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeLeaseTracker struct {
+	TrackSecretLeaseStub        func(creds.Lease) error
+	trackSecretLeaseMutex       sync.RWMutex
+	trackSecretLeaseArgsForCall []struct {
+		arg1 creds.Lease
+	}
+	trackSecretLeaseReturns struct {
+		result1 error
+	}
+	trackSecretLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLeaseTracker) TrackSecretLease(arg1 creds.Lease) error {
+	fake.trackSecretLeaseMutex.Lock()
+	ret, specificReturn := fake.trackSecretLeaseReturnsOnCall[len(fake.trackSecretLeaseArgsForCall)]
+	fake.trackSecretLeaseArgsForCall = append(fake.trackSecretLeaseArgsForCall, struct {
+		arg1 creds.Lease
+	}{arg1})
+	stub := fake.TrackSecretLeaseStub
+	fakeReturns := fake.trackSecretLeaseReturns
+	fake.recordInvocation("TrackSecretLease", []interface{}{arg1})
+	fake.trackSecretLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeaseTracker) TrackSecretLeaseCallCount() int {
+	fake.trackSecretLeaseMutex.RLock()
+	defer fake.trackSecretLeaseMutex.RUnlock()
+	return len(fake.trackSecretLeaseArgsForCall)
+}
+
+func (fake *FakeLeaseTracker) TrackSecretLeaseCalls(stub func(creds.Lease) error) {
+	fake.trackSecretLeaseMutex.Lock()
+	defer fake.trackSecretLeaseMutex.Unlock()
+	fake.TrackSecretLeaseStub = stub
+}
+
+func (fake *FakeLeaseTracker) TrackSecretLeaseArgsForCall(i int) creds.Lease {
+	fake.trackSecretLeaseMutex.RLock()
+	defer fake.trackSecretLeaseMutex.RUnlock()
+	argsForCall := fake.trackSecretLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeaseTracker) TrackSecretLeaseReturns(result1 error) {
+	fake.trackSecretLeaseMutex.Lock()
+	defer fake.trackSecretLeaseMutex.Unlock()
+	fake.TrackSecretLeaseStub = nil
+	fake.trackSecretLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseTracker) TrackSecretLeaseReturnsOnCall(i int, result1 error) {
+	fake.trackSecretLeaseMutex.Lock()
+	defer fake.trackSecretLeaseMutex.Unlock()
+	fake.TrackSecretLeaseStub = nil
+	if fake.trackSecretLeaseReturnsOnCall == nil {
+		fake.trackSecretLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.trackSecretLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseTracker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.trackSecretLeaseMutex.RLock()
+	defer fake.trackSecretLeaseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLeaseTracker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.LeaseTracker = new(FakeLeaseTracker)
@@ -0,0 +1,452 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeLeasingSecrets struct {
+	GetStub        func(string) (interface{}, *time.Time, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}
+	GetLeasedStub        func(string) (interface{}, *time.Time, *creds.Lease, bool, error)
+	getLeasedMutex       sync.RWMutex
+	getLeasedArgsForCall []struct {
+		arg1 string
+	}
+	getLeasedReturns struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 *creds.Lease
+		result4 bool
+		result5 error
+	}
+	getLeasedReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 *creds.Lease
+		result4 bool
+		result5 error
+	}
+	NewSecretLookupPathsStub        func(string, string, bool) []creds.SecretLookupPath
+	newSecretLookupPathsMutex       sync.RWMutex
+	newSecretLookupPathsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	newSecretLookupPathsReturns struct {
+		result1 []creds.SecretLookupPath
+	}
+	newSecretLookupPathsReturnsOnCall map[int]struct {
+		result1 []creds.SecretLookupPath
+	}
+	RenewLeaseStub        func(creds.Lease) (creds.Lease, error)
+	renewLeaseMutex       sync.RWMutex
+	renewLeaseArgsForCall []struct {
+		arg1 creds.Lease
+	}
+	renewLeaseReturns struct {
+		result1 creds.Lease
+		result2 error
+	}
+	renewLeaseReturnsOnCall map[int]struct {
+		result1 creds.Lease
+		result2 error
+	}
+	RevokeLeaseStub        func(string) error
+	revokeLeaseMutex       sync.RWMutex
+	revokeLeaseArgsForCall []struct {
+		arg1 string
+	}
+	revokeLeaseReturns struct {
+		result1 error
+	}
+	revokeLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLeasingSecrets) Get(arg1 string) (interface{}, *time.Time, bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeLeasingSecrets) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeLeasingSecrets) GetCalls(stub func(string) (interface{}, *time.Time, bool, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeLeasingSecrets) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasingSecrets) GetReturns(result1 interface{}, result2 *time.Time, result3 bool, result4 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeLeasingSecrets) GetReturnsOnCall(i int, result1 interface{}, result2 *time.Time, result3 bool, result4 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 *time.Time
+			result3 bool
+			result4 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeLeasingSecrets) GetLeased(arg1 string) (interface{}, *time.Time, *creds.Lease, bool, error) {
+	fake.getLeasedMutex.Lock()
+	ret, specificReturn := fake.getLeasedReturnsOnCall[len(fake.getLeasedArgsForCall)]
+	fake.getLeasedArgsForCall = append(fake.getLeasedArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetLeasedStub
+	fakeReturns := fake.getLeasedReturns
+	fake.recordInvocation("GetLeased", []interface{}{arg1})
+	fake.getLeasedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4, ret.result5
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4, fakeReturns.result5
+}
+
+func (fake *FakeLeasingSecrets) GetLeasedCallCount() int {
+	fake.getLeasedMutex.RLock()
+	defer fake.getLeasedMutex.RUnlock()
+	return len(fake.getLeasedArgsForCall)
+}
+
+func (fake *FakeLeasingSecrets) GetLeasedCalls(stub func(string) (interface{}, *time.Time, *creds.Lease, bool, error)) {
+	fake.getLeasedMutex.Lock()
+	defer fake.getLeasedMutex.Unlock()
+	fake.GetLeasedStub = stub
+}
+
+func (fake *FakeLeasingSecrets) GetLeasedArgsForCall(i int) string {
+	fake.getLeasedMutex.RLock()
+	defer fake.getLeasedMutex.RUnlock()
+	argsForCall := fake.getLeasedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasingSecrets) GetLeasedReturns(result1 interface{}, result2 *time.Time, result3 *creds.Lease, result4 bool, result5 error) {
+	fake.getLeasedMutex.Lock()
+	defer fake.getLeasedMutex.Unlock()
+	fake.GetLeasedStub = nil
+	fake.getLeasedReturns = struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 *creds.Lease
+		result4 bool
+		result5 error
+	}{result1, result2, result3, result4, result5}
+}
+
+func (fake *FakeLeasingSecrets) GetLeasedReturnsOnCall(i int, result1 interface{}, result2 *time.Time, result3 *creds.Lease, result4 bool, result5 error) {
+	fake.getLeasedMutex.Lock()
+	defer fake.getLeasedMutex.Unlock()
+	fake.GetLeasedStub = nil
+	if fake.getLeasedReturnsOnCall == nil {
+		fake.getLeasedReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 *time.Time
+			result3 *creds.Lease
+			result4 bool
+			result5 error
+		})
+	}
+	fake.getLeasedReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 *creds.Lease
+		result4 bool
+		result5 error
+	}{result1, result2, result3, result4, result5}
+}
+
+func (fake *FakeLeasingSecrets) NewSecretLookupPaths(arg1 string, arg2 string, arg3 bool) []creds.SecretLookupPath {
+	fake.newSecretLookupPathsMutex.Lock()
+	ret, specificReturn := fake.newSecretLookupPathsReturnsOnCall[len(fake.newSecretLookupPathsArgsForCall)]
+	fake.newSecretLookupPathsArgsForCall = append(fake.newSecretLookupPathsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.NewSecretLookupPathsStub
+	fakeReturns := fake.newSecretLookupPathsReturns
+	fake.recordInvocation("NewSecretLookupPaths", []interface{}{arg1, arg2, arg3})
+	fake.newSecretLookupPathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeasingSecrets) NewSecretLookupPathsCallCount() int {
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	return len(fake.newSecretLookupPathsArgsForCall)
+}
+
+func (fake *FakeLeasingSecrets) NewSecretLookupPathsCalls(stub func(string, string, bool) []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = stub
+}
+
+func (fake *FakeLeasingSecrets) NewSecretLookupPathsArgsForCall(i int) (string, string, bool) {
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	argsForCall := fake.newSecretLookupPathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLeasingSecrets) NewSecretLookupPathsReturns(result1 []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = nil
+	fake.newSecretLookupPathsReturns = struct {
+		result1 []creds.SecretLookupPath
+	}{result1}
+}
+
+func (fake *FakeLeasingSecrets) NewSecretLookupPathsReturnsOnCall(i int, result1 []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = nil
+	if fake.newSecretLookupPathsReturnsOnCall == nil {
+		fake.newSecretLookupPathsReturnsOnCall = make(map[int]struct {
+			result1 []creds.SecretLookupPath
+		})
+	}
+	fake.newSecretLookupPathsReturnsOnCall[i] = struct {
+		result1 []creds.SecretLookupPath
+	}{result1}
+}
+
+func (fake *FakeLeasingSecrets) RenewLease(arg1 creds.Lease) (creds.Lease, error) {
+	fake.renewLeaseMutex.Lock()
+	ret, specificReturn := fake.renewLeaseReturnsOnCall[len(fake.renewLeaseArgsForCall)]
+	fake.renewLeaseArgsForCall = append(fake.renewLeaseArgsForCall, struct {
+		arg1 creds.Lease
+	}{arg1})
+	stub := fake.RenewLeaseStub
+	fakeReturns := fake.renewLeaseReturns
+	fake.recordInvocation("RenewLease", []interface{}{arg1})
+	fake.renewLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLeasingSecrets) RenewLeaseCallCount() int {
+	fake.renewLeaseMutex.RLock()
+	defer fake.renewLeaseMutex.RUnlock()
+	return len(fake.renewLeaseArgsForCall)
+}
+
+func (fake *FakeLeasingSecrets) RenewLeaseCalls(stub func(creds.Lease) (creds.Lease, error)) {
+	fake.renewLeaseMutex.Lock()
+	defer fake.renewLeaseMutex.Unlock()
+	fake.RenewLeaseStub = stub
+}
+
+func (fake *FakeLeasingSecrets) RenewLeaseArgsForCall(i int) creds.Lease {
+	fake.renewLeaseMutex.RLock()
+	defer fake.renewLeaseMutex.RUnlock()
+	argsForCall := fake.renewLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasingSecrets) RenewLeaseReturns(result1 creds.Lease, result2 error) {
+	fake.renewLeaseMutex.Lock()
+	defer fake.renewLeaseMutex.Unlock()
+	fake.RenewLeaseStub = nil
+	fake.renewLeaseReturns = struct {
+		result1 creds.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeasingSecrets) RenewLeaseReturnsOnCall(i int, result1 creds.Lease, result2 error) {
+	fake.renewLeaseMutex.Lock()
+	defer fake.renewLeaseMutex.Unlock()
+	fake.RenewLeaseStub = nil
+	if fake.renewLeaseReturnsOnCall == nil {
+		fake.renewLeaseReturnsOnCall = make(map[int]struct {
+			result1 creds.Lease
+			result2 error
+		})
+	}
+	fake.renewLeaseReturnsOnCall[i] = struct {
+		result1 creds.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeasingSecrets) RevokeLease(arg1 string) error {
+	fake.revokeLeaseMutex.Lock()
+	ret, specificReturn := fake.revokeLeaseReturnsOnCall[len(fake.revokeLeaseArgsForCall)]
+	fake.revokeLeaseArgsForCall = append(fake.revokeLeaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RevokeLeaseStub
+	fakeReturns := fake.revokeLeaseReturns
+	fake.recordInvocation("RevokeLease", []interface{}{arg1})
+	fake.revokeLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeasingSecrets) RevokeLeaseCallCount() int {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	return len(fake.revokeLeaseArgsForCall)
+}
+
+func (fake *FakeLeasingSecrets) RevokeLeaseCalls(stub func(string) error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = stub
+}
+
+func (fake *FakeLeasingSecrets) RevokeLeaseArgsForCall(i int) string {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	argsForCall := fake.revokeLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasingSecrets) RevokeLeaseReturns(result1 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	fake.revokeLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeasingSecrets) RevokeLeaseReturnsOnCall(i int, result1 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	if fake.revokeLeaseReturnsOnCall == nil {
+		fake.revokeLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeasingSecrets) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.getLeasedMutex.RLock()
+	defer fake.getLeasedMutex.RUnlock()
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	fake.renewLeaseMutex.RLock()
+	defer fake.renewLeaseMutex.RUnlock()
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLeasingSecrets) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.LeasingSecrets = new(FakeLeasingSecrets)
@@ -0,0 +1,76 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeSecretAccessRecorder struct {
+	RecordSecretAccessStub        func(creds.SecretAccess)
+	recordSecretAccessMutex       sync.RWMutex
+	recordSecretAccessArgsForCall []struct {
+		arg1 creds.SecretAccess
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccess(arg1 creds.SecretAccess) {
+	fake.recordSecretAccessMutex.Lock()
+	fake.recordSecretAccessArgsForCall = append(fake.recordSecretAccessArgsForCall, struct {
+		arg1 creds.SecretAccess
+	}{arg1})
+	stub := fake.RecordSecretAccessStub
+	fake.recordInvocation("RecordSecretAccess", []interface{}{arg1})
+	fake.recordSecretAccessMutex.Unlock()
+	if stub != nil {
+		fake.RecordSecretAccessStub(arg1)
+	}
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessCallCount() int {
+	fake.recordSecretAccessMutex.RLock()
+	defer fake.recordSecretAccessMutex.RUnlock()
+	return len(fake.recordSecretAccessArgsForCall)
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessCalls(stub func(creds.SecretAccess)) {
+	fake.recordSecretAccessMutex.Lock()
+	defer fake.recordSecretAccessMutex.Unlock()
+	fake.RecordSecretAccessStub = stub
+}
+
+func (fake *FakeSecretAccessRecorder) RecordSecretAccessArgsForCall(i int) creds.SecretAccess {
+	fake.recordSecretAccessMutex.RLock()
+	defer fake.recordSecretAccessMutex.RUnlock()
+	argsForCall := fake.recordSecretAccessArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordSecretAccessMutex.RLock()
+	defer fake.recordSecretAccessMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretAccessRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.SecretAccessRecorder = new(FakeSecretAccessRecorder)
@@ -0,0 +1,89 @@
+package encryptedfile
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"filippo.io/age"
+)
+
+const (
+	// AgeExtension marks files encrypted with age.
+	AgeExtension = ".age"
+
+	// AESExtension marks files encrypted with AES-256-GCM, consisting of the
+	// 12 byte nonce followed by the sealed YAML.
+	AESExtension = ".enc"
+)
+
+// A Decrypter decrypts the contents of a secrets file.
+type Decrypter interface {
+	Decrypt([]byte) ([]byte, error)
+}
+
+type ageDecrypter struct {
+	identities []age.Identity
+}
+
+// NewAgeDecrypter parses the identities in the format written by age-keygen,
+// one AGE-SECRET-KEY per line.
+func NewAgeDecrypter(identities string) (Decrypter, error) {
+	parsed, err := age.ParseIdentities(strings.NewReader(identities))
+	if err != nil {
+		return nil, fmt.Errorf("parse age identities: %w", err)
+	}
+
+	return ageDecrypter{parsed}, nil
+}
+
+func (d ageDecrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	reader, err := age.Decrypt(bytes.NewReader(ciphertext), d.identities...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(reader)
+}
+
+type aesDecrypter struct {
+	aead cipher.AEAD
+}
+
+// NewAESDecrypter takes a hex-encoded 256 bit key.
+func NewAESDecrypter(hexKey string) (Decrypter, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("decode aes key: %w", err)
+	}
+
+	if len(key) != 32 {
+		return nil, errors.New("aes key must be 256 bits")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return aesDecrypter{aead}, nil
+}
+
+func (d aesDecrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	nonceSize := d.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	return d.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+}
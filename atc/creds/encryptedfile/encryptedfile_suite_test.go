@@ -0,0 +1,13 @@
+package encryptedfile_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestEncryptedFile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Encrypted File Creds Suite")
+}
@@ -0,0 +1,186 @@
+package encryptedfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/flag"
+)
+
+const DefaultPipelineSecretTemplate = "/{{.Team}}/{{.Pipeline}}/{{.Secret}}"
+const DefaultTeamSecretTemplate = "/{{.Team}}/{{.Secret}}"
+
+type Manager struct {
+	Path flag.Dir `mapstructure:"path" long:"path" description:"Directory of encrypted YAML files, laid out by team and pipeline according to the secret templates."`
+
+	VarSourceRoot flag.Dir `mapstructure:"-" long:"var-source-root" description:"Directory below which var_sources may read encrypted YAML files. Each team is confined to the subdirectory named after it, relative to which var_source paths are resolved. var_sources are disabled unless this is set."`
+
+	AgeIdentityFile flag.File `mapstructure:"-" long:"age-identity-file" description:"File containing the age identities used to decrypt files ending in .yml.age or .yaml.age."`
+	AESKeyFile      flag.File `mapstructure:"-" long:"aes-key-file" description:"File containing the hex-encoded 256 bit key used to decrypt files ending in .yml.enc or .yaml.enc with AES-GCM."`
+
+	// var_sources may not read key files from the web node, so their keys
+	// are configured inline
+	AgeIdentity string `mapstructure:"age_identity"`
+	AESKey      string `mapstructure:"aes_key"`
+
+	PipelineSecretTemplate string `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" default:"/{{.Team}}/{{.Pipeline}}/{{.Secret}}" description:"Path below the directory at which pipeline specific secrets are looked up"`
+	TeamSecretTemplate     string `mapstructure:"team_secret_template" long:"team-secret-template" default:"/{{.Team}}/{{.Secret}}" description:"Path below the directory at which team specific secrets are looked up"`
+	SharedPath             string `mapstructure:"shared_path" long:"shared-path" description:"Path below the directory under which to look up secrets shared by all teams"`
+
+	// teamDir is set for var_sources, whose Path is the var source root: each
+	// team only reads from teamDir within its own directory below it
+	teamDir    string
+	teamScoped bool
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	return nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"path":                     manager.Path,
+		"pipeline_secret_template": manager.PipelineSecretTemplate,
+		"team_secret_template":     manager.TeamSecretTemplate,
+		"shared_path":              manager.SharedPath,
+		"health":                   health,
+	})
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Path != ""
+}
+
+func (manager *Manager) Validate() error {
+	if manager.Path == "" {
+		return errors.New("must provide a directory of secrets files")
+	}
+
+	if _, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate); err != nil {
+		return err
+	}
+
+	if _, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate); err != nil {
+		return err
+	}
+
+	if manager.AgeIdentityFile == "" && manager.AgeIdentity == "" && manager.AESKeyFile == "" && manager.AESKey == "" {
+		return errors.New("must provide an age identity or an aes key to decrypt the secrets files with")
+	}
+
+	return nil
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "ReadDir",
+	}
+
+	_, err := ioutil.ReadDir(manager.Path.Path())
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+}
+
+func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	err := manager.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	decrypters, err := manager.decrypters()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineSecretTemplate, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	lookupTemplates := []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}
+
+	if manager.teamScoped {
+		return NewTeamSecretsFactory(
+			manager.Path.Path(),
+			manager.teamDir,
+			decrypters,
+			lookupTemplates,
+			manager.SharedPath,
+		), nil
+	}
+
+	return NewSecretsFactory(
+		manager.Path.Path(),
+		decrypters,
+		lookupTemplates,
+		manager.SharedPath,
+	), nil
+}
+
+func (manager *Manager) decrypters() (map[string]Decrypter, error) {
+	decrypters := map[string]Decrypter{}
+
+	ageIdentity, err := inlineOrFile(manager.AgeIdentity, manager.AgeIdentityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if ageIdentity != "" {
+		decrypters[AgeExtension], err = NewAgeDecrypter(ageIdentity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	aesKey, err := inlineOrFile(manager.AESKey, manager.AESKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if aesKey != "" {
+		decrypters[AESExtension], err = NewAESDecrypter(aesKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return decrypters, nil
+}
+
+func inlineOrFile(inline string, file flag.File) (string, error) {
+	if inline != "" || file == "" {
+		return inline, nil
+	}
+
+	content, err := ioutil.ReadFile(file.Path())
+	if err != nil {
+		return "", fmt.Errorf("read key file: %w", err)
+	}
+
+	return string(content), nil
+}
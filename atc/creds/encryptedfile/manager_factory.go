@@ -0,0 +1,82 @@
+package encryptedfile
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
+)
+
+type managerFactory struct {
+	// manager is the cluster-wide manager, which configures where
+	// var_sources may read files from
+	manager *Manager
+}
+
+func init() {
+	creds.Register("encryptedfile", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("Encrypted File Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "encrypted-file"
+
+	factory.manager = manager
+
+	return manager
+}
+
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	if factory.manager == nil || factory.manager.VarSourceRoot == "" {
+		return nil, errors.New("encrypted file var_sources are not enabled, as no var source root is configured")
+	}
+
+	manager := &Manager{
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.ToSlash(string(manager.Path))
+	if path.IsAbs(dir) || filepath.IsAbs(string(manager.Path)) {
+		return nil, errors.New("path must be relative to the team's directory")
+	}
+
+	for _, element := range strings.Split(dir, "/") {
+		if element == ".." {
+			return nil, errors.New("path must not contain '..'")
+		}
+	}
+
+	manager.Path = factory.manager.VarSourceRoot
+	manager.teamDir = dir
+	manager.teamScoped = true
+
+	return manager, nil
+}
@@ -0,0 +1,121 @@
+package encryptedfile_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/encryptedfile"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var manager encryptedfile.Manager
+
+	BeforeEach(func() {
+		manager = encryptedfile.Manager{}
+		_, err := flags.ParseArgs(&manager, []string{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("IsConfigured()", func() {
+		It("fails without a path", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes with a path", func() {
+			manager.Path = "/var/lib/concourse/secrets"
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		BeforeEach(func() {
+			manager.Path = "/var/lib/concourse/secrets"
+			manager.AgeIdentity = "AGE-SECRET-KEY-1"
+		})
+
+		It("passes on default templates", func() {
+			Expect(manager.PipelineSecretTemplate).To(Equal(encryptedfile.DefaultPipelineSecretTemplate))
+			Expect(manager.TeamSecretTemplate).To(Equal(encryptedfile.DefaultTeamSecretTemplate))
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("fails without any keys", func() {
+			manager.AgeIdentity = ""
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+
+		It("fails on a bad template", func() {
+			manager.TeamSecretTemplate = "/{{.Team}}/{{.Nope}}"
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+	})
+
+	Describe("NewInstance()", func() {
+		var factory creds.ManagerFactory
+
+		BeforeEach(func() {
+			factory = encryptedfile.NewManagerFactory()
+
+			clusterManager := factory.AddConfig(flags.NewParser(&struct{}{}, flags.None).Group).(*encryptedfile.Manager)
+			clusterManager.VarSourceRoot = "/var/lib/concourse/var-sources"
+		})
+
+		It("configures the manager from a var_source config", func() {
+			instance, err := factory.NewInstance(map[string]interface{}{
+				"path":         "secrets",
+				"aes_key":      "some-key",
+				"shared_path":  "shared",
+				"age_identity": "AGE-SECRET-KEY-1",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			manager := instance.(*encryptedfile.Manager)
+			Expect(string(manager.Path)).To(Equal("/var/lib/concourse/var-sources"))
+			Expect(manager.AESKey).To(Equal("some-key"))
+			Expect(manager.AgeIdentity).To(Equal("AGE-SECRET-KEY-1"))
+			Expect(manager.SharedPath).To(Equal("shared"))
+			Expect(manager.PipelineSecretTemplate).To(Equal(encryptedfile.DefaultPipelineSecretTemplate))
+		})
+
+		It("does not allow var_sources to read key files from the web node", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"path":         "secrets",
+				"aes_key_file": "/etc/concourse/aes.key",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("does not allow absolute paths", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"path":    "/var/lib/concourse/secrets",
+				"aes_key": "some-key",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("does not allow paths to climb out of the team's directory", func() {
+			for _, path := range []string{"..", "../other-team", "secrets/../../other-team"} {
+				_, err := factory.NewInstance(map[string]interface{}{
+					"path":    path,
+					"aes_key": "some-key",
+				})
+				Expect(err).To(HaveOccurred(), path)
+			}
+		})
+
+		Context("when no var source root is configured", func() {
+			BeforeEach(func() {
+				factory = encryptedfile.NewManagerFactory()
+			})
+
+			It("does not allow var_sources", func() {
+				_, err := factory.NewInstance(map[string]interface{}{
+					"aes_key": "some-key",
+				})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package encryptedfile
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// Secrets resolves secret paths to the directory they name below the root,
+// and the variable defined by the secrets files within it.
+type Secrets struct {
+	root            string
+	store           *store
+	lookupTemplates []*creds.SecretTemplate
+	sharedPath      string
+
+	teamDir    string
+	teamScoped bool
+}
+
+func (secrets *Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	for _, tmpl := range secrets.lookupTemplates {
+		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			lookupPaths = append(lookupPaths, lPath)
+		}
+	}
+	if secrets.sharedPath != "" {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(path.Join("/", secrets.sharedPath)+"/"))
+	}
+	if allowRootPath {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix("/"))
+	}
+	if secrets.teamScoped {
+		return secrets.teamLookupPaths(teamName, lookupPaths)
+	}
+	return lookupPaths
+}
+
+// teamLookupPaths confines the lookup paths to the team's directory, so that
+// the team can't read any other team's secrets.
+func (secrets *Secrets) teamLookupPaths(teamName string, lookupPaths []creds.SecretLookupPath) []creds.SecretLookupPath {
+	if teamName == "" || teamName == "." || teamName == ".." || strings.ContainsAny(teamName, `/\`) {
+		return []creds.SecretLookupPath{invalidTeamLookupPath{teamName}}
+	}
+
+	prefix := path.Join("/", teamName, secrets.teamDir)
+
+	confined := []creds.SecretLookupPath{}
+	for _, lPath := range lookupPaths {
+		confined = append(confined, teamLookupPath{prefix: prefix, lookupPath: lPath})
+	}
+	return confined
+}
+
+type invalidTeamLookupPath struct {
+	teamName string
+}
+
+func (lPath invalidTeamLookupPath) VariableToSecretPath(string) (string, error) {
+	return "", fmt.Errorf("team name '%s' cannot be used as a directory", lPath.teamName)
+}
+
+type teamLookupPath struct {
+	prefix     string
+	lookupPath creds.SecretLookupPath
+}
+
+func (lPath teamLookupPath) VariableToSecretPath(varName string) (string, error) {
+	secretPath, err := lPath.lookupPath.VariableToSecretPath(varName)
+	if err != nil {
+		return "", err
+	}
+
+	// cleaning the path on its own first means it can't climb out of the
+	// prefix
+	return path.Join(lPath.prefix, path.Clean("/"+secretPath)), nil
+}
+
+func (secrets *Secrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	secretPath = path.Clean("/" + secretPath)
+
+	dir := filepath.Join(secrets.root, filepath.FromSlash(path.Dir(secretPath)))
+
+	// secret paths include the variable name, so make sure they can't be
+	// used to escape the root
+	rel, err := filepath.Rel(secrets.root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, nil, false, nil
+	}
+
+	vars, err := secrets.store.vars(dir)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	value, found := vars[path.Base(secretPath)]
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return value, nil, true, nil
+}
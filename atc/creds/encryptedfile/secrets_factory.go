@@ -0,0 +1,39 @@
+package encryptedfile
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type secretsFactory struct {
+	secrets *Secrets
+}
+
+func NewSecretsFactory(root string, decrypters map[string]Decrypter, lookupTemplates []*creds.SecretTemplate, sharedPath string) creds.SecretsFactory {
+	return &secretsFactory{
+		secrets: &Secrets{
+			root:            root,
+			store:           newStore(decrypters),
+			lookupTemplates: lookupTemplates,
+			sharedPath:      sharedPath,
+		},
+	}
+}
+
+// NewTeamSecretsFactory is like NewSecretsFactory, except that each team only
+// reads from dir within the directory named after the team below the root.
+func NewTeamSecretsFactory(root string, dir string, decrypters map[string]Decrypter, lookupTemplates []*creds.SecretTemplate, sharedPath string) creds.SecretsFactory {
+	return &secretsFactory{
+		secrets: &Secrets{
+			root:            root,
+			store:           newStore(decrypters),
+			lookupTemplates: lookupTemplates,
+			sharedPath:      sharedPath,
+			teamDir:         dir,
+			teamScoped:      true,
+		},
+	}
+}
+
+func (factory *secretsFactory) NewSecrets() creds.Secrets {
+	return factory.secrets
+}
@@ -0,0 +1,253 @@
+package encryptedfile_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"filippo.io/age"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/encryptedfile"
+	"github.com/concourse/concourse/vars"
+	"github.com/concourse/flag"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets", func() {
+	var (
+		root     string
+		identity *age.X25519Identity
+		aesKey   []byte
+		secrets  creds.Secrets
+	)
+
+	writeAge := func(file string, content string) {
+		buf := new(bytes.Buffer)
+		writer, err := age.Encrypt(buf, identity.Recipient())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = writer.Write([]byte(content))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+
+		Expect(os.MkdirAll(filepath.Dir(filepath.Join(root, file)), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(root, file), buf.Bytes(), 0600)).To(Succeed())
+	}
+
+	writeAES := func(file string, content string) {
+		block, err := aes.NewCipher(aesKey)
+		Expect(err).ToNot(HaveOccurred())
+		aead, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		nonce := make([]byte, aead.NonceSize())
+		_, err = rand.Read(nonce)
+		Expect(err).ToNot(HaveOccurred())
+
+		sealed := aead.Seal(nonce, nonce, []byte(content), nil)
+
+		Expect(os.MkdirAll(filepath.Dir(filepath.Join(root, file)), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(root, file), sealed, 0600)).To(Succeed())
+	}
+
+	get := func(variables vars.Variables, path string, fields ...string) (interface{}, bool) {
+		value, found, err := variables.Get(vars.Reference{Path: path, Fields: fields})
+		Expect(err).ToNot(HaveOccurred())
+		return value, found
+	}
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "encrypted-file-creds")
+		Expect(err).ToNot(HaveOccurred())
+
+		identity, err = age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+
+		aesKey = make([]byte, 32)
+		_, err = rand.Read(aesKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		manager := &encryptedfile.Manager{
+			Path:                   flag.Dir(root),
+			AgeIdentity:            identity.String(),
+			AESKey:                 hex.EncodeToString(aesKey),
+			PipelineSecretTemplate: encryptedfile.DefaultPipelineSecretTemplate,
+			TeamSecretTemplate:     encryptedfile.DefaultTeamSecretTemplate,
+			SharedPath:             "shared",
+		}
+
+		factory, err := manager.NewSecretsFactory(lagertest.NewTestLogger("test"))
+		Expect(err).ToNot(HaveOccurred())
+
+		secrets = factory.NewSecrets()
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	It("finds pipeline secrets before team and shared secrets", func() {
+		writeAge("some-team/some-pipeline/secrets.yml.age", "foo: pipeline-foo\n")
+		writeAge("some-team/secrets.yml.age", "foo: team-foo\nbar: team-bar\n")
+		writeAES("shared/secrets.yaml.enc", "foo: shared-foo\nbar: shared-bar\nbaz: shared-baz\n")
+
+		variables := creds.NewVariables(secrets, "some-team", "some-pipeline", false)
+
+		value, found := get(variables, "foo")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("pipeline-foo"))
+
+		value, found = get(variables, "bar")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-bar"))
+
+		value, found = get(variables, "baz")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("shared-baz"))
+	})
+
+	It("supports structured values", func() {
+		writeAES("some-team/secrets.yml.enc", "db:\n  username: admin\n  password: s3cr3t\n")
+
+		variables := creds.NewVariables(secrets, "some-team", "some-pipeline", false)
+
+		value, found := get(variables, "db", "password")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("s3cr3t"))
+	})
+
+	It("merges the files of a directory in order of their names", func() {
+		writeAge("some-team/a.yml.age", "foo: from-a\nbar: from-a\n")
+		writeAge("some-team/b.yml.age", "foo: from-b\n")
+
+		variables := creds.NewVariables(secrets, "some-team", "", false)
+
+		value, _ := get(variables, "foo")
+		Expect(value).To(Equal("from-b"))
+
+		value, _ = get(variables, "bar")
+		Expect(value).To(Equal("from-a"))
+	})
+
+	It("ignores files which are not encrypted YAML", func() {
+		Expect(os.MkdirAll(filepath.Join(root, "some-team"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(root, "some-team", "plain.yml"), []byte("foo: plain\n"), 0600)).To(Succeed())
+
+		variables := creds.NewVariables(secrets, "some-team", "", false)
+
+		_, found := get(variables, "foo")
+		Expect(found).To(BeFalse())
+	})
+
+	It("picks up changes to the files", func() {
+		writeAge("some-team/secrets.yml.age", "foo: before\n")
+
+		variables := creds.NewVariables(secrets, "some-team", "", false)
+
+		value, _ := get(variables, "foo")
+		Expect(value).To(Equal("before"))
+
+		writeAge("some-team/secrets.yml.age", "foo: after\n")
+		future := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(filepath.Join(root, "some-team", "secrets.yml.age"), future, future)).To(Succeed())
+
+		value, _ = get(variables, "foo")
+		Expect(value).To(Equal("after"))
+	})
+
+	It("only looks in the root when it is allowed", func() {
+		writeAge("secrets.yml.age", "foo: root-foo\n")
+
+		_, found := get(creds.NewVariables(secrets, "some-team", "some-pipeline", false), "foo")
+		Expect(found).To(BeFalse())
+
+		value, found := get(creds.NewVariables(secrets, "some-team", "some-pipeline", true), "foo")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("root-foo"))
+	})
+
+	It("does not look outside of the directory", func() {
+		_, _, found, err := secrets.Get("/../../etc/foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when read by a var_source", func() {
+		BeforeEach(func() {
+			factory := encryptedfile.NewManagerFactory()
+
+			clusterManager := factory.AddConfig(flags.NewParser(&struct{}{}, flags.None).Group).(*encryptedfile.Manager)
+			clusterManager.VarSourceRoot = flag.Dir(root)
+
+			manager, err := factory.NewInstance(map[string]interface{}{
+				"path":         "some-dir",
+				"age_identity": identity.String(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			secretsFactory, err := manager.NewSecretsFactory(lagertest.NewTestLogger("test"))
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets = secretsFactory.NewSecrets()
+		})
+
+		It("reads from the path within the team's directory", func() {
+			writeAge("some-team/some-dir/some-team/some-pipeline/secrets.yml.age", "foo: pipeline-foo\n")
+			writeAge("some-team/some-dir/secrets.yml.age", "bar: root-bar\n")
+
+			variables := creds.NewVariables(secrets, "some-team", "some-pipeline", true)
+
+			value, found := get(variables, "foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-foo"))
+
+			value, found = get(variables, "/bar")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("root-bar"))
+		})
+
+		It("can't read the secrets of other teams", func() {
+			writeAge("other-team/secrets.yml.age", "foo: other-team-foo\n")
+			writeAge("other-team/some-dir/secrets.yml.age", "foo: other-team-foo\n")
+
+			variables := creds.NewVariables(secrets, "some-team", "some-pipeline", true)
+
+			for _, varName := range []string{
+				"/other-team/foo",
+				"/other-team/some-dir/foo",
+				"/../other-team/foo",
+				"/../../other-team/some-dir/foo",
+				"../../other-team/foo",
+			} {
+				_, found := get(variables, varName)
+				Expect(found).To(BeFalse(), varName)
+			}
+
+			for _, teamName := range []string{"..", ".", "other-team/..", ""} {
+				_, _, err := creds.NewVariables(secrets, teamName, "", true).Get(vars.Reference{Path: "/other-team/foo"})
+				Expect(err).To(HaveOccurred(), teamName)
+			}
+		})
+	})
+
+	It("returns an error when a file can't be decrypted", func() {
+		other, err := age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+		identity = other
+
+		writeAge("some-team/secrets.yml.age", "foo: bar\n")
+
+		_, _, err = creds.NewVariables(secrets, "some-team", "", false).Get(vars.Reference{Path: "foo"})
+		Expect(err).To(MatchError(ContainSubstring("decrypt")))
+	})
+})
@@ -0,0 +1,131 @@
+package encryptedfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// store decrypts the secrets files of each directory, keeping them in memory
+// until any of the files in the directory change.
+type store struct {
+	decrypters map[string]Decrypter
+
+	lock sync.Mutex
+	dirs map[string]dirEntry
+}
+
+type dirEntry struct {
+	signature string
+	vars      map[string]interface{}
+}
+
+func newStore(decrypters map[string]Decrypter) *store {
+	return &store{
+		decrypters: decrypters,
+		dirs:       map[string]dirEntry{},
+	}
+}
+
+// vars returns the variables defined by the secrets files in the directory,
+// merged in lexical order of their file names.
+func (s *store) vars(dir string) (map[string]interface{}, error) {
+	files, signature, err := s.files(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry, found := s.dirs[dir]
+	if found && entry.signature == signature {
+		return entry.vars, nil
+	}
+
+	merged := map[string]interface{}{}
+	for _, file := range files {
+		vars, err := s.decrypt(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range vars {
+			merged[name] = value
+		}
+	}
+
+	s.dirs[dir] = dirEntry{
+		signature: signature,
+		vars:      merged,
+	}
+
+	return merged, nil
+}
+
+// files lists the secrets files in the directory which can be decrypted,
+// along with a signature that changes whenever any of them do.
+func (s *store) files(dir string) ([]string, string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+
+		return nil, "", err
+	}
+
+	var files []string
+	var signature strings.Builder
+	for _, info := range infos {
+		if info.IsDir() || s.decrypter(info.Name()) == nil {
+			continue
+		}
+
+		files = append(files, info.Name())
+		fmt.Fprintf(&signature, "%s:%d:%d;", info.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+
+	sort.Strings(files)
+
+	return files, signature.String(), nil
+}
+
+func (s *store) decrypt(file string) (map[string]interface{}, error) {
+	ciphertext, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := s.decrypter(file).Decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", file, err)
+	}
+
+	var vars map[string]interface{}
+	err = yaml.Unmarshal(plaintext, &vars)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+
+	return vars, nil
+}
+
+// decrypter returns the decrypter for files with the name's extension, if
+// it names an encrypted YAML file.
+func (s *store) decrypter(name string) Decrypter {
+	ext := filepath.Ext(name)
+
+	base := strings.TrimSuffix(name, ext)
+	if filepath.Ext(base) != ".yml" && filepath.Ext(base) != ".yaml" {
+		return nil
+	}
+
+	return s.decrypters[ext]
+}
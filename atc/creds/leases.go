@@ -0,0 +1,185 @@
+package creds
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLeasesUnsupported is returned when renewing or revoking a lease through
+// a secret manager which does not generate dynamic secrets.
+var ErrLeasesUnsupported = errors.New("credential manager does not support leases")
+
+// A Lease is held on a dynamic secret, e.g. database credentials generated by
+// Vault, until it is revoked or expires.
+type Lease struct {
+	ID        string
+	Duration  time.Duration
+	Renewable bool
+	ExpiresAt time.Time
+}
+
+//counterfeiter:generate . LeasingSecrets
+type LeasingSecrets interface {
+	Secrets
+
+	// GetLeased behaves like Get, additionally returning the lease held on
+	// the secret if it is a dynamic secret. Every read of a dynamic secret
+	// creates a new lease.
+	GetLeased(string) (interface{}, *time.Time, *Lease, bool, error)
+
+	// RenewLease extends the lease by its duration, returning the renewed
+	// lease.
+	RenewLease(Lease) (Lease, error)
+
+	// RevokeLease revokes the lease, invalidating the secret.
+	RevokeLease(string) error
+}
+
+//counterfeiter:generate . LeaseTracker
+type LeaseTracker interface {
+	TrackSecretLease(Lease) error
+}
+
+// LeaseTrackingSecrets reads secrets on behalf of a single build. Dynamic
+// secrets are read once per build, so that every reference to them sees the
+// same credentials, and their leases are handed to the tracker so that they
+// can be renewed and revoked along with the build.
+type LeaseTrackingSecrets struct {
+	secrets LeasingSecrets
+	tracker LeaseTracker
+
+	leasedLock sync.Mutex
+	leased     map[string]interface{}
+}
+
+func NewLeaseTrackingSecrets(secrets LeasingSecrets, tracker LeaseTracker) *LeaseTrackingSecrets {
+	return &LeaseTrackingSecrets{
+		secrets: secrets,
+		tracker: tracker,
+		leased:  map[string]interface{}{},
+	}
+}
+
+func (ls *LeaseTrackingSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	ls.leasedLock.Lock()
+	defer ls.leasedLock.Unlock()
+
+	value, found := ls.leased[secretPath]
+	if found {
+		return value, nil, true, nil
+	}
+
+	value, expiration, lease, found, err := ls.secrets.GetLeased(secretPath)
+	if err != nil || !found || lease == nil {
+		return value, expiration, found, err
+	}
+
+	err = ls.tracker.TrackSecretLease(*lease)
+	if err != nil {
+		// an untracked lease would outlive the build, so give it up right away
+		_ = ls.secrets.RevokeLease(lease.ID)
+		return nil, nil, false, err
+	}
+
+	ls.leased[secretPath] = value
+
+	return value, nil, true, nil
+}
+
+func (ls *LeaseTrackingSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
+	return ls.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
+}
+
+// TransientSecrets reads secrets on behalf of something short-lived which is
+// not a build, e.g. an API request. Dynamic secrets are leased as they are
+// for builds, but the leases are only held until RevokeLeases is called.
+type TransientSecrets struct {
+	Secrets
+
+	leasing LeasingSecrets
+	leases  *leaseList
+}
+
+func NewTransientSecrets(secrets Secrets) *TransientSecrets {
+	leasing, ok := secrets.(LeasingSecrets)
+	if !ok {
+		return &TransientSecrets{Secrets: secrets}
+	}
+
+	leases := &leaseList{}
+
+	return &TransientSecrets{
+		Secrets: NewLeaseTrackingSecrets(leasing, leases),
+		leasing: leasing,
+		leases:  leases,
+	}
+}
+
+// RevokeLeases revokes the leases on every dynamic secret read so far,
+// returning the first error encountered. Leases which fail to be revoked
+// are left to expire.
+func (ts *TransientSecrets) RevokeLeases() error {
+	if ts.leases == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, lease := range ts.leases.take() {
+		err := ts.leasing.RevokeLease(lease.ID)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+type leaseList struct {
+	lock   sync.Mutex
+	leases []Lease
+}
+
+func (l *leaseList) TrackSecretLease(lease Lease) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.leases = append(l.leases, lease)
+
+	return nil
+}
+
+func (l *leaseList) take() []Lease {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	leases := l.leases
+	l.leases = nil
+
+	return leases
+}
+
+func getLeased(secrets Secrets, secretPath string) (interface{}, *time.Time, *Lease, bool, error) {
+	if leasing, ok := secrets.(LeasingSecrets); ok {
+		return leasing.GetLeased(secretPath)
+	}
+
+	value, expiration, found, err := secrets.Get(secretPath)
+	return value, expiration, nil, found, err
+}
+
+func renewLease(secrets Secrets, lease Lease) (Lease, error) {
+	if leasing, ok := secrets.(LeasingSecrets); ok {
+		return leasing.RenewLease(lease)
+	}
+
+	return Lease{}, ErrLeasesUnsupported
+}
+
+func revokeLease(secrets Secrets, leaseID string) error {
+	if leasing, ok := secrets.(LeasingSecrets); ok {
+		return leasing.RevokeLease(leaseID)
+	}
+
+	return ErrLeasesUnsupported
+}
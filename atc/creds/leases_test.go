@@ -0,0 +1,180 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LeaseTrackingSecrets", func() {
+	var (
+		fakeSecrets *credsfakes.FakeLeasingSecrets
+		fakeTracker *credsfakes.FakeLeaseTracker
+		secrets     *creds.LeaseTrackingSecrets
+	)
+
+	BeforeEach(func() {
+		fakeSecrets = new(credsfakes.FakeLeasingSecrets)
+		fakeTracker = new(credsfakes.FakeLeaseTracker)
+
+		secrets = creds.NewLeaseTrackingSecrets(fakeSecrets, fakeTracker)
+	})
+
+	Context("when the secret is static", func() {
+		var expiration time.Time
+
+		BeforeEach(func() {
+			expiration = time.Now().Add(time.Hour)
+			fakeSecrets.GetLeasedReturns("static-value", &expiration, nil, true, nil)
+		})
+
+		It("returns it without tracking anything", func() {
+			value, exp, found, err := secrets.Get("some/path")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("static-value"))
+			Expect(exp).To(Equal(&expiration))
+
+			Expect(fakeTracker.TrackSecretLeaseCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the secret is dynamic", func() {
+		var lease creds.Lease
+
+		BeforeEach(func() {
+			lease = creds.Lease{
+				ID:        "database/creds/some-role/some-lease",
+				Duration:  time.Hour,
+				Renewable: true,
+			}
+
+			fakeSecrets.GetLeasedReturns(map[string]interface{}{"username": "v-some-user"}, nil, &lease, true, nil)
+		})
+
+		It("tracks the lease", func() {
+			_, _, found, err := secrets.Get("database/creds/some-role")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fakeTracker.TrackSecretLeaseCallCount()).To(Equal(1))
+			Expect(fakeTracker.TrackSecretLeaseArgsForCall(0)).To(Equal(lease))
+		})
+
+		It("reads it only once, so that every reference sees the same credentials", func() {
+			first, _, _, err := secrets.Get("database/creds/some-role")
+			Expect(err).ToNot(HaveOccurred())
+
+			second, _, _, err := secrets.Get("database/creds/some-role")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(second).To(Equal(first))
+			Expect(fakeSecrets.GetLeasedCallCount()).To(Equal(1))
+			Expect(fakeTracker.TrackSecretLeaseCallCount()).To(Equal(1))
+		})
+
+		Context("when tracking the lease fails", func() {
+			BeforeEach(func() {
+				fakeTracker.TrackSecretLeaseReturns(errors.New("db unavailable"))
+			})
+
+			It("revokes the lease and returns the error", func() {
+				_, _, found, err := secrets.Get("database/creds/some-role")
+				Expect(err).To(MatchError("db unavailable"))
+				Expect(found).To(BeFalse())
+
+				Expect(fakeSecrets.RevokeLeaseCallCount()).To(Equal(1))
+				Expect(fakeSecrets.RevokeLeaseArgsForCall(0)).To(Equal(lease.ID))
+			})
+		})
+	})
+})
+
+var _ = Describe("TransientSecrets", func() {
+	var (
+		fakeSecrets *credsfakes.FakeLeasingSecrets
+		secrets     *creds.TransientSecrets
+		lease       creds.Lease
+	)
+
+	BeforeEach(func() {
+		fakeSecrets = new(credsfakes.FakeLeasingSecrets)
+
+		lease = creds.Lease{
+			ID:       "database/creds/some-role/some-lease",
+			Duration: time.Hour,
+		}
+
+		fakeSecrets.GetLeasedStub = func(path string) (interface{}, *time.Time, *creds.Lease, bool, error) {
+			if path == "static/path" {
+				return "static-value", nil, nil, true, nil
+			}
+
+			return map[string]interface{}{"username": "v-some-user"}, nil, &lease, true, nil
+		}
+
+		secrets = creds.NewTransientSecrets(fakeSecrets)
+	})
+
+	It("revokes the leases on the dynamic secrets it read", func() {
+		_, _, found, err := secrets.Get("static/path")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		_, _, found, err = secrets.Get("database/creds/some-role")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		Expect(fakeSecrets.RevokeLeaseCallCount()).To(BeZero())
+
+		err = secrets.RevokeLeases()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeSecrets.RevokeLeaseCallCount()).To(Equal(1))
+		Expect(fakeSecrets.RevokeLeaseArgsForCall(0)).To(Equal(lease.ID))
+	})
+
+	It("revokes each lease only once", func() {
+		_, _, _, err := secrets.Get("database/creds/some-role")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(secrets.RevokeLeases()).To(Succeed())
+		Expect(secrets.RevokeLeases()).To(Succeed())
+
+		Expect(fakeSecrets.RevokeLeaseCallCount()).To(Equal(1))
+	})
+
+	Context("when revoking a lease fails", func() {
+		BeforeEach(func() {
+			fakeSecrets.RevokeLeaseReturns(errors.New("vault unavailable"))
+		})
+
+		It("returns the error", func() {
+			_, _, _, err := secrets.Get("database/creds/some-role")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(secrets.RevokeLeases()).To(MatchError("vault unavailable"))
+		})
+	})
+
+	Context("when the secrets cannot be leased", func() {
+		It("reads them as they are", func() {
+			fakeStatic := new(credsfakes.FakeSecrets)
+			fakeStatic.GetReturns("static-value", nil, true, nil)
+
+			secrets = creds.NewTransientSecrets(fakeStatic)
+
+			value, _, found, err := secrets.Get("static/path")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("static-value"))
+
+			Expect(secrets.RevokeLeases()).To(Succeed())
+		})
+	})
+})
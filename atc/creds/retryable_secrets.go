@@ -23,20 +23,36 @@ func NewRetryableSecrets(secrets Secrets, retryConfig SecretRetryConfig) Secrets
 
 // Get retrieves the value and expiration of an individual secret
 func (rs RetryableSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	result, expiration, _, exists, err := rs.GetLeased(secretPath)
+	return result, expiration, exists, err
+}
+
+// GetLeased retrieves the value, expiration and lease of an individual secret
+func (rs RetryableSecrets) GetLeased(secretPath string) (interface{}, *time.Time, *Lease, bool, error) {
 	r := &retryhttp.DefaultRetryer{}
 	for i := 0; i < rs.retryConfig.Attempts-1; i++ {
-		result, expiration, exists, err := rs.secrets.Get(secretPath)
+		result, expiration, lease, exists, err := getLeased(rs.secrets, secretPath)
 		if err != nil && r.IsRetryable(err) {
 			time.Sleep(rs.retryConfig.Interval)
 			continue
 		}
-		return result, expiration, exists, err
+		return result, expiration, lease, exists, err
 	}
-	result, expiration, exists, err := rs.secrets.Get(secretPath)
+	result, expiration, lease, exists, err := getLeased(rs.secrets, secretPath)
 	if err != nil {
 		err = fmt.Errorf("%s (after %d retries)", err, rs.retryConfig.Attempts)
 	}
-	return result, expiration, exists, err
+	return result, expiration, lease, exists, err
+}
+
+// RenewLease extends the lease held on a dynamic secret
+func (rs RetryableSecrets) RenewLease(lease Lease) (Lease, error) {
+	return renewLease(rs.secrets, lease)
+}
+
+// RevokeLease revokes the lease held on a dynamic secret
+func (rs RetryableSecrets) RevokeLease(leaseID string) error {
+	return revokeLease(rs.secrets, leaseID)
 }
 
 // NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
@@ -0,0 +1,66 @@
+package creds
+
+// SecretAccess describes a var which was found in a credential manager. It
+// never includes the value of the secret.
+type SecretAccess struct {
+	// Var is the name of the var, without its fields.
+	Var string
+
+	// VarSource is the name of the pipeline's var_source the var was read
+	// through, or empty for the cluster-wide credential manager.
+	VarSource string
+
+	// Backend is the type of credential manager the secret was found in.
+	Backend string
+
+	// SecretPath is the lookup path at which the secret was found.
+	SecretPath string
+}
+
+//counterfeiter:generate . SecretAccessRecorder
+type SecretAccessRecorder interface {
+	RecordSecretAccess(SecretAccess)
+}
+
+// AccessRecordingSecrets tells a recorder where each var looked up through
+// it was found. The secrets themselves are passed through as-is.
+type AccessRecordingSecrets struct {
+	Secrets
+
+	recorder  SecretAccessRecorder
+	varSource string
+	backend   string
+}
+
+func NewAccessRecordingSecrets(secrets Secrets, recorder SecretAccessRecorder, backend string) *AccessRecordingSecrets {
+	return &AccessRecordingSecrets{
+		Secrets:  secrets,
+		recorder: recorder,
+		backend:  backend,
+	}
+}
+
+// ForVarSource wraps the secrets of a pipeline's var_source, so that vars
+// read through it are reported to the same recorder.
+func (s *AccessRecordingSecrets) ForVarSource(name string, backend string, secrets Secrets) *AccessRecordingSecrets {
+	return &AccessRecordingSecrets{
+		Secrets:   secrets,
+		recorder:  s.recorder,
+		varSource: name,
+		backend:   backend,
+	}
+}
+
+func recordAccess(secrets Secrets, varName string, secretPath string) {
+	recording, ok := secrets.(*AccessRecordingSecrets)
+	if !ok {
+		return
+	}
+
+	recording.recorder.RecordSecretAccess(SecretAccess{
+		Var:        varName,
+		VarSource:  recording.varSource,
+		Backend:    recording.backend,
+		SecretPath: secretPath,
+	})
+}
@@ -0,0 +1,90 @@
+package creds_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AccessRecordingSecrets", func() {
+	var (
+		fakeSecrets  *credsfakes.FakeSecrets
+		fakeRecorder *credsfakes.FakeSecretAccessRecorder
+		secrets      *creds.AccessRecordingSecrets
+	)
+
+	BeforeEach(func() {
+		fakeSecrets = new(credsfakes.FakeSecrets)
+		fakeRecorder = new(credsfakes.FakeSecretAccessRecorder)
+
+		template, err := creds.BuildSecretTemplate("pipeline", "/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}")
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeSecrets.NewSecretLookupPathsStub = func(team string, pipeline string, _ bool) []creds.SecretLookupPath {
+			return []creds.SecretLookupPath{
+				creds.NewSecretLookupWithTemplate(template, team, pipeline),
+				creds.NewSecretLookupWithPrefix("/concourse/shared/"),
+			}
+		}
+
+		fakeSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+			if path == "/concourse/shared/db-password" {
+				return map[string]interface{}{"password": "hunter2"}, nil, true, nil
+			}
+
+			return nil, nil, false, nil
+		}
+
+		secrets = creds.NewAccessRecordingSecrets(fakeSecrets, fakeRecorder, "vault")
+	})
+
+	It("records the path at which vars are found", func() {
+		variables := creds.NewVariables(secrets, "some-team", "some-pipeline", false)
+
+		value, found, err := variables.Get(vars.Reference{Path: "db-password", Fields: []string{"password"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("hunter2"))
+
+		Expect(fakeRecorder.RecordSecretAccessCallCount()).To(Equal(1))
+		Expect(fakeRecorder.RecordSecretAccessArgsForCall(0)).To(Equal(creds.SecretAccess{
+			Var:        "db-password",
+			Backend:    "vault",
+			SecretPath: "/concourse/shared/db-password",
+		}))
+	})
+
+	It("records nothing for vars which aren't found", func() {
+		variables := creds.NewVariables(secrets, "some-team", "some-pipeline", false)
+
+		_, found, err := variables.Get(vars.Reference{Path: "bogus"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		Expect(fakeRecorder.RecordSecretAccessCallCount()).To(BeZero())
+	})
+
+	It("records the var source of vars read through one", func() {
+		varSourceSecrets := new(credsfakes.FakeSecrets)
+		varSourceSecrets.GetReturns("some-value", nil, true, nil)
+
+		variables := creds.NewVariables(secrets.ForVarSource("some-source", "ssm", varSourceSecrets), "some-team", "some-pipeline", true)
+
+		_, found, err := variables.Get(vars.Reference{Source: "some-source", Path: "some-var"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		Expect(fakeRecorder.RecordSecretAccessCallCount()).To(Equal(1))
+		Expect(fakeRecorder.RecordSecretAccessArgsForCall(0)).To(Equal(creds.SecretAccess{
+			Var:        "some-var",
+			VarSource:  "some-source",
+			Backend:    "ssm",
+			SecretPath: "some-var",
+		}))
+	})
+})
@@ -35,6 +35,9 @@ func (sl VariableLookupFromSecrets) get(path string) (interface{}, bool, error)
 	if len(sl.LookupPaths) == 0 {
 		// if no paths are specified (i.e. for fake & noop secret managers), then try 1-to-1 var->secret mapping
 		result, _, found, err := sl.Secrets.Get(path)
+		if found {
+			recordAccess(sl.Secrets, path, path)
+		}
 		return result, found, err
 	}
 	// try to find a secret according to our var->secret lookup paths
@@ -51,6 +54,7 @@ func (sl VariableLookupFromSecrets) get(path string) (interface{}, bool, error)
 		if !found {
 			continue
 		}
+		recordAccess(sl.Secrets, path, secretPath)
 		return result, true, nil
 	}
 	return nil, false, nil
@@ -90,6 +90,17 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 	return secret, err
 }
 
+// RenewLease extends the lease held on a dynamic secret by the given
+// increment.
+func (ac *APIClient) RenewLease(leaseID string, increment time.Duration) (*vaultapi.Secret, error) {
+	return ac.client().Sys().Renew(leaseID, int(increment.Seconds()))
+}
+
+// RevokeLease revokes the lease held on a dynamic secret.
+func (ac *APIClient) RevokeLease(leaseID string) error {
+	return ac.client().Sys().Revoke(leaseID)
+}
+
 func (ac *APIClient) loginParams() map[string]interface{} {
 	loginParams := make(map[string]interface{})
 	for k, v := range ac.authConfig.Params {
@@ -1,6 +1,7 @@
 package vault
 
 import (
+	"errors"
 	"path"
 	"time"
 
@@ -21,10 +22,18 @@ type SecretReader interface {
 	Read(path string) (*vaultapi.Secret, error)
 }
 
+// A LeaseManager renews and revokes the leases held on dynamic secrets,
+// such as those generated by the database, AWS and PKI secret engines.
+type LeaseManager interface {
+	RenewLease(leaseID string, increment time.Duration) (*vaultapi.Secret, error)
+	RevokeLease(leaseID string) error
+}
+
 // Vault converts a vault secret to our completely untyped secret
 // data.
 type Vault struct {
 	SecretReader    SecretReader
+	LeaseManager    LeaseManager
 	Prefix          string
 	LookupTemplates []*creds.SecretTemplate
 	SharedPath      string
@@ -51,28 +60,81 @@ func (v Vault) NewSecretLookupPaths(teamName string, pipelineName string, allowR
 
 // Get retrieves the value and expiration of an individual secret
 func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	val, expiration, _, found, err := v.GetLeased(secretPath)
+	return val, expiration, found, err
+}
+
+// GetLeased retrieves the value and expiration of an individual secret,
+// along with the lease held on it if it was generated by a dynamic secret
+// engine
+func (v Vault) GetLeased(secretPath string) (interface{}, *time.Time, *creds.Lease, bool, error) {
 	if v.LoggedIn != nil {
 		select {
 		case <-v.LoggedIn:
 		case <-time.After(v.LoginTimeout):
-			return nil, nil, false, VaultLoginTimeout{}
+			return nil, nil, nil, false, VaultLoginTimeout{}
 		}
 	}
 
 	secret, expiration, found, err := v.findSecret(secretPath)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, nil, false, err
 	}
 	if !found {
-		return nil, nil, false, nil
+		return nil, nil, nil, false, nil
+	}
+
+	// static secrets (i.e. kv) carry a lease duration as a refresh hint, but
+	// only dynamic secrets come with a lease which can be renewed and revoked
+	var lease *creds.Lease
+	if secret.LeaseID != "" {
+		lease = newLease(secret)
 	}
 
 	val, found := secret.Data["value"]
 	if found {
-		return val, expiration, true, nil
+		return val, expiration, lease, true, nil
+	}
+
+	return secret.Data, expiration, lease, true, nil
+}
+
+// RenewLease extends the lease held on a dynamic secret by its duration
+func (v Vault) RenewLease(lease creds.Lease) (creds.Lease, error) {
+	if v.LeaseManager == nil {
+		return creds.Lease{}, creds.ErrLeasesUnsupported
+	}
+
+	secret, err := v.LeaseManager.RenewLease(lease.ID, lease.Duration)
+	if err != nil {
+		return creds.Lease{}, err
+	}
+
+	if secret == nil {
+		return creds.Lease{}, errors.New("no lease returned when renewing")
+	}
+
+	return *newLease(secret), nil
+}
+
+// RevokeLease revokes the lease held on a dynamic secret, invalidating it
+func (v Vault) RevokeLease(leaseID string) error {
+	if v.LeaseManager == nil {
+		return creds.ErrLeasesUnsupported
 	}
 
-	return secret.Data, expiration, true, nil
+	return v.LeaseManager.RevokeLease(leaseID)
+}
+
+func newLease(secret *vaultapi.Secret) *creds.Lease {
+	duration := time.Duration(secret.LeaseDuration) * time.Second
+
+	return &creds.Lease{
+		ID:        secret.LeaseID,
+		Duration:  duration,
+		Renewable: secret.Renewable,
+		ExpiresAt: time.Now().Add(duration),
+	}
 }
 
 func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, error) {
@@ -30,8 +30,11 @@ func NewVaultFactory(sr SecretReader, loginTimeout time.Duration, loggedIn <-cha
 }
 
 func (factory *vaultFactory) NewSecrets() creds.Secrets {
+	leaseManager, _ := factory.sr.(LeaseManager)
+
 	return &Vault{
 		SecretReader:    factory.sr,
+		LeaseManager:    leaseManager,
 		Prefix:          factory.prefix,
 		LookupTemplates: factory.lookupTemplates,
 		SharedPath:      factory.sharedPath,
@@ -302,6 +302,116 @@ var _ = Describe("Vault", func() {
 	})
 })
 
+type MockLeaseManager struct {
+	renewed []string
+	revoked []string
+}
+
+func (mlm *MockLeaseManager) RenewLease(leaseID string, increment time.Duration) (*vaultapi.Secret, error) {
+	mlm.renewed = append(mlm.renewed, leaseID)
+
+	return &vaultapi.Secret{
+		LeaseID:       leaseID,
+		LeaseDuration: int(increment.Seconds()),
+		Renewable:     true,
+	}, nil
+}
+
+func (mlm *MockLeaseManager) RevokeLease(leaseID string) error {
+	mlm.revoked = append(mlm.revoked, leaseID)
+	return nil
+}
+
+var _ = Describe("Vault dynamic secrets", func() {
+	var v *vault.Vault
+	var mlm *MockLeaseManager
+
+	BeforeEach(func() {
+		mlm = &MockLeaseManager{}
+
+		v = &vault.Vault{
+			SecretReader: &MockSecretReader{&[]MockSecret{
+				{
+					path: "/concourse/team/foo",
+					secret: &vaultapi.Secret{
+						LeaseDuration: 2764800,
+						Data:          map[string]interface{}{"value": "bar"},
+					},
+				},
+				{
+					path: "/database/creds/some-role",
+					secret: &vaultapi.Secret{
+						LeaseID:       "database/creds/some-role/some-lease",
+						LeaseDuration: 3600,
+						Renewable:     true,
+						Data: map[string]interface{}{
+							"username": "v-some-user",
+							"password": "some-password",
+						},
+					},
+				},
+			}},
+			LeaseManager: mlm,
+			Prefix:       "/concourse",
+		}
+	})
+
+	Describe("GetLeased()", func() {
+		It("returns no lease for static secrets", func() {
+			value, _, lease, found, err := v.GetLeased("/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+			Expect(lease).To(BeNil())
+		})
+
+		It("returns the lease held on dynamic secrets", func() {
+			value, _, lease, found, err := v.GetLeased("/database/creds/some-role")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{
+				"username": "v-some-user",
+				"password": "some-password",
+			}))
+
+			Expect(lease).ToNot(BeNil())
+			Expect(lease.ID).To(Equal("database/creds/some-role/some-lease"))
+			Expect(lease.Duration).To(Equal(time.Hour))
+			Expect(lease.Renewable).To(BeTrue())
+			Expect(lease.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		})
+	})
+
+	Describe("RenewLease()", func() {
+		It("renews the lease by its duration", func() {
+			renewed, err := v.RenewLease(creds.Lease{ID: "some-lease", Duration: time.Hour})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mlm.renewed).To(Equal([]string{"some-lease"}))
+			Expect(renewed.Duration).To(Equal(time.Hour))
+			Expect(renewed.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		})
+
+		Context("without a lease manager", func() {
+			BeforeEach(func() {
+				v.LeaseManager = nil
+			})
+
+			It("returns an error", func() {
+				_, err := v.RenewLease(creds.Lease{ID: "some-lease"})
+				Expect(err).To(Equal(creds.ErrLeasesUnsupported))
+			})
+		})
+	})
+
+	Describe("RevokeLease()", func() {
+		It("revokes the lease", func() {
+			err := v.RevokeLease("some-lease")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mlm.revoked).To(Equal([]string{"some-lease"}))
+		})
+	})
+})
+
 // The below tests use ghttp handlers to mock a real vault API to the api_client.
 // AppendHandlers has the following behavior which make the tests a bit messy.
 
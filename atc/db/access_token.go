@@ -1,9 +1,11 @@
 package db
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"gopkg.in/square/go-jose.v2/jwt"
 )
@@ -17,6 +19,66 @@ func scanAccessToken(rcv *AccessToken, scan scannable) error {
 	return scan.Scan(&rcv.Token, &rcv.Claims)
 }
 
+// Session describes an access token without revealing the token itself.
+type Session struct {
+	ID         int
+	Sub        string
+	UserName   string
+	Connector  string
+	ClientID   string
+	RemoteAddr string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+}
+
+var sessionColumns = []string{
+	"a.id",
+	"a.sub",
+	"u.username",
+	"a.claims",
+	"a.client_id",
+	"a.remote_addr",
+	"a.created_at",
+	"a.last_used_at",
+	"a.expires_at",
+}
+
+func scanSession(rcv *Session, scan scannable) error {
+	var (
+		userName, clientID, remoteAddr sql.NullString
+		lastUsedAt                     sql.NullTime
+		claims                         Claims
+	)
+
+	err := scan.Scan(
+		&rcv.ID,
+		&rcv.Sub,
+		&userName,
+		&claims,
+		&clientID,
+		&remoteAddr,
+		&rcv.CreatedAt,
+		&lastUsedAt,
+		&rcv.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rcv.UserName = userName.String
+	if rcv.UserName == "" {
+		rcv.UserName = claims.Username
+	}
+
+	rcv.Connector = claims.Connector
+	rcv.ClientID = clientID.String
+	rcv.RemoteAddr = remoteAddr.String
+	rcv.LastUsedAt = lastUsedAt.Time
+
+	return nil
+}
+
 type Claims struct {
 	jwt.Claims
 	FederatedClaims   `json:"federated_claims"`
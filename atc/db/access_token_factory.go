@@ -2,17 +2,80 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 )
 
+var ErrEmptySessionFilter = errors.New("a user must be given to revoke their sessions")
+var ErrSessionFilterMissingConnector = errors.New("the connector of a user given by name must be given to revoke their sessions")
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate . AccessTokenFactory
 type AccessTokenFactory interface {
-	CreateAccessToken(token string, claims Claims) error
+	CreateAccessToken(token string, claims Claims, remoteAddr string) error
 	GetAccessToken(token string) (AccessToken, bool, error)
+	RecordAccessTokenUse(token string) error
+
+	// Sessions lists the unexpired access tokens matching the filter.
+	Sessions(filter SessionFilter) ([]Session, error)
+
+	// RevokeSession revokes a single access token. If sub is not empty, the
+	// token must belong to that user.
+	RevokeSession(id int, sub string) (bool, error)
+
+	// RevokeSessions revokes all of the access tokens of the user matching
+	// the filter, which must not be empty. A user given by name must also be
+	// given a connector, as names are only unique per connector.
+	//
+	// The user's API tokens are not revoked, as they are managed separately.
+	RevokeSessions(filter SessionFilter) (int, error)
+}
+
+// SessionFilter narrows down sessions to those of a single user, identified
+// either by their sub or by their name and connector as shown in the users
+// list. Zero values are not filtered on.
+type SessionFilter struct {
+	Sub       string
+	UserName  string
+	Connector string
+}
+
+func (filter SessionFilter) IsEmpty() bool {
+	return filter.Sub == "" && filter.UserName == "" && filter.Connector == ""
+}
+
+func (filter SessionFilter) where(subColumn string) sq.And {
+	where := sq.And{}
+
+	if filter.Sub != "" {
+		where = append(where, sq.Eq{subColumn: filter.Sub})
+	}
+
+	if filter.UserName != "" || filter.Connector != "" {
+		var (
+			conditions []string
+			args       []interface{}
+		)
+
+		if filter.UserName != "" {
+			conditions = append(conditions, "username = ?")
+			args = append(args, filter.UserName)
+		}
+
+		if filter.Connector != "" {
+			conditions = append(conditions, "connector = ?")
+			args = append(args, filter.Connector)
+		}
+
+		where = append(where, sq.Expr(subColumn+" IN (SELECT sub FROM users WHERE "+strings.Join(conditions, " AND ")+")", args...))
+	}
+
+	return where
 }
 
 func NewAccessTokenFactory(conn Conn) AccessTokenFactory {
@@ -23,14 +86,20 @@ type accessTokenFactory struct {
 	conn Conn
 }
 
-func (a *accessTokenFactory) CreateAccessToken(token string, claims Claims) error {
+func (a *accessTokenFactory) CreateAccessToken(token string, claims Claims, remoteAddr string) error {
 	var expiry int64
 	if claims.Expiry != nil {
 		expiry = int64(*claims.Expiry)
 	}
+
+	var clientID string
+	if len(claims.Audience) > 0 {
+		clientID = claims.Audience[0]
+	}
+
 	_, err := psql.Insert("access_tokens").
-		Columns("token", "sub", "expires_at", "claims").
-		Values(token, claims.Subject, time.Unix(expiry, 0), claims).
+		Columns("token", "sub", "expires_at", "claims", "client_id", "remote_addr").
+		Values(token, claims.Subject, time.Unix(expiry, 0), claims, clientID, remoteAddr).
 		RunWith(a.conn).
 		Exec()
 	if err != nil {
@@ -56,3 +125,94 @@ func (a *accessTokenFactory) GetAccessToken(token string) (AccessToken, bool, er
 	}
 	return accessToken, true, nil
 }
+
+func (a *accessTokenFactory) RecordAccessTokenUse(token string) error {
+	_, err := psql.Update("access_tokens").
+		Set("last_used_at", sq.Expr("now()")).
+		Where(sq.Eq{"token": token}).
+		RunWith(a.conn).
+		Exec()
+	return err
+}
+
+func (a *accessTokenFactory) Sessions(filter SessionFilter) ([]Session, error) {
+	rows, err := psql.Select(sessionColumns...).
+		From("access_tokens a").
+		LeftJoin("users u ON u.sub = a.sub").
+		Where(sq.Expr("a.expires_at > now()")).
+		Where(filter.where("a.sub")).
+		OrderBy("a.created_at DESC", "a.id DESC").
+		RunWith(a.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	sessions := []Session{}
+	for rows.Next() {
+		var session Session
+		err = scanSession(&session, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (a *accessTokenFactory) RevokeSession(id int, sub string) (bool, error) {
+	query := psql.Delete("access_tokens").
+		Where(sq.Eq{"id": id})
+
+	if sub != "" {
+		query = query.Where(sq.Eq{"sub": sub})
+	}
+
+	revoked, err := a.revoke(query)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked == 1, nil
+}
+
+func (a *accessTokenFactory) RevokeSessions(filter SessionFilter) (int, error) {
+	if filter.IsEmpty() {
+		return 0, ErrEmptySessionFilter
+	}
+
+	if filter.UserName != "" && filter.Connector == "" {
+		return 0, ErrSessionFilterMissingConnector
+	}
+
+	return a.revoke(psql.Delete("access_tokens").Where(filter.where("sub")))
+}
+
+// revoke deletes access tokens and has every ATC drop its cached claims, so
+// that the tokens stop working straight away.
+func (a *accessTokenFactory) revoke(query sq.DeleteBuilder) (int, error) {
+	result, err := query.RunWith(a.conn).Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if revoked == 0 {
+		return 0, nil
+	}
+
+	err = a.conn.Bus().Notify(atc.ClaimsCacheChannel)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(revoked), nil
+}
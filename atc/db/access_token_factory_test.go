@@ -1,6 +1,10 @@
 package db_test
 
 import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"gopkg.in/square/go-jose.v2/jwt"
 
@@ -37,7 +41,7 @@ var _ = Describe("Access Token Factory", func() {
 
 				"groups": []interface{}{"group1", "group2"},
 			},
-		})
+		}, "")
 		Expect(err).ToNot(HaveOccurred())
 
 		token, ok, _ := factory.GetAccessToken("my-awesome-token")
@@ -76,4 +80,169 @@ var _ = Describe("Access Token Factory", func() {
 			},
 		}))
 	})
+
+	Describe("sessions", func() {
+		var (
+			expiry     *jwt.NumericDate
+			userClaims func(sub string) db.Claims
+		)
+
+		BeforeEach(func() {
+			expiry = jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+			userClaims = func(sub string) db.Claims {
+				payload, err := json.Marshal(map[string]interface{}{
+					"sub":  sub,
+					"aud":  []string{"fly"},
+					"exp":  expiry,
+					"name": sub + "-name",
+					"federated_claims": map[string]interface{}{
+						"user_id":      sub,
+						"connector_id": "github",
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				var claims db.Claims
+				Expect(json.Unmarshal(payload, &claims)).To(Succeed())
+				return claims
+			}
+
+			err := factory.CreateAccessToken("some-token", userClaims("some-sub"), "10.0.0.1")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = factory.CreateAccessToken("other-token", userClaims("some-sub"), "10.0.0.2")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = factory.CreateAccessToken("other-user-token", userClaims("other-sub"), "10.0.0.3")
+			Expect(err).ToNot(HaveOccurred())
+
+			expired := userClaims("some-sub")
+			expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+			expired.RawClaims["exp"] = expired.Expiry
+			err = factory.CreateAccessToken("expired-token", expired, "10.0.0.4")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = db.NewUserFactory(dbConn).CreateOrUpdateUser("github:some-user", "github", "some-sub")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = factory.CreateAccessToken("other-connector-token", userClaims("other-connector-sub"), "10.0.0.5")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = db.NewUserFactory(dbConn).CreateOrUpdateUser("github:some-user", "local", "other-connector-sub")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lists the unexpired sessions of a user", func() {
+			sessions, err := factory.Sessions(db.SessionFilter{Sub: "some-sub"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+
+			Expect(sessions[0].Sub).To(Equal("some-sub"))
+			Expect(sessions[0].UserName).To(Equal("github:some-user"))
+			Expect(sessions[0].Connector).To(Equal("github"))
+			Expect(sessions[0].ClientID).To(Equal("fly"))
+			Expect(sessions[0].RemoteAddr).To(Equal("10.0.0.2"))
+			Expect(sessions[0].CreatedAt).ToNot(BeZero())
+			Expect(sessions[0].LastUsedAt).To(BeZero())
+			Expect(sessions[0].ExpiresAt.Unix()).To(Equal(expiry.Time().Unix()))
+
+			Expect(sessions[1].RemoteAddr).To(Equal("10.0.0.1"))
+		})
+
+		It("lists the sessions of all users", func() {
+			sessions, err := factory.Sessions(db.SessionFilter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(HaveLen(4))
+			Expect(sessions[0].Sub).To(Equal("other-connector-sub"))
+			Expect(sessions[1].UserName).To(Equal("other-sub-name"))
+		})
+
+		It("lists the sessions of a user by name", func() {
+			sessions, err := factory.Sessions(db.SessionFilter{UserName: "github:some-user"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(HaveLen(3))
+		})
+
+		It("lists the sessions of a user by name and connector", func() {
+			sessions, err := factory.Sessions(db.SessionFilter{UserName: "github:some-user", Connector: "github"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+		})
+
+		It("records when the token was last used", func() {
+			err := factory.RecordAccessTokenUse("some-token")
+			Expect(err).ToNot(HaveOccurred())
+
+			sessions, err := factory.Sessions(db.SessionFilter{Sub: "some-sub"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions[1].LastUsedAt).ToNot(BeZero())
+		})
+
+		Describe("revoking", func() {
+			var notifier chan bool
+
+			BeforeEach(func() {
+				var err error
+				notifier, err = dbConn.Bus().Listen(atc.ClaimsCacheChannel)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				dbConn.Bus().Unlisten(atc.ClaimsCacheChannel, notifier)
+			})
+
+			It("revokes a single session", func() {
+				sessions, err := factory.Sessions(db.SessionFilter{Sub: "some-sub"})
+				Expect(err).ToNot(HaveOccurred())
+
+				revoked, err := factory.RevokeSession(sessions[0].ID, "some-sub")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoked).To(BeTrue())
+
+				_, found, err := factory.GetAccessToken("other-token")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				_, found, err = factory.GetAccessToken("some-token")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Eventually(notifier).Should(Receive())
+			})
+
+			It("does not revoke another user's session", func() {
+				sessions, err := factory.Sessions(db.SessionFilter{Sub: "some-sub"})
+				Expect(err).ToNot(HaveOccurred())
+
+				revoked, err := factory.RevokeSession(sessions[0].ID, "other-sub")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoked).To(BeFalse())
+			})
+
+			It("revokes all of a user's sessions, only for their connector", func() {
+				revoked, err := factory.RevokeSessions(db.SessionFilter{UserName: "github:some-user", Connector: "github"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoked).To(Equal(3))
+
+				sessions, err := factory.Sessions(db.SessionFilter{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sessions).To(HaveLen(2))
+				Expect(sessions[0].Sub).To(Equal("other-connector-sub"))
+				Expect(sessions[1].Sub).To(Equal("other-sub"))
+
+				Eventually(notifier).Should(Receive())
+			})
+
+			It("refuses to revoke the sessions of a user by name alone", func() {
+				_, err := factory.RevokeSessions(db.SessionFilter{UserName: "github:some-user"})
+				Expect(err).To(Equal(db.ErrSessionFilterMissingConnector))
+			})
+
+			It("refuses to revoke everyone's sessions", func() {
+				_, err := factory.RevokeSessions(db.SessionFilter{})
+				Expect(err).To(Equal(db.ErrEmptySessionFilter))
+			})
+		})
+	})
 })
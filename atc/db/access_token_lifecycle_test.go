@@ -27,13 +27,13 @@ var _ = Describe("Access Token Lifecycle", func() {
 		yesterday := jwt.NewNumericDate(now().Add(-24 * time.Hour))
 		factory.CreateAccessToken("expiredToken1", db.Claims{
 			Claims: jwt.Claims{Expiry: yesterday},
-		})
+		}, "")
 		factory.CreateAccessToken("expiredToken2", db.Claims{
 			Claims: jwt.Claims{Expiry: yesterday},
-		})
+		}, "")
 		factory.CreateAccessToken("activeToken", db.Claims{
 			Claims: jwt.Claims{Expiry: tomorrow},
-		})
+		}, "")
 
 		By("removing expired tokens")
 		n, err := lifecycle.RemoveExpiredAccessTokens(0)
@@ -50,7 +50,7 @@ var _ = Describe("Access Token Lifecycle", func() {
 		yesterday := jwt.NewNumericDate(now().Add(-24 * time.Hour))
 		factory.CreateAccessToken("expiredToken", db.Claims{
 			Claims: jwt.Claims{Expiry: yesterday},
-		})
+		}, "")
 
 		By("removing expired tokens with leeway of 25 hours")
 		n, err := lifecycle.RemoveExpiredAccessTokens(25 * time.Hour)
@@ -0,0 +1,253 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+var ErrAPITokenNameTaken = errors.New("an API token with that name already exists")
+
+// APIToken is a long-lived token for use by automation. Personal tokens act
+// as the user who created them, with the claims they had at the time;
+// team tokens have no user and are granted a role on their team instead.
+type APIToken struct {
+	ID       int
+	Name     string
+	OwnerSub string
+
+	TeamID   int
+	TeamName string
+	Role     string
+
+	Scopes []string
+	Claims map[string]interface{}
+
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (token APIToken) IsTeamToken() bool {
+	return token.TeamID != 0
+}
+
+func (token APIToken) IsExpired() bool {
+	return !token.ExpiresAt.IsZero() && token.ExpiresAt.Before(time.Now())
+}
+
+//counterfeiter:generate . APITokenFactory
+type APITokenFactory interface {
+	CreateAPIToken(rawToken string, token APIToken) (APIToken, error)
+	GetAPIToken(rawToken string) (APIToken, bool, error)
+
+	PersonalAPITokens(ownerSub string) ([]APIToken, error)
+	TeamAPITokens(teamID int) ([]APIToken, error)
+
+	RevokePersonalAPIToken(ownerSub string, id int) (bool, error)
+	RevokeTeamAPIToken(teamID int, id int) (bool, error)
+}
+
+type apiTokenFactory struct {
+	conn Conn
+}
+
+func NewAPITokenFactory(conn Conn) APITokenFactory {
+	return &apiTokenFactory{conn}
+}
+
+var apiTokensQuery = psql.Select(
+	"t.id", "t.name", "t.owner_sub", "t.team_id", "tm.name", "t.role",
+	"t.scopes", "t.claims", "t.created_by", "t.created_at", "t.expires_at",
+).
+	From("api_tokens t").
+	LeftJoin("teams tm ON tm.id = t.team_id")
+
+// CreateAPIToken stores a hash of the raw token along with its details. The
+// raw token itself is never stored.
+func (f *apiTokenFactory) CreateAPIToken(rawToken string, token APIToken) (APIToken, error) {
+	var claims interface{}
+	if len(token.Claims) > 0 {
+		payload, err := json.Marshal(token.Claims)
+		if err != nil {
+			return APIToken{}, err
+		}
+
+		claims = payload
+	}
+
+	var teamID sql.NullInt64
+	if token.TeamID != 0 {
+		teamID = sql.NullInt64{Int64: int64(token.TeamID), Valid: true}
+	}
+
+	var expiresAt sql.NullTime
+	if !token.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: token.ExpiresAt, Valid: true}
+	}
+
+	scopes := token.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	var id int
+	err := psql.Insert("api_tokens").
+		Columns(
+			"name", "token_hash", "owner_sub", "team_id", "role",
+			"scopes", "claims", "created_by", "expires_at",
+		).
+		Values(
+			token.Name, hashAPIToken(rawToken), token.OwnerSub, teamID, nullString(token.Role),
+			pq.Array(scopes), claims, token.CreatedBy, expiresAt,
+		).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return APIToken{}, ErrAPITokenNameTaken
+		}
+
+		return APIToken{}, err
+	}
+
+	row := apiTokensQuery.
+		Where(sq.Eq{"t.id": id}).
+		RunWith(f.conn).
+		QueryRow()
+
+	return scanAPIToken(row)
+}
+
+func (f *apiTokenFactory) GetAPIToken(rawToken string) (APIToken, bool, error) {
+	row := apiTokensQuery.
+		Where(sq.Eq{"t.token_hash": hashAPIToken(rawToken)}).
+		RunWith(f.conn).
+		QueryRow()
+
+	token, err := scanAPIToken(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return APIToken{}, false, nil
+		}
+
+		return APIToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+func (f *apiTokenFactory) PersonalAPITokens(ownerSub string) ([]APIToken, error) {
+	return f.apiTokens(sq.And{
+		sq.Eq{"t.owner_sub": ownerSub},
+		sq.Eq{"t.team_id": nil},
+	})
+}
+
+func (f *apiTokenFactory) TeamAPITokens(teamID int) ([]APIToken, error) {
+	return f.apiTokens(sq.Eq{"t.team_id": teamID})
+}
+
+func (f *apiTokenFactory) RevokePersonalAPIToken(ownerSub string, id int) (bool, error) {
+	return f.revoke(sq.And{
+		sq.Eq{"id": id},
+		sq.Eq{"owner_sub": ownerSub},
+		sq.Eq{"team_id": nil},
+	})
+}
+
+func (f *apiTokenFactory) RevokeTeamAPIToken(teamID int, id int) (bool, error) {
+	return f.revoke(sq.And{
+		sq.Eq{"id": id},
+		sq.Eq{"team_id": teamID},
+	})
+}
+
+func (f *apiTokenFactory) apiTokens(where sq.Sqlizer) ([]APIToken, error) {
+	rows, err := apiTokensQuery.
+		Where(where).
+		OrderBy("t.name ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (f *apiTokenFactory) revoke(where sq.Sqlizer) (bool, error) {
+	result, err := psql.Delete("api_tokens").
+		Where(where).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func scanAPIToken(scan scannable) (APIToken, error) {
+	var (
+		token APIToken
+
+		teamID    sql.NullInt64
+		teamName  sql.NullString
+		role      sql.NullString
+		claims    sql.NullString
+		expiresAt sql.NullTime
+	)
+
+	err := scan.Scan(
+		&token.ID, &token.Name, &token.OwnerSub, &teamID, &teamName, &role,
+		pq.Array(&token.Scopes), &claims, &token.CreatedBy, &token.CreatedAt, &expiresAt,
+	)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	token.TeamID = int(teamID.Int64)
+	token.TeamName = teamName.String
+	token.Role = role.String
+	token.ExpiresAt = expiresAt.Time
+
+	if claims.Valid {
+		err = json.Unmarshal([]byte(claims.String), &token.Claims)
+		if err != nil {
+			return APIToken{}, err
+		}
+	}
+
+	return token, nil
+}
+
+func hashAPIToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
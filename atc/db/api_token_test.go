@@ -0,0 +1,139 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIToken", func() {
+	var factory db.APITokenFactory
+
+	BeforeEach(func() {
+		factory = db.NewAPITokenFactory(dbConn)
+	})
+
+	Describe("CreateAPIToken", func() {
+		It("can be found by the raw token but does not store it", func() {
+			expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+			created, err := factory.CreateAPIToken("cct_some-token", db.APIToken{
+				Name:      "deploy-bot",
+				OwnerSub:  "some-sub",
+				Scopes:    []string{"read-only"},
+				Claims:    map[string]interface{}{"sub": "some-sub"},
+				CreatedBy: "some-user",
+				ExpiresAt: expiresAt,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created.ID).ToNot(BeZero())
+			Expect(created.IsTeamToken()).To(BeFalse())
+
+			token, found, err := factory.GetAPIToken("cct_some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(token.ID).To(Equal(created.ID))
+			Expect(token.Name).To(Equal("deploy-bot"))
+			Expect(token.Scopes).To(Equal([]string{"read-only"}))
+			Expect(token.Claims).To(Equal(map[string]interface{}{"sub": "some-sub"}))
+			Expect(token.ExpiresAt.Equal(expiresAt)).To(BeTrue())
+			Expect(token.CreatedAt).ToNot(BeZero())
+
+			var count int
+			err = dbConn.QueryRow("SELECT COUNT(*) FROM api_tokens WHERE token_hash = 'cct_some-token'").Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("returns the team name for team tokens", func() {
+			_, err := factory.CreateAPIToken("cct_team-token", db.APIToken{
+				Name:     "ci-bot",
+				OwnerSub: "some-sub",
+				TeamID:   defaultTeam.ID(),
+				Role:     "member",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			token, found, err := factory.GetAPIToken("cct_team-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(token.IsTeamToken()).To(BeTrue())
+			Expect(token.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(token.Role).To(Equal("member"))
+			Expect(token.ExpiresAt).To(BeZero())
+		})
+
+		It("does not allow two personal tokens with the same name", func() {
+			_, err := factory.CreateAPIToken("cct_one", db.APIToken{Name: "bot", OwnerSub: "some-sub"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = factory.CreateAPIToken("cct_two", db.APIToken{Name: "bot", OwnerSub: "some-sub"})
+			Expect(err).To(Equal(db.ErrAPITokenNameTaken))
+
+			_, err = factory.CreateAPIToken("cct_three", db.APIToken{Name: "bot", OwnerSub: "other-sub"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("GetAPIToken", func() {
+		It("does not find unknown tokens", func() {
+			_, found, err := factory.GetAPIToken("cct_bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("listing and revoking", func() {
+		var personal, team db.APIToken
+
+		BeforeEach(func() {
+			var err error
+			personal, err = factory.CreateAPIToken("cct_personal", db.APIToken{Name: "personal", OwnerSub: "some-sub"})
+			Expect(err).ToNot(HaveOccurred())
+
+			team, err = factory.CreateAPIToken("cct_team", db.APIToken{Name: "team", OwnerSub: "some-sub", TeamID: defaultTeam.ID(), Role: "viewer"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lists personal and team tokens separately", func() {
+			tokens, err := factory.PersonalAPITokens("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].ID).To(Equal(personal.ID))
+
+			tokens, err = factory.TeamAPITokens(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].ID).To(Equal(team.ID))
+		})
+
+		It("only revokes tokens belonging to the given owner or team", func() {
+			revoked, err := factory.RevokePersonalAPIToken("other-sub", personal.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+
+			revoked, err = factory.RevokePersonalAPIToken("some-sub", team.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+
+			revoked, err = factory.RevokePersonalAPIToken("some-sub", personal.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+
+			revoked, err = factory.RevokeTeamAPIToken(defaultTeam.ID(), team.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+
+			_, found, err := factory.GetAPIToken("cct_personal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = factory.GetAPIToken("cct_team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
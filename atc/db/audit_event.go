@@ -0,0 +1,278 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AuditEvent records an audited API request and the status it was answered
+// with.
+type AuditEvent struct {
+	ID int
+
+	Action    string
+	UserName  string
+	Connector string
+	TeamName  string
+	Target    map[string]string
+
+	Method     string
+	Path       string
+	RemoteAddr string
+	UserAgent  string
+	Parameters map[string][]string
+
+	Status int
+
+	CreatedAt time.Time
+}
+
+// AuditEventFilter filters the events returned by AuditEvents.
+type AuditEventFilter struct {
+	UserName string
+	Action   string
+	TeamName string
+
+	Since time.Time
+	Until time.Time
+}
+
+func (filter AuditEventFilter) apply(query sq.SelectBuilder) sq.SelectBuilder {
+	if filter.UserName != "" {
+		query = query.Where(sq.Eq{"user_name": filter.UserName})
+	}
+
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"action": filter.Action})
+	}
+
+	if filter.TeamName != "" {
+		query = query.Where(sq.Eq{"team_name": filter.TeamName})
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"created_at": filter.Since})
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where(sq.LtOrEq{"created_at": filter.Until})
+	}
+
+	return query
+}
+
+//counterfeiter:generate . AuditEventFactory
+type AuditEventFactory interface {
+	CreateAuditEvent(AuditEvent) error
+	AuditEvents(AuditEventFilter, Page) ([]AuditEvent, Pagination, error)
+}
+
+type auditEventFactory struct {
+	conn Conn
+}
+
+func NewAuditEventFactory(conn Conn) AuditEventFactory {
+	return &auditEventFactory{conn}
+}
+
+func (f *auditEventFactory) CreateAuditEvent(event AuditEvent) error {
+	var target, parameters interface{}
+	if len(event.Target) > 0 {
+		payload, err := json.Marshal(event.Target)
+		if err != nil {
+			return err
+		}
+
+		target = payload
+	}
+
+	if len(event.Parameters) > 0 {
+		payload, err := json.Marshal(event.Parameters)
+		if err != nil {
+			return err
+		}
+
+		parameters = payload
+	}
+
+	_, err := psql.Insert("audit_events").
+		Columns(
+			"action", "user_name", "connector", "team_name", "target",
+			"method", "path", "remote_addr", "user_agent", "parameters",
+			"status",
+		).
+		Values(
+			event.Action, event.UserName, event.Connector, nullString(event.TeamName), target,
+			event.Method, event.Path, event.RemoteAddr, event.UserAgent, parameters,
+			event.Status,
+		).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+// AuditEvents returns a page of the events matching the filter, most recent
+// first. Pages are bounded by event ID.
+func (f *auditEventFactory) AuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error) {
+	query := filter.apply(psql.Select(
+		"id", "action", "user_name", "connector", "team_name", "target",
+		"method", "path", "remote_addr", "user_agent", "parameters",
+		"status", "created_at",
+	).From("audit_events"))
+
+	var reverse bool
+	if page.From == nil && page.To == nil {
+		query = query.OrderBy("id DESC")
+	} else if page.From != nil && page.To == nil {
+		query = query.
+			Where(sq.GtOrEq{"id": *page.From}).
+			OrderBy("id ASC")
+		reverse = true
+	} else if page.From == nil && page.To != nil {
+		query = query.
+			Where(sq.LtOrEq{"id": *page.To}).
+			OrderBy("id DESC")
+	} else {
+		if *page.From > *page.To {
+			return nil, Pagination{}, fmt.Errorf("invalid range boundaries")
+		}
+
+		query = query.
+			Where(sq.GtOrEq{"id": *page.From}).
+			Where(sq.LtOrEq{"id": *page.To}).
+			OrderBy("id DESC")
+	}
+
+	if page.Limit > 0 {
+		query = query.Limit(uint64(page.Limit))
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		var (
+			event AuditEvent
+
+			teamName           sql.NullString
+			target, parameters sql.NullString
+		)
+
+		err := rows.Scan(
+			&event.ID, &event.Action, &event.UserName, &event.Connector, &teamName, &target,
+			&event.Method, &event.Path, &event.RemoteAddr, &event.UserAgent, &parameters,
+			&event.Status, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		event.TeamName = teamName.String
+
+		if target.Valid {
+			err = json.Unmarshal([]byte(target.String), &event.Target)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+		}
+
+		if parameters.Valid {
+			err = json.Unmarshal([]byte(parameters.String), &event.Parameters)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	if reverse {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var pagination Pagination
+
+	newest := events[0]
+	oldest := events[len(events)-1]
+
+	var olderID int
+	err = filter.apply(psql.Select("id").From("audit_events")).
+		Where(sq.Lt{"id": oldest.ID}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&olderID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, Pagination{}, err
+	} else if err == nil {
+		pagination.Older = &Page{
+			To:    &olderID,
+			Limit: page.Limit,
+		}
+	}
+
+	var newerID int
+	err = filter.apply(psql.Select("id").From("audit_events")).
+		Where(sq.Gt{"id": newest.ID}).
+		OrderBy("id ASC").
+		Limit(1).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&newerID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, Pagination{}, err
+	} else if err == nil {
+		pagination.Newer = &Page{
+			From:  &newerID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
+
+//counterfeiter:generate . AuditEventLifecycle
+type AuditEventLifecycle interface {
+	RemoveAuditEventsOlderThan(time.Duration) (int, error)
+}
+
+type auditEventLifecycle struct {
+	conn Conn
+}
+
+func NewAuditEventLifecycle(conn Conn) AuditEventLifecycle {
+	return &auditEventLifecycle{conn}
+}
+
+func (l auditEventLifecycle) RemoveAuditEventsOlderThan(age time.Duration) (int, error) {
+	result, err := psql.Delete("audit_events").
+		Where(sq.Lt{"created_at": time.Now().Add(-age)}).
+		RunWith(l.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
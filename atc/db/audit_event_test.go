@@ -0,0 +1,161 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		factory db.AuditEventFactory
+		ids     []int
+	)
+
+	BeforeEach(func() {
+		factory = db.NewAuditEventFactory(dbConn)
+
+		for _, event := range []db.AuditEvent{
+			{
+				Action:     "SaveConfig",
+				UserName:   "some-user",
+				Connector:  "github",
+				TeamName:   "some-team",
+				Target:     map[string]string{"pipeline_name": "some-pipeline"},
+				Method:     "PUT",
+				Path:       "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+				RemoteAddr: "1.2.3.4:5678",
+				UserAgent:  "fly/7.4.0",
+				Parameters: map[string][]string{"check_creds": {"true"}},
+				Status:     200,
+			},
+			{
+				Action:   "DestroyTeam",
+				UserName: "some-admin",
+				TeamName: "some-team",
+				Method:   "DELETE",
+				Path:     "/api/v1/teams/some-team",
+				Status:   204,
+			},
+			{
+				Action:   "ListActiveUsersSince",
+				UserName: "some-user",
+				Method:   "GET",
+				Path:     "/api/v1/users",
+				Status:   403,
+			},
+		} {
+			err := factory.CreateAuditEvent(event)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		rows, err := dbConn.Query("SELECT id FROM audit_events ORDER BY id ASC")
+		Expect(err).ToNot(HaveOccurred())
+
+		ids = nil
+		for rows.Next() {
+			var id int
+			Expect(rows.Scan(&id)).To(Succeed())
+			ids = append(ids, id)
+		}
+		Expect(rows.Close()).To(Succeed())
+	})
+
+	Describe("AuditEvents", func() {
+		It("returns events, most recent first", func() {
+			events, pagination, err := factory.AuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pagination).To(Equal(db.Pagination{}))
+			Expect(events).To(HaveLen(3))
+
+			Expect(events[0].Action).To(Equal("ListActiveUsersSince"))
+			Expect(events[0].TeamName).To(BeEmpty())
+			Expect(events[0].Status).To(Equal(403))
+
+			Expect(events[2].ID).To(Equal(ids[0]))
+			Expect(events[2].Action).To(Equal("SaveConfig"))
+			Expect(events[2].UserName).To(Equal("some-user"))
+			Expect(events[2].Connector).To(Equal("github"))
+			Expect(events[2].TeamName).To(Equal("some-team"))
+			Expect(events[2].Target).To(Equal(map[string]string{"pipeline_name": "some-pipeline"}))
+			Expect(events[2].Method).To(Equal("PUT"))
+			Expect(events[2].Path).To(Equal("/api/v1/teams/some-team/pipelines/some-pipeline/config"))
+			Expect(events[2].RemoteAddr).To(Equal("1.2.3.4:5678"))
+			Expect(events[2].UserAgent).To(Equal("fly/7.4.0"))
+			Expect(events[2].Parameters).To(Equal(map[string][]string{"check_creds": {"true"}}))
+			Expect(events[2].Status).To(Equal(200))
+			Expect(events[2].CreatedAt).ToNot(BeZero())
+		})
+
+		It("filters by user, action and team", func() {
+			events, _, err := factory.AuditEvents(db.AuditEventFilter{UserName: "some-user"}, db.Page{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+
+			events, _, err = factory.AuditEvents(db.AuditEventFilter{Action: "DestroyTeam"}, db.Page{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].UserName).To(Equal("some-admin"))
+
+			events, _, err = factory.AuditEvents(db.AuditEventFilter{TeamName: "some-team", UserName: "some-user"}, db.Page{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal("SaveConfig"))
+		})
+
+		It("filters by time", func() {
+			_, err := dbConn.Exec("UPDATE audit_events SET created_at = now() - interval '40 days' WHERE id = $1", ids[0])
+			Expect(err).ToNot(HaveOccurred())
+
+			events, _, err := factory.AuditEvents(db.AuditEventFilter{Since: time.Now().Add(-30 * 24 * time.Hour)}, db.Page{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+
+			events, _, err = factory.AuditEvents(db.AuditEventFilter{Until: time.Now().Add(-30 * 24 * time.Hour)}, db.Page{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(ids[0]))
+		})
+
+		It("paginates", func() {
+			events, pagination, err := factory.AuditEvents(db.AuditEventFilter{}, db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(ids[2]))
+			Expect(events[1].ID).To(Equal(ids[1]))
+			Expect(pagination.Newer).To(BeNil())
+			Expect(pagination.Older).To(Equal(&db.Page{To: db.NewIntPtr(ids[0]), Limit: 2}))
+
+			events, pagination, err = factory.AuditEvents(db.AuditEventFilter{}, *pagination.Older)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(ids[0]))
+			Expect(pagination.Older).To(BeNil())
+			Expect(pagination.Newer).To(Equal(&db.Page{From: db.NewIntPtr(ids[1]), Limit: 2}))
+
+			events, _, err = factory.AuditEvents(db.AuditEventFilter{}, *pagination.Newer)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(ids[2]))
+			Expect(events[1].ID).To(Equal(ids[1]))
+		})
+	})
+
+	Describe("AuditEventLifecycle", func() {
+		It("removes events older than the retention period", func() {
+			_, err := dbConn.Exec("UPDATE audit_events SET created_at = now() - interval '100 days' WHERE id = $1", ids[0])
+			Expect(err).ToNot(HaveOccurred())
+
+			removed, err := db.NewAuditEventLifecycle(dbConn).RemoveAuditEventsOlderThan(90 * 24 * time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(1))
+
+			events, _, err := factory.AuditEvents(db.AuditEventFilter{}, db.Page{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+		})
+	})
+})
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -20,6 +19,7 @@ import (
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/sealed"
 	"github.com/concourse/concourse/tracing"
 )
 
@@ -96,7 +96,8 @@ var buildsQuery = psql.Select(`
 		rb.name,
 		b.rerun_number,
 		b.span_context,
-		COALESCE(bc.comment, '')
+		COALESCE(bc.comment, ''),
+		b.log_archive_location
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -143,6 +144,7 @@ type Build interface {
 	IsNewerThanLastCheckOf(input Resource) bool
 	EndTime() time.Time
 	ReapTime() time.Time
+	LogArchiveLocation() string
 	IsManuallyTriggered() bool
 	IsScheduled() bool
 	IsRunning() bool
@@ -156,8 +158,6 @@ type Build interface {
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
 
-	SyslogTag(event.OriginID) string
-
 	Reload() (bool, error)
 
 	ResourcesChecked() (bool, error)
@@ -175,6 +175,15 @@ type Build interface {
 	SetComment(string) error
 	SetInterceptible(bool) error
 
+	Approval(name string) (BuildApproval, bool, error)
+	SaveApproval(BuildApproval) (bool, error)
+	ApprovalNotifier(name string) (Notifier, error)
+
+	SaveNotificationDelivery(NotificationDelivery) error
+
+	SaveTestSuites([]atc.TestSuite) error
+	TestSuites() ([]atc.TestSuite, error)
+
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
@@ -195,6 +204,13 @@ type Build interface {
 
 	IsDrained() bool
 	SetDrained(bool) error
+	SetLogArchiveLocation(string) error
+
+	TrackSecretLease(creds.Lease) error
+	SecretLeases() ([]creds.Lease, error)
+	ReleaseSecretLease(string) error
+
+	RecordSecretAccess(step string, access creds.SecretAccess) error
 
 	SpanContext() propagation.TextMapCarrier
 
@@ -245,6 +261,8 @@ type build struct {
 	endTime    time.Time
 	reapTime   time.Time
 
+	logArchiveLocation string
+
 	drained   bool
 	aborted   bool
 	completed bool
@@ -260,6 +278,15 @@ var ErrBuildDisappeared = errors.New("build disappeared from db")
 var ErrBuildHasNoPipeline = errors.New("build has no pipeline")
 var ErrBuildArtifactNotFound = errors.New("build artifact not found")
 
+// BuildApproval is the decision made on an approval step of a build.
+type BuildApproval struct {
+	Name      string
+	Approved  bool
+	Approver  string
+	Comment   string
+	DecidedAt time.Time
+}
+
 type ResourceNotFoundInPipeline struct {
 	Resource string
 	Pipeline string
@@ -269,28 +296,6 @@ func (r ResourceNotFoundInPipeline) Error() string {
 	return fmt.Sprintf("resource %s not found in pipeline %s", r.Resource, r.Pipeline)
 }
 
-// SyslogTag returns a string to be set as a tag on syslog events pertaining to
-// the build.
-func (b *build) SyslogTag(origin event.OriginID) string {
-	segments := []string{b.teamName}
-
-	if b.pipelineID != 0 {
-		segments = append(segments, b.pipelineName)
-	}
-
-	if b.jobID != 0 {
-		segments = append(segments, b.jobName, b.name)
-	} else if b.resourceID != 0 {
-		segments = append(segments, b.resourceName, strconv.Itoa(b.id))
-	} else {
-		segments = append(segments, strconv.Itoa(b.id))
-	}
-
-	segments = append(segments, origin.String())
-
-	return strings.Join(segments, "/")
-}
-
 // LagerData returns attributes which are to be emitted in logs pertaining to
 // the build.
 func (b *build) LagerData() lager.Data {
@@ -374,6 +379,8 @@ func (b *build) RerunOfName() string   { return b.rerunOfName }
 func (b *build) RerunNumber() int      { return b.rerunNumber }
 func (b *build) CreatedBy() *string    { return b.createdBy }
 
+func (b *build) LogArchiveLocation() string { return b.logArchiveLocation }
+
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
@@ -443,6 +450,86 @@ func (b *build) SetComment(comment string) error {
 	return nil
 }
 
+func (b *build) Approval(name string) (BuildApproval, bool, error) {
+	approval := BuildApproval{Name: name}
+
+	err := psql.Select("approved", "approver", "comment", "decided_at").
+		From("build_approvals").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"name":     name,
+		}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&approval.Approved, &approval.Approver, &approval.Comment, &approval.DecidedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildApproval{}, false, nil
+		}
+
+		return BuildApproval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+// SaveApproval records the decision for an approval step. Decisions are
+// final; if the step has already been decided, nothing is saved and false is
+// returned.
+func (b *build) SaveApproval(approval BuildApproval) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Insert("build_approvals").
+		Columns("build_id", "name", "approved", "approver", "comment").
+		Values(b.id, approval.Name, approval.Approved, approval.Approver, approval.Comment).
+		Suffix("ON CONFLICT (build_id, name) DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, b.conn.Bus().Notify(buildApprovalChannel(b.id))
+}
+
+// ApprovalNotifier returns a Notifier that fires once the named approval
+// step of the build has been decided.
+func (b *build) ApprovalNotifier(name string) (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildApprovalChannel(b.id), func() (bool, error) {
+		var decided bool
+		err := psql.Select("COUNT(*) > 0").
+			From("build_approvals").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"name":     name,
+			}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&decided)
+
+		return decided, err
+	})
+}
+
 func (b *build) SetInterceptible(i bool) error {
 	rows, err := psql.Update("builds").
 		Set("interceptible", i).
@@ -791,7 +878,10 @@ WITH RECURSIVE pipelines_to_archive AS (
 func (b *build) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, error) {
 	// "fly execute" generated build will have no pipeline.
 	if b.pipelineID == 0 {
-		return creds.NewVariables(globalSecrets, b.teamName, b.pipelineName, false), nil
+		return sealed.NewVariables(
+			creds.NewVariables(globalSecrets, b.teamName, b.pipelineName, false),
+			teamSealingKeys{b.conn, b.teamID},
+		), nil
 	}
 	pipeline, found, err := b.Pipeline()
 	if err != nil {
@@ -817,6 +907,59 @@ func (b *build) SetDrained(drained bool) error {
 	return err
 }
 
+func (b *build) SetLogArchiveLocation(location string) error {
+	_, err := psql.Update("builds").
+		Set("log_archive_location", location).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+
+	if err == nil {
+		b.logArchiveLocation = location
+	}
+	return err
+}
+
+// TrackSecretLease records a lease held by the build on a dynamic secret, or
+// updates its expiry if it has been renewed.
+func (b *build) TrackSecretLease(lease creds.Lease) error {
+	_, err := psql.Insert("build_secret_leases").
+		Columns("lease_id", "build_id", "duration", "renewable", "expires_at").
+		Values(lease.ID, b.id, int(lease.Duration.Seconds()), lease.Renewable, lease.ExpiresAt).
+		Suffix("ON CONFLICT (lease_id) DO UPDATE SET duration = EXCLUDED.duration, renewable = EXCLUDED.renewable, expires_at = EXCLUDED.expires_at").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// SecretLeases returns the unexpired leases held by the build.
+func (b *build) SecretLeases() ([]creds.Lease, error) {
+	rows, err := psql.Select("lease_id", "duration", "renewable", "expires_at").
+		From("build_secret_leases").
+		Where(sq.Eq{"build_id": b.id}).
+		Where(sq.Expr("expires_at > now()")).
+		OrderBy("lease_id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSecretLeases(rows)
+}
+
+// ReleaseSecretLease forgets about a lease once it has been revoked.
+func (b *build) ReleaseSecretLease(leaseID string) error {
+	_, err := psql.Delete("build_secret_leases").
+		Where(sq.Eq{
+			"lease_id": leaseID,
+			"build_id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -1798,7 +1941,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		nonce, spanContext, createdBy                                                     sql.NullString
 		drained, aborted, completed                                                       bool
 		status                                                                            string
-		pipelineInstanceVars, comment                                                     sql.NullString
+		pipelineInstanceVars, comment, logArchiveLocation                                 sql.NullString
 	)
 
 	err := row.Scan(
@@ -1835,6 +1978,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunNumber,
 		&spanContext,
 		&comment,
+		&logArchiveLocation,
 	)
 	if err != nil {
 		return err
@@ -1860,6 +2004,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
 	b.comment = comment.String
+	b.logArchiveLocation = logArchiveLocation.String
 
 	var (
 		noncense      *string
@@ -2044,6 +2189,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
+
 func latestCompletedNonRerunBuild(tx Tx, jobID int) (int, error) {
 	var latestNonRerunId int
 	err := latestCompletedBuildQuery.
@@ -13,7 +13,7 @@ import (
 //counterfeiter:generate . BuildFactory
 type BuildFactory interface {
 	Build(int) (Build, bool, error)
-	VisibleBuilds([]string, Page) ([]Build, Pagination, error)
+	VisibleBuilds([]string, BoundPipelines, Page) ([]Build, Pagination, error)
 	AllBuilds(Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
@@ -56,11 +56,12 @@ func (f *buildFactory) Build(buildID int) (Build, bool, error) {
 	return build, true, nil
 }
 
-func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]Build, Pagination, error) {
+func (f *buildFactory) VisibleBuilds(teamNames []string, boundPipelines BoundPipelines, page Page) ([]Build, Pagination, error) {
 	newBuildsQuery := buildsQuery.
 		Where(sq.Or{
 			sq.Eq{"p.public": true},
 			sq.Eq{"t.name": teamNames},
+			boundPipelines.where("t", "p"),
 		})
 
 	if page.UseDate {
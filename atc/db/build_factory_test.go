@@ -286,7 +286,7 @@ var _ = Describe("BuildFactory", func() {
 		})
 
 		It("returns visible builds for the given teams", func() {
-			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, db.Page{Limit: 10})
+			builds, _, err := buildFactory.VisibleBuilds([]string{"some-team"}, nil, db.Page{Limit: 10})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(builds).To(HaveLen(4))
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// MaxBuildLogMatchLength is the maximum length of the line returned for a
+// match. Longer lines are truncated.
+const MaxBuildLogMatchLength = 1024
+
+// BuildLogSearch filters the log events searched by SearchBuildLogs. Only
+// Query is required.
+type BuildLogSearch struct {
+	Query string
+
+	PipelineID int
+	JobName    string
+	Statuses   []BuildStatus
+
+	// Since and Until bound the start time of the builds searched.
+	Since time.Time
+	Until time.Time
+
+	Limit       int
+	OldestFirst bool
+}
+
+// BuildLogMatch is a log event containing the query, along with the build
+// and step that printed it.
+type BuildLogMatch struct {
+	BuildID              int
+	BuildName            string
+	BuildStatus          BuildStatus
+	JobName              string
+	PipelineName         string
+	PipelineInstanceVars atc.InstanceVars
+	TeamName             string
+
+	EventID  int
+	OriginID string
+	Time     time.Time
+
+	// Line is the first line of the log event containing the query.
+	Line string
+}
+
+// logSearchText is the output of a log event with ANSI escape sequences
+// removed, so that colored words are indexed as words rather than joined to
+// the escape code. It must be kept in sync with the log search indexes.
+const logSearchText = `regexp_replace(e.payload::json->>'payload', '\x1b\[[0-9;]*[A-Za-z]', '', 'g')`
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+type logEventPayload struct {
+	Time   int64 `json:"time"`
+	Origin struct {
+		ID string `json:"id"`
+	} `json:"origin"`
+	Payload string `json:"payload"`
+}
+
+// SearchBuildLogs finds the log events of the team's builds containing the
+// query, ignoring case and ANSI escape sequences. Matching events are first
+// found using the full-text index on each build events partition, and then
+// filtered down to those containing the query as a phrase.
+//
+// Matching is by whole words: a query for part of a word, e.g. "refus" for
+// "refused", will not match, nor will words joined by anything other than
+// whitespace or punctuation, e.g. "build" in "/tmp/build/src". Output split
+// across multiple log events will not be matched either.
+func (t *team) SearchBuildLogs(search BuildLogSearch) ([]BuildLogMatch, error) {
+	eventsTable := "build_events"
+	if search.PipelineID != 0 {
+		eventsTable = fmt.Sprintf("pipeline_build_events_%d", search.PipelineID)
+	}
+
+	query := psql.Select(
+		"e.build_id", "b.name", "b.status", "j.name", "p.name", "p.instance_vars", "t.name",
+		"e.event_id", "e.payload",
+	).
+		From(eventsTable+" e").
+		Join("builds b ON b.id = e.build_id").
+		Join("teams t ON t.id = b.team_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		Where(sq.Eq{
+			"b.team_id":          t.id,
+			"b.resource_id":      nil,
+			"b.resource_type_id": nil,
+			"e.type":             string(event.EventTypeLog),
+		}).
+		Where("to_tsvector('simple', "+logSearchText+") @@ plainto_tsquery('simple', ?)", search.Query).
+		Where(sq.ILike{logSearchText: "%" + escapeLike(search.Query) + "%"})
+
+	if search.PipelineID != 0 {
+		query = query.Where(sq.Eq{"b.pipeline_id": search.PipelineID})
+	}
+
+	if search.JobName != "" {
+		query = query.Where(sq.Eq{"j.name": search.JobName})
+	}
+
+	if len(search.Statuses) > 0 {
+		query = query.Where(sq.Eq{"b.status": search.Statuses})
+	}
+
+	if !search.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": search.Since})
+	}
+
+	if !search.Until.IsZero() {
+		query = query.Where(sq.LtOrEq{"b.start_time": search.Until})
+	}
+
+	if search.OldestFirst {
+		query = query.OrderBy("e.build_id ASC", "e.event_id ASC")
+	} else {
+		query = query.OrderBy("e.build_id DESC", "e.event_id ASC")
+	}
+
+	if search.Limit > 0 {
+		query = query.Limit(uint64(search.Limit))
+	}
+
+	rows, err := query.RunWith(t.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	matches := []BuildLogMatch{}
+	for rows.Next() {
+		var (
+			match BuildLogMatch
+
+			jobName, pipelineName sql.NullString
+			instanceVars          sql.NullString
+			payload               string
+		)
+
+		err := rows.Scan(
+			&match.BuildID, &match.BuildName, &match.BuildStatus, &jobName, &pipelineName, &instanceVars, &match.TeamName,
+			&match.EventID, &payload,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		match.JobName = jobName.String
+		match.PipelineName = pipelineName.String
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &match.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var log logEventPayload
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, err
+		}
+
+		match.OriginID = log.Origin.ID
+		match.Time = time.Unix(log.Time, 0)
+		match.Line = matchingLine(log.Payload, search.Query)
+
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
+
+// matchingLine returns the first line of the output containing the query,
+// without ANSI escape sequences.
+func matchingLine(output string, query string) string {
+	output = ansiEscape.ReplaceAllString(output, "")
+	line := output
+
+	lowerQuery := strings.ToLower(query)
+	for _, l := range strings.Split(output, "\n") {
+		if strings.Contains(strings.ToLower(l), lowerQuery) {
+			line = l
+			break
+		}
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) > MaxBuildLogMatchLength {
+		line = line[:MaxBuildLogMatchLength]
+	}
+
+	return line
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"strconv"
 	"time"
 
@@ -171,55 +170,6 @@ var _ = Describe("Build", func() {
 		})
 	})
 
-	Describe("SyslogTag", func() {
-		var build db.Build
-
-		var originID event.OriginID = "some-origin"
-		var tag string
-
-		JustBeforeEach(func() {
-			tag = build.SyslogTag(originID)
-		})
-
-		Context("for a one-off build", func() {
-			BeforeEach(func() {
-				var err error
-				build, err = team.CreateOneOffBuild()
-				Expect(err).ToNot(HaveOccurred())
-			})
-
-			It("includes build and team info", func() {
-				Expect(tag).To(Equal(fmt.Sprintf("%s/%d/%s", team.Name(), build.ID(), originID)))
-			})
-		})
-
-		Context("for a job build", func() {
-			BeforeEach(func() {
-				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
-				Expect(err).ToNot(HaveOccurred())
-			})
-
-			It("includes build, team, pipeline, and job info", func() {
-				Expect(tag).To(Equal(fmt.Sprintf("%s/%s/%s/%s/%s", defaultJob.TeamName(), defaultJob.PipelineName(), defaultJob.Name(), build.Name(), originID)))
-			})
-		})
-
-		Context("for a resource build", func() {
-			BeforeEach(func() {
-				var err error
-				var created bool
-				build, created, err = defaultResource.CreateBuild(context.TODO(), false, atc.Plan{})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(created).To(BeTrue())
-			})
-
-			It("includes build, team, and pipeline", func() {
-				Expect(tag).To(Equal(fmt.Sprintf("%s/%s/%s/%d/%s", defaultResource.TeamName(), defaultResource.PipelineName(), defaultResource.Name(), build.ID(), originID)))
-			})
-		})
-	})
-
 	Describe("TracingAttrs", func() {
 		var build db.Build
 
@@ -318,6 +268,84 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("LogArchiveLocation", func() {
+		It("is empty in the beginning", func() {
+			Expect(build.LogArchiveLocation()).To(BeEmpty())
+		})
+
+		It("is set after archiving and a reload", func() {
+			err := build.SetLogArchiveLocation("file:///archive/builds/1/events.gzip")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.LogArchiveLocation()).To(Equal("file:///archive/builds/1/events.gzip"))
+
+			_, err = build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.LogArchiveLocation()).To(Equal("file:///archive/builds/1/events.gzip"))
+		})
+	})
+
+	Describe("SecretLeases", func() {
+		var lease creds.Lease
+
+		BeforeEach(func() {
+			lease = creds.Lease{
+				ID:        "database/creds/some-role/some-lease",
+				Duration:  time.Hour,
+				Renewable: true,
+				ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+			}
+		})
+
+		It("returns the tracked leases", func() {
+			err := build.TrackSecretLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			leases, err := build.SecretLeases()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leases).To(HaveLen(1))
+			Expect(leases[0].ID).To(Equal(lease.ID))
+			Expect(leases[0].Duration).To(Equal(time.Hour))
+			Expect(leases[0].Renewable).To(BeTrue())
+			Expect(leases[0].ExpiresAt).To(BeTemporally("==", lease.ExpiresAt))
+		})
+
+		It("updates the expiry of renewed leases", func() {
+			err := build.TrackSecretLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			lease.ExpiresAt = lease.ExpiresAt.Add(time.Hour)
+			err = build.TrackSecretLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			leases, err := build.SecretLeases()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leases).To(HaveLen(1))
+			Expect(leases[0].ExpiresAt).To(BeTemporally("==", lease.ExpiresAt))
+		})
+
+		It("does not return expired leases", func() {
+			lease.ExpiresAt = time.Now().Add(-time.Minute)
+			err := build.TrackSecretLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			leases, err := build.SecretLeases()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leases).To(BeEmpty())
+		})
+
+		It("does not return released leases", func() {
+			err := build.TrackSecretLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.ReleaseSecretLease(lease.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			leases, err := build.SecretLeases()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leases).To(BeEmpty())
+		})
+	})
+
 	Describe("Start", func() {
 		var err error
 		var started bool
@@ -1011,6 +1039,114 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Approval", func() {
+		It("is not found before the step has been decided", func() {
+			_, found, err := build.Approval("deploy")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the approval is saved", func() {
+			var notifier db.Notifier
+
+			BeforeEach(func() {
+				var err error
+				notifier, err = build.ApprovalNotifier("deploy")
+				Expect(err).NotTo(HaveOccurred())
+
+				saved, err := build.SaveApproval(db.BuildApproval{
+					Name:     "deploy",
+					Approved: true,
+					Approver: "some-user",
+					Comment:  "ship it",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(saved).To(BeTrue())
+			})
+
+			AfterEach(func() {
+				Expect(notifier.Close()).To(Succeed())
+			})
+
+			It("notifies waiters", func() {
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			It("can be looked up by step name", func() {
+				approval, found, err := build.Approval("deploy")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(approval.Name).To(Equal("deploy"))
+				Expect(approval.Approved).To(BeTrue())
+				Expect(approval.Approver).To(Equal("some-user"))
+				Expect(approval.Comment).To(Equal("ship it"))
+				Expect(approval.DecidedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+
+			It("cannot be decided again", func() {
+				saved, err := build.SaveApproval(db.BuildApproval{
+					Name:     "deploy",
+					Approved: false,
+					Approver: "some-other-user",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(saved).To(BeFalse())
+
+				approval, _, err := build.Approval("deploy")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approval.Approved).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("TestSuites", func() {
+		It("is empty before any are saved", func() {
+			suites, err := build.TestSuites()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites).To(BeEmpty())
+		})
+
+		Context("when test suites are saved", func() {
+			BeforeEach(func() {
+				err := build.SaveTestSuites([]atc.TestSuite{
+					{
+						StepName: "unit",
+						Name:     "some-suite",
+						Duration: 1.5,
+						Cases: []atc.TestCase{
+							{Name: "passes", ClassName: "some.Class", Duration: 0.5, Status: atc.TestPassed},
+							{Name: "fails", ClassName: "some.Class", Duration: 1, Status: atc.TestFailed, FailureMessage: "nope"},
+						},
+					},
+					{
+						StepName: "unit",
+						Name:     "empty-suite",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns them with their cases", func() {
+				suites, err := build.TestSuites()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(suites).To(HaveLen(2))
+
+				Expect(suites[0].ID).NotTo(BeZero())
+				Expect(suites[0].BuildID).To(Equal(build.ID()))
+				Expect(suites[0].StepName).To(Equal("unit"))
+				Expect(suites[0].Name).To(Equal("some-suite"))
+				Expect(suites[0].Duration).To(Equal(1.5))
+				Expect(suites[0].Cases).To(Equal([]atc.TestCase{
+					{Name: "passes", ClassName: "some.Class", Duration: 0.5, Status: atc.TestPassed},
+					{Name: "fails", ClassName: "some.Class", Duration: 1, Status: atc.TestFailed, FailureMessage: "nope"},
+				}))
+
+				Expect(suites[1].Name).To(Equal("empty-suite"))
+				Expect(suites[1].Cases).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			By("allowing you to subscribe when no events have yet occurred")
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// SaveTestSuites stores the test suites parsed from a step's reports.
+func (b *build) SaveTestSuites(suites []atc.TestSuite) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, suite := range suites {
+		var suiteID int
+		err := psql.Insert("build_test_suites").
+			Columns("build_id", "step_name", "name", "duration").
+			Values(b.id, suite.StepName, suite.Name, suite.Duration).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&suiteID)
+		if err != nil {
+			return err
+		}
+
+		if len(suite.Cases) == 0 {
+			continue
+		}
+
+		insert := psql.Insert("build_test_cases").
+			Columns("suite_id", "name", "class_name", "duration", "status", "failure_message")
+
+		for _, testCase := range suite.Cases {
+			insert = insert.Values(suiteID, testCase.Name, testCase.ClassName, testCase.Duration, testCase.Status, testCase.FailureMessage)
+		}
+
+		_, err = insert.RunWith(tx).Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// TestSuites returns the build's test suites in the order they were saved.
+func (b *build) TestSuites() ([]atc.TestSuite, error) {
+	rows, err := psql.Select(
+		"s.id", "s.step_name", "s.name", "s.duration",
+		"c.name", "c.class_name", "c.duration", "c.status", "c.failure_message",
+	).
+		From("build_test_suites s").
+		LeftJoin("build_test_cases c ON c.suite_id = s.id").
+		Where(sq.Eq{"s.build_id": b.id}).
+		OrderBy("s.id", "c.id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	suites := []atc.TestSuite{}
+	for rows.Next() {
+		var (
+			suite atc.TestSuite
+
+			caseName, className, status, failureMessage sql.NullString
+			caseDuration                                sql.NullFloat64
+		)
+
+		err := rows.Scan(
+			&suite.ID, &suite.StepName, &suite.Name, &suite.Duration,
+			&caseName, &className, &caseDuration, &status, &failureMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(suites) == 0 || suites[len(suites)-1].ID != suite.ID {
+			suite.BuildID = b.id
+			suite.Cases = []atc.TestCase{}
+			suites = append(suites, suite)
+		}
+
+		if caseName.Valid {
+			last := &suites[len(suites)-1]
+			last.Cases = append(last.Cases, atc.TestCase{
+				Name:           caseName.String,
+				ClassName:      className.String,
+				Duration:       caseDuration.Float64,
+				Status:         atc.TestStatus(status.String),
+				FailureMessage: failureMessage.String,
+			})
+		}
+	}
+
+	return suites, nil
+}
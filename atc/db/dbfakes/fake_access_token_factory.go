@@ -8,11 +8,12 @@ import (
 )
 
 type FakeAccessTokenFactory struct {
-	CreateAccessTokenStub        func(string, db.Claims) error
+	CreateAccessTokenStub        func(string, db.Claims, string) error
 	createAccessTokenMutex       sync.RWMutex
 	createAccessTokenArgsForCall []struct {
 		arg1 string
 		arg2 db.Claims
+		arg3 string
 	}
 	createAccessTokenReturns struct {
 		result1 error
@@ -35,23 +36,75 @@ type FakeAccessTokenFactory struct {
 		result2 bool
 		result3 error
 	}
+	RecordAccessTokenUseStub        func(string) error
+	recordAccessTokenUseMutex       sync.RWMutex
+	recordAccessTokenUseArgsForCall []struct {
+		arg1 string
+	}
+	recordAccessTokenUseReturns struct {
+		result1 error
+	}
+	recordAccessTokenUseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeSessionStub        func(int, string) (bool, error)
+	revokeSessionMutex       sync.RWMutex
+	revokeSessionArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	revokeSessionReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeSessionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeSessionsStub        func(db.SessionFilter) (int, error)
+	revokeSessionsMutex       sync.RWMutex
+	revokeSessionsArgsForCall []struct {
+		arg1 db.SessionFilter
+	}
+	revokeSessionsReturns struct {
+		result1 int
+		result2 error
+	}
+	revokeSessionsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	SessionsStub        func(db.SessionFilter) ([]db.Session, error)
+	sessionsMutex       sync.RWMutex
+	sessionsArgsForCall []struct {
+		arg1 db.SessionFilter
+	}
+	sessionsReturns struct {
+		result1 []db.Session
+		result2 error
+	}
+	sessionsReturnsOnCall map[int]struct {
+		result1 []db.Session
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeAccessTokenFactory) CreateAccessToken(arg1 string, arg2 db.Claims) error {
+func (fake *FakeAccessTokenFactory) CreateAccessToken(arg1 string, arg2 db.Claims, arg3 string) error {
 	fake.createAccessTokenMutex.Lock()
 	ret, specificReturn := fake.createAccessTokenReturnsOnCall[len(fake.createAccessTokenArgsForCall)]
 	fake.createAccessTokenArgsForCall = append(fake.createAccessTokenArgsForCall, struct {
 		arg1 string
 		arg2 db.Claims
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.CreateAccessTokenStub
 	fakeReturns := fake.createAccessTokenReturns
-	fake.recordInvocation("CreateAccessToken", []interface{}{arg1, arg2})
+	fake.recordInvocation("CreateAccessToken", []interface{}{arg1, arg2, arg3})
 	fake.createAccessTokenMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -65,17 +118,17 @@ func (fake *FakeAccessTokenFactory) CreateAccessTokenCallCount() int {
 	return len(fake.createAccessTokenArgsForCall)
 }
 
-func (fake *FakeAccessTokenFactory) CreateAccessTokenCalls(stub func(string, db.Claims) error) {
+func (fake *FakeAccessTokenFactory) CreateAccessTokenCalls(stub func(string, db.Claims, string) error) {
 	fake.createAccessTokenMutex.Lock()
 	defer fake.createAccessTokenMutex.Unlock()
 	fake.CreateAccessTokenStub = stub
 }
 
-func (fake *FakeAccessTokenFactory) CreateAccessTokenArgsForCall(i int) (string, db.Claims) {
+func (fake *FakeAccessTokenFactory) CreateAccessTokenArgsForCall(i int) (string, db.Claims, string) {
 	fake.createAccessTokenMutex.RLock()
 	defer fake.createAccessTokenMutex.RUnlock()
 	argsForCall := fake.createAccessTokenArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeAccessTokenFactory) CreateAccessTokenReturns(result1 error) {
@@ -168,6 +221,260 @@ func (fake *FakeAccessTokenFactory) GetAccessTokenReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeAccessTokenFactory) RecordAccessTokenUse(arg1 string) error {
+	fake.recordAccessTokenUseMutex.Lock()
+	ret, specificReturn := fake.recordAccessTokenUseReturnsOnCall[len(fake.recordAccessTokenUseArgsForCall)]
+	fake.recordAccessTokenUseArgsForCall = append(fake.recordAccessTokenUseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RecordAccessTokenUseStub
+	fakeReturns := fake.recordAccessTokenUseReturns
+	fake.recordInvocation("RecordAccessTokenUse", []interface{}{arg1})
+	fake.recordAccessTokenUseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccessTokenFactory) RecordAccessTokenUseCallCount() int {
+	fake.recordAccessTokenUseMutex.RLock()
+	defer fake.recordAccessTokenUseMutex.RUnlock()
+	return len(fake.recordAccessTokenUseArgsForCall)
+}
+
+func (fake *FakeAccessTokenFactory) RecordAccessTokenUseCalls(stub func(string) error) {
+	fake.recordAccessTokenUseMutex.Lock()
+	defer fake.recordAccessTokenUseMutex.Unlock()
+	fake.RecordAccessTokenUseStub = stub
+}
+
+func (fake *FakeAccessTokenFactory) RecordAccessTokenUseArgsForCall(i int) string {
+	fake.recordAccessTokenUseMutex.RLock()
+	defer fake.recordAccessTokenUseMutex.RUnlock()
+	argsForCall := fake.recordAccessTokenUseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAccessTokenFactory) RecordAccessTokenUseReturns(result1 error) {
+	fake.recordAccessTokenUseMutex.Lock()
+	defer fake.recordAccessTokenUseMutex.Unlock()
+	fake.RecordAccessTokenUseStub = nil
+	fake.recordAccessTokenUseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAccessTokenFactory) RecordAccessTokenUseReturnsOnCall(i int, result1 error) {
+	fake.recordAccessTokenUseMutex.Lock()
+	defer fake.recordAccessTokenUseMutex.Unlock()
+	fake.RecordAccessTokenUseStub = nil
+	if fake.recordAccessTokenUseReturnsOnCall == nil {
+		fake.recordAccessTokenUseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordAccessTokenUseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSession(arg1 int, arg2 string) (bool, error) {
+	fake.revokeSessionMutex.Lock()
+	ret, specificReturn := fake.revokeSessionReturnsOnCall[len(fake.revokeSessionArgsForCall)]
+	fake.revokeSessionArgsForCall = append(fake.revokeSessionArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeSessionStub
+	fakeReturns := fake.revokeSessionReturns
+	fake.recordInvocation("RevokeSession", []interface{}{arg1, arg2})
+	fake.revokeSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionCallCount() int {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	return len(fake.revokeSessionArgsForCall)
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionCalls(stub func(int, string) (bool, error)) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = stub
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionArgsForCall(i int) (int, string) {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	argsForCall := fake.revokeSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionReturns(result1 bool, result2 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	fake.revokeSessionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	if fake.revokeSessionReturnsOnCall == nil {
+		fake.revokeSessionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeSessionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessions(arg1 db.SessionFilter) (int, error) {
+	fake.revokeSessionsMutex.Lock()
+	ret, specificReturn := fake.revokeSessionsReturnsOnCall[len(fake.revokeSessionsArgsForCall)]
+	fake.revokeSessionsArgsForCall = append(fake.revokeSessionsArgsForCall, struct {
+		arg1 db.SessionFilter
+	}{arg1})
+	stub := fake.RevokeSessionsStub
+	fakeReturns := fake.revokeSessionsReturns
+	fake.recordInvocation("RevokeSessions", []interface{}{arg1})
+	fake.revokeSessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionsCallCount() int {
+	fake.revokeSessionsMutex.RLock()
+	defer fake.revokeSessionsMutex.RUnlock()
+	return len(fake.revokeSessionsArgsForCall)
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionsCalls(stub func(db.SessionFilter) (int, error)) {
+	fake.revokeSessionsMutex.Lock()
+	defer fake.revokeSessionsMutex.Unlock()
+	fake.RevokeSessionsStub = stub
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionsArgsForCall(i int) db.SessionFilter {
+	fake.revokeSessionsMutex.RLock()
+	defer fake.revokeSessionsMutex.RUnlock()
+	argsForCall := fake.revokeSessionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionsReturns(result1 int, result2 error) {
+	fake.revokeSessionsMutex.Lock()
+	defer fake.revokeSessionsMutex.Unlock()
+	fake.RevokeSessionsStub = nil
+	fake.revokeSessionsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAccessTokenFactory) RevokeSessionsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.revokeSessionsMutex.Lock()
+	defer fake.revokeSessionsMutex.Unlock()
+	fake.RevokeSessionsStub = nil
+	if fake.revokeSessionsReturnsOnCall == nil {
+		fake.revokeSessionsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.revokeSessionsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAccessTokenFactory) Sessions(arg1 db.SessionFilter) ([]db.Session, error) {
+	fake.sessionsMutex.Lock()
+	ret, specificReturn := fake.sessionsReturnsOnCall[len(fake.sessionsArgsForCall)]
+	fake.sessionsArgsForCall = append(fake.sessionsArgsForCall, struct {
+		arg1 db.SessionFilter
+	}{arg1})
+	stub := fake.SessionsStub
+	fakeReturns := fake.sessionsReturns
+	fake.recordInvocation("Sessions", []interface{}{arg1})
+	fake.sessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAccessTokenFactory) SessionsCallCount() int {
+	fake.sessionsMutex.RLock()
+	defer fake.sessionsMutex.RUnlock()
+	return len(fake.sessionsArgsForCall)
+}
+
+func (fake *FakeAccessTokenFactory) SessionsCalls(stub func(db.SessionFilter) ([]db.Session, error)) {
+	fake.sessionsMutex.Lock()
+	defer fake.sessionsMutex.Unlock()
+	fake.SessionsStub = stub
+}
+
+func (fake *FakeAccessTokenFactory) SessionsArgsForCall(i int) db.SessionFilter {
+	fake.sessionsMutex.RLock()
+	defer fake.sessionsMutex.RUnlock()
+	argsForCall := fake.sessionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAccessTokenFactory) SessionsReturns(result1 []db.Session, result2 error) {
+	fake.sessionsMutex.Lock()
+	defer fake.sessionsMutex.Unlock()
+	fake.SessionsStub = nil
+	fake.sessionsReturns = struct {
+		result1 []db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAccessTokenFactory) SessionsReturnsOnCall(i int, result1 []db.Session, result2 error) {
+	fake.sessionsMutex.Lock()
+	defer fake.sessionsMutex.Unlock()
+	fake.SessionsStub = nil
+	if fake.sessionsReturnsOnCall == nil {
+		fake.sessionsReturnsOnCall = make(map[int]struct {
+			result1 []db.Session
+			result2 error
+		})
+	}
+	fake.sessionsReturnsOnCall[i] = struct {
+		result1 []db.Session
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAccessTokenFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -175,6 +482,14 @@ func (fake *FakeAccessTokenFactory) Invocations() map[string][][]interface{} {
 	defer fake.createAccessTokenMutex.RUnlock()
 	fake.getAccessTokenMutex.RLock()
 	defer fake.getAccessTokenMutex.RUnlock()
+	fake.recordAccessTokenUseMutex.RLock()
+	defer fake.recordAccessTokenUseMutex.RUnlock()
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	fake.revokeSessionsMutex.RLock()
+	defer fake.revokeSessionsMutex.RUnlock()
+	fake.sessionsMutex.RLock()
+	defer fake.sessionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
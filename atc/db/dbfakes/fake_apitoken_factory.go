@@ -0,0 +1,522 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAPITokenFactory struct {
+	CreateAPITokenStub        func(string, db.APIToken) (db.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		arg1 string
+		arg2 db.APIToken
+	}
+	createAPITokenReturns struct {
+		result1 db.APIToken
+		result2 error
+	}
+	createAPITokenReturnsOnCall map[int]struct {
+		result1 db.APIToken
+		result2 error
+	}
+	GetAPITokenStub        func(string) (db.APIToken, bool, error)
+	getAPITokenMutex       sync.RWMutex
+	getAPITokenArgsForCall []struct {
+		arg1 string
+	}
+	getAPITokenReturns struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	getAPITokenReturnsOnCall map[int]struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	PersonalAPITokensStub        func(string) ([]db.APIToken, error)
+	personalAPITokensMutex       sync.RWMutex
+	personalAPITokensArgsForCall []struct {
+		arg1 string
+	}
+	personalAPITokensReturns struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	personalAPITokensReturnsOnCall map[int]struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	RevokePersonalAPITokenStub        func(string, int) (bool, error)
+	revokePersonalAPITokenMutex       sync.RWMutex
+	revokePersonalAPITokenArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	revokePersonalAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokePersonalAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeTeamAPITokenStub        func(int, int) (bool, error)
+	revokeTeamAPITokenMutex       sync.RWMutex
+	revokeTeamAPITokenArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	revokeTeamAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeTeamAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	TeamAPITokensStub        func(int) ([]db.APIToken, error)
+	teamAPITokensMutex       sync.RWMutex
+	teamAPITokensArgsForCall []struct {
+		arg1 int
+	}
+	teamAPITokensReturns struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	teamAPITokensReturnsOnCall map[int]struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenFactory) CreateAPIToken(arg1 string, arg2 db.APIToken) (db.APIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	ret, specificReturn := fake.createAPITokenReturnsOnCall[len(fake.createAPITokenArgsForCall)]
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		arg1 string
+		arg2 db.APIToken
+	}{arg1, arg2})
+	stub := fake.CreateAPITokenStub
+	fakeReturns := fake.createAPITokenReturns
+	fake.recordInvocation("CreateAPIToken", []interface{}{arg1, arg2})
+	fake.createAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) CreateAPITokenCalls(stub func(string, db.APIToken) (db.APIToken, error)) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) CreateAPITokenArgsForCall(i int) (string, db.APIToken) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	argsForCall := fake.createAPITokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPITokenFactory) CreateAPITokenReturns(result1 db.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) CreateAPITokenReturnsOnCall(i int, result1 db.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	if fake.createAPITokenReturnsOnCall == nil {
+		fake.createAPITokenReturnsOnCall = make(map[int]struct {
+			result1 db.APIToken
+			result2 error
+		})
+	}
+	fake.createAPITokenReturnsOnCall[i] = struct {
+		result1 db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) GetAPIToken(arg1 string) (db.APIToken, bool, error) {
+	fake.getAPITokenMutex.Lock()
+	ret, specificReturn := fake.getAPITokenReturnsOnCall[len(fake.getAPITokenArgsForCall)]
+	fake.getAPITokenArgsForCall = append(fake.getAPITokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetAPITokenStub
+	fakeReturns := fake.getAPITokenReturns
+	fake.recordInvocation("GetAPIToken", []interface{}{arg1})
+	fake.getAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAPITokenFactory) GetAPITokenCallCount() int {
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	return len(fake.getAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) GetAPITokenCalls(stub func(string) (db.APIToken, bool, error)) {
+	fake.getAPITokenMutex.Lock()
+	defer fake.getAPITokenMutex.Unlock()
+	fake.GetAPITokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) GetAPITokenArgsForCall(i int) string {
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	argsForCall := fake.getAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPITokenFactory) GetAPITokenReturns(result1 db.APIToken, result2 bool, result3 error) {
+	fake.getAPITokenMutex.Lock()
+	defer fake.getAPITokenMutex.Unlock()
+	fake.GetAPITokenStub = nil
+	fake.getAPITokenReturns = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFactory) GetAPITokenReturnsOnCall(i int, result1 db.APIToken, result2 bool, result3 error) {
+	fake.getAPITokenMutex.Lock()
+	defer fake.getAPITokenMutex.Unlock()
+	fake.GetAPITokenStub = nil
+	if fake.getAPITokenReturnsOnCall == nil {
+		fake.getAPITokenReturnsOnCall = make(map[int]struct {
+			result1 db.APIToken
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getAPITokenReturnsOnCall[i] = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFactory) PersonalAPITokens(arg1 string) ([]db.APIToken, error) {
+	fake.personalAPITokensMutex.Lock()
+	ret, specificReturn := fake.personalAPITokensReturnsOnCall[len(fake.personalAPITokensArgsForCall)]
+	fake.personalAPITokensArgsForCall = append(fake.personalAPITokensArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PersonalAPITokensStub
+	fakeReturns := fake.personalAPITokensReturns
+	fake.recordInvocation("PersonalAPITokens", []interface{}{arg1})
+	fake.personalAPITokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) PersonalAPITokensCallCount() int {
+	fake.personalAPITokensMutex.RLock()
+	defer fake.personalAPITokensMutex.RUnlock()
+	return len(fake.personalAPITokensArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) PersonalAPITokensCalls(stub func(string) ([]db.APIToken, error)) {
+	fake.personalAPITokensMutex.Lock()
+	defer fake.personalAPITokensMutex.Unlock()
+	fake.PersonalAPITokensStub = stub
+}
+
+func (fake *FakeAPITokenFactory) PersonalAPITokensArgsForCall(i int) string {
+	fake.personalAPITokensMutex.RLock()
+	defer fake.personalAPITokensMutex.RUnlock()
+	argsForCall := fake.personalAPITokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPITokenFactory) PersonalAPITokensReturns(result1 []db.APIToken, result2 error) {
+	fake.personalAPITokensMutex.Lock()
+	defer fake.personalAPITokensMutex.Unlock()
+	fake.PersonalAPITokensStub = nil
+	fake.personalAPITokensReturns = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) PersonalAPITokensReturnsOnCall(i int, result1 []db.APIToken, result2 error) {
+	fake.personalAPITokensMutex.Lock()
+	defer fake.personalAPITokensMutex.Unlock()
+	fake.PersonalAPITokensStub = nil
+	if fake.personalAPITokensReturnsOnCall == nil {
+		fake.personalAPITokensReturnsOnCall = make(map[int]struct {
+			result1 []db.APIToken
+			result2 error
+		})
+	}
+	fake.personalAPITokensReturnsOnCall[i] = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) RevokePersonalAPIToken(arg1 string, arg2 int) (bool, error) {
+	fake.revokePersonalAPITokenMutex.Lock()
+	ret, specificReturn := fake.revokePersonalAPITokenReturnsOnCall[len(fake.revokePersonalAPITokenArgsForCall)]
+	fake.revokePersonalAPITokenArgsForCall = append(fake.revokePersonalAPITokenArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokePersonalAPITokenStub
+	fakeReturns := fake.revokePersonalAPITokenReturns
+	fake.recordInvocation("RevokePersonalAPIToken", []interface{}{arg1, arg2})
+	fake.revokePersonalAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) RevokePersonalAPITokenCallCount() int {
+	fake.revokePersonalAPITokenMutex.RLock()
+	defer fake.revokePersonalAPITokenMutex.RUnlock()
+	return len(fake.revokePersonalAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) RevokePersonalAPITokenCalls(stub func(string, int) (bool, error)) {
+	fake.revokePersonalAPITokenMutex.Lock()
+	defer fake.revokePersonalAPITokenMutex.Unlock()
+	fake.RevokePersonalAPITokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) RevokePersonalAPITokenArgsForCall(i int) (string, int) {
+	fake.revokePersonalAPITokenMutex.RLock()
+	defer fake.revokePersonalAPITokenMutex.RUnlock()
+	argsForCall := fake.revokePersonalAPITokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPITokenFactory) RevokePersonalAPITokenReturns(result1 bool, result2 error) {
+	fake.revokePersonalAPITokenMutex.Lock()
+	defer fake.revokePersonalAPITokenMutex.Unlock()
+	fake.RevokePersonalAPITokenStub = nil
+	fake.revokePersonalAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) RevokePersonalAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokePersonalAPITokenMutex.Lock()
+	defer fake.revokePersonalAPITokenMutex.Unlock()
+	fake.RevokePersonalAPITokenStub = nil
+	if fake.revokePersonalAPITokenReturnsOnCall == nil {
+		fake.revokePersonalAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokePersonalAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) RevokeTeamAPIToken(arg1 int, arg2 int) (bool, error) {
+	fake.revokeTeamAPITokenMutex.Lock()
+	ret, specificReturn := fake.revokeTeamAPITokenReturnsOnCall[len(fake.revokeTeamAPITokenArgsForCall)]
+	fake.revokeTeamAPITokenArgsForCall = append(fake.revokeTeamAPITokenArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeTeamAPITokenStub
+	fakeReturns := fake.revokeTeamAPITokenReturns
+	fake.recordInvocation("RevokeTeamAPIToken", []interface{}{arg1, arg2})
+	fake.revokeTeamAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) RevokeTeamAPITokenCallCount() int {
+	fake.revokeTeamAPITokenMutex.RLock()
+	defer fake.revokeTeamAPITokenMutex.RUnlock()
+	return len(fake.revokeTeamAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) RevokeTeamAPITokenCalls(stub func(int, int) (bool, error)) {
+	fake.revokeTeamAPITokenMutex.Lock()
+	defer fake.revokeTeamAPITokenMutex.Unlock()
+	fake.RevokeTeamAPITokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) RevokeTeamAPITokenArgsForCall(i int) (int, int) {
+	fake.revokeTeamAPITokenMutex.RLock()
+	defer fake.revokeTeamAPITokenMutex.RUnlock()
+	argsForCall := fake.revokeTeamAPITokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPITokenFactory) RevokeTeamAPITokenReturns(result1 bool, result2 error) {
+	fake.revokeTeamAPITokenMutex.Lock()
+	defer fake.revokeTeamAPITokenMutex.Unlock()
+	fake.RevokeTeamAPITokenStub = nil
+	fake.revokeTeamAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) RevokeTeamAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeTeamAPITokenMutex.Lock()
+	defer fake.revokeTeamAPITokenMutex.Unlock()
+	fake.RevokeTeamAPITokenStub = nil
+	if fake.revokeTeamAPITokenReturnsOnCall == nil {
+		fake.revokeTeamAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeTeamAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) TeamAPITokens(arg1 int) ([]db.APIToken, error) {
+	fake.teamAPITokensMutex.Lock()
+	ret, specificReturn := fake.teamAPITokensReturnsOnCall[len(fake.teamAPITokensArgsForCall)]
+	fake.teamAPITokensArgsForCall = append(fake.teamAPITokensArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.TeamAPITokensStub
+	fakeReturns := fake.teamAPITokensReturns
+	fake.recordInvocation("TeamAPITokens", []interface{}{arg1})
+	fake.teamAPITokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) TeamAPITokensCallCount() int {
+	fake.teamAPITokensMutex.RLock()
+	defer fake.teamAPITokensMutex.RUnlock()
+	return len(fake.teamAPITokensArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) TeamAPITokensCalls(stub func(int) ([]db.APIToken, error)) {
+	fake.teamAPITokensMutex.Lock()
+	defer fake.teamAPITokensMutex.Unlock()
+	fake.TeamAPITokensStub = stub
+}
+
+func (fake *FakeAPITokenFactory) TeamAPITokensArgsForCall(i int) int {
+	fake.teamAPITokensMutex.RLock()
+	defer fake.teamAPITokensMutex.RUnlock()
+	argsForCall := fake.teamAPITokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPITokenFactory) TeamAPITokensReturns(result1 []db.APIToken, result2 error) {
+	fake.teamAPITokensMutex.Lock()
+	defer fake.teamAPITokensMutex.Unlock()
+	fake.TeamAPITokensStub = nil
+	fake.teamAPITokensReturns = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) TeamAPITokensReturnsOnCall(i int, result1 []db.APIToken, result2 error) {
+	fake.teamAPITokensMutex.Lock()
+	defer fake.teamAPITokensMutex.Unlock()
+	fake.TeamAPITokensStub = nil
+	if fake.teamAPITokensReturnsOnCall == nil {
+		fake.teamAPITokensReturnsOnCall = make(map[int]struct {
+			result1 []db.APIToken
+			result2 error
+		})
+	}
+	fake.teamAPITokensReturnsOnCall[i] = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokenMutex.RLock()
+	defer fake.getAPITokenMutex.RUnlock()
+	fake.personalAPITokensMutex.RLock()
+	defer fake.personalAPITokensMutex.RUnlock()
+	fake.revokePersonalAPITokenMutex.RLock()
+	defer fake.revokePersonalAPITokenMutex.RUnlock()
+	fake.revokeTeamAPITokenMutex.RLock()
+	defer fake.revokeTeamAPITokenMutex.RUnlock()
+	fake.teamAPITokensMutex.RLock()
+	defer fake.teamAPITokensMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAPITokenFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.APITokenFactory = new(FakeAPITokenFactory)
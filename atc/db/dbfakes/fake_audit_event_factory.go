@@ -0,0 +1,197 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAuditEventFactory struct {
+	AuditEventsStub        func(db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error)
+	auditEventsMutex       sync.RWMutex
+	auditEventsArgsForCall []struct {
+		arg1 db.AuditEventFilter
+		arg2 db.Page
+	}
+	auditEventsReturns struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	auditEventsReturnsOnCall map[int]struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	CreateAuditEventStub        func(db.AuditEvent) error
+	createAuditEventMutex       sync.RWMutex
+	createAuditEventArgsForCall []struct {
+		arg1 db.AuditEvent
+	}
+	createAuditEventReturns struct {
+		result1 error
+	}
+	createAuditEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditEventFactory) AuditEvents(arg1 db.AuditEventFilter, arg2 db.Page) ([]db.AuditEvent, db.Pagination, error) {
+	fake.auditEventsMutex.Lock()
+	ret, specificReturn := fake.auditEventsReturnsOnCall[len(fake.auditEventsArgsForCall)]
+	fake.auditEventsArgsForCall = append(fake.auditEventsArgsForCall, struct {
+		arg1 db.AuditEventFilter
+		arg2 db.Page
+	}{arg1, arg2})
+	stub := fake.AuditEventsStub
+	fakeReturns := fake.auditEventsReturns
+	fake.recordInvocation("AuditEvents", []interface{}{arg1, arg2})
+	fake.auditEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAuditEventFactory) AuditEventsCallCount() int {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	return len(fake.auditEventsArgsForCall)
+}
+
+func (fake *FakeAuditEventFactory) AuditEventsCalls(stub func(db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error)) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = stub
+}
+
+func (fake *FakeAuditEventFactory) AuditEventsArgsForCall(i int) (db.AuditEventFilter, db.Page) {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	argsForCall := fake.auditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuditEventFactory) AuditEventsReturns(result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	fake.auditEventsReturns = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditEventFactory) AuditEventsReturnsOnCall(i int, result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	if fake.auditEventsReturnsOnCall == nil {
+		fake.auditEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.AuditEvent
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.auditEventsReturnsOnCall[i] = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEvent(arg1 db.AuditEvent) error {
+	fake.createAuditEventMutex.Lock()
+	ret, specificReturn := fake.createAuditEventReturnsOnCall[len(fake.createAuditEventArgsForCall)]
+	fake.createAuditEventArgsForCall = append(fake.createAuditEventArgsForCall, struct {
+		arg1 db.AuditEvent
+	}{arg1})
+	stub := fake.CreateAuditEventStub
+	fakeReturns := fake.createAuditEventReturns
+	fake.recordInvocation("CreateAuditEvent", []interface{}{arg1})
+	fake.createAuditEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventCallCount() int {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return len(fake.createAuditEventArgsForCall)
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventCalls(stub func(db.AuditEvent) error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = stub
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventArgsForCall(i int) db.AuditEvent {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	argsForCall := fake.createAuditEventArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventReturns(result1 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	fake.createAuditEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventReturnsOnCall(i int, result1 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	if fake.createAuditEventReturnsOnCall == nil {
+		fake.createAuditEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAuditEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditEventFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditEventFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.AuditEventFactory = new(FakeAuditEventFactory)
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAuditEventLifecycle struct {
+	RemoveAuditEventsOlderThanStub        func(time.Duration) (int, error)
+	removeAuditEventsOlderThanMutex       sync.RWMutex
+	removeAuditEventsOlderThanArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeAuditEventsOlderThanReturns struct {
+		result1 int
+		result2 error
+	}
+	removeAuditEventsOlderThanReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveAuditEventsOlderThan(arg1 time.Duration) (int, error) {
+	fake.removeAuditEventsOlderThanMutex.Lock()
+	ret, specificReturn := fake.removeAuditEventsOlderThanReturnsOnCall[len(fake.removeAuditEventsOlderThanArgsForCall)]
+	fake.removeAuditEventsOlderThanArgsForCall = append(fake.removeAuditEventsOlderThanArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.RemoveAuditEventsOlderThanStub
+	fakeReturns := fake.removeAuditEventsOlderThanReturns
+	fake.recordInvocation("RemoveAuditEventsOlderThan", []interface{}{arg1})
+	fake.removeAuditEventsOlderThanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveAuditEventsOlderThanCallCount() int {
+	fake.removeAuditEventsOlderThanMutex.RLock()
+	defer fake.removeAuditEventsOlderThanMutex.RUnlock()
+	return len(fake.removeAuditEventsOlderThanArgsForCall)
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveAuditEventsOlderThanCalls(stub func(time.Duration) (int, error)) {
+	fake.removeAuditEventsOlderThanMutex.Lock()
+	defer fake.removeAuditEventsOlderThanMutex.Unlock()
+	fake.RemoveAuditEventsOlderThanStub = stub
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveAuditEventsOlderThanArgsForCall(i int) time.Duration {
+	fake.removeAuditEventsOlderThanMutex.RLock()
+	defer fake.removeAuditEventsOlderThanMutex.RUnlock()
+	argsForCall := fake.removeAuditEventsOlderThanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveAuditEventsOlderThanReturns(result1 int, result2 error) {
+	fake.removeAuditEventsOlderThanMutex.Lock()
+	defer fake.removeAuditEventsOlderThanMutex.Unlock()
+	fake.RemoveAuditEventsOlderThanStub = nil
+	fake.removeAuditEventsOlderThanReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveAuditEventsOlderThanReturnsOnCall(i int, result1 int, result2 error) {
+	fake.removeAuditEventsOlderThanMutex.Lock()
+	defer fake.removeAuditEventsOlderThanMutex.Unlock()
+	fake.RemoveAuditEventsOlderThanStub = nil
+	if fake.removeAuditEventsOlderThanReturnsOnCall == nil {
+		fake.removeAuditEventsOlderThanReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.removeAuditEventsOlderThanReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditEventLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeAuditEventsOlderThanMutex.RLock()
+	defer fake.removeAuditEventsOlderThanMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditEventLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.AuditEventLifecycle = new(FakeAuditEventLifecycle)
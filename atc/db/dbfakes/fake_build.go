@@ -11,7 +11,6 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
-	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 	"go.opentelemetry.io/otel/propagation"
@@ -74,6 +73,34 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	ApprovalStub        func(string) (db.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 string
+	}
+	approvalReturns struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approvalReturnsOnCall map[int]struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	ApprovalNotifierStub        func(string) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		arg1 string
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	approvalNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -332,6 +359,16 @@ type FakeBuild struct {
 	lagerDataReturnsOnCall map[int]struct {
 		result1 lager.Data
 	}
+	LogArchiveLocationStub        func() string
+	logArchiveLocationMutex       sync.RWMutex
+	logArchiveLocationArgsForCall []struct {
+	}
+	logArchiveLocationReturns struct {
+		result1 string
+	}
+	logArchiveLocationReturnsOnCall map[int]struct {
+		result1 string
+	}
 	MarkAsAbortedStub        func() error
 	markAsAbortedMutex       sync.RWMutex
 	markAsAbortedArgsForCall []struct {
@@ -450,6 +487,29 @@ type FakeBuild struct {
 	reapTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	RecordSecretAccessStub        func(string, creds.SecretAccess) error
+	recordSecretAccessMutex       sync.RWMutex
+	recordSecretAccessArgsForCall []struct {
+		arg1 string
+		arg2 creds.SecretAccess
+	}
+	recordSecretAccessReturns struct {
+		result1 error
+	}
+	recordSecretAccessReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseSecretLeaseStub        func(string) error
+	releaseSecretLeaseMutex       sync.RWMutex
+	releaseSecretLeaseArgsForCall []struct {
+		arg1 string
+	}
+	releaseSecretLeaseReturns struct {
+		result1 error
+	}
+	releaseSecretLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -548,6 +608,19 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	SaveApprovalStub        func(db.BuildApproval) (bool, error)
+	saveApprovalMutex       sync.RWMutex
+	saveApprovalArgsForCall []struct {
+		arg1 db.BuildApproval
+	}
+	saveApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	saveApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -570,6 +643,17 @@ type FakeBuild struct {
 	saveImageResourceVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveNotificationDeliveryStub        func(db.NotificationDelivery) error
+	saveNotificationDeliveryMutex       sync.RWMutex
+	saveNotificationDeliveryArgsForCall []struct {
+		arg1 db.NotificationDelivery
+	}
+	saveNotificationDeliveryReturns struct {
+		result1 error
+	}
+	saveNotificationDeliveryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveOutputStub        func(string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
@@ -606,6 +690,17 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveTestSuitesStub        func([]atc.TestSuite) error
+	saveTestSuitesMutex       sync.RWMutex
+	saveTestSuitesArgsForCall []struct {
+		arg1 []atc.TestSuite
+	}
+	saveTestSuitesReturns struct {
+		result1 error
+	}
+	saveTestSuitesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -616,6 +711,18 @@ type FakeBuild struct {
 	schemaReturnsOnCall map[int]struct {
 		result1 string
 	}
+	SecretLeasesStub        func() ([]creds.Lease, error)
+	secretLeasesMutex       sync.RWMutex
+	secretLeasesArgsForCall []struct {
+	}
+	secretLeasesReturns struct {
+		result1 []creds.Lease
+		result2 error
+	}
+	secretLeasesReturnsOnCall map[int]struct {
+		result1 []creds.Lease
+		result2 error
+	}
 	SetCommentStub        func(string) error
 	setCommentMutex       sync.RWMutex
 	setCommentArgsForCall []struct {
@@ -649,6 +756,17 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetLogArchiveLocationStub        func(string) error
+	setLogArchiveLocationMutex       sync.RWMutex
+	setLogArchiveLocationArgsForCall []struct {
+		arg1 string
+	}
+	setLogArchiveLocationReturns struct {
+		result1 error
+	}
+	setLogArchiveLocationReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SpanContextStub        func() propagation.TextMapCarrier
 	spanContextMutex       sync.RWMutex
 	spanContextArgsForCall []struct {
@@ -692,17 +810,6 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
-	SyslogTagStub        func(event.OriginID) string
-	syslogTagMutex       sync.RWMutex
-	syslogTagArgsForCall []struct {
-		arg1 event.OriginID
-	}
-	syslogTagReturns struct {
-		result1 string
-	}
-	syslogTagReturnsOnCall map[int]struct {
-		result1 string
-	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -723,6 +830,18 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestSuitesStub        func() ([]atc.TestSuite, error)
+	testSuitesMutex       sync.RWMutex
+	testSuitesArgsForCall []struct {
+	}
+	testSuitesReturns struct {
+		result1 []atc.TestSuite
+		result2 error
+	}
+	testSuitesReturnsOnCall map[int]struct {
+		result1 []atc.TestSuite
+		result2 error
+	}
 	TracingAttrsStub        func() tracing.Attrs
 	tracingAttrsMutex       sync.RWMutex
 	tracingAttrsArgsForCall []struct {
@@ -733,6 +852,17 @@ type FakeBuild struct {
 	tracingAttrsReturnsOnCall map[int]struct {
 		result1 tracing.Attrs
 	}
+	TrackSecretLeaseStub        func(creds.Lease) error
+	trackSecretLeaseMutex       sync.RWMutex
+	trackSecretLeaseArgsForCall []struct {
+		arg1 creds.Lease
+	}
+	trackSecretLeaseReturns struct {
+		result1 error
+	}
+	trackSecretLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	VariablesStub        func(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
 	variablesMutex       sync.RWMutex
 	variablesArgsForCall []struct {
@@ -994,6 +1124,137 @@ func (fake *FakeBuild) AdoptRerunInputsAndPipesReturnsOnCall(i int, result1 []db
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Approval(arg1 string) (db.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	ret, specificReturn := fake.approvalReturnsOnCall[len(fake.approvalArgsForCall)]
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ApprovalStub
+	fakeReturns := fake.approvalReturns
+	fake.recordInvocation("Approval", []interface{}{arg1})
+	fake.approvalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCalls(stub func(string) (db.BuildApproval, bool, error)) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = stub
+}
+
+func (fake *FakeBuild) ApprovalArgsForCall(i int) string {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	argsForCall := fake.approvalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalReturnsOnCall(i int, result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	if fake.approvalReturnsOnCall == nil {
+		fake.approvalReturnsOnCall = make(map[int]struct {
+			result1 db.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approvalReturnsOnCall[i] = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalNotifier(arg1 string) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	ret, specificReturn := fake.approvalNotifierReturnsOnCall[len(fake.approvalNotifierArgsForCall)]
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ApprovalNotifierStub
+	fakeReturns := fake.approvalNotifierReturns
+	fake.recordInvocation("ApprovalNotifier", []interface{}{arg1})
+	fake.approvalNotifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierCalls(stub func(string) (db.Notifier, error)) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = stub
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) string {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	argsForCall := fake.approvalNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	if fake.approvalNotifierReturnsOnCall == nil {
+		fake.approvalNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.approvalNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -2319,6 +2580,59 @@ func (fake *FakeBuild) LagerDataReturnsOnCall(i int, result1 lager.Data) {
 	}{result1}
 }
 
+func (fake *FakeBuild) LogArchiveLocation() string {
+	fake.logArchiveLocationMutex.Lock()
+	ret, specificReturn := fake.logArchiveLocationReturnsOnCall[len(fake.logArchiveLocationArgsForCall)]
+	fake.logArchiveLocationArgsForCall = append(fake.logArchiveLocationArgsForCall, struct {
+	}{})
+	stub := fake.LogArchiveLocationStub
+	fakeReturns := fake.logArchiveLocationReturns
+	fake.recordInvocation("LogArchiveLocation", []interface{}{})
+	fake.logArchiveLocationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) LogArchiveLocationCallCount() int {
+	fake.logArchiveLocationMutex.RLock()
+	defer fake.logArchiveLocationMutex.RUnlock()
+	return len(fake.logArchiveLocationArgsForCall)
+}
+
+func (fake *FakeBuild) LogArchiveLocationCalls(stub func() string) {
+	fake.logArchiveLocationMutex.Lock()
+	defer fake.logArchiveLocationMutex.Unlock()
+	fake.LogArchiveLocationStub = stub
+}
+
+func (fake *FakeBuild) LogArchiveLocationReturns(result1 string) {
+	fake.logArchiveLocationMutex.Lock()
+	defer fake.logArchiveLocationMutex.Unlock()
+	fake.LogArchiveLocationStub = nil
+	fake.logArchiveLocationReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) LogArchiveLocationReturnsOnCall(i int, result1 string) {
+	fake.logArchiveLocationMutex.Lock()
+	defer fake.logArchiveLocationMutex.Unlock()
+	fake.LogArchiveLocationStub = nil
+	if fake.logArchiveLocationReturnsOnCall == nil {
+		fake.logArchiveLocationReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.logArchiveLocationReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) MarkAsAborted() error {
 	fake.markAsAbortedMutex.Lock()
 	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
@@ -2914,6 +3228,129 @@ func (fake *FakeBuild) ReapTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) RecordSecretAccess(arg1 string, arg2 creds.SecretAccess) error {
+	fake.recordSecretAccessMutex.Lock()
+	ret, specificReturn := fake.recordSecretAccessReturnsOnCall[len(fake.recordSecretAccessArgsForCall)]
+	fake.recordSecretAccessArgsForCall = append(fake.recordSecretAccessArgsForCall, struct {
+		arg1 string
+		arg2 creds.SecretAccess
+	}{arg1, arg2})
+	stub := fake.RecordSecretAccessStub
+	fakeReturns := fake.recordSecretAccessReturns
+	fake.recordInvocation("RecordSecretAccess", []interface{}{arg1, arg2})
+	fake.recordSecretAccessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RecordSecretAccessCallCount() int {
+	fake.recordSecretAccessMutex.RLock()
+	defer fake.recordSecretAccessMutex.RUnlock()
+	return len(fake.recordSecretAccessArgsForCall)
+}
+
+func (fake *FakeBuild) RecordSecretAccessCalls(stub func(string, creds.SecretAccess) error) {
+	fake.recordSecretAccessMutex.Lock()
+	defer fake.recordSecretAccessMutex.Unlock()
+	fake.RecordSecretAccessStub = stub
+}
+
+func (fake *FakeBuild) RecordSecretAccessArgsForCall(i int) (string, creds.SecretAccess) {
+	fake.recordSecretAccessMutex.RLock()
+	defer fake.recordSecretAccessMutex.RUnlock()
+	argsForCall := fake.recordSecretAccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) RecordSecretAccessReturns(result1 error) {
+	fake.recordSecretAccessMutex.Lock()
+	defer fake.recordSecretAccessMutex.Unlock()
+	fake.RecordSecretAccessStub = nil
+	fake.recordSecretAccessReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RecordSecretAccessReturnsOnCall(i int, result1 error) {
+	fake.recordSecretAccessMutex.Lock()
+	defer fake.recordSecretAccessMutex.Unlock()
+	fake.RecordSecretAccessStub = nil
+	if fake.recordSecretAccessReturnsOnCall == nil {
+		fake.recordSecretAccessReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordSecretAccessReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReleaseSecretLease(arg1 string) error {
+	fake.releaseSecretLeaseMutex.Lock()
+	ret, specificReturn := fake.releaseSecretLeaseReturnsOnCall[len(fake.releaseSecretLeaseArgsForCall)]
+	fake.releaseSecretLeaseArgsForCall = append(fake.releaseSecretLeaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleaseSecretLeaseStub
+	fakeReturns := fake.releaseSecretLeaseReturns
+	fake.recordInvocation("ReleaseSecretLease", []interface{}{arg1})
+	fake.releaseSecretLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ReleaseSecretLeaseCallCount() int {
+	fake.releaseSecretLeaseMutex.RLock()
+	defer fake.releaseSecretLeaseMutex.RUnlock()
+	return len(fake.releaseSecretLeaseArgsForCall)
+}
+
+func (fake *FakeBuild) ReleaseSecretLeaseCalls(stub func(string) error) {
+	fake.releaseSecretLeaseMutex.Lock()
+	defer fake.releaseSecretLeaseMutex.Unlock()
+	fake.ReleaseSecretLeaseStub = stub
+}
+
+func (fake *FakeBuild) ReleaseSecretLeaseArgsForCall(i int) string {
+	fake.releaseSecretLeaseMutex.RLock()
+	defer fake.releaseSecretLeaseMutex.RUnlock()
+	argsForCall := fake.releaseSecretLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ReleaseSecretLeaseReturns(result1 error) {
+	fake.releaseSecretLeaseMutex.Lock()
+	defer fake.releaseSecretLeaseMutex.Unlock()
+	fake.ReleaseSecretLeaseStub = nil
+	fake.releaseSecretLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReleaseSecretLeaseReturnsOnCall(i int, result1 error) {
+	fake.releaseSecretLeaseMutex.Lock()
+	defer fake.releaseSecretLeaseMutex.Unlock()
+	fake.ReleaseSecretLeaseStub = nil
+	if fake.releaseSecretLeaseReturnsOnCall == nil {
+		fake.releaseSecretLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseSecretLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -3403,6 +3840,70 @@ func (fake *FakeBuild) ResourcesCheckedReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) SaveApproval(arg1 db.BuildApproval) (bool, error) {
+	fake.saveApprovalMutex.Lock()
+	ret, specificReturn := fake.saveApprovalReturnsOnCall[len(fake.saveApprovalArgsForCall)]
+	fake.saveApprovalArgsForCall = append(fake.saveApprovalArgsForCall, struct {
+		arg1 db.BuildApproval
+	}{arg1})
+	stub := fake.SaveApprovalStub
+	fakeReturns := fake.saveApprovalReturns
+	fake.recordInvocation("SaveApproval", []interface{}{arg1})
+	fake.saveApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SaveApprovalCallCount() int {
+	fake.saveApprovalMutex.RLock()
+	defer fake.saveApprovalMutex.RUnlock()
+	return len(fake.saveApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) SaveApprovalCalls(stub func(db.BuildApproval) (bool, error)) {
+	fake.saveApprovalMutex.Lock()
+	defer fake.saveApprovalMutex.Unlock()
+	fake.SaveApprovalStub = stub
+}
+
+func (fake *FakeBuild) SaveApprovalArgsForCall(i int) db.BuildApproval {
+	fake.saveApprovalMutex.RLock()
+	defer fake.saveApprovalMutex.RUnlock()
+	argsForCall := fake.saveApprovalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveApprovalReturns(result1 bool, result2 error) {
+	fake.saveApprovalMutex.Lock()
+	defer fake.saveApprovalMutex.Unlock()
+	fake.SaveApprovalStub = nil
+	fake.saveApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.saveApprovalMutex.Lock()
+	defer fake.saveApprovalMutex.Unlock()
+	fake.SaveApprovalStub = nil
+	if fake.saveApprovalReturnsOnCall == nil {
+		fake.saveApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.saveApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -3525,6 +4026,67 @@ func (fake *FakeBuild) SaveImageResourceVersionReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveNotificationDelivery(arg1 db.NotificationDelivery) error {
+	fake.saveNotificationDeliveryMutex.Lock()
+	ret, specificReturn := fake.saveNotificationDeliveryReturnsOnCall[len(fake.saveNotificationDeliveryArgsForCall)]
+	fake.saveNotificationDeliveryArgsForCall = append(fake.saveNotificationDeliveryArgsForCall, struct {
+		arg1 db.NotificationDelivery
+	}{arg1})
+	stub := fake.SaveNotificationDeliveryStub
+	fakeReturns := fake.saveNotificationDeliveryReturns
+	fake.recordInvocation("SaveNotificationDelivery", []interface{}{arg1})
+	fake.saveNotificationDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveNotificationDeliveryCallCount() int {
+	fake.saveNotificationDeliveryMutex.RLock()
+	defer fake.saveNotificationDeliveryMutex.RUnlock()
+	return len(fake.saveNotificationDeliveryArgsForCall)
+}
+
+func (fake *FakeBuild) SaveNotificationDeliveryCalls(stub func(db.NotificationDelivery) error) {
+	fake.saveNotificationDeliveryMutex.Lock()
+	defer fake.saveNotificationDeliveryMutex.Unlock()
+	fake.SaveNotificationDeliveryStub = stub
+}
+
+func (fake *FakeBuild) SaveNotificationDeliveryArgsForCall(i int) db.NotificationDelivery {
+	fake.saveNotificationDeliveryMutex.RLock()
+	defer fake.saveNotificationDeliveryMutex.RUnlock()
+	argsForCall := fake.saveNotificationDeliveryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveNotificationDeliveryReturns(result1 error) {
+	fake.saveNotificationDeliveryMutex.Lock()
+	defer fake.saveNotificationDeliveryMutex.Unlock()
+	fake.SaveNotificationDeliveryStub = nil
+	fake.saveNotificationDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveNotificationDeliveryReturnsOnCall(i int, result1 error) {
+	fake.saveNotificationDeliveryMutex.Lock()
+	defer fake.saveNotificationDeliveryMutex.Unlock()
+	fake.SaveNotificationDeliveryStub = nil
+	if fake.saveNotificationDeliveryReturnsOnCall == nil {
+		fake.saveNotificationDeliveryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveNotificationDeliveryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveOutput(arg1 string, arg2 atc.Source, arg3 atc.VersionedResourceTypes, arg4 atc.Version, arg5 db.ResourceConfigMetadataFields, arg6 string, arg7 string) error {
 	fake.saveOutputMutex.Lock()
 	ret, specificReturn := fake.saveOutputReturnsOnCall[len(fake.saveOutputArgsForCall)]
@@ -3663,6 +4225,72 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveTestSuites(arg1 []atc.TestSuite) error {
+	var arg1Copy []atc.TestSuite
+	if arg1 != nil {
+		arg1Copy = make([]atc.TestSuite, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveTestSuitesMutex.Lock()
+	ret, specificReturn := fake.saveTestSuitesReturnsOnCall[len(fake.saveTestSuitesArgsForCall)]
+	fake.saveTestSuitesArgsForCall = append(fake.saveTestSuitesArgsForCall, struct {
+		arg1 []atc.TestSuite
+	}{arg1Copy})
+	stub := fake.SaveTestSuitesStub
+	fakeReturns := fake.saveTestSuitesReturns
+	fake.recordInvocation("SaveTestSuites", []interface{}{arg1Copy})
+	fake.saveTestSuitesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveTestSuitesCallCount() int {
+	fake.saveTestSuitesMutex.RLock()
+	defer fake.saveTestSuitesMutex.RUnlock()
+	return len(fake.saveTestSuitesArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestSuitesCalls(stub func([]atc.TestSuite) error) {
+	fake.saveTestSuitesMutex.Lock()
+	defer fake.saveTestSuitesMutex.Unlock()
+	fake.SaveTestSuitesStub = stub
+}
+
+func (fake *FakeBuild) SaveTestSuitesArgsForCall(i int) []atc.TestSuite {
+	fake.saveTestSuitesMutex.RLock()
+	defer fake.saveTestSuitesMutex.RUnlock()
+	argsForCall := fake.saveTestSuitesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveTestSuitesReturns(result1 error) {
+	fake.saveTestSuitesMutex.Lock()
+	defer fake.saveTestSuitesMutex.Unlock()
+	fake.SaveTestSuitesStub = nil
+	fake.saveTestSuitesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestSuitesReturnsOnCall(i int, result1 error) {
+	fake.saveTestSuitesMutex.Lock()
+	defer fake.saveTestSuitesMutex.Unlock()
+	fake.SaveTestSuitesStub = nil
+	if fake.saveTestSuitesReturnsOnCall == nil {
+		fake.saveTestSuitesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestSuitesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -3716,6 +4344,62 @@ func (fake *FakeBuild) SchemaReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SecretLeases() ([]creds.Lease, error) {
+	fake.secretLeasesMutex.Lock()
+	ret, specificReturn := fake.secretLeasesReturnsOnCall[len(fake.secretLeasesArgsForCall)]
+	fake.secretLeasesArgsForCall = append(fake.secretLeasesArgsForCall, struct {
+	}{})
+	stub := fake.SecretLeasesStub
+	fakeReturns := fake.secretLeasesReturns
+	fake.recordInvocation("SecretLeases", []interface{}{})
+	fake.secretLeasesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SecretLeasesCallCount() int {
+	fake.secretLeasesMutex.RLock()
+	defer fake.secretLeasesMutex.RUnlock()
+	return len(fake.secretLeasesArgsForCall)
+}
+
+func (fake *FakeBuild) SecretLeasesCalls(stub func() ([]creds.Lease, error)) {
+	fake.secretLeasesMutex.Lock()
+	defer fake.secretLeasesMutex.Unlock()
+	fake.SecretLeasesStub = stub
+}
+
+func (fake *FakeBuild) SecretLeasesReturns(result1 []creds.Lease, result2 error) {
+	fake.secretLeasesMutex.Lock()
+	defer fake.secretLeasesMutex.Unlock()
+	fake.SecretLeasesStub = nil
+	fake.secretLeasesReturns = struct {
+		result1 []creds.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SecretLeasesReturnsOnCall(i int, result1 []creds.Lease, result2 error) {
+	fake.secretLeasesMutex.Lock()
+	defer fake.secretLeasesMutex.Unlock()
+	fake.SecretLeasesStub = nil
+	if fake.secretLeasesReturnsOnCall == nil {
+		fake.secretLeasesReturnsOnCall = make(map[int]struct {
+			result1 []creds.Lease
+			result2 error
+		})
+	}
+	fake.secretLeasesReturnsOnCall[i] = struct {
+		result1 []creds.Lease
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SetComment(arg1 string) error {
 	fake.setCommentMutex.Lock()
 	ret, specificReturn := fake.setCommentReturnsOnCall[len(fake.setCommentArgsForCall)]
@@ -3899,6 +4583,67 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetLogArchiveLocation(arg1 string) error {
+	fake.setLogArchiveLocationMutex.Lock()
+	ret, specificReturn := fake.setLogArchiveLocationReturnsOnCall[len(fake.setLogArchiveLocationArgsForCall)]
+	fake.setLogArchiveLocationArgsForCall = append(fake.setLogArchiveLocationArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SetLogArchiveLocationStub
+	fakeReturns := fake.setLogArchiveLocationReturns
+	fake.recordInvocation("SetLogArchiveLocation", []interface{}{arg1})
+	fake.setLogArchiveLocationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetLogArchiveLocationCallCount() int {
+	fake.setLogArchiveLocationMutex.RLock()
+	defer fake.setLogArchiveLocationMutex.RUnlock()
+	return len(fake.setLogArchiveLocationArgsForCall)
+}
+
+func (fake *FakeBuild) SetLogArchiveLocationCalls(stub func(string) error) {
+	fake.setLogArchiveLocationMutex.Lock()
+	defer fake.setLogArchiveLocationMutex.Unlock()
+	fake.SetLogArchiveLocationStub = stub
+}
+
+func (fake *FakeBuild) SetLogArchiveLocationArgsForCall(i int) string {
+	fake.setLogArchiveLocationMutex.RLock()
+	defer fake.setLogArchiveLocationMutex.RUnlock()
+	argsForCall := fake.setLogArchiveLocationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetLogArchiveLocationReturns(result1 error) {
+	fake.setLogArchiveLocationMutex.Lock()
+	defer fake.setLogArchiveLocationMutex.Unlock()
+	fake.SetLogArchiveLocationStub = nil
+	fake.setLogArchiveLocationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetLogArchiveLocationReturnsOnCall(i int, result1 error) {
+	fake.setLogArchiveLocationMutex.Lock()
+	defer fake.setLogArchiveLocationMutex.Unlock()
+	fake.SetLogArchiveLocationStub = nil
+	if fake.setLogArchiveLocationReturnsOnCall == nil {
+		fake.setLogArchiveLocationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setLogArchiveLocationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SpanContext() propagation.TextMapCarrier {
 	fake.spanContextMutex.Lock()
 	ret, specificReturn := fake.spanContextReturnsOnCall[len(fake.spanContextArgsForCall)]
@@ -4122,67 +4867,6 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
-func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
-	fake.syslogTagMutex.Lock()
-	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
-	fake.syslogTagArgsForCall = append(fake.syslogTagArgsForCall, struct {
-		arg1 event.OriginID
-	}{arg1})
-	stub := fake.SyslogTagStub
-	fakeReturns := fake.syslogTagReturns
-	fake.recordInvocation("SyslogTag", []interface{}{arg1})
-	fake.syslogTagMutex.Unlock()
-	if stub != nil {
-		return stub(arg1)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeBuild) SyslogTagCallCount() int {
-	fake.syslogTagMutex.RLock()
-	defer fake.syslogTagMutex.RUnlock()
-	return len(fake.syslogTagArgsForCall)
-}
-
-func (fake *FakeBuild) SyslogTagCalls(stub func(event.OriginID) string) {
-	fake.syslogTagMutex.Lock()
-	defer fake.syslogTagMutex.Unlock()
-	fake.SyslogTagStub = stub
-}
-
-func (fake *FakeBuild) SyslogTagArgsForCall(i int) event.OriginID {
-	fake.syslogTagMutex.RLock()
-	defer fake.syslogTagMutex.RUnlock()
-	argsForCall := fake.syslogTagArgsForCall[i]
-	return argsForCall.arg1
-}
-
-func (fake *FakeBuild) SyslogTagReturns(result1 string) {
-	fake.syslogTagMutex.Lock()
-	defer fake.syslogTagMutex.Unlock()
-	fake.SyslogTagStub = nil
-	fake.syslogTagReturns = struct {
-		result1 string
-	}{result1}
-}
-
-func (fake *FakeBuild) SyslogTagReturnsOnCall(i int, result1 string) {
-	fake.syslogTagMutex.Lock()
-	defer fake.syslogTagMutex.Unlock()
-	fake.SyslogTagStub = nil
-	if fake.syslogTagReturnsOnCall == nil {
-		fake.syslogTagReturnsOnCall = make(map[int]struct {
-			result1 string
-		})
-	}
-	fake.syslogTagReturnsOnCall[i] = struct {
-		result1 string
-	}{result1}
-}
-
 func (fake *FakeBuild) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -4289,6 +4973,62 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TestSuites() ([]atc.TestSuite, error) {
+	fake.testSuitesMutex.Lock()
+	ret, specificReturn := fake.testSuitesReturnsOnCall[len(fake.testSuitesArgsForCall)]
+	fake.testSuitesArgsForCall = append(fake.testSuitesArgsForCall, struct {
+	}{})
+	stub := fake.TestSuitesStub
+	fakeReturns := fake.testSuitesReturns
+	fake.recordInvocation("TestSuites", []interface{}{})
+	fake.testSuitesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TestSuitesCallCount() int {
+	fake.testSuitesMutex.RLock()
+	defer fake.testSuitesMutex.RUnlock()
+	return len(fake.testSuitesArgsForCall)
+}
+
+func (fake *FakeBuild) TestSuitesCalls(stub func() ([]atc.TestSuite, error)) {
+	fake.testSuitesMutex.Lock()
+	defer fake.testSuitesMutex.Unlock()
+	fake.TestSuitesStub = stub
+}
+
+func (fake *FakeBuild) TestSuitesReturns(result1 []atc.TestSuite, result2 error) {
+	fake.testSuitesMutex.Lock()
+	defer fake.testSuitesMutex.Unlock()
+	fake.TestSuitesStub = nil
+	fake.testSuitesReturns = struct {
+		result1 []atc.TestSuite
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestSuitesReturnsOnCall(i int, result1 []atc.TestSuite, result2 error) {
+	fake.testSuitesMutex.Lock()
+	defer fake.testSuitesMutex.Unlock()
+	fake.TestSuitesStub = nil
+	if fake.testSuitesReturnsOnCall == nil {
+		fake.testSuitesReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestSuite
+			result2 error
+		})
+	}
+	fake.testSuitesReturnsOnCall[i] = struct {
+		result1 []atc.TestSuite
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) TracingAttrs() tracing.Attrs {
 	fake.tracingAttrsMutex.Lock()
 	ret, specificReturn := fake.tracingAttrsReturnsOnCall[len(fake.tracingAttrsArgsForCall)]
@@ -4342,6 +5082,67 @@ func (fake *FakeBuild) TracingAttrsReturnsOnCall(i int, result1 tracing.Attrs) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TrackSecretLease(arg1 creds.Lease) error {
+	fake.trackSecretLeaseMutex.Lock()
+	ret, specificReturn := fake.trackSecretLeaseReturnsOnCall[len(fake.trackSecretLeaseArgsForCall)]
+	fake.trackSecretLeaseArgsForCall = append(fake.trackSecretLeaseArgsForCall, struct {
+		arg1 creds.Lease
+	}{arg1})
+	stub := fake.TrackSecretLeaseStub
+	fakeReturns := fake.trackSecretLeaseReturns
+	fake.recordInvocation("TrackSecretLease", []interface{}{arg1})
+	fake.trackSecretLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TrackSecretLeaseCallCount() int {
+	fake.trackSecretLeaseMutex.RLock()
+	defer fake.trackSecretLeaseMutex.RUnlock()
+	return len(fake.trackSecretLeaseArgsForCall)
+}
+
+func (fake *FakeBuild) TrackSecretLeaseCalls(stub func(creds.Lease) error) {
+	fake.trackSecretLeaseMutex.Lock()
+	defer fake.trackSecretLeaseMutex.Unlock()
+	fake.TrackSecretLeaseStub = stub
+}
+
+func (fake *FakeBuild) TrackSecretLeaseArgsForCall(i int) creds.Lease {
+	fake.trackSecretLeaseMutex.RLock()
+	defer fake.trackSecretLeaseMutex.RUnlock()
+	argsForCall := fake.trackSecretLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) TrackSecretLeaseReturns(result1 error) {
+	fake.trackSecretLeaseMutex.Lock()
+	defer fake.trackSecretLeaseMutex.Unlock()
+	fake.TrackSecretLeaseStub = nil
+	fake.trackSecretLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) TrackSecretLeaseReturnsOnCall(i int, result1 error) {
+	fake.trackSecretLeaseMutex.Lock()
+	defer fake.trackSecretLeaseMutex.Unlock()
+	fake.TrackSecretLeaseStub = nil
+	if fake.trackSecretLeaseReturnsOnCall == nil {
+		fake.trackSecretLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.trackSecretLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Variables(arg1 lager.Logger, arg2 creds.Secrets, arg3 creds.VarSourcePool) (vars.Variables, error) {
 	fake.variablesMutex.Lock()
 	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
@@ -4419,6 +5220,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.adoptInputsAndPipesMutex.RUnlock()
 	fake.adoptRerunInputsAndPipesMutex.RLock()
 	defer fake.adoptRerunInputsAndPipesMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
@@ -4467,6 +5272,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.jobNameMutex.RUnlock()
 	fake.lagerDataMutex.RLock()
 	defer fake.lagerDataMutex.RUnlock()
+	fake.logArchiveLocationMutex.RLock()
+	defer fake.logArchiveLocationMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
 	defer fake.markAsAbortedMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -4489,6 +5296,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.publicPlanMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
+	fake.recordSecretAccessMutex.RLock()
+	defer fake.recordSecretAccessMutex.RUnlock()
+	fake.releaseSecretLeaseMutex.RLock()
+	defer fake.releaseSecretLeaseMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.rerunNumberMutex.RLock()
@@ -4507,22 +5318,32 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
 	defer fake.resourcesCheckedMutex.RUnlock()
+	fake.saveApprovalMutex.RLock()
+	defer fake.saveApprovalMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
+	fake.saveNotificationDeliveryMutex.RLock()
+	defer fake.saveNotificationDeliveryMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveTestSuitesMutex.RLock()
+	defer fake.saveTestSuitesMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
+	fake.secretLeasesMutex.RLock()
+	defer fake.secretLeasesMutex.RUnlock()
 	fake.setCommentMutex.RLock()
 	defer fake.setCommentMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setLogArchiveLocationMutex.RLock()
+	defer fake.setLogArchiveLocationMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
 	fake.startMutex.RLock()
@@ -4531,14 +5352,16 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
-	fake.syslogTagMutex.RLock()
-	defer fake.syslogTagMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testSuitesMutex.RLock()
+	defer fake.testSuitesMutex.RUnlock()
 	fake.tracingAttrsMutex.RLock()
 	defer fake.tracingAttrsMutex.RUnlock()
+	fake.trackSecretLeaseMutex.RLock()
+	defer fake.trackSecretLeaseMutex.RUnlock()
 	fake.variablesMutex.RLock()
 	defer fake.variablesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -87,11 +87,12 @@ type FakeBuildFactory struct {
 		result2 db.Pagination
 		result3 error
 	}
-	VisibleBuildsStub        func([]string, db.Page) ([]db.Build, db.Pagination, error)
+	VisibleBuildsStub        func([]string, db.BoundPipelines, db.Page) ([]db.Build, db.Pagination, error)
 	visibleBuildsMutex       sync.RWMutex
 	visibleBuildsArgsForCall []struct {
 		arg1 []string
-		arg2 db.Page
+		arg2 db.BoundPipelines
+		arg3 db.Page
 	}
 	visibleBuildsReturns struct {
 		result1 []db.Build
@@ -473,7 +474,7 @@ func (fake *FakeBuildFactory) PublicBuildsReturnsOnCall(i int, result1 []db.Buil
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.Build, db.Pagination, error) {
+func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.BoundPipelines, arg3 db.Page) ([]db.Build, db.Pagination, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -483,14 +484,15 @@ func (fake *FakeBuildFactory) VisibleBuilds(arg1 []string, arg2 db.Page) ([]db.B
 	ret, specificReturn := fake.visibleBuildsReturnsOnCall[len(fake.visibleBuildsArgsForCall)]
 	fake.visibleBuildsArgsForCall = append(fake.visibleBuildsArgsForCall, struct {
 		arg1 []string
-		arg2 db.Page
-	}{arg1Copy, arg2})
+		arg2 db.BoundPipelines
+		arg3 db.Page
+	}{arg1Copy, arg2, arg3})
 	stub := fake.VisibleBuildsStub
 	fakeReturns := fake.visibleBuildsReturns
-	fake.recordInvocation("VisibleBuilds", []interface{}{arg1Copy, arg2})
+	fake.recordInvocation("VisibleBuilds", []interface{}{arg1Copy, arg2, arg3})
 	fake.visibleBuildsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -504,17 +506,17 @@ func (fake *FakeBuildFactory) VisibleBuildsCallCount() int {
 	return len(fake.visibleBuildsArgsForCall)
 }
 
-func (fake *FakeBuildFactory) VisibleBuildsCalls(stub func([]string, db.Page) ([]db.Build, db.Pagination, error)) {
+func (fake *FakeBuildFactory) VisibleBuildsCalls(stub func([]string, db.BoundPipelines, db.Page) ([]db.Build, db.Pagination, error)) {
 	fake.visibleBuildsMutex.Lock()
 	defer fake.visibleBuildsMutex.Unlock()
 	fake.VisibleBuildsStub = stub
 }
 
-func (fake *FakeBuildFactory) VisibleBuildsArgsForCall(i int) ([]string, db.Page) {
+func (fake *FakeBuildFactory) VisibleBuildsArgsForCall(i int) ([]string, db.BoundPipelines, db.Page) {
 	fake.visibleBuildsMutex.RLock()
 	defer fake.visibleBuildsMutex.RUnlock()
 	argsForCall := fake.visibleBuildsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeBuildFactory) VisibleBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
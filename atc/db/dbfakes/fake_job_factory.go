@@ -33,10 +33,11 @@ type FakeJobFactory struct {
 		result1 db.SchedulerJobs
 		result2 error
 	}
-	VisibleJobsStub        func([]string) ([]atc.JobSummary, error)
+	VisibleJobsStub        func([]string, db.BoundPipelines) ([]atc.JobSummary, error)
 	visibleJobsMutex       sync.RWMutex
 	visibleJobsArgsForCall []struct {
 		arg1 []string
+		arg2 db.BoundPipelines
 	}
 	visibleJobsReturns struct {
 		result1 []atc.JobSummary
@@ -162,7 +163,7 @@ func (fake *FakeJobFactory) JobsToScheduleReturnsOnCall(i int, result1 db.Schedu
 	}{result1, result2}
 }
 
-func (fake *FakeJobFactory) VisibleJobs(arg1 []string) ([]atc.JobSummary, error) {
+func (fake *FakeJobFactory) VisibleJobs(arg1 []string, arg2 db.BoundPipelines) ([]atc.JobSummary, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -172,13 +173,14 @@ func (fake *FakeJobFactory) VisibleJobs(arg1 []string) ([]atc.JobSummary, error)
 	ret, specificReturn := fake.visibleJobsReturnsOnCall[len(fake.visibleJobsArgsForCall)]
 	fake.visibleJobsArgsForCall = append(fake.visibleJobsArgsForCall, struct {
 		arg1 []string
-	}{arg1Copy})
+		arg2 db.BoundPipelines
+	}{arg1Copy, arg2})
 	stub := fake.VisibleJobsStub
 	fakeReturns := fake.visibleJobsReturns
-	fake.recordInvocation("VisibleJobs", []interface{}{arg1Copy})
+	fake.recordInvocation("VisibleJobs", []interface{}{arg1Copy, arg2})
 	fake.visibleJobsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -192,17 +194,17 @@ func (fake *FakeJobFactory) VisibleJobsCallCount() int {
 	return len(fake.visibleJobsArgsForCall)
 }
 
-func (fake *FakeJobFactory) VisibleJobsCalls(stub func([]string) ([]atc.JobSummary, error)) {
+func (fake *FakeJobFactory) VisibleJobsCalls(stub func([]string, db.BoundPipelines) ([]atc.JobSummary, error)) {
 	fake.visibleJobsMutex.Lock()
 	defer fake.visibleJobsMutex.Unlock()
 	fake.VisibleJobsStub = stub
 }
 
-func (fake *FakeJobFactory) VisibleJobsArgsForCall(i int) []string {
+func (fake *FakeJobFactory) VisibleJobsArgsForCall(i int) ([]string, db.BoundPipelines) {
 	fake.visibleJobsMutex.RLock()
 	defer fake.visibleJobsMutex.RUnlock()
 	argsForCall := fake.visibleJobsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeJobFactory) VisibleJobsReturns(result1 []atc.JobSummary, result2 error) {
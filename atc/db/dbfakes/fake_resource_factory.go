@@ -35,10 +35,11 @@ type FakeResourceFactory struct {
 		result2 bool
 		result3 error
 	}
-	VisibleResourcesStub        func([]string) ([]db.Resource, error)
+	VisibleResourcesStub        func([]string, db.BoundPipelines) ([]db.Resource, error)
 	visibleResourcesMutex       sync.RWMutex
 	visibleResourcesArgsForCall []struct {
 		arg1 []string
+		arg2 db.BoundPipelines
 	}
 	visibleResourcesReturns struct {
 		result1 []db.Resource
@@ -175,7 +176,7 @@ func (fake *FakeResourceFactory) ResourceReturnsOnCall(i int, result1 db.Resourc
 	}{result1, result2, result3}
 }
 
-func (fake *FakeResourceFactory) VisibleResources(arg1 []string) ([]db.Resource, error) {
+func (fake *FakeResourceFactory) VisibleResources(arg1 []string, arg2 db.BoundPipelines) ([]db.Resource, error) {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
@@ -185,13 +186,14 @@ func (fake *FakeResourceFactory) VisibleResources(arg1 []string) ([]db.Resource,
 	ret, specificReturn := fake.visibleResourcesReturnsOnCall[len(fake.visibleResourcesArgsForCall)]
 	fake.visibleResourcesArgsForCall = append(fake.visibleResourcesArgsForCall, struct {
 		arg1 []string
-	}{arg1Copy})
+		arg2 db.BoundPipelines
+	}{arg1Copy, arg2})
 	stub := fake.VisibleResourcesStub
 	fakeReturns := fake.visibleResourcesReturns
-	fake.recordInvocation("VisibleResources", []interface{}{arg1Copy})
+	fake.recordInvocation("VisibleResources", []interface{}{arg1Copy, arg2})
 	fake.visibleResourcesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -205,17 +207,17 @@ func (fake *FakeResourceFactory) VisibleResourcesCallCount() int {
 	return len(fake.visibleResourcesArgsForCall)
 }
 
-func (fake *FakeResourceFactory) VisibleResourcesCalls(stub func([]string) ([]db.Resource, error)) {
+func (fake *FakeResourceFactory) VisibleResourcesCalls(stub func([]string, db.BoundPipelines) ([]db.Resource, error)) {
 	fake.visibleResourcesMutex.Lock()
 	defer fake.visibleResourcesMutex.Unlock()
 	fake.VisibleResourcesStub = stub
 }
 
-func (fake *FakeResourceFactory) VisibleResourcesArgsForCall(i int) []string {
+func (fake *FakeResourceFactory) VisibleResourcesArgsForCall(i int) ([]string, db.BoundPipelines) {
 	fake.visibleResourcesMutex.RLock()
 	defer fake.visibleResourcesMutex.RUnlock()
 	argsForCall := fake.visibleResourcesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResourceFactory) VisibleResourcesReturns(result1 []db.Resource, result2 error) {
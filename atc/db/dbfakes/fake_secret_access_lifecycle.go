@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretAccessLifecycle struct {
+	RemoveSecretAccessesOlderThanStub        func(time.Duration) (int, error)
+	removeSecretAccessesOlderThanMutex       sync.RWMutex
+	removeSecretAccessesOlderThanArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeSecretAccessesOlderThanReturns struct {
+		result1 int
+		result2 error
+	}
+	removeSecretAccessesOlderThanReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretAccessLifecycle) RemoveSecretAccessesOlderThan(arg1 time.Duration) (int, error) {
+	fake.removeSecretAccessesOlderThanMutex.Lock()
+	ret, specificReturn := fake.removeSecretAccessesOlderThanReturnsOnCall[len(fake.removeSecretAccessesOlderThanArgsForCall)]
+	fake.removeSecretAccessesOlderThanArgsForCall = append(fake.removeSecretAccessesOlderThanArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.RemoveSecretAccessesOlderThanStub
+	fakeReturns := fake.removeSecretAccessesOlderThanReturns
+	fake.recordInvocation("RemoveSecretAccessesOlderThan", []interface{}{arg1})
+	fake.removeSecretAccessesOlderThanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretAccessLifecycle) RemoveSecretAccessesOlderThanCallCount() int {
+	fake.removeSecretAccessesOlderThanMutex.RLock()
+	defer fake.removeSecretAccessesOlderThanMutex.RUnlock()
+	return len(fake.removeSecretAccessesOlderThanArgsForCall)
+}
+
+func (fake *FakeSecretAccessLifecycle) RemoveSecretAccessesOlderThanCalls(stub func(time.Duration) (int, error)) {
+	fake.removeSecretAccessesOlderThanMutex.Lock()
+	defer fake.removeSecretAccessesOlderThanMutex.Unlock()
+	fake.RemoveSecretAccessesOlderThanStub = stub
+}
+
+func (fake *FakeSecretAccessLifecycle) RemoveSecretAccessesOlderThanArgsForCall(i int) time.Duration {
+	fake.removeSecretAccessesOlderThanMutex.RLock()
+	defer fake.removeSecretAccessesOlderThanMutex.RUnlock()
+	argsForCall := fake.removeSecretAccessesOlderThanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessLifecycle) RemoveSecretAccessesOlderThanReturns(result1 int, result2 error) {
+	fake.removeSecretAccessesOlderThanMutex.Lock()
+	defer fake.removeSecretAccessesOlderThanMutex.Unlock()
+	fake.RemoveSecretAccessesOlderThanStub = nil
+	fake.removeSecretAccessesOlderThanReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretAccessLifecycle) RemoveSecretAccessesOlderThanReturnsOnCall(i int, result1 int, result2 error) {
+	fake.removeSecretAccessesOlderThanMutex.Lock()
+	defer fake.removeSecretAccessesOlderThanMutex.Unlock()
+	fake.RemoveSecretAccessesOlderThanStub = nil
+	if fake.removeSecretAccessesOlderThanReturnsOnCall == nil {
+		fake.removeSecretAccessesOlderThanReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.removeSecretAccessesOlderThanReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretAccessLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeSecretAccessesOlderThanMutex.RLock()
+	defer fake.removeSecretAccessesOlderThanMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretAccessLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretAccessLifecycle = new(FakeSecretAccessLifecycle)
@@ -0,0 +1,296 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeTaskResultFactory struct {
+	ArtifactDigestStub        func(string) (string, bool, error)
+	artifactDigestMutex       sync.RWMutex
+	artifactDigestArgsForCall []struct {
+		arg1 string
+	}
+	artifactDigestReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	artifactDigestReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	FindStub        func(int, string, string, []string) (db.TaskResult, bool, error)
+	findMutex       sync.RWMutex
+	findArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 []string
+	}
+	findReturns struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}
+	findReturnsOnCall map[int]struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}
+	SaveStub        func(int, string, string, int) error
+	saveMutex       sync.RWMutex
+	saveArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 int
+	}
+	saveReturns struct {
+		result1 error
+	}
+	saveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskResultFactory) ArtifactDigest(arg1 string) (string, bool, error) {
+	fake.artifactDigestMutex.Lock()
+	ret, specificReturn := fake.artifactDigestReturnsOnCall[len(fake.artifactDigestArgsForCall)]
+	fake.artifactDigestArgsForCall = append(fake.artifactDigestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ArtifactDigestStub
+	fakeReturns := fake.artifactDigestReturns
+	fake.recordInvocation("ArtifactDigest", []interface{}{arg1})
+	fake.artifactDigestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTaskResultFactory) ArtifactDigestCallCount() int {
+	fake.artifactDigestMutex.RLock()
+	defer fake.artifactDigestMutex.RUnlock()
+	return len(fake.artifactDigestArgsForCall)
+}
+
+func (fake *FakeTaskResultFactory) ArtifactDigestCalls(stub func(string) (string, bool, error)) {
+	fake.artifactDigestMutex.Lock()
+	defer fake.artifactDigestMutex.Unlock()
+	fake.ArtifactDigestStub = stub
+}
+
+func (fake *FakeTaskResultFactory) ArtifactDigestArgsForCall(i int) string {
+	fake.artifactDigestMutex.RLock()
+	defer fake.artifactDigestMutex.RUnlock()
+	argsForCall := fake.artifactDigestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskResultFactory) ArtifactDigestReturns(result1 string, result2 bool, result3 error) {
+	fake.artifactDigestMutex.Lock()
+	defer fake.artifactDigestMutex.Unlock()
+	fake.ArtifactDigestStub = nil
+	fake.artifactDigestReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskResultFactory) ArtifactDigestReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.artifactDigestMutex.Lock()
+	defer fake.artifactDigestMutex.Unlock()
+	fake.ArtifactDigestStub = nil
+	if fake.artifactDigestReturnsOnCall == nil {
+		fake.artifactDigestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.artifactDigestReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskResultFactory) Find(arg1 int, arg2 string, arg3 string, arg4 []string) (db.TaskResult, bool, error) {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.findMutex.Lock()
+	ret, specificReturn := fake.findReturnsOnCall[len(fake.findArgsForCall)]
+	fake.findArgsForCall = append(fake.findArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.FindStub
+	fakeReturns := fake.findReturns
+	fake.recordInvocation("Find", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.findMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTaskResultFactory) FindCallCount() int {
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	return len(fake.findArgsForCall)
+}
+
+func (fake *FakeTaskResultFactory) FindCalls(stub func(int, string, string, []string) (db.TaskResult, bool, error)) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = stub
+}
+
+func (fake *FakeTaskResultFactory) FindArgsForCall(i int) (int, string, string, []string) {
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	argsForCall := fake.findArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTaskResultFactory) FindReturns(result1 db.TaskResult, result2 bool, result3 error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = nil
+	fake.findReturns = struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskResultFactory) FindReturnsOnCall(i int, result1 db.TaskResult, result2 bool, result3 error) {
+	fake.findMutex.Lock()
+	defer fake.findMutex.Unlock()
+	fake.FindStub = nil
+	if fake.findReturnsOnCall == nil {
+		fake.findReturnsOnCall = make(map[int]struct {
+			result1 db.TaskResult
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findReturnsOnCall[i] = struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskResultFactory) Save(arg1 int, arg2 string, arg3 string, arg4 int) error {
+	fake.saveMutex.Lock()
+	ret, specificReturn := fake.saveReturnsOnCall[len(fake.saveArgsForCall)]
+	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SaveStub
+	fakeReturns := fake.saveReturns
+	fake.recordInvocation("Save", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskResultFactory) SaveCallCount() int {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return len(fake.saveArgsForCall)
+}
+
+func (fake *FakeTaskResultFactory) SaveCalls(stub func(int, string, string, int) error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = stub
+}
+
+func (fake *FakeTaskResultFactory) SaveArgsForCall(i int) (int, string, string, int) {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	argsForCall := fake.saveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTaskResultFactory) SaveReturns(result1 error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = nil
+	fake.saveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskResultFactory) SaveReturnsOnCall(i int, result1 error) {
+	fake.saveMutex.Lock()
+	defer fake.saveMutex.Unlock()
+	fake.SaveStub = nil
+	if fake.saveReturnsOnCall == nil {
+		fake.saveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskResultFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.artifactDigestMutex.RLock()
+	defer fake.artifactDigestMutex.RUnlock()
+	fake.findMutex.RLock()
+	defer fake.findMutex.RUnlock()
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTaskResultFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.TaskResultFactory = new(FakeTaskResultFactory)
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeTaskResultLifecycle struct {
+	RemoveUnusedTaskResultsStub        func(time.Duration) (int, error)
+	removeUnusedTaskResultsMutex       sync.RWMutex
+	removeUnusedTaskResultsArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeUnusedTaskResultsReturns struct {
+		result1 int
+		result2 error
+	}
+	removeUnusedTaskResultsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskResultLifecycle) RemoveUnusedTaskResults(arg1 time.Duration) (int, error) {
+	fake.removeUnusedTaskResultsMutex.Lock()
+	ret, specificReturn := fake.removeUnusedTaskResultsReturnsOnCall[len(fake.removeUnusedTaskResultsArgsForCall)]
+	fake.removeUnusedTaskResultsArgsForCall = append(fake.removeUnusedTaskResultsArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.RemoveUnusedTaskResultsStub
+	fakeReturns := fake.removeUnusedTaskResultsReturns
+	fake.recordInvocation("RemoveUnusedTaskResults", []interface{}{arg1})
+	fake.removeUnusedTaskResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskResultLifecycle) RemoveUnusedTaskResultsCallCount() int {
+	fake.removeUnusedTaskResultsMutex.RLock()
+	defer fake.removeUnusedTaskResultsMutex.RUnlock()
+	return len(fake.removeUnusedTaskResultsArgsForCall)
+}
+
+func (fake *FakeTaskResultLifecycle) RemoveUnusedTaskResultsCalls(stub func(time.Duration) (int, error)) {
+	fake.removeUnusedTaskResultsMutex.Lock()
+	defer fake.removeUnusedTaskResultsMutex.Unlock()
+	fake.RemoveUnusedTaskResultsStub = stub
+}
+
+func (fake *FakeTaskResultLifecycle) RemoveUnusedTaskResultsArgsForCall(i int) time.Duration {
+	fake.removeUnusedTaskResultsMutex.RLock()
+	defer fake.removeUnusedTaskResultsMutex.RUnlock()
+	argsForCall := fake.removeUnusedTaskResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskResultLifecycle) RemoveUnusedTaskResultsReturns(result1 int, result2 error) {
+	fake.removeUnusedTaskResultsMutex.Lock()
+	defer fake.removeUnusedTaskResultsMutex.Unlock()
+	fake.RemoveUnusedTaskResultsStub = nil
+	fake.removeUnusedTaskResultsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskResultLifecycle) RemoveUnusedTaskResultsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.removeUnusedTaskResultsMutex.Lock()
+	defer fake.removeUnusedTaskResultsMutex.Unlock()
+	fake.RemoveUnusedTaskResultsStub = nil
+	if fake.removeUnusedTaskResultsReturnsOnCall == nil {
+		fake.removeUnusedTaskResultsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.removeUnusedTaskResultsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskResultLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeUnusedTaskResultsMutex.RLock()
+	defer fake.removeUnusedTaskResultsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTaskResultLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.TaskResultLifecycle = new(FakeTaskResultLifecycle)
@@ -276,6 +276,30 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotificationDeliveriesStub        func(int, bool) ([]db.NotificationDelivery, error)
+	notificationDeliveriesMutex       sync.RWMutex
+	notificationDeliveriesArgsForCall []struct {
+		arg1 int
+		arg2 bool
+	}
+	notificationDeliveriesReturns struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	notificationDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	NotificationsStub        func() atc.NotificationRules
+	notificationsMutex       sync.RWMutex
+	notificationsArgsForCall []struct {
+	}
+	notificationsReturns struct {
+		result1 atc.NotificationRules
+	}
+	notificationsReturnsOnCall map[int]struct {
+		result1 atc.NotificationRules
+	}
 	OrderPipelinesStub        func([]string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
@@ -314,6 +338,16 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	PipelineAuthStub        func() atc.PipelineAuth
+	pipelineAuthMutex       sync.RWMutex
+	pipelineAuthArgsForCall []struct {
+	}
+	pipelineAuthReturns struct {
+		result1 atc.PipelineAuth
+	}
+	pipelineAuthReturnsOnCall map[int]struct {
+		result1 atc.PipelineAuth
+	}
 	PipelinesStub        func() ([]db.Pipeline, error)
 	pipelinesMutex       sync.RWMutex
 	pipelinesArgsForCall []struct {
@@ -396,6 +430,19 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SaveSealingKeyStub        func(string) (string, error)
+	saveSealingKeyMutex       sync.RWMutex
+	saveSealingKeyArgsForCall []struct {
+		arg1 string
+	}
+	saveSealingKeyReturns struct {
+		result1 string
+		result2 error
+	}
+	saveSealingKeyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -410,6 +457,69 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SealingKeyStub        func() (string, bool, error)
+	sealingKeyMutex       sync.RWMutex
+	sealingKeyArgsForCall []struct {
+	}
+	sealingKeyReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	sealingKeyReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	SearchBuildLogsStub        func(db.BuildLogSearch) ([]db.BuildLogMatch, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}
+	SecretAccessesStub        func(db.SecretAccessFilter) ([]db.SecretAccess, error)
+	secretAccessesMutex       sync.RWMutex
+	secretAccessesArgsForCall []struct {
+		arg1 db.SecretAccessFilter
+	}
+	secretAccessesReturns struct {
+		result1 []db.SecretAccess
+		result2 error
+	}
+	secretAccessesReturnsOnCall map[int]struct {
+		result1 []db.SecretAccess
+		result2 error
+	}
+	UpdateNotificationsStub        func(atc.NotificationRules) error
+	updateNotificationsMutex       sync.RWMutex
+	updateNotificationsArgsForCall []struct {
+		arg1 atc.NotificationRules
+	}
+	updateNotificationsReturns struct {
+		result1 error
+	}
+	updateNotificationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePipelineAuthStub        func(string, atc.TeamAuth) error
+	updatePipelineAuthMutex       sync.RWMutex
+	updatePipelineAuthArgsForCall []struct {
+		arg1 string
+		arg2 atc.TeamAuth
+	}
+	updatePipelineAuthReturns struct {
+		result1 error
+	}
+	updatePipelineAuthReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -1675,6 +1785,124 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) NotificationDeliveries(arg1 int, arg2 bool) ([]db.NotificationDelivery, error) {
+	fake.notificationDeliveriesMutex.Lock()
+	ret, specificReturn := fake.notificationDeliveriesReturnsOnCall[len(fake.notificationDeliveriesArgsForCall)]
+	fake.notificationDeliveriesArgsForCall = append(fake.notificationDeliveriesArgsForCall, struct {
+		arg1 int
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.NotificationDeliveriesStub
+	fakeReturns := fake.notificationDeliveriesReturns
+	fake.recordInvocation("NotificationDeliveries", []interface{}{arg1, arg2})
+	fake.notificationDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCallCount() int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return len(fake.notificationDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCalls(stub func(int, bool) ([]db.NotificationDelivery, error)) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = stub
+}
+
+func (fake *FakeTeam) NotificationDeliveriesArgsForCall(i int) (int, bool) {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	argsForCall := fake.notificationDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturns(result1 []db.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	fake.notificationDeliveriesReturns = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturnsOnCall(i int, result1 []db.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	if fake.notificationDeliveriesReturnsOnCall == nil {
+		fake.notificationDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.notificationDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Notifications() atc.NotificationRules {
+	fake.notificationsMutex.Lock()
+	ret, specificReturn := fake.notificationsReturnsOnCall[len(fake.notificationsArgsForCall)]
+	fake.notificationsArgsForCall = append(fake.notificationsArgsForCall, struct {
+	}{})
+	stub := fake.NotificationsStub
+	fakeReturns := fake.notificationsReturns
+	fake.recordInvocation("Notifications", []interface{}{})
+	fake.notificationsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) NotificationsCallCount() int {
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
+	return len(fake.notificationsArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationsCalls(stub func() atc.NotificationRules) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = stub
+}
+
+func (fake *FakeTeam) NotificationsReturns(result1 atc.NotificationRules) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	fake.notificationsReturns = struct {
+		result1 atc.NotificationRules
+	}{result1}
+}
+
+func (fake *FakeTeam) NotificationsReturnsOnCall(i int, result1 atc.NotificationRules) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	if fake.notificationsReturnsOnCall == nil {
+		fake.notificationsReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationRules
+		})
+	}
+	fake.notificationsReturnsOnCall[i] = struct {
+		result1 atc.NotificationRules
+	}{result1}
+}
+
 func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -1875,6 +2103,59 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 db.Pipeline, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PipelineAuth() atc.PipelineAuth {
+	fake.pipelineAuthMutex.Lock()
+	ret, specificReturn := fake.pipelineAuthReturnsOnCall[len(fake.pipelineAuthArgsForCall)]
+	fake.pipelineAuthArgsForCall = append(fake.pipelineAuthArgsForCall, struct {
+	}{})
+	stub := fake.PipelineAuthStub
+	fakeReturns := fake.pipelineAuthReturns
+	fake.recordInvocation("PipelineAuth", []interface{}{})
+	fake.pipelineAuthMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PipelineAuthCallCount() int {
+	fake.pipelineAuthMutex.RLock()
+	defer fake.pipelineAuthMutex.RUnlock()
+	return len(fake.pipelineAuthArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineAuthCalls(stub func() atc.PipelineAuth) {
+	fake.pipelineAuthMutex.Lock()
+	defer fake.pipelineAuthMutex.Unlock()
+	fake.PipelineAuthStub = stub
+}
+
+func (fake *FakeTeam) PipelineAuthReturns(result1 atc.PipelineAuth) {
+	fake.pipelineAuthMutex.Lock()
+	defer fake.pipelineAuthMutex.Unlock()
+	fake.PipelineAuthStub = nil
+	fake.pipelineAuthReturns = struct {
+		result1 atc.PipelineAuth
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineAuthReturnsOnCall(i int, result1 atc.PipelineAuth) {
+	fake.pipelineAuthMutex.Lock()
+	defer fake.pipelineAuthMutex.Unlock()
+	fake.PipelineAuthStub = nil
+	if fake.pipelineAuthReturnsOnCall == nil {
+		fake.pipelineAuthReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineAuth
+		})
+	}
+	fake.pipelineAuthReturnsOnCall[i] = struct {
+		result1 atc.PipelineAuth
+	}{result1}
+}
+
 func (fake *FakeTeam) Pipelines() ([]db.Pipeline, error) {
 	fake.pipelinesMutex.Lock()
 	ret, specificReturn := fake.pipelinesReturnsOnCall[len(fake.pipelinesArgsForCall)]
@@ -2250,6 +2531,70 @@ func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SaveSealingKey(arg1 string) (string, error) {
+	fake.saveSealingKeyMutex.Lock()
+	ret, specificReturn := fake.saveSealingKeyReturnsOnCall[len(fake.saveSealingKeyArgsForCall)]
+	fake.saveSealingKeyArgsForCall = append(fake.saveSealingKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SaveSealingKeyStub
+	fakeReturns := fake.saveSealingKeyReturns
+	fake.recordInvocation("SaveSealingKey", []interface{}{arg1})
+	fake.saveSealingKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SaveSealingKeyCallCount() int {
+	fake.saveSealingKeyMutex.RLock()
+	defer fake.saveSealingKeyMutex.RUnlock()
+	return len(fake.saveSealingKeyArgsForCall)
+}
+
+func (fake *FakeTeam) SaveSealingKeyCalls(stub func(string) (string, error)) {
+	fake.saveSealingKeyMutex.Lock()
+	defer fake.saveSealingKeyMutex.Unlock()
+	fake.SaveSealingKeyStub = stub
+}
+
+func (fake *FakeTeam) SaveSealingKeyArgsForCall(i int) string {
+	fake.saveSealingKeyMutex.RLock()
+	defer fake.saveSealingKeyMutex.RUnlock()
+	argsForCall := fake.saveSealingKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SaveSealingKeyReturns(result1 string, result2 error) {
+	fake.saveSealingKeyMutex.Lock()
+	defer fake.saveSealingKeyMutex.Unlock()
+	fake.SaveSealingKeyStub = nil
+	fake.saveSealingKeyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SaveSealingKeyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.saveSealingKeyMutex.Lock()
+	defer fake.saveSealingKeyMutex.Unlock()
+	fake.SaveSealingKeyStub = nil
+	if fake.saveSealingKeyReturnsOnCall == nil {
+		fake.saveSealingKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.saveSealingKeyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -2315,6 +2660,316 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SealingKey() (string, bool, error) {
+	fake.sealingKeyMutex.Lock()
+	ret, specificReturn := fake.sealingKeyReturnsOnCall[len(fake.sealingKeyArgsForCall)]
+	fake.sealingKeyArgsForCall = append(fake.sealingKeyArgsForCall, struct {
+	}{})
+	stub := fake.SealingKeyStub
+	fakeReturns := fake.sealingKeyReturns
+	fake.recordInvocation("SealingKey", []interface{}{})
+	fake.sealingKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SealingKeyCallCount() int {
+	fake.sealingKeyMutex.RLock()
+	defer fake.sealingKeyMutex.RUnlock()
+	return len(fake.sealingKeyArgsForCall)
+}
+
+func (fake *FakeTeam) SealingKeyCalls(stub func() (string, bool, error)) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = stub
+}
+
+func (fake *FakeTeam) SealingKeyReturns(result1 string, result2 bool, result3 error) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = nil
+	fake.sealingKeyReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SealingKeyReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = nil
+	if fake.sealingKeyReturnsOnCall == nil {
+		fake.sealingKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.sealingKeyReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SearchBuildLogs(arg1 db.BuildLogSearch) ([]db.BuildLogMatch, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+	}{arg1})
+	stub := fake.SearchBuildLogsStub
+	fakeReturns := fake.searchBuildLogsReturns
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1})
+	fake.searchBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(db.BuildLogSearch) ([]db.BuildLogMatch, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) db.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []db.BuildLogMatch, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogMatch, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogMatch
+			result2 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SecretAccesses(arg1 db.SecretAccessFilter) ([]db.SecretAccess, error) {
+	fake.secretAccessesMutex.Lock()
+	ret, specificReturn := fake.secretAccessesReturnsOnCall[len(fake.secretAccessesArgsForCall)]
+	fake.secretAccessesArgsForCall = append(fake.secretAccessesArgsForCall, struct {
+		arg1 db.SecretAccessFilter
+	}{arg1})
+	stub := fake.SecretAccessesStub
+	fakeReturns := fake.secretAccessesReturns
+	fake.recordInvocation("SecretAccesses", []interface{}{arg1})
+	fake.secretAccessesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SecretAccessesCallCount() int {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	return len(fake.secretAccessesArgsForCall)
+}
+
+func (fake *FakeTeam) SecretAccessesCalls(stub func(db.SecretAccessFilter) ([]db.SecretAccess, error)) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = stub
+}
+
+func (fake *FakeTeam) SecretAccessesArgsForCall(i int) db.SecretAccessFilter {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	argsForCall := fake.secretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SecretAccessesReturns(result1 []db.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	fake.secretAccessesReturns = struct {
+		result1 []db.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SecretAccessesReturnsOnCall(i int, result1 []db.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	if fake.secretAccessesReturnsOnCall == nil {
+		fake.secretAccessesReturnsOnCall = make(map[int]struct {
+			result1 []db.SecretAccess
+			result2 error
+		})
+	}
+	fake.secretAccessesReturnsOnCall[i] = struct {
+		result1 []db.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateNotifications(arg1 atc.NotificationRules) error {
+	fake.updateNotificationsMutex.Lock()
+	ret, specificReturn := fake.updateNotificationsReturnsOnCall[len(fake.updateNotificationsArgsForCall)]
+	fake.updateNotificationsArgsForCall = append(fake.updateNotificationsArgsForCall, struct {
+		arg1 atc.NotificationRules
+	}{arg1})
+	stub := fake.UpdateNotificationsStub
+	fakeReturns := fake.updateNotificationsReturns
+	fake.recordInvocation("UpdateNotifications", []interface{}{arg1})
+	fake.updateNotificationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateNotificationsCallCount() int {
+	fake.updateNotificationsMutex.RLock()
+	defer fake.updateNotificationsMutex.RUnlock()
+	return len(fake.updateNotificationsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateNotificationsCalls(stub func(atc.NotificationRules) error) {
+	fake.updateNotificationsMutex.Lock()
+	defer fake.updateNotificationsMutex.Unlock()
+	fake.UpdateNotificationsStub = stub
+}
+
+func (fake *FakeTeam) UpdateNotificationsArgsForCall(i int) atc.NotificationRules {
+	fake.updateNotificationsMutex.RLock()
+	defer fake.updateNotificationsMutex.RUnlock()
+	argsForCall := fake.updateNotificationsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateNotificationsReturns(result1 error) {
+	fake.updateNotificationsMutex.Lock()
+	defer fake.updateNotificationsMutex.Unlock()
+	fake.UpdateNotificationsStub = nil
+	fake.updateNotificationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateNotificationsReturnsOnCall(i int, result1 error) {
+	fake.updateNotificationsMutex.Lock()
+	defer fake.updateNotificationsMutex.Unlock()
+	fake.UpdateNotificationsStub = nil
+	if fake.updateNotificationsReturnsOnCall == nil {
+		fake.updateNotificationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateNotificationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineAuth(arg1 string, arg2 atc.TeamAuth) error {
+	fake.updatePipelineAuthMutex.Lock()
+	ret, specificReturn := fake.updatePipelineAuthReturnsOnCall[len(fake.updatePipelineAuthArgsForCall)]
+	fake.updatePipelineAuthArgsForCall = append(fake.updatePipelineAuthArgsForCall, struct {
+		arg1 string
+		arg2 atc.TeamAuth
+	}{arg1, arg2})
+	stub := fake.UpdatePipelineAuthStub
+	fakeReturns := fake.updatePipelineAuthReturns
+	fake.recordInvocation("UpdatePipelineAuth", []interface{}{arg1, arg2})
+	fake.updatePipelineAuthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePipelineAuthCallCount() int {
+	fake.updatePipelineAuthMutex.RLock()
+	defer fake.updatePipelineAuthMutex.RUnlock()
+	return len(fake.updatePipelineAuthArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePipelineAuthCalls(stub func(string, atc.TeamAuth) error) {
+	fake.updatePipelineAuthMutex.Lock()
+	defer fake.updatePipelineAuthMutex.Unlock()
+	fake.UpdatePipelineAuthStub = stub
+}
+
+func (fake *FakeTeam) UpdatePipelineAuthArgsForCall(i int) (string, atc.TeamAuth) {
+	fake.updatePipelineAuthMutex.RLock()
+	defer fake.updatePipelineAuthMutex.RUnlock()
+	argsForCall := fake.updatePipelineAuthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UpdatePipelineAuthReturns(result1 error) {
+	fake.updatePipelineAuthMutex.Lock()
+	defer fake.updatePipelineAuthMutex.Unlock()
+	fake.UpdatePipelineAuthStub = nil
+	fake.updatePipelineAuthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineAuthReturnsOnCall(i int, result1 error) {
+	fake.updatePipelineAuthMutex.Lock()
+	defer fake.updatePipelineAuthMutex.Unlock()
+	fake.UpdatePipelineAuthStub = nil
+	if fake.updatePipelineAuthReturnsOnCall == nil {
+		fake.updatePipelineAuthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePipelineAuthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2475,12 +3130,18 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isContainerWithinTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
 	fake.orderPipelinesWithinGroupMutex.RLock()
 	defer fake.orderPipelinesWithinGroupMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
+	fake.pipelineAuthMutex.RLock()
+	defer fake.pipelineAuthMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
@@ -2493,8 +3154,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveSealingKeyMutex.RLock()
+	defer fake.saveSealingKeyMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.sealingKeyMutex.RLock()
+	defer fake.sealingKeyMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	fake.updateNotificationsMutex.RLock()
+	defer fake.updateNotificationsMutex.RUnlock()
+	fake.updatePipelineAuthMutex.RLock()
+	defer fake.updatePipelineAuthMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	fake.workersMutex.RLock()
@@ -24,6 +24,7 @@ type InputConfig struct {
 	Name            string
 	Trigger         bool
 	Passed          JobSet
+	PassedAny       JobSet
 	UseEveryVersion bool
 	PinnedVersion   atc.Version
 	ResourceID      int
@@ -223,7 +224,7 @@ func (j *job) Config() (atc.JobConfig, error) {
 }
 
 func (j *job) AlgorithmInputs() (InputConfigs, error) {
-	rows, err := psql.Select("ji.name", "ji.resource_id", "array_agg(ji.passed_job_id)", "ji.version", "rp.version", "ji.trigger").
+	rows, err := psql.Select("ji.name", "ji.resource_id", "array_agg(ji.passed_job_id) FILTER (WHERE NOT ji.passed_any)", "array_agg(ji.passed_job_id) FILTER (WHERE ji.passed_any)", "ji.version", "rp.version", "ji.trigger").
 		From("job_inputs ji").
 		LeftJoin("resource_pins rp ON rp.resource_id = ji.resource_id").
 		Where(sq.Eq{
@@ -238,13 +239,13 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 
 	var inputs InputConfigs
 	for rows.Next() {
-		var passedJobs []sql.NullInt64
+		var passedJobs, passedAnyJobs []sql.NullInt64
 		var configVersionString, pinnedVersionString sql.NullString
 		var inputName string
 		var resourceID int
 		var trigger bool
 
-		err = rows.Scan(&inputName, &resourceID, pq.Array(&passedJobs), &configVersionString, &pinnedVersionString, &trigger)
+		err = rows.Scan(&inputName, &resourceID, pq.Array(&passedJobs), pq.Array(&passedAnyJobs), &configVersionString, &pinnedVersionString, &trigger)
 		if err != nil {
 			return nil, err
 		}
@@ -289,6 +290,17 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 			inputConfig.Passed = passed
 		}
 
+		passedAny := make(JobSet)
+		for _, s := range passedAnyJobs {
+			if s.Valid {
+				passedAny[int(s.Int64)] = true
+			}
+		}
+
+		if len(passedAny) > 0 {
+			inputConfig.PassedAny = passedAny
+		}
+
 		inputs = append(inputs, inputConfig)
 	}
 
@@ -296,7 +308,7 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 }
 
 func (j *job) Inputs() ([]atc.JobInput, error) {
-	rows, err := psql.Select("ji.name", "r.name", "array_agg(p.name ORDER BY p.id)", "ji.trigger", "ji.version").
+	rows, err := psql.Select("ji.name", "r.name", "array_agg(p.name ORDER BY p.id) FILTER (WHERE NOT ji.passed_any)", "array_agg(p.name ORDER BY p.id) FILTER (WHERE ji.passed_any)", "ji.trigger", "ji.version").
 		From("job_inputs ji").
 		Join("resources r ON r.id = ji.resource_id").
 		LeftJoin("jobs p ON p.id = ji.passed_job_id").
@@ -312,12 +324,12 @@ func (j *job) Inputs() ([]atc.JobInput, error) {
 
 	var inputs []atc.JobInput
 	for rows.Next() {
-		var passedString []sql.NullString
+		var passedString, passedAnyString []sql.NullString
 		var versionString sql.NullString
 		var inputName, resourceName string
 		var trigger bool
 
-		err = rows.Scan(&inputName, &resourceName, pq.Array(&passedString), &trigger, &versionString)
+		err = rows.Scan(&inputName, &resourceName, pq.Array(&passedString), pq.Array(&passedAnyString), &trigger, &versionString)
 		if err != nil {
 			return nil, err
 		}
@@ -338,12 +350,20 @@ func (j *job) Inputs() ([]atc.JobInput, error) {
 			}
 		}
 
+		var passedAny []string
+		for _, s := range passedAnyString {
+			if s.Valid {
+				passedAny = append(passedAny, s.String)
+			}
+		}
+
 		inputs = append(inputs, atc.JobInput{
-			Name:     inputName,
-			Resource: resourceName,
-			Trigger:  trigger,
-			Version:  version,
-			Passed:   passed,
+			Name:      inputName,
+			Resource:  resourceName,
+			Trigger:   trigger,
+			Version:   version,
+			Passed:    passed,
+			PassedAny: passedAny,
 		})
 	}
 
@@ -18,7 +18,7 @@ import (
 // dashboard object and also a scheduler job object. Figure out what this is
 // trying to encapsulate or considering splitting this out!
 type JobFactory interface {
-	VisibleJobs([]string) ([]atc.JobSummary, error)
+	VisibleJobs([]string, BoundPipelines) ([]atc.JobSummary, error)
 	AllActiveJobs() ([]atc.JobSummary, error)
 	JobsToSchedule() (SchedulerJobs, error)
 }
@@ -224,7 +224,7 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 	return schedulerJobs, nil
 }
 
-func (j *jobFactory) VisibleJobs(teamNames []string) ([]atc.JobSummary, error) {
+func (j *jobFactory) VisibleJobs(teamNames []string, boundPipelines BoundPipelines) ([]atc.JobSummary, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -235,6 +235,7 @@ func (j *jobFactory) VisibleJobs(teamNames []string) ([]atc.JobSummary, error) {
 	dashboardFactory := newDashboardFactory(tx, sq.Or{
 		sq.Eq{"tm.name": teamNames},
 		sq.Eq{"p.public": true},
+		boundPipelines.where("tm", "p"),
 	})
 
 	dashboard, err := dashboardFactory.buildDashboard()
@@ -413,7 +414,7 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 }
 
 func (d dashboardFactory) fetchJobInputs() (map[int][]atc.JobInputSummary, error) {
-	rows, err := psql.Select("j.id", "i.name", "r.name", "array_agg(jp.name ORDER BY jp.id)", "i.trigger").
+	rows, err := psql.Select("j.id", "i.name", "r.name", "array_agg(jp.name ORDER BY jp.id) FILTER (WHERE NOT i.passed_any)", "array_agg(jp.name ORDER BY jp.id) FILTER (WHERE i.passed_any)", "i.trigger").
 		From("job_inputs i").
 		Join("jobs j ON j.id = i.job_id").
 		Join("pipelines p ON p.id = j.pipeline_id").
@@ -434,12 +435,12 @@ func (d dashboardFactory) fetchJobInputs() (map[int][]atc.JobInputSummary, error
 
 	jobInputs := make(map[int][]atc.JobInputSummary)
 	for rows.Next() {
-		var passedString []sql.NullString
+		var passedString, passedAnyString []sql.NullString
 		var inputName, resourceName string
 		var jobID int
 		var trigger bool
 
-		err = rows.Scan(&jobID, &inputName, &resourceName, pq.Array(&passedString), &trigger)
+		err = rows.Scan(&jobID, &inputName, &resourceName, pq.Array(&passedString), pq.Array(&passedAnyString), &trigger)
 		if err != nil {
 			return nil, err
 		}
@@ -451,11 +452,19 @@ func (d dashboardFactory) fetchJobInputs() (map[int][]atc.JobInputSummary, error
 			}
 		}
 
+		var passedAny []string
+		for _, s := range passedAnyString {
+			if s.Valid {
+				passedAny = append(passedAny, s.String)
+			}
+		}
+
 		jobInputs[jobID] = append(jobInputs[jobID], atc.JobInputSummary{
-			Name:     inputName,
-			Resource: resourceName,
-			Trigger:  trigger,
-			Passed:   passed,
+			Name:      inputName,
+			Resource:  resourceName,
+			Trigger:   trigger,
+			Passed:    passed,
+			PassedAny: passedAny,
 		})
 	}
 
@@ -134,7 +134,7 @@ var _ = Describe("JobFactory", func() {
 
 		Describe("VisibleJobs", func() {
 			It("returns jobs in the provided teams and jobs in public pipelines", func() {
-				visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
+				visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"}, nil)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(len(visibleJobs)).To(Equal(4))
@@ -226,7 +226,7 @@ var _ = Describe("JobFactory", func() {
 				nextBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
-				visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
+				visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"}, nil)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(visibleJobs[0].Name).To(Equal("some-job"))
@@ -2212,6 +2212,78 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when the input has passed_any constraints", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithPipeline(atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name:      "some-input",
+											Resource:  "some-resource",
+											Passed:    []string{"job-1"},
+											PassedAny: []string{"job-2", "job-3"},
+											Trigger:   true,
+										},
+									},
+								},
+							},
+							{
+								Name: "job-1",
+							},
+							{
+								Name: "job-2",
+							},
+							{
+								Name: "job-3",
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-type",
+							},
+						},
+					}),
+				)
+			})
+
+			It("returns them separately from the passed constraints", func() {
+				Expect(inputs).To(Equal(db.InputConfigs{
+					{
+						Name:       "some-input",
+						JobID:      scenario.Job("some-job").ID(),
+						ResourceID: scenario.Resource("some-resource").ID(),
+						Passed: db.JobSet{
+							scenario.Job("job-1").ID(): true,
+						},
+						PassedAny: db.JobSet{
+							scenario.Job("job-2").ID(): true,
+							scenario.Job("job-3").ID(): true,
+						},
+						Trigger: true,
+					},
+				}))
+			})
+
+			It("returns them separately from the passed constraints in the job's inputs", func() {
+				jobInputs, err := scenario.Job("some-job").Inputs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobInputs).To(Equal([]atc.JobInput{
+					{
+						Name:      "some-input",
+						Resource:  "some-resource",
+						Passed:    []string{"job-1"},
+						PassedAny: []string{"job-2", "job-3"},
+						Trigger:   true,
+					},
+				}))
+			})
+		})
+
 		Context("when the input is pinned through the get step", func() {
 			BeforeEach(func() {
 				scenario = dbtest.Setup(
@@ -0,0 +1,9 @@
+CREATE TABLE build_approvals (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    approved boolean NOT NULL,
+    approver text NOT NULL,
+    comment text NOT NULL DEFAULT '',
+    decided_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (build_id, name)
+);
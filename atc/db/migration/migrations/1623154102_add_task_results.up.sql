@@ -0,0 +1,14 @@
+CREATE TABLE task_results (
+    id serial PRIMARY KEY,
+    job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    step_name text NOT NULL,
+    cache_key text NOT NULL,
+    exit_status integer NOT NULL,
+    last_used timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX task_results_job_id_step_name_cache_key_uniq
+    ON task_results (job_id, step_name, cache_key);
+
+CREATE INDEX task_results_last_used
+    ON task_results (last_used);
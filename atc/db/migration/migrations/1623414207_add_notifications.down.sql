@@ -0,0 +1,3 @@
+DROP TABLE notification_deliveries;
+
+ALTER TABLE teams DROP COLUMN notifications;
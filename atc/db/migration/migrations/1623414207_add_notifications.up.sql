@@ -0,0 +1,20 @@
+ALTER TABLE teams ADD COLUMN notifications text;
+
+CREATE TABLE notification_deliveries (
+    id serial PRIMARY KEY,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    url text NOT NULL,
+    event text NOT NULL,
+    attempt integer NOT NULL,
+    status_code integer,
+    error text NOT NULL DEFAULT '',
+    succeeded boolean NOT NULL,
+    attempted_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX notification_deliveries_team_id_idx
+    ON notification_deliveries (team_id, id DESC);
+
+CREATE INDEX notification_deliveries_build_id_idx
+    ON notification_deliveries (build_id);
@@ -0,0 +1,3 @@
+DROP TABLE build_test_cases;
+
+DROP TABLE build_test_suites;
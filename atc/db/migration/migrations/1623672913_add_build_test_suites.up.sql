@@ -0,0 +1,23 @@
+CREATE TABLE build_test_suites (
+    id serial PRIMARY KEY,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    step_name text NOT NULL,
+    name text NOT NULL,
+    duration double precision NOT NULL DEFAULT 0
+);
+
+CREATE INDEX build_test_suites_build_id_idx
+    ON build_test_suites (build_id);
+
+CREATE TABLE build_test_cases (
+    id serial PRIMARY KEY,
+    suite_id integer NOT NULL REFERENCES build_test_suites (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    class_name text NOT NULL DEFAULT '',
+    duration double precision NOT NULL DEFAULT 0,
+    status text NOT NULL,
+    failure_message text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX build_test_cases_suite_id_idx
+    ON build_test_cases (suite_id);
@@ -0,0 +1,53 @@
+-- index the output of log events in each partition for full-text search,
+-- without ANSI escape sequences so that colored words are indexed as words
+DO $$
+DECLARE
+  pipeline record;
+  team record;
+BEGIN
+FOR pipeline IN
+  SELECT id, name FROM pipelines
+LOOP
+  RAISE NOTICE 'creating log search index for pipeline % (%)', pipeline.id, pipeline.name;
+  BEGIN
+    EXECUTE format('CREATE INDEX IF NOT EXISTS pipeline_build_events_%s_log_search ON pipeline_build_events_%s USING gin (to_tsvector(''simple'', regexp_replace(payload::json->>''payload'', ''\x1b\[[0-9;]*[A-Za-z]'', '''', ''g''))) WHERE type = ''log''', pipeline.id, pipeline.id);
+  EXCEPTION
+  WHEN undefined_table then
+    RAISE NOTICE '%', SQLERRM;
+  END;
+END LOOP;
+
+FOR team IN
+  SELECT id, name FROM teams
+LOOP
+  RAISE NOTICE 'creating log search index for team % (%)', team.id, team.name;
+  BEGIN
+    EXECUTE format('CREATE INDEX IF NOT EXISTS team_build_events_%s_log_search ON team_build_events_%s USING gin (to_tsvector(''simple'', regexp_replace(payload::json->>''payload'', ''\x1b\[[0-9;]*[A-Za-z]'', '''', ''g''))) WHERE type = ''log''', team.id, team.id);
+  EXCEPTION
+  WHEN undefined_table then
+    RAISE NOTICE '%', SQLERRM;
+  END;
+END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE INDEX check_build_events_log_search ON check_build_events USING gin (to_tsvector('simple', regexp_replace(payload::json->>'payload', '\x1b\[[0-9;]*[A-Za-z]', '', 'g'))) WHERE type = 'log';
+
+CREATE OR REPLACE FUNCTION on_pipeline_insert() RETURNS TRIGGER AS $$
+BEGIN
+  EXECUTE format('CREATE TABLE IF NOT EXISTS pipeline_build_events_%s () INHERITS (build_events)', NEW.id);
+  EXECUTE format('CREATE UNIQUE INDEX pipeline_build_events_%s_build_id_event_id ON pipeline_build_events_%s (build_id, event_id)', NEW.id, NEW.id);
+  EXECUTE format('CREATE UNIQUE INDEX pipeline_build_events_%s_build_id_old_event_id ON pipeline_build_events_%s (build_id_old, event_id)', NEW.id, NEW.id);
+  EXECUTE format('CREATE INDEX pipeline_build_events_%s_log_search ON pipeline_build_events_%s USING gin (to_tsvector(''simple'', regexp_replace(payload::json->>''payload'', ''\x1b\[[0-9;]*[A-Za-z]'', '''', ''g''))) WHERE type = ''log''', NEW.id, NEW.id);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION on_team_insert() RETURNS TRIGGER AS $$
+BEGIN
+  EXECUTE format('CREATE TABLE IF NOT EXISTS team_build_events_%s () INHERITS (build_events)', NEW.id);
+  EXECUTE format('CREATE UNIQUE INDEX team_build_events_%s_build_id_event_id ON team_build_events_%s (build_id, event_id)', NEW.id, NEW.id);
+  EXECUTE format('CREATE INDEX team_build_events_%s_log_search ON team_build_events_%s USING gin (to_tsvector(''simple'', regexp_replace(payload::json->>''payload'', ''\x1b\[[0-9;]*[A-Za-z]'', '''', ''g''))) WHERE type = ''log''', NEW.id, NEW.id);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
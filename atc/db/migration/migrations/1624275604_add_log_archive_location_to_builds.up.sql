@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN log_archive_location text;
@@ -0,0 +1,12 @@
+-- leases are deliberately not tied to builds by a foreign key, so that the
+-- leases of removed builds can still be found and revoked
+CREATE TABLE build_secret_leases (
+    lease_id text PRIMARY KEY,
+    build_id integer NOT NULL,
+    duration integer NOT NULL,
+    renewable boolean NOT NULL DEFAULT false,
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX build_secret_leases_build_id_idx
+    ON build_secret_leases (build_id);
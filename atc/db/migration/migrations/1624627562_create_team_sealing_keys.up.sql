@@ -0,0 +1,5 @@
+CREATE TABLE team_sealing_keys (
+    team_id integer PRIMARY KEY REFERENCES teams (id) ON DELETE CASCADE,
+    identity text NOT NULL,
+    nonce text
+);
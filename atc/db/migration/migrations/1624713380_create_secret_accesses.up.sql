@@ -0,0 +1,26 @@
+-- secret accesses are an audit trail, so they deliberately outlive the
+-- builds, pipelines and jobs which made them; names are copied for the same
+-- reason
+CREATE TABLE secret_accesses (
+    id bigserial PRIMARY KEY,
+    team_id integer NOT NULL,
+    team_name text NOT NULL,
+    pipeline_id integer,
+    pipeline_name text,
+    pipeline_instance_vars jsonb,
+    job_name text,
+    build_id integer NOT NULL,
+    build_name text NOT NULL,
+    step_name text NOT NULL,
+    var_name text NOT NULL,
+    var_source text NOT NULL DEFAULT '',
+    backend text NOT NULL,
+    secret_path text NOT NULL,
+    accessed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX secret_accesses_team_id_var_name_accessed_at_idx
+    ON secret_accesses (team_id, var_name, accessed_at);
+
+CREATE INDEX secret_accesses_accessed_at_idx
+    ON secret_accesses (accessed_at);
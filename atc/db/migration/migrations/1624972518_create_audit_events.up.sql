@@ -0,0 +1,23 @@
+-- audit events must outlive the teams, pipelines and users they mention, so
+-- names are stored rather than references
+CREATE TABLE audit_events (
+    id bigserial PRIMARY KEY,
+    action text NOT NULL,
+    user_name text NOT NULL DEFAULT '',
+    connector text NOT NULL DEFAULT '',
+    team_name text,
+    target jsonb,
+    method text NOT NULL,
+    path text NOT NULL,
+    remote_addr text NOT NULL DEFAULT '',
+    user_agent text NOT NULL DEFAULT '',
+    parameters jsonb,
+    status integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_events_created_at_idx
+    ON audit_events (created_at);
+
+CREATE INDEX audit_events_user_name_idx
+    ON audit_events (user_name);
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN pipeline_auth text;
@@ -0,0 +1,23 @@
+-- only a hash of each token is stored; the token itself is shown once, when
+-- it is created
+CREATE TABLE api_tokens (
+    id serial PRIMARY KEY,
+    name text NOT NULL,
+    token_hash text NOT NULL UNIQUE,
+    owner_sub text NOT NULL,
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    role text,
+    scopes text[] NOT NULL DEFAULT '{}',
+    claims jsonb,
+    created_by text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX api_tokens_owner_sub_name_uniq
+    ON api_tokens (owner_sub, name)
+    WHERE team_id IS NULL;
+
+CREATE UNIQUE INDEX api_tokens_team_id_name_uniq
+    ON api_tokens (team_id, name)
+    WHERE team_id IS NOT NULL;
@@ -0,0 +1,8 @@
+DROP INDEX access_tokens_sub_idx;
+
+ALTER TABLE access_tokens
+  DROP COLUMN id,
+  DROP COLUMN client_id,
+  DROP COLUMN remote_addr,
+  DROP COLUMN created_at,
+  DROP COLUMN last_used_at;
@@ -0,0 +1,8 @@
+ALTER TABLE access_tokens
+  ADD COLUMN id serial NOT NULL UNIQUE,
+  ADD COLUMN client_id text,
+  ADD COLUMN remote_addr text,
+  ADD COLUMN created_at timestamp with time zone DEFAULT now() NOT NULL,
+  ADD COLUMN last_used_at timestamp with time zone;
+
+CREATE INDEX access_tokens_sub_idx ON access_tokens (sub);
@@ -0,0 +1,2 @@
+ALTER TABLE job_inputs
+  DROP COLUMN passed_any;
@@ -0,0 +1,2 @@
+ALTER TABLE job_inputs
+  ADD COLUMN passed_any bool NOT NULL DEFAULT false;
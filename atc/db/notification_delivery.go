@@ -0,0 +1,125 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// NotificationDelivery is a single attempt at delivering a build notification
+// to a URL.
+type NotificationDelivery struct {
+	ID int
+
+	BuildID              int
+	BuildName            string
+	JobName              string
+	PipelineName         string
+	PipelineInstanceVars atc.InstanceVars
+	TeamName             string
+
+	URL     string
+	Event   atc.BuildStatus
+	Attempt int
+
+	// StatusCode is zero if no response was received.
+	StatusCode int
+	Error      string
+	Succeeded  bool
+
+	AttemptedAt time.Time
+}
+
+func (b *build) SaveNotificationDelivery(delivery NotificationDelivery) error {
+	var statusCode sql.NullInt64
+	if delivery.StatusCode != 0 {
+		statusCode = sql.NullInt64{Int64: int64(delivery.StatusCode), Valid: true}
+	}
+
+	_, err := psql.Insert("notification_deliveries").
+		Columns("build_id", "team_id", "url", "event", "attempt", "status_code", "error", "succeeded").
+		Values(b.id, b.teamID, delivery.URL, delivery.Event, delivery.Attempt, statusCode, delivery.Error, delivery.Succeeded).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// NotificationDeliveries returns the most recent delivery attempts for the
+// team's builds, newest first.
+func (t *team) NotificationDeliveries(limit int, failedOnly bool) ([]NotificationDelivery, error) {
+	query := psql.Select(
+		"d.id", "d.build_id", "b.name", "j.name", "p.name", "p.instance_vars", "t.name",
+		"d.url", "d.event", "d.attempt", "d.status_code", "d.error", "d.succeeded", "d.attempted_at",
+	).
+		From("notification_deliveries d").
+		Join("builds b ON b.id = d.build_id").
+		Join("teams t ON t.id = d.team_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		Where(sq.Eq{"d.team_id": t.id}).
+		OrderBy("d.id DESC")
+
+	if failedOnly {
+		query = query.Where(sq.Eq{"d.succeeded": false})
+	}
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.RunWith(t.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	deliveries := []NotificationDelivery{}
+	for rows.Next() {
+		var (
+			delivery              NotificationDelivery
+			jobName, pipelineName sql.NullString
+			pipelineInstanceVars  sql.NullString
+			statusCode            sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.BuildID,
+			&delivery.BuildName,
+			&jobName,
+			&pipelineName,
+			&pipelineInstanceVars,
+			&delivery.TeamName,
+			&delivery.URL,
+			&delivery.Event,
+			&delivery.Attempt,
+			&statusCode,
+			&delivery.Error,
+			&delivery.Succeeded,
+			&delivery.AttemptedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.JobName = jobName.String
+		delivery.PipelineName = pipelineName.String
+		delivery.StatusCode = int(statusCode.Int64)
+
+		if pipelineInstanceVars.Valid {
+			err = json.Unmarshal([]byte(pipelineInstanceVars.String), &delivery.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
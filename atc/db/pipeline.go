@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/sealed"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -1087,6 +1088,7 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 // Variables creates variables for this pipeline. If this pipeline has its own
 // var_sources, a vars.MultiVars containing all pipeline specific var_sources
 // plug the global variables, otherwise just return the global variables.
+// Either way, values sealed for the team can be referenced as ((sealed:...)).
 func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, error) {
 	globalVars := creds.NewVariables(globalSecrets, p.TeamName(), p.Name(), false)
 	namedVarsMap := vars.NamedVariables{}
@@ -1120,16 +1122,30 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 		if err != nil {
 			return nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
 		}
+		// vars read through var_sources are recorded along with those read
+		// from the cluster-wide credential manager
+		if recording, ok := globalSecrets.(*creds.AccessRecordingSecrets); ok {
+			secrets = recording.ForVarSource(cm.Name, cm.Type, secrets)
+		}
+
 		namedVarsMap[cm.Name] = creds.NewVariables(secrets, p.TeamName(), p.Name(), true)
 	}
 
+	// Values sealed for the team are available to every pipeline, unless it
+	// has its own var_source of the same name.
+	if _, found := namedVarsMap[sealed.VarSource]; found {
+		return allVars, nil
+	}
+
+	sealingKeys := teamSealingKeys{p.conn, p.teamID}
+
 	// If there is no var_source from the pipeline, then just return the global
 	// vars.
 	if len(namedVarsMap) == 0 {
-		return globalVars, nil
+		return sealed.NewVariables(globalVars, sealingKeys), nil
 	}
 
-	return allVars, nil
+	return sealed.NewVariables(allVars, sealingKeys), nil
 }
 
 func (p *pipeline) SetParentIDs(jobID, buildID int) error {
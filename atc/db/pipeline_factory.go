@@ -1,6 +1,8 @@
 package db
 
 import (
+	"sort"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc/db/lock"
 )
@@ -93,3 +95,28 @@ func (f *pipelineFactory) PipelinesToSchedule() ([]Pipeline, error) {
 
 	return scanPipelines(f.conn, f.lockFactory, rows)
 }
+
+// BoundPipelines are the names of the pipelines, by team name, which a user
+// has been bound to a role on, whether or not they have a role on the team.
+type BoundPipelines map[string][]string
+
+// where matches the bound pipelines, given the aliases of the teams and
+// pipelines tables in the query.
+func (b BoundPipelines) where(teamsAlias string, pipelinesAlias string) sq.Or {
+	teamNames := make([]string, 0, len(b))
+	for teamName := range b {
+		teamNames = append(teamNames, teamName)
+	}
+
+	sort.Strings(teamNames)
+
+	cond := sq.Or{}
+	for _, teamName := range teamNames {
+		cond = append(cond, sq.And{
+			sq.Eq{teamsAlias + ".name": teamName},
+			sq.Eq{pipelinesAlias + ".name": b[teamName]},
+		})
+	}
+
+	return cond
+}
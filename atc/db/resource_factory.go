@@ -10,7 +10,7 @@ import (
 //counterfeiter:generate . ResourceFactory
 type ResourceFactory interface {
 	Resource(int) (Resource, bool, error)
-	VisibleResources([]string) ([]Resource, error)
+	VisibleResources([]string, BoundPipelines) ([]Resource, error)
 	AllResources() ([]Resource, error)
 }
 
@@ -44,7 +44,7 @@ func (r *resourceFactory) Resource(resourceID int) (Resource, bool, error) {
 	return resource, true, nil
 }
 
-func (r *resourceFactory) VisibleResources(teamNames []string) ([]Resource, error) {
+func (r *resourceFactory) VisibleResources(teamNames []string, boundPipelines BoundPipelines) ([]Resource, error) {
 	rows, err := resourcesQuery.
 		Where(sq.Or{
 			sq.Eq{"t.name": teamNames},
@@ -52,6 +52,7 @@ func (r *resourceFactory) VisibleResources(teamNames []string) ([]Resource, erro
 				sq.NotEq{"t.name": teamNames},
 				sq.Eq{"p.public": true},
 			},
+			boundPipelines.where("t", "p"),
 		}).
 		OrderBy("r.id ASC").
 		RunWith(r.conn).
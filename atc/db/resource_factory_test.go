@@ -39,7 +39,7 @@ var _ = Describe("Resource Factory", func() {
 
 		Context("VisibleResources", func() {
 			It("returns resources in the provided teams and resources in public pipelines", func() {
-				visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"})
+				visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"}, nil)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(len(visibleResources)).To(Equal(2))
@@ -48,13 +48,22 @@ var _ = Describe("Resource Factory", func() {
 			})
 
 			It("returns team name and groups for each resource", func() {
-				visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"})
+				visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"}, nil)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(visibleResources[0].TeamName()).To(Equal("default-team"))
 				Expect(visibleResources[1].TeamName()).To(Equal("other-team"))
 			})
 
+			It("returns resources in the bound private pipelines of other teams", func() {
+				visibleResources, err := resourceFactory.VisibleResources([]string{"default-team"}, db.BoundPipelines{
+					"other-team": {"private-pipeline"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(len(visibleResources)).To(Equal(3))
+				Expect(visibleResources[2].Name()).To(Equal("private-pipeline-resource"))
+			})
 		})
 
 		Context("AllResources", func() {
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// SecretAccess records a step of a build reading a var from a credential
+// manager. The value is never recorded.
+type SecretAccess struct {
+	ID int
+
+	TeamName             string
+	PipelineName         string
+	PipelineInstanceVars atc.InstanceVars
+	JobName              string
+	BuildID              int
+	BuildName            string
+	StepName             string
+
+	creds.SecretAccess
+
+	AccessedAt time.Time
+}
+
+// SecretAccessFilter filters the accesses returned by SecretAccesses.
+type SecretAccessFilter struct {
+	Var          string
+	PipelineName string
+	JobName      string
+
+	Since time.Time
+	Until time.Time
+
+	Limit int
+}
+
+//counterfeiter:generate . SecretAccessLifecycle
+type SecretAccessLifecycle interface {
+	RemoveSecretAccessesOlderThan(time.Duration) (int, error)
+}
+
+type secretAccessLifecycle struct {
+	conn Conn
+}
+
+func NewSecretAccessLifecycle(conn Conn) SecretAccessLifecycle {
+	return &secretAccessLifecycle{conn}
+}
+
+func (l secretAccessLifecycle) RemoveSecretAccessesOlderThan(age time.Duration) (int, error) {
+	result, err := psql.Delete("secret_accesses").
+		Where(sq.Lt{"accessed_at": time.Now().Add(-age)}).
+		RunWith(l.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// RecordSecretAccess records the named step of the build reading a var.
+func (b *build) RecordSecretAccess(step string, access creds.SecretAccess) error {
+	var pipelineID sql.NullInt64
+	if b.pipelineID != 0 {
+		pipelineID = sql.NullInt64{Int64: int64(b.pipelineID), Valid: true}
+	}
+
+	var instanceVars interface{}
+	if b.pipelineInstanceVars != nil {
+		payload, err := json.Marshal(b.pipelineInstanceVars)
+		if err != nil {
+			return err
+		}
+
+		instanceVars = payload
+	}
+
+	_, err := psql.Insert("secret_accesses").
+		Columns(
+			"team_id", "team_name",
+			"pipeline_id", "pipeline_name", "pipeline_instance_vars", "job_name",
+			"build_id", "build_name", "step_name",
+			"var_name", "var_source", "backend", "secret_path",
+		).
+		Values(
+			b.teamID, b.teamName,
+			pipelineID, nullString(b.pipelineName), instanceVars, nullString(b.jobName),
+			b.id, b.name, step,
+			access.Var, access.VarSource, access.Backend, access.SecretPath,
+		).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// SecretAccesses returns the vars read by the team's builds, most recent
+// first.
+func (t *team) SecretAccesses(filter SecretAccessFilter) ([]SecretAccess, error) {
+	query := psql.Select(
+		"id", "team_name", "pipeline_name", "pipeline_instance_vars", "job_name",
+		"build_id", "build_name", "step_name",
+		"var_name", "var_source", "backend", "secret_path", "accessed_at",
+	).
+		From("secret_accesses").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("accessed_at DESC", "id DESC")
+
+	if filter.Var != "" {
+		query = query.Where(sq.Eq{"var_name": filter.Var})
+	}
+
+	if filter.PipelineName != "" {
+		query = query.Where(sq.Eq{"pipeline_name": filter.PipelineName})
+	}
+
+	if filter.JobName != "" {
+		query = query.Where(sq.Eq{"job_name": filter.JobName})
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"accessed_at": filter.Since})
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where(sq.LtOrEq{"accessed_at": filter.Until})
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+
+	rows, err := query.RunWith(t.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	accesses := []SecretAccess{}
+	for rows.Next() {
+		var (
+			access SecretAccess
+
+			pipelineName, jobName sql.NullString
+			instanceVars          sql.NullString
+		)
+
+		err := rows.Scan(
+			&access.ID, &access.TeamName, &pipelineName, &instanceVars, &jobName,
+			&access.BuildID, &access.BuildName, &access.StepName,
+			&access.Var, &access.VarSource, &access.Backend, &access.SecretPath, &access.AccessedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		access.PipelineName = pipelineName.String
+		access.JobName = jobName.String
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &access.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		accesses = append(accesses, access)
+	}
+
+	return accesses, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
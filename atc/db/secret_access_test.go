@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccess", func() {
+	var (
+		jobBuild    db.Build
+		oneOffBuild db.Build
+	)
+
+	BeforeEach(func() {
+		var err error
+		jobBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = jobBuild.RecordSecretAccess("deploy", creds.SecretAccess{
+			Var:        "prod-db-password",
+			Backend:    "vault",
+			SecretPath: "/concourse/default-team/prod-db-password",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = oneOffBuild.RecordSecretAccess("fetch", creds.SecretAccess{
+			Var:        "token",
+			VarSource:  "some-source",
+			Backend:    "vault",
+			SecretPath: "/secret/token",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		otherBuild, err := otherTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = otherBuild.RecordSecretAccess("deploy", creds.SecretAccess{
+			Var:        "prod-db-password",
+			Backend:    "vault",
+			SecretPath: "/concourse/other-team/prod-db-password",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Team.SecretAccesses", func() {
+		It("returns the team's accesses, most recent first", func() {
+			accesses, err := defaultTeam.SecretAccesses(db.SecretAccessFilter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(2))
+
+			Expect(accesses[0].BuildID).To(Equal(oneOffBuild.ID()))
+			Expect(accesses[0].PipelineName).To(BeEmpty())
+			Expect(accesses[0].JobName).To(BeEmpty())
+			Expect(accesses[0].StepName).To(Equal("fetch"))
+			Expect(accesses[0].SecretAccess).To(Equal(creds.SecretAccess{
+				Var:        "token",
+				VarSource:  "some-source",
+				Backend:    "vault",
+				SecretPath: "/secret/token",
+			}))
+
+			Expect(accesses[1].BuildID).To(Equal(jobBuild.ID()))
+			Expect(accesses[1].BuildName).To(Equal(jobBuild.Name()))
+			Expect(accesses[1].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(accesses[1].PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(accesses[1].PipelineInstanceVars).To(Equal(defaultPipeline.InstanceVars()))
+			Expect(accesses[1].JobName).To(Equal(defaultJob.Name()))
+			Expect(accesses[1].StepName).To(Equal("deploy"))
+			Expect(accesses[1].Var).To(Equal("prod-db-password"))
+			Expect(accesses[1].AccessedAt).ToNot(BeZero())
+		})
+
+		It("filters by var, pipeline and job", func() {
+			accesses, err := defaultTeam.SecretAccesses(db.SecretAccessFilter{Var: "prod-db-password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].BuildID).To(Equal(jobBuild.ID()))
+
+			accesses, err = defaultTeam.SecretAccesses(db.SecretAccessFilter{
+				PipelineName: defaultPipeline.Name(),
+				JobName:      defaultJob.Name(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].BuildID).To(Equal(jobBuild.ID()))
+		})
+
+		It("filters by time", func() {
+			_, err := dbConn.Exec("UPDATE secret_accesses SET accessed_at = now() - interval '40 days' WHERE build_id = $1", jobBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			accesses, err := defaultTeam.SecretAccesses(db.SecretAccessFilter{Since: time.Now().Add(-30 * 24 * time.Hour)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].BuildID).To(Equal(oneOffBuild.ID()))
+
+			accesses, err = defaultTeam.SecretAccesses(db.SecretAccessFilter{Until: time.Now().Add(-30 * 24 * time.Hour)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].BuildID).To(Equal(jobBuild.ID()))
+		})
+
+		It("limits the number of accesses returned", func() {
+			accesses, err := defaultTeam.SecretAccesses(db.SecretAccessFilter{Limit: 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].BuildID).To(Equal(oneOffBuild.ID()))
+		})
+	})
+
+	Describe("SecretAccessLifecycle", func() {
+		It("removes accesses older than the retention period", func() {
+			_, err := dbConn.Exec("UPDATE secret_accesses SET accessed_at = now() - interval '100 days' WHERE build_id = $1", jobBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			removed, err := db.NewSecretAccessLifecycle(dbConn).RemoveSecretAccessesOlderThan(90 * 24 * time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(1))
+
+			accesses, err := defaultTeam.SecretAccesses(db.SecretAccessFilter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accesses).To(HaveLen(1))
+			Expect(accesses[0].BuildID).To(Equal(oneOffBuild.ID()))
+		})
+	})
+})
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+//counterfeiter:generate . SecretLeaseLifecycle
+type SecretLeaseLifecycle interface {
+	OrphanedSecretLeases() ([]creds.Lease, error)
+	RemoveSecretLease(leaseID string) error
+	RemoveExpiredSecretLeases() (int, error)
+}
+
+type secretLeaseLifecycle struct {
+	conn Conn
+}
+
+func NewSecretLeaseLifecycle(conn Conn) SecretLeaseLifecycle {
+	return &secretLeaseLifecycle{conn}
+}
+
+// OrphanedSecretLeases returns the unexpired leases which are still held by
+// builds that have completed or been removed, e.g. because the ATC running
+// the build went away before it could revoke them.
+func (l secretLeaseLifecycle) OrphanedSecretLeases() ([]creds.Lease, error) {
+	rows, err := psql.Select("l.lease_id", "l.duration", "l.renewable", "l.expires_at").
+		From("build_secret_leases l").
+		LeftJoin("builds b ON b.id = l.build_id").
+		Where(sq.Or{
+			sq.Eq{"b.id": nil},
+			sq.Eq{"b.completed": true},
+		}).
+		Where(sq.Expr("l.expires_at > now()")).
+		OrderBy("l.lease_id").
+		RunWith(l.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSecretLeases(rows)
+}
+
+func (l secretLeaseLifecycle) RemoveSecretLease(leaseID string) error {
+	_, err := psql.Delete("build_secret_leases").
+		Where(sq.Eq{"lease_id": leaseID}).
+		RunWith(l.conn).
+		Exec()
+	return err
+}
+
+// RemoveExpiredSecretLeases forgets about leases which have expired, as the
+// secret manager has already revoked them.
+func (l secretLeaseLifecycle) RemoveExpiredSecretLeases() (int, error) {
+	res, err := psql.Delete("build_secret_leases").
+		Where(sq.Expr("expires_at <= now()")).
+		RunWith(l.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+func scanSecretLeases(rows *sql.Rows) ([]creds.Lease, error) {
+	defer Close(rows)
+
+	var leases []creds.Lease
+	for rows.Next() {
+		var lease creds.Lease
+		var duration int
+
+		err := rows.Scan(&lease.ID, &duration, &lease.Renewable, &lease.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		lease.Duration = time.Duration(duration) * time.Second
+		leases = append(leases, lease)
+	}
+
+	return leases, rows.Err()
+}
@@ -0,0 +1,101 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretLeaseLifecycle", func() {
+	var (
+		secretLeaseLifecycle db.SecretLeaseLifecycle
+		build                db.Build
+	)
+
+	BeforeEach(func() {
+		secretLeaseLifecycle = db.NewSecretLeaseLifecycle(dbConn)
+
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.TrackSecretLease(creds.Lease{
+			ID:        "some-lease",
+			Duration:  time.Hour,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.TrackSecretLease(creds.Lease{
+			ID:        "expired-lease",
+			Duration:  time.Hour,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("OrphanedSecretLeases", func() {
+		Context("when the build is running", func() {
+			It("returns nothing", func() {
+				leases, err := secretLeaseLifecycle.OrphanedSecretLeases()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(leases).To(BeEmpty())
+			})
+		})
+
+		Context("when the build has finished", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns its unexpired leases", func() {
+				leases, err := secretLeaseLifecycle.OrphanedSecretLeases()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(leases).To(HaveLen(1))
+				Expect(leases[0].ID).To(Equal("some-lease"))
+			})
+		})
+
+		Context("when the build has been removed", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns its unexpired leases", func() {
+				leases, err := secretLeaseLifecycle.OrphanedSecretLeases()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(leases).To(HaveLen(1))
+				Expect(leases[0].ID).To(Equal("some-lease"))
+			})
+		})
+	})
+
+	Describe("RemoveSecretLease", func() {
+		It("removes the lease", func() {
+			err := secretLeaseLifecycle.RemoveSecretLease("some-lease")
+			Expect(err).ToNot(HaveOccurred())
+
+			leases, err := build.SecretLeases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leases).To(BeEmpty())
+		})
+	})
+
+	Describe("RemoveExpiredSecretLeases", func() {
+		It("removes only the expired leases", func() {
+			removed, err := secretLeaseLifecycle.RemoveExpiredSecretLeases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(1))
+
+			leases, err := build.SecretLeases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leases).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"path"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// The outputs of a task run with cache_result enabled are retained as task
+// caches whose paths are namespaced under this prefix, so they can be told
+// apart from the caches configured by the task itself.
+const taskResultPathPrefix = "cache_result/"
+
+// TaskResultOutputPath returns the task cache path under which the volume
+// for the given output of a memoized task run is retained.
+func TaskResultOutputPath(cacheKey string, outputName string) string {
+	return taskResultPathPrefix + path.Join(cacheKey, outputName)
+}
+
+// TaskResult is the recorded outcome of a previous successful run of a task
+// with cache_result enabled.
+type TaskResult struct {
+	ExitStatus int
+
+	// Handles of the volumes retained for each output, keyed by output name.
+	OutputVolumes map[string]string
+}
+
+//counterfeiter:generate . TaskResultFactory
+type TaskResultFactory interface {
+	ArtifactDigest(volumeHandle string) (string, bool, error)
+
+	Find(jobID int, stepName string, cacheKey string, outputNames []string) (TaskResult, bool, error)
+	Save(jobID int, stepName string, cacheKey string, exitStatus int) error
+}
+
+type taskResultFactory struct {
+	conn Conn
+}
+
+func NewTaskResultFactory(conn Conn) TaskResultFactory {
+	return &taskResultFactory{
+		conn: conn,
+	}
+}
+
+// ArtifactDigest identifies the content of a volume by where it came from.
+// Only volumes whose content is known not to change are identified: resource
+// cache volumes and the retained outputs of memoized task runs.
+func (f *taskResultFactory) ArtifactDigest(volumeHandle string) (string, bool, error) {
+	var resourceCacheID sql.NullInt64
+	var taskCachePath sql.NullString
+	err := psql.Select("wrc.resource_cache_id", "tc.path").
+		From("volumes v").
+		LeftJoin("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		LeftJoin("worker_task_caches wtc ON wtc.id = v.worker_task_cache_id").
+		LeftJoin("task_caches tc ON tc.id = wtc.task_cache_id").
+		Where(sq.Eq{"v.handle": volumeHandle}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&resourceCacheID, &taskCachePath)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	if resourceCacheID.Valid {
+		return fmt.Sprintf("resource-cache:%d", resourceCacheID.Int64), true, nil
+	}
+
+	if taskCachePath.Valid && strings.HasPrefix(taskCachePath.String, taskResultPathPrefix) {
+		return "task-result:" + strings.TrimPrefix(taskCachePath.String, taskResultPathPrefix), true, nil
+	}
+
+	return "", false, nil
+}
+
+// Find looks up the result recorded for the cache key. The result is only
+// found if a volume is still available on a running worker for every output.
+func (f *taskResultFactory) Find(jobID int, stepName string, cacheKey string, outputNames []string) (TaskResult, bool, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return TaskResult{}, false, err
+	}
+
+	defer Rollback(tx)
+
+	var id, exitStatus int
+	err = psql.Select("id", "exit_status").
+		From("task_results").
+		Where(sq.Eq{
+			"job_id":    jobID,
+			"step_name": stepName,
+			"cache_key": cacheKey,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id, &exitStatus)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TaskResult{}, false, nil
+		}
+
+		return TaskResult{}, false, err
+	}
+
+	outputVolumes := map[string]string{}
+	for _, outputName := range outputNames {
+		var handle string
+		err = psql.Select("v.handle").
+			From("volumes v").
+			Join("worker_task_caches wtc ON wtc.id = v.worker_task_cache_id").
+			Join("task_caches tc ON tc.id = wtc.task_cache_id").
+			Join("workers w ON w.name = v.worker_name").
+			Where(sq.Eq{
+				"tc.job_id":    jobID,
+				"tc.step_name": stepName,
+				"tc.path":      TaskResultOutputPath(cacheKey, outputName),
+				"v.state":      VolumeStateCreated,
+				"w.state":      WorkerStateRunning,
+			}).
+			Limit(1).
+			RunWith(tx).
+			QueryRow().
+			Scan(&handle)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return TaskResult{}, false, nil
+			}
+
+			return TaskResult{}, false, err
+		}
+
+		outputVolumes[outputName] = handle
+	}
+
+	_, err = psql.Update("task_results").
+		Set("last_used", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return TaskResult{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return TaskResult{}, false, err
+	}
+
+	return TaskResult{
+		ExitStatus:    exitStatus,
+		OutputVolumes: outputVolumes,
+	}, true, nil
+}
+
+// Save records the result for the cache key. The output volumes must have
+// already been initialized as task caches using TaskResultOutputPath.
+func (f *taskResultFactory) Save(jobID int, stepName string, cacheKey string, exitStatus int) error {
+	_, err := psql.Insert("task_results").
+		Columns("job_id", "step_name", "cache_key", "exit_status").
+		Values(jobID, stepName, cacheKey, exitStatus).
+		Suffix(`
+			ON CONFLICT (job_id, step_name, cache_key) DO UPDATE SET
+				exit_status = EXCLUDED.exit_status,
+				last_used = now()
+		`).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,169 @@
+package db_test
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskResultFactory", func() {
+	var (
+		taskResultFactory db.TaskResultFactory
+		creatingContainer db.CreatingContainer
+	)
+
+	BeforeEach(func() {
+		taskResultFactory = db.NewTaskResultFactory(dbConn)
+
+		build, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingContainer, err = defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	createdVolume := func(mountPath string) db.CreatedVolume {
+		creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, mountPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		createdVolume, err := creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		return createdVolume
+	}
+
+	Describe("ArtifactDigest", func() {
+		Context("when the volume does not exist", func() {
+			It("returns not found", func() {
+				_, found, err := taskResultFactory.ArtifactDigest("bogus-handle")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the volume only belongs to a container", func() {
+			It("returns not found", func() {
+				volume := createdVolume("some-path")
+
+				_, found, err := taskResultFactory.ArtifactDigest(volume.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the volume is a resource cache", func() {
+			It("identifies the volume by the resource cache", func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+					db.ForBuild(build.ID()),
+					"some-base-resource-type",
+					atc.Version{"some": "version"},
+					atc.Source{"some": "source"},
+					atc.Params{"some": "params"},
+					atc.VersionedResourceTypes{},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				volume := createdVolume("some-path")
+				err = volume.InitializeResourceCache(resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+
+				digest, found, err := taskResultFactory.ArtifactDigest(volume.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(digest).To(Equal(fmt.Sprintf("resource-cache:%d", resourceCache.ID())))
+			})
+		})
+
+		Context("when the volume is retained for a task result", func() {
+			It("identifies the volume by the task result", func() {
+				volume := createdVolume("some-path")
+				err := volume.InitializeTaskCache(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-key", "some-output"))
+				Expect(err).ToNot(HaveOccurred())
+
+				digest, found, err := taskResultFactory.ArtifactDigest(volume.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(digest).To(Equal("task-result:some-key/some-output"))
+			})
+		})
+
+		Context("when the volume is a regular task cache", func() {
+			It("returns not found", func() {
+				volume := createdVolume("some-path")
+				err := volume.InitializeTaskCache(defaultJob.ID(), "some-step", "some-cache-path")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := taskResultFactory.ArtifactDigest(volume.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Find", func() {
+		Context("when no result has been saved", func() {
+			It("returns not found", func() {
+				_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key", []string{"some-output"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a result has been saved", func() {
+			var outputVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				outputVolume = createdVolume("some-output-path")
+				err := outputVolume.InitializeTaskCache(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-key", "some-output"))
+				Expect(err).ToNot(HaveOccurred())
+
+				err = taskResultFactory.Save(defaultJob.ID(), "some-step", "some-key", 0)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the result with the output volumes", func() {
+				result, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key", []string{"some-output"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(result).To(Equal(db.TaskResult{
+					ExitStatus:    0,
+					OutputVolumes: map[string]string{"some-output": outputVolume.Handle()},
+				}))
+			})
+
+			It("does not return the result for another key", func() {
+				_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-other-key", []string{"some-output"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when an output volume is missing", func() {
+				It("returns not found", func() {
+					_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key", []string{"some-output", "some-other-output"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+
+			Context("when the worker is not running", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec("UPDATE workers SET state = 'stalled' WHERE name = $1", defaultWorker.Name())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns not found", func() {
+					_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key", []string{"some-output"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+//counterfeiter:generate . TaskResultLifecycle
+type TaskResultLifecycle interface {
+	RemoveUnusedTaskResults(unusedFor time.Duration) (int, error)
+}
+
+type taskResultLifecycle struct {
+	conn Conn
+}
+
+func NewTaskResultLifecycle(conn Conn) TaskResultLifecycle {
+	return &taskResultLifecycle{conn}
+}
+
+// RemoveUnusedTaskResults removes the results that have not been saved or
+// reused within the given duration, along with the task caches retaining
+// their output volumes so that the volumes can be garbage-collected.
+func (l taskResultLifecycle) RemoveUnusedTaskResults(unusedFor time.Duration) (int, error) {
+	res, err := l.conn.Exec(fmt.Sprintf(`
+		WITH removed AS (
+			DELETE FROM task_results
+			WHERE last_used < now() - '%d seconds'::interval
+			RETURNING job_id, step_name, cache_key
+		), removed_caches AS (
+			DELETE FROM task_caches tc
+			USING removed r
+			WHERE tc.job_id = r.job_id
+			AND tc.step_name = r.step_name
+			AND tc.path LIKE $1 || r.cache_key || '/%%'
+		)
+		SELECT 1 FROM removed
+	`, int(unusedFor.Seconds())), taskResultPathPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
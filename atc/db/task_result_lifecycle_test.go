@@ -0,0 +1,71 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskResultLifecycle", func() {
+	var (
+		taskResultFactory   db.TaskResultFactory
+		taskResultLifecycle db.TaskResultLifecycle
+	)
+
+	BeforeEach(func() {
+		taskResultFactory = db.NewTaskResultFactory(dbConn)
+		taskResultLifecycle = db.NewTaskResultLifecycle(dbConn)
+
+		_, err := taskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-key", "some-output"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = taskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", "some-cache-path")
+		Expect(err).ToNot(HaveOccurred())
+
+		err = taskResultFactory.Save(defaultJob.ID(), "some-step", "some-key", 0)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("RemoveUnusedTaskResults", func() {
+		Context("when the result has been used recently", func() {
+			It("keeps the result", func() {
+				removed, err := taskResultLifecycle.RemoveUnusedTaskResults(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(removed).To(Equal(0))
+
+				_, found, err := taskCacheFactory.Find(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-key", "some-output"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the result has not been used for a while", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec("UPDATE task_results SET last_used = now() - interval '2 hours'")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes the result and the task caches for its outputs", func() {
+				removed, err := taskResultLifecycle.RemoveUnusedTaskResults(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(removed).To(Equal(1))
+
+				_, found, err := taskCacheFactory.Find(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-key", "some-output"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("leaves other task caches alone", func() {
+				_, err := taskResultLifecycle.RemoveUnusedTaskResults(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := taskCacheFactory.Find(defaultJob.ID(), "some-step", "some-cache-path")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+})
@@ -34,6 +34,8 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	PipelineAuth() atc.PipelineAuth
+	Notifications() atc.NotificationRules
 
 	Delete() error
 	Rename(string) error
@@ -58,6 +60,10 @@ type Team interface {
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch) ([]BuildLogMatch, error)
+
+	SealingKey() (string, bool, error)
+	SaveSealingKey(string) (string, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -76,6 +82,11 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdatePipelineAuth(pipelineName string, auth atc.TeamAuth) error
+	UpdateNotifications(rules atc.NotificationRules) error
+
+	NotificationDeliveries(limit int, failedOnly bool) ([]NotificationDelivery, error)
+	SecretAccesses(SecretAccessFilter) ([]SecretAccess, error)
 }
 
 type team struct {
@@ -86,7 +97,9 @@ type team struct {
 	name  string
 	admin bool
 
-	auth atc.TeamAuth
+	auth          atc.TeamAuth
+	pipelineAuth  atc.PipelineAuth
+	notifications atc.NotificationRules
 }
 
 func (t *team) ID() int      { return t.id }
@@ -95,6 +108,10 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) PipelineAuth() atc.PipelineAuth { return t.pipelineAuth }
+
+func (t *team) Notifications() atc.NotificationRules { return t.notifications }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -654,13 +671,19 @@ func (t *team) SavePipeline(
 }
 
 func (t *team) RenamePipeline(oldName, newName string) (bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer Rollback(tx)
+
 	result, err := psql.Update("pipelines").
 		Set("name", newName).
 		Where(sq.Eq{
 			"team_id": t.id,
 			"name":    oldName,
 		}).
-		RunWith(t.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return false, err
@@ -669,7 +692,28 @@ func (t *team) RenamePipeline(oldName, newName string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return rowsAffected > 0, nil
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	// role bindings are keyed by pipeline name, so they follow the rename
+	_, err = tx.Exec(`
+		UPDATE teams
+		SET pipeline_auth = ((pipeline_auth::jsonb - $1) || jsonb_build_object($2::text, pipeline_auth::jsonb -> $1))::text
+		WHERE id = $3
+		AND jsonb_exists(pipeline_auth::jsonb, $1)
+	`, oldName, newName, t.id)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (t *team) Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error) {
@@ -966,7 +1010,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, notifications, pipeline_auth
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -976,6 +1020,96 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+// UpdatePipelineAuth replaces the role bindings for the pipelines with the
+// given name. Empty auth removes them.
+func (t *team) UpdatePipelineAuth(pipelineName string, auth atc.TeamAuth) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	var current sql.NullString
+	err = psql.Select("pipeline_auth").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&current)
+	if err != nil {
+		return err
+	}
+
+	pipelineAuth := atc.PipelineAuth{}
+	if current.Valid {
+		err = json.Unmarshal([]byte(current.String), &pipelineAuth)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(auth) == 0 {
+		delete(pipelineAuth, pipelineName)
+	} else {
+		pipelineAuth[pipelineName] = auth
+	}
+
+	var encoded sql.NullString
+	if len(pipelineAuth) > 0 {
+		payload, err := json.Marshal(pipelineAuth)
+		if err != nil {
+			return err
+		}
+
+		encoded = sql.NullString{String: string(payload), Valid: true}
+	}
+
+	query := `
+		UPDATE teams
+		SET pipeline_auth = $1
+		WHERE id = $2
+		RETURNING id, name, admin, auth, nonce, notifications, pipeline_auth
+	`
+	err = t.queryTeam(tx, query, encoded, t.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (t *team) UpdateNotifications(rules atc.NotificationRules) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	var notifications sql.NullString
+	if len(rules) > 0 {
+		jsonEncodedRules, err := json.Marshal(rules)
+		if err != nil {
+			return err
+		}
+
+		notifications = sql.NullString{String: string(jsonEncodedRules), Valid: true}
+	}
+
+	query := `
+		UPDATE teams
+		SET notifications = $1
+		WHERE id = $2
+		RETURNING id, name, admin, auth, nonce, notifications, pipeline_auth
+	`
+	err = t.queryTeam(tx, query, notifications, t.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
@@ -998,7 +1132,15 @@ func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.Pipeline
 		return nil, nil, err
 	}
 
-	variables, err := pipeline.Variables(logger, secretManager, varSourcePool)
+	secrets := creds.NewTransientSecrets(secretManager)
+	defer func() {
+		err := secrets.RevokeLeases()
+		if err != nil {
+			logger.Error("failed-to-revoke-secret-leases", err)
+		}
+	}()
+
+	variables, err := pipeline.Variables(logger, secrets, varSourcePool)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1530,7 +1672,7 @@ func scanContainers(rows *sql.Rows, conn Conn, initContainers []Container) ([]Co
 }
 
 func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, notifications, pipelineAuth sql.NullString
 
 	err := tx.QueryRow(query, params...).Scan(
 		&t.id,
@@ -1538,6 +1680,8 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&notifications,
+		&pipelineAuth,
 	)
 	if err != nil {
 		return err
@@ -1552,6 +1696,22 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		t.auth = auth
 	}
 
+	t.notifications = nil
+	if notifications.Valid {
+		err = json.Unmarshal([]byte(notifications.String), &t.notifications)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.pipelineAuth = nil
+	if pipelineAuth.Valid {
+		err = json.Unmarshal([]byte(pipelineAuth.String), &t.pipelineAuth)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1708,43 +1868,42 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 }
 
 func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
-	if len(step.Passed) != 0 {
-		for _, passedJob := range step.Passed {
-			var version sql.NullString
-			if step.Version != nil {
-				versionJSON, err := step.Version.MarshalJSON()
-				if err != nil {
-					return err
-				}
-
-				version = sql.NullString{Valid: true, String: string(versionJSON)}
-			}
-
-			_, err := psql.Insert("job_inputs").
-				Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version").
-				Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], step.Trigger, version).
-				RunWith(tx).
-				Exec()
-			if err != nil {
-				return err
-			}
+	var version sql.NullString
+	if step.Version != nil {
+		versionJSON, err := step.Version.MarshalJSON()
+		if err != nil {
+			return err
 		}
-	} else {
-		var version sql.NullString
-		if step.Version != nil {
-			versionJSON, err := step.Version.MarshalJSON()
-			if err != nil {
-				return err
-			}
 
-			version = sql.NullString{Valid: true, String: string(versionJSON)}
-		}
+		version = sql.NullString{Valid: true, String: string(versionJSON)}
+	}
 
+	if len(step.Passed) == 0 && len(step.PassedAny) == 0 {
 		_, err := psql.Insert("job_inputs").
 			Columns("name", "job_id", "resource_id", "trigger", "version").
 			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], step.Trigger, version).
 			RunWith(tx).
 			Exec()
+		return err
+	}
+
+	for _, passedJob := range step.Passed {
+		_, err := psql.Insert("job_inputs").
+			Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version").
+			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], step.Trigger, version).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, passedJob := range step.PassedAny {
+		_, err := psql.Insert("job_inputs").
+			Columns("name", "job_id", "resource_id", "passed_job_id", "passed_any", "trigger", "version").
+			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], true, step.Trigger, version).
+			RunWith(tx).
+			Exec()
 		if err != nil {
 			return err
 		}
@@ -51,10 +51,20 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var notifications sql.NullString
+	if len(t.Notifications) > 0 {
+		jsonEncodedRules, err := json.Marshal(t.Notifications)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = sql.NullString{String: string(jsonEncodedRules), Valid: true}
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, notifications").
+		Values(t.Name, auth, admin, notifications).
+		Suffix("RETURNING id, name, admin, auth, notifications, pipeline_auth").
 		RunWith(tx).
 		QueryRow()
 
@@ -90,7 +100,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, notifications, pipeline_auth").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -109,7 +119,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, notifications, pipeline_auth").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -175,13 +185,15 @@ func (factory *teamFactory) NotifyCacher() error {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, notifications, pipelineAuth sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&notifications,
+		&pipelineAuth,
 	)
 
 	if providerAuth.Valid {
@@ -191,5 +203,19 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if notifications.Valid {
+		err = json.Unmarshal([]byte(notifications.String), &t.notifications)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelineAuth.Valid {
+		err = json.Unmarshal([]byte(pipelineAuth.String), &t.pipelineAuth)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
@@ -18,6 +18,9 @@ var _ = Describe("Team Factory", func() {
 			Auth: atc.TeamAuth{
 				"owner": {"users": []string{"local:username"}},
 			},
+			Notifications: atc.NotificationRules{
+				{URL: "https://example.com/hook"},
+			},
 		}
 	})
 
@@ -32,11 +35,13 @@ var _ = Describe("Team Factory", func() {
 		It("creates the correct team", func() {
 			Expect(team.Name()).To(Equal(atcTeam.Name))
 			Expect(team.Auth()).To(Equal(atcTeam.Auth))
+			Expect(team.Notifications()).To(Equal(atcTeam.Notifications))
 
 			t, found, err := teamFactory.FindTeam(atcTeam.Name)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(t.ID()).To(Equal(team.ID()))
+			Expect(t.Notifications()).To(Equal(atcTeam.Notifications))
 		})
 	})
 
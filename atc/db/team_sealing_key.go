@@ -0,0 +1,74 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// teamSealingKeys stores the age identity which values sealed for a team are
+// encrypted to, encrypted at rest like the rest of the team's secrets.
+type teamSealingKeys struct {
+	conn   Conn
+	teamID int
+}
+
+func (t *team) SealingKey() (string, bool, error) {
+	return teamSealingKeys{t.conn, t.id}.SealingKey()
+}
+
+func (t *team) SaveSealingKey(identity string) (string, error) {
+	return teamSealingKeys{t.conn, t.id}.SaveSealingKey(identity)
+}
+
+func (keys teamSealingKeys) SealingKey() (string, bool, error) {
+	var identity string
+	var nonce sql.NullString
+
+	err := psql.Select("identity", "nonce").
+		From("team_sealing_keys").
+		Where(sq.Eq{"team_id": keys.teamID}).
+		RunWith(keys.conn).
+		QueryRow().
+		Scan(&identity, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := keys.conn.EncryptionStrategy().Decrypt(identity, noncense)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(decrypted), true, nil
+}
+
+func (keys teamSealingKeys) SaveSealingKey(identity string) (string, error) {
+	encrypted, nonce, err := keys.conn.EncryptionStrategy().Encrypt([]byte(identity))
+	if err != nil {
+		return "", err
+	}
+
+	// if another ATC got there first, theirs wins
+	_, err = psql.Insert("team_sealing_keys").
+		Columns("team_id", "identity", "nonce").
+		Values(keys.teamID, encrypted, nonce).
+		Suffix("ON CONFLICT (team_id) DO NOTHING").
+		RunWith(keys.conn).
+		Exec()
+	if err != nil {
+		return "", err
+	}
+
+	saved, _, err := keys.SealingKey()
+	return saved, err
+}
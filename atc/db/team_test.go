@@ -970,6 +970,294 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("UpdateNotifications", func() {
+		var rules atc.NotificationRules
+
+		BeforeEach(func() {
+			rules = atc.NotificationRules{
+				{URL: "https://example.com/hook", Events: []atc.BuildStatus{atc.StatusFailed}},
+			}
+		})
+
+		It("saves the notification rules to the existing team", func() {
+			err := team.UpdateNotifications(rules)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(team.Notifications()).To(Equal(rules))
+
+			reloadedTeam, found, err := teamFactory.FindTeam(team.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloadedTeam.Notifications()).To(Equal(rules))
+		})
+
+		It("does not modify the team's auth", func() {
+			err := team.UpdateProviderAuth(atc.TeamAuth{"owner": {"users": []string{"local:username"}}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.UpdateNotifications(rules)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(team.Auth()).To(Equal(atc.TeamAuth{"owner": {"users": []string{"local:username"}}}))
+		})
+
+		Context("when the rules are cleared", func() {
+			BeforeEach(func() {
+				err := team.UpdateNotifications(rules)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes the notification rules", func() {
+				err := team.UpdateNotifications(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Notifications()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("UpdatePipelineAuth", func() {
+		var memberAuth, viewerAuth atc.TeamAuth
+
+		BeforeEach(func() {
+			memberAuth = atc.TeamAuth{"member": {"users": []string{"local:contractor"}}}
+			viewerAuth = atc.TeamAuth{"viewer": {"groups": []string{"github:org:auditors"}}}
+		})
+
+		It("binds roles for the pipeline without touching other pipelines", func() {
+			err := team.UpdatePipelineAuth("some-pipeline", memberAuth)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.UpdatePipelineAuth("other-pipeline", viewerAuth)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected := atc.PipelineAuth{
+				"some-pipeline":  memberAuth,
+				"other-pipeline": viewerAuth,
+			}
+			Expect(team.PipelineAuth()).To(Equal(expected))
+
+			reloadedTeam, found, err := teamFactory.FindTeam(team.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloadedTeam.PipelineAuth()).To(Equal(expected))
+		})
+
+		It("does not modify the team's auth", func() {
+			err := team.UpdateProviderAuth(atc.TeamAuth{"owner": {"users": []string{"local:username"}}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.UpdatePipelineAuth("some-pipeline", memberAuth)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(team.Auth()).To(Equal(atc.TeamAuth{"owner": {"users": []string{"local:username"}}}))
+		})
+
+		Context("when the auth is cleared", func() {
+			BeforeEach(func() {
+				err := team.UpdatePipelineAuth("some-pipeline", memberAuth)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes the pipeline's bindings", func() {
+				err := team.UpdatePipelineAuth("some-pipeline", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.PipelineAuth()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("NotificationDeliveries", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveNotificationDelivery(db.NotificationDelivery{
+				URL:     "https://example.com/hook",
+				Event:   atc.StatusFailed,
+				Attempt: 1,
+				Error:   "connection refused",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveNotificationDelivery(db.NotificationDelivery{
+				URL:        "https://example.com/hook",
+				Event:      atc.StatusFailed,
+				Attempt:    2,
+				StatusCode: 200,
+				Succeeded:  true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherBuild, err := otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = otherBuild.SaveNotificationDelivery(db.NotificationDelivery{
+				URL:     "https://example.com/other-hook",
+				Event:   atc.StatusStarted,
+				Attempt: 1,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the team's delivery attempts, newest first", func() {
+			deliveries, err := team.NotificationDeliveries(0, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(2))
+
+			Expect(deliveries[0].BuildID).To(Equal(build.ID()))
+			Expect(deliveries[0].BuildName).To(Equal(build.Name()))
+			Expect(deliveries[0].TeamName).To(Equal(team.Name()))
+			Expect(deliveries[0].URL).To(Equal("https://example.com/hook"))
+			Expect(deliveries[0].Event).To(Equal(atc.StatusFailed))
+			Expect(deliveries[0].Attempt).To(Equal(2))
+			Expect(deliveries[0].StatusCode).To(Equal(200))
+			Expect(deliveries[0].Succeeded).To(BeTrue())
+			Expect(deliveries[0].AttemptedAt).ToNot(BeZero())
+
+			Expect(deliveries[1].Attempt).To(Equal(1))
+			Expect(deliveries[1].StatusCode).To(BeZero())
+			Expect(deliveries[1].Error).To(Equal("connection refused"))
+			Expect(deliveries[1].Succeeded).To(BeFalse())
+		})
+
+		It("limits the number of attempts returned", func() {
+			deliveries, err := team.NotificationDeliveries(1, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].Attempt).To(Equal(2))
+		})
+
+		It("can return only failed attempts", func() {
+			deliveries, err := team.NotificationDeliveries(0, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].Attempt).To(Equal(1))
+		})
+	})
+
+	Describe("SearchBuildLogs", func() {
+		var (
+			jobBuild    db.Build
+			oneOffBuild db.Build
+		)
+
+		saveLog := func(build db.Build, origin string, payload string) {
+			err := build.SaveEvent(event.Log{
+				Time:    1623938117,
+				Origin:  event.Origin{ID: event.OriginID(origin)},
+				Payload: payload,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			jobBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			saveLog(jobBuild, "some-task", "compiling...\nerror: Connection refused\n")
+			saveLog(jobBuild, "some-task", "connection established\n")
+
+			err = jobBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			saveLog(oneOffBuild, "other-task", "dial tcp: connection refused\n")
+			saveLog(oneOffBuild, "other-task", "refused the connection\n")
+
+			otherBuild, err := otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			saveLog(otherBuild, "other-team-task", "connection refused\n")
+		})
+
+		It("finds the log events of the team's builds containing the query, newest build first", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{Query: "connection refused"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(2))
+
+			Expect(matches[0].BuildID).To(Equal(oneOffBuild.ID()))
+			Expect(matches[0].OriginID).To(Equal("other-task"))
+			Expect(matches[0].Line).To(Equal("dial tcp: connection refused"))
+			Expect(matches[0].Time).To(Equal(time.Unix(1623938117, 0)))
+
+			Expect(matches[1].BuildID).To(Equal(jobBuild.ID()))
+			Expect(matches[1].BuildName).To(Equal(jobBuild.Name()))
+			Expect(matches[1].BuildStatus).To(Equal(db.BuildStatusFailed))
+			Expect(matches[1].JobName).To(Equal(defaultJob.Name()))
+			Expect(matches[1].PipelineName).To(Equal(defaultPipelineRef.Name))
+			Expect(matches[1].PipelineInstanceVars).To(Equal(defaultPipelineRef.InstanceVars))
+			Expect(matches[1].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(matches[1].OriginID).To(Equal("some-task"))
+			Expect(matches[1].Line).To(Equal("error: Connection refused"))
+		})
+
+		It("can return the oldest build first", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{Query: "connection refused", OldestFirst: true, Limit: 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].BuildID).To(Equal(jobBuild.ID()))
+		})
+
+		It("can be filtered by pipeline and job", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query:      "connection refused",
+				PipelineID: defaultPipeline.ID(),
+				JobName:    defaultJob.Name(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].BuildID).To(Equal(jobBuild.ID()))
+		})
+
+		It("can be filtered by status", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query:    "connection refused",
+				Statuses: []db.BuildStatus{db.BuildStatusStarted, db.BuildStatusPending},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].BuildID).To(Equal(oneOffBuild.ID()))
+		})
+
+		It("can be filtered by start time", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "connection refused",
+				Since: time.Now().Add(time.Hour),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("treats the query literally", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{Query: "connection%refused"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("ignores ANSI escape sequences", func() {
+			saveLog(oneOffBuild, "other-task", "\x1b[31merror\x1b[0m: permission denied\n")
+
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{Query: "error: permission"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Line).To(Equal("error: permission denied"))
+		})
+
+		It("only matches whole words", func() {
+			matches, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{Query: "refus"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+	})
+
 	Describe("Pipelines", func() {
 		var (
 			pipelines []db.Pipeline
@@ -3602,6 +3890,28 @@ var _ = Describe("Team", func() {
 			})
 		})
 
+		Context("when the pipeline has role bindings", func() {
+			var memberAuth atc.TeamAuth
+
+			BeforeEach(func() {
+				memberAuth = atc.TeamAuth{"member": {"users": []string{"local:contractor"}}}
+
+				err := defaultTeam.UpdatePipelineAuth("default-pipeline", memberAuth)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("moves them to the new name", func() {
+				found, err := defaultTeam.RenamePipeline("default-pipeline", "new-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				reloadedTeam, found, err := teamFactory.FindTeam(defaultTeam.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloadedTeam.PipelineAuth()).To(Equal(atc.PipelineAuth{"new-pipeline": memberAuth}))
+			})
+		})
+
 		Context("when there are no pipelines with the old name", func() {
 			It("returns not found", func() {
 				found, err := defaultTeam.RenamePipeline("blah-blah-blah", "new-pipeline")
@@ -3989,4 +4299,36 @@ var _ = Describe("Team", func() {
 			})
 		})
 	})
+
+	Describe("SealingKey", func() {
+		It("is not found until one is saved", func() {
+			_, found, err := team.SealingKey()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("keeps the first key saved", func() {
+			saved, err := team.SaveSealingKey("some-identity")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal("some-identity"))
+
+			saved, err = team.SaveSealingKey("other-identity")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal("some-identity"))
+
+			identity, found, err := team.SealingKey()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(identity).To(Equal("some-identity"))
+		})
+
+		It("does not share keys between teams", func() {
+			_, err := team.SaveSealingKey("some-identity")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := otherTeam.SealingKey()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+func NewApprovalDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+	policyChecker policy.Checker,
+) *approvalDelegate {
+	return &approvalDelegate{
+		buildStepDelegate{
+			build:         build,
+			planID:        planID,
+			clock:         clock,
+			state:         state,
+			stdout:        nil,
+			stderr:        nil,
+			policyChecker: policyChecker,
+		},
+	}
+}
+
+type approvalDelegate struct {
+	buildStepDelegate
+}
+
+// WaitForApproval blocks until the approval step has been decided or the
+// context is canceled. A waiting-for-approval event is only emitted if the
+// step has not already been decided, e.g. prior to an ATC restart.
+func (delegate *approvalDelegate) WaitForApproval(ctx context.Context, logger lager.Logger, plan atc.ApprovalPlan) (db.BuildApproval, error) {
+	notifier, err := delegate.build.ApprovalNotifier(plan.Name)
+	if err != nil {
+		return db.BuildApproval{}, err
+	}
+
+	defer notifier.Close()
+
+	waiting := false
+	for {
+		approval, found, err := delegate.build.Approval(plan.Name)
+		if err != nil {
+			return db.BuildApproval{}, err
+		}
+
+		if found {
+			return approval, nil
+		}
+
+		if !waiting {
+			delegate.waitingForApproval(logger, plan)
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return db.BuildApproval{}, ctx.Err()
+		case <-notifier.Notify():
+		}
+	}
+}
+
+func (delegate *approvalDelegate) ApprovalDecided(logger lager.Logger, approval db.BuildApproval) {
+	err := delegate.build.SaveEvent(event.ApprovalDecided{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Approved: approval.Approved,
+		Approver: approval.Approver,
+		Comment:  approval.Comment,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-approval-decided-event", err)
+		return
+	}
+
+	logger.Info("approval-decided", lager.Data{
+		"approved": approval.Approved,
+		"approver": approval.Approver,
+	})
+}
+
+func (delegate *approvalDelegate) waitingForApproval(logger lager.Logger, plan atc.ApprovalPlan) {
+	err := delegate.build.SaveEvent(event.WaitingForApproval{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Instructions: plan.Instructions,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-approval-event", err)
+		return
+	}
+
+	logger.Info("waiting-for-approval")
+}
@@ -0,0 +1,206 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("ApprovalDelegate", func() {
+	var (
+		logger            *lagertest.TestLogger
+		fakeBuild         *dbfakes.FakeBuild
+		fakeClock         *fakeclock.FakeClock
+		fakePolicyChecker *policyfakes.FakeChecker
+		fakeNotifier      *dbfakes.FakeNotifier
+		notify            chan struct{}
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.ApprovalDelegate
+
+		plan = atc.ApprovalPlan{
+			Name:         "deploy",
+			Instructions: "check the canary first",
+		}
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+		fakeBuild.ApprovalNotifierReturns(fakeNotifier, nil)
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+		delegate = engine.NewApprovalDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+	})
+
+	Describe("WaitForApproval", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+
+			approval db.BuildApproval
+			waitErr  error
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		JustBeforeEach(func() {
+			approval, waitErr = delegate.WaitForApproval(ctx, logger, plan)
+		})
+
+		Context("when the step has already been decided", func() {
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturns(db.BuildApproval{
+					Name:     "deploy",
+					Approved: true,
+					Approver: "some-user",
+				}, true, nil)
+			})
+
+			It("returns the decision", func() {
+				Expect(waitErr).ToNot(HaveOccurred())
+				Expect(approval).To(Equal(db.BuildApproval{
+					Name:     "deploy",
+					Approved: true,
+					Approver: "some-user",
+				}))
+			})
+
+			It("looks up the approval by step name", func() {
+				Expect(fakeBuild.ApprovalNotifierArgsForCall(0)).To(Equal("deploy"))
+				Expect(fakeBuild.ApprovalArgsForCall(0)).To(Equal("deploy"))
+			})
+
+			It("does not emit a waiting event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+			})
+
+			It("closes the notifier", func() {
+				Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the step is decided while waiting", func() {
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturnsOnCall(0, db.BuildApproval{}, false, nil)
+				fakeBuild.ApprovalReturnsOnCall(1, db.BuildApproval{}, false, nil)
+				fakeBuild.ApprovalReturnsOnCall(2, db.BuildApproval{
+					Name:     "deploy",
+					Approved: false,
+					Approver: "some-user",
+				}, true, nil)
+
+				notify <- struct{}{}
+				go func() {
+					defer GinkgoRecover()
+					Eventually(fakeBuild.ApprovalCallCount).Should(Equal(2))
+					notify <- struct{}{}
+				}()
+			})
+
+			It("returns the decision", func() {
+				Expect(waitErr).ToNot(HaveOccurred())
+				Expect(approval.Approved).To(BeFalse())
+				Expect(approval.Approver).To(Equal("some-user"))
+			})
+
+			It("emits a single waiting event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitingForApproval{
+					Time:         now.Unix(),
+					Origin:       event.Origin{ID: event.OriginID("some-plan-id")},
+					Instructions: "check the canary first",
+				}))
+			})
+		})
+
+		Context("when the context is canceled", func() {
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturns(db.BuildApproval{}, false, nil)
+				cancel()
+			})
+
+			It("returns the context error", func() {
+				Expect(waitErr).To(Equal(context.Canceled))
+			})
+		})
+
+		Context("when looking up the approval fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturns(db.BuildApproval{}, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(waitErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when creating the notifier fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.ApprovalNotifierReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(waitErr).To(Equal(disaster))
+				Expect(fakeBuild.ApprovalCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("ApprovalDecided", func() {
+		JustBeforeEach(func() {
+			delegate.ApprovalDecided(logger, db.BuildApproval{
+				Name:     "deploy",
+				Approved: true,
+				Approver: "some-user",
+				Comment:  "lgtm",
+			})
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ApprovalDecided{
+				Time:     now.Unix(),
+				Origin:   event.Origin{ID: event.OriginID("some-plan-id")},
+				Approved: true,
+				Approver: "some-user",
+				Comment:  "lgtm",
+			}))
+		})
+	})
+})
@@ -421,6 +421,29 @@ func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, pri
 	})
 }
 
+// CheckStepPolicy checks a step's configuration, as it will be run, against
+// the policy agent. Any interpolated credentials are redacted from the data
+// before it is sent.
+func (delegate *buildStepDelegate) CheckStepPolicy(action string, data map[string]interface{}) error {
+	if !delegate.policyChecker.ShouldCheckAction(action) {
+		return nil
+	}
+
+	redactedData, err := delegate.redactPolicyData(data)
+	if err != nil {
+		return fmt.Errorf("redact data: %w", err)
+	}
+
+	redactedData["job"] = delegate.build.JobName()
+
+	return delegate.checkPolicy(policy.PolicyCheckInput{
+		Action:   action,
+		Team:     delegate.build.TeamName(),
+		Pipeline: delegate.build.PipelineName(),
+		Data:     redactedData,
+	})
+}
+
 func (delegate *buildStepDelegate) checkPolicy(input policy.PolicyCheckInput) error {
 	result, err := delegate.policyChecker.Check(input)
 	if err != nil {
@@ -461,3 +484,17 @@ func (delegate *buildStepDelegate) redactImageSource(source atc.Source) (atc.Sou
 	}
 	return newSource, nil
 }
+
+func (delegate *buildStepDelegate) redactPolicyData(data map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	s := delegate.buildOutputFilter(string(b))
+	newData := map[string]interface{}{}
+	err = json.Unmarshal([]byte(s), &newData)
+	if err != nil {
+		return nil, err
+	}
+	return newData, nil
+}
@@ -555,6 +555,111 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("CheckStepPolicy", func() {
+		var (
+			data     map[string]interface{}
+			checkErr error
+		)
+
+		BeforeEach(func() {
+			fakeBuild.TeamNameReturns("some-team")
+			fakeBuild.PipelineNameReturns("some-pipeline")
+			fakeBuild.JobNameReturns("some-job")
+
+			data = map[string]interface{}{
+				"type":       "git",
+				"source":     atc.Source{"private_key": "super-secret-source"},
+				"privileged": true,
+			}
+		})
+
+		JustBeforeEach(func() {
+			checkErr = delegate.CheckStepPolicy(policy.ActionRunGetStep, data)
+		})
+
+		Context("when the action does not need to be checked", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(false)
+			})
+
+			It("succeeds without checking", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.ShouldCheckActionArgsForCall(0)).To(Equal(policy.ActionRunGetStep))
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the action needs to be checked", func() {
+			var fakeCheckResult *policyfakes.FakePolicyCheckResult
+
+			BeforeEach(func() {
+				fakeCheckResult = new(policyfakes.FakePolicyCheckResult)
+				fakeCheckResult.AllowedReturns(true)
+				fakePolicyChecker.CheckReturns(fakeCheckResult, nil)
+				fakePolicyChecker.ShouldCheckActionReturns(true)
+
+				runState.IterateInterpolatedCredsStub = func(iter vars.TrackedVarsIterator) {
+					iter.YieldCred("source-var", "super-secret-source")
+				}
+			})
+
+			It("checks the data with credentials redacted", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+					Action:   policy.ActionRunGetStep,
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Data: map[string]interface{}{
+						"type":       "git",
+						"source":     map[string]interface{}{"private_key": "((redacted))"},
+						"privileged": true,
+						"job":        "some-job",
+					},
+				}))
+			})
+
+			Context("when the check fails", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(checkErr).To(MatchError("policy check: some-error"))
+				})
+			})
+
+			Context("when the step is not allowed", func() {
+				BeforeEach(func() {
+					fakeCheckResult.AllowedReturns(false)
+					fakeCheckResult.MessagesReturns([]string{"reasonA", "reasonB"})
+				})
+
+				Context("when it should block", func() {
+					BeforeEach(func() {
+						fakeCheckResult.ShouldBlockReturns(true)
+					})
+
+					It("returns the reasons", func() {
+						Expect(checkErr).To(Equal(policy.PolicyCheckNotPass{
+							Messages: []string{"reasonA", "reasonB"},
+						}))
+					})
+				})
+
+				Context("when it should not block", func() {
+					BeforeEach(func() {
+						fakeCheckResult.ShouldBlockReturns(false)
+					})
+
+					It("succeeds", func() {
+						Expect(checkErr).ToNot(HaveOccurred())
+					})
+				})
+			})
+		})
+	})
+
 	Describe("ConstructAcrossSubsteps", func() {
 		It("constructs the across substeps and emits them as a build event", func() {
 			template := []byte(`{
@@ -25,6 +25,7 @@ type CoreStepFactory interface {
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	ApprovalStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
@@ -103,6 +104,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildTimeoutStep(build, plan)
 	}
 
+	if plan.If != nil {
+		return factory.buildIfStep(build, plan)
+	}
+
 	if plan.Try != nil {
 		return factory.buildTryStep(build, plan)
 	}
@@ -132,15 +137,19 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 	}
 
 	if plan.Task != nil {
-		return factory.buildTaskStep(build, plan)
+		return exec.RecordStepStatus(factory.buildTaskStep(build, plan), plan.Task.Name)
 	}
 
 	if plan.SetPipeline != nil {
-		return factory.buildSetPipelineStep(build, plan)
+		return exec.RecordStepStatus(factory.buildSetPipelineStep(build, plan), plan.SetPipeline.Name)
 	}
 
 	if plan.LoadVar != nil {
-		return factory.buildLoadVarStep(build, plan)
+		return exec.RecordStepStatus(factory.buildLoadVarStep(build, plan), plan.LoadVar.Name)
+	}
+
+	if plan.Approval != nil {
+		return exec.RecordStepStatus(factory.buildApprovalStep(build, plan), plan.Approval.Name)
 	}
 
 	if plan.Check != nil {
@@ -148,11 +157,11 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 	}
 
 	if plan.Get != nil {
-		return factory.buildGetStep(build, plan)
+		return exec.RecordStepStatus(factory.buildGetStep(build, plan), plan.Get.Name)
 	}
 
 	if plan.Put != nil {
-		return factory.buildPutStep(build, plan)
+		return exec.RecordStepStatus(factory.buildPutStep(build, plan), plan.Put.Name)
 	}
 
 	if plan.Retry != nil {
@@ -219,6 +228,21 @@ func (factory *stepperFactory) buildTimeoutStep(build db.Build, plan atc.Plan) e
 	return exec.Timeout(step, plan.Timeout.Duration)
 }
 
+func (factory *stepperFactory) buildIfStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.If.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		true,
+	)
+
+	// the skipped event is attributed to the nested step
+	return exec.If(step, *plan.If, stepMetadata, factory.buildDelegateFactory(build, innerPlan))
+}
+
 func (factory *stepperFactory) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
@@ -422,6 +446,21 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 	)
 }
 
+func (factory *stepperFactory) buildApprovalStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.ApprovalStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
 func (factory *stepperFactory) buildArtifactInputStep(build db.Build, plan atc.Plan) exec.Step {
 	return factory.coreFactory.ArtifactInputStep(
 		plan,
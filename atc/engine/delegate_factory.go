@@ -48,3 +48,11 @@ func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.Buil
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
 	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
 }
+
+func (delegate DelegateFactory) ApprovalDelegate(state exec.RunState) exec.ApprovalDelegate {
+	return NewApprovalDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+}
+
+func (delegate DelegateFactory) IfDelegate(state exec.RunState) exec.IfDelegate {
+	return NewIfDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+}
@@ -15,12 +15,18 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
+// SecretLeaseRenewalInterval is how often the leases held by running builds
+// on dynamic secrets are checked, renewing those which are past half of their
+// duration.
+var SecretLeaseRenewalInterval = time.Minute
+
 //counterfeiter:generate . Engine
 type Engine interface {
 	NewBuild(db.Build) Runnable
@@ -36,7 +42,9 @@ type Runnable interface {
 func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
+	secretsBackend string,
 	varSourcePool creds.VarSourcePool,
+	notifier notifications.Notifier,
 ) Engine {
 	return &engine{
 		stepperFactory: stepperFactory,
@@ -44,8 +52,10 @@ func NewEngine(
 		trackedStates:  new(sync.Map),
 		waitGroup:      new(sync.WaitGroup),
 
-		globalSecrets: secrets,
-		varSourcePool: varSourcePool,
+		globalSecrets:  secrets,
+		secretsBackend: secretsBackend,
+		varSourcePool:  varSourcePool,
+		notifier:       notifier,
 	}
 }
 
@@ -55,8 +65,10 @@ type engine struct {
 	trackedStates  *sync.Map
 	waitGroup      *sync.WaitGroup
 
-	globalSecrets creds.Secrets
-	varSourcePool creds.VarSourcePool
+	globalSecrets  creds.Secrets
+	secretsBackend string
+	varSourcePool  creds.VarSourcePool
+	notifier       notifications.Notifier
 }
 
 func (engine *engine) Drain(ctx context.Context) {
@@ -70,6 +82,8 @@ func (engine *engine) Drain(ctx context.Context) {
 	logger.Info("waiting")
 
 	engine.waitGroup.Wait()
+
+	engine.notifier.Drain(ctx)
 }
 
 func (engine *engine) NewBuild(build db.Build) Runnable {
@@ -77,7 +91,9 @@ func (engine *engine) NewBuild(build db.Build) Runnable {
 		build,
 		engine.stepperFactory,
 		engine.globalSecrets,
+		engine.secretsBackend,
 		engine.varSourcePool,
+		engine.notifier,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -88,7 +104,9 @@ func NewBuild(
 	build db.Build,
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
+	secretsBackend string,
 	varSourcePool creds.VarSourcePool,
+	notifier notifications.Notifier,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -97,8 +115,10 @@ func NewBuild(
 		build:   build,
 		builder: builder,
 
-		globalSecrets: globalSecrets,
-		varSourcePool: varSourcePool,
+		globalSecrets:  globalSecrets,
+		secretsBackend: secretsBackend,
+		varSourcePool:  varSourcePool,
+		notifier:       notifier,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -110,8 +130,10 @@ type engineBuild struct {
 	build   db.Build
 	builder StepperFactory
 
-	globalSecrets creds.Secrets
-	varSourcePool creds.VarSourcePool
+	globalSecrets  creds.Secrets
+	secretsBackend string
+	varSourcePool  creds.VarSourcePool
+	notifier       notifications.Notifier
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -199,6 +221,8 @@ func (b *engineBuild) Run(ctx context.Context) {
 	noleak := make(chan bool)
 	defer close(noleak)
 
+	go b.renewSecretLeases(logger.Session("renew-secret-leases"), SecretLeaseRenewalInterval, noleak)
+
 	go func() {
 		select {
 		case <-noleak:
@@ -273,6 +297,79 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
+		return
+	}
+
+	b.revokeSecretLeases(logger.Session("revoke-secret-leases"))
+}
+
+func (b *engineBuild) renewSecretLeases(logger lager.Logger, interval time.Duration, done <-chan bool) {
+	leasing, ok := b.globalSecrets.(creds.LeasingSecrets)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		leases, err := b.build.SecretLeases()
+		if err != nil {
+			logger.Error("failed-to-get-secret-leases", err)
+			continue
+		}
+
+		for _, lease := range leases {
+			if !lease.Renewable || time.Until(lease.ExpiresAt) > lease.Duration/2 {
+				continue
+			}
+
+			renewed, err := leasing.RenewLease(lease)
+			if err != nil {
+				logger.Error("failed-to-renew-lease", err, lager.Data{"lease": lease.ID})
+				continue
+			}
+
+			err = b.build.TrackSecretLease(renewed)
+			if err != nil {
+				logger.Error("failed-to-track-renewed-lease", err, lager.Data{"lease": lease.ID})
+			}
+		}
+	}
+}
+
+// revokeSecretLeases revokes the leases held by the build on dynamic secrets
+// once it has finished, so that they never outlive it. Leases which fail to
+// be revoked are left for the secret lease collector.
+func (b *engineBuild) revokeSecretLeases(logger lager.Logger) {
+	leasing, ok := b.globalSecrets.(creds.LeasingSecrets)
+	if !ok {
+		return
+	}
+
+	leases, err := b.build.SecretLeases()
+	if err != nil {
+		logger.Error("failed-to-get-secret-leases", err)
+		return
+	}
+
+	for _, lease := range leases {
+		err := leasing.RevokeLease(lease.ID)
+		if err != nil {
+			logger.Error("failed-to-revoke-lease", err, lager.Data{"lease": lease.ID})
+			continue
+		}
+
+		err = b.build.ReleaseSecretLease(lease.ID)
+		if err != nil {
+			logger.Error("failed-to-release-lease", err, lager.Data{"lease": lease.ID})
+		}
 	}
 }
 
@@ -305,6 +402,8 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 			metric.BuildFinished{
 				Build: b.build,
 			}.Emit(logger)
+
+			b.notifier.BuildFinished(logger, b.build)
 		} else {
 			metric.CheckBuildFinished{
 				Build: b.build,
@@ -319,7 +418,20 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 	if ok {
 		return existingState.(exec.RunState), nil
 	}
-	credVars, err := b.build.Variables(logger, b.globalSecrets, b.varSourcePool)
+	// dynamic secrets are leased to the build, rather than shared with others
+	secrets := b.globalSecrets
+	if leasing, ok := secrets.(creds.LeasingSecrets); ok {
+		secrets = creds.NewLeaseTrackingSecrets(leasing, b.build)
+	}
+
+	// checks run far too often for the secrets they read to be worth auditing
+	if b.build.Name() != db.CheckBuildName {
+		recorder := newSecretAccessRecorder(logger.Session("secret-access-recorder"), b.build)
+		secrets = creds.NewAccessRecordingSecrets(secrets, recorder, b.secretsBackend)
+		stepper = recorder.recordingStepper(stepper)
+	}
+
+	credVars, err := b.build.Variables(logger, secrets, b.varSourcePool)
 	if err != nil {
 		return nil, err
 	}
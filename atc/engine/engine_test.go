@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -20,6 +21,8 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/notifications/notificationsfakes"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 
 	. "github.com/onsi/ginkgo"
@@ -33,6 +36,7 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds   *credsfakes.FakeSecrets
 		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+		fakeBuildNotifier *notificationsfakes.FakeNotifier
 	)
 
 	BeforeEach(func() {
@@ -43,6 +47,7 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds = new(credsfakes.FakeSecrets)
 		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+		fakeBuildNotifier = new(notificationsfakes.FakeNotifier)
 	})
 
 	Describe("NewBuild", func() {
@@ -52,7 +57,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, "some-backend", fakeVarSourcePool, fakeBuildNotifier)
 		})
 
 		JustBeforeEach(func() {
@@ -64,6 +69,19 @@ var _ = Describe("Engine", func() {
 		})
 	})
 
+	Describe("Drain", func() {
+		var engine Engine
+
+		BeforeEach(func() {
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, "some-backend", fakeVarSourcePool, fakeBuildNotifier)
+		})
+
+		It("drains the notifier", func() {
+			engine.Drain(lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test")))
+			Expect(fakeBuildNotifier.DrainCallCount()).To(Equal(1))
+		})
+	})
+
 	Describe("Build", func() {
 		var (
 			build     Runnable
@@ -81,7 +99,9 @@ var _ = Describe("Engine", func() {
 				fakeBuild,
 				fakeStepperFactory,
 				fakeGlobalCreds,
+				"some-backend",
 				fakeVarSourcePool,
+				fakeBuildNotifier,
 				release,
 				trackedStates,
 				waitGroup,
@@ -245,6 +265,186 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
 									})
+
+									It("does not notify that the build started, as it is run again whenever it is resumed", func() {
+										waitGroup.Wait()
+										Expect(fakeBuildNotifier.BuildStartedCallCount()).To(BeZero())
+									})
+
+									Context("when the build is no longer running", func() {
+										BeforeEach(func() {
+											fakeBuild.IsRunningReturnsOnCall(1, false)
+											fakeBuild.TracingAttrsReturns(tracing.Attrs{})
+										})
+
+										It("notifies that the build finished", func() {
+											waitGroup.Wait()
+											Expect(fakeBuildNotifier.BuildFinishedCallCount()).To(Equal(1))
+											_, notifiedBuild := fakeBuildNotifier.BuildFinishedArgsForCall(0)
+											Expect(notifiedBuild).To(Equal(fakeBuild))
+										})
+									})
+
+									Context("when the build is a check build", func() {
+										BeforeEach(func() {
+											fakeBuild.NameReturns(db.CheckBuildName)
+											fakeBuild.IsRunningReturnsOnCall(1, false)
+											fakeBuild.TracingAttrsReturns(tracing.Attrs{})
+										})
+
+										It("does not notify", func() {
+											waitGroup.Wait()
+											Expect(fakeBuildNotifier.BuildStartedCallCount()).To(BeZero())
+											Expect(fakeBuildNotifier.BuildFinishedCallCount()).To(BeZero())
+										})
+									})
+								})
+
+								Context("when steps read vars from the credential manager", func() {
+									BeforeEach(func() {
+										fakeGlobalCreds.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+											if path == "some-secret" {
+												return "some-value", nil, true, nil
+											}
+
+											return nil, nil, false, nil
+										}
+
+										fakeBuild.VariablesStub = func(_ lager.Logger, secrets creds.Secrets, _ creds.VarSourcePool) (vars.Variables, error) {
+											return creds.NewVariables(secrets, "some-team", "some-pipeline", false), nil
+										}
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											for i := 0; i < 2; i++ {
+												_, found, err := state.Get(vars.Reference{Path: "some-secret"})
+												Expect(err).ToNot(HaveOccurred())
+												Expect(found).To(BeTrue())
+											}
+
+											return true, nil
+										}
+									})
+
+									It("records each var read by the step once", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.RecordSecretAccessCallCount()).To(Equal(1))
+
+										step, access := fakeBuild.RecordSecretAccessArgsForCall(0)
+										Expect(step).To(Equal("some-var"))
+										Expect(access).To(Equal(creds.SecretAccess{
+											Var:        "some-secret",
+											Backend:    "some-backend",
+											SecretPath: "some-secret",
+										}))
+									})
+
+									Context("when the build is a check build", func() {
+										BeforeEach(func() {
+											fakeBuild.NameReturns(db.CheckBuildName)
+										})
+
+										It("does not record anything", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.RecordSecretAccessCallCount()).To(BeZero())
+										})
+									})
+								})
+
+								Context("when the credential manager leases dynamic secrets", func() {
+									var fakeLeasingCreds *credsfakes.FakeLeasingSecrets
+
+									BeforeEach(func() {
+										fakeLeasingCreds = new(credsfakes.FakeLeasingSecrets)
+
+										build = NewBuild(
+											fakeBuild,
+											fakeStepperFactory,
+											fakeLeasingCreds,
+											"some-backend",
+											fakeVarSourcePool,
+											fakeBuildNotifier,
+											release,
+											new(sync.Map),
+											waitGroup,
+										)
+
+										fakeBuild.SecretLeasesReturns([]creds.Lease{
+											{ID: "database/creds/some-role/lease-1"},
+											{ID: "aws/creds/some-role/lease-2"},
+										}, nil)
+									})
+
+									It("reads the build variables through a lease tracker for the build", func() {
+										waitGroup.Wait()
+										_, secrets, _ := fakeBuild.VariablesArgsForCall(0)
+										Expect(secrets).To(BeAssignableToTypeOf(&creds.AccessRecordingSecrets{}))
+										Expect(secrets.(*creds.AccessRecordingSecrets).Secrets).To(BeAssignableToTypeOf(&creds.LeaseTrackingSecrets{}))
+									})
+
+									It("revokes and releases the build's leases once it has finished", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+
+										Expect(fakeLeasingCreds.RevokeLeaseCallCount()).To(Equal(2))
+										Expect(fakeLeasingCreds.RevokeLeaseArgsForCall(0)).To(Equal("database/creds/some-role/lease-1"))
+										Expect(fakeLeasingCreds.RevokeLeaseArgsForCall(1)).To(Equal("aws/creds/some-role/lease-2"))
+
+										Expect(fakeBuild.ReleaseSecretLeaseCallCount()).To(Equal(2))
+										Expect(fakeBuild.ReleaseSecretLeaseArgsForCall(0)).To(Equal("database/creds/some-role/lease-1"))
+										Expect(fakeBuild.ReleaseSecretLeaseArgsForCall(1)).To(Equal("aws/creds/some-role/lease-2"))
+									})
+
+									Context("when revoking a lease fails", func() {
+										BeforeEach(func() {
+											fakeLeasingCreds.RevokeLeaseReturnsOnCall(0, errors.New("vault unavailable"))
+										})
+
+										It("leaves it for the collector", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.ReleaseSecretLeaseCallCount()).To(Equal(1))
+											Expect(fakeBuild.ReleaseSecretLeaseArgsForCall(0)).To(Equal("aws/creds/some-role/lease-2"))
+										})
+									})
+
+									Context("while the build is running", func() {
+										var originalInterval time.Duration
+
+										BeforeEach(func() {
+											originalInterval = SecretLeaseRenewalInterval
+											SecretLeaseRenewalInterval = 10 * time.Millisecond
+
+											fakeBuild.SecretLeasesReturns([]creds.Lease{
+												{ID: "expiring-lease", Duration: time.Hour, Renewable: true, ExpiresAt: time.Now().Add(time.Minute)},
+												{ID: "fresh-lease", Duration: time.Hour, Renewable: true, ExpiresAt: time.Now().Add(time.Hour)},
+												{ID: "non-renewable-lease", Duration: time.Hour, ExpiresAt: time.Now().Add(time.Minute)},
+											}, nil)
+											fakeLeasingCreds.RenewLeaseStub = func(lease creds.Lease) (creds.Lease, error) {
+												lease.ExpiresAt = time.Now().Add(lease.Duration)
+												return lease, nil
+											}
+
+											fakeStep.RunStub = func(context.Context, exec.RunState) (bool, error) {
+												Eventually(fakeLeasingCreds.RenewLeaseCallCount).ShouldNot(BeZero())
+												return true, nil
+											}
+										})
+
+										AfterEach(func() {
+											SecretLeaseRenewalInterval = originalInterval
+										})
+
+										It("renews the leases which are past half of their duration", func() {
+											waitGroup.Wait()
+											Expect(fakeLeasingCreds.RenewLeaseArgsForCall(0).ID).To(Equal("expiring-lease"))
+
+											for i := 0; i < fakeLeasingCreds.RenewLeaseCallCount(); i++ {
+												Expect(fakeLeasingCreds.RenewLeaseArgsForCall(i).ID).To(Equal("expiring-lease"))
+											}
+
+											Expect(fakeBuild.TrackSecretLeaseCallCount()).ToNot(BeZero())
+											Expect(fakeBuild.TrackSecretLeaseArgsForCall(0).ID).To(Equal("expiring-lease"))
+										})
+									})
 								})
 
 								Context("when the build finishes woefully", func() {
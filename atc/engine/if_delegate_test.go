@@ -0,0 +1,70 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("IfDelegate", func() {
+	var (
+		logger            *lagertest.TestLogger
+		fakeBuild         *dbfakes.FakeBuild
+		fakeClock         *fakeclock.FakeClock
+		fakePolicyChecker *policyfakes.FakeChecker
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.IfDelegate
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+		delegate = engine.NewIfDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+	})
+
+	Describe("Skipped", func() {
+		JustBeforeEach(func() {
+			delegate.Skipped(logger, "vars.enabled")
+		})
+
+		It("saves a skipped event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Skipped{
+				Time:      now.Unix(),
+				Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+				Condition: "vars.enabled",
+			}))
+		})
+
+		Context("when saving the event fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(errors.New("nope"))
+			})
+
+			It("logs the error", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-save-skipped-event"))
+			})
+		})
+	})
+})
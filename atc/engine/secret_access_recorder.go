@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
+)
+
+// secretAccessRecorder records which steps of a build read which secrets.
+// Credential managers report where each var was found as it is looked up,
+// which is attributed to a step once the step reads the var. Each step is
+// recorded reading a var at most once.
+type secretAccessRecorder struct {
+	logger lager.Logger
+	build  db.Build
+
+	lock     sync.Mutex
+	found    map[string]creds.SecretAccess
+	recorded map[string]bool
+}
+
+func newSecretAccessRecorder(logger lager.Logger, build db.Build) *secretAccessRecorder {
+	return &secretAccessRecorder{
+		logger:   logger,
+		build:    build,
+		found:    map[string]creds.SecretAccess{},
+		recorded: map[string]bool{},
+	}
+}
+
+func (recorder *secretAccessRecorder) RecordSecretAccess(access creds.SecretAccess) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	recorder.found[secretAccessKey(access.VarSource, access.Var)] = access
+}
+
+func (recorder *secretAccessRecorder) RecordSecretUsage(step string, ref vars.Reference) {
+	key := secretAccessKey(ref.Source, ref.Path)
+	stepKey := step + "\x00" + key
+
+	recorder.lock.Lock()
+	access, found := recorder.found[key]
+	if !found || recorder.recorded[stepKey] {
+		// vars which weren't read from a credential manager, e.g. sealed
+		// vars, have nothing to record
+		recorder.lock.Unlock()
+		return
+	}
+
+	recorder.recorded[stepKey] = true
+	recorder.lock.Unlock()
+
+	err := recorder.build.RecordSecretAccess(step, access)
+	if err != nil {
+		recorder.logger.Error("failed-to-record-secret-access", err, lager.Data{
+			"step": step,
+			"var":  ref.String(),
+		})
+
+		recorder.lock.Lock()
+		delete(recorder.recorded, stepKey)
+		recorder.lock.Unlock()
+	}
+}
+
+// recordingStepper decorates the steps built by the stepper so that the
+// vars each of them reads are recorded.
+func (recorder *secretAccessRecorder) recordingStepper(stepper exec.Stepper) exec.Stepper {
+	return func(plan atc.Plan) exec.Step {
+		step := stepper(plan)
+
+		name, ok := stepName(plan)
+		if !ok {
+			return step
+		}
+
+		return exec.RecordSecretUsage(step, name, recorder)
+	}
+}
+
+func secretAccessKey(source string, path string) string {
+	return source + ":" + path
+}
+
+// stepName returns the name of the plan's step, unless it is a step which
+// only runs other steps.
+func stepName(plan atc.Plan) (string, bool) {
+	switch {
+	case plan.Get != nil:
+		return plan.Get.Name, true
+	case plan.Put != nil:
+		return plan.Put.Name, true
+	case plan.Task != nil:
+		return plan.Task.Name, true
+	case plan.Check != nil:
+		return plan.Check.Name, true
+	case plan.SetPipeline != nil:
+		return plan.SetPipeline.Name, true
+	case plan.LoadVar != nil:
+		return plan.LoadVar.Name, true
+	default:
+		return "", false
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/idtoken"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -22,9 +23,11 @@ type coreStepFactory struct {
 	buildFactory          db.BuildFactory
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	taskResultFactory     db.TaskResultFactory
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	defaultCheckTimeout   time.Duration
+	idTokenIssuer         idtoken.Issuer
 }
 
 func NewCoreStepFactory(
@@ -36,9 +39,11 @@ func NewCoreStepFactory(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	taskResultFactory db.TaskResultFactory,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	defaultCheckTimeout time.Duration,
+	idTokenIssuer idtoken.Issuer,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -49,9 +54,11 @@ func NewCoreStepFactory(
 		buildFactory:          buildFactory,
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		taskResultFactory:     taskResultFactory,
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		defaultCheckTimeout:   defaultCheckTimeout,
+		idTokenIssuer:         idTokenIssuer,
 	}
 }
 
@@ -73,6 +80,7 @@ func (factory *coreStepFactory) GetStep(
 		factory.strategy,
 		delegateFactory,
 		factory.pool,
+		factory.idTokenIssuer,
 	)
 
 	getStep = exec.LogError(getStep, delegateFactory)
@@ -100,6 +108,7 @@ func (factory *coreStepFactory) PutStep(
 		factory.strategy,
 		factory.pool,
 		factory.artifactSourcer,
+		factory.idTokenIssuer,
 		delegateFactory,
 	)
 
@@ -149,6 +158,11 @@ func (factory *coreStepFactory) RunStep(
 	runStep := exec.NewRunStep(
 		plan.ID,
 		*plan.Run,
+		stepMetadata,
+		containerMetadata,
+		factory.strategy,
+		factory.pool,
+		factory.artifactSourcer,
 		delegateFactory,
 	)
 
@@ -178,6 +192,8 @@ func (factory *coreStepFactory) TaskStep(
 		factory.pool,
 		factory.artifactStreamer,
 		factory.artifactSourcer,
+		factory.taskResultFactory,
+		factory.idTokenIssuer,
 		delegateFactory,
 	)
 
@@ -230,6 +246,21 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) ApprovalStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	approvalStep := exec.NewApprovalStep(
+		plan.ID,
+		*plan.Approval,
+		stepMetadata,
+		delegateFactory,
+	)
+
+	return exec.LogError(approvalStep, delegateFactory)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
@@ -52,6 +52,16 @@ func (d *taskDelegate) SetTaskConfig(config atc.TaskConfig) {
 	d.config = config
 }
 
+func (d *taskDelegate) SaveTestSuites(logger lager.Logger, suites []atc.TestSuite) {
+	err := d.build.SaveTestSuites(suites)
+	if err != nil {
+		logger.Error("failed-to-save-test-suites", err)
+		return
+	}
+
+	logger.Debug("saved-test-suites", lager.Data{"suites": len(suites)})
+}
+
 func (d *taskDelegate) Initializing(logger lager.Logger) {
 	err := d.build.SaveEvent(event.InitializeTask{
 		Origin:     d.eventOrigin,
@@ -152,6 +152,31 @@ var _ = Describe("TaskDelegate", func() {
 			Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 		})
 	})
+
+	Describe("SaveTestSuites", func() {
+		var suites []atc.TestSuite
+
+		BeforeEach(func() {
+			suites = []atc.TestSuite{
+				{
+					StepName: "some-task",
+					Name:     "some-suite",
+					Cases: []atc.TestCase{
+						{Name: "some-case", Status: atc.TestPassed},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			delegate.SaveTestSuites(logger, suites)
+		})
+
+		It("saves the suites against the build", func() {
+			Expect(fakeBuild.SaveTestSuitesCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveTestSuitesArgsForCall(0)).To(Equal(suites))
+		})
+	})
 })
 
 func containerSpecDummy() worker.ContainerSpec {
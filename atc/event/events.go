@@ -249,3 +249,32 @@ type AcrossSubsteps struct {
 
 func (AcrossSubsteps) EventType() atc.EventType  { return EventTypeAcrossSubsteps }
 func (AcrossSubsteps) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForApproval struct {
+	Time         int64  `json:"time"`
+	Origin       Origin `json:"origin"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+func (WaitingForApproval) EventType() atc.EventType  { return EventTypeWaitingForApproval }
+func (WaitingForApproval) Version() atc.EventVersion { return "1.0" }
+
+type ApprovalDecided struct {
+	Time     int64  `json:"time"`
+	Origin   Origin `json:"origin"`
+	Approved bool   `json:"approved"`
+	Approver string `json:"approver"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+func (ApprovalDecided) EventType() atc.EventType  { return EventTypeApprovalDecided }
+func (ApprovalDecided) Version() atc.EventVersion { return "1.0" }
+
+type Skipped struct {
+	Time      int64  `json:"time"`
+	Origin    Origin `json:"origin"`
+	Condition string `json:"condition"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
@@ -52,6 +52,9 @@ func init() {
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
 	RegisterEvent(AcrossSubsteps{})
+	RegisterEvent(WaitingForApproval{})
+	RegisterEvent(ApprovalDecided{})
+	RegisterEvent(Skipped{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -64,4 +64,13 @@ const (
 
 	// across step substeps (sent dynamically as of Concourse 7.4)
 	EventTypeAcrossSubsteps atc.EventType = "across-substeps"
+
+	// an approval step is waiting for someone to approve or reject the build
+	EventTypeWaitingForApproval atc.EventType = "waiting-for-approval"
+
+	// an approval step was approved or rejected
+	EventTypeApprovalDecided atc.EventType = "approval-decided"
+
+	// a step was not run because its 'if' condition was false
+	EventTypeSkipped atc.EventType = "skipped"
 )
@@ -0,0 +1,83 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tracing"
+)
+
+// ApprovalStep suspends the build until someone approves or rejects it. The
+// step succeeds if the build was approved and fails if it was rejected.
+//
+// Decisions are persisted, so a build resumed after an ATC restart picks up
+// a decision that was made while it was not being tracked.
+type ApprovalStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovalPlan
+	metadata        StepMetadata
+	delegateFactory ApprovalDelegateFactory
+}
+
+func NewApprovalStep(
+	planID atc.PlanID,
+	plan atc.ApprovalPlan,
+	metadata StepMetadata,
+	delegateFactory ApprovalDelegateFactory,
+) Step {
+	return &ApprovalStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *ApprovalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.ApprovalDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approval", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApprovalStep) run(ctx context.Context, delegate ApprovalDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approval-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	delegate.Starting(logger)
+
+	approval, err := delegate.WaitForApproval(ctx, logger, step.plan)
+	if err != nil {
+		return false, err
+	}
+
+	delegate.ApprovalDecided(logger, approval)
+
+	decision := "rejected"
+	if approval.Approved {
+		decision = "approved"
+	}
+
+	stdout := delegate.Stdout()
+	fmt.Fprintf(stdout, "%s by %s\n", decision, approval.Approver)
+	if approval.Comment != "" {
+		fmt.Fprintf(stdout, "comment: %s\n", approval.Comment)
+	}
+
+	delegate.Finished(logger, approval.Approved)
+
+	return approval.Approved, nil
+}
@@ -0,0 +1,174 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		ctx        context.Context
+		cancel     func()
+		testLogger *lagertest.TestLogger
+
+		fakeDelegate        *execfakes.FakeApprovalDelegate
+		fakeDelegateFactory *execfakes.FakeApprovalDelegateFactory
+
+		approvalPlan atc.ApprovalPlan
+		state        *execfakes.FakeRunState
+
+		step    exec.Step
+		stepOk  bool
+		stepErr error
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      42,
+			BuildName:    "some-build",
+			PipelineID:   4567,
+			PipelineName: "some-pipeline",
+		}
+
+		stdout *gbytes.Buffer
+
+		planID = atc.PlanID("56")
+	)
+
+	BeforeEach(func() {
+		testLogger = lagertest.NewTestLogger("approval-step-test")
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		state = new(execfakes.FakeRunState)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeApprovalDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+
+		fakeDelegateFactory = new(execfakes.FakeApprovalDelegateFactory)
+		fakeDelegateFactory.ApprovalDelegateReturns(fakeDelegate)
+
+		approvalPlan = atc.ApprovalPlan{
+			Name:         "deploy-to-prod",
+			Instructions: "look before you leap",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewApprovalStep(
+			planID,
+			approvalPlan,
+			stepMetadata,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("waits for the approval of the planned step", func() {
+		Expect(fakeDelegate.WaitForApprovalCallCount()).To(Equal(1))
+		_, _, plan := fakeDelegate.WaitForApprovalArgsForCall(0)
+		Expect(plan).To(Equal(approvalPlan))
+	})
+
+	Context("when the build is approved", func() {
+		var approval db.BuildApproval
+
+		BeforeEach(func() {
+			approval = db.BuildApproval{
+				Name:     "deploy-to-prod",
+				Approved: true,
+				Approver: "some-user",
+				Comment:  "ship it",
+			}
+
+			fakeDelegate.WaitForApprovalReturns(approval, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("records the decision", func() {
+			Expect(fakeDelegate.ApprovalDecidedCallCount()).To(Equal(1))
+			_, decided := fakeDelegate.ApprovalDecidedArgsForCall(0)
+			Expect(decided).To(Equal(approval))
+		})
+
+		It("prints who approved the build and why", func() {
+			Expect(stdout).To(gbytes.Say("approved by some-user"))
+			Expect(stdout).To(gbytes.Say("comment: ship it"))
+		})
+
+		It("finishes successfully", func() {
+			Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+	})
+
+	Context("when the build is rejected", func() {
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(db.BuildApproval{
+				Name:     "deploy-to-prod",
+				Approved: false,
+				Approver: "some-user",
+			}, nil)
+		})
+
+		It("fails without erroring", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("prints who rejected the build", func() {
+			Expect(stdout).To(gbytes.Say("rejected by some-user"))
+		})
+
+		It("finishes unsuccessfully", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when waiting for approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(db.BuildApproval{}, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("does not record a decision or finish", func() {
+			Expect(fakeDelegate.ApprovalDecidedCallCount()).To(BeZero())
+			Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+		})
+	})
+})
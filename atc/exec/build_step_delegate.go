@@ -8,6 +8,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 )
@@ -34,6 +35,8 @@ type BuildStepDelegate interface {
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
 
+	CheckStepPolicy(string, map[string]interface{}) error
+
 	ConstructAcrossSubsteps([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
 }
 
@@ -48,3 +51,28 @@ type SetPipelineStepDelegate interface {
 	SetPipelineChanged(lager.Logger, bool)
 	CheckRunSetPipelinePolicy(*atc.Config) error
 }
+
+//counterfeiter:generate . ApprovalDelegateFactory
+type ApprovalDelegateFactory interface {
+	ApprovalDelegate(state RunState) ApprovalDelegate
+}
+
+//counterfeiter:generate . ApprovalDelegate
+type ApprovalDelegate interface {
+	BuildStepDelegate
+
+	WaitForApproval(context.Context, lager.Logger, atc.ApprovalPlan) (db.BuildApproval, error)
+	ApprovalDecided(lager.Logger, db.BuildApproval)
+}
+
+//counterfeiter:generate . IfDelegateFactory
+type IfDelegateFactory interface {
+	IfDelegate(state RunState) IfDelegate
+}
+
+//counterfeiter:generate . IfDelegate
+type IfDelegate interface {
+	BuildStepDelegate
+
+	Skipped(lager.Logger, string)
+}
@@ -17,6 +17,18 @@ import (
 )
 
 type FakeCheckDelegate struct {
+	CheckStepPolicyStub        func(string, map[string]interface{}) error
+	checkStepPolicyMutex       sync.RWMutex
+	checkStepPolicyArgsForCall []struct {
+		arg1 string
+		arg2 map[string]interface{}
+	}
+	checkStepPolicyReturns struct {
+		result1 error
+	}
+	checkStepPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ConstructAcrossSubstepsStub        func([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
 	constructAcrossSubstepsMutex       sync.RWMutex
 	constructAcrossSubstepsArgsForCall []struct {
@@ -160,6 +172,68 @@ type FakeCheckDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCheckDelegate) CheckStepPolicy(arg1 string, arg2 map[string]interface{}) error {
+	fake.checkStepPolicyMutex.Lock()
+	ret, specificReturn := fake.checkStepPolicyReturnsOnCall[len(fake.checkStepPolicyArgsForCall)]
+	fake.checkStepPolicyArgsForCall = append(fake.checkStepPolicyArgsForCall, struct {
+		arg1 string
+		arg2 map[string]interface{}
+	}{arg1, arg2})
+	stub := fake.CheckStepPolicyStub
+	fakeReturns := fake.checkStepPolicyReturns
+	fake.recordInvocation("CheckStepPolicy", []interface{}{arg1, arg2})
+	fake.checkStepPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) CheckStepPolicyCallCount() int {
+	fake.checkStepPolicyMutex.RLock()
+	defer fake.checkStepPolicyMutex.RUnlock()
+	return len(fake.checkStepPolicyArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) CheckStepPolicyCalls(stub func(string, map[string]interface{}) error) {
+	fake.checkStepPolicyMutex.Lock()
+	defer fake.checkStepPolicyMutex.Unlock()
+	fake.CheckStepPolicyStub = stub
+}
+
+func (fake *FakeCheckDelegate) CheckStepPolicyArgsForCall(i int) (string, map[string]interface{}) {
+	fake.checkStepPolicyMutex.RLock()
+	defer fake.checkStepPolicyMutex.RUnlock()
+	argsForCall := fake.checkStepPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckDelegate) CheckStepPolicyReturns(result1 error) {
+	fake.checkStepPolicyMutex.Lock()
+	defer fake.checkStepPolicyMutex.Unlock()
+	fake.CheckStepPolicyStub = nil
+	fake.checkStepPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) CheckStepPolicyReturnsOnCall(i int, result1 error) {
+	fake.checkStepPolicyMutex.Lock()
+	defer fake.checkStepPolicyMutex.Unlock()
+	fake.CheckStepPolicyStub = nil
+	if fake.checkStepPolicyReturnsOnCall == nil {
+		fake.checkStepPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkStepPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheckDelegate) ConstructAcrossSubsteps(arg1 []byte, arg2 []atc.AcrossVar, arg3 [][]interface{}) ([]atc.VarScopedPlan, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -871,6 +945,8 @@ func (fake *FakeCheckDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checkStepPolicyMutex.RLock()
+	defer fake.checkStepPolicyMutex.RUnlock()
 	fake.constructAcrossSubstepsMutex.RLock()
 	defer fake.constructAcrossSubstepsMutex.RUnlock()
 	fake.erroredMutex.RLock()
@@ -16,6 +16,18 @@ import (
 )
 
 type FakeGetDelegate struct {
+	CheckStepPolicyStub        func(string, map[string]interface{}) error
+	checkStepPolicyMutex       sync.RWMutex
+	checkStepPolicyArgsForCall []struct {
+		arg1 string
+		arg2 map[string]interface{}
+	}
+	checkStepPolicyReturns struct {
+		result1 error
+	}
+	checkStepPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ErroredStub        func(lager.Logger, string)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
@@ -112,6 +124,68 @@ type FakeGetDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeGetDelegate) CheckStepPolicy(arg1 string, arg2 map[string]interface{}) error {
+	fake.checkStepPolicyMutex.Lock()
+	ret, specificReturn := fake.checkStepPolicyReturnsOnCall[len(fake.checkStepPolicyArgsForCall)]
+	fake.checkStepPolicyArgsForCall = append(fake.checkStepPolicyArgsForCall, struct {
+		arg1 string
+		arg2 map[string]interface{}
+	}{arg1, arg2})
+	stub := fake.CheckStepPolicyStub
+	fakeReturns := fake.checkStepPolicyReturns
+	fake.recordInvocation("CheckStepPolicy", []interface{}{arg1, arg2})
+	fake.checkStepPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) CheckStepPolicyCallCount() int {
+	fake.checkStepPolicyMutex.RLock()
+	defer fake.checkStepPolicyMutex.RUnlock()
+	return len(fake.checkStepPolicyArgsForCall)
+}
+
+func (fake *FakeGetDelegate) CheckStepPolicyCalls(stub func(string, map[string]interface{}) error) {
+	fake.checkStepPolicyMutex.Lock()
+	defer fake.checkStepPolicyMutex.Unlock()
+	fake.CheckStepPolicyStub = stub
+}
+
+func (fake *FakeGetDelegate) CheckStepPolicyArgsForCall(i int) (string, map[string]interface{}) {
+	fake.checkStepPolicyMutex.RLock()
+	defer fake.checkStepPolicyMutex.RUnlock()
+	argsForCall := fake.checkStepPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGetDelegate) CheckStepPolicyReturns(result1 error) {
+	fake.checkStepPolicyMutex.Lock()
+	defer fake.checkStepPolicyMutex.Unlock()
+	fake.CheckStepPolicyStub = nil
+	fake.checkStepPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) CheckStepPolicyReturnsOnCall(i int, result1 error) {
+	fake.checkStepPolicyMutex.Lock()
+	defer fake.checkStepPolicyMutex.Unlock()
+	fake.CheckStepPolicyStub = nil
+	if fake.checkStepPolicyReturnsOnCall == nil {
+		fake.checkStepPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkStepPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGetDelegate) Errored(arg1 lager.Logger, arg2 string) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
@@ -584,6 +658,8 @@ func (fake *FakeGetDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checkStepPolicyMutex.RLock()
+	defer fake.checkStepPolicyMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.fetchImageMutex.RLock()
@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeIfDelegateFactory struct {
+	IfDelegateStub        func(exec.RunState) exec.IfDelegate
+	ifDelegateMutex       sync.RWMutex
+	ifDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	ifDelegateReturns struct {
+		result1 exec.IfDelegate
+	}
+	ifDelegateReturnsOnCall map[int]struct {
+		result1 exec.IfDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIfDelegateFactory) IfDelegate(arg1 exec.RunState) exec.IfDelegate {
+	fake.ifDelegateMutex.Lock()
+	ret, specificReturn := fake.ifDelegateReturnsOnCall[len(fake.ifDelegateArgsForCall)]
+	fake.ifDelegateArgsForCall = append(fake.ifDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	stub := fake.IfDelegateStub
+	fakeReturns := fake.ifDelegateReturns
+	fake.recordInvocation("IfDelegate", []interface{}{arg1})
+	fake.ifDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIfDelegateFactory) IfDelegateCallCount() int {
+	fake.ifDelegateMutex.RLock()
+	defer fake.ifDelegateMutex.RUnlock()
+	return len(fake.ifDelegateArgsForCall)
+}
+
+func (fake *FakeIfDelegateFactory) IfDelegateCalls(stub func(exec.RunState) exec.IfDelegate) {
+	fake.ifDelegateMutex.Lock()
+	defer fake.ifDelegateMutex.Unlock()
+	fake.IfDelegateStub = stub
+}
+
+func (fake *FakeIfDelegateFactory) IfDelegateArgsForCall(i int) exec.RunState {
+	fake.ifDelegateMutex.RLock()
+	defer fake.ifDelegateMutex.RUnlock()
+	argsForCall := fake.ifDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIfDelegateFactory) IfDelegateReturns(result1 exec.IfDelegate) {
+	fake.ifDelegateMutex.Lock()
+	defer fake.ifDelegateMutex.Unlock()
+	fake.IfDelegateStub = nil
+	fake.ifDelegateReturns = struct {
+		result1 exec.IfDelegate
+	}{result1}
+}
+
+func (fake *FakeIfDelegateFactory) IfDelegateReturnsOnCall(i int, result1 exec.IfDelegate) {
+	fake.ifDelegateMutex.Lock()
+	defer fake.ifDelegateMutex.Unlock()
+	fake.IfDelegateStub = nil
+	if fake.ifDelegateReturnsOnCall == nil {
+		fake.ifDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.IfDelegate
+		})
+	}
+	fake.ifDelegateReturnsOnCall[i] = struct {
+		result1 exec.IfDelegate
+	}{result1}
+}
+
+func (fake *FakeIfDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ifDelegateMutex.RLock()
+	defer fake.ifDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeIfDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.IfDelegateFactory = new(FakeIfDelegateFactory)
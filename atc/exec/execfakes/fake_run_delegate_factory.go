@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeRunDelegateFactory struct {
+	RunDelegateStub        func(exec.RunState) exec.RunDelegate
+	runDelegateMutex       sync.RWMutex
+	runDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	runDelegateReturns struct {
+		result1 exec.RunDelegate
+	}
+	runDelegateReturnsOnCall map[int]struct {
+		result1 exec.RunDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRunDelegateFactory) RunDelegate(arg1 exec.RunState) exec.RunDelegate {
+	fake.runDelegateMutex.Lock()
+	ret, specificReturn := fake.runDelegateReturnsOnCall[len(fake.runDelegateArgsForCall)]
+	fake.runDelegateArgsForCall = append(fake.runDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	stub := fake.RunDelegateStub
+	fakeReturns := fake.runDelegateReturns
+	fake.recordInvocation("RunDelegate", []interface{}{arg1})
+	fake.runDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunDelegateFactory) RunDelegateCallCount() int {
+	fake.runDelegateMutex.RLock()
+	defer fake.runDelegateMutex.RUnlock()
+	return len(fake.runDelegateArgsForCall)
+}
+
+func (fake *FakeRunDelegateFactory) RunDelegateCalls(stub func(exec.RunState) exec.RunDelegate) {
+	fake.runDelegateMutex.Lock()
+	defer fake.runDelegateMutex.Unlock()
+	fake.RunDelegateStub = stub
+}
+
+func (fake *FakeRunDelegateFactory) RunDelegateArgsForCall(i int) exec.RunState {
+	fake.runDelegateMutex.RLock()
+	defer fake.runDelegateMutex.RUnlock()
+	argsForCall := fake.runDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRunDelegateFactory) RunDelegateReturns(result1 exec.RunDelegate) {
+	fake.runDelegateMutex.Lock()
+	defer fake.runDelegateMutex.Unlock()
+	fake.RunDelegateStub = nil
+	fake.runDelegateReturns = struct {
+		result1 exec.RunDelegate
+	}{result1}
+}
+
+func (fake *FakeRunDelegateFactory) RunDelegateReturnsOnCall(i int, result1 exec.RunDelegate) {
+	fake.runDelegateMutex.Lock()
+	defer fake.runDelegateMutex.Unlock()
+	fake.RunDelegateStub = nil
+	if fake.runDelegateReturnsOnCall == nil {
+		fake.runDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.RunDelegate
+		})
+	}
+	fake.runDelegateReturnsOnCall[i] = struct {
+		result1 exec.RunDelegate
+	}{result1}
+}
+
+func (fake *FakeRunDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.runDelegateMutex.RLock()
+	defer fake.runDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRunDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.RunDelegateFactory = new(FakeRunDelegateFactory)
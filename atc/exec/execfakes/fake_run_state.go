@@ -117,12 +117,31 @@ type FakeRunState struct {
 		result1 bool
 		result2 error
 	}
+	StepStatusStub        func(string) (atc.BuildStatus, bool)
+	stepStatusMutex       sync.RWMutex
+	stepStatusArgsForCall []struct {
+		arg1 string
+	}
+	stepStatusReturns struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}
+	stepStatusReturnsOnCall map[int]struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}
 	StoreResultStub        func(atc.PlanID, interface{})
 	storeResultMutex       sync.RWMutex
 	storeResultArgsForCall []struct {
 		arg1 atc.PlanID
 		arg2 interface{}
 	}
+	StoreStepStatusStub        func(string, atc.BuildStatus)
+	storeStepStatusMutex       sync.RWMutex
+	storeStepStatusArgsForCall []struct {
+		arg1 string
+		arg2 atc.BuildStatus
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -655,6 +674,70 @@ func (fake *FakeRunState) RunReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeRunState) StepStatus(arg1 string) (atc.BuildStatus, bool) {
+	fake.stepStatusMutex.Lock()
+	ret, specificReturn := fake.stepStatusReturnsOnCall[len(fake.stepStatusArgsForCall)]
+	fake.stepStatusArgsForCall = append(fake.stepStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StepStatusStub
+	fakeReturns := fake.stepStatusReturns
+	fake.recordInvocation("StepStatus", []interface{}{arg1})
+	fake.stepStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRunState) StepStatusCallCount() int {
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	return len(fake.stepStatusArgsForCall)
+}
+
+func (fake *FakeRunState) StepStatusCalls(stub func(string) (atc.BuildStatus, bool)) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = stub
+}
+
+func (fake *FakeRunState) StepStatusArgsForCall(i int) string {
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	argsForCall := fake.stepStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRunState) StepStatusReturns(result1 atc.BuildStatus, result2 bool) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = nil
+	fake.stepStatusReturns = struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) StepStatusReturnsOnCall(i int, result1 atc.BuildStatus, result2 bool) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = nil
+	if fake.stepStatusReturnsOnCall == nil {
+		fake.stepStatusReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildStatus
+			result2 bool
+		})
+	}
+	fake.stepStatusReturnsOnCall[i] = struct {
+		result1 atc.BuildStatus
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeRunState) StoreResult(arg1 atc.PlanID, arg2 interface{}) {
 	fake.storeResultMutex.Lock()
 	fake.storeResultArgsForCall = append(fake.storeResultArgsForCall, struct {
@@ -688,6 +771,39 @@ func (fake *FakeRunState) StoreResultArgsForCall(i int) (atc.PlanID, interface{}
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRunState) StoreStepStatus(arg1 string, arg2 atc.BuildStatus) {
+	fake.storeStepStatusMutex.Lock()
+	fake.storeStepStatusArgsForCall = append(fake.storeStepStatusArgsForCall, struct {
+		arg1 string
+		arg2 atc.BuildStatus
+	}{arg1, arg2})
+	stub := fake.StoreStepStatusStub
+	fake.recordInvocation("StoreStepStatus", []interface{}{arg1, arg2})
+	fake.storeStepStatusMutex.Unlock()
+	if stub != nil {
+		fake.StoreStepStatusStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRunState) StoreStepStatusCallCount() int {
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	return len(fake.storeStepStatusArgsForCall)
+}
+
+func (fake *FakeRunState) StoreStepStatusCalls(stub func(string, atc.BuildStatus)) {
+	fake.storeStepStatusMutex.Lock()
+	defer fake.storeStepStatusMutex.Unlock()
+	fake.StoreStepStatusStub = stub
+}
+
+func (fake *FakeRunState) StoreStepStatusArgsForCall(i int) (string, atc.BuildStatus) {
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	argsForCall := fake.storeStepStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -711,8 +827,12 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.resultMutex.RUnlock()
 	fake.runMutex.RLock()
 	defer fake.runMutex.RUnlock()
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
 	fake.storeResultMutex.RLock()
 	defer fake.storeResultMutex.RUnlock()
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
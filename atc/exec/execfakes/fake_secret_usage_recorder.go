@@ -0,0 +1,79 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
+)
+
+type FakeSecretUsageRecorder struct {
+	RecordSecretUsageStub        func(string, vars.Reference)
+	recordSecretUsageMutex       sync.RWMutex
+	recordSecretUsageArgsForCall []struct {
+		arg1 string
+		arg2 vars.Reference
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretUsageRecorder) RecordSecretUsage(arg1 string, arg2 vars.Reference) {
+	fake.recordSecretUsageMutex.Lock()
+	fake.recordSecretUsageArgsForCall = append(fake.recordSecretUsageArgsForCall, struct {
+		arg1 string
+		arg2 vars.Reference
+	}{arg1, arg2})
+	stub := fake.RecordSecretUsageStub
+	fake.recordInvocation("RecordSecretUsage", []interface{}{arg1, arg2})
+	fake.recordSecretUsageMutex.Unlock()
+	if stub != nil {
+		fake.RecordSecretUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSecretUsageRecorder) RecordSecretUsageCallCount() int {
+	fake.recordSecretUsageMutex.RLock()
+	defer fake.recordSecretUsageMutex.RUnlock()
+	return len(fake.recordSecretUsageArgsForCall)
+}
+
+func (fake *FakeSecretUsageRecorder) RecordSecretUsageCalls(stub func(string, vars.Reference)) {
+	fake.recordSecretUsageMutex.Lock()
+	defer fake.recordSecretUsageMutex.Unlock()
+	fake.RecordSecretUsageStub = stub
+}
+
+func (fake *FakeSecretUsageRecorder) RecordSecretUsageArgsForCall(i int) (string, vars.Reference) {
+	fake.recordSecretUsageMutex.RLock()
+	defer fake.recordSecretUsageMutex.RUnlock()
+	argsForCall := fake.recordSecretUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSecretUsageRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordSecretUsageMutex.RLock()
+	defer fake.recordSecretUsageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretUsageRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.SecretUsageRecorder = new(FakeSecretUsageRecorder)
@@ -13,7 +13,9 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/idtoken"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
@@ -59,6 +61,8 @@ type GetDelegate interface {
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
 
+	CheckStepPolicy(string, map[string]interface{}) error
+
 	UpdateVersion(lager.Logger, atc.GetPlan, runtime.VersionResult)
 }
 
@@ -73,6 +77,7 @@ type GetStep struct {
 	resourceCacheFactory db.ResourceCacheFactory
 	strategy             worker.ContainerPlacementStrategy
 	workerPool           worker.Pool
+	idTokenIssuer        idtoken.Issuer
 	delegateFactory      GetDelegateFactory
 }
 
@@ -86,6 +91,7 @@ func NewGetStep(
 	strategy worker.ContainerPlacementStrategy,
 	delegateFactory GetDelegateFactory,
 	pool worker.Pool,
+	idTokenIssuer idtoken.Issuer,
 ) Step {
 	return &GetStep{
 		planID:               planID,
@@ -97,6 +103,7 @@ func NewGetStep(
 		strategy:             strategy,
 		delegateFactory:      delegateFactory,
 		workerPool:           pool,
+		idTokenIssuer:        idTokenIssuer,
 	}
 }
 
@@ -139,6 +146,20 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
+
+	err = delegate.CheckStepPolicy(policy.ActionRunGetStep, map[string]interface{}{
+		"step":       step.plan.Name,
+		"resource":   step.plan.Resource,
+		"type":       step.plan.Type,
+		"source":     source,
+		"params":     params,
+		"tags":       step.plan.Tags,
+		"privileged": found && resourceType.Privileged,
+	})
+	if err != nil {
+		return false, err
+	}
+
 	if found {
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
@@ -183,6 +204,11 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	err = injectIDToken(step.idTokenIssuer, step.metadata, step.plan.Name, "get", &containerSpec)
+	if err != nil {
+		return false, err
+	}
+
 	resourceCache, err := step.resourceCacheFactory.FindOrCreateResourceCache(
 		db.ForBuild(step.metadata.BuildID),
 		step.plan.Type,
@@ -12,6 +12,9 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/atc/idtoken/idtokenfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/runtime"
@@ -46,6 +49,8 @@ var _ = Describe("GetStep", func() {
 		fakeDelegate        *execfakes.FakeGetDelegate
 		fakeDelegateFactory *execfakes.FakeGetDelegateFactory
 
+		idTokenIssuer idtoken.Issuer
+
 		spanCtx context.Context
 
 		getPlan *atc.GetPlan
@@ -111,6 +116,8 @@ var _ = Describe("GetStep", func() {
 		fakeDelegateFactory = new(execfakes.FakeGetDelegateFactory)
 		fakeDelegateFactory.GetDelegateReturns(fakeDelegate)
 
+		idTokenIssuer = nil
+
 		getPlan = &atc.GetPlan{
 			Name:    "some-name",
 			Type:    "some-base-type",
@@ -165,6 +172,7 @@ var _ = Describe("GetStep", func() {
 			fakeStrategy,
 			fakeDelegateFactory,
 			fakePool,
+			idTokenIssuer,
 		)
 
 		stepOk, stepErr = getStep.Run(ctx, fakeState)
@@ -246,6 +254,43 @@ var _ = Describe("GetStep", func() {
 		})
 	})
 
+	Context("when workload identity is enabled", func() {
+		var fakeIDTokenIssuer *idtokenfakes.FakeIssuer
+
+		BeforeEach(func() {
+			fakeIDTokenIssuer = new(idtokenfakes.FakeIssuer)
+			fakeIDTokenIssuer.IssueReturns("some-id-token", nil)
+			idTokenIssuer = fakeIDTokenIssuer
+		})
+
+		It("issues a token identifying the step", func() {
+			Expect(fakeIDTokenIssuer.IssueCallCount()).To(Equal(1))
+			Expect(fakeIDTokenIssuer.IssueArgsForCall(0)).To(Equal(idtoken.Identity{
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				BuildID:      42,
+				BuildName:    "some-build",
+				StepName:     "some-name",
+				StepType:     "get",
+			}))
+		})
+
+		It("populates the CONCOURSE_ID_TOKEN env var", func() {
+			Expect(containerSpec.Env).To(ContainElement("CONCOURSE_ID_TOKEN=some-id-token"))
+		})
+
+		Context("when issuing the token fails", func() {
+			BeforeEach(func() {
+				fakeIDTokenIssuer.IssueReturns("", errors.New("nope"))
+				shouldRunGetStep = false
+			})
+
+			It("errors without running the step", func() {
+				Expect(stepErr).To(MatchError("nope"))
+			})
+		})
+	})
+
 	Context("found from local cache", func() {
 		var (
 			fakeWorker *workerfakes.FakeWorker
@@ -471,6 +516,53 @@ var _ = Describe("GetStep", func() {
 		})
 	})
 
+	Describe("policy checking", func() {
+		It("checks the resolved step against policies", func() {
+			Expect(fakeDelegate.CheckStepPolicyCallCount()).To(Equal(1))
+			action, data := fakeDelegate.CheckStepPolicyArgsForCall(0)
+			Expect(action).To(Equal(policy.ActionRunGetStep))
+			Expect(data).To(Equal(map[string]interface{}{
+				"step":       "some-name",
+				"resource":   "",
+				"type":       "some-base-type",
+				"source":     atc.Source{"some": "super-secret-source"},
+				"params":     atc.Params{"some": "super-secret-params"},
+				"tags":       atc.Tags(nil),
+				"privileged": false,
+			}))
+		})
+
+		Context("when the resource type is privileged", func() {
+			BeforeEach(func() {
+				getPlan.Type = "another-custom-type"
+			})
+
+			It("checks with privileged", func() {
+				_, data := fakeDelegate.CheckStepPolicyArgsForCall(0)
+				Expect(data["privileged"]).To(BeTrue())
+			})
+		})
+
+		Context("when the step is not allowed", func() {
+			BeforeEach(func() {
+				fakeDelegate.CheckStepPolicyReturns(policy.PolicyCheckNotPass{
+					Messages: []string{"some-reason"},
+				})
+
+				shouldRunGetStep = false
+			})
+
+			It("fails without fetching the image or running the step", func() {
+				Expect(stepErr).To(Equal(policy.PolicyCheckNotPass{
+					Messages: []string{"some-reason"},
+				}))
+				Expect(stepOk).To(BeFalse())
+				Expect(fakeDelegate.FetchImageCallCount()).To(Equal(0))
+				Expect(fakePool.SelectWorkerCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Context("when using a custom resource type", func() {
 		var fakeImageSpec worker.ImageSpec
 
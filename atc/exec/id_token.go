@@ -0,0 +1,33 @@
+package exec
+
+import (
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// injectIDToken hands the step's container a token identifying the step,
+// provided workload identity is enabled. The token is not refreshed, so a step
+// running for longer than the token's TTL is left with an expired token.
+func injectIDToken(issuer idtoken.Issuer, metadata StepMetadata, stepName string, stepType string, containerSpec *worker.ContainerSpec) error {
+	if issuer == nil {
+		return nil
+	}
+
+	token, err := issuer.Issue(idtoken.Identity{
+		TeamName:             metadata.TeamName,
+		PipelineName:         metadata.PipelineName,
+		PipelineInstanceVars: metadata.PipelineInstanceVars,
+		JobName:              metadata.JobName,
+		BuildID:              metadata.BuildID,
+		BuildName:            metadata.BuildName,
+		StepName:             stepName,
+		StepType:             stepType,
+	})
+	if err != nil {
+		return err
+	}
+
+	containerSpec.Env = append(containerSpec.Env, idtoken.Env+"="+token)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/condition"
+	"github.com/concourse/concourse/vars"
+)
+
+// IfStep only runs the nested step if its condition is true. Otherwise, the
+// nested step is marked as skipped and the IfStep succeeds without running
+// it.
+type IfStep struct {
+	step            Step
+	plan            atc.IfPlan
+	metadata        StepMetadata
+	delegateFactory IfDelegateFactory
+}
+
+// If constructs an IfStep.
+func If(step Step, plan atc.IfPlan, metadata StepMetadata, delegateFactory IfDelegateFactory) Step {
+	return &IfStep{
+		step:            step,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+	}
+}
+
+// Run evaluates the condition against the build's local vars, the build
+// metadata and the statuses of the named steps that have already run.
+//
+// Conditions are type-checked when the pipeline is set, but are checked again
+// here as the plan may not have been validated, e.g. if it was planned by an
+// older ATC. Vars are only known at runtime, so an error is also returned if
+// the condition can not be evaluated.
+func (step *IfStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("if-step", lager.Data{
+		"condition": step.plan.Condition,
+	})
+
+	cond, err := condition.Parse(step.plan.Condition)
+	if err != nil {
+		return false, err
+	}
+
+	err = cond.Check()
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := cond.Evaluate(conditionResolver{
+		state:    state,
+		metadata: step.metadata,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		logger.Debug("skipped")
+		step.delegateFactory.IfDelegate(state).Skipped(logger, step.plan.Condition)
+		return true, nil
+	}
+
+	return step.step.Run(ctx, state)
+}
+
+type conditionResolver struct {
+	state    RunState
+	metadata StepMetadata
+}
+
+func (r conditionResolver) Resolve(ref condition.Reference) (interface{}, bool, error) {
+	if len(ref.Path) == 0 {
+		return nil, false, fmt.Errorf("incomplete reference '%s'", ref)
+	}
+
+	switch ref.Root {
+	case condition.RootVars:
+		return r.state.Get(vars.Reference{
+			Source: ".",
+			Path:   ref.Path[0],
+			Fields: ref.Path[1:],
+		})
+
+	case condition.RootBuild:
+		switch ref.Path[0] {
+		case "job":
+			return r.metadata.JobName, true, nil
+		case "pipeline":
+			return r.metadata.PipelineName, true, nil
+		case "team":
+			return r.metadata.TeamName, true, nil
+		case "created_by":
+			return r.metadata.CreatedBy, true, nil
+		}
+
+	case condition.RootSteps:
+		status, found := r.state.StepStatus(ref.Path[0])
+		return string(status), found, nil
+	}
+
+	return nil, false, nil
+}
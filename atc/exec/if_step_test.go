@@ -0,0 +1,174 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("IfStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep            *execfakes.FakeStep
+		fakeDelegate        *execfakes.FakeIfDelegate
+		fakeDelegateFactory *execfakes.FakeIfDelegateFactory
+
+		plan  atc.IfPlan
+		state exec.RunState
+
+		stepMetadata = exec.StepMetadata{
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			CreatedBy:    "some-user",
+		}
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("if-step-test"))
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		fakeDelegate = new(execfakes.FakeIfDelegate)
+		fakeDelegateFactory = new(execfakes.FakeIfDelegateFactory)
+		fakeDelegateFactory.IfDelegateReturns(fakeDelegate)
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = exec.If(fakeStep, plan, stepMetadata, fakeDelegateFactory).Run(ctx, state)
+	})
+
+	Context("when the condition is true", func() {
+		BeforeEach(func() {
+			state.AddLocalVar("version", map[string]interface{}{"major": 2}, false)
+			state.StoreStepStatus("unit", atc.StatusSucceeded)
+
+			plan = atc.IfPlan{
+				Condition: `vars.version.major == 2 && steps.unit == "succeeded" && build.job == "some-job" && build.pipeline == "some-pipeline" && build.team == "some-team" && build.created_by == "some-user"`,
+			}
+		})
+
+		It("runs the step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			_, runState := fakeStep.RunArgsForCall(0)
+			Expect(runState).To(Equal(state))
+		})
+
+		It("does not mark the step as skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition is false", func() {
+		BeforeEach(func() {
+			plan = atc.IfPlan{
+				Condition: "steps.unit == 'failed'",
+			}
+		})
+
+		It("does not run the step", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+
+		It("marks the step as skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			_, condition := fakeDelegate.SkippedArgsForCall(0)
+			Expect(condition).To(Equal("steps.unit == 'failed'"))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when the condition refers to an undefined var", func() {
+		BeforeEach(func() {
+			plan = atc.IfPlan{
+				Condition: "vars.missing",
+			}
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(MatchError("evaluate condition 'vars.missing': undefined var 'missing'"))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the condition is invalid", func() {
+		BeforeEach(func() {
+			plan = atc.IfPlan{
+				Condition: "(",
+			}
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+
+	for _, cond := range []string{"build", "vars", "steps", "'not a boolean'"} {
+		cond := cond
+
+		Context("when the condition does not type-check: "+cond, func() {
+			BeforeEach(func() {
+				plan = atc.IfPlan{
+					Condition: cond,
+				}
+			})
+
+			It("errors without running the step", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("invalid condition '" + cond + "'")))
+				Expect(fakeStep.RunCallCount()).To(BeZero())
+				Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+			})
+		})
+	}
+})
@@ -10,6 +10,8 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
@@ -39,6 +41,8 @@ type PutDelegate interface {
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
 
+	CheckStepPolicy(string, map[string]interface{}) error
+
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
 }
 
@@ -54,6 +58,7 @@ type PutStep struct {
 	strategy              worker.ContainerPlacementStrategy
 	workerPool            worker.Pool
 	artifactSourcer       worker.ArtifactSourcer
+	idTokenIssuer         idtoken.Issuer
 	delegateFactory       PutDelegateFactory
 }
 
@@ -67,6 +72,7 @@ func NewPutStep(
 	strategy worker.ContainerPlacementStrategy,
 	workerPool worker.Pool,
 	artifactSourcer worker.ArtifactSourcer,
+	idTokenIssuer idtoken.Issuer,
 	delegateFactory PutDelegateFactory,
 ) Step {
 	return &PutStep{
@@ -78,6 +84,7 @@ func NewPutStep(
 		resourceConfigFactory: resourceConfigFactory,
 		workerPool:            workerPool,
 		artifactSourcer:       artifactSourcer,
+		idTokenIssuer:         idTokenIssuer,
 		strategy:              strategy,
 		delegateFactory:       delegateFactory,
 	}
@@ -161,6 +168,20 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
+
+	err = delegate.CheckStepPolicy(policy.ActionRunPutStep, map[string]interface{}{
+		"step":       step.plan.Name,
+		"resource":   step.plan.Resource,
+		"type":       step.plan.Type,
+		"source":     source,
+		"params":     params,
+		"tags":       step.plan.Tags,
+		"privileged": found && resourceType.Privileged,
+	})
+	if err != nil {
+		return false, err
+	}
+
 	if found {
 		image := atc.ImageResource{
 			Name:    resourceType.Name,
@@ -198,6 +219,11 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	err = injectIDToken(step.idTokenIssuer, step.metadata, step.plan.Name, "put", &containerSpec)
+	if err != nil {
+		return false, err
+	}
+
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
 
 	containerSpec.BindMounts = []worker.BindMountSource{
@@ -19,6 +19,9 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/atc/idtoken/idtokenfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/runtime"
@@ -44,6 +47,8 @@ var _ = Describe("PutStep", func() {
 		fakeDelegate              *execfakes.FakePutDelegate
 		fakeDelegateFactory       *execfakes.FakePutDelegateFactory
 
+		idTokenIssuer idtoken.Issuer
+
 		expectedInputs []worker.InputSource
 
 		spanCtx context.Context
@@ -116,6 +121,8 @@ var _ = Describe("PutStep", func() {
 		fakeDelegateFactory = new(execfakes.FakePutDelegateFactory)
 		fakeDelegateFactory.PutDelegateReturns(fakeDelegate)
 
+		idTokenIssuer = nil
+
 		spanCtx = context.Background()
 		fakeDelegate.StartSpanReturns(spanCtx, tracing.NoopSpan)
 
@@ -228,6 +235,7 @@ var _ = Describe("PutStep", func() {
 			fakeStrategy,
 			fakePool,
 			fakeArtifactSourcer,
+			idTokenIssuer,
 			fakeDelegateFactory,
 		)
 
@@ -451,6 +459,42 @@ var _ = Describe("PutStep", func() {
 		Expect(runResource).To(Equal(fakeResource))
 	})
 
+	Describe("policy checking", func() {
+		It("checks the resolved step against policies", func() {
+			Expect(fakeDelegate.CheckStepPolicyCallCount()).To(Equal(1))
+			action, data := fakeDelegate.CheckStepPolicyArgsForCall(0)
+			Expect(action).To(Equal(policy.ActionRunPutStep))
+			Expect(data).To(Equal(map[string]interface{}{
+				"step":       "some-name",
+				"resource":   "some-resource",
+				"type":       "some-resource-type",
+				"source":     atc.Source{"some": "super-secret-source"},
+				"params":     atc.Params{"some": "super-secret-params"},
+				"tags":       atc.Tags(nil),
+				"privileged": false,
+			}))
+		})
+
+		Context("when the step is not allowed", func() {
+			BeforeEach(func() {
+				fakeDelegate.CheckStepPolicyReturns(policy.PolicyCheckNotPass{
+					Messages: []string{"some-reason"},
+				})
+
+				shouldRunPutStep = false
+			})
+
+			It("fails without fetching the image or running the step", func() {
+				Expect(stepErr).To(Equal(policy.PolicyCheckNotPass{
+					Messages: []string{"some-reason"},
+				}))
+				Expect(stepOk).To(BeFalse())
+				Expect(fakeDelegate.FetchImageCallCount()).To(Equal(0))
+				Expect(fakePool.SelectWorkerCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Context("when using a custom resource type", func() {
 		var fakeImageSpec worker.ImageSpec
 
@@ -632,6 +676,43 @@ var _ = Describe("PutStep", func() {
 		})
 	})
 
+	Context("when workload identity is enabled", func() {
+		var fakeIDTokenIssuer *idtokenfakes.FakeIssuer
+
+		BeforeEach(func() {
+			fakeIDTokenIssuer = new(idtokenfakes.FakeIssuer)
+			fakeIDTokenIssuer.IssueReturns("some-id-token", nil)
+			idTokenIssuer = fakeIDTokenIssuer
+		})
+
+		It("issues a token identifying the step", func() {
+			Expect(fakeIDTokenIssuer.IssueCallCount()).To(Equal(1))
+			Expect(fakeIDTokenIssuer.IssueArgsForCall(0)).To(Equal(idtoken.Identity{
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				BuildID:      42,
+				BuildName:    "some-build",
+				StepName:     "some-name",
+				StepType:     "put",
+			}))
+		})
+
+		It("populates the CONCOURSE_ID_TOKEN env var", func() {
+			Expect(containerSpec.Env).To(ContainElement("CONCOURSE_ID_TOKEN=some-id-token"))
+		})
+
+		Context("when issuing the token fails", func() {
+			BeforeEach(func() {
+				fakeIDTokenIssuer.IssueReturns("", errors.New("nope"))
+				shouldRunPutStep = false
+			})
+
+			It("errors without running the step", func() {
+				Expect(stepErr).To(MatchError("nope"))
+			})
+		})
+	})
+
 	Context("when creds tracker can initialize the resource", func() {
 		var (
 			fakeResourceConfig *dbfakes.FakeResourceConfig
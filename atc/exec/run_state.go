@@ -15,8 +15,9 @@ type runState struct {
 
 	vars *buildVariables
 
-	artifacts *build.Repository
-	results   *sync.Map
+	artifacts    *build.Repository
+	results      *sync.Map
+	stepStatuses *sync.Map
 
 	parent RunState
 }
@@ -33,8 +34,9 @@ func NewRunState(
 
 		vars: newBuildVariables(credVars, enableRedaction),
 
-		artifacts: build.NewRepository(),
-		results:   &sync.Map{},
+		artifacts:    build.NewRepository(),
+		results:      &sync.Map{},
+		stepStatuses: &sync.Map{},
 	}
 }
 
@@ -60,6 +62,21 @@ func (state *runState) StoreResult(id atc.PlanID, val interface{}) {
 	state.results.Store(id, val)
 }
 
+// StepStatus returns the status of the last run of the named step, which is
+// shared by all scopes of the build.
+func (state *runState) StepStatus(name string) (atc.BuildStatus, bool) {
+	val, ok := state.stepStatuses.Load(name)
+	if !ok {
+		return "", false
+	}
+
+	return val.(atc.BuildStatus), true
+}
+
+func (state *runState) StoreStepStatus(name string, status atc.BuildStatus) {
+	state.stepStatuses.Store(name, status)
+}
+
 func (state *runState) Get(ref vars.Reference) (interface{}, bool, error) {
 	return state.vars.Get(ref)
 }
@@ -164,6 +164,45 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("StepStatus", func() {
+		Context("when no status has been stored for the step", func() {
+			It("returns false", func() {
+				_, found := state.StepStatus("some-step")
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a status has been stored for the step", func() {
+			BeforeEach(func() {
+				state.StoreStepStatus("some-step", atc.StatusFailed)
+			})
+
+			It("returns the status", func() {
+				status, found := state.StepStatus("some-step")
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(atc.StatusFailed))
+			})
+
+			It("is visible from local scopes", func() {
+				status, found := state.NewLocalScope().StepStatus("some-step")
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(atc.StatusFailed))
+			})
+		})
+
+		Context("when a status is stored in a local scope", func() {
+			BeforeEach(func() {
+				state.NewLocalScope().StoreStepStatus("some-step", atc.StatusSucceeded)
+			})
+
+			It("is visible from the parent scope", func() {
+				status, found := state.StepStatus("some-step")
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(atc.StatusSucceeded))
+			})
+		})
+	})
+
 	Describe("Get", func() {
 		BeforeEach(func() {
 			state = exec.NewRunState(stepper, credVars, false)
@@ -2,60 +2,341 @@ package exec
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"path"
+	"path/filepath"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// RunStep will run a message against a prototype.
-type RunStep struct {
-	planID          atc.PlanID
-	plan            atc.RunPlan
-	delegateFactory RunDelegateFactory
-}
+// The directory in a prototype's image containing an executable for each
+// message it understands.
+const prototypeMessagesDir = "/usr/bin"
 
+//counterfeiter:generate . RunDelegateFactory
 type RunDelegateFactory interface {
 	RunDelegate(state RunState) RunDelegate
 }
 
+//counterfeiter:generate . RunDelegate
 type RunDelegate interface {
+	StartSpan(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
+
+	FetchImage(context.Context, atc.ImageResource, atc.VersionedResourceTypes, bool) (worker.ImageSpec, error)
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
 	Finished(lager.Logger, bool)
+	Errored(lager.Logger, string)
+
+	WaitingForWorker(lager.Logger)
+	SelectedWorker(lager.Logger, string)
+
+	CheckStepPolicy(string, map[string]interface{}) error
+}
+
+// RunStep will run a message against a prototype.
+type RunStep struct {
+	planID            atc.PlanID
+	plan              atc.RunPlan
+	metadata          StepMetadata
+	containerMetadata db.ContainerMetadata
+	strategy          worker.ContainerPlacementStrategy
+	workerPool        worker.Pool
+	artifactSourcer   worker.ArtifactSourcer
+	delegateFactory   RunDelegateFactory
 }
 
 func NewRunStep(
 	planID atc.PlanID,
 	plan atc.RunPlan,
+	metadata StepMetadata,
+	containerMetadata db.ContainerMetadata,
+	strategy worker.ContainerPlacementStrategy,
+	workerPool worker.Pool,
+	artifactSourcer worker.ArtifactSourcer,
 	delegateFactory RunDelegateFactory,
 ) Step {
 	return &RunStep{
-		planID:          planID,
-		plan:            plan,
-		delegateFactory: delegateFactory,
+		planID:            planID,
+		plan:              plan,
+		metadata:          metadata,
+		containerMetadata: containerMetadata,
+		strategy:          strategy,
+		workerPool:        workerPool,
+		artifactSourcer:   artifactSourcer,
+		delegateFactory:   delegateFactory,
 	}
 }
 
+// Run executes the message's executable in a container using the prototype's
+// image, or the image artifact if one is configured. The request, containing
+// the object, is passed to the executable as JSON in its first argument.
+//
+// The inputs are fetched from the artifact.Repository and mounted in the
+// working directory under their names. If any inputs are not available,
+// MissingInputsError is returned.
+//
+// If the message is run successfully, the directories for each of the outputs
+// are registered with the artifact.Repository.
 func (step *RunStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.RunDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "run", tracing.Attrs{
+		"message": step.plan.Message,
+		"type":    step.plan.Type,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *RunStep) run(ctx context.Context, state RunState, delegate RunDelegate) (bool, error) {
 	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("run-step", lager.Data{
+		"message": step.plan.Message,
+		"type":    step.plan.Type,
+	})
 
-	delegate := step.delegateFactory.RunDelegate(state)
 	delegate.Initializing(logger)
 
-	stderr := delegate.Stderr()
-	fmt.Fprint(stderr, "\x1b[1;33mthe run step is not yet implemented\x1b[0m\n\n")
+	err := delegate.CheckStepPolicy(policy.ActionRunRunStep, map[string]interface{}{
+		"message":    step.plan.Message,
+		"type":       step.plan.Type,
+		"object":     step.plan.Object,
+		"privileged": step.plan.Privileged,
+		"tags":       step.plan.Tags,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	imageSpec, err := step.imageSpec(ctx, logger, state, delegate)
+	if err != nil {
+		return false, err
+	}
+
+	repository := state.ArtifactRepository()
+
+	containerSpec, err := step.containerSpec(logger, repository, imageSpec)
+	if err != nil {
+		return false, err
+	}
+	tracing.Inject(ctx, &containerSpec)
+
+	request, err := json.Marshal(runRequest{Object: step.plan.Object})
+	if err != nil {
+		return false, fmt.Errorf("marshal request: %w", err)
+	}
+
+	processSpec := runtime.ProcessSpec{
+		Path:         path.Join(prototypeMessagesDir, step.plan.Message),
+		Args:         []string{string(request)},
+		StdoutWriter: delegate.Stdout(),
+		StderrWriter: delegate.Stderr(),
+	}
+
+	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
+
+	workerSpec := worker.WorkerSpec{
+		Tags:   step.plan.Tags,
+		TeamID: step.metadata.TeamID,
+	}
+
+	chosenWorker, _, err := step.workerPool.SelectWorker(
+		lagerctx.NewContext(ctx, logger),
+		owner,
+		containerSpec,
+		workerSpec,
+		step.strategy,
+		delegate,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	delegate.SelectedWorker(logger, chosenWorker.Name())
+
+	defer func() {
+		step.workerPool.ReleaseWorker(
+			lagerctx.NewContext(ctx, logger),
+			containerSpec,
+			chosenWorker,
+			step.strategy,
+		)
+	}()
+
+	processCtx := ctx
+	if step.plan.Timeout != "" {
+		timeout, err := time.ParseDuration(step.plan.Timeout)
+		if err != nil {
+			return false, fmt.Errorf("parse timeout: %w", err)
+		}
+
+		var cancel func()
+		processCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result, runErr := chosenWorker.RunTaskStep(
+		lagerctx.NewContext(processCtx, logger),
+		owner,
+		containerSpec,
+		step.containerMetadata,
+		processSpec,
+		delegate,
+	)
+
+	step.registerOutputs(logger, repository, result.VolumeMounts)
+
+	if runErr != nil {
+		if errors.Is(runErr, context.DeadlineExceeded) {
+			delegate.Errored(logger, TimeoutLogMessage)
+			return false, nil
+		}
 
-	delegate.Starting(logger)
+		return false, runErr
+	}
+
+	succeeded := result.ExitStatus == 0
+
+	delegate.Finished(logger, succeeded)
+
+	return succeeded, nil
+}
+
+type runRequest struct {
+	Object atc.Params `json:"object"`
+}
+
+func (step *RunStep) imageSpec(ctx context.Context, logger lager.Logger, state RunState, delegate RunDelegate) (worker.ImageSpec, error) {
+	if step.plan.ImageArtifactName != "" {
+		art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(step.plan.ImageArtifactName))
+		if !found {
+			return worker.ImageSpec{}, MissingTaskImageSourceError{step.plan.ImageArtifactName}
+		}
+
+		source, err := step.artifactSourcer.SourceImage(logger, art)
+		if err != nil {
+			return worker.ImageSpec{}, err
+		}
+
+		return worker.ImageSpec{
+			ImageArtifactSource: source,
+			Privileged:          step.plan.Privileged,
+		}, nil
+	}
+
+	image := step.plan.Image
+	if len(image.Tags) == 0 {
+		image.Tags = step.plan.Tags
+	}
+
+	return delegate.FetchImage(
+		ctx,
+		image,
+		step.plan.VersionedResourceTypes,
+		step.plan.Privileged,
+	)
+}
+
+func (step *RunStep) containerSpec(logger lager.Logger, repository *build.Repository, imageSpec worker.ImageSpec) (worker.ContainerSpec, error) {
+	var limits worker.ContainerLimits
+	if step.plan.Limits != nil {
+		limits.CPU = (*uint64)(step.plan.Limits.CPU)
+		limits.Memory = (*uint64)(step.plan.Limits.Memory)
+	}
+
+	containerSpec := worker.ContainerSpec{
+		ImageSpec: imageSpec,
+		TeamID:    step.metadata.TeamID,
+		TeamName:  step.metadata.TeamName,
+		Type:      step.containerMetadata.Type,
+
+		Dir:    step.containerMetadata.WorkingDirectory,
+		Limits: limits,
+
+		Outputs: worker.OutputPaths{},
+	}
+
+	var err error
+	containerSpec.Inputs, err = step.containerInputs(logger, repository)
+	if err != nil {
+		return worker.ContainerSpec{}, err
+	}
+
+	for _, output := range step.plan.Outputs {
+		containerSpec.Outputs[output] = step.outputPath(output)
+	}
+
+	return containerSpec, nil
+}
+
+func (step *RunStep) containerInputs(logger lager.Logger, repository *build.Repository) ([]worker.InputSource, error) {
+	inputs := map[string]runtime.Artifact{}
+
+	var missingInputs []string
+	for _, input := range step.plan.Inputs {
+		artifactName := input
+		if sourceName, ok := step.plan.InputMapping[input]; ok {
+			artifactName = sourceName
+		}
+
+		art, found := repository.ArtifactFor(build.ArtifactName(artifactName))
+		if !found {
+			missingInputs = append(missingInputs, artifactName)
+			continue
+		}
 
-	fmt.Fprintf(stderr, "\x1b[1;34mpretending to run %s on prototype %s...\x1b[0m\n", step.plan.Message, step.plan.Type)
+		inputs[filepath.Join(step.containerMetadata.WorkingDirectory, input)] = art
+	}
+
+	if len(missingInputs) > 0 {
+		return nil, MissingInputsError{missingInputs}
+	}
+
+	return step.artifactSourcer.SourceInputsAndCaches(logger, step.metadata.TeamID, inputs)
+}
 
-	delegate.Finished(logger, true)
+func (step *RunStep) registerOutputs(logger lager.Logger, repository *build.Repository, volumeMounts []worker.VolumeMount) {
+	logger.Debug("registering-outputs", lager.Data{"outputs": step.plan.Outputs})
+
+	for _, output := range step.plan.Outputs {
+		outputName := output
+		if destinationName, ok := step.plan.OutputMapping[output]; ok {
+			outputName = destinationName
+		}
+
+		outputPath := step.outputPath(output)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				art := &runtime.TaskArtifact{
+					VolumeHandle: mount.Volume.Handle(),
+				}
+				repository.RegisterArtifact(build.ArtifactName(outputName), art)
+			}
+		}
+	}
+}
 
-	return true, nil
+func (step *RunStep) outputPath(output string) string {
+	return path.Join(step.containerMetadata.WorkingDirectory, output) + "/"
 }
@@ -0,0 +1,401 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimefakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("RunStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+
+		fakePool            *workerfakes.FakePool
+		fakeClient          *workerfakes.FakeClient
+		fakeArtifactSourcer *workerfakes.FakeArtifactSourcer
+		fakeStrategy        *workerfakes.FakeContainerPlacementStrategy
+
+		fakeDelegate        *execfakes.FakeRunDelegate
+		fakeDelegateFactory *execfakes.FakeRunDelegateFactory
+
+		runPlan atc.RunPlan
+
+		repo  *build.Repository
+		state *execfakes.FakeRunState
+
+		stepOk  bool
+		stepErr error
+
+		containerMetadata = db.ContainerMetadata{
+			WorkingDirectory: "/tmp/build/run",
+			Type:             db.ContainerTypeRun,
+			StepName:         "some-message",
+		}
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:   123,
+			TeamName: "some-team",
+			BuildID:  1234,
+			JobID:    12345,
+		}
+
+		planID = atc.PlanID("42")
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("run-step-test"))
+
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+
+		fakeClient = new(workerfakes.FakeClient)
+		fakeClient.NameReturns("some-worker")
+		fakePool = new(workerfakes.FakePool)
+		fakePool.SelectWorkerReturns(fakeClient, 0, nil)
+
+		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
+		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+
+		fakeDelegate = new(execfakes.FakeRunDelegate)
+		fakeDelegate.StdoutReturns(stdoutBuf)
+		fakeDelegate.StderrReturns(stderrBuf)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+		fakeDelegate.FetchImageReturns(worker.ImageSpec{ImageURL: "some-fetched-image"}, nil)
+
+		fakeDelegateFactory = new(execfakes.FakeRunDelegateFactory)
+		fakeDelegateFactory.RunDelegateReturns(fakeDelegate)
+
+		repo = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(repo)
+
+		runPlan = atc.RunPlan{
+			Message: "some-message",
+			Type:    "some-prototype",
+			Object:  atc.Params{"some": "object"},
+			Tags:    atc.Tags{"some-tag"},
+			Image: atc.ImageResource{
+				Name:   "some-prototype",
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some-prototype"},
+			},
+			VersionedResourceTypes: atc.VersionedResourceTypes{
+				{
+					ResourceType: atc.ResourceType{
+						Name: "some-custom-type",
+						Type: "registry-image",
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewRunStep(
+			planID,
+			runPlan,
+			stepMetadata,
+			containerMetadata,
+			fakeStrategy,
+			fakePool,
+			fakeArtifactSourcer,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("fetches the prototype's image", func() {
+		Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+		_, image, types, privileged := fakeDelegate.FetchImageArgsForCall(0)
+		Expect(image).To(Equal(atc.ImageResource{
+			Name:   "some-prototype",
+			Type:   "registry-image",
+			Source: atc.Source{"repository": "some-prototype"},
+			Tags:   atc.Tags{"some-tag"},
+		}))
+		Expect(types).To(Equal(runPlan.VersionedResourceTypes))
+		Expect(privileged).To(BeFalse())
+	})
+
+	It("runs the message's executable with the object", func() {
+		Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+		_, owner, containerSpec, metadata, processSpec, _ := fakeClient.RunTaskStepArgsForCall(0)
+		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(1234, planID, 123)))
+		Expect(metadata).To(Equal(containerMetadata))
+		Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{ImageURL: "some-fetched-image"}))
+		Expect(containerSpec.Dir).To(Equal("/tmp/build/run"))
+		Expect(containerSpec.TeamID).To(Equal(123))
+		Expect(processSpec.Path).To(Equal("/usr/bin/some-message"))
+		Expect(processSpec.Args).To(Equal([]string{`{"object":{"some":"object"}}`}))
+		Expect(processSpec.StdoutWriter).To(Equal(stdoutBuf))
+		Expect(processSpec.StderrWriter).To(Equal(stderrBuf))
+	})
+
+	It("selects a worker with the plan's tags", func() {
+		Expect(fakePool.SelectWorkerCallCount()).To(Equal(1))
+		_, _, _, workerSpec, strategy, _ := fakePool.SelectWorkerArgsForCall(0)
+		Expect(workerSpec).To(Equal(worker.WorkerSpec{
+			Tags:   atc.Tags{"some-tag"},
+			TeamID: 123,
+		}))
+		Expect(strategy).To(Equal(fakeStrategy))
+
+		Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(1))
+		_, workerName := fakeDelegate.SelectedWorkerArgsForCall(0)
+		Expect(workerName).To(Equal("some-worker"))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+
+	It("checks the message against policies", func() {
+		Expect(fakeDelegate.CheckStepPolicyCallCount()).To(Equal(1))
+		action, data := fakeDelegate.CheckStepPolicyArgsForCall(0)
+		Expect(action).To(Equal(policy.ActionRunRunStep))
+		Expect(data).To(Equal(map[string]interface{}{
+			"message":    "some-message",
+			"type":       "some-prototype",
+			"object":     atc.Params{"some": "object"},
+			"privileged": false,
+			"tags":       atc.Tags{"some-tag"},
+		}))
+	})
+
+	Context("when the message is not allowed", func() {
+		BeforeEach(func() {
+			fakeDelegate.CheckStepPolicyReturns(policy.PolicyCheckNotPass{
+				Messages: []string{"some-reason"},
+			})
+		})
+
+		It("fails without fetching the image or running the message", func() {
+			Expect(stepErr).To(Equal(policy.PolicyCheckNotPass{
+				Messages: []string{"some-reason"},
+			}))
+			Expect(stepOk).To(BeFalse())
+			Expect(fakeDelegate.FetchImageCallCount()).To(BeZero())
+			Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the message exits with a nonzero status", func() {
+		BeforeEach(func() {
+			fakeClient.RunTaskStepReturns(worker.TaskResult{ExitStatus: 1}, nil)
+		})
+
+		It("fails without erroring", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when running the message fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeClient.RunTaskStepReturns(worker.TaskResult{}, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the plan has inputs", func() {
+		var (
+			inputArtifact    *runtimefakes.FakeArtifact
+			remappedArtifact *runtimefakes.FakeArtifact
+			inputSources     []worker.InputSource
+		)
+
+		BeforeEach(func() {
+			runPlan.Inputs = []string{"some-input", "other-input"}
+			runPlan.InputMapping = map[string]string{"other-input": "some-artifact"}
+
+			inputArtifact = new(runtimefakes.FakeArtifact)
+			remappedArtifact = new(runtimefakes.FakeArtifact)
+
+			inputSources = []worker.InputSource{new(workerfakes.FakeInputSource)}
+			fakeArtifactSourcer.SourceInputsAndCachesReturns(inputSources, nil)
+		})
+
+		Context("when all inputs are present", func() {
+			BeforeEach(func() {
+				repo.RegisterArtifact("some-input", inputArtifact)
+				repo.RegisterArtifact("some-artifact", remappedArtifact)
+			})
+
+			It("mounts them in the working directory", func() {
+				Expect(fakeArtifactSourcer.SourceInputsAndCachesCallCount()).To(Equal(1))
+				_, teamID, inputMap := fakeArtifactSourcer.SourceInputsAndCachesArgsForCall(0)
+				Expect(teamID).To(Equal(123))
+				Expect(inputMap).To(Equal(map[string]runtime.Artifact{
+					"/tmp/build/run/some-input":  inputArtifact,
+					"/tmp/build/run/other-input": remappedArtifact,
+				}))
+
+				_, _, containerSpec, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Inputs).To(Equal(inputSources))
+			})
+		})
+
+		Context("when any of the inputs are missing", func() {
+			BeforeEach(func() {
+				repo.RegisterArtifact("some-input", inputArtifact)
+			})
+
+			It("returns a MissingInputsError", func() {
+				Expect(stepErr).To(Equal(exec.MissingInputsError{Inputs: []string{"some-artifact"}}))
+				Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the plan has outputs", func() {
+		var fakeVolume1, fakeVolume2 *workerfakes.FakeVolume
+
+		BeforeEach(func() {
+			runPlan.Outputs = []string{"some-output", "other-output"}
+			runPlan.OutputMapping = map[string]string{"other-output": "some-artifact"}
+
+			fakeVolume1 = new(workerfakes.FakeVolume)
+			fakeVolume1.HandleReturns("some-handle-1")
+			fakeVolume2 = new(workerfakes.FakeVolume)
+			fakeVolume2.HandleReturns("some-handle-2")
+
+			fakeClient.RunTaskStepReturns(worker.TaskResult{
+				ExitStatus: 0,
+				VolumeMounts: []worker.VolumeMount{
+					{Volume: fakeVolume1, MountPath: "/tmp/build/run/some-output/"},
+					{Volume: fakeVolume2, MountPath: "/tmp/build/run/other-output/"},
+				},
+			}, nil)
+		})
+
+		It("configures output paths in the working directory", func() {
+			_, _, containerSpec, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+			Expect(containerSpec.Outputs).To(Equal(worker.OutputPaths{
+				"some-output":  "/tmp/build/run/some-output/",
+				"other-output": "/tmp/build/run/other-output/",
+			}))
+		})
+
+		It("registers the outputs as artifacts, honoring the output mapping", func() {
+			Expect(repo.AsMap()).To(Equal(map[build.ArtifactName]runtime.Artifact{
+				"some-output":   &runtime.TaskArtifact{VolumeHandle: "some-handle-1"},
+				"some-artifact": &runtime.TaskArtifact{VolumeHandle: "some-handle-2"},
+			}))
+		})
+	})
+
+	Context("when the plan has an image artifact", func() {
+		BeforeEach(func() {
+			runPlan.ImageArtifactName = "some-image-artifact"
+			runPlan.Privileged = true
+		})
+
+		Context("when the image artifact is registered in the artifact repo", func() {
+			var imageArtifact *runtimefakes.FakeArtifact
+			var source *workerfakes.FakeStreamableArtifactSource
+
+			BeforeEach(func() {
+				imageArtifact = new(runtimefakes.FakeArtifact)
+				repo.RegisterArtifact("some-image-artifact", imageArtifact)
+
+				source = new(workerfakes.FakeStreamableArtifactSource)
+				fakeArtifactSourcer.SourceImageReturns(source, nil)
+			})
+
+			It("uses it instead of fetching the prototype's image", func() {
+				Expect(fakeDelegate.FetchImageCallCount()).To(BeZero())
+
+				Expect(fakeArtifactSourcer.SourceImageCallCount()).To(Equal(1))
+				_, artifact := fakeArtifactSourcer.SourceImageArgsForCall(0)
+				Expect(artifact).To(Equal(imageArtifact))
+
+				_, _, containerSpec, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
+					ImageArtifactSource: source,
+					Privileged:          true,
+				}))
+			})
+		})
+
+		Context("when the image artifact is missing", func() {
+			It("returns a MissingTaskImageSourceError", func() {
+				Expect(stepErr).To(Equal(exec.MissingTaskImageSourceError{SourceName: "some-image-artifact"}))
+				Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when fetching the image fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.FetchImageReturns(worker.ImageSpec{}, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the process times out", func() {
+		BeforeEach(func() {
+			runPlan.Timeout = "1ms"
+
+			fakeClient.RunTaskStepStub = func(ctx context.Context, _ db.ContainerOwner, _ worker.ContainerSpec, _ db.ContainerMetadata, _ runtime.ProcessSpec, _ runtime.StartingEventDelegate) (worker.TaskResult, error) {
+				<-ctx.Done()
+				return worker.TaskResult{}, ctx.Err()
+			}
+		})
+
+		It("errors the step without failing the build", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+			_, message := fakeDelegate.ErroredArgsForCall(0)
+			Expect(message).To(Equal(exec.TimeoutLogMessage))
+		})
+	})
+})
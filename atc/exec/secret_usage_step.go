@@ -0,0 +1,55 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/vars"
+)
+
+//counterfeiter:generate . SecretUsageRecorder
+type SecretUsageRecorder interface {
+	// RecordSecretUsage is called whenever the named step reads a var which
+	// isn't local to the build.
+	RecordSecretUsage(step string, ref vars.Reference)
+}
+
+// SecretUsageStep reports every var the step it wraps reads to a recorder,
+// so that the secrets read by a build can be traced back to its steps.
+type SecretUsageStep struct {
+	Step
+
+	name     string
+	recorder SecretUsageRecorder
+}
+
+func RecordSecretUsage(step Step, name string, recorder SecretUsageRecorder) Step {
+	return SecretUsageStep{
+		Step:     step,
+		name:     name,
+		recorder: recorder,
+	}
+}
+
+func (step SecretUsageStep) Run(ctx context.Context, state RunState) (bool, error) {
+	return step.Step.Run(ctx, secretUsageState{
+		RunState: state,
+		step:     step.name,
+		recorder: step.recorder,
+	})
+}
+
+type secretUsageState struct {
+	RunState
+
+	step     string
+	recorder SecretUsageRecorder
+}
+
+func (state secretUsageState) Get(ref vars.Reference) (interface{}, bool, error) {
+	val, found, err := state.RunState.Get(ref)
+	if found && ref.Source != "." {
+		state.recorder.RecordSecretUsage(state.step, ref)
+	}
+
+	return val, found, err
+}
@@ -0,0 +1,65 @@
+package exec_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("SecretUsageStep", func() {
+	var (
+		fakeStep     *execfakes.FakeStep
+		fakeRecorder *execfakes.FakeSecretUsageRecorder
+		state        exec.RunState
+	)
+
+	BeforeEach(func() {
+		fakeStep = new(execfakes.FakeStep)
+		fakeRecorder = new(execfakes.FakeSecretUsageRecorder)
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{"some-secret": map[string]interface{}{"some-field": "some-value"}}, false)
+		state.AddLocalVar("some-local", "local-value", false)
+	})
+
+	readVars := func(refs ...vars.Reference) {
+		fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+			for _, ref := range refs {
+				_, _, err := state.Get(ref)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			return true, nil
+		}
+
+		ok, err := exec.RecordSecretUsage(fakeStep, "some-step", fakeRecorder).Run(context.Background(), state)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	}
+
+	It("records the vars read by the step", func() {
+		readVars(vars.Reference{Path: "some-secret", Fields: []string{"some-field"}})
+
+		Expect(fakeRecorder.RecordSecretUsageCallCount()).To(Equal(1))
+
+		step, ref := fakeRecorder.RecordSecretUsageArgsForCall(0)
+		Expect(step).To(Equal("some-step"))
+		Expect(ref).To(Equal(vars.Reference{Path: "some-secret", Fields: []string{"some-field"}}))
+	})
+
+	It("does not record vars which aren't found", func() {
+		readVars(vars.Reference{Path: "bogus"})
+
+		Expect(fakeRecorder.RecordSecretUsageCallCount()).To(BeZero())
+	})
+
+	It("does not record local vars", func() {
+		readVars(vars.Reference{Source: ".", Path: "some-local"})
+
+		Expect(fakeRecorder.RecordSecretUsageCallCount()).To(BeZero())
+	})
+})
@@ -49,6 +49,9 @@ type RunState interface {
 	Result(atc.PlanID, interface{}) bool
 	StoreResult(atc.PlanID, interface{})
 
+	StepStatus(name string) (atc.BuildStatus, bool)
+	StoreStepStatus(name string, status atc.BuildStatus)
+
 	Run(context.Context, atc.Plan) (bool, error)
 
 	Parent() RunState
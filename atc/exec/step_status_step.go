@@ -0,0 +1,43 @@
+package exec
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// StepStatusStep records the status of a named step once it has run, so that
+// it can be referred to by the conditions of later steps.
+type StepStatusStep struct {
+	Step
+
+	name string
+}
+
+func RecordStepStatus(step Step, name string) Step {
+	return StepStatusStep{
+		Step: step,
+		name: name,
+	}
+}
+
+func (step StepStatusStep) Run(ctx context.Context, state RunState) (bool, error) {
+	ok, err := step.Step.Run(ctx, state)
+
+	var status atc.BuildStatus
+	switch {
+	case errors.Is(err, context.Canceled):
+		status = atc.StatusAborted
+	case err != nil:
+		status = atc.StatusErrored
+	case ok:
+		status = atc.StatusSucceeded
+	default:
+		status = atc.StatusFailed
+	}
+
+	state.StoreStepStatus(step.name, status)
+
+	return ok, err
+}
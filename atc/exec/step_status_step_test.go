@@ -0,0 +1,42 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("StepStatusStep", func() {
+	DescribeTable("records the status of the step",
+		func(ok bool, err error, expected atc.BuildStatus) {
+			fakeStep := new(execfakes.FakeStep)
+			fakeStep.RunReturns(ok, err)
+
+			state := exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+			runOk, runErr := exec.RecordStepStatus(fakeStep, "some-step").Run(context.Background(), state)
+			Expect(runOk).To(Equal(ok))
+			if err != nil {
+				Expect(runErr).To(Equal(err))
+			} else {
+				Expect(runErr).ToNot(HaveOccurred())
+			}
+
+			status, found := state.StepStatus("some-step")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(expected))
+		},
+		Entry("succeeded", true, nil, atc.StatusSucceeded),
+		Entry("failed", false, nil, atc.StatusFailed),
+		Entry("errored", false, errors.New("nope"), atc.StatusErrored),
+		Entry("aborted", false, context.Canceled, atc.StatusAborted),
+	)
+})
@@ -2,6 +2,8 @@ package exec
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,9 +14,14 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/atc/junit"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -66,6 +73,7 @@ type TaskDelegate interface {
 	Stderr() io.Writer
 
 	SetTaskConfig(config atc.TaskConfig)
+	SaveTestSuites(lager.Logger, []atc.TestSuite)
 
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
@@ -74,6 +82,8 @@ type TaskDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+
+	CheckStepPolicy(string, map[string]interface{}) error
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -88,6 +98,8 @@ type TaskStep struct {
 	workerPool        worker.Pool
 	artifactSourcer   worker.ArtifactSourcer
 	artifactStreamer  worker.ArtifactStreamer
+	taskResultFactory db.TaskResultFactory
+	idTokenIssuer     idtoken.Issuer
 	delegateFactory   TaskDelegateFactory
 }
 
@@ -101,6 +113,8 @@ func NewTaskStep(
 	workerPool worker.Pool,
 	artifactStreamer worker.ArtifactStreamer,
 	artifactSourcer worker.ArtifactSourcer,
+	taskResultFactory db.TaskResultFactory,
+	idTokenIssuer idtoken.Issuer,
 	delegateFactory TaskDelegateFactory,
 ) Step {
 	return &TaskStep{
@@ -113,6 +127,8 @@ func NewTaskStep(
 		workerPool:        workerPool,
 		artifactStreamer:  artifactStreamer,
 		artifactSourcer:   artifactSourcer,
+		taskResultFactory: taskResultFactory,
+		idTokenIssuer:     idTokenIssuer,
 		delegateFactory:   delegateFactory,
 	}
 }
@@ -132,6 +148,10 @@ func NewTaskStep(
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an StreamableArtifactSource under the
 // name of the task.
+//
+// If CacheResult is set and a previous successful run of the task had the same
+// config, image, and inputs, its outputs are registered instead of running the
+// script. Otherwise the outputs of a successful run are retained for reuse.
 func (step *TaskStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.TaskDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "task", tracing.Attrs{
@@ -224,6 +244,21 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	delegate.Initializing(logger)
 
+	err = delegate.CheckStepPolicy(policy.ActionRunTaskStep, map[string]interface{}{
+		"step":           step.plan.Name,
+		"privileged":     bool(step.plan.Privileged),
+		"tags":           step.plan.Tags,
+		"platform":       config.Platform,
+		"image":          step.plan.ImageArtifactName,
+		"image_resource": config.ImageResource,
+		"rootfs_uri":     config.RootfsURI,
+		"params":         config.Params,
+		"run":            config.Run,
+	})
+	if err != nil {
+		return false, err
+	}
+
 	imageSpec, err := step.imageSpec(ctx, logger, state, delegate, config)
 	if err != nil {
 		return false, err
@@ -235,6 +270,43 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	err = injectIDToken(step.idTokenIssuer, step.metadata, step.plan.Name, "task", &containerSpec)
+	if err != nil {
+		return false, err
+	}
+
+	var resultKey string
+	if step.plan.CacheResult && step.metadata.JobID != 0 {
+		resultKey, err = step.taskResultKey(logger, delegate, repository, imageSpec, config)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if resultKey != "" {
+		result, found, err := step.taskResultFactory.Find(step.metadata.JobID, step.plan.Name, resultKey, outputNames(config))
+		if err != nil {
+			return false, err
+		}
+
+		if found {
+			fmt.Fprintln(delegate.Stderr(), "\x1b[1;36mINFO: reusing result of a previous run with the same inputs\x1b[0m")
+			fmt.Fprintln(delegate.Stderr(), "")
+
+			delegate.Starting(logger)
+
+			step.registerTaskResultOutputs(logger, repository, config, result)
+
+			if len(config.Reports) > 0 {
+				step.saveReports(ctx, logger, delegate, repository, config)
+			}
+
+			delegate.Finished(logger, ExitStatus(result.ExitStatus), step.strategy, nil)
+
+			return result.ExitStatus == 0, nil
+		}
+	}
+
 	processSpec := runtime.ProcessSpec{
 		Path:         config.Run.Path,
 		Args:         config.Run.Args,
@@ -307,6 +379,14 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, runErr
 	}
 
+	if len(config.Reports) > 0 {
+		step.saveReports(ctx, logger, delegate, repository, config)
+	}
+
+	if resultKey != "" && result.ExitStatus == 0 {
+		step.saveTaskResult(logger, resultKey, config, result.VolumeMounts, step.containerMetadata)
+	}
+
 	delegate.Finished(logger, ExitStatus(result.ExitStatus), step.strategy, chosenWorker)
 
 	return result.ExitStatus == 0, nil
@@ -498,6 +578,200 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 	return nil
 }
 
+// saveReports parses the task's test reports and saves them against the
+// build. A missing or malformed report is reported as a warning, as it should
+// not change the outcome of the task.
+func (step *TaskStep) saveReports(ctx context.Context, logger lager.Logger, delegate TaskDelegate, repository *build.Repository, config atc.TaskConfig) {
+	var suites []atc.TestSuite
+
+	for _, report := range config.Reports {
+		reportSuites, err := step.parseReport(ctx, logger, repository, config, report)
+		if err != nil {
+			logger.Info("failed-to-parse-report", lager.Data{"report": report.Path, "error": err.Error()})
+			fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: failed to parse test report %s: %s\x1b[0m\n", report.Path, err)
+			continue
+		}
+
+		for _, suite := range reportSuites {
+			suite.StepName = step.plan.Name
+			suites = append(suites, suite)
+		}
+	}
+
+	if len(suites) > 0 {
+		delegate.SaveTestSuites(logger, suites)
+	}
+}
+
+func (step *TaskStep) parseReport(ctx context.Context, logger lager.Logger, repository *build.Repository, config atc.TaskConfig, report atc.TaskReportConfig) ([]atc.TestSuite, error) {
+	output, filePath, found := config.ReportOutput(report)
+	if !found {
+		return nil, fmt.Errorf("not in one of the task's outputs")
+	}
+
+	outputName := output.Name
+	if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+		outputName = destinationName
+	}
+
+	art, found := repository.ArtifactFor(build.ArtifactName(outputName))
+	if !found {
+		return nil, artifact.UnknownArtifactSourceError{
+			Name: outputName,
+			Path: filePath,
+		}
+	}
+
+	stream, err := step.artifactStreamer.StreamFileFromArtifact(lagerctx.NewContext(ctx, logger), art, filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, artifact.FileNotFoundError{
+				Name:     outputName,
+				FilePath: filePath,
+			}
+		}
+
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return junit.Parse(stream)
+}
+
+type taskResultKeyMaterial struct {
+	Config     atc.TaskConfig    `json:"config"`
+	Privileged bool              `json:"privileged"`
+	Image      string            `json:"image"`
+	Inputs     map[string]string `json:"inputs"`
+}
+
+// taskResultKey identifies the result of the task by everything that can
+// affect it: the config (which includes the params), the image, and the
+// content of each input. An empty key is returned if the image or any of the
+// inputs can not be identified, in which case the result is not cached.
+func (step *TaskStep) taskResultKey(logger lager.Logger, delegate TaskDelegate, repository *build.Repository, imageSpec worker.ImageSpec, config atc.TaskConfig) (string, error) {
+	material := taskResultKeyMaterial{
+		Config:     config,
+		Privileged: bool(step.plan.Privileged),
+		Image:      imageSpec.ImageURL,
+		Inputs:     map[string]string{},
+	}
+
+	if imageSpec.ImageArtifactSource != nil {
+		digest, found, err := step.taskResultFactory.ArtifactDigest(imageSpec.ImageArtifactSource.Artifact().ID())
+		if err != nil {
+			return "", err
+		}
+
+		if !found {
+			step.resultNotCached(logger, delegate, "the image")
+			return "", nil
+		}
+
+		material.Image = digest
+	}
+
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.plan.InputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		art, found := repository.ArtifactFor(build.ArtifactName(inputName))
+		if !found {
+			continue
+		}
+
+		digest, found, err := step.taskResultFactory.ArtifactDigest(art.ID())
+		if err != nil {
+			return "", err
+		}
+
+		if !found {
+			step.resultNotCached(logger, delegate, fmt.Sprintf("input '%s'", inputName))
+			return "", nil
+		}
+
+		material.Inputs[input.Name] = digest
+	}
+
+	payload, err := json.Marshal(material)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), nil
+}
+
+func (step *TaskStep) resultNotCached(logger lager.Logger, delegate TaskDelegate, source string) {
+	logger.Info("result-not-cached", lager.Data{"source": source})
+
+	fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: not caching result as the content of %s can not be identified\x1b[0m\n", source)
+	fmt.Fprintln(delegate.Stderr(), "")
+}
+
+func (step *TaskStep) registerTaskResultOutputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, result db.TaskResult) {
+	logger.Debug("registering-task-result-outputs", lager.Data{"outputs": result.OutputVolumes})
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		handle, found := result.OutputVolumes[output.Name]
+		if !found {
+			continue
+		}
+
+		repository.RegisterArtifact(build.ArtifactName(outputName), &runtime.TaskArtifact{
+			VolumeHandle: handle,
+		})
+	}
+}
+
+// saveTaskResult retains the output volumes of a successful run and records
+// the result. Failing to do so only means the task will run again next time,
+// so errors are logged rather than failing the step.
+func (step *TaskStep) saveTaskResult(logger lager.Logger, resultKey string, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) {
+	for _, output := range config.Outputs {
+		outputPath := artifactsPath(output, metadata.WorkingDirectory)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				err := mount.Volume.InitializeTaskCache(
+					logger,
+					step.metadata.JobID,
+					step.plan.Name,
+					db.TaskResultOutputPath(resultKey, output.Name),
+					bool(step.plan.Privileged),
+				)
+				if err != nil {
+					logger.Error("failed-to-retain-task-result-output", err, lager.Data{"output": output.Name})
+					return
+				}
+
+				break
+			}
+		}
+	}
+
+	err := step.taskResultFactory.Save(step.metadata.JobID, step.plan.Name, resultKey, 0)
+	if err != nil {
+		logger.Error("failed-to-save-task-result", err)
+	}
+}
+
+func outputNames(config atc.TaskConfig) []string {
+	var names []string
+	for _, output := range config.Outputs {
+		names = append(names, output.Name)
+	}
+
+	return names
+}
+
 type taskInput struct {
 	config        atc.TaskInputConfig
 	artifact      runtime.Artifact
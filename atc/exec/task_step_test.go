@@ -4,14 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/atc/idtoken/idtokenfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -40,11 +48,15 @@ var _ = Describe("TaskStep", func() {
 		fakeArtifactSourcer  *workerfakes.FakeArtifactSourcer
 		fakeStrategy         *workerfakes.FakeContainerPlacementStrategy
 
+		fakeTaskResultFactory *dbfakes.FakeTaskResultFactory
+
 		spanCtx      context.Context
 		fakeDelegate *execfakes.FakeTaskDelegate
 
 		fakeDelegateFactory *execfakes.FakeTaskDelegateFactory
 
+		idTokenIssuer idtoken.Issuer
+
 		taskPlan *atc.TaskPlan
 
 		repo       *build.Repository
@@ -89,6 +101,8 @@ var _ = Describe("TaskStep", func() {
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
+		fakeTaskResultFactory = new(dbfakes.FakeTaskResultFactory)
+
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
 		fakeDelegate.StderrReturns(stderrBuf)
@@ -99,6 +113,8 @@ var _ = Describe("TaskStep", func() {
 		fakeDelegateFactory = new(execfakes.FakeTaskDelegateFactory)
 		fakeDelegateFactory.TaskDelegateReturns(fakeDelegate)
 
+		idTokenIssuer = nil
+
 		repo = build.NewRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
@@ -145,6 +161,8 @@ var _ = Describe("TaskStep", func() {
 			fakePool,
 			fakeArtifactStreamer,
 			fakeArtifactSourcer,
+			fakeTaskResultFactory,
+			idTokenIssuer,
 			fakeDelegateFactory,
 		)
 
@@ -286,6 +304,57 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Describe("policy checking", func() {
+			It("checks the resolved task against policies", func() {
+				Expect(fakeDelegate.CheckStepPolicyCallCount()).To(Equal(1))
+				action, data := fakeDelegate.CheckStepPolicyArgsForCall(0)
+				Expect(action).To(Equal(policy.ActionRunTaskStep))
+				Expect(data).To(Equal(map[string]interface{}{
+					"step":           "some-task",
+					"privileged":     false,
+					"tags":           atc.Tags(nil),
+					"platform":       "some-platform",
+					"image":          "",
+					"image_resource": (*atc.ImageResource)(nil),
+					"rootfs_uri":     "",
+					"params":         atc.TaskEnv{"SECURE": "secret-task-param"},
+					"run": atc.TaskRunConfig{
+						Path: "ls",
+						Args: []string{"some", "args"},
+					},
+				}))
+			})
+
+			Context("when privileged", func() {
+				BeforeEach(func() {
+					taskPlan.Privileged = true
+				})
+
+				It("checks with privileged", func() {
+					_, data := fakeDelegate.CheckStepPolicyArgsForCall(0)
+					Expect(data["privileged"]).To(BeTrue())
+				})
+			})
+
+			Context("when the task is not allowed", func() {
+				BeforeEach(func() {
+					fakeDelegate.CheckStepPolicyReturns(policy.PolicyCheckNotPass{
+						Messages: []string{"some-reason"},
+					})
+
+					shouldRunTaskStep = false
+				})
+
+				It("fails without selecting a worker", func() {
+					Expect(stepErr).To(Equal(policy.PolicyCheckNotPass{
+						Messages: []string{"some-reason"},
+					}))
+					Expect(stepOk).To(BeFalse())
+					Expect(fakePool.SelectWorkerCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		It("uses the correct container limits", func() {
 			Expect(atc.CPULimit(*containerSpec.Limits.CPU)).To(Equal(atc.CPULimit(1024)))
 			Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
@@ -386,6 +455,40 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when workload identity is enabled", func() {
+			var fakeIDTokenIssuer *idtokenfakes.FakeIssuer
+
+			BeforeEach(func() {
+				fakeIDTokenIssuer = new(idtokenfakes.FakeIssuer)
+				fakeIDTokenIssuer.IssueReturns("some-id-token", nil)
+				idTokenIssuer = fakeIDTokenIssuer
+			})
+
+			It("issues a token identifying the step", func() {
+				Expect(fakeIDTokenIssuer.IssueCallCount()).To(Equal(1))
+				Expect(fakeIDTokenIssuer.IssueArgsForCall(0)).To(Equal(idtoken.Identity{
+					BuildID:  1234,
+					StepName: "some-task",
+					StepType: "task",
+				}))
+			})
+
+			It("populates the CONCOURSE_ID_TOKEN env var", func() {
+				Expect(containerSpec.Env).To(ContainElement("CONCOURSE_ID_TOKEN=some-id-token"))
+			})
+
+			Context("when issuing the token fails", func() {
+				BeforeEach(func() {
+					fakeIDTokenIssuer.IssueReturns("", errors.New("nope"))
+					shouldRunTaskStep = false
+				})
+
+				It("errors without running the step", func() {
+					Expect(stepErr).To(MatchError("nope"))
+				})
+			})
+		})
+
 		Context("when the configuration specifies paths for inputs", func() {
 			var inputArtifact *runtimefakes.FakeArtifact
 			var otherInputArtifact *runtimefakes.FakeArtifact
@@ -1268,5 +1371,392 @@ var _ = Describe("TaskStep", func() {
 				Expect(artifactMap).To(ConsistOf(artifact))
 			})
 		})
+
+		Context("when the task has reports", func() {
+			var fakeVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				taskPlan.OutputMapping = map[string]string{"test-results": "remapped-test-results"}
+				taskPlan.Config = &atc.TaskConfig{
+					Platform: "some-platform",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "test-results"},
+					},
+					Reports: []atc.TaskReportConfig{
+						{Path: "test-results/unit.xml"},
+						{Path: "test-results/integration.xml"},
+					},
+				}
+
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-handle")
+
+				fakeClient.RunTaskStepReturns(worker.TaskResult{
+					ExitStatus: 1,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeVolume,
+							MountPath: "some-artifact-root/test-results/",
+						},
+					},
+				}, nil)
+
+				fakeArtifactStreamer.StreamFileFromArtifactStub = func(_ context.Context, _ runtime.Artifact, path string) (io.ReadCloser, error) {
+					switch path {
+					case "unit.xml":
+						return ioutil.NopCloser(strings.NewReader(`<testsuite name="unit"><testcase name="fails"><failure>nope</failure></testcase></testsuite>`)), nil
+					case "integration.xml":
+						return ioutil.NopCloser(strings.NewReader(`<testsuite name="integration"><testcase name="passes"/></testsuite>`)), nil
+					default:
+						return nil, baggageclaim.ErrFileNotFound
+					}
+				}
+			})
+
+			It("reads the reports from the output", func() {
+				Expect(fakeArtifactStreamer.StreamFileFromArtifactCallCount()).To(Equal(2))
+
+				_, art, path := fakeArtifactStreamer.StreamFileFromArtifactArgsForCall(0)
+				Expect(art).To(Equal(&runtime.TaskArtifact{VolumeHandle: "some-handle"}))
+				Expect(path).To(Equal("unit.xml"))
+			})
+
+			It("saves the parsed suites via the delegate, even if the task fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+
+				Expect(fakeDelegate.SaveTestSuitesCallCount()).To(Equal(1))
+				_, suites := fakeDelegate.SaveTestSuitesArgsForCall(0)
+				Expect(suites).To(Equal([]atc.TestSuite{
+					{
+						StepName: "some-task",
+						Name:     "unit",
+						Cases: []atc.TestCase{
+							{Name: "fails", Status: atc.TestFailed, FailureMessage: "nope"},
+						},
+					},
+					{
+						StepName: "some-task",
+						Name:     "integration",
+						Cases: []atc.TestCase{
+							{Name: "passes", Status: atc.TestPassed},
+						},
+					},
+				}))
+			})
+
+			Context("when a report is missing", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Reports = append(taskPlan.Config.Reports, atc.TaskReportConfig{Path: "test-results/missing.xml"})
+				})
+
+				It("warns and saves the rest", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stderrBuf).To(gbytes.Say("WARNING: failed to parse test report test-results/missing.xml"))
+
+					Expect(fakeDelegate.SaveTestSuitesCallCount()).To(Equal(1))
+					_, suites := fakeDelegate.SaveTestSuitesArgsForCall(0)
+					Expect(suites).To(HaveLen(2))
+				})
+			})
+
+			Context("when the output has a path", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Outputs = []atc.TaskOutputConfig{
+						{Name: "test-results", Path: "build/reports"},
+					}
+					taskPlan.Config.Reports = []atc.TaskReportConfig{
+						{Path: "build/reports/unit.xml"},
+					}
+
+					fakeClient.RunTaskStepReturns(worker.TaskResult{
+						ExitStatus: 1,
+						VolumeMounts: []worker.VolumeMount{
+							{
+								Volume:    fakeVolume,
+								MountPath: "some-artifact-root/build/reports/",
+							},
+						},
+					}, nil)
+				})
+
+				It("reads the report relative to the output's path", func() {
+					Expect(fakeArtifactStreamer.StreamFileFromArtifactCallCount()).To(Equal(1))
+
+					_, art, path := fakeArtifactStreamer.StreamFileFromArtifactArgsForCall(0)
+					Expect(art).To(Equal(&runtime.TaskArtifact{VolumeHandle: "some-handle"}))
+					Expect(path).To(Equal("unit.xml"))
+
+					Expect(fakeDelegate.SaveTestSuitesCallCount()).To(Equal(1))
+					_, suites := fakeDelegate.SaveTestSuitesArgsForCall(0)
+					Expect(suites).To(HaveLen(1))
+					Expect(suites[0].Name).To(Equal("unit"))
+				})
+			})
+
+			Context("when running the task errors", func() {
+				BeforeEach(func() {
+					fakeClient.RunTaskStepReturns(worker.TaskResult{}, errors.New("nope"))
+				})
+
+				It("does not parse the reports", func() {
+					Expect(fakeArtifactStreamer.StreamFileFromArtifactCallCount()).To(BeZero())
+					Expect(fakeDelegate.SaveTestSuitesCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when cache_result is enabled", func() {
+			var (
+				fakeOutputVolume *workerfakes.FakeVolume
+				taskResult       worker.TaskResult
+			)
+
+			BeforeEach(func() {
+				stepMetadata.JobID = 12345
+
+				taskPlan.CacheResult = true
+				taskPlan.Config = &atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Inputs: []atc.TaskInputConfig{
+						{Name: "some-input"},
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output"},
+					},
+				}
+
+				repo.RegisterArtifact("some-input", &runtime.TaskArtifact{VolumeHandle: "some-input-handle"})
+
+				fakeTaskResultFactory.ArtifactDigestReturns("resource-cache:1", true, nil)
+
+				fakeOutputVolume = new(workerfakes.FakeVolume)
+				fakeOutputVolume.HandleReturns("some-output-handle")
+
+				taskResult = worker.TaskResult{
+					ExitStatus: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeOutputVolume,
+							MountPath: "some-artifact-root/some-output/",
+						},
+					},
+				}
+				fakeClient.RunTaskStepReturns(taskResult, nil)
+			})
+
+			It("identifies the content of the inputs", func() {
+				Expect(fakeTaskResultFactory.ArtifactDigestCallCount()).To(Equal(1))
+				Expect(fakeTaskResultFactory.ArtifactDigestArgsForCall(0)).To(Equal("some-input-handle"))
+			})
+
+			It("looks up a previous result for the outputs", func() {
+				Expect(fakeTaskResultFactory.FindCallCount()).To(Equal(1))
+				jobID, stepName, key, outputs := fakeTaskResultFactory.FindArgsForCall(0)
+				Expect(jobID).To(Equal(12345))
+				Expect(stepName).To(Equal("some-task"))
+				Expect(key).ToNot(BeEmpty())
+				Expect(outputs).To(Equal([]string{"some-output"}))
+			})
+
+			It("keys the result by the content of the inputs", func() {
+				_, _, key, _ := fakeTaskResultFactory.FindArgsForCall(0)
+
+				fakeTaskResultFactory.ArtifactDigestReturns("resource-cache:2", true, nil)
+
+				_, err := taskStep.Run(ctx, state)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, otherKey, _ := fakeTaskResultFactory.FindArgsForCall(1)
+				Expect(otherKey).ToNot(Equal(key))
+			})
+
+			Context("when an image artifact is used", func() {
+				BeforeEach(func() {
+					taskPlan.ImageArtifactName = "some-image-artifact"
+					repo.RegisterArtifact("some-image-artifact", &runtime.TaskArtifact{VolumeHandle: "some-image-handle"})
+
+					fakeImageSource := new(workerfakes.FakeStreamableArtifactSource)
+					fakeImageSource.ArtifactReturns(&runtime.TaskArtifact{VolumeHandle: "some-image-handle"})
+					fakeArtifactSourcer.SourceImageReturns(fakeImageSource, nil)
+				})
+
+				It("identifies the content of the image", func() {
+					Expect(fakeTaskResultFactory.ArtifactDigestCallCount()).To(Equal(2))
+					Expect(fakeTaskResultFactory.ArtifactDigestArgsForCall(0)).To(Equal("some-image-handle"))
+				})
+
+				Context("when the content of the image can not be identified", func() {
+					BeforeEach(func() {
+						fakeTaskResultFactory.ArtifactDigestReturnsOnCall(0, "", false, nil)
+					})
+
+					It("does not cache the result", func() {
+						Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+						Expect(stderrBuf).To(gbytes.Say("not caching result as the content of the image can not be identified"))
+					})
+				})
+			})
+
+			Context("when there is no previous result", func() {
+				It("retains the outputs and saves the result", func() {
+					Expect(stepOk).To(BeTrue())
+
+					_, _, key, _ := fakeTaskResultFactory.FindArgsForCall(0)
+
+					Expect(fakeOutputVolume.InitializeTaskCacheCallCount()).To(Equal(1))
+					_, jobID, stepName, path, _ := fakeOutputVolume.InitializeTaskCacheArgsForCall(0)
+					Expect(jobID).To(Equal(12345))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(path).To(Equal(db.TaskResultOutputPath(key, "some-output")))
+
+					Expect(fakeTaskResultFactory.SaveCallCount()).To(Equal(1))
+					jobID, stepName, savedKey, exitStatus := fakeTaskResultFactory.SaveArgsForCall(0)
+					Expect(jobID).To(Equal(12345))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(savedKey).To(Equal(key))
+					Expect(exitStatus).To(Equal(0))
+				})
+
+				Context("when the task exits nonzero", func() {
+					BeforeEach(func() {
+						taskResult.ExitStatus = 1
+						fakeClient.RunTaskStepReturns(taskResult, nil)
+					})
+
+					It("does not save the result", func() {
+						Expect(fakeOutputVolume.InitializeTaskCacheCallCount()).To(BeZero())
+						Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when retaining an output fails", func() {
+					BeforeEach(func() {
+						fakeOutputVolume.InitializeTaskCacheReturns(errors.New("nope"))
+					})
+
+					It("succeeds without saving the result", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stepOk).To(BeTrue())
+						Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when a previous result is found", func() {
+				BeforeEach(func() {
+					fakeTaskResultFactory.FindReturns(db.TaskResult{
+						ExitStatus:    0,
+						OutputVolumes: map[string]string{"some-output": "cached-output-handle"},
+					}, true, nil)
+
+					shouldRunTaskStep = false
+				})
+
+				It("succeeds", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+
+				It("registers the cached outputs as artifacts", func() {
+					artifact, found := repo.ArtifactFor("some-output")
+					Expect(found).To(BeTrue())
+					Expect(artifact.ID()).To(Equal("cached-output-handle"))
+				})
+
+				It("says that the result was reused", func() {
+					Expect(stderrBuf).To(gbytes.Say("reusing result of a previous run"))
+				})
+
+				It("finishes with the cached exit status", func() {
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, status, _, _ := fakeDelegate.FinishedArgsForCall(0)
+					Expect(status).To(Equal(exec.ExitStatus(0)))
+				})
+
+				It("does not select a worker", func() {
+					Expect(fakePool.SelectWorkerCallCount()).To(BeZero())
+				})
+
+				Context("when the task has reports", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Reports = []atc.TaskReportConfig{
+							{Path: "some-output/junit.xml"},
+						}
+
+						fakeArtifactStreamer.StreamFileFromArtifactReturns(
+							ioutil.NopCloser(strings.NewReader(`<testsuite name="unit"><testcase name="passes"/></testsuite>`)),
+							nil,
+						)
+					})
+
+					It("saves the reports from the cached outputs", func() {
+						Expect(fakeArtifactStreamer.StreamFileFromArtifactCallCount()).To(Equal(1))
+						_, art, path := fakeArtifactStreamer.StreamFileFromArtifactArgsForCall(0)
+						Expect(art.ID()).To(Equal("cached-output-handle"))
+						Expect(path).To(Equal("junit.xml"))
+
+						Expect(fakeDelegate.SaveTestSuitesCallCount()).To(Equal(1))
+						_, suites := fakeDelegate.SaveTestSuitesArgsForCall(0)
+						Expect(suites).To(Equal([]atc.TestSuite{
+							{
+								StepName: "some-task",
+								Name:     "unit",
+								Cases: []atc.TestCase{
+									{Name: "passes", Status: atc.TestPassed},
+								},
+							},
+						}))
+					})
+				})
+			})
+
+			Context("when looking up a previous result fails", func() {
+				BeforeEach(func() {
+					fakeTaskResultFactory.FindReturns(db.TaskResult{}, false, errors.New("nope"))
+
+					shouldRunTaskStep = false
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(MatchError("nope"))
+				})
+			})
+
+			Context("when the content of an input can not be identified", func() {
+				BeforeEach(func() {
+					fakeTaskResultFactory.ArtifactDigestReturns("", false, nil)
+				})
+
+				It("runs the task without caching the result", func() {
+					Expect(stepOk).To(BeTrue())
+					Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+					Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+				})
+
+				It("warns that the result is not cached", func() {
+					Expect(stderrBuf).To(gbytes.Say("not caching result as the content of input 'some-input' can not be identified"))
+				})
+			})
+
+			Context("when the task does not belong to a job (one-off build)", func() {
+				BeforeEach(func() {
+					stepMetadata.JobID = 0
+				})
+
+				It("does not cache the result", func() {
+					Expect(fakeTaskResultFactory.ArtifactDigestCallCount()).To(BeZero())
+					Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+					Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
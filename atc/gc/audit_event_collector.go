@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type auditEventCollector struct {
+	lifecycle db.AuditEventLifecycle
+	retention time.Duration
+}
+
+func NewAuditEventCollector(lifecycle db.AuditEventLifecycle, retention time.Duration) *auditEventCollector {
+	return &auditEventCollector{
+		lifecycle: lifecycle,
+		retention: retention,
+	}
+}
+
+func (c *auditEventCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("audit-event-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	_, err := c.lifecycle.RemoveAuditEventsOlderThan(c.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-old-audit-events", err)
+		return err
+	}
+
+	return nil
+}
@@ -8,12 +8,18 @@ import (
 	"time"
 )
 
+//counterfeiter:generate . BuildLogArchiver
+type BuildLogArchiver interface {
+	Archive(context.Context, db.Build) (string, error)
+}
+
 type buildLogCollector struct {
 	pipelineFactory             db.PipelineFactory
 	pipelineLifecycle           db.PipelineLifecycle
 	batchSize                   int
 	drainerConfigured           bool
 	buildLogRetentionCalculator BuildLogRetentionCalculator
+	buildLogArchiver            BuildLogArchiver
 }
 
 func NewBuildLogCollector(
@@ -22,6 +28,7 @@ func NewBuildLogCollector(
 	batchSize int,
 	buildLogRetentionCalculator BuildLogRetentionCalculator,
 	drainerConfigured bool,
+	buildLogArchiver BuildLogArchiver,
 ) *buildLogCollector {
 	return &buildLogCollector{
 		pipelineFactory:             pipelineFactory,
@@ -29,6 +36,7 @@ func NewBuildLogCollector(
 		batchSize:                   batchSize,
 		drainerConfigured:           drainerConfigured,
 		buildLogRetentionCalculator: buildLogRetentionCalculator,
+		buildLogArchiver:            buildLogArchiver,
 	}
 }
 
@@ -66,7 +74,7 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 				continue
 			}
 
-			err = br.reapLogsOfJob(pipeline, job, logger)
+			err = br.reapLogsOfJob(ctx, pipeline, job, logger)
 			if err != nil {
 				return err
 			}
@@ -76,7 +84,8 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 	return nil
 }
 
-func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
+func (br *buildLogCollector) reapLogsOfJob(ctx context.Context,
+	pipeline db.Pipeline,
 	job db.Job,
 	logger lager.Logger) error {
 
@@ -203,6 +212,24 @@ func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
 		}
 	}
 
+	if br.buildLogArchiver != nil {
+		var unarchivedBuildIDs []int
+		buildIDsToDelete, unarchivedBuildIDs = br.archiveLogsOfBuilds(ctx, logger, buildsToConsiderDeleting, buildIDsToDelete)
+
+		// Keep the logs of builds that failed to archive within reach of the
+		// next run so that archiving them is retried.
+		for _, buildID := range unarchivedBuildIDs {
+			if firstLoggedBuildID != 0 && buildID < firstLoggedBuildID {
+				firstLoggedBuildID = buildID
+			}
+		}
+
+		if len(buildIDsToDelete) == 0 {
+			logger.Debug("no-archived-builds-to-reap")
+			return nil
+		}
+	}
+
 	logger.Debug("reaping-builds", lager.Data{
 		"build_ids": buildIDsToDelete,
 	})
@@ -223,3 +250,48 @@ func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
 
 	return nil
 }
+
+// archiveLogsOfBuilds archives the logs of the given builds, returning the
+// builds whose logs were archived and may now be deleted, and those whose
+// logs failed to archive and must be kept.
+func (br *buildLogCollector) archiveLogsOfBuilds(ctx context.Context,
+	logger lager.Logger,
+	builds []db.Build,
+	buildIDs []int) ([]int, []int) {
+
+	buildsByID := map[int]db.Build{}
+	for _, build := range builds {
+		buildsByID[build.ID()] = build
+	}
+
+	archivedBuildIDs := []int{}
+	unarchivedBuildIDs := []int{}
+	for _, buildID := range buildIDs {
+		build := buildsByID[buildID]
+
+		// The logs were archived by an earlier run which then failed to
+		// delete them.
+		if build.LogArchiveLocation() != "" {
+			archivedBuildIDs = append(archivedBuildIDs, buildID)
+			continue
+		}
+
+		location, err := br.buildLogArchiver.Archive(ctx, build)
+		if err != nil {
+			logger.Error("failed-to-archive-build-logs", err, lager.Data{"build_id": buildID})
+			unarchivedBuildIDs = append(unarchivedBuildIDs, buildID)
+			continue
+		}
+
+		err = build.SetLogArchiveLocation(location)
+		if err != nil {
+			logger.Error("failed-to-save-build-log-archive-location", err, lager.Data{"build_id": buildID})
+			unarchivedBuildIDs = append(unarchivedBuildIDs, buildID)
+			continue
+		}
+
+		archivedBuildIDs = append(archivedBuildIDs, buildID)
+	}
+
+	return archivedBuildIDs, unarchivedBuildIDs
+}
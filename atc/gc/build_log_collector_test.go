@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/gc/gcfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,6 +23,7 @@ var _ = Describe("BuildLogCollector", func() {
 		fakePipelineLifecycle *dbfakes.FakePipelineLifecycle
 		batchSize             int
 		buildLogRetainCalc    BuildLogRetentionCalculator
+		buildLogArchiver      BuildLogArchiver
 	)
 
 	BeforeEach(func() {
@@ -29,6 +31,7 @@ var _ = Describe("BuildLogCollector", func() {
 		fakePipelineLifecycle = new(dbfakes.FakePipelineLifecycle)
 		batchSize = 5
 		buildLogRetainCalc = NewBuildLogRetentionCalculator(0, 0, 0, 0)
+		buildLogArchiver = nil
 	})
 
 	JustBeforeEach(func() {
@@ -38,6 +41,7 @@ var _ = Describe("BuildLogCollector", func() {
 			batchSize,
 			buildLogRetainCalc,
 			false,
+			buildLogArchiver,
 		)
 	})
 
@@ -104,6 +108,7 @@ var _ = Describe("BuildLogCollector", func() {
 						batchSize,
 						buildLogRetainCalc,
 						true,
+						nil,
 					)
 				})
 				BeforeEach(func() {
@@ -157,6 +162,7 @@ var _ = Describe("BuildLogCollector", func() {
 						batchSize,
 						buildLogRetainCalc,
 						false,
+						nil,
 					)
 					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
 						if *page.From == 5 {
@@ -404,6 +410,117 @@ var _ = Describe("BuildLogCollector", func() {
 				})
 			})
 
+			Context("when an archiver is configured", func() {
+				var (
+					fakeBuildLogArchiver *gcfakes.FakeBuildLogArchiver
+					build7, build6       *dbfakes.FakeBuild
+					build5               *dbfakes.FakeBuild
+				)
+
+				BeforeEach(func() {
+					fakeBuildLogArchiver = new(gcfakes.FakeBuildLogArchiver)
+					fakeBuildLogArchiver.ArchiveStub = func(_ context.Context, build db.Build) (string, error) {
+						return fmt.Sprintf("file:///archive/builds/%d/events.gzip", build.ID()), nil
+					}
+					buildLogArchiver = fakeBuildLogArchiver
+
+					build7 = sb(7).(*dbfakes.FakeBuild)
+					build6 = sb(6).(*dbfakes.FakeBuild)
+					build5 = sb(5).(*dbfakes.FakeBuild)
+
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if *page.From == 5 {
+							return []db.Build{build7, build6, build5}, db.Pagination{}, nil
+						}
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						return nil, db.Pagination{}, nil
+					}
+
+					fakeJob.ConfigReturns(atc.JobConfig{
+						BuildLogRetention: &atc.BuildLogRetention{
+							Builds: 1,
+						},
+					}, nil)
+				})
+
+				It("archives the logs before deleting them", func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildLogArchiver.ArchiveCallCount()).To(Equal(2))
+
+					Expect(build6.SetLogArchiveLocationCallCount()).To(Equal(1))
+					Expect(build6.SetLogArchiveLocationArgsForCall(0)).To(Equal("file:///archive/builds/6/events.gzip"))
+					Expect(build5.SetLogArchiveLocationCallCount()).To(Equal(1))
+					Expect(build5.SetLogArchiveLocationArgsForCall(0)).To(Equal("file:///archive/builds/5/events.gzip"))
+					Expect(build7.SetLogArchiveLocationCallCount()).To(BeZero())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 5))
+
+					Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+					Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(7))
+				})
+
+				Context("when a build's logs were already archived", func() {
+					BeforeEach(func() {
+						build5.LogArchiveLocationReturns("file:///archive/builds/5/events.gzip")
+					})
+
+					It("deletes them without archiving them again", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBuildLogArchiver.ArchiveCallCount()).To(Equal(1))
+						_, archivedBuild := fakeBuildLogArchiver.ArchiveArgsForCall(0)
+						Expect(archivedBuild.ID()).To(Equal(6))
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 5))
+					})
+				})
+
+				Context("when archiving a build's logs fails", func() {
+					BeforeEach(func() {
+						fakeBuildLogArchiver.ArchiveStub = func(_ context.Context, build db.Build) (string, error) {
+							if build.ID() == 5 {
+								return "", errors.New("disk full")
+							}
+
+							return fmt.Sprintf("file:///archive/builds/%d/events.gzip", build.ID()), nil
+						}
+					})
+
+					It("only deletes the logs that were archived", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6))
+					})
+
+					It("does not move the first logged build past the build so that it is retried", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when no logs could be archived", func() {
+					BeforeEach(func() {
+						fakeBuildLogArchiver.ArchiveReturns("", errors.New("disk full"))
+						fakeBuildLogArchiver.ArchiveStub = nil
+					})
+
+					It("deletes nothing", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when count and date are set > 0", func() {
 				BeforeEach(func() {
 					fakeJob.ConfigReturns(atc.JobConfig{
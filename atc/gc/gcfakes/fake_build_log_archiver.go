@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package gcfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/gc"
+)
+
+type FakeBuildLogArchiver struct {
+	ArchiveStub        func(context.Context, db.Build) (string, error)
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Build
+	}
+	archiveReturns struct {
+		result1 string
+		result2 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildLogArchiver) Archive(arg1 context.Context, arg2 db.Build) (string, error) {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Build
+	}{arg1, arg2})
+	stub := fake.ArchiveStub
+	fakeReturns := fake.archiveReturns
+	fake.recordInvocation("Archive", []interface{}{arg1, arg2})
+	fake.archiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildLogArchiver) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakeBuildLogArchiver) ArchiveCalls(stub func(context.Context, db.Build) (string, error)) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = stub
+}
+
+func (fake *FakeBuildLogArchiver) ArchiveArgsForCall(i int) (context.Context, db.Build) {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	argsForCall := fake.archiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildLogArchiver) ArchiveReturns(result1 string, result2 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildLogArchiver) ArchiveReturnsOnCall(i int, result1 string, result2 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildLogArchiver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildLogArchiver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gc.BuildLogArchiver = new(FakeBuildLogArchiver)
@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretAccessCollector struct {
+	lifecycle db.SecretAccessLifecycle
+	retention time.Duration
+}
+
+func NewSecretAccessCollector(lifecycle db.SecretAccessLifecycle, retention time.Duration) *secretAccessCollector {
+	return &secretAccessCollector{
+		lifecycle: lifecycle,
+		retention: retention,
+	}
+}
+
+func (c *secretAccessCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("secret-access-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	_, err := c.lifecycle.RemoveSecretAccessesOlderThan(c.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-old-secret-accesses", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessCollector", func() {
+	var collector GcCollector
+	var fakeLifecycle *dbfakes.FakeSecretAccessLifecycle
+
+	BeforeEach(func() {
+		fakeLifecycle = new(dbfakes.FakeSecretAccessLifecycle)
+
+		collector = gc.NewSecretAccessCollector(fakeLifecycle, 90*24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("removes secret accesses older than the retention period", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLifecycle.RemoveSecretAccessesOlderThanCallCount()).To(Equal(1))
+			Expect(fakeLifecycle.RemoveSecretAccessesOlderThanArgsForCall(0)).To(Equal(90 * 24 * time.Hour))
+		})
+
+		Context("when removing secret accesses fails", func() {
+			BeforeEach(func() {
+				fakeLifecycle.RemoveSecretAccessesOlderThanReturns(0, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretLeaseCollector struct {
+	lifecycle db.SecretLeaseLifecycle
+	secrets   creds.LeasingSecrets
+}
+
+// NewSecretLeaseCollector returns a collector which revokes the leases on
+// dynamic secrets still held by builds that are no longer running, e.g.
+// because the ATC running them went away before revoking them itself.
+func NewSecretLeaseCollector(lifecycle db.SecretLeaseLifecycle, secrets creds.LeasingSecrets) *secretLeaseCollector {
+	return &secretLeaseCollector{
+		lifecycle: lifecycle,
+		secrets:   secrets,
+	}
+}
+
+func (c *secretLeaseCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("secret-lease-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	_, err := c.lifecycle.RemoveExpiredSecretLeases()
+	if err != nil {
+		logger.Error("failed-to-remove-expired-secret-leases", err)
+		return err
+	}
+
+	leases, err := c.lifecycle.OrphanedSecretLeases()
+	if err != nil {
+		logger.Error("failed-to-get-orphaned-secret-leases", err)
+		return err
+	}
+
+	for _, lease := range leases {
+		err := c.secrets.RevokeLease(lease.ID)
+		if err != nil {
+			logger.Error("failed-to-revoke-lease", err, lager.Data{"lease": lease.ID})
+			continue
+		}
+
+		err = c.lifecycle.RemoveSecretLease(lease.ID)
+		if err != nil {
+			logger.Error("failed-to-remove-lease", err, lager.Data{"lease": lease.ID})
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretLeaseCollector", func() {
+	var collector GcCollector
+	var fakeLifecycle *dbfakes.FakeSecretLeaseLifecycle
+	var fakeSecrets *credsfakes.FakeLeasingSecrets
+
+	BeforeEach(func() {
+		fakeLifecycle = new(dbfakes.FakeSecretLeaseLifecycle)
+		fakeSecrets = new(credsfakes.FakeLeasingSecrets)
+
+		fakeLifecycle.OrphanedSecretLeasesReturns([]creds.Lease{
+			{ID: "database/creds/some-role/lease-1"},
+			{ID: "aws/creds/some-role/lease-2"},
+		}, nil)
+
+		collector = gc.NewSecretLeaseCollector(fakeLifecycle, fakeSecrets)
+	})
+
+	Describe("Run", func() {
+		It("removes expired leases", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLifecycle.RemoveExpiredSecretLeasesCallCount()).To(Equal(1))
+		})
+
+		It("revokes and removes orphaned leases", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSecrets.RevokeLeaseCallCount()).To(Equal(2))
+			Expect(fakeSecrets.RevokeLeaseArgsForCall(0)).To(Equal("database/creds/some-role/lease-1"))
+			Expect(fakeSecrets.RevokeLeaseArgsForCall(1)).To(Equal("aws/creds/some-role/lease-2"))
+
+			Expect(fakeLifecycle.RemoveSecretLeaseCallCount()).To(Equal(2))
+			Expect(fakeLifecycle.RemoveSecretLeaseArgsForCall(0)).To(Equal("database/creds/some-role/lease-1"))
+			Expect(fakeLifecycle.RemoveSecretLeaseArgsForCall(1)).To(Equal("aws/creds/some-role/lease-2"))
+		})
+
+		Context("when revoking a lease fails", func() {
+			BeforeEach(func() {
+				fakeSecrets.RevokeLeaseReturnsOnCall(0, errors.New("vault unavailable"))
+			})
+
+			It("keeps the lease to be retried later and carries on", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLifecycle.RemoveSecretLeaseCallCount()).To(Equal(1))
+				Expect(fakeLifecycle.RemoveSecretLeaseArgsForCall(0)).To(Equal("aws/creds/some-role/lease-2"))
+			})
+		})
+
+		Context("when getting orphaned leases fails", func() {
+			BeforeEach(func() {
+				fakeLifecycle.OrphanedSecretLeasesReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+})
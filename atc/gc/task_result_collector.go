@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type taskResultCollector struct {
+	lifecycle db.TaskResultLifecycle
+	unusedFor time.Duration
+}
+
+func NewTaskResultCollector(lifecycle db.TaskResultLifecycle, unusedFor time.Duration) *taskResultCollector {
+	return &taskResultCollector{
+		lifecycle: lifecycle,
+		unusedFor: unusedFor,
+	}
+}
+
+func (c *taskResultCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("task-result-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	_, err := c.lifecycle.RemoveUnusedTaskResults(c.unusedFor)
+	if err != nil {
+		logger.Error("failed-to-remove-unused-task-results", err)
+		return err
+	}
+
+	return nil
+}
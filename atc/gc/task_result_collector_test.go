@@ -0,0 +1,45 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskResultCollector", func() {
+	var collector GcCollector
+	var fakeLifecycle *dbfakes.FakeTaskResultLifecycle
+
+	BeforeEach(func() {
+		fakeLifecycle = new(dbfakes.FakeTaskResultLifecycle)
+
+		collector = gc.NewTaskResultCollector(fakeLifecycle, 24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("tells the task result lifecycle to remove unused task results", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLifecycle.RemoveUnusedTaskResultsCallCount()).To(Equal(1))
+			unusedFor := fakeLifecycle.RemoveUnusedTaskResultsArgsForCall(0)
+			Expect(unusedFor).To(Equal(24 * time.Hour))
+		})
+
+		Context("when removing task results fails", func() {
+			BeforeEach(func() {
+				fakeLifecycle.RemoveUnusedTaskResultsReturns(0, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package idtoken
+
+import (
+	"crypto/rsa"
+	"errors"
+	"time"
+
+	"github.com/concourse/flag"
+)
+
+type Config struct {
+	Enabled    bool             `long:"enable-workload-identity" description:"Issue each task, get and put step a short-lived OIDC token identifying it, available in the container as $CONCOURSE_ID_TOKEN."`
+	SigningKey *flag.PrivateKey `long:"workload-identity-signing-key" description:"File containing an RSA private key used to sign workload identity tokens. Required when workload identity is enabled, and must differ from the session signing key."`
+	Audiences  []string         `long:"workload-identity-audience" description:"Audience to include in workload identity tokens. Can be specified multiple times. Defaults to the external URL."`
+	TTL        time.Duration    `long:"workload-identity-token-ttl" default:"1h" description:"How long workload identity tokens are valid for. Tokens are issued when a step's container is created and are not refreshed, so this should cover the longest running step."`
+}
+
+// Issuer returns the issuer configured by the flags, or nil if workload
+// identity is disabled.
+//
+// Tokens are handed to build containers, which run untrusted code, so they
+// must not be signed with the sessionKey: its public key would then be
+// published as trusted for workload identity, alongside user ID tokens.
+func (config Config) Issuer(issuerURL string, sessionKey *rsa.PrivateKey) (*JWTIssuer, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	if config.SigningKey == nil || config.SigningKey.PrivateKey == nil {
+		return nil, errors.New("--workload-identity-signing-key must be given when workload identity is enabled")
+	}
+
+	key := config.SigningKey.PrivateKey
+	if sessionKey != nil && key.Equal(sessionKey) {
+		return nil, errors.New("--workload-identity-signing-key must differ from the session signing key")
+	}
+
+	return NewIssuer(issuerURL, key, config.Audiences, config.TTL)
+}
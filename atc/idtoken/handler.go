@@ -0,0 +1,61 @@
+package idtoken
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	KeySetPath    = "/.well-known/jwks.json"
+)
+
+type discovery struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+}
+
+// NewHandler serves the OIDC discovery document and the key set which
+// external systems use to verify the tokens issued by the issuer.
+func NewHandler(logger lager.Logger, issuer *JWTIssuer) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		respond(logger.Session("discovery"), w, discovery{
+			Issuer:                           issuer.issuerURL,
+			JWKSURI:                          strings.TrimSuffix(issuer.issuerURL, "/") + KeySetPath,
+			ResponseTypesSupported:           []string{"id_token"},
+			SubjectTypesSupported:            []string{"public"},
+			IDTokenSigningAlgValuesSupported: []string{string(jose.RS256)},
+			ClaimsSupported: []string{
+				"iss", "sub", "aud", "exp", "iat", "nbf", "jti",
+				"team", "pipeline", "pipeline_instance_vars", "job",
+				"build_id", "build_name", "step", "step_type",
+			},
+		})
+	})
+
+	mux.HandleFunc(KeySetPath, func(w http.ResponseWriter, r *http.Request) {
+		respond(logger.Session("jwks"), w, issuer.KeySet())
+	})
+
+	return mux
+}
+
+func respond(logger lager.Logger, w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		logger.Error("failed-to-encode-response", err)
+	}
+}
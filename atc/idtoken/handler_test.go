@@ -0,0 +1,83 @@
+package idtoken_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/idtoken"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		issuer *idtoken.JWTIssuer
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		issuer, err = idtoken.NewIssuer("https://ci.example.com", key, nil, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		server = httptest.NewServer(idtoken.NewHandler(lagertest.NewTestLogger("test"), issuer))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serves the discovery document", func() {
+		response, err := http.Get(server.URL + "/.well-known/openid-configuration")
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+		var document map[string]interface{}
+		Expect(json.NewDecoder(response.Body).Decode(&document)).To(Succeed())
+
+		Expect(document["issuer"]).To(Equal("https://ci.example.com"))
+		Expect(document["jwks_uri"]).To(Equal("https://ci.example.com/.well-known/jwks.json"))
+		Expect(document["id_token_signing_alg_values_supported"]).To(ConsistOf("RS256"))
+		Expect(document["claims_supported"]).To(ContainElements("team", "pipeline", "job", "build_id", "step"))
+	})
+
+	It("serves the key set that verifies issued tokens", func() {
+		response, err := http.Get(server.URL + "/.well-known/jwks.json")
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		var keys jose.JSONWebKeySet
+		Expect(json.NewDecoder(response.Body).Decode(&keys)).To(Succeed())
+		Expect(keys.Keys).To(HaveLen(1))
+		Expect(keys.Keys[0].Use).To(Equal("sig"))
+		Expect(keys.Keys[0].Algorithm).To(Equal("RS256"))
+
+		raw, err := issuer.Issue(idtoken.Identity{TeamName: "some-team", BuildID: 1})
+		Expect(err).NotTo(HaveOccurred())
+
+		token, err := jwt.ParseSigned(raw)
+		Expect(err).NotTo(HaveOccurred())
+
+		matching := keys.Key(token.Headers[0].KeyID)
+		Expect(matching).To(HaveLen(1))
+
+		var claims idtoken.Claims
+		Expect(token.Claims(matching[0].Key, &claims)).To(Succeed())
+		Expect(claims.Team).To(Equal("some-team"))
+	})
+})
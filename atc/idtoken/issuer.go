@@ -0,0 +1,174 @@
+// Package idtoken turns the ATC into an OIDC identity provider for running
+// builds. Steps are handed a short-lived signed JWT identifying the team,
+// pipeline, job, build and step they belong to, which external systems can
+// verify against the keys published by the ATC instead of relying on static
+// credentials.
+package idtoken
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Env is the environment variable through which the token is handed to a
+// step's container.
+const Env = "CONCOURSE_ID_TOKEN"
+
+// Identity describes the step a token is issued to.
+type Identity struct {
+	TeamName             string
+	PipelineName         string
+	PipelineInstanceVars atc.InstanceVars
+	JobName              string
+	BuildID              int
+	BuildName            string
+	StepName             string
+	StepType             string
+}
+
+// Subject returns the token's "sub" claim, which names the step from the
+// team down so that external systems can bind trust to any prefix of it.
+//
+// Names may themselves contain ':', so each is escaped to keep one team or
+// pipeline from naming itself into another's subject.
+func (identity Identity) Subject() string {
+	parts := []string{"team", subjectEscaper.Replace(identity.TeamName)}
+
+	if identity.PipelineName != "" {
+		parts = append(parts, "pipeline", subjectEscaper.Replace(identity.PipelineName))
+
+		if len(identity.PipelineInstanceVars) != 0 {
+			parts = append(parts, "instance_vars", subjectEscaper.Replace(identity.PipelineInstanceVars.String()))
+		}
+	}
+
+	if identity.JobName != "" {
+		parts = append(parts, "job", subjectEscaper.Replace(identity.JobName))
+	}
+
+	if identity.StepName != "" {
+		parts = append(parts, "step", subjectEscaper.Replace(identity.StepName))
+	}
+
+	return strings.Join(parts, ":")
+}
+
+var subjectEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+// Claims are the claims of an issued token.
+type Claims struct {
+	jwt.Claims
+
+	Team                 string           `json:"team"`
+	Pipeline             string           `json:"pipeline,omitempty"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	Job                  string           `json:"job,omitempty"`
+	BuildID              int              `json:"build_id"`
+	BuildName            string           `json:"build_name"`
+	Step                 string           `json:"step,omitempty"`
+	StepType             string           `json:"step_type,omitempty"`
+}
+
+//counterfeiter:generate . Issuer
+type Issuer interface {
+	Issue(Identity) (string, error)
+}
+
+// JWTIssuer issues RS256-signed tokens and publishes the key that verifies
+// them.
+type JWTIssuer struct {
+	issuerURL string
+	audiences []string
+	ttl       time.Duration
+	key       jose.JSONWebKey
+	signer    jose.Signer
+}
+
+func NewIssuer(issuerURL string, key *rsa.PrivateKey, audiences []string, ttl time.Duration) (*JWTIssuer, error) {
+	if key == nil {
+		return nil, errors.New("a signing key is required to issue id tokens")
+	}
+
+	if len(audiences) == 0 {
+		audiences = []string{issuerURL}
+	}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: &key.PublicKey}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	jwk := jose.JSONWebKey{
+		Key:       key,
+		KeyID:     base64.RawURLEncoding.EncodeToString(thumbprint),
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jwk},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTIssuer{
+		issuerURL: issuerURL,
+		audiences: audiences,
+		ttl:       ttl,
+		key:       jwk,
+		signer:    signer,
+	}, nil
+}
+
+func (issuer *JWTIssuer) Issue(identity Identity) (string, error) {
+	jti := make([]byte, 16)
+	_, err := rand.Read(jti)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	claims := Claims{
+		Claims: jwt.Claims{
+			ID:        hex.EncodeToString(jti),
+			Issuer:    issuer.issuerURL,
+			Subject:   identity.Subject(),
+			Audience:  jwt.Audience(issuer.audiences),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(issuer.ttl)),
+		},
+		Team:                 identity.TeamName,
+		Pipeline:             identity.PipelineName,
+		PipelineInstanceVars: identity.PipelineInstanceVars,
+		Job:                  identity.JobName,
+		BuildID:              identity.BuildID,
+		BuildName:            identity.BuildName,
+		Step:                 identity.StepName,
+		StepType:             identity.StepType,
+	}
+
+	return jwt.Signed(issuer.signer).Claims(claims).CompactSerialize()
+}
+
+// KeySet returns the public keys with which issued tokens can be verified.
+func (issuer *JWTIssuer) KeySet() jose.JSONWebKeySet {
+	return jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{issuer.key.Public()},
+	}
+}
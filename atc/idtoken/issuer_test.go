@@ -0,0 +1,235 @@
+package idtoken_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/flag"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWTIssuer", func() {
+	var (
+		key       *rsa.PrivateKey
+		audiences []string
+		issuer    *idtoken.JWTIssuer
+
+		identity idtoken.Identity
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		audiences = []string{"vault", "sts.amazonaws.com"}
+
+		identity = idtoken.Identity{
+			TeamName:             "some-team",
+			PipelineName:         "some-pipeline",
+			PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+			JobName:              "some-job",
+			BuildID:              42,
+			BuildName:            "7",
+			StepName:             "deploy",
+			StepType:             "task",
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		issuer, err = idtoken.NewIssuer("https://ci.example.com", key, audiences, 15*time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	parse := func(raw string) idtoken.Claims {
+		token, err := jwt.ParseSigned(raw)
+		Expect(err).NotTo(HaveOccurred())
+
+		keys := issuer.KeySet()
+		Expect(keys.Keys).To(HaveLen(1))
+		Expect(token.Headers[0].KeyID).To(Equal(keys.Keys[0].KeyID))
+
+		var claims idtoken.Claims
+		Expect(token.Claims(keys.Keys[0].Key, &claims)).To(Succeed())
+
+		return claims
+	}
+
+	It("issues a token verifiable with the published key", func() {
+		raw, err := issuer.Issue(identity)
+		Expect(err).NotTo(HaveOccurred())
+
+		claims := parse(raw)
+		Expect(claims.Issuer).To(Equal("https://ci.example.com"))
+		Expect(claims.Subject).To(Equal("team:some-team:pipeline:some-pipeline:instance_vars:branch%3Amain:job:some-job:step:deploy"))
+		Expect(claims.Audience).To(ConsistOf("vault", "sts.amazonaws.com"))
+		Expect(claims.ID).NotTo(BeEmpty())
+
+		Expect(claims.Team).To(Equal("some-team"))
+		Expect(claims.Pipeline).To(Equal("some-pipeline"))
+		Expect(claims.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "main"}))
+		Expect(claims.Job).To(Equal("some-job"))
+		Expect(claims.BuildID).To(Equal(42))
+		Expect(claims.BuildName).To(Equal("7"))
+		Expect(claims.Step).To(Equal("deploy"))
+		Expect(claims.StepType).To(Equal("task"))
+	})
+
+	It("issues a short-lived token", func() {
+		raw, err := issuer.Issue(identity)
+		Expect(err).NotTo(HaveOccurred())
+
+		claims := parse(raw)
+		Expect(claims.IssuedAt.Time()).To(BeTemporally("~", time.Now(), time.Minute))
+		Expect(claims.Expiry.Time()).To(BeTemporally("~", claims.IssuedAt.Time().Add(15*time.Minute), time.Second))
+
+		Expect(claims.Validate(jwt.Expected{
+			Issuer: "https://ci.example.com",
+			Time:   time.Now().Add(time.Hour),
+		})).To(MatchError(jwt.ErrExpired))
+	})
+
+	It("issues a unique token each time", func() {
+		first, err := issuer.Issue(identity)
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := issuer.Issue(identity)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(parse(first).ID).NotTo(Equal(parse(second).ID))
+	})
+
+	It("does not publish the private key", func() {
+		keys := issuer.KeySet()
+		Expect(keys.Keys[0].IsPublic()).To(BeTrue())
+		Expect(keys.Keys[0].Key).To(Equal(&key.PublicKey))
+	})
+
+	Context("when the build is not part of a pipeline", func() {
+		BeforeEach(func() {
+			identity.PipelineName = ""
+			identity.PipelineInstanceVars = nil
+			identity.JobName = ""
+		})
+
+		It("identifies the step by team alone", func() {
+			raw, err := issuer.Issue(identity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(parse(raw).Subject).To(Equal("team:some-team:step:deploy"))
+		})
+	})
+
+	Context("when the pipeline is not instanced", func() {
+		BeforeEach(func() {
+			identity.PipelineInstanceVars = nil
+		})
+
+		It("leaves the instance vars out of the subject", func() {
+			raw, err := issuer.Issue(identity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(parse(raw).Subject).To(Equal("team:some-team:pipeline:some-pipeline:job:some-job:step:deploy"))
+		})
+	})
+
+	Context("when names contain ':'", func() {
+		It("escapes them, so that they can't be mistaken for other steps", func() {
+			forged := idtoken.Identity{
+				TeamName:     "some-team:pipeline:other-pipeline",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				StepName:     "deploy",
+			}
+
+			other := idtoken.Identity{
+				TeamName:     "some-team",
+				PipelineName: "other-pipeline:pipeline:some-pipeline",
+				JobName:      "some-job",
+				StepName:     "deploy",
+			}
+
+			Expect(forged.Subject()).To(Equal("team:some-team%3Apipeline%3Aother-pipeline:pipeline:some-pipeline:job:some-job:step:deploy"))
+			Expect(forged.Subject()).NotTo(HavePrefix("team:some-team:"))
+			Expect(forged.Subject()).NotTo(Equal(other.Subject()))
+		})
+
+		It("escapes '%' too, so that escaped names can't be forged", func() {
+			escaped := idtoken.Identity{TeamName: "a%3Ab"}
+			colon := idtoken.Identity{TeamName: "a:b"}
+
+			Expect(escaped.Subject()).To(Equal("team:a%253Ab"))
+			Expect(escaped.Subject()).NotTo(Equal(colon.Subject()))
+		})
+	})
+
+	Context("when no audiences are configured", func() {
+		BeforeEach(func() {
+			audiences = nil
+		})
+
+		It("uses the issuer as the audience", func() {
+			raw, err := issuer.Issue(identity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(parse(raw).Audience).To(ConsistOf("https://ci.example.com"))
+		})
+	})
+})
+
+var _ = Describe("Config", func() {
+	var (
+		sessionKey *rsa.PrivateKey
+		config     idtoken.Config
+	)
+
+	BeforeEach(func() {
+		var err error
+		sessionKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		config = idtoken.Config{TTL: time.Minute}
+	})
+
+	It("returns no issuer when disabled", func() {
+		issuer, err := config.Issuer("https://ci.example.com", sessionKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issuer).To(BeNil())
+	})
+
+	Context("when enabled", func() {
+		BeforeEach(func() {
+			config.Enabled = true
+		})
+
+		It("signs with the configured key", func() {
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			config.SigningKey = &flag.PrivateKey{PrivateKey: otherKey}
+
+			issuer, err := config.Issuer("https://ci.example.com", sessionKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issuer.KeySet().Keys[0].Key).To(Equal(&otherKey.PublicKey))
+		})
+
+		It("errors when no key is configured, rather than using the session signing key", func() {
+			_, err := config.Issuer("https://ci.example.com", sessionKey)
+			Expect(err).To(MatchError(ContainSubstring("--workload-identity-signing-key must be given")))
+		})
+
+		It("errors when the configured key is the session signing key", func() {
+			config.SigningKey = &flag.PrivateKey{PrivateKey: sessionKey}
+
+			_, err := config.Issuer("https://ci.example.com", sessionKey)
+			Expect(err).To(MatchError(ContainSubstring("must differ from the session signing key")))
+		})
+	})
+})
@@ -27,11 +27,12 @@ type Job struct {
 }
 
 type JobInput struct {
-	Name     string         `json:"name"`
-	Resource string         `json:"resource"`
-	Trigger  bool           `json:"trigger"`
-	Passed   []string       `json:"passed,omitempty"`
-	Version  *VersionConfig `json:"version,omitempty"`
+	Name      string         `json:"name"`
+	Resource  string         `json:"resource"`
+	Trigger   bool           `json:"trigger"`
+	Passed    []string       `json:"passed,omitempty"`
+	PassedAny []string       `json:"passed_any,omitempty"`
+	Version   *VersionConfig `json:"version,omitempty"`
 }
 
 type JobInputParams struct {
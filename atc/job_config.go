@@ -14,6 +14,10 @@ type JobConfig struct {
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	// Notifications overrides the team's notification rules for the job's
+	// builds. An empty list disables notifications for the job.
+	Notifications *NotificationRules `json:"notifications,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
@@ -95,11 +99,12 @@ func (config JobConfig) Inputs() []JobInputParams {
 		OnGet: func(step *GetStep) error {
 			inputs = append(inputs, JobInputParams{
 				JobInput: JobInput{
-					Name:     step.Name,
-					Resource: step.ResourceName(),
-					Passed:   step.Passed,
-					Version:  step.Version,
-					Trigger:  step.Trigger,
+					Name:      step.Name,
+					Resource:  step.ResourceName(),
+					Passed:    step.Passed,
+					PassedAny: step.PassedAny,
+					Version:   step.Version,
+					Trigger:   step.Trigger,
 				},
 				Params: step.Params,
 				Tags:   step.Tags,
@@ -0,0 +1,169 @@
+// Package junit parses JUnit XML test reports, as written by most test
+// runners (JUnit, pytest, go-junit-report, Ginkgo, etc.).
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// MaxFailureMessageLength is the maximum length of a failure message that is
+// kept for a test case. Longer messages are truncated.
+const MaxFailureMessageLength = 10 * 1024
+
+type testSuites struct {
+	Suites []testSuite `xml:"testsuite"`
+}
+
+type testSuite struct {
+	Name   string      `xml:"name,attr"`
+	Time   string      `xml:"time,attr"`
+	Cases  []testCase  `xml:"testcase"`
+	Suites []testSuite `xml:"testsuite"`
+}
+
+type testCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Time      string   `xml:"time,attr"`
+	Failure   *problem `xml:"failure"`
+	Error     *problem `xml:"error"`
+	Skipped   *problem `xml:"skipped"`
+}
+
+type problem struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// Parse reads a report with either a <testsuites> or a <testsuite> root
+// element. Nested suites are flattened.
+func Parse(r io.Reader) ([]atc.TestSuite, error) {
+	decoder := xml.NewDecoder(r)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no test suites found")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("parse report: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "testsuites":
+			var suites testSuites
+			err := decoder.DecodeElement(&suites, &start)
+			if err != nil {
+				return nil, fmt.Errorf("parse report: %w", err)
+			}
+
+			var parsed []atc.TestSuite
+			for _, suite := range suites.Suites {
+				parsed = append(parsed, flatten(suite)...)
+			}
+
+			return parsed, nil
+
+		case "testsuite":
+			var suite testSuite
+			err := decoder.DecodeElement(&suite, &start)
+			if err != nil {
+				return nil, fmt.Errorf("parse report: %w", err)
+			}
+
+			return flatten(suite), nil
+
+		default:
+			return nil, fmt.Errorf("unexpected root element '%s'; expected testsuites or testsuite", start.Name.Local)
+		}
+	}
+}
+
+func flatten(suite testSuite) []atc.TestSuite {
+	parsed := atc.TestSuite{
+		Name:     suite.Name,
+		Duration: parseDuration(suite.Time),
+		Cases:    []atc.TestCase{},
+	}
+
+	for _, c := range suite.Cases {
+		parsed.Cases = append(parsed.Cases, convertCase(c))
+	}
+
+	var suites []atc.TestSuite
+	if len(parsed.Cases) > 0 || len(suite.Suites) == 0 {
+		suites = append(suites, parsed)
+	}
+
+	for _, nested := range suite.Suites {
+		suites = append(suites, flatten(nested)...)
+	}
+
+	return suites
+}
+
+func convertCase(c testCase) atc.TestCase {
+	parsed := atc.TestCase{
+		Name:      c.Name,
+		ClassName: c.ClassName,
+		Duration:  parseDuration(c.Time),
+		Status:    atc.TestPassed,
+	}
+
+	switch {
+	case c.Failure != nil:
+		parsed.Status = atc.TestFailed
+		parsed.FailureMessage = c.Failure.String()
+	case c.Error != nil:
+		parsed.Status = atc.TestErrored
+		parsed.FailureMessage = c.Error.String()
+	case c.Skipped != nil:
+		parsed.Status = atc.TestSkipped
+	}
+
+	return parsed
+}
+
+// String returns the message attribute, followed by the element's text (which
+// is typically a stack trace), truncated to MaxFailureMessageLength.
+func (p problem) String() string {
+	message := strings.TrimSpace(p.Message)
+
+	body := strings.TrimSpace(p.Body)
+	if body != "" && body != message {
+		if message != "" {
+			message += "\n\n"
+		}
+
+		message += body
+	}
+
+	if len(message) > MaxFailureMessageLength {
+		message = message[:MaxFailureMessageLength]
+	}
+
+	return message
+}
+
+// parseDuration parses a duration in seconds. Some runners include thousands
+// separators; a missing or malformed duration is treated as zero.
+func parseDuration(value string) float64 {
+	duration, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+
+	return duration
+}
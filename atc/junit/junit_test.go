@@ -0,0 +1,181 @@
+package junit_test
+
+import (
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/junit"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parse", func() {
+	var (
+		report string
+
+		suites   []atc.TestSuite
+		parseErr error
+	)
+
+	JustBeforeEach(func() {
+		suites, parseErr = junit.Parse(strings.NewReader(report))
+	})
+
+	Context("when the root element is testsuites", func() {
+		BeforeEach(func() {
+			report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="some-suite" tests="4" time="1.5">
+    <testcase name="passes" classname="some.Class" time="0.25"></testcase>
+    <testcase name="fails" classname="some.Class" time="0.5">
+      <failure message="expected true to be false" type="AssertionError">
+        at some.Class.fails(Class.java:12)
+      </failure>
+    </testcase>
+    <testcase name="errors" classname="some.Class" time="1,000.5">
+      <error message="boom"></error>
+    </testcase>
+    <testcase name="skips" classname="some.Class">
+      <skipped/>
+    </testcase>
+  </testsuite>
+  <testsuite name="other-suite" time="bogus">
+    <testcase name="passes too"></testcase>
+  </testsuite>
+</testsuites>`
+		})
+
+		It("parses every suite and case", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(suites).To(Equal([]atc.TestSuite{
+				{
+					Name:     "some-suite",
+					Duration: 1.5,
+					Cases: []atc.TestCase{
+						{
+							Name:      "passes",
+							ClassName: "some.Class",
+							Duration:  0.25,
+							Status:    atc.TestPassed,
+						},
+						{
+							Name:           "fails",
+							ClassName:      "some.Class",
+							Duration:       0.5,
+							Status:         atc.TestFailed,
+							FailureMessage: "expected true to be false\n\nat some.Class.fails(Class.java:12)",
+						},
+						{
+							Name:           "errors",
+							ClassName:      "some.Class",
+							Duration:       1000.5,
+							Status:         atc.TestErrored,
+							FailureMessage: "boom",
+						},
+						{
+							Name:      "skips",
+							ClassName: "some.Class",
+							Status:    atc.TestSkipped,
+						},
+					},
+				},
+				{
+					Name: "other-suite",
+					Cases: []atc.TestCase{
+						{
+							Name:   "passes too",
+							Status: atc.TestPassed,
+						},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("when the root element is testsuite", func() {
+		BeforeEach(func() {
+			report = `<testsuite name="some-suite" time="2">
+  <testcase name="fails"><failure>some output</failure></testcase>
+</testsuite>`
+		})
+
+		It("parses the suite", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(suites).To(Equal([]atc.TestSuite{
+				{
+					Name:     "some-suite",
+					Duration: 2,
+					Cases: []atc.TestCase{
+						{
+							Name:           "fails",
+							Status:         atc.TestFailed,
+							FailureMessage: "some output",
+						},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("when suites are nested", func() {
+		BeforeEach(func() {
+			report = `<testsuites>
+  <testsuite name="outer">
+    <testsuite name="inner">
+      <testcase name="passes"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+		})
+
+		It("flattens them, omitting suites without cases of their own", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(suites).To(HaveLen(1))
+			Expect(suites[0].Name).To(Equal("inner"))
+			Expect(suites[0].Cases).To(HaveLen(1))
+		})
+	})
+
+	Context("when a failure message is too long", func() {
+		BeforeEach(func() {
+			report = `<testsuite><testcase name="fails"><failure>` +
+				strings.Repeat("x", junit.MaxFailureMessageLength+1) +
+				`</failure></testcase></testsuite>`
+		})
+
+		It("truncates it", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(suites[0].Cases[0].FailureMessage).To(HaveLen(junit.MaxFailureMessageLength))
+		})
+	})
+
+	Context("when the root element is something else", func() {
+		BeforeEach(func() {
+			report = `<html></html>`
+		})
+
+		It("errors", func() {
+			Expect(parseErr).To(MatchError("unexpected root element 'html'; expected testsuites or testsuite"))
+		})
+	})
+
+	Context("when the report is empty", func() {
+		BeforeEach(func() {
+			report = ``
+		})
+
+		It("errors", func() {
+			Expect(parseErr).To(MatchError("no test suites found"))
+		})
+	})
+
+	Context("when the report is malformed", func() {
+		BeforeEach(func() {
+			report = `<testsuite><testcase>`
+		})
+
+		It("errors", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
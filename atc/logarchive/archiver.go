@@ -0,0 +1,53 @@
+// Package logarchive moves the event streams of builds whose logs have
+// exceeded their retention out of the database and into object storage, and
+// reads them back when someone views the build.
+package logarchive
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+var ErrUnsupportedLocation = errors.New("archive location is not supported by the configured archiver")
+
+// Archiver stores archived build logs and retrieves them again.
+//
+//counterfeiter:generate . Archiver
+type Archiver interface {
+	// Archive stores the content read from the reader under the given key and
+	// returns the location it can be retrieved from.
+	Archive(ctx context.Context, key string, content io.Reader) (string, error)
+
+	// Retrieve returns the content stored at a location returned by Archive.
+	Retrieve(ctx context.Context, location string) (io.ReadCloser, error)
+}
+
+// Config configures where build logs are archived. At most one of the
+// destinations may be configured; if none are, build logs are deleted once
+// their retention is exceeded.
+type Config struct {
+	Dir string `long:"build-log-archive-dir" description:"Directory to archive build logs to once their retention is exceeded, rather than deleting them."`
+
+	S3 S3Config
+}
+
+// Archiver returns the configured Archiver, or nil if archiving is not
+// configured.
+func (config Config) Archiver() (Archiver, error) {
+	if config.Dir != "" && config.S3.Bucket != "" {
+		return nil, errors.New("only one of --build-log-archive-dir and --build-log-archive-s3-bucket may be configured")
+	}
+
+	if config.Dir != "" {
+		return NewFilesystemArchiver(config.Dir), nil
+	}
+
+	if config.S3.Bucket != "" {
+		return NewS3Archiver(config.S3)
+	}
+
+	return nil, nil
+}
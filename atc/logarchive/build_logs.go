@@ -0,0 +1,159 @@
+package logarchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// BuildLogs archives the event streams of builds as compressed,
+// newline-delimited JSON envelopes and reads them back as event sources.
+type BuildLogs struct {
+	archiver    Archiver
+	compression compression.Compression
+}
+
+func NewBuildLogs(archiver Archiver, compression compression.Compression) *BuildLogs {
+	return &BuildLogs{
+		archiver:    archiver,
+		compression: compression,
+	}
+}
+
+// Archive stores every event of the completed build and returns the
+// location of the archive. The build's events are left in place.
+func (logs *BuildLogs) Archive(ctx context.Context, build db.Build) (string, error) {
+	events, err := build.Events(0)
+	if err != nil {
+		return "", err
+	}
+
+	defer db.Close(events)
+
+	reader, writer := io.Pipe()
+
+	encoded := make(chan struct{})
+	go func() {
+		defer close(encoded)
+		writer.CloseWithError(logs.encode(writer, events))
+	}()
+
+	key := fmt.Sprintf("builds/%d/events.%s", build.ID(), logs.compression.Encoding())
+
+	location, err := logs.archiver.Archive(ctx, key, reader)
+
+	// unblock the encoder if the archiver gave up before reading everything
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	<-encoded
+
+	if err != nil {
+		return "", err
+	}
+
+	return location, nil
+}
+
+func (logs *BuildLogs) encode(w io.Writer, events db.EventSource) error {
+	compressed, err := logs.compression.NewWriter(w)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(compressed)
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return compressed.Close()
+}
+
+// Events returns an event source reading the archive at the given location,
+// starting from the given event ID.
+func (logs *BuildLogs) Events(ctx context.Context, location string, from uint) (db.EventSource, error) {
+	content, err := logs.archiver.Retrieve(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	decompressed, err := compressionOf(location).NewReader(content)
+	if err != nil {
+		_ = content.Close()
+		return nil, err
+	}
+
+	return &archivedEventSource{
+		content:      content,
+		decompressed: decompressed,
+		decoder:      json.NewDecoder(decompressed),
+		from:         from,
+	}, nil
+}
+
+// compressionOf determines the compression an archive was written with from
+// its location, so that archives remain readable if the configured
+// compression changes.
+func compressionOf(location string) compression.Compression {
+	if path.Ext(location) == "."+string(baggageclaim.ZstdEncoding) {
+		return compression.NewZstdCompression()
+	}
+
+	return compression.NewGzipCompression()
+}
+
+type archivedEventSource struct {
+	content      io.ReadCloser
+	decompressed io.ReadCloser
+	decoder      *json.Decoder
+	from         uint
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	for {
+		var ev event.Envelope
+		err := source.decoder.Decode(&ev)
+		if err != nil {
+			if err == io.EOF {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+
+			return event.Envelope{}, err
+		}
+
+		id, err := strconv.ParseUint(ev.EventID, 10, 64)
+		if err == nil && uint(id) < source.from {
+			continue
+		}
+
+		return ev, nil
+	}
+}
+
+func (source *archivedEventSource) Close() error {
+	err := source.decompressed.Close()
+	if err != nil {
+		_ = source.content.Close()
+		return err
+	}
+
+	return source.content.Close()
+}
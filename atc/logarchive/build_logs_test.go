@@ -0,0 +1,156 @@
+package logarchive_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logarchive/logarchivefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildLogs", func() {
+	var (
+		dir       string
+		archiver  logarchive.Archiver
+		comp      compression.Compression
+		fakeBuild *dbfakes.FakeBuild
+		events    []event.Envelope
+
+		buildLogs *logarchive.BuildLogs
+	)
+
+	envelope := func(id string, payload string) event.Envelope {
+		data := json.RawMessage(`{"payload":"` + payload + `"}`)
+		return event.Envelope{
+			Data:    &data,
+			Event:   event.EventTypeLog,
+			Version: atc.EventVersion("5.1"),
+			EventID: id,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "log-archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		archiver = logarchive.NewFilesystemArchiver(dir)
+		comp = compression.NewGzipCompression()
+
+		events = []event.Envelope{
+			envelope("0", "hello"),
+			envelope("1", "world"),
+			envelope("2", "bye"),
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.EventsStub = func(uint) (db.EventSource, error) {
+			fakeEventSource := new(dbfakes.FakeEventSource)
+			for i, ev := range events {
+				fakeEventSource.NextReturnsOnCall(i, ev, nil)
+			}
+			fakeEventSource.NextReturnsOnCall(len(events), event.Envelope{}, db.ErrEndOfBuildEventStream)
+			return fakeEventSource, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		buildLogs = logarchive.NewBuildLogs(archiver, comp)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	readAll := func(source db.EventSource) []event.Envelope {
+		defer source.Close()
+
+		var read []event.Envelope
+		for {
+			ev, err := source.Next()
+			if err == db.ErrEndOfBuildEventStream {
+				return read
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			read = append(read, ev)
+		}
+	}
+
+	It("archives the build's events and reads them back", func() {
+		location, err := buildLogs.Archive(context.TODO(), fakeBuild)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(location).To(HaveSuffix("/builds/42/events.gzip"))
+
+		Expect(fakeBuild.EventsCallCount()).To(Equal(1))
+		Expect(fakeBuild.EventsArgsForCall(0)).To(Equal(uint(0)))
+
+		source, err := buildLogs.Events(context.TODO(), location, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readAll(source)).To(Equal(events))
+	})
+
+	It("reads the events from the given event ID", func() {
+		location, err := buildLogs.Archive(context.TODO(), fakeBuild)
+		Expect(err).NotTo(HaveOccurred())
+
+		source, err := buildLogs.Events(context.TODO(), location, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readAll(source)).To(Equal(events[2:]))
+	})
+
+	Context("when the archive was written with a different compression", func() {
+		It("still reads it back", func() {
+			location, err := logarchive.NewBuildLogs(archiver, compression.NewZstdCompression()).Archive(context.TODO(), fakeBuild)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location).To(HaveSuffix("/builds/42/events.zstd"))
+
+			source, err := buildLogs.Events(context.TODO(), location, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readAll(source)).To(Equal(events))
+		})
+	})
+
+	Context("when reading the build's events fails", func() {
+		BeforeEach(func() {
+			fakeBuild.EventsStub = func(uint) (db.EventSource, error) {
+				fakeEventSource := new(dbfakes.FakeEventSource)
+				fakeEventSource.NextReturns(event.Envelope{}, errors.New("nope"))
+				return fakeEventSource, nil
+			}
+		})
+
+		It("returns the error without archiving anything", func() {
+			_, err := buildLogs.Archive(context.TODO(), fakeBuild)
+			Expect(err).To(MatchError("nope"))
+
+			_, err = os.Stat(dir + "/builds/42/events.gzip")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Context("when the archiver fails", func() {
+		BeforeEach(func() {
+			fakeArchiver := new(logarchivefakes.FakeArchiver)
+			fakeArchiver.ArchiveReturns("", errors.New("disk full"))
+			archiver = fakeArchiver
+		})
+
+		It("returns the error", func() {
+			_, err := buildLogs.Archive(context.TODO(), fakeBuild)
+			Expect(err).To(MatchError("disk full"))
+		})
+	})
+})
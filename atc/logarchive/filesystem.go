@@ -0,0 +1,73 @@
+package logarchive
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+type filesystemArchiver struct {
+	dir string
+}
+
+// NewFilesystemArchiver returns an Archiver which stores build logs as files
+// beneath the given directory.
+func NewFilesystemArchiver(dir string) Archiver {
+	return &filesystemArchiver{dir: dir}
+}
+
+func (archiver *filesystemArchiver) Archive(ctx context.Context, key string, content io.Reader) (string, error) {
+	path, err := filepath.Abs(filepath.Join(archiver.dir, filepath.FromSlash(key)))
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	// write to a temporary file first so that an interrupted archive never
+	// leaves a truncated file at the final path
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, content)
+	if err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return "", err
+	}
+
+	location := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return location.String(), nil
+}
+
+func (archiver *filesystemArchiver) Retrieve(ctx context.Context, location string) (io.ReadCloser, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "file" {
+		return nil, ErrUnsupportedLocation
+	}
+
+	return os.Open(filepath.FromSlash(u.Path))
+}
@@ -0,0 +1,66 @@
+package logarchive_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/logarchive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilesystemArchiver", func() {
+	var (
+		dir      string
+		archiver logarchive.Archiver
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "log-archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		archiver = logarchive.NewFilesystemArchiver(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("stores the content beneath the directory and retrieves it", func() {
+		location, err := archiver.Archive(context.TODO(), "builds/1/events.gzip", strings.NewReader("some-content"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(location).To(HavePrefix("file://"))
+		Expect(location).To(HaveSuffix("/builds/1/events.gzip"))
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "builds", "1", "events.gzip"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+
+		reader, err := archiver.Retrieve(context.TODO(), location)
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err = ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("does not leave temporary files behind", func() {
+		_, err := archiver.Archive(context.TODO(), "builds/1/events.gzip", strings.NewReader("some-content"))
+		Expect(err).NotTo(HaveOccurred())
+
+		entries, err := ioutil.ReadDir(filepath.Join(dir, "builds", "1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("refuses to retrieve locations of other archivers", func() {
+		_, err := archiver.Retrieve(context.TODO(), "s3://some-bucket/builds/1/events.gzip")
+		Expect(err).To(Equal(logarchive.ErrUnsupportedLocation))
+	})
+})
@@ -0,0 +1,203 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package logarchivefakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/concourse/concourse/atc/logarchive"
+)
+
+type FakeArchiver struct {
+	ArchiveStub        func(context.Context, string, io.Reader) (string, error)
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+	}
+	archiveReturns struct {
+		result1 string
+		result2 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RetrieveStub        func(context.Context, string) (io.ReadCloser, error)
+	retrieveMutex       sync.RWMutex
+	retrieveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	retrieveReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	retrieveReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeArchiver) Archive(arg1 context.Context, arg2 string, arg3 io.Reader) (string, error) {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	stub := fake.ArchiveStub
+	fakeReturns := fake.archiveReturns
+	fake.recordInvocation("Archive", []interface{}{arg1, arg2, arg3})
+	fake.archiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArchiver) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakeArchiver) ArchiveCalls(stub func(context.Context, string, io.Reader) (string, error)) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = stub
+}
+
+func (fake *FakeArchiver) ArchiveArgsForCall(i int) (context.Context, string, io.Reader) {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	argsForCall := fake.archiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeArchiver) ArchiveReturns(result1 string, result2 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiver) ArchiveReturnsOnCall(i int, result1 string, result2 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiver) Retrieve(arg1 context.Context, arg2 string) (io.ReadCloser, error) {
+	fake.retrieveMutex.Lock()
+	ret, specificReturn := fake.retrieveReturnsOnCall[len(fake.retrieveArgsForCall)]
+	fake.retrieveArgsForCall = append(fake.retrieveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RetrieveStub
+	fakeReturns := fake.retrieveReturns
+	fake.recordInvocation("Retrieve", []interface{}{arg1, arg2})
+	fake.retrieveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArchiver) RetrieveCallCount() int {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return len(fake.retrieveArgsForCall)
+}
+
+func (fake *FakeArchiver) RetrieveCalls(stub func(context.Context, string) (io.ReadCloser, error)) {
+	fake.retrieveMutex.Lock()
+	defer fake.retrieveMutex.Unlock()
+	fake.RetrieveStub = stub
+}
+
+func (fake *FakeArchiver) RetrieveArgsForCall(i int) (context.Context, string) {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	argsForCall := fake.retrieveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeArchiver) RetrieveReturns(result1 io.ReadCloser, result2 error) {
+	fake.retrieveMutex.Lock()
+	defer fake.retrieveMutex.Unlock()
+	fake.RetrieveStub = nil
+	fake.retrieveReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiver) RetrieveReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.retrieveMutex.Lock()
+	defer fake.retrieveMutex.Unlock()
+	fake.RetrieveStub = nil
+	if fake.retrieveReturnsOnCall == nil {
+		fake.retrieveReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.retrieveReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeArchiver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ logarchive.Archiver = new(FakeArchiver)
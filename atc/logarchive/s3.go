@@ -0,0 +1,102 @@
+package logarchive
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Config configures archiving to S3 or an S3-compatible store such as MinIO.
+type S3Config struct {
+	Bucket          string `long:"build-log-archive-s3-bucket" description:"S3 bucket to archive build logs to once their retention is exceeded, rather than deleting them."`
+	Prefix          string `long:"build-log-archive-s3-prefix" description:"Prefix of the keys build logs are archived under."`
+	Region          string `long:"build-log-archive-s3-region" default:"us-east-1" description:"AWS region of the bucket."`
+	Endpoint        string `long:"build-log-archive-s3-endpoint" description:"URL of an S3-compatible API to use instead of AWS."`
+	ForcePathStyle  bool   `long:"build-log-archive-s3-force-path-style" description:"Address the bucket as part of the path rather than the host name, as required by most S3-compatible stores."`
+	AccessKeyID     string `long:"build-log-archive-s3-access-key-id" description:"Access key ID used to authenticate. Falls back to the default AWS credential chain."`
+	SecretAccessKey string `long:"build-log-archive-s3-secret-access-key" description:"Secret access key used to authenticate."`
+	SessionToken    string `long:"build-log-archive-s3-session-token" description:"Session token used to authenticate."`
+}
+
+type s3Archiver struct {
+	bucket string
+	prefix string
+
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// NewS3Archiver returns an Archiver which stores build logs as objects in an
+// S3 bucket.
+func NewS3Archiver(config S3Config) (Archiver, error) {
+	awsConfig := &aws.Config{
+		Region:           aws.String(config.Region),
+		S3ForcePathStyle: aws.Bool(config.ForcePathStyle),
+	}
+
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+	}
+
+	if config.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, config.SessionToken)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Archiver{
+		bucket: config.Bucket,
+		prefix: config.Prefix,
+
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (archiver *s3Archiver) Archive(ctx context.Context, key string, content io.Reader) (string, error) {
+	key = path.Join(archiver.prefix, key)
+
+	_, err := archiver.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(archiver.bucket),
+		Key:    aws.String(key),
+		Body:   content,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	location := url.URL{Scheme: "s3", Host: archiver.bucket, Path: "/" + key}
+	return location.String(), nil
+}
+
+func (archiver *s3Archiver) Retrieve(ctx context.Context, location string) (io.ReadCloser, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "s3" {
+		return nil, ErrUnsupportedLocation
+	}
+
+	output, err := archiver.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(u.Host),
+		Key:    aws.String(strings.TrimPrefix(u.Path, "/")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Body, nil
+}
@@ -0,0 +1,105 @@
+package logarchive_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/concourse/concourse/atc/logarchive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// objectStore is a minimal stand-in for an S3-compatible API addressed
+// path-style, as MinIO is.
+type objectStore struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func (store *objectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		store.objects[r.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, found := store.objects[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+			return
+		}
+
+		_, _ = w.Write(body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+var _ = Describe("S3Archiver", func() {
+	var (
+		store    *objectStore
+		server   *httptest.Server
+		archiver logarchive.Archiver
+	)
+
+	BeforeEach(func() {
+		store = &objectStore{objects: map[string][]byte{}}
+		server = httptest.NewServer(store)
+
+		var err error
+		archiver, err = logarchive.NewS3Archiver(logarchive.S3Config{
+			Bucket:          "some-bucket",
+			Prefix:          "some-prefix",
+			Region:          "us-east-1",
+			Endpoint:        server.URL,
+			ForcePathStyle:  true,
+			AccessKeyID:     "some-access-key-id",
+			SecretAccessKey: "some-secret-access-key",
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads the content under the prefix and retrieves it", func() {
+		location, err := archiver.Archive(context.TODO(), "builds/1/events.gzip", strings.NewReader("some-content"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(location).To(Equal("s3://some-bucket/some-prefix/builds/1/events.gzip"))
+
+		Expect(store.objects).To(HaveKeyWithValue("/some-bucket/some-prefix/builds/1/events.gzip", []byte("some-content")))
+
+		reader, err := archiver.Retrieve(context.TODO(), location)
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("errors when the object does not exist", func() {
+		_, err := archiver.Retrieve(context.TODO(), "s3://some-bucket/some-prefix/builds/2/events.gzip")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("refuses to retrieve locations of other archivers", func() {
+		_, err := archiver.Retrieve(context.TODO(), "file:///some/dir/builds/1/events.gzip")
+		Expect(err).To(Equal(logarchive.ErrUnsupportedLocation))
+	})
+})
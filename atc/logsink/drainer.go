@@ -0,0 +1,384 @@
+package logsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// batchSize is the maximum number of entries written to a sink at once.
+const batchSize = 500
+
+//counterfeiter:generate . Drainer
+type Drainer interface {
+	Run(context.Context) error
+}
+
+type drainer struct {
+	buildFactory db.BuildFactory
+	sinks        []LogSink
+
+	// progress is the number of batches of each undrained build that each
+	// sink has accepted, so that retrying a build does not write it again to
+	// the sinks which already have it.
+	progress map[int][]int
+}
+
+// NewDrainer returns a Drainer which writes the events of every completed
+// build that has not yet been drained to each of the sinks. A build is only
+// marked as drained once every sink has accepted all of its entries.
+//
+// A sink which fails is not written to again until the next run, and the
+// builds it is missing are retried then; the other sinks and builds carry
+// on. Progress is kept in memory, so a sink may still receive some entries
+// more than once if the ATC restarts. Entries which a sink rejects outright
+// are dropped, as retrying them would block the build forever.
+func NewDrainer(buildFactory db.BuildFactory, sinks []LogSink) Drainer {
+	return &drainer{
+		buildFactory: buildFactory,
+		sinks:        sinks,
+		progress:     map[int][]int{},
+	}
+}
+
+func (d *drainer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("log-drainer")
+
+	builds, err := d.buildFactory.GetDrainableBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-drainable-builds", err)
+		return err
+	}
+
+	d.forgetProgress(builds)
+
+	if len(builds) == 0 {
+		return nil
+	}
+
+	// ignore any errors from closing the sinks
+	defer func() {
+		for _, sink := range d.sinks {
+			_ = sink.Close()
+		}
+	}()
+
+	failedSinks := make([]error, len(d.sinks))
+
+	var drainErr error
+	for _, build := range builds {
+		err := d.drainBuild(ctx, logger, build, failedSinks)
+		if err != nil && drainErr == nil {
+			drainErr = err
+		}
+	}
+
+	return drainErr
+}
+
+// forgetProgress discards the progress of builds which no longer need to be
+// drained, e.g. because they have been deleted.
+func (d *drainer) forgetProgress(builds []db.Build) {
+	drainable := map[int]bool{}
+	for _, build := range builds {
+		drainable[build.ID()] = true
+	}
+
+	for buildID := range d.progress {
+		if !drainable[buildID] {
+			delete(d.progress, buildID)
+		}
+	}
+}
+
+func (d *drainer) drainBuild(ctx context.Context, logger lager.Logger, build db.Build, failedSinks []error) error {
+	logger = logger.Session("drain-build", build.LagerData())
+
+	progress, found := d.progress[build.ID()]
+	if !found {
+		progress = make([]int, len(d.sinks))
+		d.progress[build.ID()] = progress
+	}
+
+	events, err := build.Events(0)
+	if err != nil {
+		logger.Error("failed-to-get-events", err)
+		return err
+	}
+
+	// ignore any errors coming from events.Close()
+	defer db.Close(events)
+
+	stepNames := stepNames(build.PrivatePlan())
+
+	batch := 0
+	entries := []Entry{}
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+			logger.Error("failed-to-get-next-event", err)
+			return err
+		}
+
+		entry, ok, err := newEntry(build, stepNames, ev)
+		if err != nil {
+			logger.Error("failed-to-unmarshal", err)
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, entry)
+
+		if len(entries) == batchSize {
+			d.write(ctx, logger, batch, entries, progress, failedSinks)
+			batch++
+			entries = []Entry{}
+		}
+	}
+
+	if len(entries) > 0 {
+		d.write(ctx, logger, batch, entries, progress, failedSinks)
+		batch++
+	}
+
+	for i, accepted := range progress {
+		if accepted < batch {
+			return fmt.Errorf("build %d not drained: %w", build.ID(), failedSinks[i])
+		}
+	}
+
+	err = build.SetDrained(true)
+	if err != nil {
+		logger.Error("failed-to-update-status", err)
+		return err
+	}
+
+	delete(d.progress, build.ID())
+
+	return nil
+}
+
+// write writes the batch to every sink which has not yet accepted it and has
+// not failed during this run, recording the sinks' progress.
+func (d *drainer) write(ctx context.Context, logger lager.Logger, batch int, entries []Entry, progress []int, failedSinks []error) {
+	for i, sink := range d.sinks {
+		if progress[i] > batch || failedSinks[i] != nil {
+			continue
+		}
+
+		err := sink.Write(ctx, entries)
+		if err != nil {
+			var rejected RejectedError
+			if !errors.As(err, &rejected) {
+				logger.Error("failed-to-write-to-sink", err, lager.Data{"sink": i})
+				failedSinks[i] = err
+				continue
+			}
+
+			logger.Error("dropping-entries-rejected-by-sink", err, lager.Data{
+				"sink":        i,
+				"entries":     len(entries),
+				"first-event": entries[0].EventID,
+				"last-event":  entries[len(entries)-1].EventID,
+			})
+		}
+
+		progress[i] = batch + 1
+	}
+}
+
+// stepNames maps the IDs of the build's steps to their names, so that
+// entries can say which step they came from.
+func stepNames(plan atc.Plan) map[event.OriginID]string {
+	names := map[event.OriginID]string{}
+
+	plan.Each(func(p *atc.Plan) {
+		var name string
+		switch {
+		case p.Get != nil:
+			name = p.Get.Name
+		case p.Put != nil:
+			name = p.Put.Name
+		case p.Check != nil:
+			name = p.Check.Name
+		case p.Task != nil:
+			name = p.Task.Name
+		case p.Run != nil:
+			name = p.Run.Message
+		case p.SetPipeline != nil:
+			name = p.SetPipeline.Name
+		case p.LoadVar != nil:
+			name = p.LoadVar.Name
+		case p.Approval != nil:
+			name = p.Approval.Name
+		case p.ArtifactInput != nil:
+			name = p.ArtifactInput.Name
+		case p.ArtifactOutput != nil:
+			name = p.ArtifactOutput.Name
+		}
+
+		if name != "" {
+			names[event.OriginID(p.ID)] = name
+		}
+	})
+
+	return names
+}
+
+// newEntry converts a build event into an entry. Events which carry nothing
+// worth logging are skipped.
+func newEntry(build db.Build, stepNames map[event.OriginID]string, ev event.Envelope) (Entry, bool, error) {
+	var (
+		ts      int64
+		origin  event.Origin
+		message string
+	)
+
+	switch ev.Event {
+	case event.EventTypeInitialize:
+		var initEvent event.Initialize
+		err := json.Unmarshal(*ev.Data, &initEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = initEvent.Time
+		origin = initEvent.Origin
+		message = "initializing"
+	case event.EventTypeInitializeGet:
+		var initGetEvent event.InitializeGet
+		err := json.Unmarshal(*ev.Data, &initGetEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = initGetEvent.Time
+		origin = initGetEvent.Origin
+		message = "get initializing"
+	case event.EventTypeInitializePut:
+		var initPutEvent event.InitializePut
+		err := json.Unmarshal(*ev.Data, &initPutEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = initPutEvent.Time
+		origin = initPutEvent.Origin
+		message = "put initializing"
+	case event.EventTypeInitializeTask:
+		var initTaskEvent event.InitializeTask
+		err := json.Unmarshal(*ev.Data, &initTaskEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = initTaskEvent.Time
+		origin = initTaskEvent.Origin
+		message = "task initializing"
+	case event.EventTypeSelectedWorker:
+		var selectedWorkerEvent event.SelectedWorker
+		err := json.Unmarshal(*ev.Data, &selectedWorkerEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = selectedWorkerEvent.Time
+		origin = selectedWorkerEvent.Origin
+		message = fmt.Sprintf("selected worker: %s", selectedWorkerEvent.WorkerName)
+	case event.EventTypeStartTask:
+		var startTaskEvent event.StartTask
+		err := json.Unmarshal(*ev.Data, &startTaskEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = startTaskEvent.Time
+		origin = startTaskEvent.Origin
+
+		buildConfig := startTaskEvent.TaskConfig
+		argv := strings.Join(append([]string{buildConfig.Run.Path}, buildConfig.Run.Args...), " ")
+		message = fmt.Sprintf("running %s", argv)
+	case event.EventTypeLog:
+		var logEvent event.Log
+		err := json.Unmarshal(*ev.Data, &logEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = logEvent.Time
+		origin = logEvent.Origin
+		message = logEvent.Payload
+	case event.EventTypeFinishGet:
+		var finishGetEvent event.FinishGet
+		err := json.Unmarshal(*ev.Data, &finishGetEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = finishGetEvent.Time
+		origin = finishGetEvent.Origin
+
+		version, _ := json.Marshal(finishGetEvent.FetchedVersion)
+		metadata, _ := json.Marshal(finishGetEvent.FetchedMetadata)
+		message = fmt.Sprintf("get {\"version\": %s, \"metadata\": %s", string(version), string(metadata))
+	case event.EventTypeFinishPut:
+		var finishPutEvent event.FinishPut
+		err := json.Unmarshal(*ev.Data, &finishPutEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = finishPutEvent.Time
+		origin = finishPutEvent.Origin
+
+		version, _ := json.Marshal(finishPutEvent.CreatedVersion)
+		metadata, _ := json.Marshal(finishPutEvent.CreatedMetadata)
+		message = fmt.Sprintf("put {\"version\": %s, \"metadata\": %s", string(version), string(metadata))
+	case event.EventTypeError:
+		var errorEvent event.Error
+		err := json.Unmarshal(*ev.Data, &errorEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = errorEvent.Time
+		origin = errorEvent.Origin
+		message = errorEvent.Message
+	case event.EventTypeStatus:
+		var statusEvent event.Status
+		err := json.Unmarshal(*ev.Data, &statusEvent)
+		if err != nil {
+			return Entry{}, false, err
+		}
+		ts = statusEvent.Time
+		message = statusEvent.Status.String()
+	}
+
+	if message == "" {
+		return Entry{}, false, nil
+	}
+
+	return Entry{
+		BuildID:              build.ID(),
+		BuildName:            build.Name(),
+		TeamName:             build.TeamName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		JobName:              build.JobName(),
+		ResourceName:         build.ResourceName(),
+
+		OriginID: origin.ID.String(),
+		StepName: stepNames[origin.ID],
+		Source:   string(origin.Source),
+
+		EventID:   ev.EventID,
+		EventType: ev.Event,
+		Time:      time.Unix(ts, 0),
+		Message:   message,
+	}, true, nil
+}
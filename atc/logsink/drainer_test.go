@@ -0,0 +1,250 @@
+package logsink_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logsink"
+	"github.com/concourse/concourse/atc/logsink/logsinkfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drainer", func() {
+	var (
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild        *dbfakes.FakeBuild
+		fakeEventSource  *dbfakes.FakeEventSource
+		fakeSink1        *logsinkfakes.FakeLogSink
+		fakeSink2        *logsinkfakes.FakeLogSink
+
+		drainer logsink.Drainer
+		runErr  error
+	)
+
+	envelope := func(eventType atc.EventType, id string, data string) event.Envelope {
+		payload := json.RawMessage(data)
+		return event.Envelope{
+			Data:    &payload,
+			Event:   eventType,
+			EventID: id,
+		}
+	}
+
+	newEventSource := func() *dbfakes.FakeEventSource {
+		eventSource := new(dbfakes.FakeEventSource)
+		eventSource.NextReturnsOnCall(0, envelope(event.EventTypeLog, "0", `{"time":1533744538,"origin":{"id":"some-task-id","source":"stdout"},"payload":"hello\n"}`), nil)
+		eventSource.NextReturnsOnCall(1, envelope(event.EventTypeFinishTask, "1", `{"time":1533744539,"origin":{"id":"some-task-id"},"exit_status":0}`), nil)
+		eventSource.NextReturnsOnCall(2, envelope(event.EventTypeStatus, "2", `{"time":1533744540,"status":"succeeded"}`), nil)
+		eventSource.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+		return eventSource
+	}
+
+	BeforeEach(func() {
+		fakeEventSource = newEventSource()
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PrivatePlanReturns(atc.Plan{
+			ID: "some-do-id",
+			Do: &atc.DoPlan{
+				{ID: "some-get-id", Get: &atc.GetPlan{Name: "some-input"}},
+				{ID: "some-task-id", Task: &atc.TaskPlan{Name: "some-task"}},
+			},
+		})
+		fakeBuild.EventsReturns(fakeEventSource, nil)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.GetDrainableBuildsReturns([]db.Build{fakeBuild}, nil)
+
+		fakeSink1 = new(logsinkfakes.FakeLogSink)
+		fakeSink2 = new(logsinkfakes.FakeLogSink)
+
+		drainer = logsink.NewDrainer(fakeBuildFactory, []logsink.LogSink{fakeSink1, fakeSink2})
+	})
+
+	JustBeforeEach(func() {
+		runErr = drainer.Run(context.TODO())
+	})
+
+	It("writes the build's entries to every sink with structured fields", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		expected := []logsink.Entry{
+			{
+				BuildID:      42,
+				BuildName:    "7",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				OriginID:     "some-task-id",
+				StepName:     "some-task",
+				Source:       "stdout",
+				EventID:      "0",
+				EventType:    event.EventTypeLog,
+				Time:         time.Unix(1533744538, 0),
+				Message:      "hello\n",
+			},
+			{
+				BuildID:      42,
+				BuildName:    "7",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				EventID:      "2",
+				EventType:    event.EventTypeStatus,
+				Time:         time.Unix(1533744540, 0),
+				Message:      "succeeded",
+			},
+		}
+
+		for _, sink := range []*logsinkfakes.FakeLogSink{fakeSink1, fakeSink2} {
+			Expect(sink.WriteCallCount()).To(Equal(1))
+			_, entries := sink.WriteArgsForCall(0)
+			Expect(entries).To(Equal(expected))
+		}
+	})
+
+	It("marks the build as drained", func() {
+		Expect(fakeBuild.SetDrainedCallCount()).To(Equal(1))
+		Expect(fakeBuild.SetDrainedArgsForCall(0)).To(BeTrue())
+	})
+
+	It("closes the sinks", func() {
+		Expect(fakeSink1.CloseCallCount()).To(Equal(1))
+		Expect(fakeSink2.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when a build has more entries than fit in a batch", func() {
+		BeforeEach(func() {
+			for i := 0; i < 600; i++ {
+				fakeEventSource.NextReturnsOnCall(i, envelope(event.EventTypeLog, "0", `{"time":1533744538,"payload":"hello"}`), nil)
+			}
+			fakeEventSource.NextReturnsOnCall(600, event.Envelope{}, db.ErrEndOfBuildEventStream)
+		})
+
+		It("writes them in batches", func() {
+			Expect(fakeSink1.WriteCallCount()).To(Equal(2))
+
+			_, entries := fakeSink1.WriteArgsForCall(0)
+			Expect(entries).To(HaveLen(500))
+
+			_, entries = fakeSink1.WriteArgsForCall(1)
+			Expect(entries).To(HaveLen(100))
+		})
+	})
+
+	Context("when a sink fails", func() {
+		BeforeEach(func() {
+			fakeSink1.WriteReturns(errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError(ContainSubstring("nope")))
+		})
+
+		It("still writes to the other sinks", func() {
+			Expect(fakeSink2.WriteCallCount()).To(Equal(1))
+		})
+
+		It("does not mark the build as drained, so that it is retried", func() {
+			Expect(fakeBuild.SetDrainedCallCount()).To(BeZero())
+		})
+
+		It("still closes the sinks", func() {
+			Expect(fakeSink1.CloseCallCount()).To(Equal(1))
+			Expect(fakeSink2.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when the build is retried", func() {
+			JustBeforeEach(func() {
+				fakeBuild.EventsReturns(newEventSource(), nil)
+				fakeSink1.WriteReturns(nil)
+
+				runErr = drainer.Run(context.TODO())
+			})
+
+			It("only writes to the sinks which are missing the build", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(fakeSink1.WriteCallCount()).To(Equal(2))
+				Expect(fakeSink2.WriteCallCount()).To(Equal(1))
+			})
+
+			It("marks the build as drained", func() {
+				Expect(fakeBuild.SetDrainedCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when a sink rejects the entries", func() {
+		BeforeEach(func() {
+			fakeSink1.WriteReturns(logsink.RejectedError{Err: errors.New("entry out of order")})
+		})
+
+		It("drops them and marks the build as drained", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeSink2.WriteCallCount()).To(Equal(1))
+			Expect(fakeBuild.SetDrainedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when there are other builds to drain", func() {
+		var otherBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			otherBuild = new(dbfakes.FakeBuild)
+			otherBuild.IDReturns(43)
+			otherBuild.EventsReturns(newEventSource(), nil)
+
+			fakeBuild.EventsReturns(nil, errors.New("disaster"))
+
+			fakeBuildFactory.GetDrainableBuildsReturns([]db.Build{fakeBuild, otherBuild}, nil)
+		})
+
+		It("carries on when one of them fails", func() {
+			Expect(runErr).To(MatchError("disaster"))
+
+			Expect(fakeBuild.SetDrainedCallCount()).To(BeZero())
+			Expect(otherBuild.SetDrainedCallCount()).To(Equal(1))
+		})
+
+		Context("when a sink fails", func() {
+			BeforeEach(func() {
+				fakeBuild.EventsReturns(fakeEventSource, nil)
+				fakeSink1.WriteReturns(errors.New("nope"))
+			})
+
+			It("does not write to it again until the next run", func() {
+				Expect(fakeSink1.WriteCallCount()).To(Equal(1))
+				Expect(fakeSink2.WriteCallCount()).To(Equal(2))
+
+				Expect(fakeBuild.SetDrainedCallCount()).To(BeZero())
+				Expect(otherBuild.SetDrainedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when there are no builds to drain", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetDrainableBuildsReturns(nil, nil)
+		})
+
+		It("does not touch the sinks", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeSink1.WriteCallCount()).To(BeZero())
+			Expect(fakeSink1.CloseCallCount()).To(BeZero())
+		})
+	})
+})
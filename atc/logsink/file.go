@@ -0,0 +1,135 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileConfig configures a sink which appends entries to a local file as
+// newline-delimited JSON, rotating it once it grows too large.
+type FileConfig struct {
+	Path       string `long:"log-sink-file-path" description:"File to append build logs to as newline-delimited JSON."`
+	MaxSize    int64  `long:"log-sink-file-max-size" default:"104857600" description:"Size in bytes the file may grow to before it is rotated."`
+	MaxBackups int    `long:"log-sink-file-max-backups" default:"5" description:"Number of rotated files to keep, named with the suffixes .1 (newest) to .N (oldest)."`
+}
+
+type fileSink struct {
+	config FileConfig
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+func NewFileSink(config FileConfig) LogSink {
+	return &fileSink{config: config}
+}
+
+func (sink *fileSink) Write(ctx context.Context, entries []Entry) error {
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	for _, entry := range entries {
+		err := encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sink.file == nil {
+		err := sink.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	if sink.config.MaxSize > 0 && sink.size > 0 && sink.size+int64(buf.Len()) > sink.config.MaxSize {
+		err := sink.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := sink.file.Write(buf.Bytes())
+	sink.size += int64(n)
+	return err
+}
+
+func (sink *fileSink) Close() error {
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	if sink.file == nil {
+		return nil
+	}
+
+	err := sink.file.Close()
+	sink.file = nil
+	return err
+}
+
+func (sink *fileSink) open() error {
+	file, err := os.OpenFile(sink.config.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	sink.file = file
+	sink.size = info.Size()
+
+	return nil
+}
+
+// rotate shifts each backup along by one, dropping the oldest, moves the
+// current file to the first backup and starts a new file.
+func (sink *fileSink) rotate() error {
+	err := sink.file.Close()
+	sink.file = nil
+	if err != nil {
+		return err
+	}
+
+	if sink.config.MaxBackups <= 0 {
+		err = os.Remove(sink.config.Path)
+		if err != nil {
+			return err
+		}
+
+		return sink.open()
+	}
+
+	err = os.Remove(sink.backup(sink.config.MaxBackups))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for i := sink.config.MaxBackups - 1; i >= 1; i-- {
+		err = os.Rename(sink.backup(i), sink.backup(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	err = os.Rename(sink.config.Path, sink.backup(1))
+	if err != nil {
+		return err
+	}
+
+	return sink.open()
+}
+
+func (sink *fileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", sink.config.Path, i)
+}
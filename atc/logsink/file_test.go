@@ -0,0 +1,93 @@
+package logsink_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc/logsink"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileSink", func() {
+	var (
+		dir    string
+		path   string
+		config logsink.FileConfig
+		sink   logsink.LogSink
+	)
+
+	readEntries := func(path string) []logsink.Entry {
+		file, err := os.Open(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+
+		var entries []logsink.Entry
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var entry logsink.Entry
+			Expect(json.Unmarshal(scanner.Bytes(), &entry)).To(Succeed())
+			entries = append(entries, entry)
+		}
+
+		Expect(scanner.Err()).NotTo(HaveOccurred())
+		return entries
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "log-sink")
+		Expect(err).NotTo(HaveOccurred())
+
+		path = filepath.Join(dir, "builds.log")
+		config = logsink.FileConfig{
+			Path:       path,
+			MaxSize:    1024 * 1024,
+			MaxBackups: 2,
+		}
+	})
+
+	JustBeforeEach(func() {
+		sink = logsink.NewFileSink(config)
+	})
+
+	AfterEach(func() {
+		Expect(sink.Close()).To(Succeed())
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("appends the entries as newline-delimited JSON", func() {
+		Expect(sink.Write(context.TODO(), someEntries())).To(Succeed())
+		Expect(sink.Close()).To(Succeed())
+
+		Expect(sink.Write(context.TODO(), someEntries()[:1])).To(Succeed())
+
+		Expect(readEntries(path)).To(Equal(append(someEntries(), someEntries()[0])))
+	})
+
+	Context("when the file would grow beyond its maximum size", func() {
+		BeforeEach(func() {
+			config.MaxSize = 300
+		})
+
+		It("rotates it, keeping the configured number of backups", func() {
+			entries := someEntries()
+
+			for i := 0; i < 4; i++ {
+				Expect(sink.Write(context.TODO(), entries[:1])).To(Succeed())
+			}
+
+			Expect(readEntries(path)).To(HaveLen(1))
+			Expect(readEntries(path + ".1")).To(HaveLen(1))
+			Expect(readEntries(path + ".2")).To(HaveLen(1))
+
+			_, err := os.Stat(path + ".3")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
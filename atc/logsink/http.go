@@ -0,0 +1,91 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// HTTPConfig configures a sink which posts entries as newline-delimited
+// JSON.
+type HTTPConfig struct {
+	URL     string            `long:"log-sink-http-url" description:"URL to POST build logs to as newline-delimited JSON."`
+	Headers map[string]string `long:"log-sink-http-header" value-name:"NAME:VALUE" description:"Header to send with each request to the HTTP log sink, e.g. for authorization. Can be specified multiple times."`
+	Timeout time.Duration     `long:"log-sink-http-timeout" default:"30s" description:"Timeout for each request to the HTTP log sink."`
+}
+
+type httpSink struct {
+	config HTTPConfig
+	client *http.Client
+}
+
+func NewHTTPSink(config HTTPConfig) LogSink {
+	return &httpSink{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (sink *httpSink) Write(ctx context.Context, entries []Entry) error {
+	body := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(body)
+	for _, entry := range entries {
+		err := encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.config.URL, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for name, value := range sink.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	return send(sink.client, req)
+}
+
+func (sink *httpSink) Close() error {
+	return nil
+}
+
+// send performs the request, treating any non-2xx response as an error. A
+// 4xx response means the entries themselves were refused, so it is returned
+// as a RejectedError, apart from timeouts and rate limiting which are worth
+// retrying.
+func send(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("%s responded with %s: %s", req.URL.Host, resp.Status, bytes.TrimSpace(message))
+
+		if resp.StatusCode >= 400 && resp.StatusCode <= 499 &&
+			resp.StatusCode != http.StatusRequestTimeout &&
+			resp.StatusCode != http.StatusTooManyRequests {
+			return RejectedError{Err: err}
+		}
+
+		return err
+	}
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package logsink_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logsink"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func someEntries() []logsink.Entry {
+	return []logsink.Entry{
+		{
+			BuildID:      42,
+			BuildName:    "7",
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			OriginID:     "some-origin",
+			StepName:     "some-task",
+			Source:       "stdout",
+			EventID:      "0",
+			EventType:    event.EventTypeLog,
+			Time:         time.Unix(1533744538, 0).UTC(),
+			Message:      "hello",
+		},
+		{
+			BuildID:      42,
+			BuildName:    "7",
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			EventID:      "1",
+			EventType:    event.EventTypeStatus,
+			Time:         time.Unix(1533744539, 0).UTC(),
+			Message:      "succeeded",
+		},
+	}
+}
+
+var _ = Describe("HTTPSink", func() {
+	var (
+		server *ghttp.Server
+		sink   logsink.LogSink
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		sink = logsink.NewHTTPSink(logsink.HTTPConfig{
+			URL:     server.URL() + "/logs",
+			Headers: map[string]string{"Authorization": "Bearer some-token"},
+			Timeout: time.Second,
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts the entries as newline-delimited JSON", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/logs"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/x-ndjson"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+				ghttp.VerifyBody([]byte(
+					`{"build_id":42,"build_name":"7","team_name":"some-team","pipeline_name":"some-pipeline","job_name":"some-job","origin_id":"some-origin","step_name":"some-task","source":"stdout","event_id":"0","event_type":"log","time":"2018-08-08T16:08:58Z","message":"hello"}`+"\n"+
+						`{"build_id":42,"build_name":"7","team_name":"some-team","pipeline_name":"some-pipeline","job_name":"some-job","event_id":"1","event_type":"status","time":"2018-08-08T16:08:59Z","message":"succeeded"}`+"\n",
+				)),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			),
+		)
+
+		Expect(sink.Write(context.TODO(), someEntries())).To(Succeed())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	Context("when the server responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, "upstream down"))
+		})
+
+		It("returns an error which is worth retrying", func() {
+			err := sink.Write(context.TODO(), someEntries())
+			Expect(err).To(MatchError(ContainSubstring("502 Bad Gateway: upstream down")))
+			Expect(errors.As(err, &logsink.RejectedError{})).To(BeFalse())
+		})
+	})
+
+	Context("when the server rejects the entries", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, "bad entry"))
+		})
+
+		It("returns a RejectedError", func() {
+			err := sink.Write(context.TODO(), someEntries())
+			Expect(err).To(MatchError(ContainSubstring("400 Bad Request: bad entry")))
+			Expect(errors.As(err, &logsink.RejectedError{})).To(BeTrue())
+		})
+	})
+
+	Context("when the server is rate limiting", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusTooManyRequests, "slow down"))
+		})
+
+		It("returns an error which is worth retrying", func() {
+			err := sink.Write(context.TODO(), someEntries())
+			Expect(err).To(HaveOccurred())
+			Expect(errors.As(err, &logsink.RejectedError{})).To(BeFalse())
+		})
+	})
+})
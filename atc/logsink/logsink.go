@@ -0,0 +1,88 @@
+// Package logsink drains the events of completed builds to external log
+// platforms, carrying the build's team, pipeline, job and step as structured
+// fields.
+package logsink
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Entry is a single line of a build's log along with where it came from.
+type Entry struct {
+	BuildID              int              `json:"build_id"`
+	BuildName            string           `json:"build_name"`
+	TeamName             string           `json:"team_name"`
+	PipelineName         string           `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string           `json:"job_name,omitempty"`
+	ResourceName         string           `json:"resource_name,omitempty"`
+
+	OriginID string `json:"origin_id,omitempty"`
+	StepName string `json:"step_name,omitempty"`
+	Source   string `json:"source,omitempty"`
+
+	EventID   string        `json:"event_id"`
+	EventType atc.EventType `json:"event_type"`
+	Time      time.Time     `json:"time"`
+	Message   string        `json:"message"`
+}
+
+// LogSink delivers build log entries to a log platform.
+//
+//counterfeiter:generate . LogSink
+type LogSink interface {
+	// Write delivers a batch of entries, all belonging to the same build and
+	// in the order they were emitted.
+	Write(context.Context, []Entry) error
+
+	// Close releases any connection held open between writes. The sink may
+	// be written to again afterwards.
+	Close() error
+}
+
+// RejectedError is returned by a sink which refuses a batch of entries
+// outright, e.g. with a 4xx response. The same entries would be refused
+// again, so the drainer drops them rather than retrying the build forever.
+type RejectedError struct {
+	Err error
+}
+
+func (err RejectedError) Error() string {
+	return err.Err.Error()
+}
+
+func (err RejectedError) Unwrap() error {
+	return err.Err
+}
+
+// Config configures the log sinks builds are drained to, in addition to
+// syslog.
+type Config struct {
+	HTTP HTTPConfig
+	Loki LokiConfig
+	File FileConfig
+}
+
+// Sinks returns a LogSink for every sink that is configured.
+func (config Config) Sinks() []LogSink {
+	var sinks []LogSink
+
+	if config.HTTP.URL != "" {
+		sinks = append(sinks, NewHTTPSink(config.HTTP))
+	}
+
+	if config.Loki.URL != "" {
+		sinks = append(sinks, NewLokiSink(config.Loki))
+	}
+
+	if config.File.Path != "" {
+		sinks = append(sinks, NewFileSink(config.File))
+	}
+
+	return sinks
+}
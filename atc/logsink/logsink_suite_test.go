@@ -0,0 +1,13 @@
+package logsink_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLogSink(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Log Sink Suite")
+}
@@ -0,0 +1,148 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LokiConfig configures a sink which pushes entries to a Loki-compatible
+// push API.
+type LokiConfig struct {
+	URL      string        `long:"log-sink-loki-url" description:"Base URL of a Loki-compatible API to push build logs to, e.g. http://loki:3100."`
+	TenantID string        `long:"log-sink-loki-tenant-id" description:"Tenant to push build logs as, sent in the X-Scope-OrgID header."`
+	Username string        `long:"log-sink-loki-username" description:"Username for basic auth against the Loki API."`
+	Password string        `long:"log-sink-loki-password" description:"Password for basic auth against the Loki API."`
+	Timeout  time.Duration `long:"log-sink-loki-timeout" default:"30s" description:"Timeout for each push to the Loki API."`
+}
+
+const lokiPushPath = "/loki/api/v1/push"
+
+type lokiSink struct {
+	config LokiConfig
+	client *http.Client
+}
+
+func NewLokiSink(config LokiConfig) LogSink {
+	return &lokiSink{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Write pushes the entries as one stream per step of each build. Loki
+// refuses entries older than the latest one in their stream, so the build is
+// part of the labels: otherwise builds of the same job which overlap would
+// be pushed out of order. Everything else about the entry is kept in the
+// line as JSON.
+func (sink *lokiSink) Write(ctx context.Context, entries []Entry) error {
+	var push lokiPush
+	streams := map[string]int{}
+
+	// parallel steps of the same name share a stream, and their entries
+	// may be interleaved out of order
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	for _, entry := range sorted {
+		labels := lokiLabels(entry)
+
+		key := labelsKey(labels)
+		i, found := streams[key]
+		if !found {
+			i = len(push.Streams)
+			streams[key] = i
+			push.Streams = append(push.Streams, lokiStream{Stream: labels})
+		}
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		push.Streams[i].Values = append(push.Streams[i].Values, [2]string{
+			strconv.FormatInt(entry.Time.UnixNano(), 10),
+			string(line),
+		})
+	}
+
+	body, err := json.Marshal(push)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(sink.config.URL, "/")+lokiPushPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if sink.config.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", sink.config.TenantID)
+	}
+
+	if sink.config.Username != "" {
+		req.SetBasicAuth(sink.config.Username, sink.config.Password)
+	}
+
+	return send(sink.client, req)
+}
+
+func (sink *lokiSink) Close() error {
+	return nil
+}
+
+func lokiLabels(entry Entry) map[string]string {
+	labels := map[string]string{
+		"source": "concourse",
+		"team":   entry.TeamName,
+		"build":  entry.BuildName,
+	}
+
+	if entry.PipelineName != "" {
+		labels["pipeline"] = entry.PipelineName
+	}
+
+	if entry.JobName != "" {
+		labels["job"] = entry.JobName
+	}
+
+	if entry.ResourceName != "" {
+		labels["resource"] = entry.ResourceName
+	}
+
+	if entry.StepName != "" {
+		labels["step"] = entry.StepName
+	}
+
+	return labels
+}
+
+func labelsKey(labels map[string]string) string {
+	return strings.Join([]string{
+		labels["team"],
+		labels["pipeline"],
+		labels["job"],
+		labels["resource"],
+		labels["build"],
+		labels["step"],
+	}, "\x00")
+}
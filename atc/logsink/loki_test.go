@@ -0,0 +1,148 @@
+package logsink_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/logsink"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LokiSink", func() {
+	var (
+		server *ghttp.Server
+		sink   logsink.LogSink
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		sink = logsink.NewLokiSink(logsink.LokiConfig{
+			URL:      server.URL() + "/",
+			TenantID: "some-tenant",
+			Username: "some-user",
+			Password: "some-password",
+			Timeout:  time.Second,
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("pushes a stream per step of the build labelled with where the entries came from", func() {
+		var push struct {
+			Streams []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][2]string       `json:"values"`
+			} `json:"streams"`
+		}
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/loki/api/v1/push"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyHeaderKV("X-Scope-OrgID", "some-tenant"),
+				ghttp.VerifyBasicAuth("some-user", "some-password"),
+				func(w http.ResponseWriter, r *http.Request) {
+					body, err := ioutil.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(json.Unmarshal(body, &push)).To(Succeed())
+				},
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			),
+		)
+
+		Expect(sink.Write(context.TODO(), someEntries())).To(Succeed())
+
+		Expect(push.Streams).To(HaveLen(2))
+
+		Expect(push.Streams[0].Stream).To(Equal(map[string]string{
+			"source":   "concourse",
+			"team":     "some-team",
+			"build":    "7",
+			"pipeline": "some-pipeline",
+			"job":      "some-job",
+			"step":     "some-task",
+		}))
+		Expect(push.Streams[0].Values).To(HaveLen(1))
+		Expect(push.Streams[0].Values[0][0]).To(Equal("1533744538000000000"))
+		Expect(push.Streams[0].Values[0][1]).To(MatchJSON(`{
+			"build_id": 42,
+			"build_name": "7",
+			"team_name": "some-team",
+			"pipeline_name": "some-pipeline",
+			"job_name": "some-job",
+			"origin_id": "some-origin",
+			"step_name": "some-task",
+			"source": "stdout",
+			"event_id": "0",
+			"event_type": "log",
+			"time": "2018-08-08T16:08:58Z",
+			"message": "hello"
+		}`))
+
+		Expect(push.Streams[1].Stream).To(Equal(map[string]string{
+			"source":   "concourse",
+			"team":     "some-team",
+			"build":    "7",
+			"pipeline": "some-pipeline",
+			"job":      "some-job",
+		}))
+		Expect(push.Streams[1].Values).To(HaveLen(1))
+		Expect(push.Streams[1].Values[0][0]).To(Equal("1533744539000000000"))
+	})
+
+	Context("when the push is rejected", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, "entry out of order"))
+		})
+
+		It("returns an error saying the entries were rejected", func() {
+			err := sink.Write(context.TODO(), someEntries())
+			Expect(err).To(MatchError(ContainSubstring("entry out of order")))
+			Expect(errors.As(err, &logsink.RejectedError{})).To(BeTrue())
+		})
+	})
+
+	Context("when entries of a stream are out of order", func() {
+		var push struct {
+			Streams []struct {
+				Values [][2]string `json:"values"`
+			} `json:"streams"`
+		}
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(json.Unmarshal(body, &push)).To(Succeed())
+					},
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("pushes them in order", func() {
+			entries := someEntries()
+			entries[0].Time = time.Unix(1533744540, 0)
+			entries[1].StepName = entries[0].StepName
+
+			Expect(sink.Write(context.TODO(), entries)).To(Succeed())
+
+			Expect(push.Streams).To(HaveLen(1))
+			Expect(push.Streams[0].Values).To(HaveLen(2))
+			Expect(push.Streams[0].Values[0][0]).To(Equal("1533744539000000000"))
+			Expect(push.Streams[0].Values[1][0]).To(Equal("1533744540000000000"))
+		})
+	})
+})
@@ -33,6 +33,14 @@ type EmitterFactory interface {
 	NewEmitter() (Emitter, error)
 }
 
+// StaticAttributesEmitterFactory is an EmitterFactory which is told the
+// attributes that are attached to every event before its emitter is created,
+// so that it can tell them apart from the event's own.
+type StaticAttributesEmitterFactory interface {
+	EmitterFactory
+	SetStaticAttributes(map[string]string)
+}
+
 type Monitor struct {
 	emitter          Emitter
 	eventHost        string
@@ -140,6 +148,10 @@ func (m *Monitor) Initialize(logger lager.Logger, host string, attributes map[st
 
 	for _, factory := range m.emitterFactories {
 		if factory.IsConfigured() {
+			if staticFactory, ok := factory.(StaticAttributesEmitterFactory); ok {
+				staticFactory.SetStaticAttributes(attributes)
+			}
+
 			emitter, err = factory.NewEmitter()
 			if err != nil {
 				return err
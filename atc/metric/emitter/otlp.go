@@ -0,0 +1,471 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/unit"
+	"google.golang.org/grpc/credentials"
+)
+
+const otlpInstrumentationName = "github.com/concourse/concourse/atc/metric"
+
+type OTLPConfig struct {
+	Address      string            `long:"otlp-metrics-address" description:"OTLP collector address to push metrics to (e.g. localhost:4317 for gRPC or localhost:4318 for HTTP)."`
+	Protocol     string            `long:"otlp-metrics-protocol" default:"grpc" choice:"grpc" choice:"http" description:"Protocol with which metrics are pushed to the OTLP collector."`
+	Headers      map[string]string `long:"otlp-metrics-header" description:"Headers to attach to each push of metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
+	UseTLS       bool              `long:"otlp-metrics-use-tls" description:"Use TLS when pushing metrics to the OTLP collector."`
+	PushInterval time.Duration     `long:"otlp-metrics-push-interval" default:"10s" description:"Interval on which metrics are pushed to the OTLP collector."`
+	ServiceName  string            `long:"otlp-metrics-service-name" default:"concourse-web" description:"Service name to attach to metrics as a resource attribute."`
+	GaugeExpiry  time.Duration     `long:"otlp-metrics-gauge-expiry" default:"5m" description:"Stop reporting a gauge for a set of attributes, e.g. for a worker which has gone away, once it has not been updated for this long."`
+
+	staticAttributes map[string]string
+}
+
+func init() {
+	metric.Metrics.RegisterEmitter(&OTLPConfig{})
+}
+
+func (config *OTLPConfig) Description() string { return "OpenTelemetry (OTLP)" }
+func (config *OTLPConfig) IsConfigured() bool  { return config.Address != "" }
+
+func (config *OTLPConfig) SetStaticAttributes(attributes map[string]string) {
+	config.staticAttributes = attributes
+}
+
+func (config *OTLPConfig) driver() (otlp.ProtocolDriver, error) {
+	switch config.Protocol {
+	case "", "grpc":
+		security := otlpgrpc.WithInsecure()
+		if config.UseTLS {
+			security = otlpgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, ""))
+		}
+
+		return otlpgrpc.NewDriver(
+			otlpgrpc.WithEndpoint(config.Address),
+			otlpgrpc.WithHeaders(config.Headers),
+			security,
+		), nil
+	case "http":
+		opts := []otlphttp.Option{
+			otlphttp.WithEndpoint(config.Address),
+			otlphttp.WithHeaders(config.Headers),
+		}
+		if !config.UseTLS {
+			opts = append(opts, otlphttp.WithInsecure())
+		}
+
+		return otlphttp.NewDriver(opts...), nil
+	default:
+		return nil, fmt.Errorf("unknown OTLP protocol: %s", config.Protocol)
+	}
+}
+
+func (config *OTLPConfig) NewEmitter() (metric.Emitter, error) {
+	driver, err := config.driver()
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, err := otlp.NewExporter(context.Background(), driver)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl := controller.New(
+		processor.New(
+			simple.NewWithHistogramDistribution(
+				histogram.WithExplicitBoundaries(otlpDurationBoundaries),
+			),
+			exporter,
+		),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(config.PushInterval),
+		controller.WithResource(resource.NewWithAttributes(
+			semconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	err = ctrl.Start(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	staticLabels := make([]string, 0, len(config.staticAttributes))
+	for k := range config.staticAttributes {
+		staticLabels = append(staticLabels, k)
+	}
+
+	return NewOTLPEmitter(ctrl.MeterProvider().Meter(otlpInstrumentationName), staticLabels, config.GaugeExpiry), nil
+}
+
+// otlpDurationBoundaries are the histogram bucket boundaries, in
+// milliseconds, shared by every duration instrument. They span fast API
+// responses through to builds that run for hours.
+var otlpDurationBoundaries = []float64{
+	1, 5, 10, 25, 50, 100, 250, 500,
+	1000, 2500, 5000, 10000, 30000, 60000,
+	300000, 600000, 1800000, 3600000, 7200000,
+}
+
+type otlpInstrumentKind int
+
+const (
+	// otlpCounter instruments are fed the delta carried by the event.
+	otlpCounter otlpInstrumentKind = iota
+
+	// otlpUpDownCounter instruments go up by one when the event's value is
+	// 1 and down by one when it is 0.
+	otlpUpDownCounter
+
+	// otlpIncrement instruments count how many times the event happened,
+	// ignoring its value.
+	otlpIncrement
+
+	// otlpGauge instruments report the last value seen for each set of
+	// attributes.
+	otlpGauge
+
+	// otlpHistogram instruments record the distribution of the event's
+	// value, which is a duration in milliseconds.
+	otlpHistogram
+)
+
+type otlpInstrument struct {
+	Name        string
+	Kind        otlpInstrumentKind
+	Unit        unit.Unit
+	Description string
+}
+
+// otlpInstruments maps the name of every metric.Event to the instrument it is
+// recorded to. Events that are not listed here are reported as gauges named
+// after the event.
+var otlpInstruments = map[string]otlpInstrument{
+	"build started":        {"concourse.builds.executions", otlpIncrement, unit.Dimensionless, "Number of builds that have begun running."},
+	"build finished":       {"concourse.builds.duration", otlpHistogram, unit.Milliseconds, "Duration of finished builds."},
+	"builds started":       {"concourse.builds.started", otlpCounter, unit.Dimensionless, "Number of builds started by the scheduler."},
+	"builds running":       {"concourse.builds.running", otlpGauge, unit.Dimensionless, "Number of builds currently running."},
+	"check build started":  {"concourse.check_builds.executions", otlpIncrement, unit.Dimensionless, "Number of check builds that have begun running."},
+	"check build finished": {"concourse.check_builds.duration", otlpHistogram, unit.Milliseconds, "Duration of finished check builds."},
+	"check builds started": {"concourse.check_builds.started", otlpCounter, unit.Dimensionless, "Number of check builds started."},
+	"check builds running": {"concourse.check_builds.running", otlpGauge, unit.Dimensionless, "Number of check builds currently running."},
+
+	"checks started":  {"concourse.checks.started", otlpCounter, unit.Dimensionless, "Number of checks started."},
+	"checks finished": {"concourse.checks.finished", otlpCounter, unit.Dimensionless, "Number of checks finished."},
+	"checks enqueued": {"concourse.checks.enqueued", otlpCounter, unit.Dimensionless, "Number of checks enqueued."},
+
+	"jobs scheduled":                {"concourse.jobs.scheduled", otlpCounter, unit.Dimensionless, "Number of jobs scheduled."},
+	"jobs scheduling":               {"concourse.jobs.scheduling", otlpGauge, unit.Dimensionless, "Number of jobs currently being scheduled."},
+	"scheduling: job duration (ms)": {"concourse.jobs.scheduling.duration", otlpHistogram, unit.Milliseconds, "Time taken to schedule a job."},
+
+	"steps waiting":          {"concourse.steps.waiting", otlpGauge, unit.Dimensionless, "Number of steps waiting for a worker."},
+	"steps waiting duration": {"concourse.steps.waiting.duration", otlpHistogram, unit.Milliseconds, "Time steps spent waiting for a worker."},
+
+	"containers created": {"concourse.containers.created", otlpCounter, unit.Dimensionless, "Number of containers created."},
+	"containers deleted": {"concourse.containers.deleted", otlpCounter, unit.Dimensionless, "Number of containers deleted."},
+	"failed containers":  {"concourse.containers.failed", otlpCounter, unit.Dimensionless, "Number of containers that failed to be created."},
+	"volumes created":    {"concourse.volumes.created", otlpCounter, unit.Dimensionless, "Number of volumes created."},
+	"volumes deleted":    {"concourse.volumes.deleted", otlpCounter, unit.Dimensionless, "Number of volumes deleted."},
+	"failed volumes":     {"concourse.volumes.failed", otlpCounter, unit.Dimensionless, "Number of volumes that failed to be created."},
+	"volumes streamed":   {"concourse.volumes.streamed", otlpCounter, unit.Dimensionless, "Number of volumes streamed between workers."},
+
+	"get step cache hits":      {"concourse.get_steps.cache_hits", otlpCounter, unit.Dimensionless, "Number of get steps that reused a resource cache."},
+	"streamed resource caches": {"concourse.resource_caches.streamed", otlpCounter, unit.Dimensionless, "Number of resource caches streamed between workers."},
+
+	"worker containers":         {"concourse.workers.containers", otlpGauge, unit.Dimensionless, "Number of containers per worker."},
+	"worker unknown containers": {"concourse.workers.unknown_containers", otlpGauge, unit.Dimensionless, "Number of containers per worker unknown to the database."},
+	"worker volumes":            {"concourse.workers.volumes", otlpGauge, unit.Dimensionless, "Number of volumes per worker."},
+	"worker unknown volumes":    {"concourse.workers.unknown_volumes", otlpGauge, unit.Dimensionless, "Number of volumes per worker unknown to the database."},
+	"worker tasks":              {"concourse.workers.tasks", otlpGauge, unit.Dimensionless, "Number of tasks running per worker."},
+	"worker state":              {"concourse.workers.registered", otlpGauge, unit.Dimensionless, "Number of workers in each state."},
+
+	"lock held": {"concourse.locks.held", otlpUpDownCounter, unit.Dimensionless, "Number of database locks currently held."},
+
+	"database queries":     {"concourse.db.queries", otlpCounter, unit.Dimensionless, "Number of database queries issued."},
+	"database connections": {"concourse.db.connections", otlpGauge, unit.Dimensionless, "Number of open database connections."},
+
+	"http response time":            {"concourse.http.response.duration", otlpHistogram, unit.Milliseconds, "Time taken to respond to API requests."},
+	"concurrent requests":           {"concourse.concurrent_requests.active", otlpGauge, unit.Dimensionless, "Number of in-flight requests to rate-limited endpoints."},
+	"concurrent requests limit hit": {"concourse.concurrent_requests.limit_hit", otlpCounter, unit.Dimensionless, "Number of requests rejected by the concurrent request limit."},
+
+	"error log": {"concourse.error_logs", otlpCounter, unit.Dimensionless, "Number of errors logged."},
+
+	"gc: build collector duration (ms)":                         {"concourse.gc.build_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the build collector."},
+	"gc: worker collector duration (ms)":                        {"concourse.gc.worker_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the worker collector."},
+	"gc: resource cache use collector duration (ms)":            {"concourse.gc.resource_cache_use_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the resource cache use collector."},
+	"gc: resource config collector duration (ms)":               {"concourse.gc.resource_config_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the resource config collector."},
+	"gc: resource cache collector duration (ms)":                {"concourse.gc.resource_cache_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the resource cache collector."},
+	"gc: resource config check session collector duration (ms)": {"concourse.gc.resource_config_check_session_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the resource config check session collector."},
+	"gc: artifact collector duration (ms)":                      {"concourse.gc.artifact_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the artifact collector."},
+	"gc: container collector duration (ms)":                     {"concourse.gc.container_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the container collector."},
+	"gc: volume collector duration (ms)":                        {"concourse.gc.volume_collector.duration", otlpHistogram, unit.Milliseconds, "Time taken by the volume collector."},
+	"GC container collector job dropped":                        {"concourse.gc.container_collector.jobs_dropped", otlpCounter, unit.Dimensionless, "Number of container collector jobs dropped."},
+
+	"orphaned volumes to be garbage collected":      {"concourse.gc.orphaned_volumes", otlpGauge, unit.Dimensionless, "Number of orphaned volumes awaiting garbage collection."},
+	"creating containers to be garbage collected":   {"concourse.gc.creating_containers", otlpGauge, unit.Dimensionless, "Number of creating containers awaiting garbage collection."},
+	"created containers to be garbage collected":    {"concourse.gc.created_containers", otlpGauge, unit.Dimensionless, "Number of created containers awaiting garbage collection."},
+	"destroying containers to be garbage collected": {"concourse.gc.destroying_containers", otlpGauge, unit.Dimensionless, "Number of destroying containers awaiting garbage collection."},
+	"failed containers to be garbage collected":     {"concourse.gc.failed_containers", otlpGauge, unit.Dimensionless, "Number of failed containers awaiting garbage collection."},
+	"created volumes to be garbage collected":       {"concourse.gc.created_volumes", otlpGauge, unit.Dimensionless, "Number of created volumes awaiting garbage collection."},
+	"destroying volumes to be garbage collected":    {"concourse.gc.destroying_volumes", otlpGauge, unit.Dimensionless, "Number of destroying volumes awaiting garbage collection."},
+	"failed volumes to be garbage collected":        {"concourse.gc.failed_volumes", otlpGauge, unit.Dimensionless, "Number of failed volumes awaiting garbage collection."},
+
+	"gc pause total duration": {"concourse.go.gc_pause_total", otlpGauge, unit.Unit("ns"), "Cumulative time spent in Go garbage collection pauses."},
+	"mallocs":                 {"concourse.go.mallocs", otlpGauge, unit.Dimensionless, "Cumulative count of heap objects allocated."},
+	"frees":                   {"concourse.go.frees", otlpGauge, unit.Dimensionless, "Cumulative count of heap objects freed."},
+	"goroutines":              {"concourse.go.goroutines", otlpGauge, unit.Dimensionless, "Number of goroutines."},
+}
+
+// otlpLabels are the attributes of each event which are recorded as labels.
+// Any others, such as build IDs or request paths, would make a new series for
+// every value ever seen, which the SDK keeps for as long as the ATC runs.
+var otlpLabels = map[string][]string{
+	"build started":                 {"team_name", "pipeline", "job"},
+	"build finished":                {"team_name", "pipeline", "job", "build_status"},
+	"check build started":           {"team_name", "pipeline", "resource"},
+	"check build finished":          {"team_name", "pipeline", "resource", "build_status"},
+	"checks finished":               {"status"},
+	"scheduling: job duration (ms)": {"pipeline", "job"},
+
+	"steps waiting":          {"platform", "teamId", "teamName", "type", "workerTags"},
+	"steps waiting duration": {"platform", "teamId", "teamName", "type", "workerTags"},
+
+	"worker containers":         {"worker", "platform", "team_name", "tags"},
+	"worker unknown containers": {"worker"},
+	"worker volumes":            {"worker", "platform", "team_name", "tags"},
+	"worker unknown volumes":    {"worker"},
+	"worker tasks":              {"worker", "platform"},
+	"worker state":              {"state"},
+
+	"lock held":            {"type"},
+	"database connections": {"ConnectionName"},
+
+	"http response time":            {"route", "method", "status"},
+	"concurrent requests":           {"action"},
+	"concurrent requests limit hit": {"action"},
+
+	"error log": {"message"},
+}
+
+type otlpGaugeValue struct {
+	value   float64
+	labels  []attribute.KeyValue
+	updated time.Time
+}
+
+type OTLPEmitter struct {
+	meter        otelmetric.Meter
+	staticLabels []string
+	gaugeExpiry  time.Duration
+
+	// lock guards the instruments. It must not be held by gauge callbacks,
+	// which are run by the SDK while it holds its own locks.
+	lock           sync.Mutex
+	counters       map[string]otelmetric.Float64Counter
+	upDownCounters map[string]otelmetric.Float64UpDownCounter
+	histograms     map[string]otelmetric.Float64ValueRecorder
+	gauges         map[string]map[attribute.Distinct]otlpGaugeValue
+
+	gaugeValuesLock sync.Mutex
+}
+
+// NewOTLPEmitter returns an emitter that records events to instruments
+// created from the given meter. The static labels, which are configured with
+// --metrics-attribute, are recorded for every event. Gauges are no longer
+// reported for a set of labels once they have not been updated within the
+// expiry, unless it is zero.
+func NewOTLPEmitter(meter otelmetric.Meter, staticLabels []string, gaugeExpiry time.Duration) *OTLPEmitter {
+	return &OTLPEmitter{
+		meter:          meter,
+		staticLabels:   staticLabels,
+		gaugeExpiry:    gaugeExpiry,
+		counters:       map[string]otelmetric.Float64Counter{},
+		upDownCounters: map[string]otelmetric.Float64UpDownCounter{},
+		histograms:     map[string]otelmetric.Float64ValueRecorder{},
+		gauges:         map[string]map[attribute.Distinct]otlpGaugeValue{},
+	}
+}
+
+func (emitter *OTLPEmitter) Emit(logger lager.Logger, event metric.Event) {
+	instrument, found := otlpInstruments[event.Name]
+	if !found {
+		instrument = otlpInstrument{
+			Name: "concourse." + specialChars.ReplaceAllString(strings.Replace(strings.ToLower(event.Name), " ", "_", -1), ""),
+			Kind: otlpGauge,
+		}
+	}
+
+	labels := emitter.labels(event)
+
+	emitter.lock.Lock()
+	defer emitter.lock.Unlock()
+
+	var err error
+	switch instrument.Kind {
+	case otlpCounter:
+		err = emitter.addToCounter(instrument, event.Value, labels)
+	case otlpIncrement:
+		err = emitter.addToCounter(instrument, 1, labels)
+	case otlpUpDownCounter:
+		err = emitter.addToUpDownCounter(instrument, event.Value, labels)
+	case otlpHistogram:
+		err = emitter.record(instrument, event.Value, labels)
+	default:
+		err = emitter.observe(instrument, event.Value, labels)
+	}
+	if err != nil {
+		logger.Error("failed-to-record-metric", err, lager.Data{"instrument": instrument.Name})
+	}
+}
+
+func (emitter *OTLPEmitter) addToCounter(instrument otlpInstrument, value float64, labels []attribute.KeyValue) error {
+	counter, found := emitter.counters[instrument.Name]
+	if !found {
+		var err error
+		counter, err = emitter.meter.NewFloat64Counter(instrument.Name, instrument.options()...)
+		if err != nil {
+			return err
+		}
+
+		emitter.counters[instrument.Name] = counter
+	}
+
+	if value < 0 {
+		return errors.New("counter cannot be decreased")
+	}
+
+	counter.Add(context.Background(), value, labels...)
+
+	return nil
+}
+
+func (emitter *OTLPEmitter) addToUpDownCounter(instrument otlpInstrument, value float64, labels []attribute.KeyValue) error {
+	counter, found := emitter.upDownCounters[instrument.Name]
+	if !found {
+		var err error
+		counter, err = emitter.meter.NewFloat64UpDownCounter(instrument.Name, instrument.options()...)
+		if err != nil {
+			return err
+		}
+
+		emitter.upDownCounters[instrument.Name] = counter
+	}
+
+	delta := float64(-1)
+	if value == 1 {
+		delta = 1
+	}
+
+	counter.Add(context.Background(), delta, labels...)
+
+	return nil
+}
+
+func (emitter *OTLPEmitter) record(instrument otlpInstrument, value float64, labels []attribute.KeyValue) error {
+	histogram, found := emitter.histograms[instrument.Name]
+	if !found {
+		var err error
+		histogram, err = emitter.meter.NewFloat64ValueRecorder(instrument.Name, instrument.options()...)
+		if err != nil {
+			return err
+		}
+
+		emitter.histograms[instrument.Name] = histogram
+	}
+
+	histogram.Record(context.Background(), value, labels...)
+
+	return nil
+}
+
+func (emitter *OTLPEmitter) observe(instrument otlpInstrument, value float64, labels []attribute.KeyValue) error {
+	values, found := emitter.gauges[instrument.Name]
+	if !found {
+		values = map[attribute.Distinct]otlpGaugeValue{}
+
+		_, err := emitter.meter.NewFloat64ValueObserver(
+			instrument.Name,
+			func(_ context.Context, result otelmetric.Float64ObserverResult) {
+				emitter.gaugeValuesLock.Lock()
+				defer emitter.gaugeValuesLock.Unlock()
+
+				for key, gauge := range values {
+					if emitter.gaugeExpiry != 0 && time.Since(gauge.updated) > emitter.gaugeExpiry {
+						delete(values, key)
+						continue
+					}
+
+					result.Observe(gauge.value, gauge.labels...)
+				}
+			},
+			instrument.options()...,
+		)
+		if err != nil {
+			return err
+		}
+
+		emitter.gauges[instrument.Name] = values
+	}
+
+	emitter.gaugeValuesLock.Lock()
+	defer emitter.gaugeValuesLock.Unlock()
+
+	set := attribute.NewSet(labels...)
+	values[set.Equivalent()] = otlpGaugeValue{
+		value:   value,
+		labels:  labels,
+		updated: time.Now(),
+	}
+
+	return nil
+}
+
+func (instrument otlpInstrument) options() []otelmetric.InstrumentOption {
+	var opts []otelmetric.InstrumentOption
+	if instrument.Unit != "" {
+		opts = append(opts, otelmetric.WithUnit(instrument.Unit))
+	}
+
+	if instrument.Description != "" {
+		opts = append(opts, otelmetric.WithDescription(instrument.Description))
+	}
+
+	return opts
+}
+
+// labels converts the event's attributes which are recorded for its
+// instrument, along with the static ones, into OTel attributes.
+func (emitter *OTLPEmitter) labels(event metric.Event) []attribute.KeyValue {
+	labels := []attribute.KeyValue{}
+	if event.Host != "" {
+		labels = append(labels, attribute.String("host", event.Host))
+	}
+
+	for _, keys := range [][]string{emitter.staticLabels, otlpLabels[event.Name]} {
+		for _, k := range keys {
+			if v, found := event.Attributes[k]; found {
+				labels = append(labels, attribute.String(k, v))
+			}
+		}
+	}
+
+	return labels
+}
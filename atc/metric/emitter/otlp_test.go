@@ -0,0 +1,365 @@
+package emitter_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/emitter"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// collectorStub stands in for an OTLP collector, remembering every metric
+// pushed to it.
+type collectorStub struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	lock     sync.Mutex
+	requests []*collectormetrics.ExportMetricsServiceRequest
+	headers  []http.Header
+}
+
+func (stub *collectorStub) Export(ctx context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+
+	stub.requests = append(stub.requests, req)
+
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (stub *collectorStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	Expect(err).NotTo(HaveOccurred())
+
+	req := &collectormetrics.ExportMetricsServiceRequest{}
+	Expect(proto.Unmarshal(body, req)).To(Succeed())
+
+	stub.lock.Lock()
+	stub.headers = append(stub.headers, r.Header)
+	stub.lock.Unlock()
+
+	stub.Export(r.Context(), req)
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+// metric returns the most recently pushed metric with the given name.
+func (stub *collectorStub) metric(name string) *metricspb.Metric {
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+
+	var found *metricspb.Metric
+	for _, req := range stub.requests {
+		for _, rm := range req.GetResourceMetrics() {
+			for _, ilm := range rm.GetInstrumentationLibraryMetrics() {
+				for _, m := range ilm.GetMetrics() {
+					if m.GetName() == name {
+						found = m
+					}
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+func (stub *collectorStub) resourceAttribute(key string) string {
+	stub.lock.Lock()
+	defer stub.lock.Unlock()
+
+	for _, req := range stub.requests {
+		for _, rm := range req.GetResourceMetrics() {
+			for _, attr := range rm.GetResource().GetAttributes() {
+				if attr.GetKey() == key {
+					return attr.GetValue().GetStringValue()
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func labels(kvs interface{}) map[string]string {
+	result := map[string]string{}
+
+	switch point := kvs.(type) {
+	case *metricspb.DoubleDataPoint:
+		for _, kv := range point.GetLabels() {
+			result[kv.GetKey()] = kv.GetValue()
+		}
+	case *metricspb.DoubleHistogramDataPoint:
+		for _, kv := range point.GetLabels() {
+			result[kv.GetKey()] = kv.GetValue()
+		}
+	}
+
+	return result
+}
+
+var _ = Describe("OTLPEmitter", func() {
+	var (
+		stub   *collectorStub
+		config *emitter.OTLPConfig
+		logger *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		stub = &collectorStub{}
+		logger = lagertest.NewTestLogger("otlp")
+	})
+
+	Context("when pushing over gRPC", func() {
+		var (
+			server *grpc.Server
+			e      metric.Emitter
+		)
+
+		BeforeEach(func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			server = grpc.NewServer()
+			collectormetrics.RegisterMetricsServiceServer(server, stub)
+			go server.Serve(listener)
+
+			config = &emitter.OTLPConfig{
+				Address:      listener.Addr().String(),
+				Protocol:     "grpc",
+				PushInterval: 50 * time.Millisecond,
+				ServiceName:  "some-web",
+			}
+
+			e, err = config.NewEmitter()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		It("records durations to histograms", func() {
+			e.Emit(logger, metric.Event{
+				Name:  "build finished",
+				Value: 1500,
+				Host:  "some-host",
+				Attributes: map[string]string{
+					"team_name":    "some-team",
+					"pipeline":     "some-pipeline",
+					"job":          "some-job",
+					"build_status": "succeeded",
+					"build_id":     "42",
+					"build":        "7",
+				},
+			})
+
+			Eventually(func() *metricspb.Metric { return stub.metric("concourse.builds.duration") }).ShouldNot(BeNil())
+
+			m := stub.metric("concourse.builds.duration")
+			Expect(m.GetUnit()).To(Equal("ms"))
+			Expect(m.GetDoubleHistogram()).NotTo(BeNil())
+
+			points := m.GetDoubleHistogram().GetDataPoints()
+			Expect(points).To(HaveLen(1))
+			Expect(points[0].GetCount()).To(Equal(uint64(1)))
+			Expect(points[0].GetSum()).To(Equal(1500.0))
+			Expect(labels(points[0])).To(Equal(map[string]string{
+				"host":         "some-host",
+				"team_name":    "some-team",
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_status": "succeeded",
+			}))
+		})
+
+		It("adds deltas to counters", func() {
+			e.Emit(logger, metric.Event{Name: "containers created", Value: 3})
+			e.Emit(logger, metric.Event{Name: "containers created", Value: 2})
+
+			Eventually(func() float64 {
+				m := stub.metric("concourse.containers.created")
+				if m == nil || len(m.GetDoubleSum().GetDataPoints()) == 0 {
+					return 0
+				}
+
+				return m.GetDoubleSum().GetDataPoints()[0].GetValue()
+			}).Should(Equal(5.0))
+
+			Expect(stub.metric("concourse.containers.created").GetDoubleSum().GetIsMonotonic()).To(BeTrue())
+		})
+
+		It("reports the last value of gauges for each set of attributes", func() {
+			e.Emit(logger, metric.Event{Name: "worker containers", Value: 10, Attributes: map[string]string{"worker": "worker-1"}})
+			e.Emit(logger, metric.Event{Name: "worker containers", Value: 4, Attributes: map[string]string{"worker": "worker-2"}})
+			e.Emit(logger, metric.Event{Name: "worker containers", Value: 7, Attributes: map[string]string{"worker": "worker-1"}})
+
+			Eventually(func() map[string]float64 {
+				values := map[string]float64{}
+
+				m := stub.metric("concourse.workers.containers")
+				for _, point := range m.GetDoubleGauge().GetDataPoints() {
+					values[labels(point)["worker"]] = point.GetValue()
+				}
+
+				return values
+			}).Should(Equal(map[string]float64{
+				"worker-1": 7,
+				"worker-2": 4,
+			}))
+		})
+
+		Context("when gauges expire", func() {
+			BeforeEach(func() {
+				config.GaugeExpiry = 200 * time.Millisecond
+
+				var err error
+				e, err = config.NewEmitter()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("stops reporting the sets of attributes which are no longer updated", func() {
+				e.Emit(logger, metric.Event{Name: "worker containers", Value: 10, Attributes: map[string]string{"worker": "worker-1"}})
+
+				reported := func() []string {
+					workers := []string{}
+
+					m := stub.metric("concourse.workers.containers")
+					for _, point := range m.GetDoubleGauge().GetDataPoints() {
+						workers = append(workers, labels(point)["worker"])
+					}
+
+					return workers
+				}
+
+				Eventually(reported).Should(Equal([]string{"worker-1"}))
+
+				Eventually(func() []string {
+					e.Emit(logger, metric.Event{Name: "worker containers", Value: 4, Attributes: map[string]string{"worker": "worker-2"}})
+					return reported()
+				}).Should(Equal([]string{"worker-2"}))
+			})
+		})
+
+		It("tracks held locks with an up-down counter", func() {
+			e.Emit(logger, metric.Event{Name: "lock held", Value: 1, Attributes: map[string]string{"type": "Batch"}})
+			e.Emit(logger, metric.Event{Name: "lock held", Value: 1, Attributes: map[string]string{"type": "Batch"}})
+			e.Emit(logger, metric.Event{Name: "lock held", Value: 0, Attributes: map[string]string{"type": "Batch"}})
+
+			Eventually(func() float64 {
+				m := stub.metric("concourse.locks.held")
+				if m == nil || len(m.GetDoubleSum().GetDataPoints()) == 0 {
+					return 0
+				}
+
+				return m.GetDoubleSum().GetDataPoints()[0].GetValue()
+			}).Should(Equal(1.0))
+		})
+
+		It("reports events it has no mapping for as gauges", func() {
+			e.Emit(logger, metric.Event{Name: "some new metric (widgets)", Value: 42})
+
+			Eventually(func() *metricspb.Metric { return stub.metric("concourse.some_new_metric_widgets") }).ShouldNot(BeNil())
+			Expect(stub.metric("concourse.some_new_metric_widgets").GetDoubleGauge().GetDataPoints()[0].GetValue()).To(Equal(42.0))
+		})
+
+		It("identifies the service on the resource", func() {
+			e.Emit(logger, metric.Event{Name: "goroutines", Value: 1})
+
+			Eventually(func() string { return stub.resourceAttribute("service.name") }).Should(Equal("some-web"))
+		})
+
+		Context("when attributes are configured with --metrics-attribute", func() {
+			var monitor *metric.Monitor
+
+			BeforeEach(func() {
+				monitor = metric.NewMonitor()
+				monitor.RegisterEmitter(config)
+
+				err := monitor.Initialize(logger, "some-host", map[string]string{"environment": "staging"}, 100)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("attaches them to every recorded metric, but only the instrument's own attributes", func() {
+				metric.HTTPResponseTime{
+					Route:      "GetBuild",
+					Path:       "/api/v1/builds/1",
+					Method:     "GET",
+					StatusCode: 200,
+					Duration:   20 * time.Millisecond,
+				}.Emit(logger, monitor)
+
+				Eventually(func() *metricspb.Metric { return stub.metric("concourse.http.response.duration") }).ShouldNot(BeNil())
+
+				points := stub.metric("concourse.http.response.duration").GetDoubleHistogram().GetDataPoints()
+				Expect(points).To(HaveLen(1))
+				Expect(labels(points[0])).To(Equal(map[string]string{
+					"host":        "some-host",
+					"environment": "staging",
+					"route":       "GetBuild",
+					"method":      "GET",
+					"status":      "200",
+				}))
+			})
+		})
+	})
+
+	Context("when pushing over HTTP", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(stub)
+
+			config = &emitter.OTLPConfig{
+				Address:      strings.TrimPrefix(server.URL, "http://"),
+				Protocol:     "http",
+				Headers:      map[string]string{"Authorization": "Bearer some-token"},
+				PushInterval: 50 * time.Millisecond,
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("pushes metrics with the configured headers", func() {
+			e, err := config.NewEmitter()
+			Expect(err).NotTo(HaveOccurred())
+
+			e.Emit(logger, metric.Event{Name: "jobs scheduled", Value: 2})
+
+			Eventually(func() *metricspb.Metric { return stub.metric("concourse.jobs.scheduled") }).ShouldNot(BeNil())
+
+			stub.lock.Lock()
+			defer stub.lock.Unlock()
+			Expect(stub.headers[0].Get("Authorization")).To(Equal("Bearer some-token"))
+		})
+	})
+
+	Context("when the protocol is unknown", func() {
+		It("returns an error", func() {
+			config = &emitter.OTLPConfig{
+				Address:  "localhost:4317",
+				Protocol: "carrier-pigeon",
+			}
+
+			_, err := config.NewEmitter()
+			Expect(err).To(MatchError("unknown OTLP protocol: carrier-pigeon"))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package atc
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// NotificationEvents are the build statuses that notifications can be sent
+// for.
+var NotificationEvents = []BuildStatus{
+	StatusStarted,
+	StatusSucceeded,
+	StatusFailed,
+	StatusErrored,
+	StatusAborted,
+}
+
+// NotificationRule configures a URL to POST a notification to when a build
+// reaches one of the given statuses. If no events are configured, the URL is
+// notified of all of them.
+type NotificationRule struct {
+	URL    string        `json:"url"`
+	Events []BuildStatus `json:"events,omitempty"`
+}
+
+func (rule NotificationRule) Matches(status BuildStatus) bool {
+	if len(rule.Events) == 0 {
+		return true
+	}
+
+	for _, event := range rule.Events {
+		if event == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rule NotificationRule) Validate() error {
+	if rule.URL == "" {
+		return fmt.Errorf("url must be specified")
+	}
+
+	u, err := url.Parse(rule.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url '%s': %w", rule.URL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url '%s': must be an absolute http or https url", rule.URL)
+	}
+
+	for _, event := range rule.Events {
+		if !isNotificationEvent(event) {
+			return fmt.Errorf("unknown event '%s'; expected one of started, succeeded, failed, errored or aborted", event)
+		}
+	}
+
+	return nil
+}
+
+func isNotificationEvent(status BuildStatus) bool {
+	for _, event := range NotificationEvents {
+		if event == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+type NotificationRules []NotificationRule
+
+func (rules NotificationRules) Validate() error {
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("notifications[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Matching returns the rules which match the given status.
+func (rules NotificationRules) Matching(status BuildStatus) NotificationRules {
+	var matching NotificationRules
+	for _, rule := range rules {
+		if rule.Matches(status) {
+			matching = append(matching, rule)
+		}
+	}
+
+	return matching
+}
+
+// NotificationPayload is the body of the request sent for a notification.
+type NotificationPayload struct {
+	Event BuildStatus `json:"event"`
+	Build Build       `json:"build"`
+	URL   string      `json:"url"`
+}
+
+// NotificationDelivery is a single attempt at delivering a notification.
+type NotificationDelivery struct {
+	ID                   int          `json:"id"`
+	BuildID              int          `json:"build_id"`
+	BuildName            string       `json:"build_name"`
+	JobName              string       `json:"job_name,omitempty"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	TeamName             string       `json:"team_name"`
+	URL                  string       `json:"url"`
+	Event                BuildStatus  `json:"event"`
+	Attempt              int          `json:"attempt"`
+	StatusCode           int          `json:"status_code,omitempty"`
+	Error                string       `json:"error,omitempty"`
+	Succeeded            bool         `json:"succeeded"`
+	AttemptedAt          int64        `json:"attempted_at"`
+}
@@ -0,0 +1,57 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationRules", func() {
+	Describe("Matching", func() {
+		rules := atc.NotificationRules{
+			{URL: "https://example.com/all"},
+			{URL: "https://example.com/failures", Events: []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored}},
+		}
+
+		It("returns the rules with no events configured", func() {
+			Expect(rules.Matching(atc.StatusStarted)).To(Equal(atc.NotificationRules{
+				{URL: "https://example.com/all"},
+			}))
+		})
+
+		It("returns the rules configured for the event", func() {
+			Expect(rules.Matching(atc.StatusErrored)).To(Equal(rules))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("accepts valid rules", func() {
+			rules := atc.NotificationRules{
+				{URL: "http://example.com/hook"},
+				{URL: "https://example.com/hook", Events: atc.NotificationEvents},
+			}
+
+			Expect(rules.Validate()).To(Succeed())
+		})
+
+		It("rejects rules without a url", func() {
+			rules := atc.NotificationRules{{}}
+			Expect(rules.Validate()).To(MatchError("notifications[0]: url must be specified"))
+		})
+
+		It("rejects relative urls", func() {
+			rules := atc.NotificationRules{{URL: "/hook"}}
+			Expect(rules.Validate()).To(MatchError("notifications[0]: invalid url '/hook': must be an absolute http or https url"))
+		})
+
+		It("rejects unknown events", func() {
+			rules := atc.NotificationRules{
+				{URL: "https://example.com/hook"},
+				{URL: "https://example.com/hook", Events: []atc.BuildStatus{atc.StatusPending}},
+			}
+
+			Expect(rules.Validate()).To(MatchError("notifications[1]: unknown event 'pending'; expected one of started, succeeded, failed, errored or aborted"))
+		})
+	})
+})
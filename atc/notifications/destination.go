@@ -0,0 +1,98 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrDestinationNotAllowed is returned when a notification URL resolves to an
+// internal address which the operator has not allowed.
+var ErrDestinationNotAllowed = errors.New("notification destination is an internal address; it must be allowed with --notification-allowed-network")
+
+// Network is a CIDR block which notifications may be delivered to even if it
+// is internal, e.g. 10.0.0.0/8.
+type Network struct {
+	*net.IPNet
+}
+
+func (n *Network) UnmarshalFlag(value string) error {
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return fmt.Errorf("invalid network '%s': %w", value, err)
+	}
+
+	n.IPNet = ipNet
+	return nil
+}
+
+// destinationAllowed refuses loopback, link-local, private and unspecified
+// addresses unless they are within one of the allowed networks. Notification
+// URLs are set by pipeline members, so without this they could be used to
+// reach services only the web node can, such as cloud metadata endpoints.
+func destinationAllowed(ip net.IP, allowedNetworks []Network) bool {
+	for _, network := range allowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return !(ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified())
+}
+
+// newClient returns a client which checks each address it connects to after
+// it has been resolved, so a hostname cannot be pointed at an internal address
+// after the URL is configured, and redirects are held to the same check as
+// they are dialed in the same way.
+//
+// Proxies configured in the environment are not used, as the check would then
+// apply to the proxy rather than the destination.
+func newClient(config Config) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !destinationAllowed(ip, config.AllowedNetworks) {
+				return ErrDestinationNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("refusing to follow redirect to %s URL", req.URL.Scheme)
+			}
+
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,161 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package notificationsfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/notifications"
+)
+
+type FakeNotifier struct {
+	BuildFinishedStub        func(lager.Logger, db.Build)
+	buildFinishedMutex       sync.RWMutex
+	buildFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Build
+	}
+	BuildStartedStub        func(lager.Logger, db.Build)
+	buildStartedMutex       sync.RWMutex
+	buildStartedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Build
+	}
+	DrainStub        func(context.Context)
+	drainMutex       sync.RWMutex
+	drainArgsForCall []struct {
+		arg1 context.Context
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotifier) BuildFinished(arg1 lager.Logger, arg2 db.Build) {
+	fake.buildFinishedMutex.Lock()
+	fake.buildFinishedArgsForCall = append(fake.buildFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Build
+	}{arg1, arg2})
+	stub := fake.BuildFinishedStub
+	fake.recordInvocation("BuildFinished", []interface{}{arg1, arg2})
+	fake.buildFinishedMutex.Unlock()
+	if stub != nil {
+		fake.BuildFinishedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeNotifier) BuildFinishedCallCount() int {
+	fake.buildFinishedMutex.RLock()
+	defer fake.buildFinishedMutex.RUnlock()
+	return len(fake.buildFinishedArgsForCall)
+}
+
+func (fake *FakeNotifier) BuildFinishedCalls(stub func(lager.Logger, db.Build)) {
+	fake.buildFinishedMutex.Lock()
+	defer fake.buildFinishedMutex.Unlock()
+	fake.BuildFinishedStub = stub
+}
+
+func (fake *FakeNotifier) BuildFinishedArgsForCall(i int) (lager.Logger, db.Build) {
+	fake.buildFinishedMutex.RLock()
+	defer fake.buildFinishedMutex.RUnlock()
+	argsForCall := fake.buildFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotifier) BuildStarted(arg1 lager.Logger, arg2 db.Build) {
+	fake.buildStartedMutex.Lock()
+	fake.buildStartedArgsForCall = append(fake.buildStartedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Build
+	}{arg1, arg2})
+	stub := fake.BuildStartedStub
+	fake.recordInvocation("BuildStarted", []interface{}{arg1, arg2})
+	fake.buildStartedMutex.Unlock()
+	if stub != nil {
+		fake.BuildStartedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeNotifier) BuildStartedCallCount() int {
+	fake.buildStartedMutex.RLock()
+	defer fake.buildStartedMutex.RUnlock()
+	return len(fake.buildStartedArgsForCall)
+}
+
+func (fake *FakeNotifier) BuildStartedCalls(stub func(lager.Logger, db.Build)) {
+	fake.buildStartedMutex.Lock()
+	defer fake.buildStartedMutex.Unlock()
+	fake.BuildStartedStub = stub
+}
+
+func (fake *FakeNotifier) BuildStartedArgsForCall(i int) (lager.Logger, db.Build) {
+	fake.buildStartedMutex.RLock()
+	defer fake.buildStartedMutex.RUnlock()
+	argsForCall := fake.buildStartedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotifier) Drain(arg1 context.Context) {
+	fake.drainMutex.Lock()
+	fake.drainArgsForCall = append(fake.drainArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DrainStub
+	fake.recordInvocation("Drain", []interface{}{arg1})
+	fake.drainMutex.Unlock()
+	if stub != nil {
+		fake.DrainStub(arg1)
+	}
+}
+
+func (fake *FakeNotifier) DrainCallCount() int {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	return len(fake.drainArgsForCall)
+}
+
+func (fake *FakeNotifier) DrainCalls(stub func(context.Context)) {
+	fake.drainMutex.Lock()
+	defer fake.drainMutex.Unlock()
+	fake.DrainStub = stub
+}
+
+func (fake *FakeNotifier) DrainArgsForCall(i int) context.Context {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	argsForCall := fake.drainArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildFinishedMutex.RLock()
+	defer fake.buildFinishedMutex.RUnlock()
+	fake.buildStartedMutex.RLock()
+	defer fake.buildStartedMutex.RUnlock()
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ notifications.Notifier = new(FakeNotifier)
@@ -0,0 +1,288 @@
+// Package notifications delivers outbound webhooks when builds start and
+// finish, as configured by the notification rules of the build's team and
+// job.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/cenkalti/backoff"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+const (
+	EventHeader     = "X-Concourse-Event"
+	BuildIDHeader   = "X-Concourse-Build-ID"
+	SignatureHeader = "X-Concourse-Signature"
+)
+
+// Config configures how notifications are delivered.
+type Config struct {
+	SigningKey    string        `long:"notification-signing-key" description:"Key used to sign build notification payloads. The HMAC-SHA256 signature of the body is sent in the X-Concourse-Signature header."`
+	Timeout       time.Duration `long:"notification-timeout" default:"10s" description:"Timeout for each attempt at delivering a build notification."`
+	MaxAttempts   int           `long:"notification-max-attempts" default:"5" description:"Maximum number of attempts at delivering a build notification."`
+	RetryInterval time.Duration `long:"notification-retry-interval" default:"5s" description:"Interval before retrying a failed delivery, doubled after each attempt."`
+
+	AllowedNetworks []Network `long:"notification-allowed-network" description:"CIDR block which build notifications may be delivered to even though it is internal. Loopback, link-local and private addresses are refused otherwise. Can be specified multiple times."`
+}
+
+//counterfeiter:generate . Notifier
+type Notifier interface {
+	// BuildStarted is called once, when the build goes from pending to
+	// started.
+	BuildStarted(lager.Logger, db.Build)
+	BuildFinished(lager.Logger, db.Build)
+
+	// Drain gives up on retrying deliveries and waits for those in flight to
+	// finish. Nothing is delivered once it has been called.
+	Drain(context.Context)
+}
+
+type webhookNotifier struct {
+	teamFactory db.TeamFactory
+	client      *http.Client
+	externalURL string
+	config      Config
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	deliveries *sync.WaitGroup
+}
+
+func NewWebhookNotifier(teamFactory db.TeamFactory, externalURL string, config Config) Notifier {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &webhookNotifier{
+		teamFactory: teamFactory,
+		client:      newClient(config),
+		externalURL: externalURL,
+		config:      config,
+
+		ctx:        ctx,
+		cancel:     cancel,
+		deliveries: new(sync.WaitGroup),
+	}
+}
+
+func (n *webhookNotifier) BuildStarted(logger lager.Logger, build db.Build) {
+	n.notify(logger.Session("notify"), build, atc.StatusStarted)
+}
+
+func (n *webhookNotifier) BuildFinished(logger lager.Logger, build db.Build) {
+	n.notify(logger.Session("notify"), build, atc.BuildStatus(build.Status()))
+}
+
+func (n *webhookNotifier) Drain(ctx context.Context) {
+	n.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		n.deliveries.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// notify sends the event to every matching rule. Deliveries are made in the
+// background so that a slow or unreachable URL does not hold up the build.
+//
+// Only builds of jobs are notified; one-off and check builds are not.
+func (n *webhookNotifier) notify(logger lager.Logger, build db.Build, event atc.BuildStatus) {
+	if build.JobID() == 0 {
+		return
+	}
+
+	if n.ctx.Err() != nil {
+		logger.Info("draining", lager.Data{"event": event})
+		return
+	}
+
+	rules, err := n.rules(build)
+	if err != nil {
+		logger.Error("failed-to-find-notification-rules", err)
+		return
+	}
+
+	rules = rules.Matching(event)
+	if len(rules) == 0 {
+		return
+	}
+
+	payload := atc.NotificationPayload{
+		Event: event,
+		Build: present.Build(build, nil, nil),
+		URL:   n.externalURL + "/builds/" + strconv.Itoa(build.ID()),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed-to-marshal-payload", err)
+		return
+	}
+
+	for _, rule := range rules {
+		n.deliveries.Add(1)
+		go func(url string) {
+			defer n.deliveries.Done()
+			n.deliver(logger.Session("deliver", lager.Data{"url": url, "event": event}), build, url, event, body)
+		}(rule.URL)
+	}
+}
+
+// rules returns the job's notification rules if it has any configured, and
+// otherwise the team's.
+func (n *webhookNotifier) rules(build db.Build) (atc.NotificationRules, error) {
+	job, found, err := build.Job()
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		config, err := job.Config()
+		if err != nil {
+			return nil, err
+		}
+
+		if config.Notifications != nil {
+			return *config.Notifications, nil
+		}
+	}
+
+	team, found, err := n.teamFactory.FindTeam(build.TeamName())
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return team.Notifications(), nil
+}
+
+// deliver POSTs the body to the URL, retrying with exponential backoff until
+// it succeeds, the URL rejects it or is not allowed, or the maximum number of
+// attempts is reached, or the notifier is drained. Every attempt is recorded
+// against the build.
+func (n *webhookNotifier) deliver(logger lager.Logger, build db.Build, url string, event atc.BuildStatus, body []byte) {
+	retryBackoff := backoff.NewExponentialBackOff()
+	retryBackoff.InitialInterval = n.config.RetryInterval
+	retryBackoff.Multiplier = 2
+	retryBackoff.RandomizationFactor = 0
+	retryBackoff.MaxElapsedTime = 0
+	retryBackoff.Reset()
+
+	for attempt := 1; ; attempt++ {
+		statusCode, err := n.post(url, event, build.ID(), body)
+
+		delivery := db.NotificationDelivery{
+			URL:        url,
+			Event:      event,
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Succeeded:  err == nil,
+		}
+
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+
+		if saveErr := build.SaveNotificationDelivery(delivery); saveErr != nil {
+			logger.Error("failed-to-save-delivery", saveErr)
+		}
+
+		if err == nil {
+			logger.Debug("delivered", lager.Data{"attempt": attempt})
+			return
+		}
+
+		if errors.Is(err, ErrDestinationNotAllowed) || !retryable(statusCode) || attempt >= n.config.MaxAttempts {
+			logger.Info("giving-up", lager.Data{"attempt": attempt, "error": err.Error()})
+			return
+		}
+
+		select {
+		case <-time.After(retryBackoff.NextBackOff()):
+		case <-n.ctx.Done():
+			logger.Info("giving-up-while-draining", lager.Data{"attempt": attempt, "error": err.Error()})
+			return
+		}
+	}
+}
+
+func (n *webhookNotifier) post(url string, event atc.BuildStatus, buildID int, body []byte) (int, error) {
+	// attempts in flight are left to finish when draining; they are bounded
+	// by the client's timeout
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event))
+	req.Header.Set(BuildIDHeader, strconv.Itoa(buildID))
+
+	if n.config.SigningKey != "" {
+		req.Header.Set(SignatureHeader, Sign(n.config.SigningKey, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryable returns false for client errors, which are not expected to
+// succeed if the same request is sent again.
+func retryable(statusCode int) bool {
+	switch {
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode >= 400 && statusCode < 500:
+		return false
+	default:
+		return true
+	}
+}
+
+// Sign returns the value of the signature header for the body, in the form
+// "sha256=<hex-encoded HMAC-SHA256 of the body>".
+func Sign(key string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,368 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifications"
+)
+
+var _ = Describe("WebhookNotifier", func() {
+	var (
+		logger *lagertest.TestLogger
+		server *ghttp.Server
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeJob         *dbfakes.FakeJob
+		fakeBuild       *dbfakes.FakeBuild
+
+		config   notifications.Config
+		notifier notifications.Notifier
+	)
+
+	deliveries := func() []db.NotificationDelivery {
+		var saved []db.NotificationDelivery
+		for i := 0; i < fakeBuild.SaveNotificationDeliveryCallCount(); i++ {
+			saved = append(saved, fakeBuild.SaveNotificationDeliveryArgsForCall(i))
+		}
+		return saved
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		server = ghttp.NewServer()
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NotificationsReturns(atc.NotificationRules{
+			{URL: server.URL() + "/team-hook"},
+		})
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job"}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.JobIDReturns(1)
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.StatusReturns(db.BuildStatusFailed)
+		fakeBuild.JobReturns(fakeJob, true, nil)
+
+		config = notifications.Config{
+			SigningKey:    "some-key",
+			Timeout:       time.Second,
+			MaxAttempts:   3,
+			RetryInterval: time.Millisecond,
+		}
+
+		// the test server listens on loopback, which is refused by default
+		var loopback notifications.Network
+		Expect(loopback.UnmarshalFlag("127.0.0.0/8")).To(Succeed())
+		config.AllowedNetworks = []notifications.Network{loopback}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		notifier = notifications.NewWebhookNotifier(fakeTeamFactory, "https://ci.example.com", config)
+	})
+
+	Describe("BuildFinished", func() {
+		Context("when the delivery succeeds", func() {
+			var body []byte
+
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/team-hook"),
+						ghttp.VerifyContentType("application/json"),
+						ghttp.VerifyHeaderKV(notifications.EventHeader, "failed"),
+						ghttp.VerifyHeaderKV(notifications.BuildIDHeader, "42"),
+						func(w http.ResponseWriter, r *http.Request) {
+							var err error
+							body, err = ioutil.ReadAll(r.Body)
+							Expect(err).ToNot(HaveOccurred())
+
+							Expect(r.Header.Get(notifications.SignatureHeader)).To(Equal(notifications.Sign("some-key", body)))
+						},
+					),
+				)
+			})
+
+			It("posts the build to the team's url", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(server.ReceivedRequests).Should(HaveLen(1))
+
+				var payload atc.NotificationPayload
+				Expect(json.Unmarshal(body, &payload)).To(Succeed())
+				Expect(payload.Event).To(Equal(atc.StatusFailed))
+				Expect(payload.URL).To(Equal("https://ci.example.com/builds/42"))
+				Expect(payload.Build.ID).To(Equal(42))
+				Expect(payload.Build.Name).To(Equal("7"))
+				Expect(payload.Build.JobName).To(Equal("some-job"))
+				Expect(payload.Build.PipelineName).To(Equal("some-pipeline"))
+				Expect(payload.Build.TeamName).To(Equal("some-team"))
+				Expect(payload.Build.Status).To(Equal(atc.StatusFailed))
+			})
+
+			It("records the delivery", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+
+				Expect(deliveries()).To(Equal([]db.NotificationDelivery{
+					{
+						URL:        server.URL() + "/team-hook",
+						Event:      atc.StatusFailed,
+						Attempt:    1,
+						StatusCode: http.StatusOK,
+						Succeeded:  true,
+					},
+				}))
+			})
+		})
+
+		Context("when the job overrides the team's rules", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					Notifications: &atc.NotificationRules{
+						{URL: server.URL() + "/job-hook", Events: []atc.BuildStatus{atc.StatusFailed}},
+					},
+				}, nil)
+
+				server.AppendHandlers(ghttp.VerifyRequest("POST", "/job-hook"))
+			})
+
+			It("posts to the job's url instead", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(server.ReceivedRequests).Should(HaveLen(1))
+				Consistently(server.ReceivedRequests).Should(HaveLen(1))
+			})
+
+			It("only posts for the configured events", func() {
+				notifier.BuildStarted(logger, fakeBuild)
+				Consistently(server.ReceivedRequests).Should(BeEmpty())
+			})
+		})
+
+		Context("when the job disables notifications", func() {
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:          "some-job",
+					Notifications: &atc.NotificationRules{},
+				}, nil)
+			})
+
+			It("does not post anything", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Consistently(server.ReceivedRequests).Should(BeEmpty())
+			})
+		})
+
+		Context("when the build is a one-off build", func() {
+			BeforeEach(func() {
+				fakeBuild.JobIDReturns(0)
+			})
+
+			It("does not post anything", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Consistently(server.ReceivedRequests).Should(BeEmpty())
+			})
+		})
+
+		Context("when the delivery fails with a server error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusBadGateway, nil),
+					ghttp.RespondWith(http.StatusOK, nil),
+				)
+			})
+
+			It("retries until it succeeds", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(2))
+				Consistently(server.ReceivedRequests).Should(HaveLen(2))
+
+				saved := deliveries()
+				Expect(saved[0].Attempt).To(Equal(1))
+				Expect(saved[0].StatusCode).To(Equal(http.StatusBadGateway))
+				Expect(saved[0].Error).To(Equal("unexpected response: 502 Bad Gateway"))
+				Expect(saved[0].Succeeded).To(BeFalse())
+
+				Expect(saved[1].Attempt).To(Equal(2))
+				Expect(saved[1].Succeeded).To(BeTrue())
+			})
+		})
+
+		Context("when the delivery keeps failing", func() {
+			BeforeEach(func() {
+				server.SetAllowUnhandledRequests(true)
+				server.SetUnhandledRequestStatusCode(http.StatusServiceUnavailable)
+			})
+
+			It("gives up after the maximum number of attempts", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(3))
+				Consistently(server.ReceivedRequests).Should(HaveLen(3))
+			})
+		})
+
+		Context("when the url rejects the delivery", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, nil))
+			})
+
+			It("does not retry", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+				Consistently(server.ReceivedRequests).Should(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("destinations", func() {
+		Context("when the url resolves to an internal address which is not allowed", func() {
+			BeforeEach(func() {
+				config.AllowedNetworks = nil
+
+				fakeTeam.NotificationsReturns(atc.NotificationRules{
+					{URL: strings.Replace(server.URL(), "127.0.0.1", "localhost", 1) + "/team-hook"},
+				})
+			})
+
+			It("refuses to deliver to it, without retrying", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+				Consistently(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+
+				saved := deliveries()
+				Expect(saved[0].Succeeded).To(BeFalse())
+				Expect(saved[0].StatusCode).To(BeZero())
+				Expect(saved[0].Error).To(ContainSubstring("--notification-allowed-network"))
+			})
+		})
+
+		Context("when the url redirects to an internal address", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusTemporaryRedirect, nil, http.Header{
+						"Location": {"http://169.254.169.254/latest/meta-data/"},
+					}),
+				)
+			})
+
+			It("refuses to follow the redirect", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+				Consistently(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+
+				saved := deliveries()
+				Expect(saved[0].Succeeded).To(BeFalse())
+				Expect(saved[0].Error).To(ContainSubstring("--notification-allowed-network"))
+			})
+		})
+
+		Context("when an allowed network is invalid", func() {
+			It("errors", func() {
+				var network notifications.Network
+				Expect(network.UnmarshalFlag("10.0.0.0")).To(MatchError(ContainSubstring("invalid network '10.0.0.0'")))
+			})
+		})
+	})
+
+	Describe("BuildStarted", func() {
+		BeforeEach(func() {
+			fakeBuild.StatusReturns(db.BuildStatusStarted)
+
+			server.AppendHandlers(ghttp.VerifyHeaderKV(notifications.EventHeader, "started"))
+		})
+
+		It("posts a started event", func() {
+			notifier.BuildStarted(logger, fakeBuild)
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+		})
+	})
+
+	Describe("Drain", func() {
+		Context("when a delivery is waiting to be retried", func() {
+			BeforeEach(func() {
+				config.RetryInterval = time.Hour
+
+				server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, nil))
+			})
+
+			It("gives up on it and returns", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(fakeBuild.SaveNotificationDeliveryCallCount).Should(Equal(1))
+
+				drained := make(chan struct{})
+				go func() {
+					defer close(drained)
+					notifier.Drain(context.Background())
+				}()
+
+				Eventually(drained).Should(BeClosed())
+				Expect(fakeBuild.SaveNotificationDeliveryCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when a delivery is in flight", func() {
+			var release chan struct{}
+
+			BeforeEach(func() {
+				release = make(chan struct{})
+
+				server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+					<-release
+				})
+			})
+
+			It("waits for it to finish", func() {
+				notifier.BuildFinished(logger, fakeBuild)
+				Eventually(server.ReceivedRequests).Should(HaveLen(1))
+
+				drained := make(chan struct{})
+				go func() {
+					defer close(drained)
+					notifier.Drain(context.Background())
+				}()
+
+				Consistently(drained).ShouldNot(BeClosed())
+
+				close(release)
+
+				Eventually(drained).Should(BeClosed())
+				Expect(fakeBuild.SaveNotificationDeliveryCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveNotificationDeliveryArgsForCall(0).Succeeded).To(BeTrue())
+			})
+		})
+
+		It("does not deliver anything afterwards", func() {
+			notifier.Drain(context.Background())
+
+			notifier.BuildFinished(logger, fakeBuild)
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+})
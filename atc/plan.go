@@ -11,6 +11,7 @@ type Plan struct {
 	Run         *RunPlan         `json:"run,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approval    *ApprovalPlan    `json:"approval,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
@@ -25,6 +26,7 @@ type Plan struct {
 	Try     *TryPlan     `json:"try,omitempty"`
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
+	If      *IfPlan      `json:"if,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -84,6 +86,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 		plan.Timeout.Step.Each(f)
 	}
 
+	if plan.If != nil {
+		plan.If.Step.Each(f)
+	}
+
 	if plan.Retry != nil {
 		for i, p := range *plan.Retry {
 			p.Each(f)
@@ -137,6 +143,11 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+type IfPlan struct {
+	Step      Plan   `json:"step"`
+	Condition string `json:"condition"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -299,6 +310,10 @@ type TaskPlan struct {
 	// image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// Reuse the outputs and exit status of a previous successful run of the
+	// task if its config, image, and inputs are unchanged.
+	CacheResult bool `json:"cache_result,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	//
 	// XXX(check-refactor): Eliminating this would be great - if we can replace
@@ -334,6 +349,27 @@ type RunPlan struct {
 	// A timeout to enforce on the run step's process. Note that fetching the
 	// prototype's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
+
+	// Artifacts to mount into the container, and directories to register as
+	// artifacts once the message has been run.
+	Inputs  []string `json:"inputs,omitempty"`
+	Outputs []string `json:"outputs,omitempty"`
+
+	// Map of prototype input names to build artifact names, and of prototype
+	// output names to build artifact names.
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
+
+	// An artifact in the build plan to use as the prototype's image. Takes
+	// precedence over Image.
+	ImageArtifactName string `json:"image,omitempty"`
+
+	// The image configured by the prototype.
+	Image ImageResource `json:"image_resource"`
+
+	// Resource types to have available for use when fetching the prototype's
+	// image.
+	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
 type SetPipelinePlan struct {
@@ -352,6 +388,14 @@ type LoadVarPlan struct {
 	Reveal bool   `json:"reveal,omitempty"`
 }
 
+type ApprovalPlan struct {
+	// The name of the step. Decisions are recorded against this name.
+	Name string `json:"name"`
+
+	// Instructions shown to whoever is asked to approve the build.
+	Instructions string `json:"instructions,omitempty"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
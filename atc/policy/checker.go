@@ -12,6 +12,10 @@ import (
 
 const ActionUseImage = "UseImage"
 const ActionRunSetPipeline = "SetPipeline"
+const ActionRunGetStep = "GetStep"
+const ActionRunPutStep = "PutStep"
+const ActionRunTaskStep = "TaskStep"
+const ActionRunRunStep = "RunStep"
 
 type PolicyCheckNotPass struct {
 	Messages []string
@@ -0,0 +1,295 @@
+// Package embedded evaluates policy checks against Rego policies in-process,
+// as an alternative to running a separate OPA server.
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/topdown"
+
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/opa"
+)
+
+type result struct {
+	allowed     bool
+	shouldBlock bool
+	messages    []string
+}
+
+func (r result) Allowed() bool {
+	return r.allowed
+}
+
+func (r result) ShouldBlock() bool {
+	return r.shouldBlock
+}
+
+func (r result) Messages() []string {
+	return r.messages
+}
+
+// Agent evaluates the query against the most recently loaded policies.
+type Agent struct {
+	config Config
+	logger lager.Logger
+	client *http.Client
+
+	lock     sync.RWMutex
+	query    rego.PreparedEvalQuery
+	revision string
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewAgent loads the configured policies, failing if they cannot be loaded or
+// compiled, and keeps reloading them in the background if a reload interval is
+// configured.
+func NewAgent(logger lager.Logger, config Config) (*Agent, error) {
+	agent := &Agent{
+		config: config,
+		logger: logger,
+		client: &http.Client{Timeout: config.Timeout},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	_, err := agent.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("loaded-policies", lager.Data{"revision": agent.revision})
+
+	if config.ReloadInterval > 0 {
+		go agent.reloadPeriodically()
+	} else {
+		close(agent.done)
+	}
+
+	return agent, nil
+}
+
+// Stop stops reloading the policies.
+func (agent *Agent) Stop() {
+	close(agent.stop)
+	<-agent.done
+}
+
+func (agent *Agent) Check(input policy.PolicyCheckInput) (policy.PolicyCheckResult, error) {
+	start := time.Now()
+
+	agent.lock.RLock()
+	query, revision := agent.query, agent.revision
+	agent.lock.RUnlock()
+
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	agent.logger.Debug("embedded-opa-check", lager.Data{"input": string(payload)})
+
+	parsedInput, err := ast.ValueFromReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), agent.config.Timeout)
+	defer cancel()
+
+	value, err := agent.evaluate(ctx, query, parsedInput)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := agent.parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing policy result: %w", err)
+	}
+
+	if !res.allowed {
+		// tracing is slow, so it's only paid for when there is something to
+		// explain
+		tracer := topdown.NewBufferTracer()
+
+		_, err = agent.evaluate(ctx, query, parsedInput, rego.EvalQueryTracer(tracer))
+		if err != nil {
+			return nil, err
+		}
+
+		res.messages = explain(*tracer, res.messages)
+	}
+
+	agent.logger.Info("decision", lager.Data{
+		"revision": revision,
+		"action":   input.Action,
+		"user":     input.User,
+		"team":     input.Team,
+		"pipeline": input.Pipeline,
+		"allowed":  res.allowed,
+		"block":    res.shouldBlock,
+		"reasons":  res.messages,
+		"duration": time.Since(start).String(),
+	})
+
+	return res, nil
+}
+
+func (agent *Agent) evaluate(ctx context.Context, query rego.PreparedEvalQuery, input ast.Value, options ...rego.EvalOption) (interface{}, error) {
+	resultSet, err := query.Eval(ctx, append(options, rego.EvalParsedInput(input))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resultSet) == 0 || len(resultSet[0].Expressions) == 0 {
+		return nil, fmt.Errorf("policy query '%s' is undefined", agent.config.Query)
+	}
+
+	return resultSet[0].Expressions[0].Value, nil
+}
+
+// parse interprets the query result the same way as the OPA agent does the
+// result of its HTTP API.
+func (agent *Agent) parse(value interface{}) (result, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return result{}, err
+	}
+
+	parsed, err := opa.ParseOpaResult(payload, opa.OpaConfig{
+		ResultAllowedKey:     agent.config.ResultAllowedKey,
+		ResultShouldBlockKey: agent.config.ResultShouldBlockKey,
+		ResultMessagesKey:    agent.config.ResultMessagesKey,
+	})
+	if err != nil {
+		return result{}, err
+	}
+
+	return result{
+		allowed:     parsed.Allowed(),
+		shouldBlock: parsed.ShouldBlock(),
+		messages:    parsed.Messages(),
+	}, nil
+}
+
+// reload loads the policies and swaps them in if they have changed. If they
+// fail to load or compile, the previous policies are kept.
+func (agent *Agent) reload() (bool, error) {
+	loaded, err := loadPolicies(agent.client, agent.config.Bundles)
+	if err != nil {
+		return false, err
+	}
+
+	agent.lock.RLock()
+	unchanged := loaded.revision == agent.revision
+	agent.lock.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), agent.config.Timeout)
+	defer cancel()
+
+	query, err := loaded.prepare(ctx, agent.config.Query)
+	if err != nil {
+		return false, err
+	}
+
+	agent.lock.Lock()
+	agent.query = query
+	agent.revision = loaded.revision
+	agent.lock.Unlock()
+
+	return true, nil
+}
+
+func (agent *Agent) reloadPeriodically() {
+	defer close(agent.done)
+
+	ticker := time.NewTicker(agent.config.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-agent.stop:
+			return
+		case <-ticker.C:
+			logger := agent.logger.Session("reload")
+
+			reloaded, err := agent.reload()
+			if err != nil {
+				logger.Error("failed-to-reload-policies", err)
+				continue
+			}
+
+			if reloaded {
+				logger.Info("reloaded-policies", lager.Data{"revision": agent.revision})
+			}
+		}
+	}
+}
+
+// explain annotates each message with the rule that produced it, e.g. a
+// 'deny[msg]' rule, so that whoever is blocked knows which policy to look at.
+// Messages which were not produced by a rule are left as they are.
+func explain(trace []*topdown.Event, messages []string) []string {
+	rules := map[string]*ast.Rule{}
+	for _, event := range trace {
+		if event.Op != topdown.ExitOp {
+			continue
+		}
+
+		rule, ok := event.Node.(*ast.Rule)
+		if !ok || rule.Module == nil {
+			continue
+		}
+
+		term := rule.Head.Key
+		if term == nil {
+			term = rule.Head.Value
+		}
+
+		if term == nil {
+			continue
+		}
+
+		value := term.Value
+		if v, ok := value.(ast.Var); ok && event.Locals != nil {
+			value = event.Locals.Get(v)
+		}
+
+		message, ok := value.(ast.String)
+		if !ok {
+			continue
+		}
+
+		if _, found := rules[string(message)]; !found {
+			rules[string(message)] = rule
+		}
+	}
+
+	explained := make([]string, len(messages))
+	for i, message := range messages {
+		rule, found := rules[message]
+		if !found {
+			explained[i] = message
+			continue
+		}
+
+		explained[i] = fmt.Sprintf("%s (rule %s at %s)", message, rule.Path(), rule.Location)
+	}
+
+	return explained
+}
@@ -0,0 +1,328 @@
+package embedded_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/embedded"
+	"github.com/open-policy-agent/opa/bundle"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const pipelinePolicy = `package concourse
+
+deny[msg] {
+	input.action == "UseImage"
+	input.data.privileged
+	msg := sprintf("privileged image %s is not allowed", [input.data.image_source.repository])
+}
+
+deny["pipelines must not be named 'forbidden'"] {
+	input.action == "SetPipeline"
+	input.pipeline == "forbidden"
+}
+
+warn["pipelines should not be named 'discouraged'"] {
+	input.action == "SetPipeline"
+	input.pipeline == "discouraged"
+}
+
+decision = {
+	"allowed": count(deny) + count(warn) == 0,
+	"block": count(deny) > 0,
+	"reasons": array.concat([r | deny[r]], [r | warn[r]]),
+}
+`
+
+var _ = Describe("Embedded Policy Agent", func() {
+	var (
+		logger *lagertest.TestLogger
+		dir    string
+		config embedded.Config
+		agent  *embedded.Agent
+		newErr error
+
+		policyFile string
+	)
+
+	writePolicy := func(name string, contents string) {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("embedded")
+
+		var err error
+		dir, err = ioutil.TempDir("", "embedded-policies")
+		Expect(err).ToNot(HaveOccurred())
+
+		writePolicy("pipelines.rego", pipelinePolicy)
+		policyFile = filepath.Join(dir, "pipelines.rego")
+
+		config = embedded.Config{
+			Bundles:              []string{dir},
+			Query:                "data.concourse.decision",
+			Timeout:              5 * time.Second,
+			ResultAllowedKey:     "allowed",
+			ResultShouldBlockKey: "block",
+			ResultMessagesKey:    "reasons",
+		}
+	})
+
+	AfterEach(func() {
+		if agent != nil {
+			agent.Stop()
+			agent = nil
+		}
+
+		os.RemoveAll(dir)
+	})
+
+	JustBeforeEach(func() {
+		agent, newErr = embedded.NewAgent(logger, config)
+	})
+
+	It("is configured when bundles are given", func() {
+		Expect((&embedded.Config{}).IsConfigured()).To(BeFalse())
+		Expect(config.IsConfigured()).To(BeTrue())
+	})
+
+	Context("when the policies load", func() {
+		JustBeforeEach(func() {
+			Expect(newErr).ToNot(HaveOccurred())
+		})
+
+		Context("when no policy denies the action", func() {
+			It("allows it", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{
+					Action:   policy.ActionRunSetPipeline,
+					Team:     "main",
+					Pipeline: "some-pipeline",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeTrue())
+				Expect(result.ShouldBlock()).To(BeFalse())
+				Expect(result.Messages()).To(BeEmpty())
+			})
+
+			It("logs the decision", func() {
+				_, err := agent.Check(policy.PolicyCheckInput{
+					Action:   policy.ActionRunSetPipeline,
+					Team:     "main",
+					Pipeline: "some-pipeline",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				logs := logger.LogMessages()
+				Expect(logs).To(ContainElement("embedded.decision"))
+			})
+		})
+
+		Context("when a policy denies the action", func() {
+			It("blocks it, explaining which rule denied it", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{
+					Action: policy.ActionUseImage,
+					Team:   "main",
+					Data: map[string]interface{}{
+						"privileged":   true,
+						"image_source": map[string]interface{}{"repository": "busybox"},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeTrue())
+				Expect(result.Messages()).To(ConsistOf(
+					"privileged image busybox is not allowed (rule data.concourse.deny at " + policyFile + ":3)",
+				))
+			})
+
+			It("explains rules with constant messages", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{
+					Action:   policy.ActionRunSetPipeline,
+					Pipeline: "forbidden",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ShouldBlock()).To(BeTrue())
+				Expect(result.Messages()).To(ConsistOf(
+					"pipelines must not be named 'forbidden' (rule data.concourse.deny at " + policyFile + ":9)",
+				))
+			})
+		})
+
+		Context("when a policy only warns about the action", func() {
+			It("does not block it", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{
+					Action:   policy.ActionRunSetPipeline,
+					Pipeline: "discouraged",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeFalse())
+				Expect(result.Messages()).To(ConsistOf(
+					"pipelines should not be named 'discouraged' (rule data.concourse.warn at " + policyFile + ":14)",
+				))
+			})
+		})
+
+		Context("when policies read data documents", func() {
+			BeforeEach(func() {
+				writePolicy("data.json", `{"concourse": {"allowed_teams": ["main"]}}`)
+				writePolicy("pipelines.rego", `package concourse
+
+decision = {"allowed": allowed}
+
+default allowed = false
+
+allowed {
+	data.concourse.allowed_teams[_] == input.team
+}
+`)
+			})
+
+			It("evaluates against them", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{Team: "main"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeTrue())
+
+				result, err = agent.Check(policy.PolicyCheckInput{Team: "other"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeTrue())
+			})
+		})
+
+		Context("when the query is undefined", func() {
+			BeforeEach(func() {
+				config.Query = "data.concourse.nope"
+			})
+
+			It("returns an error", func() {
+				_, err := agent.Check(policy.PolicyCheckInput{})
+				Expect(err).To(MatchError("policy query 'data.concourse.nope' is undefined"))
+			})
+		})
+
+		Context("when the result has no allowed key", func() {
+			BeforeEach(func() {
+				config.ResultAllowedKey = "permitted"
+			})
+
+			It("returns an error", func() {
+				_, err := agent.Check(policy.PolicyCheckInput{})
+				Expect(err).To(MatchError(ContainSubstring("allowed: key 'permitted' not found")))
+			})
+		})
+
+		Context("when the bundle is served over http", func() {
+			var server *httptest.Server
+
+			BeforeEach(func() {
+				buf := new(bytes.Buffer)
+				err := bundle.NewWriter(buf).Write(bundle.Bundle{
+					Data: map[string]interface{}{},
+					Modules: []bundle.ModuleFile{
+						{
+							URL:  "/pipelines.rego",
+							Path: "/pipelines.rego",
+							Raw:  []byte(pipelinePolicy),
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				tarball := buf.Bytes()
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write(tarball)
+				}))
+
+				config.Bundles = []string{server.URL + "/bundle.tar.gz"}
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("evaluates the policies in it", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{
+					Action:   policy.ActionRunSetPipeline,
+					Pipeline: "forbidden",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ShouldBlock()).To(BeTrue())
+				Expect(result.Messages()).To(ConsistOf(
+					"pipelines must not be named 'forbidden' (rule data.concourse.deny at " + server.URL + "/bundle.tar.gz#/pipelines.rego:9)",
+				))
+			})
+		})
+
+		Context("when the policies change", func() {
+			BeforeEach(func() {
+				config.ReloadInterval = 10 * time.Millisecond
+			})
+
+			It("reloads them", func() {
+				result, err := agent.Check(policy.PolicyCheckInput{Action: policy.ActionRunSetPipeline, Pipeline: "new-pipeline"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeTrue())
+
+				writePolicy("pipelines.rego", `package concourse
+
+decision = {"allowed": false, "reasons": ["no more pipelines"]}
+`)
+
+				Eventually(func() bool {
+					result, err := agent.Check(policy.PolicyCheckInput{Action: policy.ActionRunSetPipeline, Pipeline: "new-pipeline"})
+					Expect(err).ToNot(HaveOccurred())
+					return result.Allowed()
+				}).Should(BeFalse())
+			})
+
+			Context("when the new policies do not compile", func() {
+				It("keeps using the old ones", func() {
+					writePolicy("pipelines.rego", `package concourse
+
+decision = {
+`)
+
+					Eventually(logger.LogMessages).Should(ContainElement("embedded.reload.failed-to-reload-policies"))
+
+					result, err := agent.Check(policy.PolicyCheckInput{Action: policy.ActionRunSetPipeline, Pipeline: "forbidden"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.ShouldBlock()).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Context("when the policies do not compile", func() {
+		BeforeEach(func() {
+			writePolicy("broken.rego", "package concourse\n\ndecision = {\n")
+		})
+
+		It("fails to create the agent", func() {
+			Expect(newErr).To(HaveOccurred())
+			Expect(agent).To(BeNil())
+		})
+	})
+
+	Context("when there are no policies", func() {
+		BeforeEach(func() {
+			os.Remove(filepath.Join(dir, "pipelines.rego"))
+		})
+
+		It("fails to create the agent", func() {
+			Expect(newErr).To(MatchError(ContainSubstring("no policies found")))
+			Expect(agent).To(BeNil())
+		})
+	})
+})
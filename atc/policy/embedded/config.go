@@ -0,0 +1,30 @@
+package embedded
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc/policy"
+)
+
+type Config struct {
+	Bundles              []string      `long:"embedded-opa-bundle" description:"Path to a .rego file, directory or .tar.gz bundle, or http(s) URL of a bundle, containing the policies to evaluate in-process. Can be specified multiple times."`
+	Query                string        `long:"embedded-opa-query" default:"data.concourse.decision" description:"Rego query whose result decides policy checks."`
+	ReloadInterval       time.Duration `long:"embedded-opa-reload-interval" default:"30s" description:"Interval on which to reload the policies if they have changed. 0 disables reloading."`
+	Timeout              time.Duration `long:"embedded-opa-timeout" default:"5s" description:"Timeout for evaluating a policy check or downloading a bundle."`
+	ResultAllowedKey     string        `long:"embedded-opa-result-allowed-key" default:"allowed" description:"Key name of if pass policy check in the query result. Expects a boolean value."`
+	ResultShouldBlockKey string        `long:"embedded-opa-result-should-block-key" default:"block" description:"Key name of if should block current action in the query result. Expects a boolean value."`
+	ResultMessagesKey    string        `long:"embedded-opa-result-messages-key" default:"reasons" description:"Key name of messages in the query result."`
+}
+
+func init() {
+	policy.RegisterAgent(&Config{})
+}
+
+func (c *Config) Description() string { return "Embedded Open Policy Agent" }
+func (c *Config) IsConfigured() bool  { return len(c.Bundles) > 0 }
+
+func (c *Config) NewAgent(logger lager.Logger) (policy.Agent, error) {
+	return NewAgent(logger, *c)
+}
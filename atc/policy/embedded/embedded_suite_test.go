@@ -0,0 +1,13 @@
+package embedded_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestEmbedded(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Embedded Policy Agent Suite")
+}
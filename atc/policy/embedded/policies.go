@@ -0,0 +1,180 @@
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/loader"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
+)
+
+// policies are the modules and data loaded from all of the configured
+// bundles. The revision changes whenever any of them do.
+type policies struct {
+	modules  map[string][]byte
+	data     map[string]interface{}
+	revision string
+}
+
+func loadPolicies(client *http.Client, sources []string) (policies, error) {
+	loaded := policies{
+		modules: map[string][]byte{},
+		data:    map[string]interface{}{},
+	}
+
+	var paths []string
+	for _, source := range sources {
+		if isURL(source) {
+			err := loaded.download(client, source)
+			if err != nil {
+				return policies{}, fmt.Errorf("load %s: %w", source, err)
+			}
+		} else {
+			paths = append(paths, source)
+		}
+	}
+
+	if len(paths) > 0 {
+		result, err := loader.NewFileLoader().All(paths)
+		if err != nil {
+			return policies{}, err
+		}
+
+		for _, file := range result.Modules {
+			loaded.modules[file.Name] = file.Raw
+		}
+
+		err = mergeDocuments(loaded.data, result.Documents)
+		if err != nil {
+			return policies{}, err
+		}
+	}
+
+	if len(loaded.modules) == 0 {
+		return policies{}, fmt.Errorf("no policies found in %s", strings.Join(sources, ", "))
+	}
+
+	revision, err := loaded.fingerprint()
+	if err != nil {
+		return policies{}, err
+	}
+
+	loaded.revision = revision
+
+	return loaded, nil
+}
+
+// download fetches a bundle, which is either a gzipped tarball in the OPA
+// bundle format or a single .rego file.
+func (loaded *policies) download(client *http.Client, url string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if !isGzip(body) {
+		loaded.modules[url] = body
+		return nil
+	}
+
+	b, err := bundle.NewReader(bytes.NewReader(body)).Read()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range b.Modules {
+		loaded.modules[url+"#"+file.Path] = file.Raw
+	}
+
+	return mergeDocuments(loaded.data, b.Data)
+}
+
+func (loaded policies) fingerprint() (string, error) {
+	var names []string
+	for name := range loaded.modules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\x00%d\x00", name, len(loaded.modules[name]))
+		hash.Write(loaded.modules[name])
+	}
+
+	// maps are marshalled with sorted keys, so this is stable
+	data, err := json.Marshal(loaded.data)
+	if err != nil {
+		return "", err
+	}
+
+	hash.Write(data)
+
+	return hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
+
+func (loaded policies) prepare(ctx context.Context, query string) (rego.PreparedEvalQuery, error) {
+	options := []func(*rego.Rego){
+		rego.Query(query),
+		rego.Store(inmem.NewFromObject(loaded.data)),
+	}
+
+	for name, module := range loaded.modules {
+		options = append(options, rego.Module(name, string(module)))
+	}
+
+	return rego.New(options...).PrepareForEval(ctx)
+}
+
+func mergeDocuments(dest map[string]interface{}, src map[string]interface{}) error {
+	for key, value := range src {
+		existing, found := dest[key]
+		if !found {
+			dest[key] = value
+			continue
+		}
+
+		existingObj, ok1 := existing.(map[string]interface{})
+		valueObj, ok2 := value.(map[string]interface{})
+		if !ok1 || !ok2 {
+			return fmt.Errorf("conflicting data for key '%s'", key)
+		}
+
+		err := mergeDocuments(existingObj, valueObj)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+func isGzip(body []byte) bool {
+	return len(body) > 2 && body[0] == 0x1f && body[1] == 0x8b
+}
@@ -16,6 +16,7 @@ func (plan Plan) Public() *json.RawMessage {
 		Run            *json.RawMessage `json:"run,omitempty"`
 		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		Approval       *json.RawMessage `json:"approval,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
@@ -24,6 +25,7 @@ func (plan Plan) Public() *json.RawMessage {
 		Try            *json.RawMessage `json:"try,omitempty"`
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
+		If             *json.RawMessage `json:"if,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
 		ArtifactInput  *json.RawMessage `json:"artifact_input,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
@@ -71,6 +73,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -99,6 +105,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Timeout = plan.Timeout.Public()
 	}
 
+	if plan.If != nil {
+		public.If = plan.If.Public()
+	}
+
 	if plan.Retry != nil {
 		public.Retry = plan.Retry.Public()
 	}
@@ -307,6 +317,16 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name         string `json:"name"`
+		Instructions string `json:"instructions,omitempty"`
+	}{
+		Name:         plan.Name,
+		Instructions: plan.Instructions,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -317,6 +337,16 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan IfPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step      *json.RawMessage `json:"step"`
+		Condition string           `json:"condition"`
+	}{
+		Step:      plan.Step.Public(),
+		Condition: plan.Condition,
+	})
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
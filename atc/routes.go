@@ -15,6 +15,8 @@ const (
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 	SetBuildComment     = "SetBuildComment"
+	ApproveBuild        = "ApproveBuild"
+	ListBuildTestSuites = "ListBuildTestSuites"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -105,6 +107,15 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	ListNotificationDeliveries = "ListNotificationDeliveries"
+	SearchBuildLogs            = "SearchBuildLogs"
+	GetTeamSealingKey          = "GetTeamSealingKey"
+	ListSecretAccesses         = "ListSecretAccesses"
+	SetPipelineAuth            = "SetPipelineAuth"
+	ListTeamAPITokens          = "ListTeamAPITokens"
+	CreateTeamAPIToken         = "CreateTeamAPIToken"
+	RevokeTeamAPIToken         = "RevokeTeamAPIToken"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
@@ -115,6 +126,19 @@ const (
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
+
+	ListAuditEvents = "ListAuditEvents"
+
+	ListAPITokens  = "ListAPITokens"
+	CreateAPIToken = "CreateAPIToken"
+	RevokeAPIToken = "RevokeAPIToken"
+
+	ListUserSessions   = "ListUserSessions"
+	RevokeUserSession  = "RevokeUserSession"
+	RevokeUserSessions = "RevokeUserSessions"
+	ListSessions       = "ListSessions"
+	RevokeSession      = "RevokeSession"
+	RevokeSessions     = "RevokeSessions"
 )
 
 const (
@@ -137,6 +161,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
 	{Path: "/api/v1/builds/:build_id/comment", Method: "PUT", Name: SetBuildComment},
+	{Path: "/api/v1/builds/:build_id/approvals/:step_name", Method: "PUT", Name: ApproveBuild},
+	{Path: "/api/v1/builds/:build_id/tests", Method: "GET", Name: ListBuildTestSuites},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -214,6 +240,19 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/user", Method: "GET", Name: GetUser},
 	{Path: "/api/v1/users", Method: "GET", Name: ListActiveUsersSince},
 
+	{Path: "/api/v1/audit", Method: "GET", Name: ListAuditEvents},
+
+	{Path: "/api/v1/tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/tokens/:token_id", Method: "DELETE", Name: RevokeAPIToken},
+
+	{Path: "/api/v1/user/sessions", Method: "GET", Name: ListUserSessions},
+	{Path: "/api/v1/user/sessions", Method: "DELETE", Name: RevokeUserSessions},
+	{Path: "/api/v1/user/sessions/:session_id", Method: "DELETE", Name: RevokeUserSession},
+	{Path: "/api/v1/sessions", Method: "GET", Name: ListSessions},
+	{Path: "/api/v1/sessions", Method: "DELETE", Name: RevokeSessions},
+	{Path: "/api/v1/sessions/:session_id", Method: "DELETE", Name: RevokeSession},
+
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
 	{Path: "/api/v1/teams/:team_name/containers", Method: "GET", Name: ListContainers},
@@ -230,6 +269,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/builds/search", Method: "GET", Name: SearchBuildLogs},
+	{Path: "/api/v1/teams/:team_name/notifications/deliveries", Method: "GET", Name: ListNotificationDeliveries},
+	{Path: "/api/v1/teams/:team_name/sealing_key", Method: "GET", Name: GetTeamSealingKey},
+	{Path: "/api/v1/teams/:team_name/secrets/accesses", Method: "GET", Name: ListSecretAccesses},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/auth", Method: "PUT", Name: SetPipelineAuth},
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "GET", Name: ListTeamAPITokens},
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "POST", Name: CreateTeamAPIToken},
+	{Path: "/api/v1/teams/:team_name/tokens/:token_id", Method: "DELETE", Name: RevokeTeamAPIToken},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
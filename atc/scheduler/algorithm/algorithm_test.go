@@ -3286,4 +3286,163 @@ var _ = DescribeTable("Input resolving",
 			},
 		},
 	}),
+
+	Entry("resolves passed_any with the latest build of any of the jobs", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "fast-lane", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "full-lane", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "fast-lane", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+				{Job: "full-lane", BuildID: 4, Resource: "resource-x", Version: "rxv4", CheckOrder: 4},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				PassedAny: []string{"fast-lane", "full-lane"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv4",
+			},
+			PassedBuildIDs: map[string][]int{
+				"resource-x": []int{4},
+			},
+		},
+	}),
+
+	Entry("resolves passed_any with another job when the latest build does not satisfy the passed constraints", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "unit", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "unit", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "full-lane", BuildID: 3, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "full-lane", BuildID: 4, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "fast-lane", BuildID: 5, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Passed:    []string{"unit"},
+				PassedAny: []string{"fast-lane", "full-lane"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+			PassedBuildIDs: map[string][]int{
+				"resource-x": []int{2, 4},
+			},
+		},
+	}),
+
+	Entry("returns a missing input reason when none of the passed_any jobs passed a version that satisfies the passed constraints", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "unit", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "unit", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "fast-lane", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Passed:    []string{"unit"},
+				PassedAny: []string{"fast-lane", "full-lane"},
+			},
+		},
+
+		Result: Result{
+			OK: false,
+			Errors: map[string]string{
+				"resource-x": "no satisfiable builds from passed jobs found for set of inputs",
+			},
+		},
+	}),
+
+	Entry("resolves inputs sharing passed_any jobs from the same build", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "fast-lane", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "fast-lane", BuildID: 1, Resource: "resource-y", Version: "ryv1", CheckOrder: 1},
+				{Job: "full-lane", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "full-lane", BuildID: 2, Resource: "resource-y", Version: "ryv2", CheckOrder: 2},
+				{Job: "fast-lane", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				PassedAny: []string{"fast-lane", "full-lane"},
+			},
+			{
+				Name:      "resource-y",
+				Resource:  "resource-y",
+				PassedAny: []string{"fast-lane", "full-lane"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+				"resource-y": "ryv2",
+			},
+		},
+	}),
+
+	Entry("with every and passed_any, returns the earliest unused build of any of the jobs", Example{
+		DB: DB{
+			BuildInputs: []DBRow{
+				{Job: CurrentJobName, BuildID: 100, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+
+			BuildPipes: []DBRow{
+				{FromBuildID: 1, ToBuildID: 100},
+			},
+
+			BuildOutputs: []DBRow{
+				{Job: "fast-lane", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "full-lane", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "fast-lane", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Version:   Version{Every: true},
+				PassedAny: []string{"fast-lane", "full-lane"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
 )
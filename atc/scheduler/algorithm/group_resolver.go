@@ -172,18 +172,136 @@ func (r *groupResolver) trySatisfyPassedConstraintsForInput(ctx context.Context,
 
 		if worked {
 			// resolving recursively worked!
-			break
+			span.SetStatus(codes.Ok, "")
+			return true, "", nil
 		} else {
 			span.SetStatus(codes.Error, "")
 			return false, db.NoSatisfiableBuilds, nil
 		}
 	}
 
+	if len(inputConfig.PassedAny) != 0 {
+		orderedAnyJobs := r.orderJobs(inputConfig.PassedAny)
+
+		for _, passedJobID := range orderedAnyJobs {
+			if currentCandidate != nil && currentCandidate.VouchedForBy[passedJobID] {
+				// one of the jobs has already vouched for the candidate, which is
+				// all that passed_any asks for
+				span.SetStatus(codes.Ok, "")
+				return true, "", nil
+			}
+		}
+
+		worked, err := r.tryPassedAnyBuilds(ctx, inputIndex, orderedAnyJobs)
+		if err != nil {
+			tracing.End(span, err)
+			return false, "", err
+		}
+
+		if !worked {
+			span.SetStatus(codes.Error, "")
+			return false, db.NoSatisfiableBuilds, nil
+		}
+	}
+
 	// all passed constraints were satisfied
 	span.SetStatus(codes.Ok, "")
 	return true, "", nil
 }
 
+// passedAnyJobBuilds is the next build to try from one of an input's
+// passed_any jobs.
+type passedAnyJobBuilds struct {
+	jobID   int
+	builds  db.PaginatedBuilds
+	buildID int
+	hasNext bool
+}
+
+func (b *passedAnyJobBuilds) advance(ctx context.Context) (bool, error) {
+	buildID, ok, err := b.builds.Next(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	b.buildID = buildID
+	b.hasNext = b.builds.HasNext()
+
+	return true, nil
+}
+
+// tryPassedAnyBuilds tries the builds of all of the input's passed_any jobs
+// together, so that the most recent build is tried first no matter which job
+// it came from. With version: every the oldest unused build goes first
+// instead.
+func (r *groupResolver) tryPassedAnyBuilds(ctx context.Context, inputIndex int, passedJobIDs []int) (bool, error) {
+	ctx, span := tracing.StartSpan(ctx, "groupResolver.tryPassedAnyBuilds", tracing.Attrs{})
+	defer span.End()
+
+	inputConfig := r.inputConfigs[inputIndex]
+
+	jobBuilds := []*passedAnyJobBuilds{}
+	for _, passedJobID := range passedJobIDs {
+		builds, _, err := r.paginatedBuilds(ctx, inputConfig, r.candidates[inputIndex], inputConfig.JobID, passedJobID)
+		if err != nil {
+			tracing.End(span, err)
+			return false, err
+		}
+
+		next := &passedAnyJobBuilds{jobID: passedJobID, builds: builds}
+
+		ok, err := next.advance(ctx)
+		if err != nil {
+			tracing.End(span, err)
+			return false, err
+		}
+
+		if ok {
+			jobBuilds = append(jobBuilds, next)
+		}
+	}
+
+	for len(jobBuilds) > 0 {
+		nextIdx := 0
+		for i, b := range jobBuilds {
+			if inputConfig.UseEveryVersion {
+				if b.buildID < jobBuilds[nextIdx].buildID {
+					nextIdx = i
+				}
+			} else if b.buildID > jobBuilds[nextIdx].buildID {
+				nextIdx = i
+			}
+		}
+
+		next := jobBuilds[nextIdx]
+
+		worked, err := r.tryBuildOutputs(ctx, inputIndex, next.jobID, next.buildID, next.hasNext)
+		if err != nil {
+			tracing.End(span, err)
+			return false, err
+		}
+
+		if worked {
+			span.SetStatus(codes.Ok, "")
+			return true, nil
+		}
+
+		ok, err := next.advance(ctx)
+		if err != nil {
+			tracing.End(span, err)
+			return false, err
+		}
+
+		if !ok {
+			// reached the end of this job's builds
+			jobBuilds = append(jobBuilds[:nextIdx], jobBuilds[nextIdx+1:]...)
+		}
+	}
+
+	span.SetStatus(codes.Error, "")
+	return false, nil
+}
+
 func (r *groupResolver) tryJobBuilds(ctx context.Context, inputIndex int, passedJobID int, builds db.PaginatedBuilds) (bool, error) {
 	ctx, span := tracing.StartSpan(ctx, "groupResolver.tryJobBuilds", tracing.Attrs{})
 	defer span.End()
@@ -366,7 +484,7 @@ func (r *groupResolver) paginatedBuilds(ctx context.Context, currentInputConfig
 
 		relatedPassedBuilds := map[int]db.BuildCursor{}
 		for jobID, build := range r.lastUsedPassedBuilds {
-			if currentInputConfig.Passed[jobID] {
+			if currentInputConfig.Passed[jobID] || currentInputConfig.PassedAny[jobID] {
 				relatedPassedBuilds[jobID] = build
 			}
 		}
@@ -383,7 +501,7 @@ func (r *groupResolver) paginatedBuilds(ctx context.Context, currentInputConfig
 			}
 
 			return paginatedBuilds, false, err
-		} else if currentCandidate == nil && len(relatedPassedBuilds) > 0 {
+		} else if currentCandidate == nil && len(relatedPassedBuilds) > 0 && currentInputConfig.Passed[passedJobID] {
 			// we've run with version: every and passed: before, just not with this
 			// job, and there's no candidate yet, so skip it for now and let the
 			// algorithm continue from where the other jobs left off rather than
@@ -391,6 +509,9 @@ func (r *groupResolver) paginatedBuilds(ctx context.Context, currentInputConfig
 			//
 			// this job will eventually vouch for it during the recursive resolve
 			// call
+			//
+			// passed_any jobs are never skipped, as the input may not be waiting
+			// on them at all
 			return db.PaginatedBuilds{}, true, nil
 		}
 	}
@@ -409,7 +530,7 @@ func (r *groupResolver) paginatedBuilds(ctx context.Context, currentInputConfig
 func (r *groupResolver) constrainingCandidates(passedJobID int) map[string][]string {
 	constrainingCandidates := map[string][]string{}
 	for passedIndex, passedInput := range r.inputConfigs {
-		if passedInput.Passed[passedJobID] && r.candidates[passedIndex] != nil {
+		if (passedInput.Passed[passedJobID] || passedInput.PassedAny[passedJobID]) && r.candidates[passedIndex] != nil {
 			resID := strconv.Itoa(passedInput.ResourceID)
 			constrainingCandidates[resID] = append(constrainingCandidates[resID], string(r.candidates[passedIndex].Version))
 		}
@@ -427,7 +548,7 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 		return false, false, nil
 	}
 
-	if !inputConfig.Passed[passedJobID] {
+	if !inputConfig.Passed[passedJobID] && !inputConfig.PassedAny[passedJobID] {
 		// unrelated; this input is unaffected by the current job
 		return false, false, nil
 	}
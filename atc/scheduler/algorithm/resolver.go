@@ -18,7 +18,7 @@ func constructResolvers(
 	resolvers := []Resolver{}
 	inputConfigsWithPassed := db.InputConfigs{}
 	for _, input := range inputs {
-		if len(input.Passed) == 0 {
+		if len(input.Passed) == 0 && len(input.PassedAny) == 0 {
 			if input.PinnedVersion != nil {
 				resolvers = append(resolvers, NewPinnedResolver(versions, input))
 			} else {
@@ -44,7 +44,7 @@ func groupInputsConfigsByPassedJobs(passedInputConfigs db.InputConfigs) []relate
 		var index int
 		var found bool
 
-		for passedJob := range inputConfig.Passed {
+		for passedJob := range constrainingJobs(inputConfig) {
 			for groupIndex, group := range groupedPassedInputConfigs {
 				if group.passedJobs[passedJob] {
 					found = true
@@ -56,14 +56,14 @@ func groupInputsConfigsByPassedJobs(passedInputConfigs db.InputConfigs) []relate
 		if found {
 			groupedPassedInputConfigs[index].inputConfigs = append(groupedPassedInputConfigs[index].inputConfigs, inputConfig)
 
-			for inputPassedJob := range inputConfig.Passed {
+			for inputPassedJob := range constrainingJobs(inputConfig) {
 				if !groupedPassedInputConfigs[index].passedJobs[inputPassedJob] {
 					groupedPassedInputConfigs[index].passedJobs[inputPassedJob] = true
 				}
 			}
 		} else {
 			passedJobs := map[int]bool{}
-			for jobID := range inputConfig.Passed {
+			for jobID := range constrainingJobs(inputConfig) {
 				passedJobs[jobID] = true
 			}
 
@@ -76,3 +76,18 @@ func groupInputsConfigsByPassedJobs(passedInputConfigs db.InputConfigs) []relate
 
 	return groupedPassedInputConfigs
 }
+
+// constrainingJobs returns the jobs listed in either the input's passed or
+// passed_any constraints; inputs sharing any of them are resolved together.
+func constrainingJobs(inputConfig db.InputConfig) db.JobSet {
+	jobs := db.JobSet{}
+	for jobID := range inputConfig.Passed {
+		jobs[jobID] = true
+	}
+
+	for jobID := range inputConfig.PassedAny {
+		jobs[jobID] = true
+	}
+
+	return jobs
+}
@@ -63,6 +63,7 @@ type Input struct {
 	Name                  string
 	Resource              string
 	Passed                []string
+	PassedAny             []string
 	Version               Version
 	NoResourceConfigScope bool
 }
@@ -162,9 +163,16 @@ func (example Example) Run() {
 			passed[setup.jobIDs.ID(jobName)] = true
 		}
 
+		passedAny := db.JobSet{}
+		for _, jobName := range input.PassedAny {
+			setup.insertJob(jobName)
+			passedAny[setup.jobIDs.ID(jobName)] = true
+		}
+
 		inputConfigs[i] = db.InputConfig{
 			Name:            input.Name,
 			Passed:          passed,
+			PassedAny:       passedAny,
 			ResourceID:      setup.resourceIDs.ID(input.Resource),
 			UseEveryVersion: input.Version.Every,
 			JobID:           setup.jobIDs.ID(CurrentJobName),
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 )
 
 //counterfeiter:generate . BuildStarter
@@ -34,16 +35,19 @@ type Build interface {
 func NewBuildStarter(
 	planner BuildPlanner,
 	algorithm Algorithm,
+	notifier notifications.Notifier,
 ) BuildStarter {
 	return &buildStarter{
 		planner:   planner,
 		algorithm: algorithm,
+		notifier:  notifier,
 	}
 }
 
 type buildStarter struct {
 	planner   BuildPlanner
 	algorithm Algorithm
+	notifier  notifications.Notifier
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -233,6 +237,10 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		metric.Metrics.CheckBuildsStarted.Inc()
 	} else {
 		metric.Metrics.BuildsStarted.Inc()
+
+		// notified here rather than when the build is run, as it is run again
+		// every time it is resumed
+		s.notifier.BuildStarted(logger, nextPendingBuild)
 	}
 
 	return startResults{
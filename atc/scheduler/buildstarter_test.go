@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifications/notificationsfakes"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
 
@@ -19,6 +20,7 @@ var _ = Describe("BuildStarter", func() {
 	var (
 		fakePipeline  *dbfakes.FakePipeline
 		fakePlanner   *schedulerfakes.FakeBuildPlanner
+		fakeNotifier  *notificationsfakes.FakeNotifier
 		pendingBuilds []db.Build
 		fakeAlgorithm *schedulerfakes.FakeAlgorithm
 
@@ -34,7 +36,9 @@ var _ = Describe("BuildStarter", func() {
 		fakePlanner = new(schedulerfakes.FakeBuildPlanner)
 		fakeAlgorithm = new(schedulerfakes.FakeAlgorithm)
 
-		buildStarter = scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm)
+		fakeNotifier = new(notificationsfakes.FakeNotifier)
+
+		buildStarter = scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm, fakeNotifier)
 
 		disaster = errors.New("bad thing")
 	})
@@ -714,6 +718,13 @@ var _ = Describe("BuildStarter", func() {
 											Expect(pendingBuild2.StartCallCount()).To(Equal(1))
 										})
 
+										It("does not notify that it started", func() {
+											for i := 0; i < fakeNotifier.BuildStartedCallCount(); i++ {
+												_, notifiedBuild := fakeNotifier.BuildStartedArgsForCall(i)
+												Expect(notifiedBuild.ID()).ToNot(Equal(pendingBuild1.ID()))
+											}
+										})
+
 										It("finishes the build with aborted status", func() {
 											Expect(pendingBuild1.FinishCallCount()).To(Equal(1))
 											Expect(pendingBuild1.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
@@ -780,6 +791,17 @@ var _ = Describe("BuildStarter", func() {
 											Expect(rerunBuild.StartCallCount()).To(Equal(1))
 											Expect(rerunBuild.StartArgsForCall(0)).To(Equal(plannedPlan))
 										})
+
+										It("notifies that each build started", func() {
+											Expect(fakeNotifier.BuildStartedCallCount()).To(Equal(3))
+
+											var notifiedBuilds []int
+											for i := 0; i < fakeNotifier.BuildStartedCallCount(); i++ {
+												_, notifiedBuild := fakeNotifier.BuildStartedArgsForCall(i)
+												notifiedBuilds = append(notifiedBuilds, notifiedBuild.ID())
+											}
+											Expect(notifiedBuilds).To(ConsistOf(99, 999, 555))
+										})
 									})
 								})
 							})
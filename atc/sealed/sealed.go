@@ -0,0 +1,165 @@
+// Package sealed lets pipeline authors embed secrets directly in their
+// pipeline config as ((sealed:...)) vars, encrypted to a public key published
+// by their team. Only the ATC holds the team's private key, so the values are
+// only ever decrypted while resolving vars for a build.
+package sealed
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/concourse/concourse/vars"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// VarSource is the name of the var source through which sealed values are
+// referenced.
+const VarSource = "sealed"
+
+// ErrNoSealingKey is returned when unsealing a value for a team which has
+// never published a sealing key, and so can't have had anything sealed to it.
+var ErrNoSealingKey = errors.New("team has no sealing key")
+
+//counterfeiter:generate . KeyStore
+type KeyStore interface {
+	// SealingKey returns the team's age identity, if it has one.
+	SealingKey() (string, bool, error)
+
+	// SaveSealingKey saves the identity unless the team already has one,
+	// returning the identity the team ends up with.
+	SaveSealingKey(string) (string, error)
+}
+
+// Recipient returns the public key values are sealed to for the team,
+// generating the team's key pair the first time it is asked for.
+func Recipient(store KeyStore) (string, error) {
+	identity, found, err := store.SealingKey()
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		generated, err := age.GenerateX25519Identity()
+		if err != nil {
+			return "", err
+		}
+
+		identity, err = store.SaveSealingKey(generated.String())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	parsed, err := age.ParseX25519Identity(identity)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.Recipient().String(), nil
+}
+
+// Seal encrypts the value to the recipient, encoded so that it can be used
+// as the path of a ((sealed:...)) var.
+func Seal(recipient string, value string) (string, error) {
+	parsed, err := age.ParseX25519Recipient(strings.TrimSpace(recipient))
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+
+	writer, err := age.Encrypt(buf, parsed)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = writer.Write([]byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Unseal decrypts a value sealed by Seal with the identity.
+func Unseal(identity string, sealed string) (string, error) {
+	parsed, err := age.ParseX25519Identity(identity)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("malformed sealed value: %w", err)
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(ciphertext), parsed)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(value), nil
+}
+
+// Variables unseals the values referenced through the sealed var source with
+// the team's identity, and looks up all other vars in the variables they
+// wrap.
+type Variables struct {
+	vars.Variables
+
+	store KeyStore
+}
+
+func NewVariables(variables vars.Variables, store KeyStore) Variables {
+	return Variables{
+		Variables: variables,
+		store:     store,
+	}
+}
+
+func (v Variables) Get(ref vars.Reference) (interface{}, bool, error) {
+	if ref.Source != VarSource {
+		return v.Variables.Get(ref)
+	}
+
+	identity, found, err := v.store.SealingKey()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, ErrNoSealingKey
+	}
+
+	value, err := Unseal(identity, ref.Path)
+	if err != nil {
+		return nil, false, fmt.Errorf("unseal var: %w", err)
+	}
+
+	if len(ref.Fields) == 0 {
+		return value, true, nil
+	}
+
+	result, err := vars.Traverse(value, ref.String(), ref.Fields)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
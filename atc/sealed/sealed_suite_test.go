@@ -0,0 +1,13 @@
+package sealed_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSealed(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sealed Suite")
+}
@@ -0,0 +1,141 @@
+package sealed_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/sealed"
+	"github.com/concourse/concourse/atc/sealed/sealedfakes"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	identity  = "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX"
+	recipient = "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj"
+)
+
+var _ = Describe("Sealed", func() {
+	var fakeStore *sealedfakes.FakeKeyStore
+
+	BeforeEach(func() {
+		fakeStore = new(sealedfakes.FakeKeyStore)
+	})
+
+	Describe("Recipient", func() {
+		Context("when the team has a key", func() {
+			BeforeEach(func() {
+				fakeStore.SealingKeyReturns(identity, true, nil)
+			})
+
+			It("returns its public key", func() {
+				Expect(sealed.Recipient(fakeStore)).To(Equal(recipient))
+				Expect(fakeStore.SaveSealingKeyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the team has no key", func() {
+			BeforeEach(func() {
+				fakeStore.SealingKeyReturns("", false, nil)
+			})
+
+			It("returns the public key of the key the team ends up with", func() {
+				fakeStore.SaveSealingKeyReturns(identity, nil)
+
+				Expect(sealed.Recipient(fakeStore)).To(Equal(recipient))
+
+				Expect(fakeStore.SaveSealingKeyCallCount()).To(Equal(1))
+				Expect(fakeStore.SaveSealingKeyArgsForCall(0)).To(HavePrefix("AGE-SECRET-KEY-1"))
+			})
+
+			Context("when saving the key fails", func() {
+				BeforeEach(func() {
+					fakeStore.SaveSealingKeyReturns("", errors.New("nope"))
+				})
+
+				It("returns the error", func() {
+					_, err := sealed.Recipient(fakeStore)
+					Expect(err).To(MatchError("nope"))
+				})
+			})
+		})
+	})
+
+	Describe("Seal", func() {
+		It("produces a value which can be used as a var", func() {
+			value, err := sealed.Seal(recipient, "some-secret")
+			Expect(err).NotTo(HaveOccurred())
+
+			ref, err := vars.ParseReference("sealed:" + value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref.Source).To(Equal("sealed"))
+			Expect(ref.Path).To(Equal(value))
+			Expect(ref.Fields).To(BeEmpty())
+
+			Expect(sealed.Unseal(identity, value)).To(Equal("some-secret"))
+		})
+
+		It("rejects invalid recipients", func() {
+			_, err := sealed.Seal("bogus", "some-secret")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Variables", func() {
+		var (
+			fakeVars  *vars.StaticVariables
+			variables vars.Variables
+		)
+
+		BeforeEach(func() {
+			fakeVars = &vars.StaticVariables{"some-var": "some-value"}
+			fakeStore.SealingKeyReturns(identity, true, nil)
+
+			variables = sealed.NewVariables(fakeVars, fakeStore)
+		})
+
+		It("unseals sealed vars", func() {
+			value, err := sealed.Seal(recipient, "some-secret")
+			Expect(err).NotTo(HaveOccurred())
+
+			result, found, err := variables.Get(vars.Reference{Source: "sealed", Path: value})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(result).To(Equal("some-secret"))
+		})
+
+		It("looks up other vars in the wrapped variables", func() {
+			result, found, err := variables.Get(vars.Reference{Path: "some-var"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(result).To(Equal("some-value"))
+
+			Expect(fakeStore.SealingKeyCallCount()).To(Equal(0))
+		})
+
+		It("errors for values sealed to another team", func() {
+			value, err := sealed.Seal("age1qstf4rdnjtqt3qx2eum466c3ktw3rwvzh7eu9lhewqzy48537aeq8gsu2v", "some-secret")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = variables.Get(vars.Reference{Source: "sealed", Path: value})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("errors for malformed values", func() {
+			_, _, err := variables.Get(vars.Reference{Source: "sealed", Path: "not*base64"})
+			Expect(err).To(MatchError(ContainSubstring("malformed sealed value")))
+		})
+
+		Context("when the team has no sealing key", func() {
+			BeforeEach(func() {
+				fakeStore.SealingKeyReturns("", false, nil)
+			})
+
+			It("returns ErrNoSealingKey", func() {
+				_, _, err := variables.Get(vars.Reference{Source: "sealed", Path: "whatever"})
+				Expect(err).To(Equal(sealed.ErrNoSealingKey))
+			})
+		})
+	})
+})
@@ -0,0 +1,191 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package sealedfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/sealed"
+)
+
+type FakeKeyStore struct {
+	SaveSealingKeyStub        func(string) (string, error)
+	saveSealingKeyMutex       sync.RWMutex
+	saveSealingKeyArgsForCall []struct {
+		arg1 string
+	}
+	saveSealingKeyReturns struct {
+		result1 string
+		result2 error
+	}
+	saveSealingKeyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SealingKeyStub        func() (string, bool, error)
+	sealingKeyMutex       sync.RWMutex
+	sealingKeyArgsForCall []struct {
+	}
+	sealingKeyReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	sealingKeyReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeKeyStore) SaveSealingKey(arg1 string) (string, error) {
+	fake.saveSealingKeyMutex.Lock()
+	ret, specificReturn := fake.saveSealingKeyReturnsOnCall[len(fake.saveSealingKeyArgsForCall)]
+	fake.saveSealingKeyArgsForCall = append(fake.saveSealingKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SaveSealingKeyStub
+	fakeReturns := fake.saveSealingKeyReturns
+	fake.recordInvocation("SaveSealingKey", []interface{}{arg1})
+	fake.saveSealingKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyStore) SaveSealingKeyCallCount() int {
+	fake.saveSealingKeyMutex.RLock()
+	defer fake.saveSealingKeyMutex.RUnlock()
+	return len(fake.saveSealingKeyArgsForCall)
+}
+
+func (fake *FakeKeyStore) SaveSealingKeyCalls(stub func(string) (string, error)) {
+	fake.saveSealingKeyMutex.Lock()
+	defer fake.saveSealingKeyMutex.Unlock()
+	fake.SaveSealingKeyStub = stub
+}
+
+func (fake *FakeKeyStore) SaveSealingKeyArgsForCall(i int) string {
+	fake.saveSealingKeyMutex.RLock()
+	defer fake.saveSealingKeyMutex.RUnlock()
+	argsForCall := fake.saveSealingKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeKeyStore) SaveSealingKeyReturns(result1 string, result2 error) {
+	fake.saveSealingKeyMutex.Lock()
+	defer fake.saveSealingKeyMutex.Unlock()
+	fake.SaveSealingKeyStub = nil
+	fake.saveSealingKeyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyStore) SaveSealingKeyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.saveSealingKeyMutex.Lock()
+	defer fake.saveSealingKeyMutex.Unlock()
+	fake.SaveSealingKeyStub = nil
+	if fake.saveSealingKeyReturnsOnCall == nil {
+		fake.saveSealingKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.saveSealingKeyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyStore) SealingKey() (string, bool, error) {
+	fake.sealingKeyMutex.Lock()
+	ret, specificReturn := fake.sealingKeyReturnsOnCall[len(fake.sealingKeyArgsForCall)]
+	fake.sealingKeyArgsForCall = append(fake.sealingKeyArgsForCall, struct {
+	}{})
+	stub := fake.SealingKeyStub
+	fakeReturns := fake.sealingKeyReturns
+	fake.recordInvocation("SealingKey", []interface{}{})
+	fake.sealingKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeKeyStore) SealingKeyCallCount() int {
+	fake.sealingKeyMutex.RLock()
+	defer fake.sealingKeyMutex.RUnlock()
+	return len(fake.sealingKeyArgsForCall)
+}
+
+func (fake *FakeKeyStore) SealingKeyCalls(stub func() (string, bool, error)) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = stub
+}
+
+func (fake *FakeKeyStore) SealingKeyReturns(result1 string, result2 bool, result3 error) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = nil
+	fake.sealingKeyReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeKeyStore) SealingKeyReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = nil
+	if fake.sealingKeyReturnsOnCall == nil {
+		fake.sealingKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.sealingKeyReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeKeyStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.saveSealingKeyMutex.RLock()
+	defer fake.saveSealingKeyMutex.RUnlock()
+	fake.sealingKeyMutex.RLock()
+	defer fake.sealingKeyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeKeyStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sealed.KeyStore = new(FakeKeyStore)
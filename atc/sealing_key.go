@@ -0,0 +1,7 @@
+package atc
+
+// SealingKey is the public key which values are sealed to before being
+// embedded in a team's pipeline configs as ((sealed:...)) vars.
+type SealingKey struct {
+	PublicKey string `json:"public_key"`
+}
@@ -0,0 +1,19 @@
+package atc
+
+// SecretAccess records a step of a build reading a var from a credential
+// manager. The value of the var is never recorded.
+type SecretAccess struct {
+	ID                   int          `json:"id"`
+	TeamName             string       `json:"team_name"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string       `json:"job_name,omitempty"`
+	BuildID              int          `json:"build_id"`
+	BuildName            string       `json:"build_name"`
+	StepName             string       `json:"step_name"`
+	Var                  string       `json:"var"`
+	VarSource            string       `json:"var_source,omitempty"`
+	Backend              string       `json:"backend"`
+	SecretPath           string       `json:"secret_path"`
+	AccessedAt           int64        `json:"accessed_at"`
+}
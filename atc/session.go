@@ -0,0 +1,14 @@
+package atc
+
+// Session is an access token issued to a user on login. The token itself is
+// never shown.
+type Session struct {
+	ID         int    `json:"id"`
+	UserName   string `json:"user_name"`
+	Connector  string `json:"connector,omitempty"`
+	ClientID   string `json:"client_id,omitempty"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsedAt int64  `json:"last_used_at,omitempty"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
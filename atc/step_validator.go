@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/concourse/concourse/atc/condition"
 )
 
 // StepValidator is a StepVisitor which validates each step that visits it,
@@ -25,8 +27,13 @@ type StepValidator struct {
 	config  Config
 	context []string
 
-	seenGetName    scope
-	localVarScopes []scope
+	seenGetName      scope
+	seenApprovalName scope
+	localVarScopes   []scope
+
+	// repeatedDepth is the number of `across` and `attempts` steps enclosing
+	// the step being visited.
+	repeatedDepth int
 }
 
 type scope map[string]bool
@@ -41,10 +48,11 @@ type scope map[string]bool
 // errors like 'jobs(foo).plan.task(bar): blah blah'.
 func NewStepValidator(config Config, context []string) *StepValidator {
 	return &StepValidator{
-		config:         config,
-		context:        context,
-		seenGetName:    scope{},
-		localVarScopes: []scope{{}},
+		config:           config,
+		context:          context,
+		seenGetName:      scope{},
+		seenApprovalName: scope{},
+		localVarScopes:   []scope{{}},
 	}
 }
 
@@ -132,8 +140,27 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 	}
 
 	validator.pushContext(".passed")
+	validator.validatePassed(step.Passed, resourceName)
+	validator.popContext()
+
+	validator.pushContext(".passed_any")
+	validator.validatePassed(step.PassedAny, resourceName)
+
+	for _, job := range step.PassedAny {
+		for _, passed := range step.Passed {
+			if job == passed {
+				validator.recordError("job '%s' is also listed in passed", job)
+			}
+		}
+	}
+
+	validator.popContext()
 
-	for _, job := range step.Passed {
+	return nil
+}
+
+func (validator *StepValidator) validatePassed(jobs []string, resourceName string) {
+	for _, job := range jobs {
 		jobConfig, found := validator.config.Jobs.Lookup(job)
 		if !found {
 			validator.recordError("unknown job '%s'", job)
@@ -161,10 +188,6 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 			validator.recordError("job '%s' does not interact with resource '%s'", job, resourceName)
 		}
 	}
-
-	validator.popContext()
-
-	return nil
 }
 
 func (validator *StepValidator) VisitPut(step *PutStep) error {
@@ -252,6 +275,35 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitApproval(step *ApprovalStep) error {
+	validator.pushContext(".approval(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	// decisions are recorded against the step name, so two approval steps
+	// with the same name would always be decided together
+	if validator.seenApprovalName[step.Name] {
+		validator.recordError("repeated name")
+	}
+
+	// for the same reason, every value of an `across` step and every retry
+	// would share one decision
+	if validator.repeatedDepth > 0 {
+		validator.recordError("cannot be used within `across` or `attempts`, as every iteration would share one decision")
+	}
+
+	validator.seenApprovalName[step.Name] = true
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
@@ -323,6 +375,9 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 		validator.popContext()
 	}
 
+	validator.repeatedDepth++
+	defer func() { validator.repeatedDepth-- }()
+
 	return step.Step.Visit(validator)
 }
 
@@ -343,8 +398,33 @@ func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitIf(step *IfStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.pushContext(".if")
+	defer validator.popContext()
+
+	cond, err := condition.Parse(step.Condition)
+	if err != nil {
+		validator.recordError(err.Error())
+		return nil
+	}
+
+	err = cond.Check()
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitRetry(step *RetryStep) error {
+	validator.repeatedDepth++
 	err := step.Step.Visit(validator)
+	validator.repeatedDepth--
 	if err != nil {
 		return err
 	}
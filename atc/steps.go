@@ -194,11 +194,13 @@ type StepVisitor interface {
 	VisitRun(*RunStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitApproval(*ApprovalStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
 	VisitAcross(*AcrossStep) error
 	VisitTimeout(*TimeoutStep) error
+	VisitIf(*IfStep) error
 	VisitRetry(*RetryStep) error
 	VisitOnSuccess(*OnSuccessStep) error
 	VisitOnFailure(*OnFailureStep) error
@@ -222,6 +224,12 @@ type StepDetector struct {
 // some important inter-modifier precedence - while core step types are parsed
 // last.
 var StepPrecedence = []StepDetector{
+	{
+		// parsed first so that when the condition is false, none of the
+		// step's hooks are run either
+		Key: "if",
+		New: func() StepConfig { return &IfStep{} },
+	},
 	{
 		Key: "ensure",
 		New: func() StepConfig { return &EnsureStep{} },
@@ -278,6 +286,10 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "approval",
+		New: func() StepConfig { return &ApprovalStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -293,14 +305,15 @@ var StepPrecedence = []StepDetector{
 }
 
 type GetStep struct {
-	Name     string         `json:"get"`
-	Resource string         `json:"resource,omitempty"`
-	Version  *VersionConfig `json:"version,omitempty"`
-	Params   Params         `json:"params,omitempty"`
-	Passed   []string       `json:"passed,omitempty"`
-	Trigger  bool           `json:"trigger,omitempty"`
-	Tags     Tags           `json:"tags,omitempty"`
-	Timeout  string         `json:"timeout,omitempty"`
+	Name      string         `json:"get"`
+	Resource  string         `json:"resource,omitempty"`
+	Version   *VersionConfig `json:"version,omitempty"`
+	Params    Params         `json:"params,omitempty"`
+	Passed    []string       `json:"passed,omitempty"`
+	PassedAny []string       `json:"passed_any,omitempty"`
+	Trigger   bool           `json:"trigger,omitempty"`
+	Tags      Tags           `json:"tags,omitempty"`
+	Timeout   string         `json:"timeout,omitempty"`
 }
 
 func (step *GetStep) ResourceName() string {
@@ -350,6 +363,7 @@ type TaskStep struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	CacheResult       bool              `json:"cache_result,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -365,13 +379,17 @@ type RunStep struct {
 	Limits     *ContainerLimits `json:"container_limits,omitempty"`
 	Timeout    string           `json:"timeout,omitempty"`
 
-	// XXX(prototypes): inputs, outputs, input_mapping, output_mapping?
-	// see https://github.com/concourse/rfcs/pull/103
+	Inputs        []string          `json:"inputs,omitempty"`
+	Outputs       []string          `json:"outputs,omitempty"`
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
 
-	// XXX(prototypes): set_vars?
+	// An artifact produced earlier in the build to use as the prototype's
+	// image instead of the image configured by the prototype. That way, you
+	// can build a prototype and run it in the same pipeline.
+	ImageArtifactName string `json:"image,omitempty"`
 
-	// XXX(prototypes): image? That way, you can build a prototype and run it
-	// in the same pipeline. This would be in place of type.
+	// XXX(prototypes): set_vars?
 }
 
 func (step *RunStep) Visit(v StepVisitor) error {
@@ -402,6 +420,15 @@ func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+type ApprovalStep struct {
+	Name         string `json:"approval"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+func (step *ApprovalStep) Visit(v StepVisitor) error {
+	return v.VisitApproval(step)
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
@@ -539,6 +566,25 @@ func (step *TimeoutStep) Visit(v StepVisitor) error {
 	return v.VisitTimeout(step)
 }
 
+// IfStep only runs the wrapped step if its condition, written in the
+// expression language implemented by the condition package, is true.
+type IfStep struct {
+	Step      StepConfig `json:"-"`
+	Condition string     `json:"if"`
+}
+
+func (step *IfStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *IfStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *IfStep) Visit(v StepVisitor) error {
+	return v.VisitIf(step)
+}
+
 type OnSuccessStep struct {
 	Step StepConfig `json:"-"`
 	Hook Step       `json:"on_success"`
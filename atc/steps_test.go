@@ -84,6 +84,7 @@ var factoryTests = []StepTest{
 			output_mapping: {specific: generic}
 			image: some-image
 			timeout: 1h
+			cache_result: true
 		`,
 
 		StepConfig: &atc.TaskStep{
@@ -101,6 +102,7 @@ var factoryTests = []StepTest{
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			CacheResult:       true,
 		},
 	},
 	{
@@ -178,6 +180,11 @@ var factoryTests = []StepTest{
 			tags: [tag-1, tag-2]
 			container_limits: {cpu: 10, memory: 1024}
 			timeout: 1h
+			inputs: [some-input, other-input]
+			outputs: [some-output]
+			input_mapping: {other-input: some-artifact}
+			output_mapping: {some-output: other-artifact}
+			image: some-image
 		`,
 
 		StepConfig: &atc.RunStep{
@@ -190,9 +197,14 @@ var factoryTests = []StepTest{
 				},
 				"baz": "qux",
 			},
-			Tags:    []string{"tag-1", "tag-2"},
-			Limits:  &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
-			Timeout: "1h",
+			Tags:              []string{"tag-1", "tag-2"},
+			Limits:            &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
+			Timeout:           "1h",
+			Inputs:            []string{"some-input", "other-input"},
+			Outputs:           []string{"some-output"},
+			InputMapping:      map[string]string{"other-input": "some-artifact"},
+			OutputMapping:     map[string]string{"some-output": "other-artifact"},
+			ImageArtifactName: "some-image",
 		},
 	},
 	{
@@ -231,6 +243,19 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "approval step",
+
+		ConfigYAML: `
+			approval: deploy-to-prod
+			instructions: check the staging dashboard first
+		`,
+
+		StepConfig: &atc.ApprovalStep{
+			Name:         "deploy-to-prod",
+			Instructions: "check the staging dashboard first",
+		},
+	},
 	{
 		Title: "try step",
 
@@ -429,6 +454,34 @@ var factoryTests = []StepTest{
 			Duration: "1h",
 		},
 	},
+	{
+		Title: "if modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			if: build.job == "deploy"
+			ensure:
+			  load_var: other-var
+			  file: other-file
+		`,
+
+		StepConfig: &atc.IfStep{
+			Step: &atc.EnsureStep{
+				Step: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "other-var",
+						File: "other-file",
+					},
+				},
+			},
+			Condition: `build.job == "deploy"`,
+		},
+	},
 	{
 		Title: "attempts modifier",
 
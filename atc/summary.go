@@ -42,10 +42,11 @@ type BuildSummary struct {
 }
 
 type JobInputSummary struct {
-	Name     string   `json:"name"`
-	Resource string   `json:"resource"`
-	Passed   []string `json:"passed,omitempty"`
-	Trigger  bool     `json:"trigger,omitempty"`
+	Name      string   `json:"name"`
+	Resource  string   `json:"resource"`
+	Passed    []string `json:"passed,omitempty"`
+	PassedAny []string `json:"passed_any,omitempty"`
+	Trigger   bool     `json:"trigger,omitempty"`
 }
 
 type JobOutputSummary struct {
@@ -0,0 +1,89 @@
+package syslog
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/concourse/concourse/atc/logsink"
+)
+
+type sink struct {
+	hostname  string
+	transport string
+	address   string
+	caCerts   []string
+
+	lock   sync.Mutex
+	syslog *Syslog
+}
+
+// NewSink returns a LogSink which sends entries to a syslog server. The
+// connection is made on the first write and kept until the sink is closed.
+func NewSink(transport string, address string, hostname string, caCerts []string) logsink.LogSink {
+	return &sink{
+		hostname:  hostname,
+		transport: transport,
+		address:   address,
+		caCerts:   caCerts,
+	}
+}
+
+func (s *sink) Write(ctx context.Context, entries []logsink.Entry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.syslog == nil {
+		syslog, err := Dial(s.transport, s.address, s.caCerts)
+		if err != nil {
+			return err
+		}
+
+		s.syslog = syslog
+	}
+
+	for _, entry := range entries {
+		err := s.syslog.Write(s.hostname, Tag(entry), entry.Time, entry.Message, entry.EventID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.syslog == nil {
+		return nil
+	}
+
+	err := s.syslog.Close()
+	s.syslog = nil
+	return err
+}
+
+// Tag returns the syslog tag identifying where the entry came from, in the
+// form team/pipeline/job/build/origin.
+func Tag(entry logsink.Entry) string {
+	segments := []string{entry.TeamName}
+
+	if entry.PipelineName != "" {
+		segments = append(segments, entry.PipelineName)
+	}
+
+	if entry.JobName != "" {
+		segments = append(segments, entry.JobName, entry.BuildName)
+	} else if entry.ResourceName != "" {
+		segments = append(segments, entry.ResourceName, strconv.Itoa(entry.BuildID))
+	} else {
+		segments = append(segments, strconv.Itoa(entry.BuildID))
+	}
+
+	segments = append(segments, entry.OriginID)
+
+	return strings.Join(segments, "/")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logsink"
 	"github.com/concourse/concourse/atc/syslog"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -62,7 +63,7 @@ func newFakeBuild(id int) db.Build {
 	return fakeBuild
 }
 
-var _ = Describe("Drainer", func() {
+var _ = Describe("Sink", func() {
 	var fakeBuildFactory *dbfakes.FakeBuildFactory
 	var server *testServer
 
@@ -82,7 +83,9 @@ var _ = Describe("Drainer", func() {
 			})
 
 			It("drains all build events by tcp", func() {
-				testDrainer := syslog.NewDrainer("tcp", server.Addr, "test", []string{}, fakeBuildFactory)
+				testDrainer := logsink.NewDrainer(fakeBuildFactory, []logsink.LogSink{
+					syslog.NewSink("tcp", server.Addr, "test", []string{}),
+				})
 				err := testDrainer.Run(context.TODO())
 				Expect(err).NotTo(HaveOccurred())
 
@@ -98,4 +101,37 @@ var _ = Describe("Drainer", func() {
 		})
 
 	})
+
+	Describe("Tag", func() {
+		It("identifies the build and origin of one-off builds", func() {
+			Expect(syslog.Tag(logsink.Entry{
+				BuildID:   42,
+				BuildName: "42",
+				TeamName:  "some-team",
+				OriginID:  "some-origin",
+			})).To(Equal("some-team/42/some-origin"))
+		})
+
+		It("identifies the pipeline, job and build name of job builds", func() {
+			Expect(syslog.Tag(logsink.Entry{
+				BuildID:      42,
+				BuildName:    "7",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				OriginID:     "some-origin",
+			})).To(Equal("some-team/some-pipeline/some-job/7/some-origin"))
+		})
+
+		It("identifies the pipeline, resource and build ID of check builds", func() {
+			Expect(syslog.Tag(logsink.Entry{
+				BuildID:      42,
+				BuildName:    "check",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				ResourceName: "some-resource",
+				OriginID:     "some-origin",
+			})).To(Equal("some-team/some-pipeline/some-resource/42/some-origin"))
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strings"
 
 	"sigs.k8s.io/yaml"
@@ -36,6 +37,10 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Test reports written to the task's outputs, which are parsed and stored
+	// against the build once the task finishes.
+	Reports []TaskReportConfig `json:"reports,omitempty"`
 }
 
 type ImageResource struct {
@@ -99,6 +104,7 @@ func (config TaskConfig) Validate() error {
 
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.validateReports()...)
 
 	if len(errors) > 0 {
 		return TaskValidationError{
@@ -121,6 +127,60 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateReports() []string {
+	var messages []string
+
+	for i, report := range config.Reports {
+		if report.Path == "" {
+			messages = append(messages, fmt.Sprintf("  report in position %d is missing a path", i))
+			continue
+		}
+
+		if _, _, found := config.ReportOutput(report); !found {
+			messages = append(messages, fmt.Sprintf("  report in position %d must be in one of the task's outputs: %s", i, report.Path))
+		}
+	}
+
+	return messages
+}
+
+// ReportOutput returns the output that the report is written to, i.e. the
+// one whose path contains the report's path, along with the path of the
+// report within it. The innermost output is chosen if outputs are nested.
+func (config TaskConfig) ReportOutput(report TaskReportConfig) (TaskOutputConfig, string, bool) {
+	reportPath := path.Clean(report.Path)
+
+	var (
+		found      bool
+		match      TaskOutputConfig
+		outputPath string
+	)
+
+	for _, output := range config.Outputs {
+		effectivePath := output.Path
+		if effectivePath == "" {
+			effectivePath = output.Name
+		}
+
+		effectivePath = path.Clean(effectivePath)
+		if !strings.HasPrefix(reportPath, effectivePath+"/") {
+			continue
+		}
+
+		if !found || len(effectivePath) > len(outputPath) {
+			found = true
+			match = output
+			outputPath = effectivePath
+		}
+	}
+
+	if !found {
+		return TaskOutputConfig{}, "", false
+	}
+
+	return match, strings.TrimPrefix(reportPath, outputPath+"/"), true
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -157,6 +217,12 @@ type TaskCacheConfig struct {
 	Path string `json:"path,omitempty"`
 }
 
+// TaskReportConfig points to a JUnit XML report within one of the task's
+// outputs, e.g. "test-results/junit.xml".
+type TaskReportConfig struct {
+	Path string `json:"path"`
+}
+
 type TaskEnv map[string]string
 
 func (te *TaskEnv) UnmarshalJSON(p []byte) error {
@@ -385,6 +385,78 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has reports", func() {
+			BeforeEach(func() {
+				validConfig.Outputs = append(validConfig.Outputs, TaskOutputConfig{Name: "test-results"})
+				validConfig.Reports = append(validConfig.Reports, TaskReportConfig{Path: "test-results/junit.xml"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			It("finds the output the report is written to", func() {
+				output, filePath, found := validConfig.ReportOutput(validConfig.Reports[0])
+				Expect(found).To(BeTrue())
+				Expect(output).To(Equal(TaskOutputConfig{Name: "test-results"}))
+				Expect(filePath).To(Equal("junit.xml"))
+			})
+
+			Context("when the output has a path", func() {
+				BeforeEach(func() {
+					validConfig.Outputs = []TaskOutputConfig{
+						{Name: "test-results", Path: "build/reports/"},
+						{Name: "build", Path: "build"},
+					}
+					validConfig.Reports = []TaskReportConfig{{Path: "build/reports/unit/junit.xml"}}
+				})
+
+				It("is valid", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+
+				It("finds the innermost output by its path", func() {
+					output, filePath, found := validConfig.ReportOutput(validConfig.Reports[0])
+					Expect(found).To(BeTrue())
+					Expect(output).To(Equal(TaskOutputConfig{Name: "test-results", Path: "build/reports/"}))
+					Expect(filePath).To(Equal("unit/junit.xml"))
+				})
+
+				It("does not match the report against the output's name", func() {
+					_, _, found := validConfig.ReportOutput(TaskReportConfig{Path: "test-results/junit.xml"})
+					Expect(found).To(BeFalse())
+				})
+			})
+
+			Context("when report.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = append(invalidConfig.Reports, TaskReportConfig{Path: ""})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("report in position 0 is missing a path")))
+				})
+			})
+
+			Context("when report.path is not in an output", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "test-results"})
+					invalidConfig.Reports = append(
+						invalidConfig.Reports,
+						TaskReportConfig{Path: "some-input/junit.xml"},
+						TaskReportConfig{Path: "test-results"},
+					)
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+
+					Expect(err).To(MatchError(ContainSubstring("report in position 0 must be in one of the task's outputs: some-input/junit.xml")))
+					Expect(err).To(MatchError(ContainSubstring("report in position 1 must be in one of the task's outputs: test-results")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
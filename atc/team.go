@@ -13,10 +13,18 @@ type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	Notifications NotificationRules `json:"notifications,omitempty"`
+
+	PipelineAuth PipelineAuth `json:"pipeline_auth,omitempty"`
 }
 
 func (team Team) Validate() error {
-	return team.Auth.Validate()
+	if err := team.Auth.Validate(); err != nil {
+		return err
+	}
+
+	return team.Notifications.Validate()
 }
 
 type TeamAuth map[string]map[string][]string
@@ -37,3 +45,22 @@ func (auth TeamAuth) Validate() error {
 
 	return nil
 }
+
+// PipelineAuth binds roles to users and groups for single pipelines of a
+// team, on top of the roles they have for the whole team. It is keyed by
+// pipeline name, so a binding covers every instance of an instanced pipeline.
+//
+// Users bound only to a pipeline cannot set its config, whatever their role:
+// vars in the config are resolved with the team's credentials, so doing so
+// would let them read any of the team's secrets.
+type PipelineAuth map[string]TeamAuth
+
+func (auth PipelineAuth) Validate() error {
+	for _, pipelineAuth := range auth {
+		if err := pipelineAuth.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
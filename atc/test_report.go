@@ -0,0 +1,41 @@
+package atc
+
+type TestStatus string
+
+const (
+	TestPassed  TestStatus = "passed"
+	TestFailed  TestStatus = "failed"
+	TestErrored TestStatus = "errored"
+	TestSkipped TestStatus = "skipped"
+)
+
+// TestSuite is a suite of test cases parsed from a task's report.
+type TestSuite struct {
+	ID       int        `json:"id,omitempty"`
+	BuildID  int        `json:"build_id,omitempty"`
+	StepName string     `json:"step_name"`
+	Name     string     `json:"name"`
+	Duration float64    `json:"duration"`
+	Cases    []TestCase `json:"cases"`
+}
+
+// Count returns the number of cases in the suite with the given status.
+func (suite TestSuite) Count(status TestStatus) int {
+	var count int
+	for _, testCase := range suite.Cases {
+		if testCase.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// TestCase is the result of a single test. Durations are in seconds.
+type TestCase struct {
+	Name           string     `json:"name"`
+	ClassName      string     `json:"class_name,omitempty"`
+	Duration       float64    `json:"duration"`
+	Status         TestStatus `json:"status"`
+	FailureMessage string     `json:"failure_message,omitempty"`
+}
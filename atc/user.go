@@ -11,16 +11,17 @@ type User struct {
 }
 
 type UserInfo struct {
-	Sub           string              `json:"sub"`
-	Name          string              `json:"name"`
-	UserId        string              `json:"user_id"`
-	UserName      string              `json:"user_name"`
-	Email         string              `json:"email"`
-	IsAdmin       bool                `json:"is_admin"`
-	IsSystem      bool                `json:"is_system"`
-	Teams         map[string][]string `json:"teams"`
-	Connector     string              `json:"connector"`
-	DisplayUserId string              `json:"display_user_id"`
+	Sub           string                         `json:"sub"`
+	Name          string                         `json:"name"`
+	UserId        string                         `json:"user_id"`
+	UserName      string                         `json:"user_name"`
+	Email         string                         `json:"email"`
+	IsAdmin       bool                           `json:"is_admin"`
+	IsSystem      bool                           `json:"is_system"`
+	Teams         map[string][]string            `json:"teams"`
+	PipelineRoles map[string]map[string][]string `json:"pipeline_roles,omitempty"`
+	Connector     string                         `json:"connector"`
+	DisplayUserId string                         `json:"display_user_id"`
 }
 
 //counterfeiter:generate . DisplayUserIdGenerator
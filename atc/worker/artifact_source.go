@@ -117,6 +117,9 @@ type StreamableArtifactSource interface {
 	// StreamFile returns the contents of a single file in the artifact source.
 	// This is used for loading a task's configuration at runtime.
 	StreamFile(context.Context, string) (io.ReadCloser, error)
+
+	// Artifact returns the artifact that the source was created for.
+	Artifact() runtime.Artifact
 }
 
 type artifactSource struct {
@@ -146,6 +149,10 @@ func NewStreamableArtifactSource(
 	}
 }
 
+func (source *artifactSource) Artifact() runtime.Artifact {
+	return source.artifact
+}
+
 func (source *artifactSource) StreamTo(
 	ctx context.Context,
 	destination ArtifactDestination,
@@ -146,6 +146,12 @@ var _ = Describe("StreamableArtifactSource", func() {
 		artifactSource = worker.NewStreamableArtifactSource(fakeArtifact, fakeVolume, comp, enabledP2pStreaming, p2pStreamingTimeout, fakeResourceCacheFactory)
 	})
 
+	Context("Artifact", func() {
+		It("returns the artifact the source was created for", func() {
+			Expect(artifactSource.Artifact()).To(Equal(fakeArtifact))
+		})
+	})
+
 	Context("StreamTo", func() {
 		var streamToErr error
 
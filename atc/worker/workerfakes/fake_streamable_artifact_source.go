@@ -7,10 +7,21 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakeStreamableArtifactSource struct {
+	ArtifactStub        func() runtime.Artifact
+	artifactMutex       sync.RWMutex
+	artifactArgsForCall []struct {
+	}
+	artifactReturns struct {
+		result1 runtime.Artifact
+	}
+	artifactReturnsOnCall map[int]struct {
+		result1 runtime.Artifact
+	}
 	ExistsOnStub        func(lager.Logger, worker.Worker) (worker.Volume, bool, error)
 	existsOnMutex       sync.RWMutex
 	existsOnArgsForCall []struct {
@@ -57,6 +68,59 @@ type FakeStreamableArtifactSource struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStreamableArtifactSource) Artifact() runtime.Artifact {
+	fake.artifactMutex.Lock()
+	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
+	fake.artifactArgsForCall = append(fake.artifactArgsForCall, struct {
+	}{})
+	stub := fake.ArtifactStub
+	fakeReturns := fake.artifactReturns
+	fake.recordInvocation("Artifact", []interface{}{})
+	fake.artifactMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStreamableArtifactSource) ArtifactCallCount() int {
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
+	return len(fake.artifactArgsForCall)
+}
+
+func (fake *FakeStreamableArtifactSource) ArtifactCalls(stub func() runtime.Artifact) {
+	fake.artifactMutex.Lock()
+	defer fake.artifactMutex.Unlock()
+	fake.ArtifactStub = stub
+}
+
+func (fake *FakeStreamableArtifactSource) ArtifactReturns(result1 runtime.Artifact) {
+	fake.artifactMutex.Lock()
+	defer fake.artifactMutex.Unlock()
+	fake.ArtifactStub = nil
+	fake.artifactReturns = struct {
+		result1 runtime.Artifact
+	}{result1}
+}
+
+func (fake *FakeStreamableArtifactSource) ArtifactReturnsOnCall(i int, result1 runtime.Artifact) {
+	fake.artifactMutex.Lock()
+	defer fake.artifactMutex.Unlock()
+	fake.ArtifactStub = nil
+	if fake.artifactReturnsOnCall == nil {
+		fake.artifactReturnsOnCall = make(map[int]struct {
+			result1 runtime.Artifact
+		})
+	}
+	fake.artifactReturnsOnCall[i] = struct {
+		result1 runtime.Artifact
+	}{result1}
+}
+
 func (fake *FakeStreamableArtifactSource) ExistsOn(arg1 lager.Logger, arg2 worker.Worker) (worker.Volume, bool, error) {
 	fake.existsOnMutex.Lock()
 	ret, specificReturn := fake.existsOnReturnsOnCall[len(fake.existsOnArgsForCall)]
@@ -255,6 +319,8 @@ func (fake *FakeStreamableArtifactSource) StreamToReturnsOnCall(i int, result1 e
 func (fake *FakeStreamableArtifactSource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
 	fake.existsOnMutex.RLock()
 	defer fake.existsOnMutex.RUnlock()
 	fake.streamFileMutex.RLock()
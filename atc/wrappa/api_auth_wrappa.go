@@ -47,12 +47,14 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.ListBuildArtifacts,
+			atc.ListBuildTestSuites:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
 		case atc.AbortBuild,
-			atc.SetBuildComment:
+			atc.SetBuildComment,
+			atc.ApproveBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -91,6 +93,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
 			atc.ListTeamBuilds,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken,
+			atc.ListUserSessions,
+			atc.RevokeUserSession,
+			atc.RevokeUserSessions,
 			atc.GetUser:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
@@ -112,6 +120,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetLogLevel,
 			atc.DestroyTeam,
 			atc.ListActiveUsersSince,
+			atc.ListAuditEvents,
+			atc.ListSessions,
+			atc.RevokeSession,
+			atc.RevokeSessions,
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.SetWall,
@@ -120,6 +132,14 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// authorized (requested team matches resource team and has required role, or is admin)
 		case atc.GetTeam,
+			atc.ListNotificationDeliveries,
+			atc.SearchBuildLogs,
+			atc.GetTeamSealingKey,
+			atc.ListSecretAccesses,
+			atc.SetPipelineAuth,
+			atc.ListTeamAPITokens,
+			atc.CreateTeamAPIToken,
+			atc.RevokeTeamAPIToken,
 			atc.SetTeam,
 			atc.RenameTeam,
 			atc.ListContainers,
@@ -152,7 +172,6 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpausePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
 			atc.ClearResourceCache,
@@ -161,6 +180,11 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetArtifact:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
+		// pipeline config is interpolated with the team's credentials, so
+		// users bound only to the pipeline cannot set it
+		case atc.SaveConfig:
+			newHandler = auth.CheckTeamAuthorizationHandler(handler, rejector)
+
 		// think about it!
 		default:
 			panic(fmt.Sprintf("you missed a spot: %q", name))
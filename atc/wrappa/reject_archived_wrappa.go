@@ -51,6 +51,8 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetBuildPlan,
 			atc.AbortBuild,
 			atc.SetBuildComment,
+			atc.ApproveBuild,
+			atc.ListBuildTestSuites,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
@@ -81,6 +83,23 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListContainers,
 			atc.ListVolumes,
 			atc.ListTeamBuilds,
+			atc.ListNotificationDeliveries,
+			atc.SearchBuildLogs,
+			atc.GetTeamSealingKey,
+			atc.ListSecretAccesses,
+			atc.SetPipelineAuth,
+			atc.ListTeamAPITokens,
+			atc.CreateTeamAPIToken,
+			atc.RevokeTeamAPIToken,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken,
+			atc.ListUserSessions,
+			atc.RevokeUserSession,
+			atc.RevokeUserSessions,
+			atc.ListSessions,
+			atc.RevokeSession,
+			atc.RevokeSessions,
 			atc.ListWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
@@ -105,6 +124,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.ListActiveUsersSince,
+			atc.ListAuditEvents,
 			atc.SetWall,
 			atc.ClearWall,
 			atc.DeletePipeline,
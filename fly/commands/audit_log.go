@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type AuditLogCommand struct {
+	User   string        `short:"u" long:"user" description:"Only show events caused by this user"`
+	Action string        `short:"a" long:"action" description:"Only show events for this API action, e.g. SaveConfig"`
+	Team   string        `long:"team" description:"Only show events for this team"`
+	Last   time.Duration `long:"last" description:"Only show events within this long ago, e.g. 720h"`
+	Since  string        `long:"since" description:"Start of the range of event times to show"`
+	Until  string        `long:"until" description:"End of the range of event times to show"`
+	Count  int           `short:"c" long:"count" default:"50" description:"Maximum number of events to show"`
+	Json   bool          `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *AuditLogCommand) Execute([]string) error {
+	filter, err := command.filter()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	events, _, err := target.Client().AuditEvents(filter, concourse.Page{Limit: command.Count})
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(events)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "user", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "target", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, event := range events {
+		teamCell := ui.TableCell{Contents: event.TeamName}
+		if event.TeamName == "" {
+			teamCell = ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+		}
+
+		statusCell := ui.TableCell{Contents: strconv.Itoa(event.Status)}
+		if event.Status >= 400 {
+			statusCell.Color = color.New(color.FgRed)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: time.Unix(event.CreatedAt, 0).Local().Format(timeDateLayout)},
+			{Contents: auditEventUser(event)},
+			{Contents: event.Action},
+			teamCell,
+			{Contents: auditEventTarget(event)},
+			statusCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *AuditLogCommand) filter() (concourse.AuditEventFilter, error) {
+	filter := concourse.AuditEventFilter{
+		UserName: command.User,
+		Action:   command.Action,
+		TeamName: command.Team,
+	}
+
+	if command.Last != 0 && command.Since != "" {
+		return filter, errors.New("Cannot specify both --last and --since")
+	}
+
+	if command.Last != 0 {
+		filter.Since = time.Now().Add(-command.Last)
+	}
+
+	var err error
+	if command.Since != "" {
+		filter.Since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return filter, errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		filter.Until, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return filter, errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Since.After(filter.Until) {
+		return filter, errors.New("Cannot have --since after --until")
+	}
+
+	return filter, nil
+}
+
+func auditEventUser(event atc.AuditEvent) string {
+	if event.Connector != "" {
+		return event.UserName + " (" + event.Connector + ")"
+	}
+
+	return event.UserName
+}
+
+func auditEventTarget(event atc.AuditEvent) string {
+	var pairs []string
+	for key, value := range event.Target {
+		pairs = append(pairs, strings.TrimSuffix(key, "_name")+":"+value)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
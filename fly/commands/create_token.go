@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type CreateTokenCommand struct {
+	Name      string        `long:"name" required:"true" description:"Name of the token"`
+	Scopes    []string      `long:"scope" description:"Limit the token to a scope, e.g. read-only or trigger-job:pipeline/main/deploy (can be specified multiple times)"`
+	ExpiresIn time.Duration `long:"expires-in" description:"Expire the token after this long, e.g. 720h. Required for personal tokens, which cannot outlive your session"`
+	Team      string        `long:"team" description:"Create a service account token on this team, rather than a personal token"`
+	Role      string        `long:"role" default:"member" description:"Role the team token is granted on the team"`
+	Json      bool          `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CreateTokenCommand) Execute([]string) error {
+	if command.ExpiresIn < 0 {
+		return errors.New("--expires-in must be positive")
+	}
+
+	if command.Team == "" && command.ExpiresIn == 0 {
+		return errors.New("--expires-in must be specified for personal tokens")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	request := atc.APIToken{
+		Name:   command.Name,
+		Scopes: command.Scopes,
+	}
+
+	if command.ExpiresIn != 0 {
+		request.ExpiresAt = time.Now().Add(command.ExpiresIn).Unix()
+	}
+
+	var token atc.APIToken
+	if command.Team != "" {
+		team, err := target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+
+		request.Role = command.Role
+
+		token, err = team.CreateAPIToken(request)
+		if err != nil {
+			return err
+		}
+	} else {
+		token, err = target.Client().CreateAPIToken(request)
+		if err != nil {
+			return err
+		}
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(token)
+	}
+
+	fmt.Printf("created token '%s':\n\n", token.Name)
+	fmt.Println(token.Token)
+	fmt.Println()
+	fmt.Println(ui.WarningColor("this token will not be shown again, so keep it somewhere safe"))
+
+	if len(token.Scopes) == 0 {
+		fmt.Println(color.New(color.Faint).Sprint("the token is not limited to any scopes"))
+	}
+
+	return nil
+}
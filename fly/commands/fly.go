@@ -24,13 +24,23 @@ type FlyCommand struct {
 	Sync   SyncCommand   `command:"sync"  alias:"s" description:"Download and replace the current fly from the target"`
 
 	ActiveUsers ActiveUsersCommand `command:"active-users" alias:"au" description:"List the active users since a date or for the past 2 months"`
+	AuditLog    AuditLogCommand    `command:"audit-log" alias:"al" description:"List audited API requests made by users"`
 	Userinfo    UserinfoCommand    `command:"userinfo" description:"User information"`
 
+	CreateToken CreateTokenCommand `command:"create-token" alias:"ctk" description:"Create a scoped API token for automation"`
+	Tokens      TokensCommand      `command:"tokens" alias:"tks" description:"List your API tokens, or a team's"`
+	RevokeToken RevokeTokenCommand `command:"revoke-token" alias:"rtk" description:"Revoke an API token"`
+
+	Sessions      SessionsCommand      `command:"sessions" alias:"ses" description:"List your sessions, or those of other users"`
+	RevokeSession RevokeSessionCommand `command:"revoke-session" alias:"rses" description:"Revoke sessions, logging them out"`
+
 	Teams       TeamsCommand       `command:"teams" alias:"t" description:"List the configured teams"`
 	GetTeam     GetTeamCommand     `command:"get-team"  alias:"gt" description:"Show team configuration"`
 	SetTeam     SetTeamCommand     `command:"set-team"  alias:"st" description:"Create or modify a team to have the given credentials"`
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
+	SealSecret  SealSecretCommand  `command:"seal-secret"  alias:"ss" description:"Encrypt a secret so that it can be embedded in a pipeline config as a sealed var"`
+	SecretUsage SecretUsageCommand `command:"secret-usage" alias:"su" description:"List which builds read which secrets from the credential manager"`
 
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
@@ -49,6 +59,7 @@ type FlyCommand struct {
 	DestroyPipeline           DestroyPipelineCommand         `command:"destroy-pipeline"          alias:"dp"   description:"Destroy a pipeline"`
 	GetPipeline               GetPipelineCommand             `command:"get-pipeline"              alias:"gp"   description:"Get a pipeline's current configuration"`
 	SetPipeline               SetPipelineCommand             `command:"set-pipeline"              alias:"sp"   description:"Create or update a pipeline's configuration"`
+	SetPipelineAuth           SetPipelineAuthCommand         `command:"set-pipeline-auth"         alias:"spa"  description:"Grant users and groups roles on a single pipeline (they cannot set its config)"`
 	PausePipeline             PausePipelineCommand           `command:"pause-pipeline"            alias:"pp"   description:"Pause a pipeline"`
 	ArchivePipeline           ArchivePipelineCommand         `command:"archive-pipeline"          alias:"ap"   description:"Archive a pipeline"`
 	UnpausePipeline           UnpausePipelineCommand         `command:"unpause-pipeline"          alias:"up"   description:"Un-pause a pipeline"`
@@ -73,12 +84,17 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Builds       BuildsCommand       `command:"builds"        alias:"bs" description:"List builds data"`
+	AbortBuild   AbortBuildCommand   `command:"abort-build"   alias:"ab" description:"Abort a build"`
+	RerunBuild   RerunBuildCommand   `command:"rerun-build"   alias:"rb" description:"Rerun a build"`
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve or reject a build waiting on an approval step"`
+	TestResults  TestResultsCommand  `command:"test-results"  alias:"tr"  description:"List the test results reported by a build"`
+	SearchLogs   SearchLogsCommand   `command:"search-logs"   alias:"sl"  description:"Search the build logs of a team for some text"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
+	Notifications NotificationsCommand `command:"notifications" alias:"ns" description:"List the delivery attempts of build notifications"`
+
 	Volumes VolumesCommand `command:"volumes" alias:"vs" description:"List the active volumes"`
 
 	Workers     WorkersCommand     `command:"workers" alias:"ws" description:"List the registered workers"`
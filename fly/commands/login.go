@@ -9,8 +9,10 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/pty"
@@ -33,6 +35,7 @@ type LoginCommand struct {
 	ClientCertPath atc.PathFlag `long:"client-cert" description:"Path to a PEM-encoded client certificate file."`
 	ClientKeyPath  atc.PathFlag `long:"client-key" description:"Path to a PEM-encoded client key file."`
 	OpenBrowser    bool         `short:"b" long:"open-browser" description:"Open browser to the auth endpoint"`
+	Device         bool         `long:"device" description:"Log in by approving a code from another device, for machines without a browser"`
 
 	BrowserOnly bool
 }
@@ -93,6 +96,10 @@ func (command *LoginCommand) Execute(args []string) error {
 		return errors.New("unexpected argument [" + strings.Join(args, ", ") + "]")
 	}
 
+	if command.Device && command.Username != "" {
+		return errors.New("--device cannot be used together with --username and --password")
+	}
+
 	err = target.ValidateWithWarningOnly()
 	if err != nil {
 		return err
@@ -121,7 +128,7 @@ func (command *LoginCommand) Execute(args []string) error {
 		return err
 	}
 
-	isRawMode := pty.IsTerminal() && !command.BrowserOnly
+	isRawMode := pty.IsTerminal() && !command.BrowserOnly && !command.Device
 	if isRawMode {
 		state, err := terminal.MakeRaw(int(os.Stdin.Fd()))
 		if err != nil {
@@ -140,6 +147,8 @@ func (command *LoginCommand) Execute(args []string) error {
 	} else {
 		if command.Username != "" && command.Password != "" {
 			tokenType, tokenValue, err = command.passwordGrant(client, command.Username, command.Password)
+		} else if command.Device {
+			tokenType, tokenValue, err = command.deviceCodeGrant(client)
 		} else {
 			tokenType, tokenValue, err = command.authCodeGrant(client.URL(), command.BrowserOnly, isRawMode)
 		}
@@ -245,6 +254,101 @@ func (command *LoginCommand) authCodeGrant(targetUrl string, browserOnly bool, i
 	}
 }
 
+// deviceCodeGrant logs in with the OAuth device authorization grant
+// (RFC 8628): the user approves a code from any device with a browser while
+// fly polls for the resulting token.
+func (command *LoginCommand) deviceCodeGrant(client concourse.Client) (string, string, error) {
+	var code struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+
+	errCode, err := postDeviceForm(client.HTTPClient(), client.URL()+"/sky/device/code", url.Values{
+		"client_id": {"fly"},
+		"scope":     {"openid profile email federated:id groups"},
+	}, &code)
+	if err != nil {
+		return "", "", err
+	}
+	if errCode != "" {
+		return "", "", fmt.Errorf("failed to start device login: %s", errCode)
+	}
+
+	fmt.Println("navigate to the following URL on any device with a browser:")
+	fmt.Println("")
+	fmt.Printf("  %s\n", code.VerificationURIComplete)
+	fmt.Println("")
+	fmt.Printf("and log in, then approve the device after checking that it shows the code %s\n", code.UserCode)
+	fmt.Println("")
+	fmt.Println("waiting for approval...")
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	for {
+		time.Sleep(interval)
+
+		var token struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+		}
+
+		errCode, err := postDeviceForm(client.HTTPClient(), client.URL()+"/sky/device/token", url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {code.DeviceCode},
+		}, &token)
+		if err != nil {
+			return "", "", err
+		}
+
+		switch errCode {
+		case "":
+			return token.TokenType, token.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "expired_token":
+			return "", "", errors.New("the code expired before it was approved, please try again")
+		case "access_denied":
+			return "", "", errors.New("the login was denied")
+		default:
+			return "", "", fmt.Errorf("device login failed: %s", errCode)
+		}
+	}
+}
+
+// postDeviceForm posts a device authorization request, decoding successful
+// responses into result and returning the OAuth error code otherwise.
+func postDeviceForm(httpClient *http.Client, endpoint string, form url.Values, result interface{}) (string, error) {
+	response, err := httpClient.PostForm(endpoint, form)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return "", json.NewDecoder(response.Body).Decode(result)
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		var errResponse struct {
+			Error string `json:"error"`
+		}
+		err = json.NewDecoder(response.Body).Decode(&errResponse)
+		if err != nil || errResponse.Error == "" {
+			return "", fmt.Errorf("unexpected response from %s: %s", endpoint, response.Status)
+		}
+		return errResponse.Error, nil
+	default:
+		return "", fmt.Errorf("unexpected response from %s: %s", endpoint, response.Status)
+	}
+}
+
 func listenForTokenCallback(tokenChannel chan string, errorChannel chan error, portChannel chan string, targetUrl string) {
 	s := &http.Server{
 		Addr: "127.0.0.1:0",
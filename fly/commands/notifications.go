@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type NotificationsCommand struct {
+	Team   string `long:"team" description:"Name of the team to show notification deliveries for, if different from the target default"`
+	Count  int    `short:"c" long:"count" default:"50" description:"Number of delivery attempts to show"`
+	Failed bool   `long:"failed" description:"Only show failed delivery attempts"`
+	Json   bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *NotificationsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	deliveries, err := team.NotificationDeliveries(command.Count, command.Failed)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(deliveries)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "event", Color: color.New(color.Bold)},
+			{Contents: "url", Color: color.New(color.Bold)},
+			{Contents: "attempt", Color: color.New(color.Bold)},
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "result", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, delivery := range deliveries {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: deliveryBuildName(delivery)},
+			ui.BuildStatusCell(delivery.Event),
+			{Contents: delivery.URL},
+			{Contents: strconv.Itoa(delivery.Attempt)},
+			{Contents: time.Unix(delivery.AttemptedAt, 0).Local().Format(timeDateLayout)},
+			deliveryResultCell(delivery),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func deliveryBuildName(delivery atc.NotificationDelivery) string {
+	var names []string
+	if delivery.PipelineName != "" {
+		pipelineRef := atc.PipelineRef{
+			Name:         delivery.PipelineName,
+			InstanceVars: delivery.PipelineInstanceVars,
+		}
+
+		names = append(names, pipelineRef.String())
+	}
+
+	if delivery.JobName != "" {
+		names = append(names, delivery.JobName)
+	}
+
+	names = append(names, delivery.BuildName)
+
+	return strings.Join(names, "/")
+}
+
+func deliveryResultCell(delivery atc.NotificationDelivery) ui.TableCell {
+	if delivery.Succeeded {
+		return ui.TableCell{Contents: "delivered", Color: ui.SucceededColor}
+	}
+
+	return ui.TableCell{Contents: delivery.Error, Color: ui.FailedColor}
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type RevokeSessionCommand struct {
+	ID        int    `long:"id" description:"ID of the session to revoke, as shown by sessions"`
+	All       bool   `short:"a" long:"all" description:"Revoke all of your sessions, including this one"`
+	User      string `short:"u" long:"user" description:"Revoke the sessions of this user, rather than your own (admin only). Their API tokens are not revoked; use revoke-api-token for those."`
+	Connector string `short:"c" long:"connector" description:"Connector the user logged in through, as shown by sessions. Required to revoke all of a user's sessions, as user names are only unique per connector."`
+}
+
+func (command *RevokeSessionCommand) Execute([]string) error {
+	if command.ID != 0 && command.All {
+		return errors.New("Cannot specify both --id and --all")
+	}
+
+	if command.ID == 0 && !command.All && command.User == "" {
+		return errors.New("Either --id, --all or --user must be specified")
+	}
+
+	if command.ID == 0 && command.User != "" && command.Connector == "" {
+		return errors.New("--connector must be specified to revoke all of a user's sessions")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	client := target.Client()
+
+	var revoked bool
+	switch {
+	case command.ID != 0 && command.User != "":
+		revoked, err = client.RevokeSession(command.ID)
+	case command.ID != 0:
+		revoked, err = client.RevokeUserSession(command.ID)
+	case command.User != "":
+		revoked, err = client.RevokeSessions(command.User, command.Connector)
+	default:
+		revoked, err = client.RevokeUserSessions()
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case command.ID != 0 && !revoked:
+		return fmt.Errorf("session %d does not exist", command.ID)
+	case command.ID != 0:
+		fmt.Printf("session %d revoked\n", command.ID)
+	case command.User != "" && !revoked:
+		return fmt.Errorf("user '%s' of connector '%s' has no sessions", command.User, command.Connector)
+	case command.User != "":
+		fmt.Printf("sessions of user '%s' of connector '%s' revoked\n", command.User, command.Connector)
+	default:
+		fmt.Println("all of your sessions revoked; run `fly login` to log in again")
+	}
+
+	return nil
+}
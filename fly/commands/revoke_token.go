@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type RevokeTokenCommand struct {
+	ID   int    `long:"id" required:"true" description:"ID of the token to revoke, as shown by fly tokens"`
+	Team string `long:"team" description:"Revoke a service account token of this team, rather than a personal token"`
+}
+
+func (command *RevokeTokenCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var revoked bool
+	if command.Team != "" {
+		team, err := target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+
+		revoked, err = team.RevokeAPIToken(command.ID)
+		if err != nil {
+			return err
+		}
+	} else {
+		revoked, err = target.Client().RevokeAPIToken(command.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !revoked {
+		return fmt.Errorf("token %d does not exist", command.ID)
+	}
+
+	fmt.Printf("token %d revoked\n", command.ID)
+
+	return nil
+}
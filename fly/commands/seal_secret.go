@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc/sealed"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type SealSecretCommand struct {
+	Team  string `long:"team" description:"Name of the team whose pipelines will use the secret, if different from the target default"`
+	Value string `short:"v" long:"value" description:"Value to seal. Read from stdin if not specified."`
+}
+
+func (command *SealSecretCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	value := command.Value
+	if value == "" {
+		input, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		value = strings.TrimSuffix(string(input), "\n")
+	}
+
+	if value == "" {
+		return errors.New("no value to seal given")
+	}
+
+	key, err := team.SealingKey()
+	if err != nil {
+		return err
+	}
+
+	sealedValue, err := sealed.Seal(key.PublicKey, value)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("((%s:%s))\n", sealed.VarSource, sealedValue)
+
+	return nil
+}
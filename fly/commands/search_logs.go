@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SearchLogsCommand struct {
+	Pipeline    *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only search the builds of this pipeline"`
+	Job         flaghelpers.JobFlag       `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Only search the builds of this job"`
+	Statuses    []string                  `short:"s" long:"status" description:"Only search builds with this status (can be specified multiple times)"`
+	Since       string                    `long:"since" description:"Start of the range of build start times to search"`
+	Until       string                    `long:"until" description:"End of the range of build start times to search"`
+	Count       int                       `short:"c" long:"count" default:"50" description:"Maximum number of matching lines to show"`
+	OldestFirst bool                      `long:"oldest-first" description:"Show matches from the oldest builds first"`
+	Team        string                    `long:"team" description:"Name of the team whose builds to search, if different from the target default"`
+	Json        bool                      `long:"json" description:"Print command result as JSON"`
+
+	Args struct {
+		Query string `positional-arg-name:"QUERY" required:"true" description:"Words to search the build logs for. Only whole words match, ignoring case and colors"`
+	} `positional-args:"yes"`
+}
+
+func (command *SearchLogsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	search, err := command.search()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	matches, found, err := team.SearchBuildLogs(search)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline not found")
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(matches)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "origin", Color: color.New(color.Bold)},
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "line", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, match := range matches {
+		var names []string
+		if match.PipelineName != "" {
+			pipelineRef := atc.PipelineRef{
+				Name:         match.PipelineName,
+				InstanceVars: match.PipelineInstanceVars,
+			}
+
+			names = append(names, pipelineRef.String())
+		}
+
+		if match.JobName != "" {
+			names = append(names, match.JobName)
+		}
+
+		names = append(names, match.BuildName)
+
+		timeCell := ui.TableCell{Contents: "n/a"}
+		if match.Time != 0 {
+			timeCell.Contents = time.Unix(match.Time, 0).Local().Format(timeDateLayout)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(match.BuildID)},
+			{Contents: strings.Join(names, "/")},
+			ui.BuildStatusCell(match.BuildStatus),
+			{Contents: match.OriginID},
+			timeCell,
+			{Contents: strings.TrimRight(match.Line, "\r\n")},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *SearchLogsCommand) search() (concourse.BuildLogSearch, error) {
+	search := concourse.BuildLogSearch{
+		Query:       command.Args.Query,
+		Limit:       command.Count,
+		OldestFirst: command.OldestFirst,
+	}
+
+	jobFlag := command.Job.PipelineRef.Name != "" && command.Job.JobName != ""
+	if command.Pipeline != nil && jobFlag {
+		return search, errors.New("Cannot specify both --pipeline and --job")
+	}
+
+	if command.Pipeline != nil {
+		_, err := command.Pipeline.Validate()
+		if err != nil {
+			return search, err
+		}
+
+		search.Pipeline = command.Pipeline.Ref()
+	}
+
+	if jobFlag {
+		search.Pipeline = command.Job.PipelineRef
+		search.JobName = command.Job.JobName
+	}
+
+	for _, status := range command.Statuses {
+		search.Statuses = append(search.Statuses, atc.BuildStatus(status))
+	}
+
+	var err error
+	if command.Since != "" {
+		search.Since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return search, errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		search.Until, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return search, errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Since != "" && command.Until != "" && search.Since.After(search.Until) {
+		return search, errors.New("Cannot have --since after --until")
+	}
+
+	return search, nil
+}
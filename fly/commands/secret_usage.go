@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SecretUsageCommand struct {
+	Var      string        `short:"v" long:"var" description:"Only show reads of this var"`
+	Pipeline string        `short:"p" long:"pipeline" description:"Only show reads by builds of pipelines with this name"`
+	Job      string        `short:"j" long:"job" description:"Only show reads by builds of jobs with this name"`
+	Last     time.Duration `long:"last" description:"Only show reads within this long ago, e.g. 720h"`
+	Since    string        `long:"since" description:"Start of the range of read times to show"`
+	Until    string        `long:"until" description:"End of the range of read times to show"`
+	Count    int           `short:"c" long:"count" default:"50" description:"Maximum number of reads to show"`
+	Team     string        `long:"team" description:"Name of the team whose secret reads to show, if different from the target default"`
+	Json     bool          `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SecretUsageCommand) Execute([]string) error {
+	filter, err := command.filter()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	accesses, err := team.SecretAccesses(filter)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(accesses)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "var", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "backend", Color: color.New(color.Bold)},
+			{Contents: "path", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, access := range accesses {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: time.Unix(access.AccessedAt, 0).Local().Format(timeDateLayout)},
+			{Contents: secretAccessVarName(access)},
+			{Contents: secretAccessBuildName(access)},
+			{Contents: access.StepName},
+			{Contents: access.Backend},
+			{Contents: access.SecretPath},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *SecretUsageCommand) filter() (concourse.SecretAccessFilter, error) {
+	filter := concourse.SecretAccessFilter{
+		Var:          command.Var,
+		PipelineName: command.Pipeline,
+		JobName:      command.Job,
+		Limit:        command.Count,
+	}
+
+	if command.Last != 0 && command.Since != "" {
+		return filter, errors.New("Cannot specify both --last and --since")
+	}
+
+	if command.Last != 0 {
+		filter.Since = time.Now().Add(-command.Last)
+	}
+
+	var err error
+	if command.Since != "" {
+		filter.Since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return filter, errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		filter.Until, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return filter, errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Since.After(filter.Until) {
+		return filter, errors.New("Cannot have --since after --until")
+	}
+
+	return filter, nil
+}
+
+func secretAccessVarName(access atc.SecretAccess) string {
+	if access.VarSource != "" {
+		return access.VarSource + ":" + access.Var
+	}
+
+	return access.Var
+}
+
+func secretAccessBuildName(access atc.SecretAccess) string {
+	var names []string
+	if access.PipelineName != "" {
+		pipelineRef := atc.PipelineRef{
+			Name:         access.PipelineName,
+			InstanceVars: access.PipelineInstanceVars,
+		}
+
+		names = append(names, pipelineRef.String())
+	}
+
+	if access.JobName != "" {
+		names = append(names, access.JobName)
+	}
+
+	names = append(names, access.BuildName)
+
+	return strings.Join(names, "/")
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SessionsCommand struct {
+	All       bool   `short:"a" long:"all" description:"List the sessions of every user (admin only)"`
+	User      string `short:"u" long:"user" description:"List the sessions of this user, as shown by active-users (admin only)"`
+	Connector string `short:"c" long:"connector" description:"Only list the sessions of the user who logged in through this connector"`
+	Json      bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SessionsCommand) Execute([]string) error {
+	if command.All && command.User != "" {
+		return errors.New("Cannot specify both --all and --user")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var sessions []atc.Session
+	if command.All || command.User != "" {
+		sessions, err = target.Client().Sessions(command.User, command.Connector)
+	} else {
+		sessions, err = target.Client().UserSessions()
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(sessions)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "user", Color: color.New(color.Bold)},
+			{Contents: "connector", Color: color.New(color.Bold)},
+			{Contents: "client", Color: color.New(color.Bold)},
+			{Contents: "address", Color: color.New(color.Bold)},
+			{Contents: "issued", Color: color.New(color.Bold)},
+			{Contents: "last used", Color: color.New(color.Bold)},
+			{Contents: "expires", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, session := range sessions {
+		lastUsedCell := ui.TableCell{Contents: "never", Color: color.New(color.Faint)}
+		if session.LastUsedAt != 0 {
+			lastUsedCell = ui.TableCell{Contents: time.Unix(session.LastUsedAt, 0).Local().Format(timeDateLayout)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(session.ID)},
+			{Contents: session.UserName},
+			stringOrDefault(session.Connector),
+			stringOrDefault(session.ClientID),
+			stringOrDefault(session.RemoteAddr),
+			{Contents: time.Unix(session.CreatedAt, 0).Local().Format(timeDateLayout)},
+			lastUsedCell,
+			{Contents: time.Unix(session.ExpiresAt, 0).Local().Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
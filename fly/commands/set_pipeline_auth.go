@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/skymarshal/skycmd"
+	"github.com/vito/go-interact/interact"
+)
+
+type SetPipelineAuthCommand struct {
+	Pipeline        string               `short:"p" long:"pipeline" required:"true" description:"Pipeline or instance group to grant roles on"`
+	Team            string               `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+	Role            string               `long:"role" default:"owner" description:"Role to grant the given users and groups, when not using a configuration file"`
+	Remove          bool                 `long:"remove" description:"Remove all role bindings from the pipeline"`
+	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
+	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+}
+
+func (command *SetPipelineAuthCommand) Validate() error {
+	if strings.Contains(command.Pipeline, "/") {
+		return errors.New("pipeline name cannot contain '/'")
+	}
+
+	return nil
+}
+
+func (command *SetPipelineAuthCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	authRoles := atc.TeamAuth{}
+	if !command.Remove {
+		authRoles, err = command.authRoles()
+		if err != nil {
+			fmt.Fprintln(ui.Stderr, "error:", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Println("setting role bindings for pipeline:", ui.Embolden("%s", command.Pipeline))
+
+	roles := []string{}
+	for role := range authRoles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	if len(roles) == 0 {
+		fmt.Println()
+		fmt.Printf("  %s\n", ui.OffColor.Sprint("no roles"))
+	}
+
+	for _, role := range roles {
+		authUsers := authRoles[role]["users"]
+		authGroups := authRoles[role]["groups"]
+
+		fmt.Println()
+		fmt.Printf("role %s:\n", ui.Embolden(role))
+		fmt.Printf("  users:\n")
+		if len(authUsers) > 0 {
+			for _, user := range authUsers {
+				fmt.Printf("  - %s\n", user)
+			}
+		} else {
+			fmt.Printf("    %s\n", ui.OffColor.Sprint("none"))
+		}
+
+		fmt.Println()
+		fmt.Printf("  groups:\n")
+		if len(authGroups) > 0 {
+			for _, group := range authGroups {
+				fmt.Printf("  - %s\n", group)
+			}
+		} else {
+			fmt.Printf("    %s\n", ui.OffColor.Sprint("none"))
+		}
+	}
+
+	confirm := true
+	if !command.SkipInteractive {
+		confirm = false
+		err = interact.NewInteraction("\napply pipeline role bindings?").Resolve(&confirm)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !confirm {
+		displayhelpers.Failf("bailing out")
+	}
+
+	err = team.SetPipelineAuth(command.Pipeline, authRoles)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("pipeline role bindings updated")
+
+	return nil
+}
+
+// authRoles reads the role bindings from the configuration file, or grants
+// the users and groups given as flags the role chosen with --role.
+func (command *SetPipelineAuthCommand) authRoles() (atc.TeamAuth, error) {
+	auth, err := command.AuthFlags.Format()
+	if err != nil {
+		return nil, err
+	}
+
+	if command.AuthFlags.Config.Path() != "" || command.Role == "owner" {
+		return auth, nil
+	}
+
+	return atc.TeamAuth{command.Role: auth["owner"]}, nil
+}
@@ -2,8 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -14,6 +16,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
 	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
 )
 
 func WireTeamConnectors(command *flags.Command) {
@@ -68,6 +71,12 @@ func (command *SetTeamCommand) Execute([]string) error {
 		os.Exit(1)
 	}
 
+	notifications, err := command.notifications()
+	if err != nil {
+		fmt.Fprintln(ui.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
 	roles := []string{}
 	for role := range authRoles {
 		roles = append(roles, role)
@@ -103,6 +112,23 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if len(notifications) > 0 {
+		fmt.Println()
+		fmt.Println("notifications:")
+		for _, rule := range notifications {
+			events := "all events"
+			if len(rule.Events) > 0 {
+				var names []string
+				for _, event := range rule.Events {
+					names = append(names, string(event))
+				}
+				events = strings.Join(names, ", ")
+			}
+
+			fmt.Printf("- %s (%s)\n", rule.URL, events)
+		}
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -120,7 +146,10 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{
+		Auth:          authRoles,
+		Notifications: notifications,
+	}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -139,3 +168,33 @@ func (command *SetTeamCommand) Execute([]string) error {
 
 	return nil
 }
+
+// notifications reads the notification rules from the team's configuration
+// file, if one is given.
+func (command *SetTeamCommand) notifications() (atc.NotificationRules, error) {
+	path := command.AuthFlags.Config.Path()
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config struct {
+		Notifications atc.NotificationRules `json:"notifications"`
+	}
+
+	err = yaml.Unmarshal(content, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.Notifications.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return config.Notifications, nil
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type TestResultsCommand struct {
+	Job    flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job containing the build"`
+	Build  string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+	Failed bool                `long:"failed" description:"Only show tests that failed or errored"`
+	Json   bool                `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *TestResultsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	suites, found, err := target.Client().BuildTestSuites(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("build does not exist")
+	}
+
+	if command.Failed {
+		suites = failedTestSuites(suites)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(suites)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "suite", Color: color.New(color.Bold)},
+			{Contents: "test", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+		},
+	}
+
+	var total int
+	counts := map[atc.TestStatus]int{}
+	for _, suite := range suites {
+		for _, testCase := range suite.Cases {
+			name := testCase.Name
+			if testCase.ClassName != "" {
+				name = testCase.ClassName + "." + testCase.Name
+			}
+
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: suite.StepName},
+				{Contents: suite.Name},
+				{Contents: name},
+				testStatusCell(testCase.Status),
+				{Contents: fmt.Sprintf("%.3fs", testCase.Duration)},
+			})
+
+			total++
+			counts[testCase.Status]++
+		}
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	for _, suite := range suites {
+		for _, testCase := range suite.Cases {
+			if testCase.FailureMessage == "" {
+				continue
+			}
+
+			fmt.Println()
+			fmt.Printf("%s %s\n", ui.FailedColor.Sprint("---"), ui.Embolden("%s/%s/%s", suite.StepName, suite.Name, testCase.Name))
+			fmt.Println(testCase.FailureMessage)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf(
+		"%d tests, %d passed, %d failed, %d errored, %d skipped\n",
+		total,
+		counts[atc.TestPassed],
+		counts[atc.TestFailed],
+		counts[atc.TestErrored],
+		counts[atc.TestSkipped],
+	)
+
+	return nil
+}
+
+func failedTestSuites(suites []atc.TestSuite) []atc.TestSuite {
+	var failed []atc.TestSuite
+	for _, suite := range suites {
+		var cases []atc.TestCase
+		for _, testCase := range suite.Cases {
+			if testCase.Status == atc.TestFailed || testCase.Status == atc.TestErrored {
+				cases = append(cases, testCase)
+			}
+		}
+
+		if len(cases) > 0 {
+			suite.Cases = cases
+			failed = append(failed, suite)
+		}
+	}
+
+	return failed
+}
+
+func testStatusCell(status atc.TestStatus) ui.TableCell {
+	cell := ui.TableCell{Contents: string(status)}
+
+	switch status {
+	case atc.TestPassed:
+		cell.Color = ui.SucceededColor
+	case atc.TestFailed:
+		cell.Color = ui.FailedColor
+	case atc.TestErrored:
+		cell.Color = ui.ErroredColor
+	case atc.TestSkipped:
+		cell.Color = ui.PendingColor
+	}
+
+	return cell
+}
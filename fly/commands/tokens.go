@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type TokensCommand struct {
+	Team string `long:"team" description:"List the service account tokens of this team, rather than your personal tokens"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *TokensCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var tokens []atc.APIToken
+	if command.Team != "" {
+		team, err := target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+
+		tokens, err = team.APITokens()
+		if err != nil {
+			return err
+		}
+	} else {
+		tokens, err = target.Client().APITokens()
+		if err != nil {
+			return err
+		}
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(tokens)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	headers := ui.TableRow{
+		{Contents: "id", Color: color.New(color.Bold)},
+		{Contents: "name", Color: color.New(color.Bold)},
+	}
+
+	if command.Team != "" {
+		headers = append(headers, ui.TableCell{Contents: "role", Color: color.New(color.Bold)})
+	}
+
+	headers = append(headers,
+		ui.TableCell{Contents: "scopes", Color: color.New(color.Bold)},
+		ui.TableCell{Contents: "created by", Color: color.New(color.Bold)},
+		ui.TableCell{Contents: "created at", Color: color.New(color.Bold)},
+		ui.TableCell{Contents: "expires", Color: color.New(color.Bold)},
+	)
+
+	table := ui.Table{Headers: headers}
+
+	for _, token := range tokens {
+		row := ui.TableRow{
+			{Contents: strconv.Itoa(token.ID)},
+			{Contents: token.Name},
+		}
+
+		if command.Team != "" {
+			row = append(row, ui.TableCell{Contents: token.Role})
+		}
+
+		scopesCell := ui.TableCell{Contents: strings.Join(token.Scopes, ",")}
+		if len(token.Scopes) == 0 {
+			scopesCell = ui.TableCell{Contents: "all", Color: color.New(color.Faint)}
+		}
+
+		expiresCell := ui.TableCell{Contents: "never", Color: color.New(color.Faint)}
+		if token.ExpiresAt != 0 {
+			expiresAt := time.Unix(token.ExpiresAt, 0)
+
+			expiresCell = ui.TableCell{Contents: expiresAt.Local().Format(timeDateLayout)}
+			if expiresAt.Before(time.Now()) {
+				expiresCell.Color = color.New(color.FgRed)
+			}
+		}
+
+		row = append(row,
+			scopesCell,
+			ui.TableCell{Contents: token.CreatedBy},
+			ui.TableCell{Contents: time.Unix(token.CreatedAt, 0).Local().Format(timeDateLayout)},
+			expiresCell,
+		)
+
+		table.Data = append(table.Data, row)
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -53,6 +53,14 @@ func (command *UserinfoCommand) Execute([]string) error {
 		}
 	}
 
+	for team, pipelines := range userinfo.PipelineRoles {
+		for pipeline, roles := range pipelines {
+			for _, role := range roles {
+				teamRoles = append(teamRoles, team+"/"+pipeline+"/"+role)
+			}
+		}
+	}
+
 	sort.Strings(teamRoles)
 
 	row := ui.TableRow{
@@ -0,0 +1,299 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	var teamHandler http.HandlerFunc
+
+	BeforeEach(func() {
+		teamHandler = ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/teams/some-team"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{Name: "some-team"}),
+		)
+	})
+
+	Describe("create-token", func() {
+		Context("when creating a personal token", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/tokens"),
+						func(w http.ResponseWriter, r *http.Request) {
+							var token atc.APIToken
+							Expect(json.NewDecoder(r.Body).Decode(&token)).To(Succeed())
+							Expect(token.Name).To(Equal("deploy-bot"))
+							Expect(token.Scopes).To(Equal([]string{"trigger-job:pipeline/main/deploy", "read-only"}))
+							Expect(token.ExpiresAt).To(BeNumerically("~", time.Now().Add(8*time.Hour).Unix(), 60))
+						},
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.APIToken{
+							ID:     1,
+							Name:   "deploy-bot",
+							Scopes: []string{"trigger-job:pipeline/main/deploy", "read-only"},
+							Token:  "cct_some-secret",
+						}),
+					),
+				)
+			})
+
+			It("prints the token once", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "create-token",
+					"--name", "deploy-bot",
+					"--scope", "trigger-job:pipeline/main/deploy",
+					"--scope", "read-only",
+					"--expires-in", "8h",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("created token 'deploy-bot'"))
+				Expect(sess.Out).To(gbytes.Say("cct_some-secret"))
+				Expect(sess.Out).To(gbytes.Say("will not be shown again"))
+			})
+		})
+
+		Context("when creating a personal token without an expiry", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "create-token", "--name", "deploy-bot")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("--expires-in must be specified for personal tokens"))
+			})
+		})
+
+		Context("when creating a team token which expires", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					teamHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/tokens"),
+						func(w http.ResponseWriter, r *http.Request) {
+							var token atc.APIToken
+							Expect(json.NewDecoder(r.Body).Decode(&token)).To(Succeed())
+							Expect(token.Name).To(Equal("ci"))
+							Expect(token.Role).To(Equal("viewer"))
+							Expect(token.ExpiresAt).To(BeNumerically("~", time.Now().Add(time.Hour).Unix(), 60))
+						},
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.APIToken{
+							ID:       2,
+							Name:     "ci",
+							TeamName: "some-team",
+							Role:     "viewer",
+							Token:    "cct_some-secret",
+						}),
+					),
+				)
+			})
+
+			It("creates the token on the team with the given role", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "create-token",
+					"--name", "ci",
+					"--team", "some-team",
+					"--role", "viewer",
+					"--expires-in", "1h",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("cct_some-secret"))
+			})
+		})
+
+		Context("when the token is invalid", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/tokens"),
+						ghttp.RespondWith(http.StatusBadRequest, "invalid scope 'bogus': unknown scope or action 'bogus'"),
+					),
+				)
+			})
+
+			It("prints the error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "create-token", "--name", "bot", "--scope", "bogus", "--expires-in", "1h")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("unknown scope or action 'bogus'"))
+			})
+		})
+	})
+
+	Describe("tokens", func() {
+		var createdAt, expiresAt time.Time
+
+		BeforeEach(func() {
+			createdAt = time.Date(2021, 6, 29, 12, 30, 0, 0, time.UTC)
+			expiresAt = time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		})
+
+		Context("when listing personal tokens", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/tokens"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.APIToken{
+							{
+								ID:        1,
+								Name:      "deploy-bot",
+								Scopes:    []string{"trigger-job:pipeline/main/deploy", "read-only"},
+								CreatedBy: "some-user",
+								CreatedAt: createdAt.Unix(),
+								ExpiresAt: expiresAt.Unix(),
+							},
+							{
+								ID:        2,
+								Name:      "laptop",
+								CreatedBy: "some-user",
+								CreatedAt: createdAt.Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists them", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "tokens")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "scopes", Color: color.New(color.Bold)},
+						{Contents: "created by", Color: color.New(color.Bold)},
+						{Contents: "created at", Color: color.New(color.Bold)},
+						{Contents: "expires", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "1"},
+							{Contents: "deploy-bot"},
+							{Contents: "trigger-job:pipeline/main/deploy,read-only"},
+							{Contents: "some-user"},
+							{Contents: createdAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: expiresAt.Local().Format("2006-01-02@15:04:05-0700")},
+						},
+						{
+							{Contents: "2"},
+							{Contents: "laptop"},
+							{Contents: "all", Color: color.New(color.Faint)},
+							{Contents: "some-user"},
+							{Contents: createdAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "never", Color: color.New(color.Faint)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when listing a team's tokens as JSON", func() {
+			var tokens []atc.APIToken
+
+			BeforeEach(func() {
+				tokens = []atc.APIToken{
+					{
+						ID:        3,
+						Name:      "ci",
+						TeamName:  "some-team",
+						Role:      "member",
+						CreatedBy: "some-user",
+						CreatedAt: createdAt.Unix(),
+					},
+				}
+
+				atcServer.AppendHandlers(
+					teamHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/tokens"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, tokens),
+					),
+				)
+			})
+
+			It("prints them", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "tokens", "--team", "some-team", "--json")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var printed []atc.APIToken
+				Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+				Expect(printed).To(Equal(tokens))
+			})
+		})
+	})
+
+	Describe("revoke-token", func() {
+		Context("when the token exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/tokens/1"),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("revokes it", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "revoke-token", "--id", "1")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("token 1 revoked"))
+			})
+		})
+
+		Context("when revoking a team token which does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					teamHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/tokens/3"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "revoke-token", "--id", "3", "--team", "some-team")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("token 3 does not exist"))
+			})
+		})
+	})
+})
@@ -0,0 +1,154 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("ApproveBuild", func() {
+	var expectedApproveURL = "/api/v1/builds/23/approvals/deploy"
+
+	var expectedBuild = atc.Build{
+		ID:      23,
+		Name:    "42",
+		Status:  "started",
+		JobName: "myjob",
+		APIURL:  "api/v1/builds/123",
+	}
+
+	Context("when the build exists", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+			)
+		})
+
+		Context("and the approval step exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedApproveURL),
+						ghttp.VerifyJSONRepresenting(atc.ApproveBuildBody{Approved: true, Comment: "lgtm"}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildApproval{
+							Name:     "deploy",
+							Approved: true,
+							Approver: "some-user",
+							Comment:  "lgtm",
+						}),
+					),
+				)
+			})
+
+			It("approves the build", func() {
+				Expect(func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-s", "deploy", "-c", "lgtm")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(gbytes.Say("build successfully approved"))
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(3))
+			})
+		})
+
+		Context("and the build is rejected", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedApproveURL),
+						ghttp.VerifyJSONRepresenting(atc.ApproveBuildBody{Approved: false}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildApproval{
+							Name:     "deploy",
+							Approved: false,
+							Approver: "some-user",
+						}),
+					),
+				)
+			})
+
+			It("rejects the build", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-s", "deploy", "--reject")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("build successfully rejected"))
+			})
+		})
+
+		Context("and the approval step does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedApproveURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-s", "deploy")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: approval step 'deploy' not found in build"))
+			})
+		})
+	})
+
+	Context("when the build does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/42"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("returns an error", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "42", "-s", "deploy")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: build does not exist"))
+		})
+	})
+
+	Context("when the step is not specified", func() {
+		It("asks the user to specify a step", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("s", "step") + "' was not specified"))
+		})
+	})
+})
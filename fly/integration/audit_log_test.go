@@ -0,0 +1,236 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("audit-log", func() {
+		var (
+			flyCmd    *exec.Cmd
+			createdAt time.Time
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log")
+			createdAt = time.Date(2021, 6, 29, 12, 30, 0, 0, time.UTC)
+		})
+
+		Context("when events are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit", "limit=50"),
+						ghttp.RespondWithJSONEncoded(200, []atc.AuditEvent{
+							{
+								ID:        2,
+								Action:    atc.SaveConfig,
+								UserName:  "some-user",
+								Connector: "github",
+								TeamName:  "main",
+								Target:    map[string]string{"pipeline_name": "some-pipeline"},
+								Method:    "PUT",
+								Path:      "/api/v1/teams/main/pipelines/some-pipeline/config",
+								Status:    200,
+								CreatedAt: createdAt.Unix(),
+							},
+							{
+								ID:        1,
+								Action:    atc.ListActiveUsersSince,
+								UserName:  "other-user",
+								Method:    "GET",
+								Path:      "/api/v1/users",
+								Status:    403,
+								CreatedAt: createdAt.Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists them to the user", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "user", Color: color.New(color.Bold)},
+						{Contents: "action", Color: color.New(color.Bold)},
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "target", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: createdAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "some-user (github)"},
+							{Contents: "SaveConfig"},
+							{Contents: "main"},
+							{Contents: "pipeline:some-pipeline"},
+							{Contents: "200"},
+						},
+						{
+							{Contents: createdAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "other-user"},
+							{Contents: "ListActiveUsersSince"},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: ""},
+							{Contents: "403", Color: color.New(color.FgRed)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args,
+					"--user", "some-user",
+					"--action", "SaveConfig",
+					"--team", "main",
+					"--since", "2021-06-01 00:00:00",
+					"--until", "2021-06-30 00:00:00",
+					"--count", "10",
+				)
+
+				since, err := time.ParseInLocation("2006-01-02 15:04:05", "2021-06-01 00:00:00", time.Local)
+				Expect(err).NotTo(HaveOccurred())
+
+				until, err := time.ParseInLocation("2006-01-02 15:04:05", "2021-06-30 00:00:00", time.Local)
+				Expect(err).NotTo(HaveOccurred())
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit",
+							"action=SaveConfig&limit=10"+
+								"&since="+strconv.FormatInt(since.Unix(), 10)+
+								"&team=main"+
+								"&until="+strconv.FormatInt(until.Unix(), 10)+
+								"&user=some-user"),
+						ghttp.RespondWithJSONEncoded(200, []atc.AuditEvent{}),
+					),
+				)
+			})
+
+			It("passes them to the API", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when --last is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--last", "24h")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit"),
+						func(w http.ResponseWriter, r *http.Request) {
+							since, err := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(time.Unix(since, 0)).To(BeTemporally("~", time.Now().Add(-24*time.Hour), time.Minute))
+						},
+						ghttp.RespondWithJSONEncoded(200, []atc.AuditEvent{}),
+					),
+				)
+			})
+
+			It("only asks for events within that long ago", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when both --last and --since are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--last", "24h", "--since", "2021-06-01 00:00:00")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --last and --since"))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit"),
+						ghttp.RespondWithJSONEncoded(200, []atc.AuditEvent{
+							{
+								ID:        1,
+								Action:    atc.DestroyTeam,
+								UserName:  "some-admin",
+								TeamName:  "some-team",
+								Method:    "DELETE",
+								Path:      "/api/v1/teams/some-team",
+								Status:    204,
+								CreatedAt: 1624969800,
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints the events as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[{
+					"id": 1,
+					"action": "DestroyTeam",
+					"user_name": "some-admin",
+					"team_name": "some-team",
+					"method": "DELETE",
+					"path": "/api/v1/teams/some-team",
+					"status": 204,
+					"created_at": 1624969800
+				}]`))
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit"),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
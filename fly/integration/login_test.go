@@ -621,6 +621,94 @@ var _ = Describe("login Command", func() {
 			})
 		})
 
+		Context("with device authorization grant", func() {
+			var deviceTokenResponse http.HandlerFunc
+
+			BeforeEach(func() {
+				deviceTokenResponse = ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+					"token_type":   "Bearer",
+					"access_token": "access-token",
+					"expires_in":   3600,
+				})
+			})
+
+			JustBeforeEach(func() {
+				loginATCServer.AppendHandlers(
+					infoHandler(),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/sky/device/code"),
+						ghttp.VerifyFormKV("client_id", "fly"),
+						ghttp.VerifyFormKV("scope", "openid profile email federated:id groups"),
+						ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+							"device_code":               "some-device-code",
+							"user_code":                 "ABCD-EFGH",
+							"verification_uri":          loginATCServer.URL() + "/sky/device",
+							"verification_uri_complete": loginATCServer.URL() + "/sky/device?user_code=ABCD-EFGH",
+							"expires_in":                300,
+							"interval":                  1,
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/sky/device/token"),
+						ghttp.VerifyFormKV("grant_type", "urn:ietf:params:oauth:grant-type:device_code"),
+						ghttp.VerifyFormKV("device_code", "some-device-code"),
+						ghttp.RespondWithJSONEncoded(400, map[string]string{"error": "authorization_pending"}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/sky/device/token"),
+						ghttp.VerifyFormKV("device_code", "some-device-code"),
+						deviceTokenResponse,
+					),
+					userInfoHandler(),
+				)
+			})
+
+			It("polls until the code is approved from another device", func() {
+				flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "--device")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say(regexp.QuoteMeta(loginATCServer.URL() + "/sky/device?user_code=ABCD-EFGH")))
+				Eventually(sess.Out).Should(gbytes.Say("ABCD-EFGH"))
+				Eventually(sess.Out).Should(gbytes.Say("target saved"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(flyRcContents)).To(ContainSubstring("value: access-token"))
+			})
+
+			Context("when the code expires before it is approved", func() {
+				BeforeEach(func() {
+					deviceTokenResponse = ghttp.RespondWithJSONEncoded(400, map[string]string{"error": "expired_token"})
+				})
+
+				It("errors", func() {
+					flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "--device")
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("the code expired before it was approved"))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(1))
+				})
+			})
+
+			It("cannot be combined with a username and password", func() {
+				flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "--device", "-u", "some_username", "-p", "some_password")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("--device cannot be used together with --username and --password"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
 		Context("cannot successfully login", func() {
 			Context("team does not exist", func() {
 				It("returns a warning", func() {
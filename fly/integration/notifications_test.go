@@ -0,0 +1,192 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("notifications", func() {
+		var (
+			flyCmd      *exec.Cmd
+			attemptedAt time.Time
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "notifications")
+			attemptedAt = time.Date(2021, 6, 11, 12, 30, 0, 0, time.UTC)
+		})
+
+		Context("when deliveries are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/notifications/deliveries", "limit=50"),
+						ghttp.RespondWithJSONEncoded(200, []atc.NotificationDelivery{
+							{
+								ID:                   2,
+								BuildID:              42,
+								BuildName:            "3",
+								JobName:              "some-job",
+								PipelineName:         "some-pipeline",
+								PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
+								TeamName:             "main",
+								URL:                  "https://example.com/hook",
+								Event:                atc.StatusFailed,
+								Attempt:              2,
+								StatusCode:           200,
+								Succeeded:            true,
+								AttemptedAt:          attemptedAt.Unix(),
+							},
+							{
+								ID:           1,
+								BuildID:      42,
+								BuildName:    "3",
+								JobName:      "some-job",
+								PipelineName: "some-pipeline",
+								TeamName:     "main",
+								URL:          "https://example.com/hook",
+								Event:        atc.StatusFailed,
+								Attempt:      1,
+								StatusCode:   502,
+								Error:        "unexpected response: 502 Bad Gateway",
+								AttemptedAt:  attemptedAt.Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists them to the user", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "event", Color: color.New(color.Bold)},
+						{Contents: "url", Color: color.New(color.Bold)},
+						{Contents: "attempt", Color: color.New(color.Bold)},
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "result", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-pipeline/branch:master/some-job/3"},
+							{Contents: "failed", Color: color.New(color.FgRed)},
+							{Contents: "https://example.com/hook"},
+							{Contents: "2"},
+							{Contents: attemptedAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "delivered", Color: color.New(color.FgGreen)},
+						},
+						{
+							{Contents: "some-pipeline/some-job/3"},
+							{Contents: "failed", Color: color.New(color.FgRed)},
+							{Contents: "https://example.com/hook"},
+							{Contents: "1"},
+							{Contents: attemptedAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "unexpected response: 502 Bad Gateway", Color: color.New(color.FgRed)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when --failed and --count are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--failed", "--count", "10")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/notifications/deliveries", "failed=true&limit=10"),
+						ghttp.RespondWithJSONEncoded(200, []atc.NotificationDelivery{}),
+					),
+				)
+			})
+
+			It("only asks for failed deliveries", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/notifications/deliveries"),
+						ghttp.RespondWithJSONEncoded(200, []atc.NotificationDelivery{
+							{
+								ID:          1,
+								BuildID:     42,
+								BuildName:   "3",
+								TeamName:    "main",
+								URL:         "https://example.com/hook",
+								Event:       atc.StatusStarted,
+								Attempt:     1,
+								StatusCode:  204,
+								Succeeded:   true,
+								AttemptedAt: 1623414207,
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints response in json as stdout", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"id": 1,
+						"build_id": 42,
+						"build_name": "3",
+						"team_name": "main",
+						"url": "https://example.com/hook",
+						"event": "started",
+						"attempt": 1,
+						"status_code": 204,
+						"succeeded": true,
+						"attempted_at": 1623414207
+					}
+				]`))
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/notifications/deliveries"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
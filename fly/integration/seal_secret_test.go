@@ -0,0 +1,129 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/sealed"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("seal-secret", func() {
+		const (
+			identity  = "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX"
+			publicKey = "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj"
+		)
+
+		var sealedVar = regexp.MustCompile(`\(\(sealed:([-\w]+)\)\)`)
+
+		unsealed := func(sess *gexec.Session) string {
+			matches := sealedVar.FindStringSubmatch(string(sess.Out.Contents()))
+			Expect(matches).To(HaveLen(2))
+
+			value, err := sealed.Unseal(identity, matches[1])
+			Expect(err).NotTo(HaveOccurred())
+
+			return value
+		}
+
+		Context("when the team's sealing key is returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/sealing_key"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SealingKey{PublicKey: publicKey}),
+					),
+				)
+			})
+
+			It("prints the value sealed to the key as a var", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "seal-secret", "--value", "some-secret")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(unsealed(sess)).To(Equal("some-secret"))
+			})
+
+			It("reads the value from stdin when it is not given", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "seal-secret")
+				flyCmd.Stdin = strings.NewReader("some-secret\n")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(unsealed(sess)).To(Equal("some-secret"))
+			})
+		})
+
+		Context("when --team is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "other-team",
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/sealing_key"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SealingKey{PublicKey: publicKey}),
+					),
+				)
+			})
+
+			It("seals the value to that team's key", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "seal-secret", "--team", "other-team", "--value", "some-secret")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(unsealed(sess)).To(Equal("some-secret"))
+			})
+		})
+
+		Context("when no value is given", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "seal-secret")
+				flyCmd.Stdin = strings.NewReader("")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("no value to seal given"))
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/sealing_key"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "seal-secret", "--value", "some-secret")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
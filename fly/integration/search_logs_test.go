@@ -0,0 +1,158 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("search-logs", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			matches = []atc.BuildLogMatch{
+				{
+					BuildID:      23,
+					BuildName:    "42",
+					BuildStatus:  atc.StatusFailed,
+					JobName:      "some-job",
+					PipelineName: "some-pipeline",
+					TeamName:     "main",
+					EventID:      7,
+					OriginID:     "some-origin",
+					Time:         1623938117,
+					Line:         "panic: some failure\n",
+				},
+			}
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "some failure")
+		})
+
+		Context("when matches are found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/builds/search", "limit=50&q=some+failure"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, matches),
+					),
+				)
+			})
+
+			It("lists the matching lines", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "origin", Color: color.New(color.Bold)},
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "line", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "23"},
+							{Contents: "some-pipeline/some-job/42"},
+							{Contents: "failed", Color: color.New(color.FgRed)},
+							{Contents: "some-origin"},
+							{Contents: time.Unix(1623938117, 0).Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "panic: some failure"},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args,
+					"-j", "some-pipeline/some-job",
+					"--status", "failed",
+					"--status", "errored",
+					"--oldest-first",
+					"-c", "5",
+					"--json",
+				)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/builds/search", "job_name=some-job&limit=5&oldest_first=true&pipeline_name=some-pipeline&q=some+failure&status=failed&status=errored"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, matches),
+					),
+				)
+			})
+
+			It("passes them along and prints the matches as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"build_id": 23,
+						"build_name": "42",
+						"build_status": "failed",
+						"job_name": "some-job",
+						"pipeline_name": "some-pipeline",
+						"team_name": "main",
+						"event_id": 7,
+						"origin_id": "some-origin",
+						"time": 1623938117,
+						"line": "panic: some failure\n"
+					}
+				]`))
+			})
+		})
+
+		Context("when both --pipeline and --job are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-p", "some-pipeline", "-j", "some-pipeline/some-job")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --pipeline and --job"))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-p", "some-pipeline")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/builds/search"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline not found"))
+			})
+		})
+	})
+})
@@ -0,0 +1,245 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("secret-usage", func() {
+		var (
+			flyCmd     *exec.Cmd
+			accessedAt time.Time
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "secret-usage")
+			accessedAt = time.Date(2021, 6, 26, 12, 30, 0, 0, time.UTC)
+		})
+
+		Context("when accesses are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets/accesses", "limit=50"),
+						ghttp.RespondWithJSONEncoded(200, []atc.SecretAccess{
+							{
+								ID:                   2,
+								TeamName:             "main",
+								PipelineName:         "some-pipeline",
+								PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
+								JobName:              "some-job",
+								BuildID:              42,
+								BuildName:            "3",
+								StepName:             "deploy",
+								Var:                  "prod-db-password",
+								Backend:              "vault",
+								SecretPath:           "/concourse/main/prod-db-password",
+								AccessedAt:           accessedAt.Unix(),
+							},
+							{
+								ID:         1,
+								TeamName:   "main",
+								BuildID:    41,
+								BuildName:  "41",
+								StepName:   "fetch",
+								Var:        "token",
+								VarSource:  "some-source",
+								Backend:    "vault",
+								SecretPath: "/secret/token",
+								AccessedAt: accessedAt.Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists them to the user", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "var", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "step", Color: color.New(color.Bold)},
+						{Contents: "backend", Color: color.New(color.Bold)},
+						{Contents: "path", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: accessedAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "prod-db-password"},
+							{Contents: "some-pipeline/branch:master/some-job/3"},
+							{Contents: "deploy"},
+							{Contents: "vault"},
+							{Contents: "/concourse/main/prod-db-password"},
+						},
+						{
+							{Contents: accessedAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "some-source:token"},
+							{Contents: "41"},
+							{Contents: "fetch"},
+							{Contents: "vault"},
+							{Contents: "/secret/token"},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args,
+					"--var", "prod-db-password",
+					"--pipeline", "some-pipeline",
+					"--job", "some-job",
+					"--since", "2021-06-01 00:00:00",
+					"--until", "2021-06-30 00:00:00",
+					"--count", "10",
+				)
+
+				since, err := time.ParseInLocation("2006-01-02 15:04:05", "2021-06-01 00:00:00", time.Local)
+				Expect(err).NotTo(HaveOccurred())
+
+				until, err := time.ParseInLocation("2006-01-02 15:04:05", "2021-06-30 00:00:00", time.Local)
+				Expect(err).NotTo(HaveOccurred())
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets/accesses",
+							"job_name=some-job&limit=10&pipeline_name=some-pipeline"+
+								"&since="+strconv.FormatInt(since.Unix(), 10)+
+								"&until="+strconv.FormatInt(until.Unix(), 10)+
+								"&var=prod-db-password"),
+						ghttp.RespondWithJSONEncoded(200, []atc.SecretAccess{}),
+					),
+				)
+			})
+
+			It("passes them to the API", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when --last is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--var", "prod-db-password", "--last", "720h")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets/accesses"),
+						func(w http.ResponseWriter, r *http.Request) {
+							since, err := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(time.Unix(since, 0)).To(BeTemporally("~", time.Now().Add(-720*time.Hour), time.Minute))
+						},
+						ghttp.RespondWithJSONEncoded(200, []atc.SecretAccess{}),
+					),
+				)
+			})
+
+			It("only asks for accesses within that long ago", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when both --last and --since are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--last", "720h", "--since", "2021-06-01 00:00:00")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --last and --since"))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets/accesses"),
+						ghttp.RespondWithJSONEncoded(200, []atc.SecretAccess{
+							{
+								ID:         1,
+								TeamName:   "main",
+								BuildID:    42,
+								BuildName:  "3",
+								StepName:   "deploy",
+								Var:        "prod-db-password",
+								Backend:    "vault",
+								SecretPath: "/concourse/main/prod-db-password",
+								AccessedAt: 1624710600,
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints response in json as stdout", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"id": 1,
+						"team_name": "main",
+						"build_id": 42,
+						"build_name": "3",
+						"step_name": "deploy",
+						"var": "prod-db-password",
+						"backend": "vault",
+						"secret_path": "/concourse/main/prod-db-password",
+						"accessed_at": 1624710600
+					}
+				]`))
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets/accesses"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
@@ -0,0 +1,264 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("sessions", func() {
+		var (
+			createdAt, lastUsedAt, expiresAt time.Time
+			sessions                         []atc.Session
+		)
+
+		BeforeEach(func() {
+			createdAt = time.Date(2021, 7, 2, 12, 30, 0, 0, time.UTC)
+			lastUsedAt = createdAt.Add(time.Hour)
+			expiresAt = createdAt.Add(24 * time.Hour)
+
+			sessions = []atc.Session{
+				{
+					ID:         2,
+					UserName:   "some-user",
+					Connector:  "github",
+					ClientID:   "fly",
+					RemoteAddr: "10.0.0.1:12345",
+					CreatedAt:  createdAt.Unix(),
+					LastUsedAt: lastUsedAt.Unix(),
+					ExpiresAt:  expiresAt.Unix(),
+				},
+				{
+					ID:        1,
+					UserName:  "some-user",
+					Connector: "github",
+					CreatedAt: createdAt.Unix(),
+					ExpiresAt: expiresAt.Unix(),
+				},
+			}
+		})
+
+		Context("when listing your own sessions", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/user/sessions"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, sessions),
+					),
+				)
+			})
+
+			It("lists them", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "sessions")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "user", Color: color.New(color.Bold)},
+						{Contents: "connector", Color: color.New(color.Bold)},
+						{Contents: "client", Color: color.New(color.Bold)},
+						{Contents: "address", Color: color.New(color.Bold)},
+						{Contents: "issued", Color: color.New(color.Bold)},
+						{Contents: "last used", Color: color.New(color.Bold)},
+						{Contents: "expires", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "2"},
+							{Contents: "some-user"},
+							{Contents: "github"},
+							{Contents: "fly"},
+							{Contents: "10.0.0.1:12345"},
+							{Contents: createdAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: lastUsedAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: expiresAt.Local().Format("2006-01-02@15:04:05-0700")},
+						},
+						{
+							{Contents: "1"},
+							{Contents: "some-user"},
+							{Contents: "github"},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: createdAt.Local().Format("2006-01-02@15:04:05-0700")},
+							{Contents: "never", Color: color.New(color.Faint)},
+							{Contents: expiresAt.Local().Format("2006-01-02@15:04:05-0700")},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when listing another user's sessions", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/sessions", "user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, sessions),
+					),
+				)
+			})
+
+			It("asks for their sessions", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "sessions", "--user", "some-user", "--json")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`"remote_addr": "10.0.0.1:12345"`))
+			})
+		})
+
+		Context("when listing every user's sessions without being an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/sessions", ""),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "sessions", "--all")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("forbidden"))
+			})
+		})
+	})
+
+	Describe("revoke-session", func() {
+		var (
+			args     []string
+			method   string
+			path     string
+			query    string
+			status   int
+			exitCode int
+		)
+
+		BeforeEach(func() {
+			status = http.StatusNoContent
+			exitCode = 0
+		})
+
+		JustBeforeEach(func() {
+			if path != "" {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(method, path, query),
+						ghttp.RespondWith(status, nil),
+					),
+				)
+			}
+		})
+
+		run := func() *gexec.Session {
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "revoke-session"}, args...)...)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(exitCode))
+			return sess
+		}
+
+		Context("when revoking one of your sessions", func() {
+			BeforeEach(func() {
+				args = []string{"--id", "2"}
+				method, path, query = "DELETE", "/api/v1/user/sessions/2", ""
+			})
+
+			It("revokes it", func() {
+				Expect(run().Out).To(gbytes.Say("session 2 revoked"))
+			})
+
+			Context("when it does not exist", func() {
+				BeforeEach(func() {
+					status = http.StatusNotFound
+					exitCode = 1
+				})
+
+				It("errors", func() {
+					Expect(run().Err).To(gbytes.Say("session 2 does not exist"))
+				})
+			})
+		})
+
+		Context("when revoking all of your sessions", func() {
+			BeforeEach(func() {
+				args = []string{"--all"}
+				method, path, query = "DELETE", "/api/v1/user/sessions", ""
+			})
+
+			It("tells you to log in again", func() {
+				Expect(run().Out).To(gbytes.Say("fly login"))
+			})
+		})
+
+		Context("when revoking another user's session", func() {
+			BeforeEach(func() {
+				args = []string{"--user", "some-user", "--id", "3"}
+				method, path, query = "DELETE", "/api/v1/sessions/3", ""
+			})
+
+			It("revokes it", func() {
+				Expect(run().Out).To(gbytes.Say("session 3 revoked"))
+			})
+		})
+
+		Context("when revoking all of another user's sessions", func() {
+			BeforeEach(func() {
+				args = []string{"--user", "some-user", "--connector", "github"}
+				method, path, query = "DELETE", "/api/v1/sessions", "connector=github&user=some-user"
+			})
+
+			It("revokes them", func() {
+				Expect(run().Out).To(gbytes.Say("sessions of user 'some-user' of connector 'github' revoked"))
+			})
+
+			Context("when no connector is given", func() {
+				BeforeEach(func() {
+					args = []string{"--user", "some-user"}
+					path = ""
+					exitCode = 1
+				})
+
+				It("errors", func() {
+					Expect(run().Err).To(gbytes.Say("--connector must be specified"))
+				})
+			})
+		})
+
+		Context("when nothing to revoke is given", func() {
+			BeforeEach(func() {
+				args = []string{}
+				path = ""
+				exitCode = 1
+			})
+
+			It("errors", func() {
+				Expect(run().Err).To(gbytes.Say("Either --id, --all or --user must be specified"))
+			})
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("set-pipeline-auth", func() {
+		var (
+			flyCmd    *exec.Cmd
+			cmdParams []string
+		)
+
+		BeforeEach(func() {
+			cmdParams = []string{}
+		})
+
+		JustBeforeEach(func() {
+			params := append([]string{"-t", targetName, "set-pipeline-auth", "-p", "some-pipeline"}, cmdParams...)
+			flyCmd = exec.Command(flyPath, params...)
+		})
+
+		yes := func(stdin io.Writer) {
+			fmt.Fprintf(stdin, "y\n")
+		}
+
+		no := func(stdin io.Writer) {
+			fmt.Fprintf(stdin, "n\n")
+		}
+
+		Context("using a config file", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_local_auth.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/auth"),
+						ghttp.VerifyJSON(`{
+							"member": {"users": ["local:some-member"], "groups": []},
+							"owner": {"users": ["local:some-owner"], "groups": []},
+							"viewer": {"users": ["local:some-viewer"], "groups": []}
+						}`),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("shows the roles and sends them once confirmed", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("setting role bindings for pipeline: some-pipeline"))
+				Eventually(sess.Out).Should(gbytes.Say("role member:"))
+				Eventually(sess.Out).Should(gbytes.Say("- local:some-member"))
+				Eventually(sess.Out).Should(gbytes.Say("role owner:"))
+				Eventually(sess.Out).Should(gbytes.Say("- local:some-owner"))
+				Eventually(sess.Out).Should(gbytes.Say("role viewer:"))
+				Eventually(sess.Out).Should(gbytes.Say("- local:some-viewer"))
+
+				Eventually(sess).Should(gbytes.Say(`apply pipeline role bindings\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("pipeline role bindings updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("using command line args", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"--local-user", "some-user", "--role", "viewer", "--non-interactive"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/auth"),
+						ghttp.VerifyJSON(`{
+							"viewer": {"users": ["local:some-user"], "groups": []}
+						}`),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("grants the users the given role", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("role viewer:"))
+				Eventually(sess.Out).Should(gbytes.Say("- local:some-user"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when no users or groups are given", func() {
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("auth config for the team does not have users and groups configured"))
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+
+		Context("when --remove is given", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"--remove", "--non-interactive"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/auth"),
+						ghttp.VerifyJSON(`{}`),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("removes the pipeline's role bindings", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("no roles"))
+				Eventually(sess.Out).Should(gbytes.Say("pipeline role bindings updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the user declines", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"--local-user", "some-user"}
+			})
+
+			It("bails out", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say(`apply pipeline role bindings\? \[yN\]: `))
+				no(stdin)
+
+				Eventually(sess.Err).Should(gbytes.Say("bailing out"))
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+
+		Context("when the user is not an owner", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"--local-user", "some-user", "--non-interactive"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/auth"),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
@@ -403,6 +403,67 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("notifications", func() {
+			Context("when the config file has notification rules", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"-c", "fixtures/team_config_with_notifications.yml"}
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+							ghttp.VerifyJSON(`{
+								"auth": {
+									"owner": {
+										"users": ["local:some-owner"],
+										"groups": []
+									}
+								},
+								"notifications": [
+									{"url": "https://example.com/all"},
+									{"url": "https://example.com/failures", "events": ["failed", "errored"]}
+								]
+							}`),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+								Name: "venture",
+								ID:   8,
+							}),
+						),
+					)
+				})
+
+				It("shows and sends the notification rules", func() {
+					stdin, err := flyCmd.StdinPipe()
+					Expect(err).NotTo(HaveOccurred())
+
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Out).Should(gbytes.Say("notifications:"))
+					Eventually(sess.Out).Should(gbytes.Say(`- https://example.com/all \(all events\)`))
+					Eventually(sess.Out).Should(gbytes.Say(`- https://example.com/failures \(failed, errored\)`))
+
+					Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+					yes(stdin)
+
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+
+			Context("when a notification rule is invalid", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"-c", "fixtures/team_config_with_invalid_notifications.yml"}
+				})
+
+				It("errors", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("notifications\\[0\\]: unknown event 'pending'"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
@@ -548,6 +609,27 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("Setting gitea auth", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"--gitea-org", "my-org", "--gitea-team", "my-org:my-team", "--gitea-user", "my-username"}
+				})
+
+				It("shows the users and groups configured for gitea auth", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Out).Should(gbytes.Say("setting team: venture"))
+					Eventually(sess.Out).Should(gbytes.Say("role owner:"))
+					Eventually(sess.Out).Should(gbytes.Say("users:"))
+					Eventually(sess.Out).Should(gbytes.Say("- gitea:my-username"))
+					Eventually(sess.Out).Should(gbytes.Say("groups:"))
+					Eventually(sess.Out).Should(gbytes.Say("- gitea:my-org"))
+					Eventually(sess.Out).Should(gbytes.Say("- gitea:my-org:my-team"))
+
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+
 			Context("Setting ldap auth", func() {
 				BeforeEach(func() {
 					cmdParams = []string{"--ldap-group", "my-group", "--ldap-user", "my-username"}
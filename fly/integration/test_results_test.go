@@ -0,0 +1,135 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("test-results", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			expectedBuild = atc.Build{
+				ID:      23,
+				Name:    "42",
+				Status:  "failed",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/23",
+			}
+
+			suites = []atc.TestSuite{
+				{
+					ID:       1,
+					BuildID:  23,
+					StepName: "unit",
+					Name:     "some-suite",
+					Duration: 1.5,
+					Cases: []atc.TestCase{
+						{Name: "passes", ClassName: "some.Class", Duration: 0.25, Status: atc.TestPassed},
+						{Name: "fails", Duration: 1.25, Status: atc.TestFailed, FailureMessage: "expected true to be false"},
+						{Name: "skips", Status: atc.TestSkipped},
+					},
+				},
+			}
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "test-results", "-b", "23")
+		})
+
+		Context("when the build exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/tests"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, suites),
+					),
+				)
+			})
+
+			It("lists the test results and failure messages", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "step", Color: color.New(color.Bold)},
+						{Contents: "suite", Color: color.New(color.Bold)},
+						{Contents: "test", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "duration", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "unit"}, {Contents: "some-suite"}, {Contents: "some.Class.passes"}, {Contents: "passed", Color: color.New(color.FgGreen)}, {Contents: "0.250s"}},
+						{{Contents: "unit"}, {Contents: "some-suite"}, {Contents: "fails"}, {Contents: "failed", Color: color.New(color.FgRed)}, {Contents: "1.250s"}},
+						{{Contents: "unit"}, {Contents: "some-suite"}, {Contents: "skips"}, {Contents: "skipped", Color: color.New(color.FgWhite)}, {Contents: "0.000s"}},
+					},
+				}))
+
+				Expect(sess.Out).To(gbytes.Say("--- unit/some-suite/fails"))
+				Expect(sess.Out).To(gbytes.Say("expected true to be false"))
+				Expect(sess.Out).To(gbytes.Say("3 tests, 1 passed, 1 failed, 0 errored, 1 skipped"))
+			})
+
+			Context("when --failed and --json are given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--failed", "--json")
+				})
+
+				It("prints only the failed tests as JSON", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"id": 1,
+							"build_id": 23,
+							"step_name": "unit",
+							"name": "some-suite",
+							"duration": 1.5,
+							"cases": [
+								{"name": "fails", "duration": 1.25, "status": "failed", "failure_message": "expected true to be false"}
+							]
+						}
+					]`))
+				})
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("build does not exist"))
+			})
+		})
+	})
+})
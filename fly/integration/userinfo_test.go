@@ -91,6 +91,43 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when the user has pipeline role bindings", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/user"),
+						ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+							"user_name": "test_user",
+							"teams": map[string][]string{
+								"test_team": {"viewer"},
+							},
+							"pipeline_roles": map[string]map[string][]string{
+								"other_team": {"some-pipeline": {"member"}},
+								"test_team":  {"other-pipeline": {"owner"}},
+							},
+							"display_user_id": "test_id",
+						}),
+					),
+				)
+			})
+
+			It("shows them alongside the team roles", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "username", Color: color.New(color.Bold)},
+						{Contents: "team/role", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "test_id"}, {Contents: "other_team/some-pipeline/member,test_team/other-pipeline/owner,test_team/viewer"}},
+					},
+				}))
+			})
+		})
+
 		Context("and the api returns an internal server error", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -20,6 +20,7 @@ func main() {
 	helpParser.NamespaceDelimiter = "-"
 
 	commands.WireTeamConnectors(parser.Find("set-team"))
+	commands.WireTeamConnectors(parser.Find("set-pipeline-auth"))
 
 	_, err := parser.Parse()
 	err = loginAndRetry(parser, err)
@@ -0,0 +1,110 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// APITokens lists the personal API tokens of the current user.
+func (client *client) APITokens() ([]atc.APIToken, error) {
+	var tokens []atc.APIToken
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListAPITokens,
+	}, &internal.Response{
+		Result: &tokens,
+	})
+
+	return tokens, err
+}
+
+// CreateAPIToken creates a personal API token which acts as the current
+// user. The raw token is only returned from this call.
+func (client *client) CreateAPIToken(token atc.APIToken) (atc.APIToken, error) {
+	jsonBytes, err := json.Marshal(token)
+	if err != nil {
+		return atc.APIToken{}, err
+	}
+
+	var created atc.APIToken
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.CreateAPIToken,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &created,
+	})
+
+	return created, err
+}
+
+func (client *client) RevokeAPIToken(id int) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RevokeAPIToken,
+		Params:      rata.Params{"token_id": strconv.Itoa(id)},
+	}, nil)
+
+	return revokeResult(err)
+}
+
+// APITokens lists the team's API tokens.
+func (team *team) APITokens() ([]atc.APIToken, error) {
+	var tokens []atc.APIToken
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListTeamAPITokens,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &tokens,
+	})
+
+	return tokens, err
+}
+
+// CreateAPIToken creates an API token which is granted the given role on the
+// team. The raw token is only returned from this call.
+func (team *team) CreateAPIToken(token atc.APIToken) (atc.APIToken, error) {
+	jsonBytes, err := json.Marshal(token)
+	if err != nil {
+		return atc.APIToken{}, err
+	}
+
+	var created atc.APIToken
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateTeamAPIToken,
+		Params:      rata.Params{"team_name": team.Name()},
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &created,
+	})
+
+	return created, err
+}
+
+func (team *team) RevokeAPIToken(id int) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.RevokeTeamAPIToken,
+		Params: rata.Params{
+			"team_name": team.Name(),
+			"token_id":  strconv.Itoa(id),
+		},
+	}, nil)
+
+	return revokeResult(err)
+}
+
+func revokeResult(err error) (bool, error) {
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
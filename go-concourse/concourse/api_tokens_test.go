@@ -0,0 +1,175 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler API Tokens", func() {
+	Describe("APITokens", func() {
+		var expectedTokens []atc.APIToken
+
+		BeforeEach(func() {
+			expectedTokens = []atc.APIToken{
+				{
+					ID:        1,
+					Name:      "some-token",
+					Scopes:    []string{"read-only"},
+					CreatedBy: "some-user",
+					CreatedAt: 100,
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/tokens"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedTokens),
+				),
+			)
+		})
+
+		It("returns the user's tokens", func() {
+			tokens, err := client.APITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal(expectedTokens))
+		})
+	})
+
+	Describe("CreateAPIToken", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/tokens"),
+					ghttp.VerifyJSONRepresenting(atc.APIToken{
+						Name:      "some-token",
+						Scopes:    []string{"trigger-job:pipeline/deploy"},
+						ExpiresAt: 200,
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.APIToken{
+						ID:        1,
+						Name:      "some-token",
+						Scopes:    []string{"trigger-job:pipeline/deploy"},
+						ExpiresAt: 200,
+						Token:     "cct_some-token",
+					}),
+				),
+			)
+		})
+
+		It("returns the token", func() {
+			token, err := client.CreateAPIToken(atc.APIToken{
+				Name:      "some-token",
+				Scopes:    []string{"trigger-job:pipeline/deploy"},
+				ExpiresAt: 200,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.ID).To(Equal(1))
+			Expect(token.Token).To(Equal("cct_some-token"))
+		})
+	})
+
+	Describe("RevokeAPIToken", func() {
+		var status int
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/tokens/1"),
+					ghttp.RespondWith(status, nil),
+				),
+			)
+		})
+
+		Context("when the token is revoked", func() {
+			BeforeEach(func() {
+				status = http.StatusNoContent
+			})
+
+			It("returns true", func() {
+				revoked, err := client.RevokeAPIToken(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked).To(BeTrue())
+			})
+		})
+
+		Context("when the token does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("returns false", func() {
+				revoked, err := client.RevokeAPIToken(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked).To(BeFalse())
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				status = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				_, err := client.RevokeAPIToken(1)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("team tokens", func() {
+		It("lists the team's tokens", func() {
+			expectedTokens := []atc.APIToken{
+				{ID: 2, Name: "ci", TeamName: "some-team", Role: "member"},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/tokens"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedTokens),
+				),
+			)
+
+			tokens, err := team.APITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal(expectedTokens))
+		})
+
+		It("creates a token on the team", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/tokens"),
+					ghttp.VerifyJSONRepresenting(atc.APIToken{Name: "ci", Role: "member"}),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.APIToken{
+						ID:       2,
+						Name:     "ci",
+						TeamName: "some-team",
+						Role:     "member",
+						Token:    "cct_some-token",
+					}),
+				),
+			)
+
+			token, err := team.CreateAPIToken(atc.APIToken{Name: "ci", Role: "member"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Token).To(Equal("cct_some-token"))
+		})
+
+		It("revokes a token on the team", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/tokens/2"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+
+			revoked, err := team.RevokeAPIToken(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+	})
+})
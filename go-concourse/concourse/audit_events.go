@@ -0,0 +1,69 @@
+package concourse
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+// AuditEventFilter narrows down the events returned by AuditEvents. Zero
+// values are not filtered on.
+type AuditEventFilter struct {
+	UserName string
+	Action   string
+	TeamName string
+
+	Since time.Time
+	Until time.Time
+}
+
+func (filter AuditEventFilter) queryParams(query url.Values) url.Values {
+	if filter.UserName != "" {
+		query.Set("user", filter.UserName)
+	}
+
+	if filter.Action != "" {
+		query.Set("action", filter.Action)
+	}
+
+	if filter.TeamName != "" {
+		query.Set("team", filter.TeamName)
+	}
+
+	if !filter.Since.IsZero() {
+		query.Set("since", strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		query.Set("until", strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	return query
+}
+
+func (client *client) AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	var events []atc.AuditEvent
+
+	headers := http.Header{}
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListAuditEvents,
+		Query:       filter.queryParams(page.QueryParams()),
+	}, &internal.Response{
+		Result:  &events,
+		Headers: &headers,
+	})
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	pagination, err := paginationFromHeaders(headers)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
@@ -0,0 +1,100 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Audit Events", func() {
+	Describe("AuditEvents", func() {
+		expectedURL := "/api/v1/audit"
+
+		Context("when no filters are given", func() {
+			var expectedEvents []atc.AuditEvent
+
+			BeforeEach(func() {
+				expectedEvents = []atc.AuditEvent{
+					{
+						ID:        42,
+						Action:    atc.SaveConfig,
+						UserName:  "some-user",
+						Connector: "github",
+						TeamName:  "some-team",
+						Target:    map[string]string{"pipeline_name": "some-pipeline"},
+						Method:    "PUT",
+						Path:      "/api/v1/teams/some-team/pipelines/some-pipeline/config",
+						Status:    200,
+						CreatedAt: 100,
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				events, pagination, err := client.AuditEvents(concourse.AuditEventFilter{}, concourse.Page{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+				Expect(pagination).To(Equal(concourse.Pagination{}))
+			})
+		})
+
+		Context("when filters and a page are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL,
+							"action=SaveConfig&limit=5&since=100&team=some-team&to=42&until=200&user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.AuditEvent{}, http.Header{
+							"Link": []string{
+								`<http://some-url.com/api/v1/audit?from=43&limit=5>; rel="previous"`,
+								`<http://some-url.com/api/v1/audit?to=37&limit=5>; rel="next"`,
+							},
+						}),
+					),
+				)
+			})
+
+			It("sends them as query params and returns the pagination", func() {
+				_, pagination, err := client.AuditEvents(concourse.AuditEventFilter{
+					UserName: "some-user",
+					Action:   atc.SaveConfig,
+					TeamName: "some-team",
+					Since:    time.Unix(100, 0),
+					Until:    time.Unix(200, 0),
+				}, concourse.Page{To: 42, Limit: 5})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pagination.Previous).To(Equal(&concourse.Page{From: 43, Limit: 5}))
+				Expect(pagination.Next).To(Equal(&concourse.Page{To: 37, Limit: 5}))
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, _, err := client.AuditEvents(concourse.AuditEventFilter{}, concourse.Page{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// BuildLogSearch is the text to search the team's build logs for, along with
+// optional filters on the builds searched.
+type BuildLogSearch struct {
+	Query string
+
+	Pipeline atc.PipelineRef
+	JobName  string
+	Statuses []atc.BuildStatus
+
+	Since time.Time
+	Until time.Time
+
+	Limit       int
+	OldestFirst bool
+}
+
+func (search BuildLogSearch) queryParams() url.Values {
+	query := url.Values{}
+	if search.Pipeline.Name != "" {
+		query = search.Pipeline.QueryParams()
+		if query == nil {
+			query = url.Values{}
+		}
+
+		query.Set("pipeline_name", search.Pipeline.Name)
+	}
+
+	query.Set("q", search.Query)
+
+	if search.JobName != "" {
+		query.Set("job_name", search.JobName)
+	}
+
+	for _, status := range search.Statuses {
+		query.Add("status", string(status))
+	}
+
+	if !search.Since.IsZero() {
+		query.Set("since", strconv.FormatInt(search.Since.Unix(), 10))
+	}
+
+	if !search.Until.IsZero() {
+		query.Set("until", strconv.FormatInt(search.Until.Unix(), 10))
+	}
+
+	if search.Limit > 0 {
+		query.Set("limit", strconv.Itoa(search.Limit))
+	}
+
+	if search.OldestFirst {
+		query.Set("oldest_first", "true")
+	}
+
+	return query
+}
+
+// SearchBuildLogs returns false if the pipeline to search was not found.
+func (team *team) SearchBuildLogs(search BuildLogSearch) ([]atc.BuildLogMatch, bool, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	var matches []atc.BuildLogMatch
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SearchBuildLogs,
+		Params:      params,
+		Query:       search.queryParams(),
+	}, &internal.Response{
+		Result: &matches,
+	})
+
+	switch err.(type) {
+	case nil:
+		return matches, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
@@ -0,0 +1,104 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Log Search", func() {
+	Describe("SearchBuildLogs", func() {
+		expectedURL := "/api/v1/teams/some-team/builds/search"
+
+		Context("when only a query is given", func() {
+			var expectedMatches []atc.BuildLogMatch
+
+			BeforeEach(func() {
+				expectedMatches = []atc.BuildLogMatch{
+					{
+						BuildID:     42,
+						BuildName:   "7",
+						BuildStatus: atc.StatusFailed,
+						JobName:     "some-job",
+						TeamName:    "some-team",
+						EventID:     3,
+						OriginID:    "some-plan-id",
+						Time:        100,
+						Line:        "error: connection refused",
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "q=connection+refused"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedMatches),
+					),
+				)
+			})
+
+			It("returns the matches", func() {
+				matches, found, err := team.SearchBuildLogs(concourse.BuildLogSearch{Query: "connection refused"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(matches).To(Equal(expectedMatches))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL,
+							"job_name=some-job&limit=5&oldest_first=true&pipeline_name=some-pipeline"+
+								"&q=boom&since=100&status=failed&status=errored&until=200&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.BuildLogMatch{}),
+					),
+				)
+			})
+
+			It("passes them as query params", func() {
+				_, found, err := team.SearchBuildLogs(concourse.BuildLogSearch{
+					Query: "boom",
+					Pipeline: atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "master"},
+					},
+					JobName:     "some-job",
+					Statuses:    []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+					Since:       time.Unix(100, 0),
+					Until:       time.Unix(200, 0),
+					Limit:       5,
+					OldestFirst: true,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.SearchBuildLogs(concourse.BuildLogSearch{
+					Query:    "boom",
+					Pipeline: atc.PipelineRef{Name: "missing-pipeline"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
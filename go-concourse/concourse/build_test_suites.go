@@ -0,0 +1,30 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildTestSuites(buildID string) ([]atc.TestSuite, bool, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var suites []atc.TestSuite
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildTestSuites,
+		Params:      params,
+	}, &internal.Response{
+		Result: &suites,
+	})
+
+	switch err.(type) {
+	case nil:
+		return suites, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
@@ -0,0 +1,64 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Test Suites", func() {
+	Describe("BuildTestSuites", func() {
+		expectedURL := "/api/v1/builds/1234/tests"
+
+		Context("when the build exists", func() {
+			expectedSuites := []atc.TestSuite{
+				{
+					ID:       1,
+					BuildID:  1234,
+					StepName: "unit",
+					Name:     "some-suite",
+					Duration: 1.5,
+					Cases: []atc.TestCase{
+						{Name: "fails", Duration: 1.5, Status: atc.TestFailed, FailureMessage: "nope"},
+					},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSuites),
+					),
+				)
+			})
+
+			It("returns the build's test suites", func() {
+				suites, found, err := client.BuildTestSuites("1234")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(suites).To(Equal(expectedSuites))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildTestSuites("1234")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
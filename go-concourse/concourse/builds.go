@@ -3,6 +3,7 @@ package concourse
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -195,6 +196,47 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+var ErrApprovalAlreadyDecided = errors.New("approval has already been decided or the build is no longer running")
+
+func (client *client) ApproveBuild(buildID string, stepName string, body atc.ApproveBuildBody) (atc.BuildApproval, bool, error) {
+	params := rata.Params{
+		"build_id":  buildID,
+		"step_name": stepName,
+	}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(body)
+	if err != nil {
+		return atc.BuildApproval{}, false, fmt.Errorf("Unable to marshal approval: %s", err)
+	}
+
+	var approval atc.BuildApproval
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.ApproveBuild,
+		Params:      params,
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &approval,
+	})
+
+	switch e := err.(type) {
+	case nil:
+		return approval, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.BuildApproval{}, false, nil
+	case internal.UnexpectedResponseError:
+		if e.StatusCode == http.StatusConflict {
+			return atc.BuildApproval{}, true, ErrApprovalAlreadyDecided
+		}
+		return atc.BuildApproval{}, false, err
+	default:
+		return atc.BuildApproval{}, false, err
+	}
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
@@ -468,6 +468,83 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("ApproveBuild", func() {
+		var (
+			expectedURL = "/api/v1/builds/123/approvals/deploy"
+
+			approval atc.BuildApproval
+			found    bool
+			err      error
+		)
+
+		JustBeforeEach(func() {
+			approval, found, err = client.ApproveBuild("123", "deploy", atc.ApproveBuildBody{
+				Approved: true,
+				Comment:  "ship it",
+			})
+		})
+
+		Context("when the approval is saved", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.VerifyJSONRepresenting(atc.ApproveBuildBody{Approved: true, Comment: "ship it"}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildApproval{
+							Name:     "deploy",
+							Approved: true,
+							Approver: "some-user",
+							Comment:  "ship it",
+						}),
+					),
+				)
+			})
+
+			It("returns the approval", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(approval).To(Equal(atc.BuildApproval{
+					Name:     "deploy",
+					Approved: true,
+					Approver: "some-user",
+					Comment:  "ship it",
+				}))
+			})
+		})
+
+		Context("when the step is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the step has already been decided", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(Equal(concourse.ErrApprovalAlreadyDecided))
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
@@ -21,7 +21,9 @@ type Client interface {
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
 	AbortBuild(buildID string) error
+	ApproveBuild(buildID string, stepName string, body atc.ApproveBuildBody) (atc.BuildApproval, bool, error)
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildTestSuites(buildID string) ([]atc.TestSuite, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
@@ -35,6 +37,18 @@ type Client interface {
 	Team(teamName string) Team
 	UserInfo() (atc.UserInfo, error)
 	ListActiveUsersSince(since time.Time) ([]atc.User, error)
+	AuditEvents(AuditEventFilter, Page) ([]atc.AuditEvent, Pagination, error)
+
+	APITokens() ([]atc.APIToken, error)
+	CreateAPIToken(atc.APIToken) (atc.APIToken, error)
+	RevokeAPIToken(id int) (bool, error)
+
+	UserSessions() ([]atc.Session, error)
+	RevokeUserSession(id int) (bool, error)
+	RevokeUserSessions() (bool, error)
+	Sessions(userName string, connector string) ([]atc.Session, error)
+	RevokeSession(id int) (bool, error)
+	RevokeSessions(userName string, connector string) (bool, error)
 }
 
 type client struct {
@@ -12,6 +12,18 @@ import (
 )
 
 type FakeClient struct {
+	APITokensStub        func() ([]atc.APIToken, error)
+	aPITokensMutex       sync.RWMutex
+	aPITokensArgsForCall []struct {
+	}
+	aPITokensReturns struct {
+		result1 []atc.APIToken
+		result2 error
+	}
+	aPITokensReturnsOnCall map[int]struct {
+		result1 []atc.APIToken
+		result2 error
+	}
 	AbortBuildStub        func(string) error
 	abortBuildMutex       sync.RWMutex
 	abortBuildArgsForCall []struct {
@@ -23,6 +35,39 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(string, string, atc.ApproveBuildBody) (atc.BuildApproval, bool, error)
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.ApproveBuildBody
+	}
+	approveBuildReturns struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	AuditEventsStub        func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)
+	auditEventsMutex       sync.RWMutex
+	auditEventsArgsForCall []struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}
+	auditEventsReturns struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
+	auditEventsReturnsOnCall map[int]struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -81,6 +126,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildTestSuitesStub        func(string) ([]atc.TestSuite, bool, error)
+	buildTestSuitesMutex       sync.RWMutex
+	buildTestSuitesArgsForCall []struct {
+		arg1 string
+	}
+	buildTestSuitesReturns struct {
+		result1 []atc.TestSuite
+		result2 bool
+		result3 error
+	}
+	buildTestSuitesReturnsOnCall map[int]struct {
+		result1 []atc.TestSuite
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -96,6 +156,19 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	CreateAPITokenStub        func(atc.APIToken) (atc.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		arg1 atc.APIToken
+	}
+	createAPITokenReturns struct {
+		result1 atc.APIToken
+		result2 error
+	}
+	createAPITokenReturnsOnCall map[int]struct {
+		result1 atc.APIToken
+		result2 error
+	}
 	FindTeamStub        func(string) (concourse.Team, error)
 	findTeamMutex       sync.RWMutex
 	findTeamArgsForCall []struct {
@@ -243,6 +316,71 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RevokeAPITokenStub        func(int) (bool, error)
+	revokeAPITokenMutex       sync.RWMutex
+	revokeAPITokenArgsForCall []struct {
+		arg1 int
+	}
+	revokeAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeSessionStub        func(int) (bool, error)
+	revokeSessionMutex       sync.RWMutex
+	revokeSessionArgsForCall []struct {
+		arg1 int
+	}
+	revokeSessionReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeSessionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeSessionsStub        func(string, string) (bool, error)
+	revokeSessionsMutex       sync.RWMutex
+	revokeSessionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	revokeSessionsReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeSessionsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeUserSessionStub        func(int) (bool, error)
+	revokeUserSessionMutex       sync.RWMutex
+	revokeUserSessionArgsForCall []struct {
+		arg1 int
+	}
+	revokeUserSessionReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeUserSessionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeUserSessionsStub        func() (bool, error)
+	revokeUserSessionsMutex       sync.RWMutex
+	revokeUserSessionsArgsForCall []struct {
+	}
+	revokeUserSessionsReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeUserSessionsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -257,6 +395,20 @@ type FakeClient struct {
 		result1 *atc.Worker
 		result2 error
 	}
+	SessionsStub        func(string, string) ([]atc.Session, error)
+	sessionsMutex       sync.RWMutex
+	sessionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	sessionsReturns struct {
+		result1 []atc.Session
+		result2 error
+	}
+	sessionsReturnsOnCall map[int]struct {
+		result1 []atc.Session
+		result2 error
+	}
 	TeamStub        func(string) concourse.Team
 	teamMutex       sync.RWMutex
 	teamArgsForCall []struct {
@@ -290,10 +442,78 @@ type FakeClient struct {
 		result1 atc.UserInfo
 		result2 error
 	}
+	UserSessionsStub        func() ([]atc.Session, error)
+	userSessionsMutex       sync.RWMutex
+	userSessionsArgsForCall []struct {
+	}
+	userSessionsReturns struct {
+		result1 []atc.Session
+		result2 error
+	}
+	userSessionsReturnsOnCall map[int]struct {
+		result1 []atc.Session
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) APITokens() ([]atc.APIToken, error) {
+	fake.aPITokensMutex.Lock()
+	ret, specificReturn := fake.aPITokensReturnsOnCall[len(fake.aPITokensArgsForCall)]
+	fake.aPITokensArgsForCall = append(fake.aPITokensArgsForCall, struct {
+	}{})
+	stub := fake.APITokensStub
+	fakeReturns := fake.aPITokensReturns
+	fake.recordInvocation("APITokens", []interface{}{})
+	fake.aPITokensMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) APITokensCallCount() int {
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
+	return len(fake.aPITokensArgsForCall)
+}
+
+func (fake *FakeClient) APITokensCalls(stub func() ([]atc.APIToken, error)) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = stub
+}
+
+func (fake *FakeClient) APITokensReturns(result1 []atc.APIToken, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	fake.aPITokensReturns = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) APITokensReturnsOnCall(i int, result1 []atc.APIToken, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	if fake.aPITokensReturnsOnCall == nil {
+		fake.aPITokensReturnsOnCall = make(map[int]struct {
+			result1 []atc.APIToken
+			result2 error
+		})
+	}
+	fake.aPITokensReturnsOnCall[i] = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) AbortBuild(arg1 string) error {
 	fake.abortBuildMutex.Lock()
 	ret, specificReturn := fake.abortBuildReturnsOnCall[len(fake.abortBuildArgsForCall)]
@@ -355,6 +575,143 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 string, arg2 string, arg3 atc.ApproveBuildBody) (atc.BuildApproval, bool, error) {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.ApproveBuildBody
+	}{arg1, arg2, arg3})
+	stub := fake.ApproveBuildStub
+	fakeReturns := fake.approveBuildReturns
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1, arg2, arg3})
+	fake.approveBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(string, string, atc.ApproveBuildBody) (atc.BuildApproval, bool, error)) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) (string, string, atc.ApproveBuildBody) {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 atc.BuildApproval, result2 bool, result3 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 atc.BuildApproval, result2 bool, result3 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) AuditEvents(arg1 concourse.AuditEventFilter, arg2 concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error) {
+	fake.auditEventsMutex.Lock()
+	ret, specificReturn := fake.auditEventsReturnsOnCall[len(fake.auditEventsArgsForCall)]
+	fake.auditEventsArgsForCall = append(fake.auditEventsArgsForCall, struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}{arg1, arg2})
+	stub := fake.AuditEventsStub
+	fakeReturns := fake.auditEventsReturns
+	fake.recordInvocation("AuditEvents", []interface{}{arg1, arg2})
+	fake.auditEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) AuditEventsCallCount() int {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	return len(fake.auditEventsArgsForCall)
+}
+
+func (fake *FakeClient) AuditEventsCalls(stub func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = stub
+}
+
+func (fake *FakeClient) AuditEventsArgsForCall(i int) (concourse.AuditEventFilter, concourse.Page) {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	argsForCall := fake.auditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) AuditEventsReturns(result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	fake.auditEventsReturns = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) AuditEventsReturnsOnCall(i int, result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	if fake.auditEventsReturnsOnCall == nil {
+		fake.auditEventsReturnsOnCall = make(map[int]struct {
+			result1 []atc.AuditEvent
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.auditEventsReturnsOnCall[i] = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -620,6 +977,73 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildTestSuites(arg1 string) ([]atc.TestSuite, bool, error) {
+	fake.buildTestSuitesMutex.Lock()
+	ret, specificReturn := fake.buildTestSuitesReturnsOnCall[len(fake.buildTestSuitesArgsForCall)]
+	fake.buildTestSuitesArgsForCall = append(fake.buildTestSuitesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BuildTestSuitesStub
+	fakeReturns := fake.buildTestSuitesReturns
+	fake.recordInvocation("BuildTestSuites", []interface{}{arg1})
+	fake.buildTestSuitesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildTestSuitesCallCount() int {
+	fake.buildTestSuitesMutex.RLock()
+	defer fake.buildTestSuitesMutex.RUnlock()
+	return len(fake.buildTestSuitesArgsForCall)
+}
+
+func (fake *FakeClient) BuildTestSuitesCalls(stub func(string) ([]atc.TestSuite, bool, error)) {
+	fake.buildTestSuitesMutex.Lock()
+	defer fake.buildTestSuitesMutex.Unlock()
+	fake.BuildTestSuitesStub = stub
+}
+
+func (fake *FakeClient) BuildTestSuitesArgsForCall(i int) string {
+	fake.buildTestSuitesMutex.RLock()
+	defer fake.buildTestSuitesMutex.RUnlock()
+	argsForCall := fake.buildTestSuitesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTestSuitesReturns(result1 []atc.TestSuite, result2 bool, result3 error) {
+	fake.buildTestSuitesMutex.Lock()
+	defer fake.buildTestSuitesMutex.Unlock()
+	fake.BuildTestSuitesStub = nil
+	fake.buildTestSuitesReturns = struct {
+		result1 []atc.TestSuite
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTestSuitesReturnsOnCall(i int, result1 []atc.TestSuite, result2 bool, result3 error) {
+	fake.buildTestSuitesMutex.Lock()
+	defer fake.buildTestSuitesMutex.Unlock()
+	fake.BuildTestSuitesStub = nil
+	if fake.buildTestSuitesReturnsOnCall == nil {
+		fake.buildTestSuitesReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestSuite
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildTestSuitesReturnsOnCall[i] = struct {
+		result1 []atc.TestSuite
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -687,6 +1111,70 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) CreateAPIToken(arg1 atc.APIToken) (atc.APIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	ret, specificReturn := fake.createAPITokenReturnsOnCall[len(fake.createAPITokenArgsForCall)]
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		arg1 atc.APIToken
+	}{arg1})
+	stub := fake.CreateAPITokenStub
+	fakeReturns := fake.createAPITokenReturns
+	fake.recordInvocation("CreateAPIToken", []interface{}{arg1})
+	fake.createAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeClient) CreateAPITokenCalls(stub func(atc.APIToken) (atc.APIToken, error)) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = stub
+}
+
+func (fake *FakeClient) CreateAPITokenArgsForCall(i int) atc.APIToken {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	argsForCall := fake.createAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CreateAPITokenReturns(result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateAPITokenReturnsOnCall(i int, result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	if fake.createAPITokenReturnsOnCall == nil {
+		fake.createAPITokenReturnsOnCall = make(map[int]struct {
+			result1 atc.APIToken
+			result2 error
+		})
+	}
+	fake.createAPITokenReturnsOnCall[i] = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) FindTeam(arg1 string) (concourse.Team, error) {
 	fake.findTeamMutex.Lock()
 	ret, specificReturn := fake.findTeamReturnsOnCall[len(fake.findTeamArgsForCall)]
@@ -1402,6 +1890,319 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RevokeAPIToken(arg1 int) (bool, error) {
+	fake.revokeAPITokenMutex.Lock()
+	ret, specificReturn := fake.revokeAPITokenReturnsOnCall[len(fake.revokeAPITokenArgsForCall)]
+	fake.revokeAPITokenArgsForCall = append(fake.revokeAPITokenArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeAPITokenStub
+	fakeReturns := fake.revokeAPITokenReturns
+	fake.recordInvocation("RevokeAPIToken", []interface{}{arg1})
+	fake.revokeAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RevokeAPITokenCallCount() int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	return len(fake.revokeAPITokenArgsForCall)
+}
+
+func (fake *FakeClient) RevokeAPITokenCalls(stub func(int) (bool, error)) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = stub
+}
+
+func (fake *FakeClient) RevokeAPITokenArgsForCall(i int) int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	argsForCall := fake.revokeAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RevokeAPITokenReturns(result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	fake.revokeAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	if fake.revokeAPITokenReturnsOnCall == nil {
+		fake.revokeAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeSession(arg1 int) (bool, error) {
+	fake.revokeSessionMutex.Lock()
+	ret, specificReturn := fake.revokeSessionReturnsOnCall[len(fake.revokeSessionArgsForCall)]
+	fake.revokeSessionArgsForCall = append(fake.revokeSessionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeSessionStub
+	fakeReturns := fake.revokeSessionReturns
+	fake.recordInvocation("RevokeSession", []interface{}{arg1})
+	fake.revokeSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RevokeSessionCallCount() int {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	return len(fake.revokeSessionArgsForCall)
+}
+
+func (fake *FakeClient) RevokeSessionCalls(stub func(int) (bool, error)) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = stub
+}
+
+func (fake *FakeClient) RevokeSessionArgsForCall(i int) int {
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	argsForCall := fake.revokeSessionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RevokeSessionReturns(result1 bool, result2 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	fake.revokeSessionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeSessionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeSessionMutex.Lock()
+	defer fake.revokeSessionMutex.Unlock()
+	fake.RevokeSessionStub = nil
+	if fake.revokeSessionReturnsOnCall == nil {
+		fake.revokeSessionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeSessionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeSessions(arg1 string, arg2 string) (bool, error) {
+	fake.revokeSessionsMutex.Lock()
+	ret, specificReturn := fake.revokeSessionsReturnsOnCall[len(fake.revokeSessionsArgsForCall)]
+	fake.revokeSessionsArgsForCall = append(fake.revokeSessionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeSessionsStub
+	fakeReturns := fake.revokeSessionsReturns
+	fake.recordInvocation("RevokeSessions", []interface{}{arg1, arg2})
+	fake.revokeSessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RevokeSessionsCallCount() int {
+	fake.revokeSessionsMutex.RLock()
+	defer fake.revokeSessionsMutex.RUnlock()
+	return len(fake.revokeSessionsArgsForCall)
+}
+
+func (fake *FakeClient) RevokeSessionsCalls(stub func(string, string) (bool, error)) {
+	fake.revokeSessionsMutex.Lock()
+	defer fake.revokeSessionsMutex.Unlock()
+	fake.RevokeSessionsStub = stub
+}
+
+func (fake *FakeClient) RevokeSessionsArgsForCall(i int) (string, string) {
+	fake.revokeSessionsMutex.RLock()
+	defer fake.revokeSessionsMutex.RUnlock()
+	argsForCall := fake.revokeSessionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RevokeSessionsReturns(result1 bool, result2 error) {
+	fake.revokeSessionsMutex.Lock()
+	defer fake.revokeSessionsMutex.Unlock()
+	fake.RevokeSessionsStub = nil
+	fake.revokeSessionsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeSessionsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeSessionsMutex.Lock()
+	defer fake.revokeSessionsMutex.Unlock()
+	fake.RevokeSessionsStub = nil
+	if fake.revokeSessionsReturnsOnCall == nil {
+		fake.revokeSessionsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeSessionsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeUserSession(arg1 int) (bool, error) {
+	fake.revokeUserSessionMutex.Lock()
+	ret, specificReturn := fake.revokeUserSessionReturnsOnCall[len(fake.revokeUserSessionArgsForCall)]
+	fake.revokeUserSessionArgsForCall = append(fake.revokeUserSessionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeUserSessionStub
+	fakeReturns := fake.revokeUserSessionReturns
+	fake.recordInvocation("RevokeUserSession", []interface{}{arg1})
+	fake.revokeUserSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RevokeUserSessionCallCount() int {
+	fake.revokeUserSessionMutex.RLock()
+	defer fake.revokeUserSessionMutex.RUnlock()
+	return len(fake.revokeUserSessionArgsForCall)
+}
+
+func (fake *FakeClient) RevokeUserSessionCalls(stub func(int) (bool, error)) {
+	fake.revokeUserSessionMutex.Lock()
+	defer fake.revokeUserSessionMutex.Unlock()
+	fake.RevokeUserSessionStub = stub
+}
+
+func (fake *FakeClient) RevokeUserSessionArgsForCall(i int) int {
+	fake.revokeUserSessionMutex.RLock()
+	defer fake.revokeUserSessionMutex.RUnlock()
+	argsForCall := fake.revokeUserSessionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RevokeUserSessionReturns(result1 bool, result2 error) {
+	fake.revokeUserSessionMutex.Lock()
+	defer fake.revokeUserSessionMutex.Unlock()
+	fake.RevokeUserSessionStub = nil
+	fake.revokeUserSessionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeUserSessionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeUserSessionMutex.Lock()
+	defer fake.revokeUserSessionMutex.Unlock()
+	fake.RevokeUserSessionStub = nil
+	if fake.revokeUserSessionReturnsOnCall == nil {
+		fake.revokeUserSessionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeUserSessionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeUserSessions() (bool, error) {
+	fake.revokeUserSessionsMutex.Lock()
+	ret, specificReturn := fake.revokeUserSessionsReturnsOnCall[len(fake.revokeUserSessionsArgsForCall)]
+	fake.revokeUserSessionsArgsForCall = append(fake.revokeUserSessionsArgsForCall, struct {
+	}{})
+	stub := fake.RevokeUserSessionsStub
+	fakeReturns := fake.revokeUserSessionsReturns
+	fake.recordInvocation("RevokeUserSessions", []interface{}{})
+	fake.revokeUserSessionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RevokeUserSessionsCallCount() int {
+	fake.revokeUserSessionsMutex.RLock()
+	defer fake.revokeUserSessionsMutex.RUnlock()
+	return len(fake.revokeUserSessionsArgsForCall)
+}
+
+func (fake *FakeClient) RevokeUserSessionsCalls(stub func() (bool, error)) {
+	fake.revokeUserSessionsMutex.Lock()
+	defer fake.revokeUserSessionsMutex.Unlock()
+	fake.RevokeUserSessionsStub = stub
+}
+
+func (fake *FakeClient) RevokeUserSessionsReturns(result1 bool, result2 error) {
+	fake.revokeUserSessionsMutex.Lock()
+	defer fake.revokeUserSessionsMutex.Unlock()
+	fake.RevokeUserSessionsStub = nil
+	fake.revokeUserSessionsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeUserSessionsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeUserSessionsMutex.Lock()
+	defer fake.revokeUserSessionsMutex.Unlock()
+	fake.RevokeUserSessionsStub = nil
+	if fake.revokeUserSessionsReturnsOnCall == nil {
+		fake.revokeUserSessionsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeUserSessionsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1467,6 +2268,71 @@ func (fake *FakeClient) SaveWorkerReturnsOnCall(i int, result1 *atc.Worker, resu
 	}{result1, result2}
 }
 
+func (fake *FakeClient) Sessions(arg1 string, arg2 string) ([]atc.Session, error) {
+	fake.sessionsMutex.Lock()
+	ret, specificReturn := fake.sessionsReturnsOnCall[len(fake.sessionsArgsForCall)]
+	fake.sessionsArgsForCall = append(fake.sessionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SessionsStub
+	fakeReturns := fake.sessionsReturns
+	fake.recordInvocation("Sessions", []interface{}{arg1, arg2})
+	fake.sessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) SessionsCallCount() int {
+	fake.sessionsMutex.RLock()
+	defer fake.sessionsMutex.RUnlock()
+	return len(fake.sessionsArgsForCall)
+}
+
+func (fake *FakeClient) SessionsCalls(stub func(string, string) ([]atc.Session, error)) {
+	fake.sessionsMutex.Lock()
+	defer fake.sessionsMutex.Unlock()
+	fake.SessionsStub = stub
+}
+
+func (fake *FakeClient) SessionsArgsForCall(i int) (string, string) {
+	fake.sessionsMutex.RLock()
+	defer fake.sessionsMutex.RUnlock()
+	argsForCall := fake.sessionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) SessionsReturns(result1 []atc.Session, result2 error) {
+	fake.sessionsMutex.Lock()
+	defer fake.sessionsMutex.Unlock()
+	fake.SessionsStub = nil
+	fake.sessionsReturns = struct {
+		result1 []atc.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SessionsReturnsOnCall(i int, result1 []atc.Session, result2 error) {
+	fake.sessionsMutex.Lock()
+	defer fake.sessionsMutex.Unlock()
+	fake.SessionsStub = nil
+	if fake.sessionsReturnsOnCall == nil {
+		fake.sessionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Session
+			result2 error
+		})
+	}
+	fake.sessionsReturnsOnCall[i] = struct {
+		result1 []atc.Session
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Team(arg1 string) concourse.Team {
 	fake.teamMutex.Lock()
 	ret, specificReturn := fake.teamReturnsOnCall[len(fake.teamArgsForCall)]
@@ -1637,11 +2503,73 @@ func (fake *FakeClient) UserInfoReturnsOnCall(i int, result1 atc.UserInfo, resul
 	}{result1, result2}
 }
 
+func (fake *FakeClient) UserSessions() ([]atc.Session, error) {
+	fake.userSessionsMutex.Lock()
+	ret, specificReturn := fake.userSessionsReturnsOnCall[len(fake.userSessionsArgsForCall)]
+	fake.userSessionsArgsForCall = append(fake.userSessionsArgsForCall, struct {
+	}{})
+	stub := fake.UserSessionsStub
+	fakeReturns := fake.userSessionsReturns
+	fake.recordInvocation("UserSessions", []interface{}{})
+	fake.userSessionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) UserSessionsCallCount() int {
+	fake.userSessionsMutex.RLock()
+	defer fake.userSessionsMutex.RUnlock()
+	return len(fake.userSessionsArgsForCall)
+}
+
+func (fake *FakeClient) UserSessionsCalls(stub func() ([]atc.Session, error)) {
+	fake.userSessionsMutex.Lock()
+	defer fake.userSessionsMutex.Unlock()
+	fake.UserSessionsStub = stub
+}
+
+func (fake *FakeClient) UserSessionsReturns(result1 []atc.Session, result2 error) {
+	fake.userSessionsMutex.Lock()
+	defer fake.userSessionsMutex.Unlock()
+	fake.UserSessionsStub = nil
+	fake.userSessionsReturns = struct {
+		result1 []atc.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UserSessionsReturnsOnCall(i int, result1 []atc.Session, result2 error) {
+	fake.userSessionsMutex.Lock()
+	defer fake.userSessionsMutex.Unlock()
+	fake.UserSessionsStub = nil
+	if fake.userSessionsReturnsOnCall == nil {
+		fake.userSessionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Session
+			result2 error
+		})
+	}
+	fake.userSessionsReturnsOnCall[i] = struct {
+		result1 []atc.Session
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
@@ -1650,8 +2578,12 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildTestSuitesMutex.RLock()
+	defer fake.buildTestSuitesMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
@@ -1676,14 +2608,28 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	fake.revokeSessionMutex.RLock()
+	defer fake.revokeSessionMutex.RUnlock()
+	fake.revokeSessionsMutex.RLock()
+	defer fake.revokeSessionsMutex.RUnlock()
+	fake.revokeUserSessionMutex.RLock()
+	defer fake.revokeUserSessionMutex.RUnlock()
+	fake.revokeUserSessionsMutex.RLock()
+	defer fake.revokeUserSessionsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.sessionsMutex.RLock()
+	defer fake.sessionsMutex.RUnlock()
 	fake.teamMutex.RLock()
 	defer fake.teamMutex.RUnlock()
 	fake.uRLMutex.RLock()
 	defer fake.uRLMutex.RUnlock()
 	fake.userInfoMutex.RLock()
 	defer fake.userInfoMutex.RUnlock()
+	fake.userSessionsMutex.RLock()
+	defer fake.userSessionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
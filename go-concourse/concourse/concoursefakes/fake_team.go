@@ -10,6 +10,18 @@ import (
 )
 
 type FakeTeam struct {
+	APITokensStub        func() ([]atc.APIToken, error)
+	aPITokensMutex       sync.RWMutex
+	aPITokensArgsForCall []struct {
+	}
+	aPITokensReturns struct {
+		result1 []atc.APIToken
+		result2 error
+	}
+	aPITokensReturnsOnCall map[int]struct {
+		result1 []atc.APIToken
+		result2 error
+	}
 	ATCTeamStub        func() atc.Team
 	aTCTeamMutex       sync.RWMutex
 	aTCTeamArgsForCall []struct {
@@ -190,6 +202,19 @@ type FakeTeam struct {
 		result1 int64
 		result2 error
 	}
+	CreateAPITokenStub        func(atc.APIToken) (atc.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		arg1 atc.APIToken
+	}
+	createAPITokenReturns struct {
+		result1 atc.APIToken
+		result2 error
+	}
+	createAPITokenReturnsOnCall map[int]struct {
+		result1 atc.APIToken
+		result2 error
+	}
 	CreateArtifactStub        func(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	createArtifactMutex       sync.RWMutex
 	createArtifactArgsForCall []struct {
@@ -526,6 +551,20 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotificationDeliveriesStub        func(int, bool) ([]atc.NotificationDelivery, error)
+	notificationDeliveriesMutex       sync.RWMutex
+	notificationDeliveriesArgsForCall []struct {
+		arg1 int
+		arg2 bool
+	}
+	notificationDeliveriesReturns struct {
+		result1 []atc.NotificationDelivery
+		result2 error
+	}
+	notificationDeliveriesReturnsOnCall map[int]struct {
+		result1 []atc.NotificationDelivery
+		result2 error
+	}
 	OrderingPipelinesStub        func([]string) error
 	orderingPipelinesMutex       sync.RWMutex
 	orderingPipelinesArgsForCall []struct {
@@ -724,6 +763,19 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RevokeAPITokenStub        func(int) (bool, error)
+	revokeAPITokenMutex       sync.RWMutex
+	revokeAPITokenArgsForCall []struct {
+		arg1 int
+	}
+	revokeAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ScheduleJobStub        func(atc.PipelineRef, string) (bool, error)
 	scheduleJobMutex       sync.RWMutex
 	scheduleJobArgsForCall []struct {
@@ -738,6 +790,46 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SealingKeyStub        func() (atc.SealingKey, error)
+	sealingKeyMutex       sync.RWMutex
+	sealingKeyArgsForCall []struct {
+	}
+	sealingKeyReturns struct {
+		result1 atc.SealingKey
+		result2 error
+	}
+	sealingKeyReturnsOnCall map[int]struct {
+		result1 atc.SealingKey
+		result2 error
+	}
+	SearchBuildLogsStub        func(concourse.BuildLogSearch) ([]atc.BuildLogMatch, bool, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 concourse.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 []atc.BuildLogMatch
+		result2 bool
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []atc.BuildLogMatch
+		result2 bool
+		result3 error
+	}
+	SecretAccessesStub        func(concourse.SecretAccessFilter) ([]atc.SecretAccess, error)
+	secretAccessesMutex       sync.RWMutex
+	secretAccessesArgsForCall []struct {
+		arg1 concourse.SecretAccessFilter
+	}
+	secretAccessesReturns struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
+	secretAccessesReturnsOnCall map[int]struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
 	SetJobBuildCommentStub        func(atc.PipelineRef, string, string, string) (bool, error)
 	setJobBuildCommentMutex       sync.RWMutex
 	setJobBuildCommentArgsForCall []struct {
@@ -769,6 +861,18 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SetPipelineAuthStub        func(string, atc.TeamAuth) error
+	setPipelineAuthMutex       sync.RWMutex
+	setPipelineAuthArgsForCall []struct {
+		arg1 string
+		arg2 atc.TeamAuth
+	}
+	setPipelineAuthReturns struct {
+		result1 error
+	}
+	setPipelineAuthReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -829,6 +933,62 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeam) APITokens() ([]atc.APIToken, error) {
+	fake.aPITokensMutex.Lock()
+	ret, specificReturn := fake.aPITokensReturnsOnCall[len(fake.aPITokensArgsForCall)]
+	fake.aPITokensArgsForCall = append(fake.aPITokensArgsForCall, struct {
+	}{})
+	stub := fake.APITokensStub
+	fakeReturns := fake.aPITokensReturns
+	fake.recordInvocation("APITokens", []interface{}{})
+	fake.aPITokensMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) APITokensCallCount() int {
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
+	return len(fake.aPITokensArgsForCall)
+}
+
+func (fake *FakeTeam) APITokensCalls(stub func() ([]atc.APIToken, error)) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = stub
+}
+
+func (fake *FakeTeam) APITokensReturns(result1 []atc.APIToken, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	fake.aPITokensReturns = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) APITokensReturnsOnCall(i int, result1 []atc.APIToken, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	if fake.aPITokensReturnsOnCall == nil {
+		fake.aPITokensReturnsOnCall = make(map[int]struct {
+			result1 []atc.APIToken
+			result2 error
+		})
+	}
+	fake.aPITokensReturnsOnCall[i] = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ATCTeam() atc.Team {
 	fake.aTCTeamMutex.Lock()
 	ret, specificReturn := fake.aTCTeamReturnsOnCall[len(fake.aTCTeamArgsForCall)]
@@ -1612,6 +1772,70 @@ func (fake *FakeTeam) ClearTaskCacheReturnsOnCall(i int, result1 int64, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateAPIToken(arg1 atc.APIToken) (atc.APIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	ret, specificReturn := fake.createAPITokenReturnsOnCall[len(fake.createAPITokenArgsForCall)]
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		arg1 atc.APIToken
+	}{arg1})
+	stub := fake.CreateAPITokenStub
+	fakeReturns := fake.createAPITokenReturns
+	fake.recordInvocation("CreateAPIToken", []interface{}{arg1})
+	fake.createAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeam) CreateAPITokenCalls(stub func(atc.APIToken) (atc.APIToken, error)) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = stub
+}
+
+func (fake *FakeTeam) CreateAPITokenArgsForCall(i int) atc.APIToken {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	argsForCall := fake.createAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateAPITokenReturns(result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateAPITokenReturnsOnCall(i int, result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	if fake.createAPITokenReturnsOnCall == nil {
+		fake.createAPITokenReturnsOnCall = make(map[int]struct {
+			result1 atc.APIToken
+			result2 error
+		})
+	}
+	fake.createAPITokenReturnsOnCall[i] = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateArtifact(arg1 io.Reader, arg2 string, arg3 []string) (atc.WorkerArtifact, error) {
 	var arg3Copy []string
 	if arg3 != nil {
@@ -3160,6 +3384,71 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) NotificationDeliveries(arg1 int, arg2 bool) ([]atc.NotificationDelivery, error) {
+	fake.notificationDeliveriesMutex.Lock()
+	ret, specificReturn := fake.notificationDeliveriesReturnsOnCall[len(fake.notificationDeliveriesArgsForCall)]
+	fake.notificationDeliveriesArgsForCall = append(fake.notificationDeliveriesArgsForCall, struct {
+		arg1 int
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.NotificationDeliveriesStub
+	fakeReturns := fake.notificationDeliveriesReturns
+	fake.recordInvocation("NotificationDeliveries", []interface{}{arg1, arg2})
+	fake.notificationDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCallCount() int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return len(fake.notificationDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCalls(stub func(int, bool) ([]atc.NotificationDelivery, error)) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = stub
+}
+
+func (fake *FakeTeam) NotificationDeliveriesArgsForCall(i int) (int, bool) {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	argsForCall := fake.notificationDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturns(result1 []atc.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	fake.notificationDeliveriesReturns = struct {
+		result1 []atc.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturnsOnCall(i int, result1 []atc.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	if fake.notificationDeliveriesReturnsOnCall == nil {
+		fake.notificationDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []atc.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.notificationDeliveriesReturnsOnCall[i] = struct {
+		result1 []atc.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) OrderingPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -4039,6 +4328,70 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RevokeAPIToken(arg1 int) (bool, error) {
+	fake.revokeAPITokenMutex.Lock()
+	ret, specificReturn := fake.revokeAPITokenReturnsOnCall[len(fake.revokeAPITokenArgsForCall)]
+	fake.revokeAPITokenArgsForCall = append(fake.revokeAPITokenArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeAPITokenStub
+	fakeReturns := fake.revokeAPITokenReturns
+	fake.recordInvocation("RevokeAPIToken", []interface{}{arg1})
+	fake.revokeAPITokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RevokeAPITokenCallCount() int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	return len(fake.revokeAPITokenArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeAPITokenCalls(stub func(int) (bool, error)) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = stub
+}
+
+func (fake *FakeTeam) RevokeAPITokenArgsForCall(i int) int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	argsForCall := fake.revokeAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) RevokeAPITokenReturns(result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	fake.revokeAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	if fake.revokeAPITokenReturnsOnCall == nil {
+		fake.revokeAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ScheduleJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.scheduleJobMutex.Lock()
 	ret, specificReturn := fake.scheduleJobReturnsOnCall[len(fake.scheduleJobArgsForCall)]
@@ -4104,6 +4457,193 @@ func (fake *FakeTeam) ScheduleJobReturnsOnCall(i int, result1 bool, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SealingKey() (atc.SealingKey, error) {
+	fake.sealingKeyMutex.Lock()
+	ret, specificReturn := fake.sealingKeyReturnsOnCall[len(fake.sealingKeyArgsForCall)]
+	fake.sealingKeyArgsForCall = append(fake.sealingKeyArgsForCall, struct {
+	}{})
+	stub := fake.SealingKeyStub
+	fakeReturns := fake.sealingKeyReturns
+	fake.recordInvocation("SealingKey", []interface{}{})
+	fake.sealingKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SealingKeyCallCount() int {
+	fake.sealingKeyMutex.RLock()
+	defer fake.sealingKeyMutex.RUnlock()
+	return len(fake.sealingKeyArgsForCall)
+}
+
+func (fake *FakeTeam) SealingKeyCalls(stub func() (atc.SealingKey, error)) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = stub
+}
+
+func (fake *FakeTeam) SealingKeyReturns(result1 atc.SealingKey, result2 error) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = nil
+	fake.sealingKeyReturns = struct {
+		result1 atc.SealingKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SealingKeyReturnsOnCall(i int, result1 atc.SealingKey, result2 error) {
+	fake.sealingKeyMutex.Lock()
+	defer fake.sealingKeyMutex.Unlock()
+	fake.SealingKeyStub = nil
+	if fake.sealingKeyReturnsOnCall == nil {
+		fake.sealingKeyReturnsOnCall = make(map[int]struct {
+			result1 atc.SealingKey
+			result2 error
+		})
+	}
+	fake.sealingKeyReturnsOnCall[i] = struct {
+		result1 atc.SealingKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SearchBuildLogs(arg1 concourse.BuildLogSearch) ([]atc.BuildLogMatch, bool, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 concourse.BuildLogSearch
+	}{arg1})
+	stub := fake.SearchBuildLogsStub
+	fakeReturns := fake.searchBuildLogsReturns
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1})
+	fake.searchBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(concourse.BuildLogSearch) ([]atc.BuildLogMatch, bool, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) concourse.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []atc.BuildLogMatch, result2 bool, result3 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []atc.BuildLogMatch
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []atc.BuildLogMatch, result2 bool, result3 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildLogMatch
+			result2 bool
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []atc.BuildLogMatch
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SecretAccesses(arg1 concourse.SecretAccessFilter) ([]atc.SecretAccess, error) {
+	fake.secretAccessesMutex.Lock()
+	ret, specificReturn := fake.secretAccessesReturnsOnCall[len(fake.secretAccessesArgsForCall)]
+	fake.secretAccessesArgsForCall = append(fake.secretAccessesArgsForCall, struct {
+		arg1 concourse.SecretAccessFilter
+	}{arg1})
+	stub := fake.SecretAccessesStub
+	fakeReturns := fake.secretAccessesReturns
+	fake.recordInvocation("SecretAccesses", []interface{}{arg1})
+	fake.secretAccessesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SecretAccessesCallCount() int {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	return len(fake.secretAccessesArgsForCall)
+}
+
+func (fake *FakeTeam) SecretAccessesCalls(stub func(concourse.SecretAccessFilter) ([]atc.SecretAccess, error)) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = stub
+}
+
+func (fake *FakeTeam) SecretAccessesArgsForCall(i int) concourse.SecretAccessFilter {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	argsForCall := fake.secretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SecretAccessesReturns(result1 []atc.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	fake.secretAccessesReturns = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SecretAccessesReturnsOnCall(i int, result1 []atc.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	if fake.secretAccessesReturnsOnCall == nil {
+		fake.secretAccessesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretAccess
+			result2 error
+		})
+	}
+	fake.secretAccessesReturnsOnCall[i] = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) SetJobBuildComment(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 string) (bool, error) {
 	fake.setJobBuildCommentMutex.Lock()
 	ret, specificReturn := fake.setJobBuildCommentReturnsOnCall[len(fake.setJobBuildCommentArgsForCall)]
@@ -4237,6 +4777,68 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetPipelineAuth(arg1 string, arg2 atc.TeamAuth) error {
+	fake.setPipelineAuthMutex.Lock()
+	ret, specificReturn := fake.setPipelineAuthReturnsOnCall[len(fake.setPipelineAuthArgsForCall)]
+	fake.setPipelineAuthArgsForCall = append(fake.setPipelineAuthArgsForCall, struct {
+		arg1 string
+		arg2 atc.TeamAuth
+	}{arg1, arg2})
+	stub := fake.SetPipelineAuthStub
+	fakeReturns := fake.setPipelineAuthReturns
+	fake.recordInvocation("SetPipelineAuth", []interface{}{arg1, arg2})
+	fake.setPipelineAuthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetPipelineAuthCallCount() int {
+	fake.setPipelineAuthMutex.RLock()
+	defer fake.setPipelineAuthMutex.RUnlock()
+	return len(fake.setPipelineAuthArgsForCall)
+}
+
+func (fake *FakeTeam) SetPipelineAuthCalls(stub func(string, atc.TeamAuth) error) {
+	fake.setPipelineAuthMutex.Lock()
+	defer fake.setPipelineAuthMutex.Unlock()
+	fake.SetPipelineAuthStub = stub
+}
+
+func (fake *FakeTeam) SetPipelineAuthArgsForCall(i int) (string, atc.TeamAuth) {
+	fake.setPipelineAuthMutex.RLock()
+	defer fake.setPipelineAuthMutex.RUnlock()
+	argsForCall := fake.setPipelineAuthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SetPipelineAuthReturns(result1 error) {
+	fake.setPipelineAuthMutex.Lock()
+	defer fake.setPipelineAuthMutex.Unlock()
+	fake.SetPipelineAuthStub = nil
+	fake.setPipelineAuthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetPipelineAuthReturnsOnCall(i int, result1 error) {
+	fake.setPipelineAuthMutex.Lock()
+	defer fake.setPipelineAuthMutex.Unlock()
+	fake.SetPipelineAuthStub = nil
+	if fake.setPipelineAuthReturnsOnCall == nil {
+		fake.setPipelineAuthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPipelineAuthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
@@ -4501,6 +5103,8 @@ func (fake *FakeTeam) VersionedResourceTypesReturnsOnCall(i int, result1 atc.Ver
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
 	fake.aTCTeamMutex.RLock()
 	defer fake.aTCTeamMutex.RUnlock()
 	fake.archivePipelineMutex.RLock()
@@ -4525,6 +5129,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.clearResourceCacheMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
 	fake.createArtifactMutex.RLock()
 	defer fake.createArtifactMutex.RUnlock()
 	fake.createBuildMutex.RLock()
@@ -4573,6 +5179,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.listVolumesMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
 	fake.orderingPipelinesMutex.RLock()
 	defer fake.orderingPipelinesMutex.RUnlock()
 	fake.orderingPipelinesWithinGroupMutex.RLock()
@@ -4599,12 +5207,22 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
 	fake.scheduleJobMutex.RLock()
 	defer fake.scheduleJobMutex.RUnlock()
+	fake.sealingKeyMutex.RLock()
+	defer fake.sealingKeyMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
 	fake.setJobBuildCommentMutex.RLock()
 	defer fake.setJobBuildCommentMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
+	fake.setPipelineAuthMutex.RLock()
+	defer fake.setPipelineAuthMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
@@ -0,0 +1,37 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) NotificationDeliveries(limit int, failedOnly bool) ([]atc.NotificationDelivery, error) {
+	var deliveries []atc.NotificationDelivery
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	if failedOnly {
+		query.Set("failed", "true")
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListNotificationDeliveries,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &deliveries,
+	})
+
+	return deliveries, err
+}
@@ -0,0 +1,46 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Notifications", func() {
+	Describe("NotificationDeliveries", func() {
+		var expectedDeliveries []atc.NotificationDelivery
+
+		BeforeEach(func() {
+			expectedDeliveries = []atc.NotificationDelivery{
+				{
+					ID:        2,
+					BuildID:   42,
+					BuildName: "7",
+					JobName:   "some-job",
+					TeamName:  "some-team",
+					URL:       "https://example.com/hook",
+					Event:     atc.StatusFailed,
+					Attempt:   1,
+					Error:     "connection refused",
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/notifications/deliveries", "limit=5&failed=true"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDeliveries),
+				),
+			)
+		})
+
+		It("returns the delivery attempts", func() {
+			deliveries, err := team.NotificationDeliveries(5, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(Equal(expectedDeliveries))
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) SealingKey() (atc.SealingKey, error) {
+	var key atc.SealingKey
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetTeamSealingKey,
+		Params:      params,
+	}, &internal.Response{
+		Result: &key,
+	})
+
+	return key, err
+}
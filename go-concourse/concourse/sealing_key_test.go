@@ -0,0 +1,30 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Sealing Key", func() {
+	Describe("SealingKey", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/sealing_key"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SealingKey{PublicKey: "age1some-key"}),
+				),
+			)
+		})
+
+		It("returns the team's public key", func() {
+			key, err := team.SealingKey()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(atc.SealingKey{PublicKey: "age1some-key"}))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// SecretAccessFilter narrows down the secret accesses returned by
+// SecretAccesses. Zero values are not filtered on.
+type SecretAccessFilter struct {
+	Var          string
+	PipelineName string
+	JobName      string
+
+	Since time.Time
+	Until time.Time
+
+	Limit int
+}
+
+func (filter SecretAccessFilter) queryParams() url.Values {
+	query := url.Values{}
+
+	if filter.Var != "" {
+		query.Set("var", filter.Var)
+	}
+
+	if filter.PipelineName != "" {
+		query.Set("pipeline_name", filter.PipelineName)
+	}
+
+	if filter.JobName != "" {
+		query.Set("job_name", filter.JobName)
+	}
+
+	if !filter.Since.IsZero() {
+		query.Set("since", strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		query.Set("until", strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	return query
+}
+
+func (team *team) SecretAccesses(filter SecretAccessFilter) ([]atc.SecretAccess, error) {
+	var accesses []atc.SecretAccess
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListSecretAccesses,
+		Params:      params,
+		Query:       filter.queryParams(),
+	}, &internal.Response{
+		Result: &accesses,
+	})
+
+	return accesses, err
+}
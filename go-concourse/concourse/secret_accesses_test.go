@@ -0,0 +1,93 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Secret Accesses", func() {
+	Describe("SecretAccesses", func() {
+		expectedURL := "/api/v1/teams/some-team/secrets/accesses"
+
+		Context("when no filters are given", func() {
+			var expectedAccesses []atc.SecretAccess
+
+			BeforeEach(func() {
+				expectedAccesses = []atc.SecretAccess{
+					{
+						ID:         1,
+						TeamName:   "some-team",
+						BuildID:    42,
+						BuildName:  "7",
+						StepName:   "deploy",
+						Var:        "prod-db-password",
+						Backend:    "vault",
+						SecretPath: "/concourse/some-team/prod-db-password",
+						AccessedAt: 100,
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedAccesses),
+					),
+				)
+			})
+
+			It("returns the accesses", func() {
+				accesses, err := team.SecretAccesses(concourse.SecretAccessFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(accesses).To(Equal(expectedAccesses))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL,
+							"job_name=some-job&limit=5&pipeline_name=some-pipeline&since=100&until=200&var=prod-db-password"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.SecretAccess{}),
+					),
+				)
+			})
+
+			It("passes them as query params", func() {
+				_, err := team.SecretAccesses(concourse.SecretAccessFilter{
+					Var:          "prod-db-password",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					Since:        time.Unix(100, 0),
+					Until:        time.Unix(200, 0),
+					Limit:        5,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := team.SecretAccesses(concourse.SecretAccessFilter{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// UserSessions lists the sessions of the current user.
+func (client *client) UserSessions() ([]atc.Session, error) {
+	var sessions []atc.Session
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListUserSessions,
+	}, &internal.Response{
+		Result: &sessions,
+	})
+
+	return sessions, err
+}
+
+func (client *client) RevokeUserSession(id int) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RevokeUserSession,
+		Params:      rata.Params{"session_id": strconv.Itoa(id)},
+	}, nil)
+
+	return revokeResult(err)
+}
+
+// RevokeUserSessions revokes every session of the current user, including
+// the one the client is using.
+func (client *client) RevokeUserSessions() (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RevokeUserSessions,
+	}, nil)
+
+	return revokeResult(err)
+}
+
+// Sessions lists the sessions of every user, or of the given user if
+// userName is not empty, narrowed down to a connector if it is not empty.
+// Only admins may list other users' sessions.
+func (client *client) Sessions(userName string, connector string) ([]atc.Session, error) {
+	query := url.Values{}
+	if userName != "" {
+		query.Set("user", userName)
+	}
+
+	if connector != "" {
+		query.Set("connector", connector)
+	}
+
+	var sessions []atc.Session
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListSessions,
+		Query:       query,
+	}, &internal.Response{
+		Result: &sessions,
+	})
+
+	return sessions, err
+}
+
+func (client *client) RevokeSession(id int) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RevokeSession,
+		Params:      rata.Params{"session_id": strconv.Itoa(id)},
+	}, nil)
+
+	return revokeResult(err)
+}
+
+// RevokeSessions revokes all of the sessions of the user with the given name
+// who logged in through the given connector.
+func (client *client) RevokeSessions(userName string, connector string) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RevokeSessions,
+		Query:       url.Values{"user": {userName}, "connector": {connector}},
+	}, nil)
+
+	return revokeResult(err)
+}
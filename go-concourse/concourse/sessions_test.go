@@ -0,0 +1,188 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Sessions", func() {
+	var expectedSessions []atc.Session
+
+	BeforeEach(func() {
+		expectedSessions = []atc.Session{
+			{
+				ID:         1,
+				UserName:   "some-user",
+				Connector:  "github",
+				ClientID:   "fly",
+				RemoteAddr: "10.0.0.1:12345",
+				CreatedAt:  100,
+				LastUsedAt: 150,
+				ExpiresAt:  200,
+			},
+		}
+	})
+
+	Describe("UserSessions", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/user/sessions"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSessions),
+				),
+			)
+		})
+
+		It("returns the user's sessions", func() {
+			sessions, err := client.UserSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(Equal(expectedSessions))
+		})
+	})
+
+	Describe("RevokeUserSession", func() {
+		var status int
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/user/sessions/1"),
+					ghttp.RespondWith(status, nil),
+				),
+			)
+		})
+
+		Context("when the session is revoked", func() {
+			BeforeEach(func() {
+				status = http.StatusNoContent
+			})
+
+			It("returns true", func() {
+				revoked, err := client.RevokeUserSession(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked).To(BeTrue())
+			})
+		})
+
+		Context("when the session does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("returns false", func() {
+				revoked, err := client.RevokeUserSession(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("RevokeUserSessions", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/user/sessions"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("revokes them", func() {
+			revoked, err := client.RevokeUserSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+	})
+
+	Describe("Sessions", func() {
+		Context("when no user is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/sessions", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSessions),
+					),
+				)
+			})
+
+			It("returns every user's sessions", func() {
+				sessions, err := client.Sessions("", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sessions).To(Equal(expectedSessions))
+			})
+		})
+
+		Context("when a user is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/sessions", "user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSessions),
+					),
+				)
+			})
+
+			It("returns their sessions", func() {
+				sessions, err := client.Sessions("some-user", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sessions).To(Equal(expectedSessions))
+			})
+		})
+
+		Context("when a user and connector are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/sessions", "connector=github&user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSessions),
+					),
+				)
+			})
+
+			It("returns their sessions", func() {
+				sessions, err := client.Sessions("some-user", "github")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sessions).To(Equal(expectedSessions))
+			})
+		})
+	})
+
+	Describe("RevokeSession", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/sessions/1"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("revokes it", func() {
+			revoked, err := client.RevokeSession(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+	})
+
+	Describe("RevokeSessions", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/sessions", "connector=github&user=some-user"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("revokes all of the user's sessions", func() {
+			revoked, err := client.RevokeSessions("some-user", "github")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+	})
+})
@@ -16,6 +16,7 @@ type Team interface {
 
 	CreateOrUpdate(team atc.Team) (atc.Team, bool, bool, []ConfigWarning, error)
 	RenameTeam(teamName, name string) (bool, []ConfigWarning, error)
+	SetPipelineAuth(pipelineName string, auth atc.TeamAuth) error
 	DestroyTeam(teamName string) error
 
 	Pipeline(pipelineRef atc.PipelineRef) (atc.Pipeline, bool, error)
@@ -70,13 +71,22 @@ type Team interface {
 	ListContainers(queryList map[string]string) ([]atc.Container, error)
 	GetContainer(id string) (atc.Container, error)
 	ListVolumes() ([]atc.Volume, error)
+	NotificationDeliveries(limit int, failedOnly bool) ([]atc.NotificationDelivery, error)
+	SealingKey() (atc.SealingKey, error)
+	SecretAccesses(filter SecretAccessFilter) ([]atc.SecretAccess, error)
+
 	CreateBuild(plan atc.Plan) (atc.Build, error)
 	Builds(page Page) ([]atc.Build, Pagination, error)
+	SearchBuildLogs(search BuildLogSearch) ([]atc.BuildLogMatch, bool, error)
 	OrderingPipelines(pipelineNames []string) error
 	OrderingPipelinesWithinGroup(groupName string, instanceVars []atc.InstanceVars) error
 
 	CreateArtifact(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	GetArtifact(int) (io.ReadCloser, error)
+
+	APITokens() ([]atc.APIToken, error)
+	CreateAPIToken(atc.APIToken) (atc.APIToken, error)
+	RevokeAPIToken(id int) (bool, error)
 }
 
 type team struct {
@@ -107,6 +107,25 @@ func (team *team) RenameTeam(teamName, name string) (bool, []ConfigWarning, erro
 	}
 }
 
+func (team *team) SetPipelineAuth(pipelineName string, auth atc.TeamAuth) error {
+	params := rata.Params{
+		"team_name":     team.Name(),
+		"pipeline_name": pipelineName,
+	}
+
+	jsonBytes, err := json.Marshal(auth)
+	if err != nil {
+		return err
+	}
+
+	return team.connection.Send(internal.Request{
+		RequestName: atc.SetPipelineAuth,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, nil)
+}
+
 func (client *client) ListTeams() ([]atc.Team, error) {
 	var teams []atc.Team
 	err := client.connection.Send(internal.Request{
@@ -252,6 +252,74 @@ var _ = Describe("ATC Handler Teams", func() {
 		})
 	})
 
+	Describe("SetPipelineAuth", func() {
+		var (
+			expectedURL string
+			auth        atc.TeamAuth
+			err         error
+		)
+
+		BeforeEach(func() {
+			expectedURL = "/api/v1/teams/some-team/pipelines/some-pipeline/auth"
+			team = client.Team("some-team")
+			auth = atc.TeamAuth{
+				"viewer": map[string][]string{
+					"users": {"local:some-user"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = team.SetPipelineAuth("some-pipeline", auth)
+		})
+
+		Context("when the server saves the role bindings", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.VerifyJSONRepresenting(auth),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the user is not an owner", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when the server rejects the role bindings", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusBadRequest, "invalid auth"),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("ListTeams", func() {
 		var expectedTeams []atc.Team
 
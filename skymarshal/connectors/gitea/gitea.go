@@ -0,0 +1,324 @@
+// Package gitea provides authentication through Gitea and Forgejo, including
+// the user's organization and team memberships, which the connector bundled
+// with dex does not load.
+package gitea
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/dex/connector"
+	"github.com/concourse/dex/pkg/log"
+	"golang.org/x/oauth2"
+)
+
+// pageSize is how many organizations or teams are requested at a time; it is
+// the largest page Gitea serves by default.
+const pageSize = 50
+
+type Config struct {
+	BaseURL      string `json:"baseURL"`
+	ClientID     string `json:"clientID"`
+	ClientSecret string `json:"clientSecret"`
+	RedirectURI  string `json:"redirectURI"`
+	RootCA       string `json:"rootCA"`
+}
+
+func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error) {
+	baseURL := strings.TrimRight(c.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://gitea.com"
+	}
+
+	g := &giteaConnector{
+		baseURL:      baseURL,
+		clientID:     c.ClientID,
+		clientSecret: c.ClientSecret,
+		redirectURI:  c.RedirectURI,
+		logger:       logger,
+	}
+
+	if c.RootCA != "" {
+		var err error
+		g.httpClient, err = newHTTPClient(c.RootCA)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+var (
+	_ connector.CallbackConnector = (*giteaConnector)(nil)
+	_ connector.RefreshConnector  = (*giteaConnector)(nil)
+)
+
+type giteaConnector struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	redirectURI  string
+	logger       log.Logger
+	httpClient   *http.Client
+}
+
+type connectorData struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+type user struct {
+	ID       int    `json:"id"`
+	Name     string `json:"full_name"`
+	Username string `json:"login"`
+	Email    string `json:"email"`
+}
+
+type organization struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// name returns the organization's handle; older Gitea releases only set
+// username and newer ones deprecate it in favour of name.
+func (o organization) name() string {
+	if o.Username != "" {
+		return o.Username
+	}
+	return o.Name
+}
+
+type team struct {
+	Name         string       `json:"name"`
+	Organization organization `json:"organization"`
+}
+
+type oauth2Error struct {
+	error            string
+	errorDescription string
+}
+
+func (e *oauth2Error) Error() string {
+	if e.errorDescription == "" {
+		return e.error
+	}
+	return e.error + ": " + e.errorDescription
+}
+
+func (c *giteaConnector) oauth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     c.clientID,
+		ClientSecret: c.clientSecret,
+		RedirectURL:  c.redirectURI,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  c.baseURL + "/login/oauth/authorize",
+			TokenURL: c.baseURL + "/login/oauth/access_token",
+		},
+	}
+}
+
+func (c *giteaConnector) context(ctx context.Context) context.Context {
+	if c.httpClient == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+}
+
+func (c *giteaConnector) LoginURL(scopes connector.Scopes, callbackURL, state string) (string, error) {
+	if c.redirectURI != callbackURL {
+		return "", fmt.Errorf("expected callback URL %q did not match the URL in the config %q", callbackURL, c.redirectURI)
+	}
+	return c.oauth2Config().AuthCodeURL(state), nil
+}
+
+func (c *giteaConnector) HandleCallback(s connector.Scopes, r *http.Request) (connector.Identity, error) {
+	q := r.URL.Query()
+	if errType := q.Get("error"); errType != "" {
+		return connector.Identity{}, &oauth2Error{errType, q.Get("error_description")}
+	}
+
+	ctx := c.context(r.Context())
+
+	token, err := c.oauth2Config().Exchange(ctx, q.Get("code"))
+	if err != nil {
+		return connector.Identity{}, fmt.Errorf("gitea: failed to get token: %v", err)
+	}
+
+	identity, err := c.identity(ctx, c.oauth2Config().Client(ctx, token), s, connector.Identity{})
+	if err != nil {
+		return connector.Identity{}, err
+	}
+
+	if s.OfflineAccess {
+		identity.ConnectorData, err = json.Marshal(connectorData{
+			AccessToken:  token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			Expiry:       token.Expiry,
+		})
+		if err != nil {
+			return connector.Identity{}, fmt.Errorf("gitea: marshal connector data: %v", err)
+		}
+	}
+
+	return identity, nil
+}
+
+func (c *giteaConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+	if len(identity.ConnectorData) == 0 {
+		return identity, errors.New("gitea: no upstream access token found")
+	}
+
+	var data connectorData
+	if err := json.Unmarshal(identity.ConnectorData, &data); err != nil {
+		return identity, fmt.Errorf("gitea: unmarshal connector data: %v", err)
+	}
+
+	ctx = c.context(ctx)
+
+	token, err := c.oauth2Config().TokenSource(ctx, &oauth2.Token{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		Expiry:       data.Expiry,
+	}).Token()
+	if err != nil {
+		return identity, fmt.Errorf("gitea: refresh token: %v", err)
+	}
+
+	identity, err = c.identity(ctx, c.oauth2Config().Client(ctx, token), s, identity)
+	if err != nil {
+		return identity, err
+	}
+
+	identity.ConnectorData, err = json.Marshal(connectorData{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	})
+	if err != nil {
+		return identity, fmt.Errorf("gitea: marshal connector data: %v", err)
+	}
+
+	return identity, nil
+}
+
+// identity fills in the user's profile and, if asked for, their groups: the
+// name of every organization they belong to and "org:team" for every team.
+func (c *giteaConnector) identity(ctx context.Context, client *http.Client, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+	var u user
+	if err := c.get(ctx, client, "/api/v1/user", &u); err != nil {
+		return identity, fmt.Errorf("gitea: get user: %v", err)
+	}
+
+	identity.UserID = strconv.Itoa(u.ID)
+	identity.Username = u.Name
+	if identity.Username == "" {
+		identity.Username = u.Email
+	}
+	identity.PreferredUsername = u.Username
+	identity.Email = u.Email
+	identity.EmailVerified = true
+
+	if s.Groups {
+		groups, err := c.groups(ctx, client)
+		if err != nil {
+			return identity, err
+		}
+		identity.Groups = groups
+	}
+
+	return identity, nil
+}
+
+func (c *giteaConnector) groups(ctx context.Context, client *http.Client) ([]string, error) {
+	groups := []string{}
+
+	for page := 1; ; page++ {
+		var orgs []organization
+		if err := c.get(ctx, client, c.pagePath("/api/v1/user/orgs", page), &orgs); err != nil {
+			return nil, fmt.Errorf("gitea: get orgs: %v", err)
+		}
+
+		for _, org := range orgs {
+			groups = append(groups, org.name())
+		}
+
+		if len(orgs) < pageSize {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		var teams []team
+		if err := c.get(ctx, client, c.pagePath("/api/v1/user/teams", page), &teams); err != nil {
+			return nil, fmt.Errorf("gitea: get teams: %v", err)
+		}
+
+		for _, t := range teams {
+			groups = append(groups, t.Organization.name()+":"+t.Name)
+		}
+
+		if len(teams) < pageSize {
+			break
+		}
+	}
+
+	return groups, nil
+}
+
+func (c *giteaConnector) pagePath(path string, page int) string {
+	return fmt.Sprintf("%s?page=%d&limit=%d", path, page, pageSize)
+}
+
+// get queries the Gitea API using the provided client, which is expected to
+// be constructed by the golang.org/x/oauth2 package so that it inserts the
+// user's bearer token.
+func (c *giteaConnector) get(ctx context.Context, client *http.Client, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read body: %v", err)
+		}
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func newHTTPClient(rootCA string) (*http.Client, error) {
+	rootCABytes, err := ioutil.ReadFile(rootCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root-ca: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(rootCABytes) {
+		return nil, fmt.Errorf("no certs found in root CA file %q", rootCA)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,236 @@
+package gitea_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/skymarshal/connectors/gitea"
+	"github.com/concourse/concourse/skymarshal/logger"
+	"github.com/concourse/dex/connector"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Gitea connector", func() {
+	var (
+		giteaServer *ghttp.Server
+		conn        connector.CallbackConnector
+		scopes      connector.Scopes
+	)
+
+	BeforeEach(func() {
+		giteaServer = ghttp.NewServer()
+
+		config := &gitea.Config{
+			BaseURL:      giteaServer.URL(),
+			ClientID:     "some-client-id",
+			ClientSecret: "some-client-secret",
+			RedirectURI:  "https://concourse.example.com/sky/issuer/callback",
+		}
+
+		opened, err := config.Open("gitea", logger.New(lagertest.NewTestLogger("gitea")))
+		Expect(err).NotTo(HaveOccurred())
+
+		conn = opened.(connector.CallbackConnector)
+		scopes = connector.Scopes{Groups: true}
+	})
+
+	AfterEach(func() {
+		giteaServer.Close()
+	})
+
+	Describe("LoginURL", func() {
+		It("points at the server's authorize endpoint", func() {
+			loginURL, err := conn.LoginURL(scopes, "https://concourse.example.com/sky/issuer/callback", "some-state")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loginURL).To(HavePrefix(giteaServer.URL() + "/login/oauth/authorize?"))
+			Expect(loginURL).To(ContainSubstring("state=some-state"))
+		})
+
+		It("rejects an unexpected callback URL", func() {
+			_, err := conn.LoginURL(scopes, "https://elsewhere.example.com/callback", "some-state")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("HandleCallback", func() {
+		var (
+			identity connector.Identity
+			err      error
+		)
+
+		BeforeEach(func() {
+			giteaServer.RouteToHandler("POST", "/login/oauth/access_token", ghttp.CombineHandlers(
+				ghttp.VerifyFormKV("code", "some-code"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"access_token":  "some-access-token",
+					"refresh_token": "some-refresh-token",
+					"token_type":    "bearer",
+					"expires_in":    3600,
+				}),
+			))
+
+			giteaServer.RouteToHandler("GET", "/api/v1/user", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-access-token"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"id":        42,
+					"login":     "some-login",
+					"full_name": "Some User",
+					"email":     "some-user@example.com",
+				}),
+			))
+
+			giteaServer.RouteToHandler("GET", "/api/v1/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer some-access-token"))
+
+				orgs := []map[string]string{}
+				if r.URL.Query().Get("page") == "1" {
+					for i := 0; i < 50; i++ {
+						orgs = append(orgs, map[string]string{"username": fmt.Sprintf("org-%d", i)})
+					}
+				} else if r.URL.Query().Get("page") == "2" {
+					orgs = append(orgs, map[string]string{"name": "newer-org"})
+				}
+
+				ghttp.RespondWithJSONEncoded(http.StatusOK, orgs)(w, r)
+			})
+
+			giteaServer.RouteToHandler("GET", "/api/v1/user/teams", ghttp.RespondWithJSONEncoded(http.StatusOK, []map[string]interface{}{
+				{
+					"name":         "some-team",
+					"organization": map[string]string{"username": "org-0"},
+				},
+			}))
+		})
+
+		JustBeforeEach(func() {
+			request := httptest.NewRequest("GET", "/sky/issuer/callback?code=some-code&state=some-state", nil)
+			identity, err = conn.HandleCallback(scopes, request)
+		})
+
+		It("returns the user's identity", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity.UserID).To(Equal("42"))
+			Expect(identity.Username).To(Equal("Some User"))
+			Expect(identity.PreferredUsername).To(Equal("some-login"))
+			Expect(identity.Email).To(Equal("some-user@example.com"))
+			Expect(identity.EmailVerified).To(BeTrue())
+		})
+
+		It("returns every org and team the user belongs to as groups", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity.Groups).To(HaveLen(52))
+			Expect(identity.Groups).To(ContainElement("org-0"))
+			Expect(identity.Groups).To(ContainElement("org-49"))
+			Expect(identity.Groups).To(ContainElement("newer-org"))
+			Expect(identity.Groups).To(ContainElement("org-0:some-team"))
+		})
+
+		Context("when groups are not requested", func() {
+			BeforeEach(func() {
+				scopes.Groups = false
+			})
+
+			It("does not look them up", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(identity.Groups).To(BeEmpty())
+
+				for _, request := range giteaServer.ReceivedRequests() {
+					Expect(request.URL.Path).NotTo(HavePrefix("/api/v1/user/"))
+				}
+			})
+		})
+
+		Context("when offline access is requested", func() {
+			BeforeEach(func() {
+				scopes.OfflineAccess = true
+			})
+
+			It("keeps the tokens for refreshing", func() {
+				Expect(err).NotTo(HaveOccurred())
+				var data map[string]string
+				Expect(json.Unmarshal(identity.ConnectorData, &data)).To(Succeed())
+				Expect(data["accessToken"]).To(Equal("some-access-token"))
+				Expect(data["refreshToken"]).To(Equal("some-refresh-token"))
+			})
+		})
+
+		Context("when looking up teams fails", func() {
+			BeforeEach(func() {
+				giteaServer.RouteToHandler("GET", "/api/v1/user/teams", ghttp.RespondWith(http.StatusForbidden, "nope"))
+			})
+
+			It("errors", func() {
+				Expect(err).To(MatchError(ContainSubstring("gitea: get teams")))
+			})
+		})
+
+		Context("when the user denied access", func() {
+			JustBeforeEach(func() {
+				request := httptest.NewRequest("GET", "/sky/issuer/callback?error=access_denied", nil)
+				identity, err = conn.HandleCallback(scopes, request)
+			})
+
+			It("errors", func() {
+				Expect(err).To(MatchError("access_denied"))
+			})
+		})
+	})
+
+	Describe("Refresh", func() {
+		var (
+			identity connector.Identity
+			err      error
+		)
+
+		BeforeEach(func() {
+			giteaServer.RouteToHandler("POST", "/login/oauth/access_token", ghttp.CombineHandlers(
+				ghttp.VerifyFormKV("grant_type", "refresh_token"),
+				ghttp.VerifyFormKV("refresh_token", "some-refresh-token"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"access_token":  "new-access-token",
+					"refresh_token": "new-refresh-token",
+					"token_type":    "bearer",
+					"expires_in":    3600,
+				}),
+			))
+
+			giteaServer.RouteToHandler("GET", "/api/v1/user", ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Authorization", "Bearer new-access-token"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"id":    42,
+					"login": "renamed-login",
+				}),
+			))
+
+			giteaServer.RouteToHandler("GET", "/api/v1/user/orgs", ghttp.RespondWithJSONEncoded(http.StatusOK, []map[string]string{
+				{"username": "some-org"},
+			}))
+			giteaServer.RouteToHandler("GET", "/api/v1/user/teams", ghttp.RespondWithJSONEncoded(http.StatusOK, []map[string]string{}))
+		})
+
+		JustBeforeEach(func() {
+			refresher := conn.(connector.RefreshConnector)
+			identity, err = refresher.Refresh(context.Background(), scopes, connector.Identity{
+				UserID:        "42",
+				ConnectorData: []byte(`{"accessToken":"some-access-token","refreshToken":"some-refresh-token","expiry":"2021-07-01T00:00:00Z"}`),
+			})
+		})
+
+		It("reloads the user's profile and groups with a refreshed token", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity.PreferredUsername).To(Equal("renamed-login"))
+			Expect(identity.Groups).To(Equal([]string{"some-org"}))
+
+			var data map[string]string
+			Expect(json.Unmarshal(identity.ConnectorData, &data)).To(Succeed())
+			Expect(data["accessToken"]).To(Equal("new-access-token"))
+			Expect(data["refreshToken"]).To(Equal("new-refresh-token"))
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/skymarshal/connectors/gitea"
 	"github.com/concourse/concourse/skymarshal/logger"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	s "github.com/concourse/concourse/skymarshal/storage"
@@ -33,6 +34,12 @@ type DexConfig struct {
 //go:embed web
 var webFS embed.FS
 
+func init() {
+	// dex's own gitea connector doesn't load org and team memberships, so it
+	// is replaced with one that does.
+	server.ConnectorsConfig["gitea"] = func() server.ConnectorConfig { return new(gitea.Config) }
+}
+
 func NewDexServer(config *DexConfig) (*server.Server, error) {
 
 	newDexServerConfig, err := NewDexServerConfig(config)
@@ -41,6 +41,7 @@ var _ = Describe("skyDisplayUserIdGenerator", func() {
 				displayUserIdGenerator, err = skycmd.NewSkyDisplayUserIdGenerator(map[string]string{
 					"ldap":            "user_id",
 					"github":          "username",
+					"gitea":           "username",
 					"bitbucket-cloud": "name",
 					"cf":              "email",
 					"gitlab":          "email",
@@ -61,6 +62,7 @@ var _ = Describe("skyDisplayUserIdGenerator", func() {
 
 				Entry("ldap connector", "ldap", "userid"),
 				Entry("github connector", "github", "preferredUsername"),
+				Entry("gitea connector", "gitea", "preferredUsername"),
 				Entry("bitbucket-cloud connector", "bitbucket-cloud", "username"),
 				Entry("cf connector", "cf", "email"),
 				Entry("gitlab connector", "cf", "email"),
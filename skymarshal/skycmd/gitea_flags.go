@@ -0,0 +1,75 @@
+package skycmd
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/concourse/concourse/skymarshal/connectors/gitea"
+	"github.com/concourse/flag"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+func init() {
+	RegisterConnector(&Connector{
+		id:         "gitea",
+		config:     &GiteaFlags{},
+		teamConfig: &GiteaTeamFlags{},
+	})
+}
+
+type GiteaFlags struct {
+	ClientID     string    `long:"client-id" description:"(Required) Client id"`
+	ClientSecret string    `long:"client-secret" description:"(Required) Client secret"`
+	URL          string    `long:"url" description:"(Required) URL of the Gitea or Forgejo server (Include scheme, No trailing slash)"`
+	CACert       flag.File `long:"ca-cert" description:"CA certificate of the Gitea or Forgejo server"`
+}
+
+func (flag *GiteaFlags) Name() string {
+	return "Gitea"
+}
+
+func (flag *GiteaFlags) Validate() error {
+	var errs *multierror.Error
+
+	if flag.ClientID == "" {
+		errs = multierror.Append(errs, errors.New("Missing client-id"))
+	}
+
+	if flag.ClientSecret == "" {
+		errs = multierror.Append(errs, errors.New("Missing client-secret"))
+	}
+
+	if flag.URL == "" {
+		errs = multierror.Append(errs, errors.New("Missing url"))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (flag *GiteaFlags) Serialize(redirectURI string) ([]byte, error) {
+	if err := flag.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(gitea.Config{
+		ClientID:     flag.ClientID,
+		ClientSecret: flag.ClientSecret,
+		RedirectURI:  redirectURI,
+		BaseURL:      flag.URL,
+		RootCA:       flag.CACert.Path(),
+	})
+}
+
+type GiteaTeamFlags struct {
+	Users []string `long:"user" description:"A whitelisted Gitea user" value-name:"USERNAME"`
+	Orgs  []string `long:"org" description:"A whitelisted Gitea org" value-name:"ORG_NAME"`
+	Teams []string `long:"team" description:"A whitelisted Gitea team" value-name:"ORG_NAME:TEAM_NAME"`
+}
+
+func (flag *GiteaTeamFlags) GetUsers() []string {
+	return flag.Users
+}
+
+func (flag *GiteaTeamFlags) GetGroups() []string {
+	return append(flag.Orgs, flag.Teams...)
+}
@@ -0,0 +1,514 @@
+package skyserver
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/dex/storage"
+	"golang.org/x/oauth2"
+)
+
+// The device authorization grant (RFC 8628) lets fly log in from machines
+// without a browser: fly asks for a device code, the user logs in as usual
+// from any other device and explicitly approves the code, and fly polls until
+// the resulting token is handed over.
+//
+// Both forms involved are bound to a CSRF token kept in a same-site cookie,
+// so that another site can't make a logged in user approve its device.
+const (
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	deviceCodeValidFor = 5 * time.Minute
+	devicePollInterval = 5 * time.Second
+
+	deviceTokenPending  = "authorization_pending"
+	deviceTokenApproval = "awaiting_approval"
+	deviceTokenComplete = "complete"
+	deviceTokenDenied   = "access_denied"
+	deviceTokenRedeemed = "redeemed"
+
+	errSlowDown             = "slow_down"
+	errExpiredToken         = "expired_token"
+	errInvalidGrant         = "invalid_grant"
+	errInvalidRequest       = "invalid_request"
+	errUnsupportedGrantType = "unsupported_grant_type"
+)
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type deviceToken struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	Expiry      time.Time `json:"expiry"`
+
+	// Approval is the hash of the CSRF token handed to the browser asking the
+	// user to approve the device, so that no other browser can approve it.
+	Approval string `json:"approval,omitempty"`
+}
+
+type deviceErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// DeviceCode starts a device authorization by handing out a device code for
+// the client to poll with and a user code for the user to approve.
+func (s *SkyServer) DeviceCode(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device-code")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	verificationURL, err := s.deviceVerificationURL()
+	if err != nil {
+		logger.Error("failed-to-build-verification-url", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	expiry := now.Add(deviceCodeValidFor)
+
+	request := storage.DeviceRequest{
+		UserCode:   storage.NewUserCode(),
+		DeviceCode: storage.NewDeviceCode(),
+		ClientID:   r.FormValue("client_id"),
+		Scopes:     strings.Fields(r.FormValue("scope")),
+		Expiry:     expiry,
+	}
+
+	err = s.config.Storage.CreateDeviceRequest(request)
+	if err != nil {
+		logger.Error("failed-to-create-device-request", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = s.config.Storage.CreateDeviceToken(storage.DeviceToken{
+		DeviceCode:      request.DeviceCode,
+		Status:          deviceTokenPending,
+		Expiry:          expiry,
+		LastRequestTime: now,
+	})
+	if err != nil {
+		logger.Error("failed-to-create-device-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	complete := *verificationURL
+	complete.RawQuery = url.Values{"user_code": {request.UserCode}}.Encode()
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, deviceCodeResponse{
+		DeviceCode:              request.DeviceCode,
+		UserCode:                request.UserCode,
+		VerificationURI:         verificationURL.String(),
+		VerificationURIComplete: complete.String(),
+		ExpiresIn:               int(deviceCodeValidFor.Seconds()),
+		Interval:                int(devicePollInterval.Seconds()),
+	})
+}
+
+// Device shows the page where the user enters (or confirms) their user code,
+// and on submission sends them off to log in on behalf of the device.
+func (s *SkyServer) Device(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device")
+
+	userCode := strings.ToUpper(strings.TrimSpace(r.FormValue("user_code")))
+
+	switch r.Method {
+	case http.MethodGet:
+		s.renderDevicePage(w, logger, http.StatusOK, userCode, "")
+
+	case http.MethodPost:
+		if !s.validDeviceCSRFToken(r) {
+			s.renderDevicePage(w, logger, http.StatusForbidden, userCode, "The page has expired, please submit the code again.")
+			return
+		}
+
+		request, err := s.config.Storage.GetDeviceRequest(userCode)
+		if err != nil || time.Now().After(request.Expiry) {
+			if err != nil && err != storage.ErrNotFound {
+				logger.Error("failed-to-get-device-request", err)
+			}
+			s.renderDevicePage(w, logger, http.StatusBadRequest, userCode, "The code is invalid or has expired.")
+			return
+		}
+
+		s.config.TokenMiddleware.UnsetDeviceCSRFToken(w)
+
+		s.authorize(w, r, logger, stateToken{
+			RedirectURI: "/",
+			UserCode:    request.UserCode,
+			Entropy:     randomString(),
+		})
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// DeviceToken is polled by the client until the user has approved its
+// device code, at which point the access token is handed over exactly once.
+func (s *SkyServer) DeviceToken(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device-token")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.FormValue("grant_type") != GrantTypeDeviceCode {
+		writeDeviceError(w, errUnsupportedGrantType, "")
+		return
+	}
+
+	deviceCode := r.FormValue("device_code")
+	if deviceCode == "" {
+		writeDeviceError(w, errInvalidRequest, "missing device_code")
+		return
+	}
+
+	now := time.Now()
+
+	var (
+		polled   storage.DeviceToken
+		slowDown bool
+	)
+	err := s.config.Storage.UpdateDeviceToken(deviceCode, func(old storage.DeviceToken) (storage.DeviceToken, error) {
+		polled = old
+
+		switch old.Status {
+		case deviceTokenPending, deviceTokenApproval:
+			if now.Before(old.LastRequestTime.Add(time.Duration(old.PollIntervalSeconds) * time.Second)) {
+				old.PollIntervalSeconds += int(devicePollInterval.Seconds())
+				slowDown = true
+			} else {
+				old.PollIntervalSeconds = int(devicePollInterval.Seconds())
+			}
+			old.LastRequestTime = now
+		case deviceTokenComplete:
+			old.Status = deviceTokenRedeemed
+			old.Token = ""
+		}
+
+		return old, nil
+	})
+	if err == storage.ErrNotFound {
+		writeDeviceError(w, errInvalidGrant, "unknown device_code")
+		return
+	}
+	if err != nil {
+		logger.Error("failed-to-update-device-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if now.After(polled.Expiry) {
+		writeDeviceError(w, errExpiredToken, "")
+		return
+	}
+
+	switch polled.Status {
+	case deviceTokenPending, deviceTokenApproval:
+		if slowDown {
+			writeDeviceError(w, errSlowDown, "")
+		} else {
+			writeDeviceError(w, deviceTokenPending, "")
+		}
+
+	case deviceTokenComplete:
+		var token deviceToken
+		err = json.Unmarshal([]byte(polled.Token), &token)
+		if err != nil {
+			logger.Error("failed-to-unmarshal-device-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(w, http.StatusOK, deviceTokenResponse{
+			AccessToken: token.AccessToken,
+			TokenType:   token.TokenType,
+			ExpiresIn:   int(time.Until(token.Expiry).Seconds()),
+		})
+
+	case deviceTokenDenied:
+		writeDeviceError(w, deviceTokenDenied, "the user denied the login")
+
+	default:
+		writeDeviceError(w, errInvalidGrant, "device_code has already been used")
+	}
+}
+
+// confirmDevice is called instead of setting cookies when the login being
+// called back was started from the device page: the token is held back for
+// the device waiting on the user code, and the user is asked to approve
+// handing it over.
+func (s *SkyServer) confirmDevice(w http.ResponseWriter, oauth2Token *oauth2.Token, userCode string) {
+	logger := s.config.Logger.Session("confirm-device")
+
+	request, err := s.config.Storage.GetDeviceRequest(userCode)
+	if err != nil || time.Now().After(request.Expiry) {
+		if err != nil && err != storage.ErrNotFound {
+			logger.Error("failed-to-get-device-request", err)
+		}
+		http.Error(w, "the code has expired, please log in from your device again", http.StatusBadRequest)
+		return
+	}
+
+	csrfToken := randomString()
+
+	token, err := json.Marshal(deviceToken{
+		AccessToken: oauth2Token.AccessToken,
+		TokenType:   oauth2Token.TokenType,
+		Expiry:      oauth2Token.Expiry,
+		Approval:    approvalHash(csrfToken),
+	})
+	if err != nil {
+		logger.Error("failed-to-marshal-device-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = s.config.Storage.UpdateDeviceToken(request.DeviceCode, func(old storage.DeviceToken) (storage.DeviceToken, error) {
+		if old.Status != deviceTokenPending && old.Status != deviceTokenApproval {
+			return old, errors.New("device code has already been approved")
+		}
+
+		old.Status = deviceTokenApproval
+		old.Token = string(token)
+		return old, nil
+	})
+	if err != nil {
+		logger.Error("failed-to-update-device-token", err)
+		http.Error(w, "the code has already been used", http.StatusBadRequest)
+		return
+	}
+
+	err = s.config.TokenMiddleware.SetDeviceCSRFToken(w, csrfToken, request.Expiry)
+	if err != nil {
+		logger.Error("failed-to-set-device-csrf-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	deviceApprovalTemplate.Execute(w, struct {
+		UserCode  string
+		CSRFToken string
+	}{request.UserCode, csrfToken})
+}
+
+// DeviceApprove hands the token held back by confirmDevice over to the
+// device, or throws it away if the user denies the login.
+func (s *SkyServer) DeviceApprove(w http.ResponseWriter, r *http.Request) {
+	logger := s.config.Logger.Session("device-approve")
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !s.validDeviceCSRFToken(r) {
+		http.Error(w, "the page has expired, please log in from your device again", http.StatusForbidden)
+		return
+	}
+
+	userCode := strings.ToUpper(strings.TrimSpace(r.FormValue("user_code")))
+	approved := r.FormValue("approve") == "true"
+
+	request, err := s.config.Storage.GetDeviceRequest(userCode)
+	if err != nil || time.Now().After(request.Expiry) {
+		if err != nil && err != storage.ErrNotFound {
+			logger.Error("failed-to-get-device-request", err)
+		}
+		http.Error(w, "the code has expired, please log in from your device again", http.StatusBadRequest)
+		return
+	}
+
+	approval := approvalHash(r.FormValue("csrf_token"))
+
+	err = s.config.Storage.UpdateDeviceToken(request.DeviceCode, func(old storage.DeviceToken) (storage.DeviceToken, error) {
+		if old.Status != deviceTokenApproval {
+			return old, errors.New("device code is not awaiting approval")
+		}
+
+		var token deviceToken
+		err := json.Unmarshal([]byte(old.Token), &token)
+		if err != nil {
+			return old, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token.Approval), []byte(approval)) != 1 {
+			return old, errors.New("device code was not logged in from this browser")
+		}
+
+		if !approved {
+			old.Status = deviceTokenDenied
+			old.Token = ""
+			return old, nil
+		}
+
+		token.Approval = ""
+
+		approvedToken, err := json.Marshal(token)
+		if err != nil {
+			return old, err
+		}
+
+		old.Status = deviceTokenComplete
+		old.Token = string(approvedToken)
+		return old, nil
+	})
+	if err != nil {
+		logger.Error("failed-to-update-device-token", err)
+		http.Error(w, "the code can no longer be approved, please log in from your device again", http.StatusBadRequest)
+		return
+	}
+
+	s.config.TokenMiddleware.UnsetDeviceCSRFToken(w)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if approved {
+		deviceSuccessTemplate.Execute(w, nil)
+	} else {
+		deviceDeniedTemplate.Execute(w, nil)
+	}
+}
+
+func (s *SkyServer) validDeviceCSRFToken(r *http.Request) bool {
+	cookie := s.config.TokenMiddleware.GetDeviceCSRFToken(r)
+	form := r.FormValue("csrf_token")
+
+	return cookie != "" && subtle.ConstantTimeCompare([]byte(cookie), []byte(form)) == 1
+}
+
+func approvalHash(csrfToken string) string {
+	sum := sha256.Sum256([]byte(csrfToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *SkyServer) deviceVerificationURL() (*url.URL, error) {
+	base, err := url.Parse(s.config.OAuthConfig.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ResolveReference(&url.URL{Path: "/sky/device"}), nil
+}
+
+func writeDeviceError(w http.ResponseWriter, code string, description string) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusBadRequest, deviceErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// renderDevicePage renders the user code form along with a new CSRF token for
+// it to be submitted with.
+func (s *SkyServer) renderDevicePage(w http.ResponseWriter, logger lager.Logger, status int, userCode string, message string) {
+	csrfToken := randomString()
+
+	err := s.config.TokenMiddleware.SetDeviceCSRFToken(w, csrfToken, time.Now().Add(deviceCodeValidFor))
+	if err != nil {
+		logger.Error("failed-to-set-device-csrf-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	devicePageTemplate.Execute(w, struct {
+		UserCode  string
+		CSRFToken string
+		Message   string
+	}{userCode, csrfToken, message})
+}
+
+var devicePageTemplate = template.Must(template.New("device").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Concourse - Log in a device</title></head>
+<body>
+  <h2>Log in a device</h2>
+  {{ with .Message }}<p>{{ . }}</p>{{ end }}
+  <p>Check that the code below matches the one shown on your device, then log in to approve it.</p>
+  <form method="post" action="/sky/device">
+    <input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+    <input type="text" name="user_code" placeholder="XXXX-XXXX" value="{{ .UserCode }}" autofocus required>
+    <button type="submit">Log in</button>
+  </form>
+</body>
+</html>
+`))
+
+var deviceApprovalTemplate = template.Must(template.New("device-approval").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Concourse - Approve device</title></head>
+<body>
+  <h2>Approve device {{ .UserCode }}?</h2>
+  <p>Approving logs the device showing the code {{ .UserCode }} in as you. Only approve it if you started this login yourself.</p>
+  <form method="post" action="/sky/device/approve">
+    <input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+    <input type="hidden" name="user_code" value="{{ .UserCode }}">
+    <button type="submit" name="approve" value="true">Approve</button>
+    <button type="submit" name="approve" value="false">Deny</button>
+  </form>
+</body>
+</html>
+`))
+
+var deviceDeniedTemplate = template.Must(template.New("device-denied").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Concourse - Device login denied</title></head>
+<body>
+  <h2>Device login denied</h2>
+  <p>The device has not been logged in. You can close this window.</p>
+</body>
+</html>
+`))
+
+var deviceSuccessTemplate = template.Must(template.New("device-success").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Concourse - Device logged in</title></head>
+<body>
+  <h2>Device logged in</h2>
+  <p>You can close this window and return to your device.</p>
+</body>
+</html>
+`))
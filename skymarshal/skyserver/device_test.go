@@ -0,0 +1,487 @@
+package skyserver_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/skymarshal/skyserver"
+	"github.com/concourse/dex/storage"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Device Authorization", func() {
+	var (
+		response *http.Response
+		body     []byte
+	)
+
+	BeforeEach(func() {
+		skyServer.Start()
+
+		skyServer.Client().CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	})
+
+	do := func(method string, path string, form url.Values) {
+		request, err := http.NewRequest(method, skyServer.URL+path, strings.NewReader(form.Encode()))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		response, err = skyServer.Client().Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		body, err = ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	createDeviceCode := func(userCode string, deviceCode string, expiry time.Time) {
+		err := dexStorage.CreateDeviceRequest(storage.DeviceRequest{
+			UserCode:   userCode,
+			DeviceCode: deviceCode,
+			ClientID:   "fly",
+			Expiry:     expiry,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = dexStorage.CreateDeviceToken(storage.DeviceToken{
+			DeviceCode:      deviceCode,
+			Status:          "authorization_pending",
+			Expiry:          expiry,
+			LastRequestTime: time.Now().Add(-time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	deviceError := func() string {
+		var errResponse struct {
+			Error string `json:"error"`
+		}
+		Expect(json.Unmarshal(body, &errResponse)).To(Succeed())
+		return errResponse.Error
+	}
+
+	Describe("POST /sky/device/code", func() {
+		JustBeforeEach(func() {
+			do("POST", "/sky/device/code", url.Values{
+				"client_id": {"fly"},
+				"scope":     {"openid groups"},
+			})
+		})
+
+		It("hands out a device code and a user code", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Cache-Control")).To(Equal("no-store"))
+
+			var code struct {
+				DeviceCode              string `json:"device_code"`
+				UserCode                string `json:"user_code"`
+				VerificationURI         string `json:"verification_uri"`
+				VerificationURIComplete string `json:"verification_uri_complete"`
+				ExpiresIn               int    `json:"expires_in"`
+				Interval                int    `json:"interval"`
+			}
+			Expect(json.Unmarshal(body, &code)).To(Succeed())
+
+			Expect(code.DeviceCode).NotTo(BeEmpty())
+			Expect(code.UserCode).To(MatchRegexp(`^[A-Z]{4}-[A-Z]{4}$`))
+			Expect(code.VerificationURI).To(Equal("https://concourse.example.com/sky/device"))
+			Expect(code.VerificationURIComplete).To(Equal("https://concourse.example.com/sky/device?user_code=" + code.UserCode))
+			Expect(code.ExpiresIn).To(Equal(300))
+			Expect(code.Interval).To(Equal(5))
+
+			request, err := dexStorage.GetDeviceRequest(code.UserCode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.DeviceCode).To(Equal(code.DeviceCode))
+			Expect(request.ClientID).To(Equal("fly"))
+			Expect(request.Scopes).To(Equal([]string{"openid", "groups"}))
+
+			token, err := dexStorage.GetDeviceToken(code.DeviceCode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Status).To(Equal("authorization_pending"))
+		})
+	})
+
+	Describe("GET /sky/device", func() {
+		JustBeforeEach(func() {
+			do("GET", "/sky/device?user_code=abcd-efgh", nil)
+		})
+
+		It("asks the user to confirm the code", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring(`value="ABCD-EFGH"`))
+			Expect(string(body)).NotTo(ContainSubstring("invalid"))
+		})
+
+		It("binds the form to a CSRF token", func() {
+			Expect(fakeTokenMiddleware.SetDeviceCSRFTokenCallCount()).To(Equal(1))
+			_, csrfToken, _ := fakeTokenMiddleware.SetDeviceCSRFTokenArgsForCall(0)
+			Expect(csrfToken).NotTo(BeEmpty())
+			Expect(string(body)).To(ContainSubstring(`name="csrf_token" value="` + csrfToken + `"`))
+		})
+	})
+
+	Describe("POST /sky/device", func() {
+		var (
+			expiry    time.Time
+			csrfToken string
+		)
+
+		BeforeEach(func() {
+			expiry = time.Now().Add(time.Minute)
+			csrfToken = "some-csrf-token"
+
+			fakeTokenMiddleware.GetDeviceCSRFTokenReturns("some-csrf-token")
+		})
+
+		JustBeforeEach(func() {
+			createDeviceCode("ABCD-EFGH", "some-device-code", expiry)
+
+			do("POST", "/sky/device", url.Values{
+				"user_code":  {"abcd-efgh"},
+				"csrf_token": {csrfToken},
+			})
+		})
+
+		It("starts a login on behalf of the device", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusTemporaryRedirect))
+
+			redirectURL, err := response.Location()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redirectURL.Path).To(Equal("/auth"))
+
+			Expect(fakeTokenMiddleware.SetStateTokenCallCount()).To(Equal(1))
+			_, raw, _ := fakeTokenMiddleware.SetStateTokenArgsForCall(0)
+			Expect(redirectURL.Query().Get("state")).To(Equal(raw))
+
+			data, err := base64.StdEncoding.DecodeString(raw)
+			Expect(err).NotTo(HaveOccurred())
+
+			var state map[string]string
+			Expect(json.Unmarshal(data, &state)).To(Succeed())
+			Expect(state["user_code"]).To(Equal("ABCD-EFGH"))
+		})
+
+		Context("when the code has expired", func() {
+			BeforeEach(func() {
+				expiry = time.Now().Add(-time.Minute)
+			})
+
+			It("asks for the code again", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(string(body)).To(ContainSubstring("invalid or has expired"))
+				Expect(fakeTokenMiddleware.SetStateTokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the CSRF token does not match", func() {
+			BeforeEach(func() {
+				csrfToken = "some-other-csrf-token"
+			})
+
+			It("does not start a login", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(string(body)).To(ContainSubstring("submit the code again"))
+				Expect(fakeTokenMiddleware.SetStateTokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when there is no CSRF cookie, e.g. for a cross-site form", func() {
+			BeforeEach(func() {
+				csrfToken = ""
+				fakeTokenMiddleware.GetDeviceCSRFTokenReturns("")
+			})
+
+			It("does not start a login", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTokenMiddleware.SetStateTokenCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /sky/device/token", func() {
+		var form url.Values
+
+		BeforeEach(func() {
+			createDeviceCode("ABCD-EFGH", "some-device-code", time.Now().Add(time.Minute))
+
+			form = url.Values{
+				"grant_type":  {skyserver.GrantTypeDeviceCode},
+				"device_code": {"some-device-code"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			do("POST", "/sky/device/token", form)
+		})
+
+		Context("when the device code has not been approved yet", func() {
+			It("asks the client to keep polling", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("authorization_pending"))
+			})
+
+			It("asks the client to slow down when it polls too often", func() {
+				do("POST", "/sky/device/token", form)
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("slow_down"))
+			})
+		})
+
+		Context("when the device code has been approved", func() {
+			BeforeEach(func() {
+				err := dexStorage.UpdateDeviceToken("some-device-code", func(old storage.DeviceToken) (storage.DeviceToken, error) {
+					old.Status = "complete"
+					old.Token = `{"access_token":"some-token","token_type":"bearer","expiry":"` +
+						time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`
+					return old, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("hands over the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var token struct {
+					AccessToken string `json:"access_token"`
+					TokenType   string `json:"token_type"`
+					ExpiresIn   int    `json:"expires_in"`
+				}
+				Expect(json.Unmarshal(body, &token)).To(Succeed())
+				Expect(token.AccessToken).To(Equal("some-token"))
+				Expect(token.TokenType).To(Equal("bearer"))
+				Expect(token.ExpiresIn).To(BeNumerically("~", 3600, 5))
+			})
+
+			It("only hands it over once", func() {
+				do("POST", "/sky/device/token", form)
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("invalid_grant"))
+			})
+		})
+
+		Context("when the device code has expired", func() {
+			BeforeEach(func() {
+				createDeviceCode("HGFE-DCBA", "expired-device-code", time.Now().Add(-time.Minute))
+				form.Set("device_code", "expired-device-code")
+			})
+
+			It("tells the client to give up", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("expired_token"))
+			})
+		})
+
+		Context("when the device code is unknown", func() {
+			BeforeEach(func() {
+				form.Set("device_code", "bogus")
+			})
+
+			It("errors", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("invalid_grant"))
+			})
+		})
+
+		Context("when the grant type is not the device code grant", func() {
+			BeforeEach(func() {
+				form.Set("grant_type", "authorization_code")
+			})
+
+			It("errors", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("unsupported_grant_type"))
+			})
+		})
+	})
+
+	Describe("GET /sky/callback for a device login", func() {
+		var expiry time.Time
+
+		BeforeEach(func() {
+			expiry = time.Now().Add(time.Minute)
+
+			dexServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/token"),
+					ghttp.VerifyFormKV("code", "some-code"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"token_type":   "bearer",
+						"access_token": "some-token",
+						"expires_in":   3600,
+					}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			createDeviceCode("ABCD-EFGH", "some-device-code", expiry)
+
+			state, err := json.Marshal(map[string]string{
+				"redirect_uri": "/",
+				"user_code":    "ABCD-EFGH",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			stateToken := base64.StdEncoding.EncodeToString(state)
+			fakeTokenMiddleware.GetStateTokenReturns(stateToken)
+
+			do("GET", "/sky/callback?"+url.Values{"code": {"some-code"}, "state": {stateToken}}.Encode(), nil)
+		})
+
+		It("asks the user to approve the device before handing over the token", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring("Approve device ABCD-EFGH?"))
+			Expect(fakeTokenMiddleware.SetAuthTokenCallCount()).To(BeZero())
+
+			Expect(fakeTokenMiddleware.SetDeviceCSRFTokenCallCount()).To(Equal(1))
+			_, csrfToken, _ := fakeTokenMiddleware.SetDeviceCSRFTokenArgsForCall(0)
+			Expect(string(body)).To(ContainSubstring(`name="csrf_token" value="` + csrfToken + `"`))
+
+			token, err := dexStorage.GetDeviceToken("some-device-code")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Status).To(Equal("awaiting_approval"))
+		})
+
+		It("keeps the device polling until the user approves", func() {
+			do("POST", "/sky/device/token", url.Values{
+				"grant_type":  {skyserver.GrantTypeDeviceCode},
+				"device_code": {"some-device-code"},
+			})
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(deviceError()).To(Equal("authorization_pending"))
+		})
+
+		Context("when the device code has expired in the meantime", func() {
+			BeforeEach(func() {
+				expiry = time.Now().Add(-time.Minute)
+			})
+
+			It("errors", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(string(body)).To(ContainSubstring("expired"))
+			})
+		})
+	})
+
+	Describe("POST /sky/device/approve", func() {
+		var (
+			stateToken string
+			form       url.Values
+			csrfCookie string
+		)
+
+		BeforeEach(func() {
+			form = url.Values{
+				"user_code": {"ABCD-EFGH"},
+				"approve":   {"true"},
+			}
+			csrfCookie = ""
+
+			createDeviceCode("ABCD-EFGH", "some-device-code", time.Now().Add(time.Minute))
+
+			dexServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"token_type":   "bearer",
+						"access_token": "some-token",
+						"expires_in":   3600,
+					}),
+				),
+			)
+
+			state, err := json.Marshal(map[string]string{
+				"redirect_uri": "/",
+				"user_code":    "ABCD-EFGH",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			stateToken = base64.StdEncoding.EncodeToString(state)
+			fakeTokenMiddleware.GetStateTokenReturns(stateToken)
+		})
+
+		JustBeforeEach(func() {
+			do("GET", "/sky/callback?"+url.Values{"code": {"some-code"}, "state": {stateToken}}.Encode(), nil)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			_, csrfToken, _ := fakeTokenMiddleware.SetDeviceCSRFTokenArgsForCall(0)
+			if csrfCookie == "" {
+				csrfCookie = csrfToken
+			}
+			fakeTokenMiddleware.GetDeviceCSRFTokenReturns(csrfCookie)
+
+			if form.Get("csrf_token") == "" {
+				form.Set("csrf_token", csrfToken)
+			}
+
+			do("POST", "/sky/device/approve", form)
+		})
+
+		It("hands the token over to the device", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring("return to your device"))
+			Expect(fakeTokenMiddleware.UnsetDeviceCSRFTokenCallCount()).To(Equal(1))
+
+			token, err := dexStorage.GetDeviceToken("some-device-code")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Status).To(Equal("complete"))
+			Expect(token.Token).To(ContainSubstring(`"access_token":"some-token"`))
+			Expect(token.Token).NotTo(ContainSubstring("approval"))
+		})
+
+		Context("when the user denies the login", func() {
+			BeforeEach(func() {
+				form.Set("approve", "false")
+			})
+
+			It("tells the device that it was denied", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(string(body)).To(ContainSubstring("Device login denied"))
+
+				do("POST", "/sky/device/token", url.Values{
+					"grant_type":  {skyserver.GrantTypeDeviceCode},
+					"device_code": {"some-device-code"},
+				})
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(deviceError()).To(Equal("access_denied"))
+			})
+		})
+
+		Context("when the CSRF token does not match", func() {
+			BeforeEach(func() {
+				form.Set("csrf_token", "bogus")
+			})
+
+			It("does not hand over the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+
+				token, err := dexStorage.GetDeviceToken("some-device-code")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.Status).To(Equal("awaiting_approval"))
+			})
+		})
+
+		Context("when it is approved from another browser", func() {
+			BeforeEach(func() {
+				csrfCookie = "another-browsers-csrf-token"
+				form.Set("csrf_token", "another-browsers-csrf-token")
+			})
+
+			It("does not hand over the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+				token, err := dexStorage.GetDeviceToken("some-device-code")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.Status).To(Equal("awaiting_approval"))
+			})
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/skymarshal/storage"
 	"github.com/concourse/concourse/skymarshal/token"
 	"golang.org/x/oauth2"
 	"gopkg.in/square/go-jose.v2/jwt"
@@ -25,6 +26,7 @@ type SkyConfig struct {
 	TokenParser     token.Parser
 	OAuthConfig     *oauth2.Config
 	HTTPClient      *http.Client
+	Storage         storage.Storage
 }
 
 func NewSkyHandler(server *SkyServer) http.Handler {
@@ -32,6 +34,10 @@ func NewSkyHandler(server *SkyServer) http.Handler {
 	handler.HandleFunc("/sky/login", server.Login)
 	handler.HandleFunc("/sky/logout", server.Logout)
 	handler.HandleFunc("/sky/callback", server.Callback)
+	handler.HandleFunc("/sky/device", server.Device)
+	handler.HandleFunc("/sky/device/approve", server.DeviceApprove)
+	handler.HandleFunc("/sky/device/code", server.DeviceCode)
+	handler.HandleFunc("/sky/device/token", server.DeviceToken)
 	return handler
 }
 
@@ -97,10 +103,14 @@ func (s *SkyServer) NewLogin(w http.ResponseWriter, r *http.Request) {
 		redirectURI = "/"
 	}
 
-	stateToken := encode(stateToken{
+	s.authorize(w, r, logger, stateToken{
 		RedirectURI: redirectURI,
 		Entropy:     randomString(),
 	})
+}
+
+func (s *SkyServer) authorize(w http.ResponseWriter, r *http.Request, logger lager.Logger, state stateToken) {
+	stateToken := encode(state)
 
 	err := s.config.TokenMiddleware.SetStateToken(w, stateToken, time.Now().Add(time.Hour))
 	if err != nil {
@@ -154,7 +164,13 @@ func (s *SkyServer) Callback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	s.Redirect(w, r, dexToken, decode(stateToken).RedirectURI)
+	state := decode(stateToken)
+	if state.UserCode != "" {
+		s.confirmDevice(w, dexToken, state.UserCode)
+		return
+	}
+
+	s.Redirect(w, r, dexToken, state.RedirectURI)
 }
 
 func (s *SkyServer) Redirect(w http.ResponseWriter, r *http.Request, oauth2Token *oauth2.Token, redirectURI string) {
@@ -202,6 +218,7 @@ func (s *SkyServer) Logout(w http.ResponseWriter, r *http.Request) {
 
 type stateToken struct {
 	RedirectURI string `json:"redirect_uri"`
+	UserCode    string `json:"user_code,omitempty"`
 	Entropy     string `json:"entropy"`
 }
 
@@ -10,8 +10,11 @@ import (
 	. "github.com/onsi/gomega"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/skymarshal/logger"
 	"github.com/concourse/concourse/skymarshal/skyserver"
+	"github.com/concourse/concourse/skymarshal/storage"
 	"github.com/concourse/concourse/skymarshal/token/tokenfakes"
+	"github.com/concourse/dex/storage/memory"
 	"github.com/onsi/gomega/ghttp"
 	"golang.org/x/oauth2"
 )
@@ -19,6 +22,7 @@ import (
 var (
 	fakeTokenMiddleware *tokenfakes.FakeMiddleware
 	fakeTokenParser     *tokenfakes.FakeParser
+	dexStorage          storage.Storage
 	skyServer           *httptest.Server
 	dexServer           *ghttp.Server
 	signingKey          *rsa.PrivateKey
@@ -35,6 +39,7 @@ var _ = BeforeEach(func() {
 
 	fakeTokenMiddleware = new(tokenfakes.FakeMiddleware)
 	fakeTokenParser = new(tokenfakes.FakeParser)
+	dexStorage = memory.New(logger.New(lagertest.NewTestLogger("dex")))
 
 	dexServer = ghttp.NewTLSServer()
 
@@ -51,6 +56,7 @@ var _ = BeforeEach(func() {
 		Endpoint:     endpoint,
 		ClientID:     "dex-client-id",
 		ClientSecret: "dex-client-secret",
+		RedirectURL:  "https://concourse.example.com/sky/callback",
 		Scopes:       []string{"some-scope"},
 	}
 
@@ -60,6 +66,7 @@ var _ = BeforeEach(func() {
 		TokenParser:     fakeTokenParser,
 		OAuthConfig:     oauthConfig,
 		HTTPClient:      dexServer.HTTPTestServer.Client(),
+		Storage:         dexStorage,
 	}
 
 	server, err := skyserver.NewSkyServer(config)
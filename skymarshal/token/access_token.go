@@ -93,7 +93,7 @@ func StoreAccessToken(
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		err = accessTokenFactory.CreateAccessToken(resp.AccessToken, claims)
+		err = accessTokenFactory.CreateAccessToken(resp.AccessToken, claims, r.RemoteAddr)
 		if err != nil {
 			logger.Error("create-access-token-in-db", err)
 			w.WriteHeader(http.StatusInternalServerError)
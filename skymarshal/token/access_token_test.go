@@ -168,6 +168,23 @@ var _ = Describe("Access Tokens", func() {
 				Expect(rec.Body.String()).To(Equal(t.expectBody))
 			})
 		}
+
+		It("stores the address the token was requested from", func() {
+			baseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"access_token":"123","token_type":"bearer","expires_in":1234,"id_token":"a.b.c"}`))
+			})
+			handler := token.StoreAccessToken(dummyLogger, baseHandler, generator, claimsParser, accessTokenFactory, userFactory, displayUserIdGenerator)
+			generator.GenerateAccessTokenReturns("123abc", nil)
+
+			r, _ := http.NewRequest("GET", "/sky/issuer/token", nil)
+			r.RemoteAddr = "10.0.0.1:12345"
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			Expect(accessTokenFactory.CreateAccessTokenCallCount()).To(Equal(1))
+			rawToken, _, remoteAddr := accessTokenFactory.CreateAccessTokenArgsForCall(0)
+			Expect(rawToken).To(Equal("123abc"))
+			Expect(remoteAddr).To(Equal("10.0.0.1:12345"))
+		})
 	})
 
 	Describe("Token Generation", func() {
@@ -18,6 +18,10 @@ type Middleware interface {
 	SetStateToken(http.ResponseWriter, string, time.Time) error
 	UnsetStateToken(http.ResponseWriter)
 	GetStateToken(*http.Request) string
+
+	SetDeviceCSRFToken(http.ResponseWriter, string, time.Time) error
+	UnsetDeviceCSRFToken(http.ResponseWriter)
+	GetDeviceCSRFToken(*http.Request) string
 }
 
 type middleware struct {
@@ -31,6 +35,11 @@ func NewMiddleware(secureCookies bool) Middleware {
 const stateCookieName = "skymarshal_state"
 const authCookieName = "skymarshal_auth"
 const csrfCookieName = "skymarshal_csrf"
+const deviceCSRFCookieName = "skymarshal_device_csrf"
+
+// deviceCookiePath scopes the device CSRF cookie to the pages approving
+// device logins.
+const deviceCookiePath = "/sky/device"
 
 func (m *middleware) UnsetAuthToken(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
@@ -124,3 +133,36 @@ func (m *middleware) GetStateToken(r *http.Request) string {
 	}
 	return cookie.Value
 }
+
+func (m *middleware) UnsetDeviceCSRFToken(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     deviceCSRFCookieName,
+		Path:     deviceCookiePath,
+		MaxAge:   -1,
+		Secure:   m.secureCookies,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (m *middleware) SetDeviceCSRFToken(w http.ResponseWriter, csrfToken string, expiry time.Time) error {
+	http.SetCookie(w, &http.Cookie{
+		Name:     deviceCSRFCookieName,
+		Value:    csrfToken,
+		Path:     deviceCookiePath,
+		Expires:  expiry,
+		Secure:   m.secureCookies,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return nil
+}
+
+func (m *middleware) GetDeviceCSRFToken(r *http.Request) string {
+	cookie, err := r.Cookie(deviceCSRFCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -169,4 +169,52 @@ var _ = Describe("Token Middleware", func() {
 			})
 		})
 	})
+
+	Describe("Device CSRF Tokens", func() {
+
+		Describe("GetDeviceCSRFToken", func() {
+			var result string
+
+			BeforeEach(func() {
+				r.AddCookie(&http.Cookie{Name: "skymarshal_device_csrf", Value: "blah"})
+			})
+
+			JustBeforeEach(func() {
+				result = middleware.GetDeviceCSRFToken(r)
+			})
+
+			It("gets the token from the request", func() {
+				Expect(result).To(Equal("blah"))
+			})
+		})
+
+		Describe("SetDeviceCSRFToken", func() {
+			JustBeforeEach(func() {
+				err = middleware.SetDeviceCSRFToken(w, "blah", expiry)
+			})
+
+			It("writes the token to a strict same-site cookie for the device pages", func() {
+				cookies := w.Result().Cookies()
+				Expect(cookies).To(HaveLen(1))
+				Expect(cookies[0].Name).To(Equal("skymarshal_device_csrf"))
+				Expect(cookies[0].Path).To(Equal("/sky/device"))
+				Expect(cookies[0].SameSite).To(Equal(http.SameSiteStrictMode))
+				Expect(cookies[0].Expires.Unix()).To(Equal(expiry.Unix()))
+				Expect(cookies[0].Value).To(Equal("blah"))
+			})
+		})
+
+		Describe("UnsetDeviceCSRFToken", func() {
+			JustBeforeEach(func() {
+				middleware.UnsetDeviceCSRFToken(w)
+			})
+
+			It("clears the token from the cookie", func() {
+				cookies := w.Result().Cookies()
+				Expect(cookies).To(HaveLen(1))
+				Expect(cookies[0].Name).To(Equal("skymarshal_device_csrf"))
+				Expect(cookies[0].Value).To(Equal(""))
+			})
+		})
+	})
 })
@@ -32,6 +32,17 @@ type FakeMiddleware struct {
 	getCSRFTokenReturnsOnCall map[int]struct {
 		result1 string
 	}
+	GetDeviceCSRFTokenStub        func(*http.Request) string
+	getDeviceCSRFTokenMutex       sync.RWMutex
+	getDeviceCSRFTokenArgsForCall []struct {
+		arg1 *http.Request
+	}
+	getDeviceCSRFTokenReturns struct {
+		result1 string
+	}
+	getDeviceCSRFTokenReturnsOnCall map[int]struct {
+		result1 string
+	}
 	GetStateTokenStub        func(*http.Request) string
 	getStateTokenMutex       sync.RWMutex
 	getStateTokenArgsForCall []struct {
@@ -69,6 +80,19 @@ type FakeMiddleware struct {
 	setCSRFTokenReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetDeviceCSRFTokenStub        func(http.ResponseWriter, string, time.Time) error
+	setDeviceCSRFTokenMutex       sync.RWMutex
+	setDeviceCSRFTokenArgsForCall []struct {
+		arg1 http.ResponseWriter
+		arg2 string
+		arg3 time.Time
+	}
+	setDeviceCSRFTokenReturns struct {
+		result1 error
+	}
+	setDeviceCSRFTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetStateTokenStub        func(http.ResponseWriter, string, time.Time) error
 	setStateTokenMutex       sync.RWMutex
 	setStateTokenArgsForCall []struct {
@@ -92,6 +116,11 @@ type FakeMiddleware struct {
 	unsetCSRFTokenArgsForCall []struct {
 		arg1 http.ResponseWriter
 	}
+	UnsetDeviceCSRFTokenStub        func(http.ResponseWriter)
+	unsetDeviceCSRFTokenMutex       sync.RWMutex
+	unsetDeviceCSRFTokenArgsForCall []struct {
+		arg1 http.ResponseWriter
+	}
 	UnsetStateTokenStub        func(http.ResponseWriter)
 	unsetStateTokenMutex       sync.RWMutex
 	unsetStateTokenArgsForCall []struct {
@@ -223,6 +252,67 @@ func (fake *FakeMiddleware) GetCSRFTokenReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeMiddleware) GetDeviceCSRFToken(arg1 *http.Request) string {
+	fake.getDeviceCSRFTokenMutex.Lock()
+	ret, specificReturn := fake.getDeviceCSRFTokenReturnsOnCall[len(fake.getDeviceCSRFTokenArgsForCall)]
+	fake.getDeviceCSRFTokenArgsForCall = append(fake.getDeviceCSRFTokenArgsForCall, struct {
+		arg1 *http.Request
+	}{arg1})
+	stub := fake.GetDeviceCSRFTokenStub
+	fakeReturns := fake.getDeviceCSRFTokenReturns
+	fake.recordInvocation("GetDeviceCSRFToken", []interface{}{arg1})
+	fake.getDeviceCSRFTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMiddleware) GetDeviceCSRFTokenCallCount() int {
+	fake.getDeviceCSRFTokenMutex.RLock()
+	defer fake.getDeviceCSRFTokenMutex.RUnlock()
+	return len(fake.getDeviceCSRFTokenArgsForCall)
+}
+
+func (fake *FakeMiddleware) GetDeviceCSRFTokenCalls(stub func(*http.Request) string) {
+	fake.getDeviceCSRFTokenMutex.Lock()
+	defer fake.getDeviceCSRFTokenMutex.Unlock()
+	fake.GetDeviceCSRFTokenStub = stub
+}
+
+func (fake *FakeMiddleware) GetDeviceCSRFTokenArgsForCall(i int) *http.Request {
+	fake.getDeviceCSRFTokenMutex.RLock()
+	defer fake.getDeviceCSRFTokenMutex.RUnlock()
+	argsForCall := fake.getDeviceCSRFTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMiddleware) GetDeviceCSRFTokenReturns(result1 string) {
+	fake.getDeviceCSRFTokenMutex.Lock()
+	defer fake.getDeviceCSRFTokenMutex.Unlock()
+	fake.GetDeviceCSRFTokenStub = nil
+	fake.getDeviceCSRFTokenReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeMiddleware) GetDeviceCSRFTokenReturnsOnCall(i int, result1 string) {
+	fake.getDeviceCSRFTokenMutex.Lock()
+	defer fake.getDeviceCSRFTokenMutex.Unlock()
+	fake.GetDeviceCSRFTokenStub = nil
+	if fake.getDeviceCSRFTokenReturnsOnCall == nil {
+		fake.getDeviceCSRFTokenReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.getDeviceCSRFTokenReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeMiddleware) GetStateToken(arg1 *http.Request) string {
 	fake.getStateTokenMutex.Lock()
 	ret, specificReturn := fake.getStateTokenReturnsOnCall[len(fake.getStateTokenArgsForCall)]
@@ -410,6 +500,69 @@ func (fake *FakeMiddleware) SetCSRFTokenReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeMiddleware) SetDeviceCSRFToken(arg1 http.ResponseWriter, arg2 string, arg3 time.Time) error {
+	fake.setDeviceCSRFTokenMutex.Lock()
+	ret, specificReturn := fake.setDeviceCSRFTokenReturnsOnCall[len(fake.setDeviceCSRFTokenArgsForCall)]
+	fake.setDeviceCSRFTokenArgsForCall = append(fake.setDeviceCSRFTokenArgsForCall, struct {
+		arg1 http.ResponseWriter
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.SetDeviceCSRFTokenStub
+	fakeReturns := fake.setDeviceCSRFTokenReturns
+	fake.recordInvocation("SetDeviceCSRFToken", []interface{}{arg1, arg2, arg3})
+	fake.setDeviceCSRFTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMiddleware) SetDeviceCSRFTokenCallCount() int {
+	fake.setDeviceCSRFTokenMutex.RLock()
+	defer fake.setDeviceCSRFTokenMutex.RUnlock()
+	return len(fake.setDeviceCSRFTokenArgsForCall)
+}
+
+func (fake *FakeMiddleware) SetDeviceCSRFTokenCalls(stub func(http.ResponseWriter, string, time.Time) error) {
+	fake.setDeviceCSRFTokenMutex.Lock()
+	defer fake.setDeviceCSRFTokenMutex.Unlock()
+	fake.SetDeviceCSRFTokenStub = stub
+}
+
+func (fake *FakeMiddleware) SetDeviceCSRFTokenArgsForCall(i int) (http.ResponseWriter, string, time.Time) {
+	fake.setDeviceCSRFTokenMutex.RLock()
+	defer fake.setDeviceCSRFTokenMutex.RUnlock()
+	argsForCall := fake.setDeviceCSRFTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeMiddleware) SetDeviceCSRFTokenReturns(result1 error) {
+	fake.setDeviceCSRFTokenMutex.Lock()
+	defer fake.setDeviceCSRFTokenMutex.Unlock()
+	fake.SetDeviceCSRFTokenStub = nil
+	fake.setDeviceCSRFTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMiddleware) SetDeviceCSRFTokenReturnsOnCall(i int, result1 error) {
+	fake.setDeviceCSRFTokenMutex.Lock()
+	defer fake.setDeviceCSRFTokenMutex.Unlock()
+	fake.SetDeviceCSRFTokenStub = nil
+	if fake.setDeviceCSRFTokenReturnsOnCall == nil {
+		fake.setDeviceCSRFTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setDeviceCSRFTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeMiddleware) SetStateToken(arg1 http.ResponseWriter, arg2 string, arg3 time.Time) error {
 	fake.setStateTokenMutex.Lock()
 	ret, specificReturn := fake.setStateTokenReturnsOnCall[len(fake.setStateTokenArgsForCall)]
@@ -537,6 +690,38 @@ func (fake *FakeMiddleware) UnsetCSRFTokenArgsForCall(i int) http.ResponseWriter
 	return argsForCall.arg1
 }
 
+func (fake *FakeMiddleware) UnsetDeviceCSRFToken(arg1 http.ResponseWriter) {
+	fake.unsetDeviceCSRFTokenMutex.Lock()
+	fake.unsetDeviceCSRFTokenArgsForCall = append(fake.unsetDeviceCSRFTokenArgsForCall, struct {
+		arg1 http.ResponseWriter
+	}{arg1})
+	stub := fake.UnsetDeviceCSRFTokenStub
+	fake.recordInvocation("UnsetDeviceCSRFToken", []interface{}{arg1})
+	fake.unsetDeviceCSRFTokenMutex.Unlock()
+	if stub != nil {
+		fake.UnsetDeviceCSRFTokenStub(arg1)
+	}
+}
+
+func (fake *FakeMiddleware) UnsetDeviceCSRFTokenCallCount() int {
+	fake.unsetDeviceCSRFTokenMutex.RLock()
+	defer fake.unsetDeviceCSRFTokenMutex.RUnlock()
+	return len(fake.unsetDeviceCSRFTokenArgsForCall)
+}
+
+func (fake *FakeMiddleware) UnsetDeviceCSRFTokenCalls(stub func(http.ResponseWriter)) {
+	fake.unsetDeviceCSRFTokenMutex.Lock()
+	defer fake.unsetDeviceCSRFTokenMutex.Unlock()
+	fake.UnsetDeviceCSRFTokenStub = stub
+}
+
+func (fake *FakeMiddleware) UnsetDeviceCSRFTokenArgsForCall(i int) http.ResponseWriter {
+	fake.unsetDeviceCSRFTokenMutex.RLock()
+	defer fake.unsetDeviceCSRFTokenMutex.RUnlock()
+	argsForCall := fake.unsetDeviceCSRFTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeMiddleware) UnsetStateToken(arg1 http.ResponseWriter) {
 	fake.unsetStateTokenMutex.Lock()
 	fake.unsetStateTokenArgsForCall = append(fake.unsetStateTokenArgsForCall, struct {
@@ -576,18 +761,24 @@ func (fake *FakeMiddleware) Invocations() map[string][][]interface{} {
 	defer fake.getAuthTokenMutex.RUnlock()
 	fake.getCSRFTokenMutex.RLock()
 	defer fake.getCSRFTokenMutex.RUnlock()
+	fake.getDeviceCSRFTokenMutex.RLock()
+	defer fake.getDeviceCSRFTokenMutex.RUnlock()
 	fake.getStateTokenMutex.RLock()
 	defer fake.getStateTokenMutex.RUnlock()
 	fake.setAuthTokenMutex.RLock()
 	defer fake.setAuthTokenMutex.RUnlock()
 	fake.setCSRFTokenMutex.RLock()
 	defer fake.setCSRFTokenMutex.RUnlock()
+	fake.setDeviceCSRFTokenMutex.RLock()
+	defer fake.setDeviceCSRFTokenMutex.RUnlock()
 	fake.setStateTokenMutex.RLock()
 	defer fake.setStateTokenMutex.RUnlock()
 	fake.unsetAuthTokenMutex.RLock()
 	defer fake.unsetAuthTokenMutex.RUnlock()
 	fake.unsetCSRFTokenMutex.RLock()
 	defer fake.unsetCSRFTokenMutex.RUnlock()
+	fake.unsetDeviceCSRFTokenMutex.RLock()
+	defer fake.unsetDeviceCSRFTokenMutex.RUnlock()
 	fake.unsetStateTokenMutex.RLock()
 	defer fake.unsetStateTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}